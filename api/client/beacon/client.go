@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	log "github.com/sirupsen/logrus"
@@ -35,6 +36,8 @@ const (
 	getForkSchedulePath     = "/eth/v1/config/fork_schedule"
 	getStatePath            = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath      = "/eth/v1/node/version"
+	getDepositSnapshotPath  = "/eth/v1/beacon/deposit_snapshot"
+	getForkChoicePath       = "/prysm/v1/debug/forkchoice"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -145,6 +148,14 @@ func withSSZEncoding() reqOption {
 	}
 }
 
+func withQueryParam(key, value string) reqOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		q.Set(key, value)
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
 // get is a generic, opinionated GET function to reduce boilerplate amongst the getters in this package.
 func (c *Client) get(ctx context.Context, path string, opts ...reqOption) ([]byte, error) {
 	u := c.baseURL.ResolveReference(&url.URL{Path: path})
@@ -345,6 +356,37 @@ func (c *Client) GetWeakSubjectivity(ctx context.Context) (*WeakSubjectivityData
 	}, nil
 }
 
+// GetDepositSnapshot retrieves the EIP-4881 snapshot of the deposit tree at the last finalized
+// execution block known to the beacon node.
+func (c *Client) GetDepositSnapshot(ctx context.Context) (*trie.DepositTreeSnapshot, error) {
+	b, err := c.get(ctx, getDepositSnapshotPath, withSSZEncoding())
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting deposit snapshot")
+	}
+	snapshot := &trie.DepositTreeSnapshot{}
+	if err := snapshot.UnmarshalSSZ(b); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling deposit snapshot")
+	}
+	root, err := snapshot.CalculateRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit snapshot")
+	}
+	if root != snapshot.DepositRoot {
+		return nil, errors.Errorf("deposit snapshot root %#x does not match its finalized hashes", snapshot.DepositRoot)
+	}
+	return snapshot, nil
+}
+
+// GetForkChoice retrieves the fork choice tree of the beacon node rendered in the given format, which
+// can be json, dot or mermaid. The debug endpoints of the beacon node must be enabled.
+func (c *Client) GetForkChoice(ctx context.Context, format string) ([]byte, error) {
+	b, err := c.get(ctx, getForkChoicePath, withQueryParam("format", format))
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting fork choice")
+	}
+	return b, nil
+}
+
 func non200Err(response *http.Response) error {
 	bodyBytes, err := io.ReadAll(response.Body)
 	var body string
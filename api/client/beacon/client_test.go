@@ -1,6 +1,10 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -136,3 +140,19 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetForkChoice(t *testing.T) {
+	c := &Client{
+		hc:      &http.Client{},
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		require.Equal(t, getForkChoicePath, req.URL.Path)
+		res.Body = io.NopCloser(bytes.NewBufferString("format=" + req.URL.Query().Get("format")))
+		return res, nil
+	}}
+	b, err := c.GetForkChoice(context.Background(), "dot")
+	require.NoError(t, err)
+	require.Equal(t, "format=dot", string(b))
+}
@@ -191,6 +191,10 @@ func WriteMiddlewareResponseHeadersAndBody(grpcResp *http.Response, responseJson
 			if h == "Grpc-Metadata-"+grpc.HttpCodeMetadataKey {
 				statusCodeHeader = vs[0]
 			}
+			// Whether the data depends on optimistic blocks is part of the Ethereum API response.
+			if h == "Grpc-Metadata-"+grpc.ExecutionOptimisticMetadataKey {
+				w.Header().Set(grpc.ExecutionOptimisticMetadataKey, vs[0])
+			}
 		} else {
 			for _, v := range vs {
 				w.Header().Set(h, v)
@@ -303,6 +303,25 @@ func TestWriteMiddlewareResponseHeadersAndBody(t *testing.T) {
 		assert.DeepEqual(t, responseJson, writer.Body.Bytes())
 	})
 
+	t.Run("execution_optimistic_header", func(t *testing.T) {
+		response := &http.Response{
+			Header: http.Header{
+				"Grpc-Metadata-" + grpc.ExecutionOptimisticMetadataKey: []string{"true"},
+			},
+			StatusCode: 200,
+		}
+		container := defaultResponseContainer()
+		responseJson, err := json.Marshal(container)
+		require.NoError(t, err)
+		writer := httptest.NewRecorder()
+
+		errJson := WriteMiddlewareResponseHeadersAndBody(response, responseJson, writer)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, "true", writer.Header().Get(grpc.ExecutionOptimisticMetadataKey))
+		_, ok := writer.Header()["Grpc-Metadata-"+grpc.ExecutionOptimisticMetadataKey]
+		assert.Equal(t, false, ok, "gRPC metadata header should not be forwarded")
+	})
+
 	t.Run("GET_no_grpc_status_code_header", func(t *testing.T) {
 		response := &http.Response{
 			Header:     http.Header{},
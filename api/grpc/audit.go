@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	requestLatency = promauto.NewSummaryVec(prometheus.SummaryOpts{
+		Name:       "grpc_server_request_latency_seconds",
+		Help:       "Latency quantiles of the unary gRPC requests served, per method, over the last ten minutes.",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		MaxAge:     10 * time.Minute,
+	}, []string{"method"})
+	slowRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_slow_requests_total",
+		Help: "The number of unary gRPC requests served slower than the slow request threshold, per method.",
+	}, []string{"method"})
+)
+
+// AuditUnaryServerInterceptor records the latency of the unary requests per method, and logs the
+// requests taking longer than the threshold with the address and user agent of the caller, to
+// find which API consumers are expensive to serve. Slow requests are not logged when the threshold
+// is 0. Streams are long lived by design and are not audited.
+func AuditUnaryServerInterceptor(threshold time.Duration) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		duration := time.Since(start)
+		requestLatency.WithLabelValues(info.FullMethod).Observe(duration.Seconds())
+		if threshold > 0 && duration >= threshold {
+			slowRequests.WithLabelValues(info.FullMethod).Inc()
+			logrus.WithFields(logrus.Fields{
+				"method":    info.FullMethod,
+				"peer":      callerAddress(ctx),
+				"userAgent": callerUserAgent(ctx),
+				"duration":  duration,
+				"code":      status.Code(err).String(),
+			}).Warn("Slow gRPC request")
+		}
+		return resp, err
+	}
+}
+
+// callerAddress returns the address of the caller of the request, if known.
+func callerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	return p.Addr.String()
+}
+
+// callerUserAgent returns the user agent sent by the caller of the request, if any.
+func callerUserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	return strings.Join(md.Get("user-agent"), ",")
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestAuditUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconChain/ListValidators"}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4000}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("user-agent", "dashboard/1.0"))
+	fast := func(context.Context, interface{}) (interface{}, error) {
+		return "fast", nil
+	}
+	slow := func(context.Context, interface{}) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, errors.New("failed")
+	}
+
+	t.Run("fast_request", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		resp, err := AuditUnaryServerInterceptor(10*time.Millisecond)(ctx, nil, info, fast)
+		require.NoError(t, err)
+		assert.Equal(t, "fast", resp)
+		assert.LogsDoNotContain(t, hook, "Slow gRPC request")
+	})
+
+	t.Run("slow_request", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		_, err := AuditUnaryServerInterceptor(10*time.Millisecond)(ctx, nil, info, slow)
+		assert.ErrorContains(t, "failed", err)
+		assert.LogsContain(t, hook, "Slow gRPC request")
+		assert.LogsContain(t, hook, "10.0.0.1:4000")
+		assert.LogsContain(t, hook, "dashboard/1.0")
+		assert.LogsContain(t, hook, info.FullMethod)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		_, err := AuditUnaryServerInterceptor(0)(ctx, nil, info, slow)
+		assert.ErrorContains(t, "failed", err)
+		assert.LogsDoNotContain(t, hook, "Slow gRPC request")
+	})
+}
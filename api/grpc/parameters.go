@@ -6,3 +6,7 @@ const CustomErrorMetadataKey = "Custom-Error"
 
 // HttpCodeMetadataKey is the key to use when setting custom HTTP status codes in gRPC metadata.
 const HttpCodeMetadataKey = "X-Http-Code"
+
+// ExecutionOptimisticMetadataKey is the key of the metadata telling whether the data of a response depends on
+// optimistic blocks, whose execution payloads are not verified yet.
+const ExecutionOptimisticMetadataKey = "Eth-Execution-Optimistic"
@@ -0,0 +1,115 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"go.opencensus.io/trace"
+)
+
+// IsAncestor returns true if the block with root `ancestorRoot` is an ancestor of the block with
+// root `descendantRoot`. A block is considered to be its own ancestor. The fork choice store is
+// used when possible, otherwise the blocks are looked up in the DB, which makes it possible to
+// answer the question for finalized roots.
+func (s *Service) IsAncestor(ctx context.Context, ancestorRoot, descendantRoot [32]byte) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.IsAncestor")
+	defer span.End()
+
+	if ancestorRoot == descendantRoot {
+		return true, nil
+	}
+	ancestorSlot, err := s.blockSlot(ctx, ancestorRoot)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get ancestor block")
+	}
+	descendantSlot, err := s.blockSlot(ctx, descendantRoot)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get descendant block")
+	}
+	if descendantSlot <= ancestorSlot {
+		return false, nil
+	}
+	r, err := s.ancestor(ctx, descendantRoot[:], ancestorSlot)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get ancestor root")
+	}
+	return bytesutil.ToBytes32(r) == ancestorRoot, nil
+}
+
+// CommonAncestor returns the root and slot of the most recent common ancestor of the blocks with
+// roots `r1` and `r2`. The fork choice store is queried first and the DB is walked back when either
+// of the roots, or their common ancestor, is no longer part of fork choice. It returns an error
+// wrapping forkchoice.ErrUnknownCommonAncestor if the ancestry of either root can't be resolved.
+func (s *Service) CommonAncestor(ctx context.Context, r1, r2 [32]byte) ([32]byte, types.Slot, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.CommonAncestor")
+	defer span.End()
+	return s.commonAncestor(ctx, r1, r2, 0)
+}
+
+// commonAncestor is CommonAncestor with the DB walk stopping at `minSlot`: ErrUnknownCommonAncestor
+// is returned when the roots don't meet at or above that slot.
+func (s *Service) commonAncestor(ctx context.Context, r1, r2 [32]byte, minSlot types.Slot) ([32]byte, types.Slot, error) {
+	root, err := s.ForkChoicer().CommonAncestorRoot(ctx, r1, r2)
+	if err == nil {
+		slot, err := s.blockSlot(ctx, root)
+		if err != nil {
+			return [32]byte{}, 0, err
+		}
+		return root, slot, nil
+	}
+	if !errors.Is(err, forkchoice.ErrUnknownCommonAncestor) {
+		return [32]byte{}, 0, err
+	}
+	return s.commonAncestorByDB(ctx, r1, r2, minSlot)
+}
+
+// This retrieves the common ancestor of two roots using DB, by walking back the chain of whichever
+// block has the higher slot until both roots meet. Slower than the fork choice store lookup. The
+// walk gives up once it goes below `minSlot`.
+func (s *Service) commonAncestorByDB(ctx context.Context, r1, r2 [32]byte, minSlot types.Slot) ([32]byte, types.Slot, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.commonAncestorByDB")
+	defer span.End()
+
+	b1, err := s.getBlock(ctx, r1)
+	if err != nil {
+		return [32]byte{}, 0, errors.Wrap(forkchoice.ErrUnknownCommonAncestor, err.Error())
+	}
+	b2, err := s.getBlock(ctx, r2)
+	if err != nil {
+		return [32]byte{}, 0, errors.Wrap(forkchoice.ErrUnknownCommonAncestor, err.Error())
+	}
+	for r1 != r2 {
+		if ctx.Err() != nil {
+			return [32]byte{}, 0, ctx.Err()
+		}
+		if b1.Block().Slot() < minSlot || b2.Block().Slot() < minSlot {
+			return [32]byte{}, 0, errors.Wrapf(forkchoice.ErrUnknownCommonAncestor, "no common ancestor since slot %d", minSlot)
+		}
+		if b1.Block().Slot() >= b2.Block().Slot() {
+			r1 = bytesutil.ToBytes32(b1.Block().ParentRoot())
+			b1, err = s.getBlock(ctx, r1)
+		} else {
+			r2 = bytesutil.ToBytes32(b2.Block().ParentRoot())
+			b2, err = s.getBlock(ctx, r2)
+		}
+		if err != nil {
+			return [32]byte{}, 0, errors.Wrap(forkchoice.ErrUnknownCommonAncestor, err.Error())
+		}
+	}
+	return r1, b1.Block().Slot(), nil
+}
+
+// Returns the slot of the block with root `r` from either the initial sync blocks cache or the DB.
+func (s *Service) blockSlot(ctx context.Context, r [32]byte) (types.Slot, error) {
+	b, err := s.getBlock(ctx, r)
+	if errors.Is(err, errBlockNotFoundInCacheOrDB) {
+		return 0, errors.Wrapf(ErrUnknownBlockRoot, "%#x", r)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return b.Block().Slot(), nil
+}
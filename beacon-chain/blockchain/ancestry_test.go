@@ -0,0 +1,113 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+// Chain setup
+// 0 -- 1 -- 2 -- 3
+//
+//	\-4
+func setupAncestryChain(t *testing.T, service *Service, insertForkchoice bool) [][32]byte {
+	ctx := context.Background()
+	parents := []int{-1, 0, 1, 2, 1}
+	roots := make([][32]byte, len(parents))
+	for i, p := range parents {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = types.Slot(i)
+		if p >= 0 {
+			blk.Block.ParentRoot = roots[p][:]
+		}
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+		util.SaveBlock(t, ctx, service.cfg.BeaconDB, blk)
+		if insertForkchoice {
+			ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+			ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+			st, blkRoot, err := prepareForkchoiceState(ctx, blk.Block.Slot, r, bytesutil.ToBytes32(blk.Block.ParentRoot), [32]byte{}, ojc, ofc)
+			require.NoError(t, err)
+			require.NoError(t, service.ForkChoicer().InsertNode(ctx, st, blkRoot))
+		}
+	}
+	return roots
+}
+
+func TestService_IsAncestor(t *testing.T) {
+	ctx := context.Background()
+	for _, insertForkchoice := range []bool{false, true} {
+		t.Run(fmt.Sprintf("forkchoice=%t", insertForkchoice), func(t *testing.T) {
+			service := setupBeaconChain(t, testDB.SetupDB(t))
+			roots := setupAncestryChain(t, service, insertForkchoice)
+
+			isAncestor, err := service.IsAncestor(ctx, roots[1], roots[3])
+			require.NoError(t, err)
+			assert.Equal(t, true, isAncestor)
+
+			isAncestor, err = service.IsAncestor(ctx, roots[3], roots[3])
+			require.NoError(t, err)
+			assert.Equal(t, true, isAncestor)
+
+			isAncestor, err = service.IsAncestor(ctx, roots[2], roots[4])
+			require.NoError(t, err)
+			assert.Equal(t, false, isAncestor)
+
+			isAncestor, err = service.IsAncestor(ctx, roots[3], roots[1])
+			require.NoError(t, err)
+			assert.Equal(t, false, isAncestor)
+
+			_, err = service.IsAncestor(ctx, [32]byte{'a'}, roots[1])
+			require.ErrorIs(t, err, ErrUnknownBlockRoot)
+		})
+	}
+}
+
+func TestService_CommonAncestor(t *testing.T) {
+	ctx := context.Background()
+	for _, insertForkchoice := range []bool{false, true} {
+		t.Run(fmt.Sprintf("forkchoice=%t", insertForkchoice), func(t *testing.T) {
+			service := setupBeaconChain(t, testDB.SetupDB(t))
+			roots := setupAncestryChain(t, service, insertForkchoice)
+
+			root, slot, err := service.CommonAncestor(ctx, roots[3], roots[4])
+			require.NoError(t, err)
+			assert.Equal(t, roots[1], root)
+			assert.Equal(t, types.Slot(1), slot)
+
+			root, slot, err = service.CommonAncestor(ctx, roots[2], roots[3])
+			require.NoError(t, err)
+			assert.Equal(t, roots[2], root)
+			assert.Equal(t, types.Slot(2), slot)
+
+			_, _, err = service.CommonAncestor(ctx, roots[3], [32]byte{'a'})
+			require.ErrorIs(t, err, forkchoice.ErrUnknownCommonAncestor)
+		})
+	}
+}
+
+func TestService_commonAncestorByDB_MinSlot(t *testing.T) {
+	ctx := context.Background()
+	service := setupBeaconChain(t, testDB.SetupDB(t))
+	roots := setupAncestryChain(t, service, false)
+
+	root, slot, err := service.commonAncestorByDB(ctx, roots[3], roots[4], 1)
+	require.NoError(t, err)
+	assert.Equal(t, roots[1], root)
+	assert.Equal(t, types.Slot(1), slot)
+
+	// The walk gives up below the minimum slot rather than walking back to the common ancestor.
+	_, _, err = service.commonAncestorByDB(ctx, roots[3], roots[4], 2)
+	require.ErrorIs(t, err, forkchoice.ErrUnknownCommonAncestor)
+}
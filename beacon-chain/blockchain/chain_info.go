@@ -77,6 +77,12 @@ type CanonicalFetcher interface {
 	IsCanonical(ctx context.Context, blockRoot [32]byte) (bool, error)
 }
 
+// AncestryFetcher answers ancestry questions about blocks known to the node.
+type AncestryFetcher interface {
+	IsAncestor(ctx context.Context, ancestorRoot, descendantRoot [32]byte) (bool, error)
+	CommonAncestor(ctx context.Context, r1, r2 [32]byte) ([32]byte, types.Slot, error)
+}
+
 // FinalizationFetcher defines a common interface for methods in blockchain service which
 // directly retrieve finalization and justification related data.
 type FinalizationFetcher interface {
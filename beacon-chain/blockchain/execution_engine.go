@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
@@ -94,13 +95,9 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *notifyForkcho
 			if len(lastValidHash) == 0 {
 				lastValidHash = defaultLatestValidHash
 			}
-			invalidRoots, err := s.ForkChoicer().SetOptimisticToInvalid(ctx, headRoot, bytesutil.ToBytes32(headBlk.ParentRoot()), bytesutil.ToBytes32(lastValidHash))
+			invalidRoots, err := s.pruneInvalidBlocks(ctx, headRoot, bytesutil.ToBytes32(headBlk.ParentRoot()), bytesutil.ToBytes32(lastValidHash))
 			if err != nil {
-				log.WithError(err).Error("Could not set head root to invalid")
-				return nil, nil
-			}
-			if err := s.removeInvalidBlockAndState(ctx, invalidRoots); err != nil {
-				log.WithError(err).Error("Could not remove invalid block and state")
+				log.WithError(err).Error("Could not prune invalid blocks")
 				return nil, nil
 			}
 
@@ -229,13 +226,10 @@ func (s *Service) notifyNewPayload(ctx context.Context, postStateVersion int,
 		if err != nil {
 			return false, err
 		}
-		invalidRoots, err := s.ForkChoicer().SetOptimisticToInvalid(ctx, root, bytesutil.ToBytes32(blk.Block().ParentRoot()), bytesutil.ToBytes32(lastValidHash))
+		invalidRoots, err := s.pruneInvalidBlocks(ctx, root, bytesutil.ToBytes32(blk.Block().ParentRoot()), bytesutil.ToBytes32(lastValidHash))
 		if err != nil {
 			return false, err
 		}
-		if err := s.removeInvalidBlockAndState(ctx, invalidRoots); err != nil {
-			return false, err
-		}
 		log.WithFields(logrus.Fields{
 			"slot":         blk.Block().Slot(),
 			"blockRoot":    fmt.Sprintf("%#x", root),
@@ -284,6 +278,39 @@ func (s *Service) optimisticCandidateBlock(ctx context.Context, blk interfaces.B
 	return errNotOptimisticCandidate
 }
 
+// shouldOverrideFCU returns true if the execution engine should not be notified of the new head,
+// because it is a late block which the proposer of the next slot, served by this node, may reorg.
+// The engine is then notified of the parent instead, on which the next payload may be built.
+func (s *Service) shouldOverrideFCU(newHeadRoot [32]byte) bool {
+	if !features.Get().EnableProposerReorgs {
+		return false
+	}
+	if _, _, ok := s.cfg.ProposerSlotIndexCache.GetProposerPayloadIDs(s.CurrentSlot()+1, [32]byte{} /* root */); !ok {
+		return false
+	}
+	return s.ForkChoicer().CachedHeadRoot() == newHeadRoot && s.ForkChoicer().ShouldOverrideFCU()
+}
+
+// parentForkchoiceUpdateArg returns the forkchoice update argument of the parent of a late head
+// block which may be reorged. Notifying the execution engine with it keeps the parent as the engine
+// head, and starts building the payload of the next slot on top of the parent.
+func (s *Service) parentForkchoiceUpdateArg(ctx context.Context, headBlock interfaces.BeaconBlock) (*notifyForkchoiceUpdateArg, error) {
+	parentRoot := bytesutil.ToBytes32(headBlock.ParentRoot())
+	parentBlock, err := s.getBlock(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent block")
+	}
+	parentState, err := s.cfg.StateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	return &notifyForkchoiceUpdateArg{
+		headState: parentState,
+		headRoot:  parentRoot,
+		headBlock: parentBlock.Block(),
+	}, nil
+}
+
 // getPayloadAttributes returns the payload attributes for the given state and slot.
 // The attribute is required to initiate a payload build process in the context of an `engine_forkchoiceUpdated` call.
 func (s *Service) getPayloadAttribute(ctx context.Context, st state.BeaconState, slot types.Slot) (bool, *enginev1.PayloadAttributes, types.ValidatorIndex, error) {
@@ -1247,3 +1247,38 @@ func TestService_getPayloadHash(t *testing.T) {
 	require.NoError(t, err)
 	require.DeepEqual(t, [32]byte{'a'}, h)
 }
+
+func TestService_parentForkchoiceUpdateArg(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	opts := []Option{
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB)),
+		WithForkChoiceStore(doublylinkedtree.New()),
+	}
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	parent := util.NewBeaconBlockBellatrix()
+	parent.Block.Slot = 1
+	parentBlk := util.SaveBlock(t, ctx, service.cfg.BeaconDB, parent)
+	parentRoot, err := parentBlk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	head := util.NewBeaconBlockBellatrix()
+	head.Block.Slot = 2
+	head.Block.ParentRoot = parentRoot[:]
+	headBlk, err := consensusblocks.NewSignedBeaconBlock(head)
+	require.NoError(t, err)
+
+	_, err = service.parentForkchoiceUpdateArg(ctx, headBlk.Block())
+	require.ErrorContains(t, "could not get parent state", err)
+
+	st, _ := util.DeterministicGenesisStateBellatrix(t, 1)
+	require.NoError(t, service.cfg.BeaconDB.SaveState(ctx, st, parentRoot))
+	arg, err := service.parentForkchoiceUpdateArg(ctx, headBlk.Block())
+	require.NoError(t, err)
+	require.Equal(t, parentRoot, arg.headRoot)
+	require.Equal(t, parentBlk.Block().Slot(), arg.headBlock.Slot())
+	require.NotNil(t, arg.headState)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/protoarray"
@@ -78,9 +79,14 @@ func TestSaveHead_Different(t *testing.T) {
 	require.NoError(t, headState.SetSlot(1))
 	require.NoError(t, service.cfg.BeaconDB.SaveStateSummary(context.Background(), &ethpb.StateSummary{Slot: 1, Root: newRoot[:]}))
 	require.NoError(t, service.cfg.BeaconDB.SaveState(context.Background(), headState, newRoot))
+	service.cfg.SlotTimelineCache = cache.NewSlotTimelineCache()
 	require.NoError(t, service.saveHead(context.Background(), newRoot, wsb, headState))
 
 	assert.Equal(t, types.Slot(1), service.HeadSlot(), "Head did not change")
+	timelines := service.cfg.SlotTimelineCache.Timelines(1)
+	require.Equal(t, 1, len(timelines))
+	assert.Equal(t, types.Slot(1), timelines[0].Slot)
+	assert.Equal(t, false, timelines[0].HeadUpdated.IsZero())
 
 	cachedRoot, err := service.HeadRoot(context.Background())
 	require.NoError(t, err)
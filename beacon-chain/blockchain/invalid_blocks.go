@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+)
+
+// invalidBlockCacheSize is the number of invalid block roots remembered, so that their descendants are
+// rejected without being verified again.
+const invalidBlockCacheSize = 1024
+
+var errInvalidAncestor = errors.New("block descends from a block with an invalid execution payload")
+
+// pruneInvalidBlocks marks the block and all its ancestors up to the last valid execution block hash as
+// invalid. They are removed from fork choice and the DB, cached as invalid, and an InvalidBlocksPruned
+// event is sent with their roots.
+func (s *Service) pruneInvalidBlocks(ctx context.Context, root, parentRoot, lastValidHash [32]byte) ([][32]byte, error) {
+	invalidRoots, err := s.ForkChoicer().SetOptimisticToInvalid(ctx, root, parentRoot, lastValidHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set block to invalid")
+	}
+	if err := s.removeInvalidBlockAndState(ctx, invalidRoots); err != nil {
+		return nil, errors.Wrap(err, "could not remove invalid blocks and states")
+	}
+	s.cacheInvalidRoots(append(invalidRoots, root))
+	invalidBlockRootsCount.Add(float64(len(invalidRoots)))
+	if s.cfg.StateNotifier != nil {
+		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.InvalidBlocksPruned,
+			Data: &statefeed.InvalidBlocksPrunedData{
+				BlockRoot:     root,
+				LastValidHash: lastValidHash,
+				InvalidRoots:  invalidRoots,
+			},
+		})
+	}
+	return invalidRoots, nil
+}
+
+func (s *Service) cacheInvalidRoots(roots [][32]byte) {
+	if s.invalidBlockRoots == nil {
+		return
+	}
+	for _, r := range roots {
+		s.invalidBlockRoots.Add(r, true)
+	}
+}
+
+// isKnownInvalid returns true if the block root is cached as invalid.
+func (s *Service) isKnownInvalid(root [32]byte) bool {
+	return s.invalidBlockRoots != nil && s.invalidBlockRoots.Contains(root)
+}
+
+// checkInvalidAncestor rejects a block which is known to be invalid or whose parent is, and caches the
+// block as invalid in the latter case.
+func (s *Service) checkInvalidAncestor(root, parentRoot [32]byte) error {
+	if s.isKnownInvalid(root) {
+		return invalidBlock{error: errors.Wrapf(ErrInvalidPayload, "block %#x is known to be invalid", bytesutil.Trunc(root[:])), root: root}
+	}
+	if s.isKnownInvalid(parentRoot) {
+		s.cacheInvalidRoots([][32]byte{root})
+		return invalidBlock{error: errors.Wrapf(errInvalidAncestor, "parent %#x is invalid", bytesutil.Trunc(parentRoot[:])), root: root}
+	}
+	return nil
+}
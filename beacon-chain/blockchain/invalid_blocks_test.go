@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
+	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestService_pruneInvalidBlocks(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	fcs := doublylinkedtree.New()
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	service, err := NewService(ctx,
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB)),
+		WithForkChoiceStore(fcs),
+		WithStateNotifier(notifier),
+	)
+	require.NoError(t, err)
+
+	ojc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ofc := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	ra, rb, rc, rd := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}, [32]byte{'d'}
+	st, root, err := prepareForkchoiceState(ctx, 1, ra, [32]byte{}, [32]byte{'A'}, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 2, rb, ra, [32]byte{'B'}, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 3, rc, rb, [32]byte{'C'}, ojc, ofc)
+	require.NoError(t, err)
+	require.NoError(t, fcs.InsertNode(ctx, st, root))
+
+	// C is invalid and B is its first invalid ancestor.
+	invalidRoots, err := service.pruneInvalidBlocks(ctx, rc, rb, [32]byte{'A'})
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{rc, rb}, invalidRoots)
+	assert.Equal(t, false, fcs.HasNode(rb))
+	assert.Equal(t, false, fcs.HasNode(rc))
+	assert.Equal(t, true, fcs.HasNode(ra))
+
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, statefeed.InvalidBlocksPruned, int(events[0].Type))
+	data, ok := events[0].Data.(*statefeed.InvalidBlocksPrunedData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, rc, data.BlockRoot)
+	assert.Equal(t, [32]byte{'A'}, data.LastValidHash)
+	assert.DeepEqual(t, invalidRoots, data.InvalidRoots)
+
+	// Invalid blocks and their descendants are rejected without being processed again.
+	require.NoError(t, service.checkInvalidAncestor(rd, ra))
+	err = service.checkInvalidAncestor(rb, ra)
+	assert.Equal(t, true, IsInvalidBlock(err))
+	err = service.checkInvalidAncestor(rd, rc)
+	assert.ErrorContains(t, errInvalidAncestor.Error(), err)
+	assert.Equal(t, rd, InvalidBlockRoot(err))
+	assert.Equal(t, true, service.isKnownInvalid(rd))
+}
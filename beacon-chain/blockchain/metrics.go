@@ -150,6 +150,10 @@ var (
 		Name: "new_payload_invalid_node_count",
 		Help: "Count the number of invalid nodes after newPayload EE call",
 	})
+	invalidBlockRootsCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "invalid_block_roots_pruned_count",
+		Help: "Count the number of blocks pruned from fork choice because of an invalid payload in their ancestry",
+	})
 	forkchoiceUpdatedValidNodeCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "forkchoice_updated_valid_node_count",
 		Help: "Count the number of valid nodes after forkchoiceUpdated EE call",
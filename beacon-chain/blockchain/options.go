@@ -8,7 +8,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
@@ -35,6 +37,14 @@ func WithWeakSubjectivityCheckpoint(c *ethpb.Checkpoint) Option {
 	}
 }
 
+// WithCheckpointStateCacheMaxBytes bounds the estimated memory used by the checkpoint state cache.
+func WithCheckpointStateCacheMaxBytes(maxBytes uint64) Option {
+	return func(s *Service) error {
+		s.checkpointStateCache = cache.NewCheckpointStateCacheWithMaxBytes(maxBytes)
+		return nil
+	}
+}
+
 // WithDatabase for head access.
 func WithDatabase(beaconDB db.HeadAccessDatabase) Option {
 	return func(s *Service) error {
@@ -75,6 +85,22 @@ func WithProposerIdsCache(c *cache.ProposerPayloadIDsCache) Option {
 	}
 }
 
+// WithSlotTimelineCache for recording when blocks go through each step of their processing.
+func WithSlotTimelineCache(c *cache.SlotTimelineCache) Option {
+	return func(s *Service) error {
+		s.cfg.SlotTimelineCache = c
+		return nil
+	}
+}
+
+// WithArrivalTracker for recording the canonical block of each slot.
+func WithArrivalTracker(t *arrival.Tracker) Option {
+	return func(s *Service) error {
+		s.cfg.ArrivalTracker = t
+		return nil
+	}
+}
+
 // WithAttestationPool for attestation lifecycle after chain inclusion.
 func WithAttestationPool(p attestations.Pool) Option {
 	return func(s *Service) error {
@@ -91,6 +117,14 @@ func WithExitPool(p voluntaryexits.PoolManager) Option {
 	}
 }
 
+// WithBLSToExecPool for BLS to execution changes lifecycle after chain inclusion.
+func WithBLSToExecPool(p blstoexec.PoolManager) Option {
+	return func(s *Service) error {
+		s.cfg.BLSToExecPool = p
+		return nil
+	}
+}
+
 // WithSlashingPool for slashings lifecycle after chain inclusion.
 func WithSlashingPool(p slashings.PoolManager) Option {
 	return func(s *Service) error {
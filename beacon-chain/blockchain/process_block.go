@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
@@ -99,6 +100,9 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 		return invalidBlock{error: err}
 	}
 	b := signed.Block()
+	if err := s.checkInvalidAncestor(blockRoot, bytesutil.ToBytes32(b.ParentRoot())); err != nil {
+		return err
+	}
 
 	preState, err := s.getBlockPreState(ctx, b)
 	if err != nil {
@@ -119,6 +123,7 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 	if err != nil {
 		return invalidBlock{error: err}
 	}
+	s.cfg.SlotTimelineCache.Record(b.Slot(), cache.SignatureVerified, time.Now())
 	postStateVersion, postStateHeader, err := getStateVersionAndPayload(postState)
 	if err != nil {
 		return err
@@ -127,6 +132,7 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 	if err != nil {
 		return errors.Wrap(err, "could not validate new payload")
 	}
+	s.cfg.SlotTimelineCache.Record(b.Slot(), cache.PayloadVerified, time.Now())
 	if isValidPayload {
 		if err := s.validateMergeTransitionBlock(ctx, preStateVersion, preStateHeader, signed); err != nil {
 			return err
@@ -297,6 +303,9 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		return invalidBlock{error: err}
 	}
 	b := blks[0].Block()
+	if err := s.checkInvalidAncestor(blockRoots[0], bytesutil.ToBytes32(b.ParentRoot())); err != nil {
+		return err
+	}
 
 	// Retrieve incoming block's pre state.
 	if err := s.verifyBlkPreState(ctx, b); err != nil {
@@ -317,11 +326,11 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 
 	jCheckpoints := make([]*ethpb.Checkpoint, len(blks))
 	fCheckpoints := make([]*ethpb.Checkpoint, len(blks))
-	sigSet := &bls.SignatureBatch{
-		Signatures: [][]byte{},
-		PublicKeys: []bls.PublicKey{},
-		Messages:   [][32]byte{},
-	}
+	// Signatures are verified by chunks of blocks in the background, while the following blocks
+	// are transitioned.
+	verifier := newSignatureBatchVerifier(len(blks))
+	defer verifier.abort()
+	sigSet := bls.NewSet()
 	type versionAndHeader struct {
 		version int
 		header  *enginev1.ExecutionPayloadHeader
@@ -360,13 +369,13 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 			header:  h,
 		}
 		sigSet.Join(set)
+		if (i+1)%signatureChunkBlocks == 0 || i == len(blks)-1 {
+			verifier.add(sigSet)
+			sigSet = bls.NewSet()
+		}
 	}
-	verify, err := sigSet.Verify()
-	if err != nil {
-		return invalidBlock{error: err}
-	}
-	if !verify {
-		return errors.New("batch block signature verification failed")
+	if err := verifier.wait(); err != nil {
+		return err
 	}
 
 	// blocks have been verified, save them and call the engine
@@ -633,22 +642,8 @@ func (s *Service) fillMissingPayloadIDRoutine(ctx context.Context, stateFeed *ev
 				if !atHalfSlot(ti) {
 					continue
 				}
-				_, id, has := s.cfg.ProposerSlotIndexCache.GetProposerPayloadIDs(s.CurrentSlot()+1, s.headRoot())
-				// There exists proposer for next slot, but we haven't called fcu w/ payload attribute yet.
-				if has && id == [8]byte{} {
-					headBlock, err := s.headBlock()
-					if err != nil {
-						log.WithError(err).Error("Could not get head block")
-					} else {
-						if _, err := s.notifyForkchoiceUpdate(ctx, &notifyForkchoiceUpdateArg{
-							headState: s.headState(ctx),
-							headRoot:  s.headRoot(),
-							headBlock: headBlock.Block(),
-						}); err != nil {
-							log.WithError(err).Error("Could not prepare payload on empty ID")
-						}
-					}
-					missedPayloadIDFilledCount.Inc()
+				if err := s.fillMissingPayloadID(ctx); err != nil {
+					log.WithError(err).Error("Could not prepare payload on empty ID")
 				}
 			case <-s.ctx.Done():
 				log.Debug("Context closed, exiting routine")
@@ -658,6 +653,43 @@ func (s *Service) fillMissingPayloadIDRoutine(ctx context.Context, stateFeed *ev
 	}()
 }
 
+// fillMissingPayloadID calls forkchoice updated with the payload attribute of the next slot proposer
+// if its payload ID has not been cached yet. The payload is built on the parent of the head instead
+// of the head when the head is a late block which the proposer may reorg.
+func (s *Service) fillMissingPayloadID(ctx context.Context) error {
+	headBlock, err := s.headBlock()
+	if err != nil {
+		return errors.Wrap(err, "could not get head block")
+	}
+	headRoot := s.headRoot()
+	override := s.shouldOverrideFCU(headRoot)
+	root := headRoot
+	if override {
+		root = bytesutil.ToBytes32(headBlock.Block().ParentRoot())
+	}
+	_, id, has := s.cfg.ProposerSlotIndexCache.GetProposerPayloadIDs(s.CurrentSlot()+1, root)
+	// There exists proposer for next slot, but we haven't called fcu w/ payload attribute yet.
+	if !has || id != [8]byte{} {
+		return nil
+	}
+	missedPayloadIDFilledCount.Inc()
+	var arg *notifyForkchoiceUpdateArg
+	if override {
+		arg, err = s.parentForkchoiceUpdateArg(ctx, headBlock.Block())
+		if err != nil {
+			return err
+		}
+	} else {
+		arg = &notifyForkchoiceUpdateArg{
+			headState: s.headState(ctx),
+			headRoot:  headRoot,
+			headBlock: headBlock.Block(),
+		}
+	}
+	_, err = s.notifyForkchoiceUpdate(ctx, arg)
+	return err
+}
+
 // Returns true if time `t` is halfway through the slot in sec.
 func atHalfSlot(t time.Time) bool {
 	s := params.BeaconConfig().SecondsPerSlot
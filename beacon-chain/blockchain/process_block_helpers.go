@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
@@ -299,9 +300,43 @@ func (s *Service) insertFinalizedDeposits(ctx context.Context, fRoot [32]byte) e
 	if err = s.cfg.DepositCache.PruneProofs(ctx, int64(eth1DepositIndex)); err != nil {
 		return errors.Wrap(err, "could not prune deposit proofs")
 	}
+	if err := s.saveDepositSnapshot(ctx, finalizedState); err != nil {
+		log.WithError(err).Warn("Could not save deposit snapshot")
+	}
 	return nil
 }
 
+// saves the EIP-4881 snapshot of the finalized deposits, once all the deposits of the eth1 data of the
+// finalized state are included. The execution block of the eth1 data is then the block of the snapshot.
+func (s *Service) saveDepositSnapshot(ctx context.Context, fState state.ReadOnlyBeaconState) error {
+	eth1Data := fState.Eth1Data()
+	if eth1Data == nil || fState.Eth1DepositIndex() != eth1Data.DepositCount || s.cfg.ExecutionEngineCaller == nil {
+		return nil
+	}
+	saved, err := s.cfg.BeaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get saved deposit snapshot")
+	}
+	if saved != nil && saved.DepositCount >= eth1Data.DepositCount {
+		return nil
+	}
+	blk, err := s.cfg.ExecutionEngineCaller.ExecutionBlockByHash(ctx, common.BytesToHash(eth1Data.BlockHash), false /* no txs */)
+	if err != nil {
+		return errors.Wrap(err, "could not get execution block of finalized eth1 data")
+	}
+	if blk == nil || blk.Number == nil {
+		return errors.Errorf("execution block %#x not found", eth1Data.BlockHash)
+	}
+	snapshot, err := s.cfg.DepositCache.FinalizedDepositsSnapshot(ctx, bytesutil.ToBytes32(eth1Data.BlockHash), blk.Number.Uint64())
+	if err != nil {
+		return errors.Wrap(err, "could not create snapshot of finalized deposits")
+	}
+	if snapshot.DepositRoot != bytesutil.ToBytes32(eth1Data.DepositRoot) {
+		return errors.Errorf("finalized deposits root %#x does not match finalized eth1 data", snapshot.DepositRoot)
+	}
+	return s.cfg.BeaconDB.SaveDepositSnapshot(ctx, snapshot)
+}
+
 // The deletes input attestations from the attestation pool, so proposers don't include them in a block for the future.
 func (s *Service) deletePoolAtts(atts []*ethpb.Attestation) error {
 	for _, att := range atts {
@@ -34,6 +34,7 @@ import (
 	consensusblocks "github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
@@ -1370,6 +1371,55 @@ func TestInsertFinalizedDeposits(t *testing.T) {
 	}
 }
 
+func TestInsertFinalizedDeposits_SavesDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	blockHash := common.Hash{'h'}
+	engine := &mockExecution.EngineClient{BlockByHashMap: map[[32]byte]*enginev1.ExecutionBlock{
+		blockHash: {Header: gethtypes.Header{Number: big.NewInt(107)}},
+	}}
+	opts := append(testServiceOptsWithDB(t), WithDepositCache(depositCache), WithExecutionEngineCaller(engine))
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	zeroSig := [96]byte{}
+	leaves := make([][]byte, 0)
+	for i := uint64(0); i < 10; i++ {
+		root := []byte(strconv.Itoa(int(i)))
+		d := &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+			PublicKey:             bytesutil.FromBytes48([fieldparams.BLSPubkeyLength]byte{}),
+			WithdrawalCredentials: params.BeaconConfig().ZeroHash[:],
+			Amount:                i,
+			Signature:             zeroSig[:],
+		}, Proof: [][]byte{root}}
+		assert.NoError(t, depositCache.InsertDeposit(ctx, d, 100+i, int64(i), bytesutil.ToBytes32(root)))
+		leaf, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		leaves = append(leaves, leaf[:])
+	}
+	depositTrie, err := trie.GenerateTrieFromItems(leaves[:8], params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	depositRoot, err := depositTrie.HashTreeRoot()
+	require.NoError(t, err)
+
+	gs, _ := util.DeterministicGenesisState(t, 32)
+	require.NoError(t, service.saveGenesisData(ctx, gs))
+	gs = gs.Copy()
+	assert.NoError(t, gs.SetEth1Data(&ethpb.Eth1Data{DepositCount: 8, DepositRoot: depositRoot[:], BlockHash: blockHash[:]}))
+	assert.NoError(t, gs.SetEth1DepositIndex(8))
+	assert.NoError(t, service.cfg.StateGen.SaveState(ctx, [32]byte{'m', 'o', 'c', 'k'}, gs))
+
+	assert.NoError(t, service.insertFinalizedDeposits(ctx, [32]byte{'m', 'o', 'c', 'k'}))
+	snapshot, err := service.cfg.BeaconDB.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, uint64(8), snapshot.DepositCount)
+	assert.Equal(t, depositRoot, snapshot.DepositRoot)
+	assert.Equal(t, [32]byte(blockHash), snapshot.ExecutionBlockHash)
+	assert.Equal(t, uint64(107), snapshot.ExecutionBlockHeight)
+}
+
 func TestInsertFinalizedDeposits_MultipleFinalizedRoutines(t *testing.T) {
 	ctx := context.Background()
 	opts := testServiceOptsWithDB(t)
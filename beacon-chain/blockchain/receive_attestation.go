@@ -201,8 +201,19 @@ func (s *Service) notifyEngineIfChangedHead(ctx context.Context, newHeadRoot [32
 		headRoot:  newHeadRoot,
 		headBlock: newHeadBlock.Block(),
 	}
-	_, err = s.notifyForkchoiceUpdate(s.ctx, arg)
-	if err != nil {
+	if s.shouldOverrideFCU(newHeadRoot) {
+		parentArg, err := s.parentForkchoiceUpdateArg(ctx, newHeadBlock.Block())
+		if err != nil {
+			log.WithError(err).Error("Could not get parent of late head block")
+		} else {
+			log.WithFields(logrus.Fields{
+				"slot":      newHeadBlock.Block().Slot(),
+				"blockRoot": fmt.Sprintf("%#x", bytesutil.Trunc(newHeadRoot[:])),
+			}).Debug("Notifying the execution engine of the parent of a late head block which may be reorged")
+			arg = parentArg
+		}
+	}
+	if _, err := s.notifyForkchoiceUpdate(s.ctx, arg); err != nil {
 		return err
 	}
 	if err := s.saveHead(ctx, newHeadRoot, newHeadBlock, headState); err != nil {
@@ -3,9 +3,13 @@ package blockchain
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
@@ -32,13 +36,18 @@ type SlashingReceiver interface {
 
 // ReceiveBlock is a function that defines the operations (minus pubsub)
 // that are performed on a received block. The operations consist of:
-//   1. Validate block, apply state transition and update checkpoints
-//   2. Apply fork choice to the processed block
-//   3. Save latest head info
+//  1. Validate block, apply state transition and update checkpoints
+//  2. Apply fork choice to the processed block
+//  3. Save latest head info
 func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.SignedBeaconBlock, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "blockChain.ReceiveBlock")
 	defer span.End()
+	span.AddAttributes(
+		trace.Int64Attribute("slot", int64(block.Block().Slot())),
+		trace.StringAttribute("blockRoot", hexutil.Encode(blockRoot[:])),
+	)
 	receivedTime := time.Now()
+	s.cfg.SlotTimelineCache.Record(block.Block().Slot(), cache.BlockReceived, receivedTime)
 	blockCopy, err := block.Copy()
 	if err != nil {
 		return err
@@ -61,6 +70,11 @@ func (s *Service) ReceiveBlock(ctx context.Context, block interfaces.SignedBeaco
 		return err
 	}
 
+	// Commit the database writes grouped since the last sync point along with the block.
+	if err := s.cfg.BeaconDB.SyncPoint(kv.SyncPointBlockImport); err != nil {
+		log.WithError(err).Error("Could not commit database writes")
+	}
+
 	// Reports on block and fork choice metrics.
 	finalized := s.FinalizedCheckpt()
 	reportSlotMetrics(blockCopy.Block().Slot(), s.HeadSlot(), s.CurrentSlot(), finalized)
@@ -120,6 +134,9 @@ func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []interfaces.Sig
 	if err := s.cfg.BeaconDB.SaveBlocks(ctx, s.getInitSyncBlocks()); err != nil {
 		return err
 	}
+	if err := s.cfg.BeaconDB.SyncPoint(kv.SyncPointBlockImport); err != nil {
+		log.WithError(err).Error("Could not commit database writes")
+	}
 	finalized := s.FinalizedCheckpt()
 	if finalized == nil {
 		return errNilFinalizedInStore
@@ -160,6 +177,18 @@ func (s *Service) handlePostBlockOperations(b interfaces.BeaconBlock) error {
 		s.cfg.ExitPool.MarkIncluded(e)
 	}
 
+	// Mark block BLS to execution changes as seen so we don't include same ones in future blocks.
+	changes, err := b.Body().BLSToExecutionChanges()
+	switch {
+	case errors.Is(err, blocks.ErrUnsupportedGetter):
+	case err != nil:
+		return errors.Wrap(err, "could not get BLS to execution changes")
+	default:
+		for _, c := range changes {
+			s.cfg.BLSToExecPool.MarkIncluded(c)
+		}
+	}
+
 	//  Mark attester slashings as seen so we don't include same ones in future blocks.
 	for _, as := range b.Body().AttesterSlashings() {
 		s.cfg.SlashingPool.MarkIncludedAttesterSlashing(as)
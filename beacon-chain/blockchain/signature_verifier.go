@@ -0,0 +1,83 @@
+package blockchain
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+)
+
+// signatureChunkBlocks is the number of blocks of a batch whose signatures are verified together,
+// while the state transition of the following blocks goes on.
+const signatureChunkBlocks = 8
+
+// signatureBatchVerifier verifies the signature batches of the blocks of a batch in the
+// background, one at a time as the batch verification already uses all cores, so that the
+// verification overlaps with the sequential state transition of the following blocks.
+type signatureBatchVerifier struct {
+	verify  func(set *bls.SignatureBatch) (bool, error)
+	sets    chan *bls.SignatureBatch
+	done    chan struct{}
+	once    sync.Once
+	lock    sync.Mutex
+	aborted bool
+	err     error
+}
+
+// newSignatureBatchVerifier starts a verifier for the signature batches of count blocks.
+func newSignatureBatchVerifier(count int) *signatureBatchVerifier {
+	v := &signatureBatchVerifier{
+		verify: (*bls.SignatureBatch).Verify,
+		// Buffered for every chunk, so that adding a signature batch never blocks the transition.
+		sets: make(chan *bls.SignatureBatch, count/signatureChunkBlocks+1),
+		done: make(chan struct{}),
+	}
+	go v.loop()
+	return v
+}
+
+func (v *signatureBatchVerifier) loop() {
+	defer close(v.done)
+	for set := range v.sets {
+		v.lock.Lock()
+		skip := v.aborted || v.err != nil
+		v.lock.Unlock()
+		if skip {
+			continue
+		}
+		verified, err := v.verify(set)
+		v.lock.Lock()
+		switch {
+		case err != nil:
+			v.err = invalidBlock{error: err}
+		case !verified:
+			v.err = errBatchSignatureVerification
+		}
+		v.lock.Unlock()
+	}
+}
+
+// add queues the signature batch of a chunk of blocks for verification.
+func (v *signatureBatchVerifier) add(set *bls.SignatureBatch) {
+	if len(set.Signatures) == 0 {
+		return
+	}
+	v.sets <- set
+}
+
+// wait waits for all the queued signature batches to be verified and returns the first failure.
+func (v *signatureBatchVerifier) wait() error {
+	v.once.Do(func() { close(v.sets) })
+	<-v.done
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.err
+}
+
+// abort skips the verification of the signature batches which are still queued, when the batch
+// fails before all of them are verified.
+func (v *signatureBatchVerifier) abort() {
+	v.lock.Lock()
+	v.aborted = true
+	v.lock.Unlock()
+	v.once.Do(func() { close(v.sets) })
+}
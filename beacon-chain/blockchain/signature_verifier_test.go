@@ -0,0 +1,61 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestSignatureBatchVerifier(t *testing.T) {
+	set := func(sig byte) *bls.SignatureBatch {
+		return &bls.SignatureBatch{Signatures: [][]byte{{sig}}, PublicKeys: []bls.PublicKey{nil}, Messages: [][32]byte{{}}}
+	}
+	newVerifier := func(verified map[byte]bool, calls *int) *signatureBatchVerifier {
+		v := newSignatureBatchVerifier(3 * signatureChunkBlocks)
+		v.verify = func(set *bls.SignatureBatch) (bool, error) {
+			*calls++
+			if set.Signatures[0][0] == 'e' {
+				return false, errors.New("bad signature")
+			}
+			return verified[set.Signatures[0][0]], nil
+		}
+		return v
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		calls := 0
+		v := newVerifier(map[byte]bool{'a': true, 'b': true}, &calls)
+		v.add(set('a'))
+		v.add(bls.NewSet())
+		v.add(set('b'))
+		require.NoError(t, v.wait())
+		assert.Equal(t, 2, calls)
+		v.abort()
+	})
+	t.Run("not verified", func(t *testing.T) {
+		calls := 0
+		v := newVerifier(map[byte]bool{'a': true}, &calls)
+		v.add(set('b'))
+		v.add(set('a'))
+		require.ErrorIs(t, v.wait(), errBatchSignatureVerification)
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		calls := 0
+		v := newVerifier(nil, &calls)
+		v.add(set('e'))
+		err := v.wait()
+		require.ErrorContains(t, "bad signature", err)
+		assert.Equal(t, true, IsInvalidBlock(err))
+	})
+	t.Run("aborted", func(t *testing.T) {
+		calls := 0
+		v := newVerifier(nil, &calls)
+		v.abort()
+		require.NoError(t, v.wait())
+		assert.Equal(t, 0, calls)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 )
@@ -18,6 +19,14 @@ var (
 	// window to accept attestation based on latest spec.
 	maxCheckpointStateSize = 10
 
+	// DefaultCheckpointStateCacheBytes is the default estimated memory the states held by the
+	// checkpoint state cache can use.
+	DefaultCheckpointStateCacheBytes = uint64(1024 * 1024 * 1024)
+
+	// Estimated number of bytes used by every validator of a state: the validator record, its
+	// balance, inactivity score and participation flags.
+	bytesPerValidator = uint64(121 + 8 + 8 + 2)
+
 	// Metrics.
 	checkpointStateMiss = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "check_point_state_cache_miss",
@@ -27,19 +36,46 @@ var (
 		Name: "check_point_state_cache_hit",
 		Help: "The number of check point state requests that are present in the cache.",
 	})
+	checkpointStateEviction = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "check_point_state_cache_eviction",
+		Help: "The number of check point states evicted from the cache.",
+	})
+	checkpointStateCacheBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "check_point_state_cache_size_bytes",
+		Help: "The estimated memory used by the states held by the check point state cache.",
+	})
+	checkpointStateCacheEntries = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "check_point_state_cache_entries",
+		Help: "The number of states held by the check point state cache.",
+	})
 )
 
-// CheckpointStateCache is a struct with 1 queue for looking up state by checkpoint.
+// CheckpointStateCache is a struct with 1 queue for looking up state by checkpoint. The cache is
+// bounded by both a number of entries and the estimated memory used by the cached states, the least
+// recently used states are evicted first.
 type CheckpointStateCache struct {
-	cache *lru.Cache
-	lock  sync.RWMutex
+	cache    *lru.Cache
+	lock     sync.RWMutex
+	sizes    map[[32]byte]uint64
+	size     uint64
+	maxBytes uint64
 }
 
 // NewCheckpointStateCache creates a new checkpoint state cache for storing/accessing processed state.
 func NewCheckpointStateCache() *CheckpointStateCache {
-	return &CheckpointStateCache{
-		cache: lruwrpr.New(maxCheckpointStateSize),
+	return NewCheckpointStateCacheWithMaxBytes(DefaultCheckpointStateCacheBytes)
+}
+
+// NewCheckpointStateCacheWithMaxBytes creates a new checkpoint state cache whose states use at most
+// maxBytes of estimated memory. The most recently added state is always kept, even if it alone
+// exceeds the limit.
+func NewCheckpointStateCacheWithMaxBytes(maxBytes uint64) *CheckpointStateCache {
+	c := &CheckpointStateCache{
+		sizes:    make(map[[32]byte]uint64),
+		maxBytes: maxBytes,
 	}
+	c.cache = lruwrpr.NewWithEvict(maxCheckpointStateSize, c.onEvicted)
+	return c
 }
 
 // StateByCheckpoint fetches state by checkpoint. Returns true with a
@@ -65,7 +101,8 @@ func (c *CheckpointStateCache) StateByCheckpoint(cp *ethpb.Checkpoint) (state.Be
 }
 
 // AddCheckpointState adds CheckpointState object to the cache. This method also trims the least
-// recently added CheckpointState object if the cache size has ready the max cache size limit.
+// recently used CheckpointState objects if the cache has reached its max number of entries or the
+// states it holds exceed its max estimated memory.
 func (c *CheckpointStateCache) AddCheckpointState(cp *ethpb.Checkpoint, s state.ReadOnlyBeaconState) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -73,6 +110,34 @@ func (c *CheckpointStateCache) AddCheckpointState(cp *ethpb.Checkpoint, s state.
 	if err != nil {
 		return err
 	}
+	// Replacing a value doesn't trigger the eviction callback.
+	c.size -= c.sizes[h]
+	size := estimatedStateSize(s)
+	c.sizes[h] = size
+	c.size += size
 	c.cache.Add(h, s)
+	for c.size > c.maxBytes && c.cache.Len() > 1 {
+		c.cache.RemoveOldest()
+	}
+	checkpointStateCacheBytes.Set(float64(c.size))
+	checkpointStateCacheEntries.Set(float64(c.cache.Len()))
 	return nil
 }
+
+// onEvicted keeps track of the memory released by evicted states. It is called by the
+// underlying LRU while the write lock is held.
+func (c *CheckpointStateCache) onEvicted(key, _ interface{}) {
+	h := key.([32]byte)
+	c.size -= c.sizes[h]
+	delete(c.sizes, h)
+	checkpointStateEviction.Inc()
+}
+
+// estimatedStateSize estimates the memory used by a state from the size of its validator
+// registry, which dominates it, and of its fixed size vectors.
+func estimatedStateSize(s state.ReadOnlyBeaconState) uint64 {
+	cfg := params.BeaconConfig()
+	fixed := uint64(cfg.SlotsPerHistoricalRoot)*2*32 + uint64(cfg.EpochsPerHistoricalVector)*32 +
+		uint64(cfg.EpochsPerSlashingsVector)*8
+	return fixed + uint64(s.NumValidators())*bytesPerValidator
+}
@@ -71,3 +71,42 @@ func TestCheckpointStateCache_MaxSize(t *testing.T) {
 
 	assert.Equal(t, maxCheckpointStateSize, len(c.cache.Keys()))
 }
+
+func TestCheckpointStateCache_MaxBytes(t *testing.T) {
+	st, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators: make([]*ethpb.Validator, 64),
+		Balances:   make([]uint64, 64),
+	})
+	require.NoError(t, err)
+	size := estimatedStateSize(st)
+	c := NewCheckpointStateCacheWithMaxBytes(3 * size)
+
+	cp := func(i uint64) *ethpb.Checkpoint {
+		return &ethpb.Checkpoint{Epoch: types.Epoch(i), Root: make([]byte, 32)}
+	}
+	for i := uint64(0); i < 5; i++ {
+		require.NoError(t, c.AddCheckpointState(cp(i), st))
+	}
+	assert.Equal(t, 3, c.cache.Len())
+	assert.Equal(t, 3*size, c.size)
+
+	// The oldest states were evicted.
+	s, err := c.StateByCheckpoint(cp(1))
+	require.NoError(t, err)
+	assert.Equal(t, state.BeaconState(nil), s)
+	s, err = c.StateByCheckpoint(cp(4))
+	require.NoError(t, err)
+	assert.NotNil(t, s)
+
+	// Replacing a state doesn't count it twice.
+	require.NoError(t, c.AddCheckpointState(cp(4), st))
+	assert.Equal(t, 3, c.cache.Len())
+	assert.Equal(t, 3*size, c.size)
+
+	// A state larger than the limit is still cached on its own.
+	c = NewCheckpointStateCacheWithMaxBytes(size / 2)
+	require.NoError(t, c.AddCheckpointState(cp(0), st))
+	require.NoError(t, c.AddCheckpointState(cp(1), st))
+	assert.Equal(t, 1, c.cache.Len())
+	assert.Equal(t, size, c.size)
+}
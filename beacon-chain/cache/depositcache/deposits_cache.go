@@ -50,28 +50,43 @@ type FinalizedDeposits struct {
 // stores all the deposit related data that is required by the beacon-node.
 type DepositCache struct {
 	// Beacon chain deposits in memory.
-	pendingDeposits   []*ethpb.DepositContainer
-	deposits          []*ethpb.DepositContainer
-	finalizedDeposits *FinalizedDeposits
-	depositsByKey     map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer
-	depositsLock      sync.RWMutex
+	pendingDeposits []*ethpb.DepositContainer
+	deposits        []*ethpb.DepositContainer
+	finalizedTree   *finalizedTree
+	depositsByKey   map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer
+	depositsLock    sync.RWMutex
+	// Deposit count and root of the deposit tree snapshot the cache was started from, if any.
+	// The cache has no deposit containers for the deposits of the snapshot.
+	snapshotCount uint64
+	snapshotRoot  [32]byte
+	// Store of the finalized deposit containers, if any. The containers of the stored index range
+	// are not kept in memory.
+	store       Store
+	storedFirst int64
+	storedLast  int64
+	lastStored  *ethpb.DepositContainer
 }
 
 // New instantiates a new deposit cache
-func New() (*DepositCache, error) {
-	finalizedDepositsTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		return nil, err
+func New(opts ...Option) (*DepositCache, error) {
+	dc := &DepositCache{
+		pendingDeposits: []*ethpb.DepositContainer{},
+		deposits:        []*ethpb.DepositContainer{},
+		depositsByKey:   map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer{},
+		finalizedTree:   newFinalizedTree(),
+		storedLast:      -1,
 	}
-
-	// finalizedDeposits.MerkleTrieIndex is initialized to -1 because it represents the index of the last trie item.
-	// Inserting the first item into the trie will set the value of the index to 0.
-	return &DepositCache{
-		pendingDeposits:   []*ethpb.DepositContainer{},
-		deposits:          []*ethpb.DepositContainer{},
-		depositsByKey:     map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer{},
-		finalizedDeposits: &FinalizedDeposits{Deposits: finalizedDepositsTrie, MerkleTrieIndex: -1},
-	}, nil
+	for _, o := range opts {
+		if err := o(dc); err != nil {
+			return nil, err
+		}
+	}
+	if dc.store != nil {
+		if err := dc.loadStoredRange(context.Background()); err != nil {
+			return nil, errors.Wrap(err, "could not load stored deposit containers")
+		}
+	}
+	return dc, nil
 }
 
 // InsertDeposit into the database. If deposit or block number are nil
@@ -91,8 +106,8 @@ func (dc *DepositCache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blo
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if int(index) != len(dc.deposits) {
-		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", len(dc.deposits), index)
+	if want := dc.firstIndex() + int64(len(dc.deposits)); index != want {
+		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", want, index)
 	}
 	// Keep the slice sorted on insertion in order to avoid costly sorting on retrieval.
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= index })
@@ -109,7 +124,8 @@ func (dc *DepositCache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blo
 	return nil
 }
 
-// InsertDepositContainers inserts a set of deposit containers into our deposit cache.
+// InsertDepositContainers inserts a set of deposit containers into our deposit cache. With a store,
+// the containers which are already stored are not kept in memory.
 func (dc *DepositCache) InsertDepositContainers(ctx context.Context, ctrs []*ethpb.DepositContainer) {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertDepositContainers")
 	defer span.End()
@@ -117,6 +133,10 @@ func (dc *DepositCache) InsertDepositContainers(ctx context.Context, ctrs []*eth
 	defer dc.depositsLock.Unlock()
 
 	sort.SliceStable(ctrs, func(i int, j int) bool { return ctrs[i].Index < ctrs[j].Index })
+	if dc.hasStored() {
+		dc.extendFinalizedTree(ctx)
+		ctrs = ctrs[sort.Search(len(ctrs), func(i int) bool { return ctrs[i].Index > dc.storedLast }):]
+	}
 	dc.deposits = ctrs
 	for _, c := range ctrs {
 		// Use a new value, as the reference
@@ -129,14 +149,15 @@ func (dc *DepositCache) InsertDepositContainers(ctx context.Context, ctrs []*eth
 }
 
 // InsertFinalizedDeposits inserts deposits up to eth1DepositIndex (inclusive) into the finalized deposits cache.
+// With a store, the containers of the finalized deposits are moved from memory to the store.
 func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex int64) {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertFinalizedDeposits")
 	defer span.End()
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	depositTrie := dc.finalizedDeposits.Deposits
-	insertIndex := int(dc.finalizedDeposits.MerkleTrieIndex + 1)
+	dc.extendFinalizedTree(ctx)
+	insertIndex := int64(dc.finalizedTree.count)
 
 	// Don't insert into finalized trie if there is no deposit to
 	// insert.
@@ -145,16 +166,16 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 	}
 	// In the event we have less deposits than we need to
 	// finalize we finalize till the index on which we do have it.
-	if len(dc.deposits) <= int(eth1DepositIndex) {
-		eth1DepositIndex = int64(len(dc.deposits)) - 1
+	if last := dc.deposits[len(dc.deposits)-1].Index; last < eth1DepositIndex {
+		eth1DepositIndex = last
 	}
 	// If we finalize to some lower deposit index, we
 	// ignore it.
-	if int(eth1DepositIndex) < insertIndex {
+	if eth1DepositIndex < insertIndex {
 		return
 	}
 	for _, d := range dc.deposits {
-		if d.Index <= dc.finalizedDeposits.MerkleTrieIndex {
+		if d.Index < insertIndex {
 			continue
 		}
 		if d.Index > eth1DepositIndex {
@@ -165,20 +186,71 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 			log.WithError(err).Error("Could not hash deposit data. Finalized deposit cache not updated.")
 			return
 		}
-		if err = depositTrie.Insert(depHash[:], insertIndex); err != nil {
+		if err = dc.finalizedTree.insert(depHash); err != nil {
 			log.WithError(err).Error("Could not insert deposit hash")
 			return
 		}
 		insertIndex++
 	}
 
-	dc.finalizedDeposits = &FinalizedDeposits{
-		Deposits:        depositTrie,
-		MerkleTrieIndex: eth1DepositIndex,
+	if dc.store != nil {
+		if err := dc.storeDeposits(ctx, eth1DepositIndex); err != nil {
+			log.WithError(err).Error("Could not store finalized deposits, keeping them in memory")
+		}
+	}
+}
+
+// InsertFinalizedDepositsSnapshot starts the finalized deposits from an EIP-4881 snapshot of the
+// deposit tree, for nodes which don't have the deposits of the snapshot. The deposits inserted in the
+// cache afterwards must come after the snapshot. The snapshot is ignored if more deposits are
+// already finalized.
+func (dc *DepositCache) InsertFinalizedDepositsSnapshot(ctx context.Context, snapshot *trie.DepositTreeSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertFinalizedDepositsSnapshot")
+	defer span.End()
+
+	tree, err := finalizedTreeFromSnapshot(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit tree from snapshot")
+	}
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	if snapshot.DepositCount <= dc.finalizedTree.count {
+		return nil
+	}
+	dc.finalizedTree = tree
+	dc.snapshotCount = snapshot.DepositCount
+	dc.snapshotRoot = snapshot.DepositRoot
+	dc.extendFinalizedTree(ctx)
+	return nil
+}
+
+// FinalizedDepositsSnapshot returns the EIP-4881 snapshot of the finalized deposits trie, whose
+// deposits were all included by the execution block with the given hash and height.
+func (dc *DepositCache) FinalizedDepositsSnapshot(ctx context.Context, executionHash [32]byte, executionHeight uint64) (*trie.DepositTreeSnapshot, error) {
+	_, span := trace.StartSpan(ctx, "DepositsCache.FinalizedDepositsSnapshot")
+	defer span.End()
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+
+	return dc.finalizedTree.snapshot(executionHash, executionHeight)
+}
+
+// Index of the first deposit container of the cache. When the cache is empty, this is the index
+// following the stored deposit containers, or the deposit count of the snapshot the cache was
+// started from, if any.
+func (dc *DepositCache) firstIndex() int64 {
+	if len(dc.deposits) > 0 {
+		return dc.deposits[0].Index
 	}
+	if dc.hasStored() {
+		return dc.storedLast + 1
+	}
+	return int64(dc.snapshotCount)
 }
 
-// AllDepositContainers returns all historical deposit containers.
+// AllDepositContainers returns all historical deposit containers held in memory. With a store,
+// these are the deposit containers which are not finalized yet.
 func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*ethpb.DepositContainer {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.AllDepositContainers")
 	defer span.End()
@@ -196,13 +268,34 @@ func (dc *DepositCache) AllDeposits(ctx context.Context, untilBlk *big.Int) []*e
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 
-	return dc.allDeposits(untilBlk)
+	return dc.allDeposits(ctx, untilBlk)
 }
 
-func (dc *DepositCache) allDeposits(untilBlk *big.Int) []*ethpb.Deposit {
+func (dc *DepositCache) allDeposits(ctx context.Context, untilBlk *big.Int) []*ethpb.Deposit {
+	return dc.depositsAfter(ctx, -1, untilBlk)
+}
+
+// depositsAfter returns the deposits with an index greater than the given index, until the given
+// block number (inclusive). Stored deposits are loaded from the store.
+func (dc *DepositCache) depositsAfter(ctx context.Context, index int64, untilBlk *big.Int) []*ethpb.Deposit {
 	var deposits []*ethpb.Deposit
+	if dc.hasStored() && index < dc.storedLast {
+		start := index + 1
+		if start < dc.storedFirst {
+			start = dc.storedFirst
+		}
+		ctrs, err := dc.store.DepositContainers(ctx, start, dc.storedLast+1)
+		if err != nil {
+			log.WithError(err).Error("Could not load stored deposit containers")
+		}
+		for _, ctnr := range ctrs {
+			if untilBlk == nil || untilBlk.Uint64() >= ctnr.Eth1BlockHeight {
+				deposits = append(deposits, ctnr.Deposit)
+			}
+		}
+	}
 	for _, ctnr := range dc.deposits {
-		if untilBlk == nil || untilBlk.Uint64() >= ctnr.Eth1BlockHeight {
+		if ctnr.Index > index && (untilBlk == nil || untilBlk.Uint64() >= ctnr.Eth1BlockHeight) {
 			deposits = append(deposits, ctnr.Deposit)
 		}
 	}
@@ -217,12 +310,18 @@ func (dc *DepositCache) DepositsNumberAndRootAtHeight(ctx context.Context, block
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Eth1BlockHeight > blockHeight.Uint64() })
+	if heightIdx > 0 {
+		return uint64(dc.deposits[heightIdx-1].Index + 1), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	}
+	if ctnr := dc.storedDepositAtHeight(ctx, blockHeight.Uint64()); ctnr != nil {
+		return uint64(ctnr.Index + 1), bytesutil.ToBytes32(ctnr.DepositRoot)
+	}
 	// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
-	// deposit.
-	if heightIdx == 0 {
-		return 0, [32]byte{}
+	// deposit. Nodes started from a deposit snapshot send the root of the snapshot instead.
+	if dc.snapshotCount > 0 && dc.firstIndex() >= int64(dc.snapshotCount) && (!dc.hasStored() || dc.storedFirst >= int64(dc.snapshotCount)) {
+		return dc.snapshotCount, dc.snapshotRoot
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	return 0, [32]byte{}
 }
 
 // DepositByPubkey looks through historical deposits and finds one which contains
@@ -235,6 +334,16 @@ func (dc *DepositCache) DepositByPubkey(ctx context.Context, pubKey []byte) (*et
 
 	var deposit *ethpb.Deposit
 	var blockNum *big.Int
+	// Stored deposits come before the deposits in memory, so they are looked up first.
+	if dc.hasStored() {
+		ctnr, err := dc.store.DepositContainerByPubkey(ctx, pubKey)
+		if err != nil {
+			log.WithError(err).Error("Could not load stored deposit container")
+		}
+		if ctnr != nil {
+			return ctnr.Deposit, big.NewInt(int64(ctnr.Eth1BlockHeight))
+		}
+	}
 	deps, ok := dc.depositsByKey[bytesutil.ToBytes48(pubKey)]
 	if !ok || len(deps) == 0 {
 		return deposit, blockNum
@@ -247,16 +356,25 @@ func (dc *DepositCache) DepositByPubkey(ctx context.Context, pubKey []byte) (*et
 	return deposit, blockNum
 }
 
-// FinalizedDeposits returns the finalized deposits trie.
+// FinalizedDeposits returns the finalized deposits trie. The trie is built from the incremental
+// tree of the finalized deposits, so it can only prove the deposits which come after them.
 func (dc *DepositCache) FinalizedDeposits(ctx context.Context) *FinalizedDeposits {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.FinalizedDeposits")
 	defer span.End()
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 
+	depositTrie, err := dc.finalizedTree.trie()
+	if err != nil {
+		// The tree only holds valid hashes, so this can't happen. Fall back to no finalized deposits,
+		// for which callers rebuild the trie from all deposits.
+		log.WithError(err).Error("Could not build finalized deposits trie")
+		depositTrie, _ = trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
+		return &FinalizedDeposits{Deposits: depositTrie, MerkleTrieIndex: -1}
+	}
 	return &FinalizedDeposits{
-		Deposits:        dc.finalizedDeposits.Deposits.Copy(),
-		MerkleTrieIndex: dc.finalizedDeposits.MerkleTrieIndex,
+		Deposits:        depositTrie,
+		MerkleTrieIndex: int64(dc.finalizedTree.count) - 1,
 	}
 }
 
@@ -268,18 +386,7 @@ func (dc *DepositCache) NonFinalizedDeposits(ctx context.Context, lastFinalizedI
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 
-	if dc.finalizedDeposits == nil {
-		return dc.allDeposits(untilBlk)
-	}
-
-	var deposits []*ethpb.Deposit
-	for _, d := range dc.deposits {
-		if (d.Index > lastFinalizedIndex) && (untilBlk == nil || untilBlk.Uint64() >= d.Eth1BlockHeight) {
-			deposits = append(deposits, d.Deposit)
-		}
-	}
-
-	return deposits
+	return dc.depositsAfter(ctx, lastFinalizedIndex, untilBlk)
 }
 
 // PruneProofs removes proofs from all deposits whose index is equal or less than untilDepositIndex.
@@ -289,11 +396,13 @@ func (dc *DepositCache) PruneProofs(ctx context.Context, untilDepositIndex int64
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if untilDepositIndex >= int64(len(dc.deposits)) {
-		untilDepositIndex = int64(len(dc.deposits) - 1)
+	// Deposit containers are stored in order of index, starting at the first index of the cache.
+	untilPosition := untilDepositIndex - dc.firstIndex()
+	if untilPosition >= int64(len(dc.deposits)) {
+		untilPosition = int64(len(dc.deposits) - 1)
 	}
 
-	for i := untilDepositIndex; i >= 0; i-- {
+	for i := untilPosition; i >= 0; i-- {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -637,7 +637,9 @@ func TestFinalizedDeposits_InitializedCorrectly(t *testing.T) {
 	dc, err := New()
 	require.NoError(t, err)
 
-	finalizedDeposits := dc.finalizedDeposits
+	assert.NotNil(t, dc.finalizedTree)
+	assert.Equal(t, uint64(0), dc.finalizedTree.count)
+	finalizedDeposits := dc.FinalizedDeposits(context.Background())
 	assert.NotNil(t, finalizedDeposits)
 	assert.NotNil(t, finalizedDeposits.Deposits)
 	assert.Equal(t, int64(-1), finalizedDeposits.MerkleTrieIndex)
@@ -790,7 +792,7 @@ func TestFinalizedDeposits_ReturnsTrieCorrectly(t *testing.T) {
 		generateCtr(15, 9),
 		generateCtr(30, 10))
 	trieItems := make([][]byte, 0, len(dc.deposits))
-	for _, dep := range dc.allDeposits(big.NewInt(30)) {
+	for _, dep := range dc.allDeposits(context.Background(), big.NewInt(30)) {
 		depHash, err := dep.Data.HashTreeRoot()
 		assert.NoError(t, err)
 		trieItems = append(trieItems, depHash[:])
@@ -1056,3 +1058,66 @@ func makeDepositProof() [][]byte {
 	}
 	return proof
 }
+
+func TestFinalizedDeposits_StartedFromSnapshot(t *testing.T) {
+	ctx := context.Background()
+	deposits := make([]*ethpb.Deposit, 6)
+	leaves := make([][]byte, len(deposits))
+	for i := range deposits {
+		deposits[i] = &ethpb.Deposit{
+			Proof: [][]byte{{'p'}},
+			Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			},
+		}
+		h, err := deposits[i].Data.HashTreeRoot()
+		require.NoError(t, err)
+		leaves[i] = h[:]
+	}
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	full, err := trie.GenerateTrieFromItems(leaves, depth)
+	require.NoError(t, err)
+	snapshot, err := trie.NewDepositTreeSnapshot(full, 3, [32]byte{'h'}, 12)
+	require.NoError(t, err)
+
+	dc, err := New()
+	require.NoError(t, err)
+	require.NoError(t, dc.InsertFinalizedDepositsSnapshot(ctx, snapshot))
+	assert.Equal(t, int64(2), dc.FinalizedDeposits(ctx).MerkleTrieIndex)
+	require.ErrorContains(t, "wanted deposit with index 3 to be inserted but received 0", dc.InsertDeposit(ctx, deposits[0], 10, 0, [32]byte{}))
+	roots := make([][32]byte, len(deposits))
+	for i := 3; i < len(deposits); i++ {
+		partial, err := trie.GenerateTrieFromItems(leaves[:i+1], depth)
+		require.NoError(t, err)
+		roots[i], err = partial.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, dc.InsertDeposit(ctx, deposits[i], uint64(10+i), int64(i), roots[i]))
+	}
+
+	count, root := dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(12))
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, snapshot.DepositRoot, root)
+	count, root = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(14))
+	assert.Equal(t, uint64(5), count)
+	assert.Equal(t, roots[4], root)
+
+	dc.InsertFinalizedDeposits(ctx, 4)
+	finalized := dc.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(4), finalized.MerkleTrieIndex)
+	finalizedRoot, err := finalized.Deposits.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, roots[4], finalizedRoot)
+	finalizedSnapshot, err := dc.FinalizedDepositsSnapshot(ctx, [32]byte{'i'}, 14)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), finalizedSnapshot.DepositCount)
+	assert.Equal(t, roots[4], finalizedSnapshot.DepositRoot)
+
+	require.NoError(t, dc.PruneProofs(ctx, 4))
+	ctrs := dc.AllDepositContainers(ctx)
+	require.Equal(t, 3, len(ctrs))
+	assert.Equal(t, true, ctrs[0].Deposit.Proof == nil)
+	assert.Equal(t, true, ctrs[1].Deposit.Proof == nil)
+	assert.NotNil(t, ctrs[2].Deposit.Proof)
+}
@@ -0,0 +1,106 @@
+package depositcache
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+)
+
+// finalizedTree is the incremental Merkle tree of the finalized deposits, as maintained by the
+// deposit contract. It only keeps one node per level of the tree, the root of the left subtree on
+// the path to the next deposit, instead of every node of the tree.
+type finalizedTree struct {
+	branch [][32]byte
+	count  uint64
+}
+
+func newFinalizedTree() *finalizedTree {
+	return &finalizedTree{branch: make([][32]byte, params.BeaconConfig().DepositContractTreeDepth)}
+}
+
+// finalizedTreeFromSnapshot rebuilds the tree of the deposits of an EIP-4881 snapshot.
+func finalizedTreeFromSnapshot(s *trie.DepositTreeSnapshot) (*finalizedTree, error) {
+	root, err := s.CalculateRoot()
+	if err != nil {
+		return nil, err
+	}
+	if root != s.DepositRoot {
+		return nil, errors.Errorf("snapshot deposit root %#x does not match its finalized hashes", s.DepositRoot)
+	}
+	t := newFinalizedTree()
+	t.count = s.DepositCount
+	// The finalized hashes of the snapshot are the left subtrees of the tree, largest first.
+	index := 0
+	for h := len(t.branch) - 1; h >= 0; h-- {
+		if (t.count>>uint(h))&1 == 1 {
+			t.branch[h] = s.Finalized[index]
+			index++
+		}
+	}
+	return t, nil
+}
+
+// insert appends the hash of a deposit to the tree.
+//
+// Deposit contract definition:
+//
+//	size += 1
+//	for height in range(DEPOSIT_CONTRACT_TREE_DEPTH):
+//	    if size % 2 == 1:
+//	        branch[height] = node
+//	        return
+//	    node = sha256(branch[height] + node)
+//	    size /= 2
+func (t *finalizedTree) insert(leaf [32]byte) error {
+	size := t.count + 1
+	if size>>uint(len(t.branch)) > 0 {
+		return errors.New("deposit tree is full")
+	}
+	node := leaf
+	for h := range t.branch {
+		if size&1 == 1 {
+			t.branch[h] = node
+			break
+		}
+		node = hash.Hash(append(t.branch[h][:], node[:]...))
+		size >>= 1
+	}
+	t.count++
+	return nil
+}
+
+// snapshot returns the EIP-4881 snapshot of the tree, whose deposits were all included by the
+// execution block with the given hash and height.
+func (t *finalizedTree) snapshot(executionHash [32]byte, executionHeight uint64) (*trie.DepositTreeSnapshot, error) {
+	s := &trie.DepositTreeSnapshot{
+		Finalized:            make([][32]byte, 0, len(t.branch)),
+		DepositCount:         t.count,
+		ExecutionBlockHash:   executionHash,
+		ExecutionBlockHeight: executionHeight,
+	}
+	for h := len(t.branch) - 1; h >= 0; h-- {
+		if (t.count>>uint(h))&1 == 1 {
+			s.Finalized = append(s.Finalized, t.branch[h])
+		}
+	}
+	root, err := s.CalculateRoot()
+	if err != nil {
+		return nil, err
+	}
+	s.DepositRoot = root
+	return s, nil
+}
+
+// trie returns a sparse Merkle trie with the deposits of the tree, which can be extended with the
+// deposits that come after them.
+func (t *finalizedTree) trie() (*trie.SparseMerkleTrie, error) {
+	if t.count == 0 {
+		return trie.NewTrie(uint64(len(t.branch)))
+	}
+	s, err := t.snapshot([32]byte{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return trie.NewTrieFromSnapshot(s)
+}
@@ -0,0 +1,68 @@
+package depositcache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestFinalizedTree_MatchesSparseMerkleTrie(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	tree := newFinalizedTree()
+	leaves := make([][]byte, 0)
+	for i := 0; i < 20; i++ {
+		leaf := hash.Hash([]byte{byte(i)})
+		require.NoError(t, tree.insert(leaf))
+		leaves = append(leaves, leaf[:])
+
+		full, err := trie.GenerateTrieFromItems(leaves, depth)
+		require.NoError(t, err)
+		want, err := full.HashTreeRoot()
+		require.NoError(t, err)
+		s, err := tree.snapshot([32]byte{}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, want, s.DepositRoot)
+		fromFull, err := trie.NewDepositTreeSnapshot(full, uint64(i+1), [32]byte{}, 0)
+		require.NoError(t, err)
+		assert.DeepEqual(t, fromFull.Finalized, s.Finalized)
+	}
+}
+
+func TestFinalizedTree_FromSnapshot(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	leaves := make([][]byte, 0)
+	for i := 0; i < 13; i++ {
+		leaf := hash.Hash([]byte{byte(i)})
+		leaves = append(leaves, leaf[:])
+	}
+	full, err := trie.GenerateTrieFromItems(leaves[:11], depth)
+	require.NoError(t, err)
+	s, err := trie.NewDepositTreeSnapshot(full, 11, [32]byte{'h'}, 7)
+	require.NoError(t, err)
+
+	tree, err := finalizedTreeFromSnapshot(s)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), tree.count)
+	for _, leaf := range leaves[11:] {
+		var l [32]byte
+		copy(l[:], leaf)
+		require.NoError(t, tree.insert(l))
+	}
+	full, err = trie.GenerateTrieFromItems(leaves, depth)
+	require.NoError(t, err)
+	want, err := full.HashTreeRoot()
+	require.NoError(t, err)
+	depositTrie, err := tree.trie()
+	require.NoError(t, err)
+	got, err := depositTrie.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	s.DepositRoot = [32]byte{'x'}
+	_, err = finalizedTreeFromSnapshot(s)
+	require.ErrorContains(t, "does not match its finalized hashes", err)
+}
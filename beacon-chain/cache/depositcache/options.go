@@ -0,0 +1,13 @@
+package depositcache
+
+// Option for the deposit cache.
+type Option func(dc *DepositCache) error
+
+// WithStore moves the finalized deposit containers out of memory into the given store, from which
+// they are loaded on demand.
+func WithStore(s Store) Option {
+	return func(dc *DepositCache) error {
+		dc.store = s
+		return nil
+	}
+}
@@ -0,0 +1,168 @@
+package depositcache
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// Number of stored deposit containers loaded at once to extend the finalized deposits tree.
+const storedDepositsBatchSize = 4096
+
+// Store persists the deposit containers of finalized deposits, which the cache then no longer
+// keeps in memory. The stored containers form a contiguous range of deposit indices.
+type Store interface {
+	SaveDepositContainers(ctx context.Context, ctrs []*ethpb.DepositContainer) error
+	// DepositContainers returns the stored containers with an index in [start, end).
+	DepositContainers(ctx context.Context, start, end int64) ([]*ethpb.DepositContainer, error)
+	// DepositContainerByPubkey returns the first stored container of the public key, if any.
+	DepositContainerByPubkey(ctx context.Context, pubKey []byte) (*ethpb.DepositContainer, error)
+	// DepositContainerIndexRange returns the indices of the first and last stored containers.
+	// The last index is lower than the first one when the store is empty.
+	DepositContainerIndexRange(ctx context.Context) (int64, int64, error)
+}
+
+func (dc *DepositCache) hasStored() bool {
+	return dc.store != nil && dc.storedLast >= dc.storedFirst
+}
+
+// loadStoredRange reads the index range of the stored deposit containers and the last of them.
+func (dc *DepositCache) loadStoredRange(ctx context.Context) error {
+	first, last, err := dc.store.DepositContainerIndexRange(ctx)
+	if err != nil {
+		return err
+	}
+	if last < first {
+		return nil
+	}
+	ctrs, err := dc.store.DepositContainers(ctx, last, last+1)
+	if err != nil {
+		return err
+	}
+	if len(ctrs) != 1 {
+		return errors.Errorf("missing stored deposit container with index %d", last)
+	}
+	dc.storedFirst, dc.storedLast, dc.lastStored = first, last, ctrs[0]
+	return nil
+}
+
+// extendFinalizedTree inserts the stored deposits which are not in the finalized deposits tree yet,
+// as the tree is not persisted with them.
+func (dc *DepositCache) extendFinalizedTree(ctx context.Context) {
+	if !dc.hasStored() || int64(dc.finalizedTree.count) > dc.storedLast {
+		return
+	}
+	if int64(dc.finalizedTree.count) < dc.storedFirst {
+		log.WithError(errors.Errorf("stored deposits start at index %d", dc.storedFirst)).
+			Warnf("Could not insert stored deposits after deposit %d into the finalized deposits tree", dc.finalizedTree.count)
+		return
+	}
+	for start := int64(dc.finalizedTree.count); start <= dc.storedLast; start += storedDepositsBatchSize {
+		end := start + storedDepositsBatchSize
+		if end > dc.storedLast+1 {
+			end = dc.storedLast + 1
+		}
+		ctrs, err := dc.store.DepositContainers(ctx, start, end)
+		if err == nil && int64(len(ctrs)) != end-start {
+			err = errors.Errorf("got %d deposit containers in range [%d, %d)", len(ctrs), start, end)
+		}
+		if err != nil {
+			log.WithError(err).Error("Could not load stored deposits into the finalized deposits tree")
+			return
+		}
+		for _, c := range ctrs {
+			depHash, err := c.Deposit.Data.HashTreeRoot()
+			if err != nil {
+				log.WithError(err).Error("Could not hash deposit data")
+				return
+			}
+			if err := dc.finalizedTree.insert(depHash); err != nil {
+				log.WithError(err).Error("Could not insert deposit hash")
+				return
+			}
+		}
+	}
+}
+
+// storeDeposits moves the deposit containers up to the given index (inclusive) from memory to the
+// store. Their proofs are not stored, as they are only needed for deposits yet to be included.
+func (dc *DepositCache) storeDeposits(ctx context.Context, untilIndex int64) error {
+	n := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index > untilIndex })
+	if n == 0 {
+		return nil
+	}
+	ctrs := make([]*ethpb.DepositContainer, n)
+	for i, d := range dc.deposits[:n] {
+		ctrs[i] = &ethpb.DepositContainer{
+			Deposit:         &ethpb.Deposit{Data: d.Deposit.Data},
+			Eth1BlockHeight: d.Eth1BlockHeight,
+			DepositRoot:     d.DepositRoot,
+			Index:           d.Index,
+		}
+	}
+	if err := dc.store.SaveDepositContainers(ctx, ctrs); err != nil {
+		return err
+	}
+	if !dc.hasStored() {
+		dc.storedFirst = ctrs[0].Index
+	}
+	dc.storedLast = ctrs[n-1].Index
+	dc.lastStored = ctrs[n-1]
+	for _, c := range dc.deposits[:n] {
+		pKey := bytesutil.ToBytes48(c.Deposit.Data.PublicKey)
+		remaining := dc.depositsByKey[pKey][:0]
+		for _, d := range dc.depositsByKey[pKey] {
+			if d.Index > untilIndex {
+				remaining = append(remaining, d)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(dc.depositsByKey, pKey)
+		} else {
+			dc.depositsByKey[pKey] = remaining
+		}
+	}
+	dc.deposits = dc.deposits[n:]
+	return nil
+}
+
+// storedDepositAtHeight returns the last stored deposit container with an execution block height
+// lower or equal to the given height, if any.
+func (dc *DepositCache) storedDepositAtHeight(ctx context.Context, height uint64) *ethpb.DepositContainer {
+	if !dc.hasStored() {
+		return nil
+	}
+	if dc.lastStored.Eth1BlockHeight <= height {
+		return dc.lastStored
+	}
+	var found *ethpb.DepositContainer
+	var loadErr error
+	// Stored containers are ordered by height as well as by index.
+	sort.Search(int(dc.storedLast-dc.storedFirst+1), func(i int) bool {
+		if loadErr != nil {
+			return true
+		}
+		index := dc.storedFirst + int64(i)
+		ctrs, err := dc.store.DepositContainers(ctx, index, index+1)
+		if err == nil && len(ctrs) != 1 {
+			err = errors.Errorf("missing stored deposit container with index %d", index)
+		}
+		if err != nil {
+			loadErr = err
+			return true
+		}
+		if ctrs[0].Eth1BlockHeight > height {
+			return true
+		}
+		found = ctrs[0]
+		return false
+	})
+	if loadErr != nil {
+		log.WithError(loadErr).Error("Could not load stored deposit container")
+		return nil
+	}
+	return found
+}
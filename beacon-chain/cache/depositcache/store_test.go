@@ -0,0 +1,163 @@
+package depositcache
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+type mockStore struct {
+	ctrs map[int64]*ethpb.DepositContainer
+}
+
+func (m *mockStore) SaveDepositContainers(_ context.Context, ctrs []*ethpb.DepositContainer) error {
+	for _, c := range ctrs {
+		m.ctrs[c.Index] = c
+	}
+	return nil
+}
+
+func (m *mockStore) DepositContainers(_ context.Context, start, end int64) ([]*ethpb.DepositContainer, error) {
+	var ctrs []*ethpb.DepositContainer
+	for i := start; i < end; i++ {
+		if c, ok := m.ctrs[i]; ok {
+			ctrs = append(ctrs, c)
+		}
+	}
+	return ctrs, nil
+}
+
+func (m *mockStore) DepositContainerByPubkey(_ context.Context, pubKey []byte) (*ethpb.DepositContainer, error) {
+	first, last, err := m.DepositContainerIndexRange(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for i := first; i <= last; i++ {
+		if c := m.ctrs[i]; bytes.Equal(c.Deposit.Data.PublicKey, pubKey) {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockStore) DepositContainerIndexRange(_ context.Context) (int64, int64, error) {
+	first, last := int64(0), int64(-1)
+	for i := range m.ctrs {
+		if last < first || i < first {
+			first = i
+		}
+		if i > last {
+			last = i
+		}
+	}
+	return first, last, nil
+}
+
+func TestDepositCache_WithStore(t *testing.T) {
+	ctx := context.Background()
+	store := &mockStore{ctrs: make(map[int64]*ethpb.DepositContainer)}
+	dc, err := New(WithStore(store))
+	require.NoError(t, err)
+
+	leaves := make([][]byte, 0)
+	roots := make([][32]byte, 10)
+	for i := 0; i < 10; i++ {
+		d := &ethpb.Deposit{
+			Proof: [][]byte{{'p'}},
+			Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			},
+		}
+		h, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		leaves = append(leaves, h[:])
+		roots[i] = [32]byte{byte(i)}
+		require.NoError(t, dc.InsertDeposit(ctx, d, uint64(10+i), int64(i), roots[i]))
+	}
+	all := dc.AllDepositContainers(ctx)
+
+	dc.InsertFinalizedDeposits(ctx, 5)
+	assert.Equal(t, 6, len(store.ctrs))
+	assert.DeepEqual(t, [][]byte(nil), store.ctrs[3].Deposit.Proof)
+	assert.Equal(t, 4, len(dc.AllDepositContainers(ctx)))
+	assert.Equal(t, int64(6), dc.AllDepositContainers(ctx)[0].Index)
+	assert.Equal(t, 10, len(dc.AllDeposits(ctx, nil)))
+	assert.Equal(t, 8, len(dc.AllDeposits(ctx, big.NewInt(17))))
+	assert.Equal(t, 6, len(dc.NonFinalizedDeposits(ctx, 3, nil)))
+	assert.Equal(t, 4, len(dc.NonFinalizedDeposits(ctx, 5, nil)))
+
+	dep, blk := dc.DepositByPubkey(ctx, bytesutil.PadTo([]byte{2}, 48))
+	require.NotNil(t, dep)
+	assert.Equal(t, uint64(12), blk.Uint64())
+	dep, blk = dc.DepositByPubkey(ctx, bytesutil.PadTo([]byte{8}, 48))
+	require.NotNil(t, dep)
+	assert.Equal(t, uint64(18), blk.Uint64())
+
+	count, root := dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(12))
+	assert.Equal(t, uint64(3), count)
+	assert.Equal(t, roots[2], root)
+	count, root = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(15))
+	assert.Equal(t, uint64(6), count)
+	assert.Equal(t, roots[5], root)
+	count, _ = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(9))
+	assert.Equal(t, uint64(0), count)
+
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	finalized, err := trie.GenerateTrieFromItems(leaves[:6], depth)
+	require.NoError(t, err)
+	want, err := finalized.HashTreeRoot()
+	require.NoError(t, err)
+	fd := dc.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(5), fd.MerkleTrieIndex)
+	got, err := fd.Deposits.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// A restarted cache only keeps the deposit containers which are not stored in memory, and
+	// rebuilds the finalized deposits from the store.
+	restarted, err := New(WithStore(store))
+	require.NoError(t, err)
+	restarted.InsertDepositContainers(ctx, all)
+	assert.Equal(t, 4, len(restarted.AllDepositContainers(ctx)))
+	fd = restarted.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(5), fd.MerkleTrieIndex)
+	got, err = fd.Deposits.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	count, root = restarted.DepositsNumberAndRootAtHeight(ctx, big.NewInt(13))
+	assert.Equal(t, uint64(4), count)
+	assert.Equal(t, roots[3], root)
+}
+
+func TestDepositCache_WithStore_EmptyMemory(t *testing.T) {
+	ctx := context.Background()
+	store := &mockStore{ctrs: make(map[int64]*ethpb.DepositContainer)}
+	for i := int64(0); i < 3; i++ {
+		store.ctrs[i] = &ethpb.DepositContainer{
+			Deposit: &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			}},
+			Eth1BlockHeight: uint64(i),
+			Index:           i,
+		}
+	}
+	dc, err := New(WithStore(store))
+	require.NoError(t, err)
+
+	d := &ethpb.Deposit{Data: &ethpb.Deposit_Data{PublicKey: bytesutil.PadTo([]byte{'a'}, 48)}}
+	require.ErrorContains(t, "wanted deposit with index 3 to be inserted but received 0", dc.InsertDeposit(ctx, d, 5, 0, [32]byte{}))
+	require.NoError(t, dc.InsertDeposit(ctx, d, 5, 3, [32]byte{}))
+	assert.Equal(t, 4, len(dc.AllDeposits(ctx, nil)))
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+)
+
+// slotTimelineSize is the number of most recent slots for which timelines are kept.
+const slotTimelineSize = 64
+
+// SlotTimelineEvent is a step of the processing of a slot by the node.
+type SlotTimelineEvent uint8
+
+const (
+	// BlockReceived is when the block of the slot is received by the blockchain service.
+	BlockReceived SlotTimelineEvent = iota
+	// SignatureVerified is when the state transition of the block, signatures included, is verified.
+	SignatureVerified
+	// PayloadVerified is when the execution payload of the block is verified by the execution engine.
+	PayloadVerified
+	// HeadUpdated is when the block of the slot becomes the head of the node.
+	HeadUpdated
+	// AttestationsPacked is when the attestations of a block proposed at the slot are packed.
+	AttestationsPacked
+)
+
+// SlotTimeline holds the time of the first occurrence of each event of a slot. Events which
+// didn't happen have a zero time.
+type SlotTimeline struct {
+	Slot               types.Slot
+	BlockReceived      time.Time
+	SignatureVerified  time.Time
+	PayloadVerified    time.Time
+	HeadUpdated        time.Time
+	AttestationsPacked time.Time
+}
+
+// SlotTimelineCache keeps the timelines of the most recent slots, to see where time goes
+// during the processing of each slot.
+type SlotTimelineCache struct {
+	timelines map[types.Slot]*SlotTimeline
+	lock      sync.RWMutex
+}
+
+// NewSlotTimelineCache creates a new slot timeline cache.
+func NewSlotTimelineCache() *SlotTimelineCache {
+	return &SlotTimelineCache{
+		timelines: make(map[types.Slot]*SlotTimeline),
+	}
+}
+
+// Record records the time of an event of the slot, unless the event was already recorded for
+// the slot. Recording an event on a nil cache does nothing.
+func (c *SlotTimelineCache) Record(slot types.Slot, event SlotTimelineEvent, t time.Time) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	timeline, ok := c.timelines[slot]
+	if !ok {
+		timeline = &SlotTimeline{Slot: slot}
+		c.timelines[slot] = timeline
+		c.prune(slot)
+	}
+	var field *time.Time
+	switch event {
+	case BlockReceived:
+		field = &timeline.BlockReceived
+	case SignatureVerified:
+		field = &timeline.SignatureVerified
+	case PayloadVerified:
+		field = &timeline.PayloadVerified
+	case HeadUpdated:
+		field = &timeline.HeadUpdated
+	case AttestationsPacked:
+		field = &timeline.AttestationsPacked
+	default:
+		return
+	}
+	if field.IsZero() {
+		*field = t
+	}
+}
+
+// Timelines returns copies of the timelines of the last n slots with recorded events, oldest first.
+func (c *SlotTimelineCache) Timelines(n int) []*SlotTimeline {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	timelines := make([]*SlotTimeline, 0, len(c.timelines))
+	for _, t := range c.timelines {
+		cp := *t
+		timelines = append(timelines, &cp)
+	}
+	sort.Slice(timelines, func(i, j int) bool {
+		return timelines[i].Slot < timelines[j].Slot
+	})
+	if n >= 0 && n < len(timelines) {
+		timelines = timelines[len(timelines)-n:]
+	}
+	return timelines
+}
+
+// Removes the timelines which are too old to be kept once the given slot is recorded.
+func (c *SlotTimelineCache) prune(latest types.Slot) {
+	if len(c.timelines) <= slotTimelineSize {
+		return
+	}
+	for s := range c.timelines {
+		if s+slotTimelineSize <= latest {
+			delete(c.timelines, s)
+		}
+	}
+	// Events can still be recorded for old slots, drop the oldest timelines if needed.
+	for len(c.timelines) > slotTimelineSize {
+		oldest := latest
+		for s := range c.timelines {
+			if s < oldest {
+				oldest = s
+			}
+		}
+		delete(c.timelines, oldest)
+	}
+}
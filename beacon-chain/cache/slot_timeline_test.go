@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestSlotTimelineCache_Record(t *testing.T) {
+	c := NewSlotTimelineCache()
+	now := time.Now()
+	c.Record(1, BlockReceived, now)
+	c.Record(1, SignatureVerified, now.Add(time.Millisecond))
+	c.Record(1, PayloadVerified, now.Add(2*time.Millisecond))
+	c.Record(1, HeadUpdated, now.Add(3*time.Millisecond))
+	c.Record(2, AttestationsPacked, now.Add(4*time.Millisecond))
+	// Only the first occurrence of an event is kept.
+	c.Record(1, BlockReceived, now.Add(time.Second))
+
+	timelines := c.Timelines(10)
+	require.Equal(t, 2, len(timelines))
+	assert.Equal(t, types.Slot(1), timelines[0].Slot)
+	assert.Equal(t, now, timelines[0].BlockReceived)
+	assert.Equal(t, now.Add(time.Millisecond), timelines[0].SignatureVerified)
+	assert.Equal(t, now.Add(2*time.Millisecond), timelines[0].PayloadVerified)
+	assert.Equal(t, now.Add(3*time.Millisecond), timelines[0].HeadUpdated)
+	assert.Equal(t, true, timelines[0].AttestationsPacked.IsZero())
+	assert.Equal(t, types.Slot(2), timelines[1].Slot)
+	assert.Equal(t, now.Add(4*time.Millisecond), timelines[1].AttestationsPacked)
+
+	timelines = c.Timelines(1)
+	require.Equal(t, 1, len(timelines))
+	assert.Equal(t, types.Slot(2), timelines[0].Slot)
+
+	var nilCache *SlotTimelineCache
+	nilCache.Record(1, BlockReceived, now)
+}
+
+func TestSlotTimelineCache_Prune(t *testing.T) {
+	c := NewSlotTimelineCache()
+	for i := types.Slot(0); i < 2*slotTimelineSize; i++ {
+		c.Record(i, BlockReceived, time.Now())
+	}
+	timelines := c.Timelines(-1)
+	require.Equal(t, slotTimelineSize, len(timelines))
+	assert.Equal(t, types.Slot(slotTimelineSize), timelines[0].Slot)
+
+	// Recording an old slot doesn't grow the cache.
+	c.Record(1, BlockReceived, time.Now())
+	assert.Equal(t, slotTimelineSize, len(c.Timelines(-1)))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/crypto/hash/htr"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
@@ -36,12 +37,28 @@ const executionToBLSPadding = 12
 //    )
 //
 func ProcessBLSToExecutionChange(st state.BeaconState, signed *ethpb.SignedBLSToExecutionChange) (state.BeaconState, error) {
+	val, err := ValidateBLSToExecutionChange(st, signed)
+	if err != nil {
+		return nil, err
+	}
+	message := signed.Message
+	newCredentials := make([]byte, executionToBLSPadding)
+	newCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	val.WithdrawalCredentials = append(newCredentials, message.ToExecutionAddress...)
+	err = st.UpdateValidatorAtIndex(message.ValidatorIndex, val)
+	return st, err
+}
+
+// ValidateBLSToExecutionChange checks that a SignedBLSToExecutionChange message can be applied to
+// the given state, verifying its signature, without changing the state. It returns a copy of the
+// validator whose withdrawal address the message changes.
+func ValidateBLSToExecutionChange(st state.ReadOnlyBeaconState, signed *ethpb.SignedBLSToExecutionChange) (*ethpb.Validator, error) {
 	if signed == nil {
-		return st, errNilSignedWithdrawalMessage
+		return nil, errNilSignedWithdrawalMessage
 	}
 	message := signed.Message
 	if message == nil {
-		return st, errNilWithdrawalMessage
+		return nil, errNilWithdrawalMessage
 	}
 
 	val, err := st.ValidatorAtIndex(message.ValidatorIndex)
@@ -55,6 +72,9 @@ func ProcessBLSToExecutionChange(st state.BeaconState, signed *ethpb.SignedBLSTo
 
 	// hash the public key and verify it matches the withdrawal credentials
 	fromPubkey := message.FromBlsPubkey
+	if len(fromPubkey) != fieldparams.BLSPubkeyLength {
+		return nil, errInvalidBLSPubkeyLength
+	}
 	pubkeyChunks := [][32]byte{bytesutil.ToBytes32(fromPubkey[:32]), bytesutil.ToBytes32(fromPubkey[32:])}
 	digest := make([][32]byte, 1)
 	htr.VectorizedSha256(pubkeyChunks, digest)
@@ -70,9 +90,5 @@ func ProcessBLSToExecutionChange(st state.BeaconState, signed *ethpb.SignedBLSTo
 	if err := signing.VerifySigningRoot(message, fromPubkey, signed.Signature, domain); err != nil {
 		return nil, signing.ErrSigFailedToVerify
 	}
-	newCredentials := make([]byte, executionToBLSPadding)
-	newCredentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
-	val.WithdrawalCredentials = append(newCredentials, message.ToExecutionAddress...)
-	err = st.UpdateValidatorAtIndex(message.ValidatorIndex, val)
-	return st, err
+	return val, nil
 }
@@ -26,6 +26,12 @@ const (
 	FinalizedCheckpoint
 	// NewHead of the chain event.
 	NewHead
+	// InvalidBlocksPruned is sent when blocks are removed from fork choice after the execution
+	// engine found an invalid payload in their ancestry.
+	InvalidBlocksPruned
+	// ChainStalled is sent when the head of the chain has not advanced for the configured number
+	// of slots.
+	ChainStalled
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -40,6 +46,16 @@ type BlockProcessedData struct {
 	Verified bool
 }
 
+// InvalidBlocksPrunedData is the data sent with InvalidBlocksPruned events.
+type InvalidBlocksPrunedData struct {
+	// BlockRoot of the block whose payload was found invalid.
+	BlockRoot [32]byte
+	// LastValidHash is the latest valid execution block hash in the ancestry of the block.
+	LastValidHash [32]byte
+	// InvalidRoots are the roots of all the blocks removed from fork choice.
+	InvalidRoots [][32]byte
+}
+
 // ChainStartedData is the data sent with ChainStarted events.
 type ChainStartedData struct {
 	// StartTime is the time at which the chain started.
@@ -59,3 +75,13 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents state.validators.HashTreeRoot().
 	GenesisValidatorsRoot []byte
 }
+
+// ChainStalledData is the data sent with ChainStalled events.
+type ChainStalledData struct {
+	// HeadSlot is the slot of the head that stopped advancing.
+	HeadSlot types.Slot
+	// CurrentSlot is the wall clock slot when the stall was detected.
+	CurrentSlot types.Slot
+	// BundlePath is the path of the diagnostics archive, empty if it could not be written.
+	BundlePath string
+}
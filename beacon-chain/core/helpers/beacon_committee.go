@@ -158,9 +158,38 @@ func CommitteeAssignments(
 	state state.BeaconState,
 	epoch types.Epoch,
 ) (map[types.ValidatorIndex]*CommitteeAssignmentContainer, map[types.ValidatorIndex][]types.Slot, error) {
+	nextEpoch := time.NextEpoch(state)
+	proposerIndexToSlots, err := ProposerAssignments(ctx, state, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If previous proposer indices computation is outside if current proposal epoch range,
+	// we need to reset state slot back to start slot so that we can compute the correct committees.
+	currentProposalEpoch := epoch < nextEpoch
+	if !currentProposalEpoch {
+		if err := state.SetSlot(state.Slot() - params.BeaconConfig().SlotsPerEpoch); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	validatorIndexToCommittee, err := AttesterAssignments(ctx, state, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return validatorIndexToCommittee, proposerIndexToSlots, nil
+}
+
+// ProposerAssignments is a map of validator indices pointing to the slots at which they propose
+// in the given epoch. The slot of the state is set to the last slot of the epoch.
+func ProposerAssignments(
+	ctx context.Context,
+	state state.BeaconState,
+	epoch types.Epoch,
+) (map[types.ValidatorIndex][]types.Slot, error) {
 	nextEpoch := time.NextEpoch(state)
 	if epoch > nextEpoch {
-		return nil, nil, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"epoch %d can't be greater than next epoch %d",
 			epoch,
 			nextEpoch,
@@ -172,7 +201,7 @@ func CommitteeAssignments(
 	// we use a map of proposer idx -> []slot to keep track of this possibility.
 	startSlot, err := slots.EpochStart(epoch)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	proposerIndexToSlots := make(map[types.ValidatorIndex][]types.Slot, params.BeaconConfig().SlotsPerEpoch)
 	for slot := startSlot; slot < startSlot+params.BeaconConfig().SlotsPerEpoch; slot++ {
@@ -181,27 +210,40 @@ func CommitteeAssignments(
 			continue
 		}
 		if err := state.SetSlot(slot); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		i, err := BeaconProposerIndex(ctx, state)
 		if err != nil {
-			return nil, nil, errors.Wrapf(err, "could not check proposer at slot %d", state.Slot())
+			return nil, errors.Wrapf(err, "could not check proposer at slot %d", state.Slot())
 		}
 		proposerIndexToSlots[i] = append(proposerIndexToSlots[i], slot)
 	}
+	return proposerIndexToSlots, nil
+}
 
-	// If previous proposer indices computation is outside if current proposal epoch range,
-	// we need to reset state slot back to start slot so that we can compute the correct committees.
-	currentProposalEpoch := epoch < nextEpoch
-	if !currentProposalEpoch {
-		if err := state.SetSlot(state.Slot() - params.BeaconConfig().SlotsPerEpoch); err != nil {
-			return nil, nil, err
-		}
+// AttesterAssignments is a map of validator indices pointing to the appropriate committee
+// assignment for the given epoch, without the proposer assignments.
+func AttesterAssignments(
+	ctx context.Context,
+	state state.ReadOnlyBeaconState,
+	epoch types.Epoch,
+) (map[types.ValidatorIndex]*CommitteeAssignmentContainer, error) {
+	nextEpoch := time.NextEpoch(state)
+	if epoch > nextEpoch {
+		return nil, fmt.Errorf(
+			"epoch %d can't be greater than next epoch %d",
+			epoch,
+			nextEpoch,
+		)
+	}
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
 	}
 
 	activeValidatorIndices, err := ActiveValidatorIndices(ctx, state, epoch)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	// Each slot in an epoch has a different set of committees. This value is derived from the
 	// active validator set, which does not change.
@@ -215,7 +257,7 @@ func CommitteeAssignments(
 			slot := startSlot + i
 			committee, err := BeaconCommitteeFromState(ctx, state, slot, types.CommitteeIndex(j) /*committee index*/)
 			if err != nil {
-				return nil, nil, err
+				return nil, err
 			}
 
 			cac := &CommitteeAssignmentContainer{
@@ -229,7 +271,7 @@ func CommitteeAssignments(
 		}
 	}
 
-	return validatorIndexToCommittee, proposerIndexToSlots, nil
+	return validatorIndexToCommittee, nil
 }
 
 // VerifyBitfieldLength verifies that a bitfield length matches the given committee size.
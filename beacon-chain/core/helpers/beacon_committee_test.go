@@ -235,6 +235,42 @@ func TestCommitteeAssignments_CannotRetrieveFuture(t *testing.T) {
 	require.NotEqual(t, 0, len(proposerIndxs), "wanted non-zero proposer index set")
 }
 
+func TestProposerAndAttesterAssignments_MatchCommitteeAssignments(t *testing.T) {
+	validators := make([]*ethpb.Validator, 4*params.BeaconConfig().SlotsPerEpoch)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	state, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators:  validators,
+		Slot:        2 * params.BeaconConfig().SlotsPerEpoch, // epoch 2
+		RandaoMixes: make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+	})
+	require.NoError(t, err)
+	ClearCache()
+
+	for _, epoch := range []types.Epoch{time.CurrentEpoch(state), time.NextEpoch(state)} {
+		committees, proposers, err := CommitteeAssignments(context.Background(), state.Copy(), epoch)
+		require.NoError(t, err)
+		attesters, err := AttesterAssignments(context.Background(), state, epoch)
+		require.NoError(t, err)
+		assert.DeepEqual(t, committees, attesters)
+		proposerState := state.Copy()
+		onlyProposers, err := ProposerAssignments(context.Background(), proposerState, epoch)
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposers, onlyProposers)
+		lastSlot, err := slots.EpochEnd(epoch)
+		require.NoError(t, err)
+		assert.Equal(t, lastSlot, proposerState.Slot())
+	}
+
+	_, err = AttesterAssignments(context.Background(), state, time.NextEpoch(state)+1)
+	assert.ErrorContains(t, "can't be greater than next epoch", err)
+	_, err = ProposerAssignments(context.Background(), state, time.NextEpoch(state)+1)
+	assert.ErrorContains(t, "can't be greater than next epoch", err)
+}
+
 func TestCommitteeAssignments_EverySlotHasMin1Proposer(t *testing.T) {
 	// Initialize test with 256 validators, each slot and each index gets 4 validators.
 	validators := make([]*ethpb.Validator, 4*params.BeaconConfig().SlotsPerEpoch)
@@ -64,7 +64,9 @@ func ExecuteStateTransition(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not execute state transition")
 	}
+	_, verifySpan := trace.StartSpan(ctx, "core.state.ExecuteStateTransition.VerifySignatures")
 	valid, err := set.Verify()
+	verifySpan.End()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not batch verify signature")
 	}
@@ -26,6 +26,12 @@ type Database = iface.Database
 // SlasherDatabase defines necessary methods for Prysm's slasher implementation.
 type SlasherDatabase = iface.SlasherDatabase
 
+// CheckpointChange records the time at which the justified or finalized checkpoint of the node changed.
+type CheckpointChange = iface.CheckpointChange
+
+// EpochBoundaryRoots holds the roots of the canonical block and state at the start slot of a finalized epoch.
+type EpochBoundaryRoots = iface.EpochBoundaryRoots
+
 // ErrExistingGenesisState is an error when the user attempts to save a different genesis state
 // when one already exists in a database.
 var ErrExistingGenesisState = iface.ErrExistingGenesisState
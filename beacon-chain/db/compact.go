@@ -0,0 +1,181 @@
+package db
+
+import (
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Maximum number of bytes of keys and values written to the compacted database in a transaction.
+const compactTxMaxSize = 64 << 20
+
+// Compact compacts the beacon node and slasher databases found in the data directory.
+func Compact(cliCtx *cli.Context) error {
+	dbDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	found := false
+	for _, dbFile := range []string{kv.DatabaseFileName, slasherkv.DatabaseFileName} {
+		dbPath := path.Join(dbDir, dbFile)
+		if !file.FileExists(dbPath) {
+			continue
+		}
+		found = true
+		log.WithField("path", dbPath).Info("Compacting database")
+		start := time.Now()
+		before, after, err := CompactDatabase(dbPath)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"path":   dbPath,
+			"before": humanBytes(before),
+			"after":  humanBytes(after),
+			"took":   time.Since(start),
+		}).Info("Compacted database")
+	}
+	if !found {
+		return errors.Errorf("no database found in %s", dbDir)
+	}
+	return nil
+}
+
+// CompactDatabase rewrites the bolt database at dbPath into a new file without the free pages left
+// by deleted data, then replaces the database with it. It returns the size of the database file
+// before and after compaction. The database must not be in use by another process.
+func CompactDatabase(dbPath string) (int64, int64, error) {
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "could not stat %s", dbPath)
+	}
+	src, err := bolt.Open(dbPath, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout:  1 * time.Second,
+		ReadOnly: true,
+	})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return 0, 0, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return 0, 0, errors.Wrapf(err, "could not open %s", dbPath)
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	compactedPath := dbPath + ".compact"
+	if err := os.RemoveAll(compactedPath); err != nil {
+		return 0, 0, errors.Wrapf(err, "could not remove %s", compactedPath)
+	}
+	dst, err := bolt.Open(compactedPath, info.Mode(), &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "could not create %s", compactedPath)
+	}
+	if err := compactBolt(dst, src, compactTxMaxSize); err != nil {
+		if closeErr := dst.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Could not close compacted database")
+		}
+		if rmErr := os.Remove(compactedPath); rmErr != nil {
+			log.WithError(rmErr).Error("Could not remove compacted database")
+		}
+		return 0, 0, errors.Wrap(err, "could not compact database")
+	}
+	if err := dst.Close(); err != nil {
+		return 0, 0, errors.Wrap(err, "could not close compacted database")
+	}
+	compactedInfo, err := os.Stat(compactedPath)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "could not stat %s", compactedPath)
+	}
+	if err := os.Rename(compactedPath, dbPath); err != nil {
+		return 0, 0, errors.Wrapf(err, "could not replace %s with the compacted database", dbPath)
+	}
+	return info.Size(), compactedInfo.Size(), nil
+}
+
+// compactBolt copies every bucket of src into the empty database dst, committing a transaction every
+// txMaxSize bytes of keys and values. Pages are filled completely, as the copy writes keys in order.
+func compactBolt(dst, src *bolt.DB, txMaxSize int) error {
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// The transaction is already closed once committed.
+		if err := tx.Rollback(); err != nil && !errors.Is(err, bolt.ErrTxClosed) {
+			log.WithError(err).Error("Could not roll back transaction")
+		}
+	}()
+
+	var size int
+	// Put copies a key and value into the current transaction, which is committed and replaced
+	// once it grows too large. A nil value creates a nested bucket.
+	put := func(bucketPath [][]byte, k, v []byte, seq uint64) error {
+		if size+len(k)+len(v) > txMaxSize {
+			if err := tx.Commit(); err != nil {
+				return err
+			}
+			tx, err = dst.Begin(true)
+			if err != nil {
+				return err
+			}
+			size = 0
+		}
+		size += len(k) + len(v)
+
+		if len(bucketPath) == 0 {
+			b, err := tx.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+			return b.SetSequence(seq)
+		}
+		b := tx.Bucket(bucketPath[0])
+		for _, name := range bucketPath[1:] {
+			b = b.Bucket(name)
+		}
+		b.FillPercent = 1.0
+		if v == nil {
+			nested, err := b.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+			return nested.SetSequence(seq)
+		}
+		return b.Put(k, v)
+	}
+
+	if err := src.View(func(srcTx *bolt.Tx) error {
+		return srcTx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if err := put(nil, name, nil, b.Sequence()); err != nil {
+				return err
+			}
+			return copyBucket(b, [][]byte{name}, put)
+		})
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func copyBucket(b *bolt.Bucket, bucketPath [][]byte, put func([][]byte, []byte, []byte, uint64) error) error {
+	return b.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return put(bucketPath, k, v, 0)
+		}
+		nested := b.Bucket(k)
+		if err := put(bucketPath, k, nil, nested.Sequence()); err != nil {
+			return err
+		}
+		return copyBucket(nested, append(append([][]byte{}, bucketPath...), k), put)
+	})
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestCompactDatabase(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "test.db")
+	boltDB, err := bolt.Open(dbPath, params.BeaconIoConfig().ReadWritePermissions, nil)
+	require.NoError(t, err)
+	value := make([]byte, 1024)
+	require.NoError(t, boltDB.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("top"))
+		if err != nil {
+			return err
+		}
+		if err := b.SetSequence(7); err != nil {
+			return err
+		}
+		nested, err := b.CreateBucket([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		if err := nested.SetSequence(3); err != nil {
+			return err
+		}
+		for i := 0; i < 1000; i++ {
+			if err := b.Put([]byte(fmt.Sprintf("key%04d", i)), value); err != nil {
+				return err
+			}
+			if err := nested.Put([]byte(fmt.Sprintf("key%04d", i)), []byte{byte(i)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	// Deleting most of the data leaves free pages in the file.
+	require.NoError(t, boltDB.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("top"))
+		for i := 10; i < 1000; i++ {
+			if err := b.Delete([]byte(fmt.Sprintf("key%04d", i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	require.NoError(t, boltDB.Close())
+
+	before, after, err := CompactDatabase(dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, true, after < before, "Database did not shrink: %d -> %d", before, after)
+
+	boltDB, err = bolt.Open(dbPath, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{ReadOnly: true})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, boltDB.Close())
+	}()
+	require.NoError(t, boltDB.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("top"))
+		require.NotNil(t, b)
+		assert.Equal(t, uint64(7), b.Sequence())
+		keys := 0
+		require.NoError(t, b.ForEach(func(_, _ []byte) error {
+			keys++
+			return nil
+		}))
+		// The remaining values and the nested bucket.
+		assert.Equal(t, 11, keys)
+		assert.DeepEqual(t, value, b.Get([]byte("key0009")))
+		assert.Equal(t, true, b.Get([]byte("key0010")) == nil)
+
+		nested := b.Bucket([]byte("nested"))
+		require.NotNil(t, nested)
+		assert.Equal(t, uint64(3), nested.Sequence())
+		assert.Equal(t, 1000, nested.Stats().KeyN)
+		assert.DeepEqual(t, []byte{byte(999 % 256)}, nested.Get([]byte("key0999")))
+		return nil
+	}))
+}
+
+func TestCompactBolt_SplitsTransactions(t *testing.T) {
+	dir := t.TempDir()
+	src, err := bolt.Open(path.Join(dir, "src.db"), params.BeaconIoConfig().ReadWritePermissions, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, src.Close())
+	}()
+	require.NoError(t, src.Update(func(tx *bolt.Tx) error {
+		for _, name := range []string{"a", "b"} {
+			b, err := tx.CreateBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+			for i := 0; i < 100; i++ {
+				if err := b.Put([]byte{byte(i)}, []byte(name)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}))
+	dst, err := bolt.Open(path.Join(dir, "dst.db"), params.BeaconIoConfig().ReadWritePermissions, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, dst.Close())
+	}()
+
+	// Every transaction holds a few keys only.
+	require.NoError(t, compactBolt(dst, src, 8))
+	require.NoError(t, dst.View(func(tx *bolt.Tx) error {
+		for _, name := range []string{"a", "b"} {
+			b := tx.Bucket([]byte(name))
+			require.NotNil(t, b)
+			assert.Equal(t, 100, b.Stats().KeyN)
+			assert.DeepEqual(t, []byte(name), b.Get([]byte{99}))
+		}
+		return nil
+	}))
+}
+
+func TestCompactDatabase_MissingDatabase(t *testing.T) {
+	_, _, err := CompactDatabase(path.Join(t.TempDir(), "test.db"))
+	require.ErrorContains(t, "could not stat", err)
+}
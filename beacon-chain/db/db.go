@@ -9,8 +9,8 @@ import (
 )
 
 // NewDB initializes a new DB.
-func NewDB(ctx context.Context, dirPath string) (Database, error) {
-	return kv.NewKVStore(ctx, dirPath)
+func NewDB(ctx context.Context, dirPath string, opts ...kv.KVStoreOption) (Database, error) {
+	return kv.NewKVStore(ctx, dirPath, opts...)
 }
 
 // NewDBFilename uses the KVStoreDatafilePath so that if this layer of
@@ -0,0 +1,37 @@
+package iface
+
+import (
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+)
+
+// CheckpointKind tells which of the checkpoints of the node a CheckpointChange is about.
+type CheckpointKind uint8
+
+const (
+	// JustifiedCheckpointKind is the kind of changes of the justified checkpoint.
+	JustifiedCheckpointKind CheckpointKind = iota
+	// FinalizedCheckpointKind is the kind of changes of the finalized checkpoint.
+	FinalizedCheckpointKind
+)
+
+// String returns the name of the checkpoint kind.
+func (k CheckpointKind) String() string {
+	switch k {
+	case JustifiedCheckpointKind:
+		return "justified"
+	case FinalizedCheckpointKind:
+		return "finalized"
+	default:
+		return "unknown"
+	}
+}
+
+// CheckpointChange records the time at which the justified or finalized checkpoint of the node changed.
+type CheckpointChange struct {
+	Kind  CheckpointKind
+	Epoch types.Epoch
+	Root  [32]byte
+	Time  time.Time
+}
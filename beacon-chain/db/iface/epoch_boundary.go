@@ -0,0 +1,14 @@
+package iface
+
+import (
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+)
+
+// EpochBoundaryRoots holds the roots of the canonical block and state at the start slot of a
+// finalized epoch. When the start slot is skipped, the block root is the one of the last block
+// before it.
+type EpochBoundaryRoots struct {
+	Epoch     types.Epoch
+	BlockRoot [32]byte
+	StateRoot [32]byte
+}
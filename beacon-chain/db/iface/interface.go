@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/backup"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 )
@@ -47,16 +48,24 @@ type ReadOnlyDatabase interface {
 	LastArchivedRoot(ctx context.Context) [32]byte
 	LastArchivedSlot(ctx context.Context) (types.Slot, error)
 	LastValidatedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	CheckpointHistory(ctx context.Context, limit int) ([]*CheckpointChange, error)
+	EpochBoundaryRoots(ctx context.Context, epoch types.Epoch) (*EpochBoundaryRoots, error)
 	// Deposit contract related handlers.
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// ExecutionChainData operations.
 	ExecutionChainData(ctx context.Context) (*ethpb.ETH1ChainData, error)
+	DepositSnapshot(ctx context.Context) (*trie.DepositTreeSnapshot, error)
+	DepositContainers(ctx context.Context, start, end int64) ([]*ethpb.DepositContainer, error)
+	DepositContainerByPubkey(ctx context.Context, pubKey []byte) (*ethpb.DepositContainer, error)
+	DepositContainerIndexRange(ctx context.Context) (int64, int64, error)
 	// Fee reicipients operations.
 	FeeRecipientByValidatorID(ctx context.Context, id types.ValidatorIndex) (common.Address, error)
 	RegistrationByValidatorID(ctx context.Context, id types.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// Known peers operations.
+	KnownPeers(ctx context.Context) ([]*KnownPeer, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -67,6 +76,7 @@ type NoHeadAccessDatabase interface {
 	DeleteBlock(ctx context.Context, root [32]byte) error
 	SaveBlock(ctx context.Context, block interfaces.SignedBeaconBlock) error
 	SaveBlocks(ctx context.Context, blocks []interfaces.SignedBeaconBlock) error
+	ReplaceBlocks(ctx context.Context, blocks []interfaces.SignedBeaconBlock) error
 	SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error
 	// State related methods.
 	SaveState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
@@ -79,15 +89,20 @@ type NoHeadAccessDatabase interface {
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveLastValidatedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
+	SaveEpochBoundaryRoots(ctx context.Context, roots []*EpochBoundaryRoots) error
 	// Deposit contract related handlers.
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// SaveExecutionChainData operations.
 	SaveExecutionChainData(ctx context.Context, data *ethpb.ETH1ChainData) error
+	SaveDepositSnapshot(ctx context.Context, snapshot *trie.DepositTreeSnapshot) error
+	SaveDepositContainers(ctx context.Context, ctrs []*ethpb.DepositContainer) error
 	// Run any required database migrations.
 	RunMigrations(ctx context.Context) error
 	// Fee reicipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Known peers operations.
+	SaveKnownPeers(ctx context.Context, peers []*KnownPeer) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint types.Slot) error
 }
@@ -108,6 +123,9 @@ type HeadAccessDatabase interface {
 	// initialization method needed for origin checkpoint sync
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error
+
+	// SyncPoint flushes the database writes to disk, if they are not flushed on commit.
+	SyncPoint(reason string) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
@@ -164,6 +182,7 @@ type Database interface {
 	backup.BackupExporter
 	HeadAccessDatabase
 
+	Flush(ctx context.Context) error
 	DatabasePath() string
 	ClearDB() error
 }
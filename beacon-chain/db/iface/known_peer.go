@@ -0,0 +1,17 @@
+package iface
+
+import "time"
+
+// KnownPeer is a peer the node was connected to with a good score, saved to be dialed again after
+// a restart.
+type KnownPeer struct {
+	// ID of the libp2p peer.
+	ID string
+	// Address is the multiaddress the peer was connected on, including its peer ID.
+	Address string
+	// ENR of the peer, empty if unknown.
+	ENR string
+	// Score of the peer when it was last seen.
+	Score    float64
+	LastSeen time.Time
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/urfave/cli/v2"
+)
+
+// Maximum number of entries printed for every kind of inconsistency.
+const maxReportedEntries = 10
+
+// Inspect prints the space used by every bucket of the beacon node database found in the data
+// directory, then verifies the invariants linking its buckets and reports the inconsistencies.
+// It returns an error if any inconsistency is found.
+func Inspect(cliCtx *cli.Context) error {
+	dbDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	dbPath := path.Join(dbDir, kv.DatabaseFileName)
+	if !file.FileExists(dbPath) {
+		return errors.Errorf("no database found in %s", dbDir)
+	}
+	stats, err := DatabaseStats(dbPath)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not stat %s", dbPath)
+	}
+	if err := writeStats(os.Stdout, dbPath, info.Size(), stats); err != nil {
+		return err
+	}
+
+	store, err := kv.NewKVStore(cliCtx.Context, dbDir)
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", dbPath)
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	report, err := store.CheckIntegrity(cliCtx.Context, cliCtx.Bool(cmd.VerifyStateRootsFlag.Name))
+	if err != nil {
+		return err
+	}
+	if err := writeIntegrityReport(os.Stdout, report); err != nil {
+		return err
+	}
+	if !report.Healthy() {
+		return errors.New("database is inconsistent")
+	}
+	return nil
+}
+
+func writeIntegrityReport(w io.Writer, r *kv.IntegrityReport) error {
+	if _, err := fmt.Fprintf(w, "checked %d blocks, %d states, %d state summaries and %d finalized blocks\n",
+		r.Blocks, r.States, r.StateSummaries, r.FinalizedBlocks); err != nil {
+		return err
+	}
+	for _, orphaned := range []struct {
+		name  string
+		roots [][32]byte
+	}{
+		{"states without block", r.OrphanedStates},
+		{"state summaries without block", r.OrphanedStateSummaries},
+		{"slot index entries without block", r.OrphanedSlotIndices},
+		{"parent root index entries without block", r.OrphanedParentIndices},
+		{"states not matching the state root of their block", r.StateRootMismatches},
+	} {
+		if len(orphaned.roots) == 0 {
+			continue
+		}
+		entries := make([]string, 0, len(orphaned.roots))
+		for _, root := range orphaned.roots {
+			entries = append(entries, fmt.Sprintf("%#x", root))
+		}
+		if err := writeReportEntries(w, orphaned.name, entries); err != nil {
+			return err
+		}
+	}
+	if r.MisalignedIndices > 0 {
+		if _, err := fmt.Fprintf(w, "%d block index entries with a misaligned root list\n", r.MisalignedIndices); err != nil {
+			return err
+		}
+	}
+	if err := writeReportEntries(w, "finalized chain errors", r.FinalizedChainErrors); err != nil {
+		return err
+	}
+	if r.Healthy() {
+		_, err := fmt.Fprintln(w, "no inconsistency found")
+		return err
+	}
+	return nil
+}
+
+func writeReportEntries(w io.Writer, name string, entries []string) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "%d %s:\n", len(entries), name); err != nil {
+		return err
+	}
+	for i, e := range entries {
+		if i == maxReportedEntries {
+			_, err := fmt.Fprintf(w, "  ... and %d more\n", len(entries)-maxReportedEntries)
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "  %s\n", e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
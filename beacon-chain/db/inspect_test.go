@@ -0,0 +1,33 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestWriteIntegrityReport(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeIntegrityReport(buf, &kv.IntegrityReport{Blocks: 3}))
+	assert.Equal(t, true, strings.Contains(buf.String(), "checked 3 blocks"))
+	assert.Equal(t, true, strings.Contains(buf.String(), "no inconsistency found"))
+
+	report := &kv.IntegrityReport{OrphanedStates: [][32]byte{{'a'}}, MisalignedIndices: 2}
+	for i := 0; i < maxReportedEntries+5; i++ {
+		report.FinalizedChainErrors = append(report.FinalizedChainErrors, fmt.Sprintf("error %d", i))
+	}
+	buf.Reset()
+	require.NoError(t, writeIntegrityReport(buf, report))
+	out := buf.String()
+	assert.Equal(t, true, strings.Contains(out, "1 states without block:\n  0x61"))
+	assert.Equal(t, true, strings.Contains(out, "2 block index entries with a misaligned root list"))
+	assert.Equal(t, true, strings.Contains(out, "15 finalized chain errors:"))
+	assert.Equal(t, true, strings.Contains(out, "error 9\n  ... and 5 more\n"))
+	assert.Equal(t, false, strings.Contains(out, "error 10"))
+	assert.Equal(t, false, strings.Contains(out, "no inconsistency found"))
+}
@@ -276,7 +276,19 @@ func (s *Store) SaveBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 func (s *Store) SaveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBlock) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBlocks")
 	defer span.End()
+	return s.saveBlocks(ctx, blks, false /* overwrite */)
+}
+
+// ReplaceBlocks saves the blocks to the db, overwriting the blocks already stored under the same
+// roots. It is meant to repair blocks whose stored encoding is corrupted, including finalized
+// blocks which can't be deleted.
+func (s *Store) ReplaceBlocks(ctx context.Context, blks []interfaces.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ReplaceBlocks")
+	defer span.End()
+	return s.saveBlocks(ctx, blks, true /* overwrite */)
+}
 
+func (s *Store) saveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBlock, overwrite bool) error {
 	// Performing marshaling, hashing, and indexing outside the bolt transaction
 	// to minimize the time we hold the DB lock.
 	blockRoots := make([][]byte, len(blks))
@@ -299,7 +311,7 @@ func (s *Store) SaveBlocks(ctx context.Context, blks []interfaces.SignedBeaconBl
 	return s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(blocksBucket)
 		for i, blk := range blks {
-			if existingBlock := bkt.Get(blockRoots[i]); existingBlock != nil {
+			if existingBlock := bkt.Get(blockRoots[i]); existingBlock != nil && !overwrite {
 				continue
 			}
 			if err := updateValueForIndices(ctx, indicesForBlocks[i], blockRoots[i], tx); err != nil {
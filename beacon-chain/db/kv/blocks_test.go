@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	bolt "go.etcd.io/bbolt"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -291,6 +292,42 @@ func TestStore_DeleteFinalizedBlock(t *testing.T) {
 	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, cp))
 	require.ErrorIs(t, db.DeleteBlock(ctx, root), ErrDeleteJustifiedAndFinalized)
 }
+func TestStore_ReplaceBlocks(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	blks := makeBlocks(t, 0, 4, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	root, err := blks[2].Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	// Corrupt the stored encoding of the block.
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blocksBucket).Put(root[:], []byte("corrupted"))
+	}))
+	db.blockCache.Del(string(root[:]))
+	_, err = db.Block(ctx, root)
+	require.ErrorContains(t, "could not snappy decode block", err)
+
+	// Saving the block again does not repair it.
+	require.NoError(t, db.SaveBlocks(ctx, blks[2:3]))
+	db.blockCache.Del(string(root[:]))
+	_, err = db.Block(ctx, root)
+	require.NotNil(t, err)
+
+	require.NoError(t, db.ReplaceBlocks(ctx, blks[2:3]))
+	db.blockCache.Del(string(root[:]))
+	blk, err := db.Block(ctx, root)
+	require.NoError(t, err)
+	wanted, err := blks[2].Proto()
+	require.NoError(t, err)
+	got, err := blk.Proto()
+	require.NoError(t, err)
+	assert.DeepEqual(t, wanted, got)
+	_, roots, err := db.BlockRootsBySlot(ctx, blks[2].Block().Slot())
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{root}, roots)
+}
+
 func TestStore_GenesisBlock(t *testing.T) {
 	db := setupDB(t)
 	ctx := context.Background()
@@ -1,13 +1,16 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
 	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
@@ -69,6 +72,11 @@ func (s *Store) SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.C
 				return errors.Wrapf(errMissingStateForCheckpoint, "could not save justified checkpoint, finalized root: %#x", bytesutil.Trunc(checkpoint.Root))
 			}
 		}
+		if !bytes.Equal(bucket.Get(justifiedCheckpointKey), enc) {
+			if err := recordCheckpointChange(tx, iface.JustifiedCheckpointKind, checkpoint, prysmTime.Now()); err != nil {
+				return errors.Wrap(err, "could not record checkpoint change")
+			}
+		}
 		return bucket.Put(justifiedCheckpointKey, enc)
 	})
 }
@@ -92,6 +100,11 @@ func (s *Store) SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.C
 				return errors.Wrapf(errMissingStateForCheckpoint, "could not save finalized checkpoint, finalized root: %#x", bytesutil.Trunc(checkpoint.Root))
 			}
 		}
+		if !bytes.Equal(bucket.Get(finalizedCheckpointKey), enc) {
+			if err := recordCheckpointChange(tx, iface.FinalizedCheckpointKind, checkpoint, prysmTime.Now()); err != nil {
+				return errors.Wrap(err, "could not record checkpoint change")
+			}
+		}
 		if err := bucket.Put(finalizedCheckpointKey, enc); err != nil {
 			return err
 		}
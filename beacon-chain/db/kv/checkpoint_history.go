@@ -0,0 +1,77 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// A checkpoint change is stored as kind (1 byte), epoch (8 bytes), root (32 bytes) and the unix
+// time of the change in nanoseconds (8 bytes), under the big endian sequence number of the change.
+const checkpointChangeLength = 1 + 8 + 32 + 8
+
+// CheckpointHistory returns the last `limit` changes of the justified and finalized checkpoints
+// saved by the node, oldest first. Changes are recorded with the time they were saved, which makes
+// it possible to look back at how long finality took after the fact.
+func (s *Store) CheckpointHistory(ctx context.Context, limit int) ([]*iface.CheckpointChange, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.CheckpointHistory")
+	defer span.End()
+
+	changes := make([]*iface.CheckpointChange, 0)
+	if limit <= 0 {
+		return changes, nil
+	}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(checkpointHistoryBucket).Cursor()
+		for k, v := c.Last(); k != nil && len(changes) < limit; k, v = c.Prev() {
+			change, err := decodeCheckpointChange(v)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes, nil
+}
+
+// Appends the change of the checkpoint of the given kind to the checkpoint history.
+func recordCheckpointChange(tx *bolt.Tx, kind iface.CheckpointKind, checkpoint *ethpb.Checkpoint, t time.Time) error {
+	bkt := tx.Bucket(checkpointHistoryBucket)
+	seq, err := bkt.NextSequence()
+	if err != nil {
+		return err
+	}
+	enc := make([]byte, checkpointChangeLength)
+	enc[0] = byte(kind)
+	binary.BigEndian.PutUint64(enc[1:9], uint64(checkpoint.Epoch))
+	copy(enc[9:41], checkpoint.Root)
+	binary.BigEndian.PutUint64(enc[41:], uint64(t.UnixNano()))
+	return bkt.Put(bytesutil.Uint64ToBytesBigEndian(seq), enc)
+}
+
+func decodeCheckpointChange(enc []byte) (*iface.CheckpointChange, error) {
+	if len(enc) != checkpointChangeLength {
+		return nil, fmt.Errorf("invalid checkpoint change length %d, expected %d", len(enc), checkpointChangeLength)
+	}
+	return &iface.CheckpointChange{
+		Kind:  iface.CheckpointKind(enc[0]),
+		Epoch: types.Epoch(binary.BigEndian.Uint64(enc[1:9])),
+		Root:  bytesutil.ToBytes32(enc[9:41]),
+		Time:  time.Unix(0, int64(binary.BigEndian.Uint64(enc[41:]))),
+	}, nil
+}
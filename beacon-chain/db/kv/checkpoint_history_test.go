@@ -0,0 +1,80 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestStore_CheckpointHistory(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	changes, err := db.CheckpointHistory(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changes))
+
+	// A valid chain is required to save the finalized checkpoint.
+	genesis := bytesutil.ToBytes32([]byte{'G', 'E', 'N', 'E', 'S', 'I', 'S'})
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesis))
+	roots := make([][32]byte, 3)
+	parent := genesis
+	for i := range roots {
+		blk := util.NewBeaconBlock()
+		blk.Block.ParentRoot = parent[:]
+		blk.Block.Slot = types.Slot(i+1) * params.BeaconConfig().SlotsPerEpoch
+		wsb, err := blocks.NewSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		roots[i], err = blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blk.Block.Slot))
+		require.NoError(t, db.SaveState(ctx, st, roots[i]))
+		parent = roots[i]
+	}
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[0][:]}))
+	// Saving the same checkpoint again is not a change.
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[0][:]}))
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: roots[1][:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[0][:]}))
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[2][:]}))
+
+	changes, err = db.CheckpointHistory(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(changes))
+	wanted := []struct {
+		kind  iface.CheckpointKind
+		epoch types.Epoch
+		root  [32]byte
+	}{
+		{iface.JustifiedCheckpointKind, 1, roots[0]},
+		{iface.JustifiedCheckpointKind, 2, roots[1]},
+		{iface.FinalizedCheckpointKind, 1, roots[0]},
+		{iface.JustifiedCheckpointKind, 3, roots[2]},
+	}
+	for i, w := range wanted {
+		assert.Equal(t, w.kind, changes[i].Kind)
+		assert.Equal(t, w.epoch, changes[i].Epoch)
+		assert.Equal(t, w.root, changes[i].Root)
+		if i > 0 {
+			assert.Equal(t, false, changes[i].Time.Before(changes[i-1].Time))
+		}
+	}
+
+	changes, err = db.CheckpointHistory(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(changes))
+	assert.Equal(t, iface.FinalizedCheckpointKind, changes[0].Kind)
+	assert.Equal(t, types.Epoch(3), changes[1].Epoch)
+}
@@ -0,0 +1,117 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveDepositContainers saves finalized deposit containers, keyed by their deposit index.
+func (s *Store) SaveDepositContainers(ctx context.Context, ctrs []*ethpb.DepositContainer) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveDepositContainers")
+	defer span.End()
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(depositContainersBucket)
+		pubkeyBkt := tx.Bucket(depositContainerPubkeyIndicesBucket)
+		for _, c := range ctrs {
+			if c == nil || c.Deposit == nil || c.Deposit.Data == nil {
+				return errors.New("cannot save nil deposit container")
+			}
+			if c.Index < 0 {
+				return errors.Errorf("invalid deposit index %d", c.Index)
+			}
+			enc, err := encode(ctx, c)
+			if err != nil {
+				return err
+			}
+			key := bytesutil.Uint64ToBytesBigEndian(uint64(c.Index))
+			if err := bkt.Put(key, enc); err != nil {
+				return err
+			}
+			// Only the first deposit of a public key is indexed.
+			if pubkeyBkt.Get(c.Deposit.Data.PublicKey) == nil {
+				if err := pubkeyBkt.Put(c.Deposit.Data.PublicKey, key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	tracing.AnnotateError(span, err)
+	return err
+}
+
+// DepositContainers retrieves the saved deposit containers with an index in [start, end).
+func (s *Store) DepositContainers(ctx context.Context, start, end int64) ([]*ethpb.DepositContainer, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DepositContainers")
+	defer span.End()
+
+	var ctrs []*ethpb.DepositContainer
+	if start < 0 || end <= start {
+		return ctrs, nil
+	}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(depositContainersBucket).Cursor()
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(uint64(start))); k != nil; k, v = c.Next() {
+			if bytesutil.BytesToUint64BigEndian(k) >= uint64(end) {
+				break
+			}
+			ctr := &ethpb.DepositContainer{}
+			if err := decode(ctx, v, ctr); err != nil {
+				return err
+			}
+			ctrs = append(ctrs, ctr)
+		}
+		return nil
+	})
+	return ctrs, err
+}
+
+// DepositContainerByPubkey retrieves the first saved deposit container of the public key, or nil
+// if none was saved.
+func (s *Store) DepositContainerByPubkey(ctx context.Context, pubKey []byte) (*ethpb.DepositContainer, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DepositContainerByPubkey")
+	defer span.End()
+
+	var ctr *ethpb.DepositContainer
+	err := s.db.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket(depositContainerPubkeyIndicesBucket).Get(pubKey)
+		if key == nil {
+			return nil
+		}
+		enc := tx.Bucket(depositContainersBucket).Get(key)
+		if enc == nil {
+			return errors.Errorf("missing deposit container with index %d", bytesutil.BytesToUint64BigEndian(key))
+		}
+		ctr = &ethpb.DepositContainer{}
+		return decode(ctx, enc, ctr)
+	})
+	return ctr, err
+}
+
+// DepositContainerIndexRange retrieves the indices of the first and last saved deposit containers.
+// The last index is lower than the first one when no deposit container was saved.
+func (s *Store) DepositContainerIndexRange(ctx context.Context) (int64, int64, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DepositContainerIndexRange")
+	defer span.End()
+
+	first, last := int64(0), int64(-1)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(depositContainersBucket).Cursor()
+		k, _ := c.First()
+		if k == nil {
+			return nil
+		}
+		first = int64(bytesutil.BytesToUint64BigEndian(k))
+		k, _ = c.Last()
+		last = int64(bytesutil.BytesToUint64BigEndian(k))
+		return nil
+	})
+	return first, last, err
+}
@@ -0,0 +1,61 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestStore_DepositContainers(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	first, last, err := db.DepositContainerIndexRange(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, last < first)
+
+	ctrs := make([]*ethpb.DepositContainer, 6)
+	for i := range ctrs {
+		ctrs[i] = &ethpb.DepositContainer{
+			Deposit: &ethpb.Deposit{Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i % 4)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+				Amount:                uint64(i),
+			}},
+			Eth1BlockHeight: uint64(100 + i),
+			DepositRoot:     bytesutil.PadTo([]byte{byte(i)}, 32),
+			Index:           int64(i + 2),
+		}
+	}
+	require.NoError(t, db.SaveDepositContainers(ctx, ctrs[:3]))
+	require.NoError(t, db.SaveDepositContainers(ctx, ctrs[3:]))
+	require.ErrorContains(t, "cannot save nil deposit container", db.SaveDepositContainers(ctx, []*ethpb.DepositContainer{{}}))
+
+	first, last, err = db.DepositContainerIndexRange(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), first)
+	assert.Equal(t, int64(7), last)
+
+	got, err := db.DepositContainers(ctx, 3, 6)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	for i, c := range got {
+		assert.DeepEqual(t, ctrs[i+1], c)
+	}
+	got, err = db.DepositContainers(ctx, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 6, len(got))
+
+	// The first deposit of a public key is returned.
+	c, err := db.DepositContainerByPubkey(ctx, bytesutil.PadTo([]byte{1}, 48))
+	require.NoError(t, err)
+	assert.DeepEqual(t, ctrs[1], c)
+	c, err = db.DepositContainerByPubkey(ctx, bytesutil.PadTo([]byte{9}, 48))
+	require.NoError(t, err)
+	assert.Equal(t, true, c == nil)
+}
@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// EpochBoundaryRoots returns the indexed block and state roots at the start of the given finalized
+// epoch, or nil if the epoch is not indexed.
+func (s *Store) EpochBoundaryRoots(ctx context.Context, epoch types.Epoch) (*iface.EpochBoundaryRoots, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.EpochBoundaryRoots")
+	defer span.End()
+
+	var roots *iface.EpochBoundaryRoots
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(epochBoundaryRootsBucket).Get(bytesutil.Uint64ToBytesBigEndian(uint64(epoch)))
+		if enc == nil {
+			return nil
+		}
+		if len(enc) != 64 {
+			return fmt.Errorf("invalid epoch boundary roots length %d, expected 64", len(enc))
+		}
+		roots = &iface.EpochBoundaryRoots{
+			Epoch:     epoch,
+			BlockRoot: bytesutil.ToBytes32(enc[:32]),
+			StateRoot: bytesutil.ToBytes32(enc[32:]),
+		}
+		return nil
+	})
+	return roots, err
+}
+
+// SaveEpochBoundaryRoots indexes the block and state roots at the start of finalized epochs.
+func (s *Store) SaveEpochBoundaryRoots(ctx context.Context, roots []*iface.EpochBoundaryRoots) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveEpochBoundaryRoots")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(epochBoundaryRootsBucket)
+		for _, r := range roots {
+			enc := make([]byte, 0, 64)
+			enc = append(enc, r.BlockRoot[:]...)
+			enc = append(enc, r.StateRoot[:]...)
+			if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(uint64(r.Epoch)), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,33 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestStore_EpochBoundaryRoots(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	roots, err := db.EpochBoundaryRoots(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, true, roots == nil)
+
+	saved := []*iface.EpochBoundaryRoots{
+		{Epoch: 1, BlockRoot: [32]byte{'a'}, StateRoot: [32]byte{'b'}},
+		{Epoch: 2, BlockRoot: [32]byte{'c'}, StateRoot: [32]byte{'d'}},
+	}
+	require.NoError(t, db.SaveEpochBoundaryRoots(ctx, saved))
+	for _, want := range saved {
+		roots, err = db.EpochBoundaryRoots(ctx, want.Epoch)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, roots)
+	}
+	roots, err = db.EpochBoundaryRoots(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, true, roots == nil)
+}
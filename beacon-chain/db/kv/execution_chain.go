@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
 	v2 "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	bolt "go.etcd.io/bbolt"
@@ -22,12 +23,17 @@ func (s *Store) SaveExecutionChainData(ctx context.Context, data *v2.ETH1ChainDa
 		return err
 	}
 
-	err := s.db.Update(func(tx *bolt.Tx) error {
+	enc, err := proto.Marshal(data)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	if s.syncPolicy == SyncPerSlot {
+		s.groupedWrites.setExecutionChainData(enc)
+		return nil
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(powchainBucket)
-		enc, err := proto.Marshal(data)
-		if err != nil {
-			return err
-		}
 		return bkt.Put(powchainDataKey, enc)
 	})
 	tracing.AnnotateError(span, err)
@@ -40,6 +46,10 @@ func (s *Store) ExecutionChainData(ctx context.Context) (*v2.ETH1ChainData, erro
 	defer span.End()
 
 	var data *v2.ETH1ChainData
+	if enc := s.groupedWrites.getExecutionChainData(); enc != nil {
+		data = &v2.ETH1ChainData{}
+		return data, proto.Unmarshal(enc, data)
+	}
 	err := s.db.View(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(powchainBucket)
 		enc := bkt.Get(powchainDataKey)
@@ -51,3 +61,42 @@ func (s *Store) ExecutionChainData(ctx context.Context) (*v2.ETH1ChainData, erro
 	})
 	return data, err
 }
+
+// SaveDepositSnapshot saves the EIP-4881 snapshot of the finalized deposit tree.
+func (s *Store) SaveDepositSnapshot(ctx context.Context, snapshot *trie.DepositTreeSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveDepositSnapshot")
+	defer span.End()
+
+	if snapshot == nil {
+		err := errors.New("cannot save nil deposit snapshot")
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	enc, err := snapshot.MarshalSSZ()
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(powchainBucket).Put(depositSnapshotKey, enc)
+	})
+	tracing.AnnotateError(span, err)
+	return err
+}
+
+// DepositSnapshot retrieves the EIP-4881 snapshot of the finalized deposit tree, or nil if none was saved.
+func (s *Store) DepositSnapshot(ctx context.Context) (*trie.DepositTreeSnapshot, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DepositSnapshot")
+	defer span.End()
+
+	var snapshot *trie.DepositTreeSnapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(powchainBucket).Get(depositSnapshotKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		snapshot = &trie.DepositTreeSnapshot{}
+		return snapshot.UnmarshalSSZ(enc)
+	})
+	return snapshot, err
+}
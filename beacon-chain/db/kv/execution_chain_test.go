@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	v2 "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
 )
 
 func TestStore_SavePowchainData(t *testing.T) {
@@ -33,3 +36,25 @@ func TestStore_SavePowchainData(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_DepositSnapshot(t *testing.T) {
+	store := setupDB(t)
+	ctx := context.Background()
+
+	snapshot, err := store.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, snapshot == nil)
+	require.ErrorContains(t, "cannot save nil deposit snapshot", store.SaveDepositSnapshot(ctx, nil))
+
+	want := &trie.DepositTreeSnapshot{
+		Finalized:            [][32]byte{{'a'}, {'b'}},
+		DepositRoot:          [32]byte{'r'},
+		DepositCount:         3,
+		ExecutionBlockHash:   [32]byte{'h'},
+		ExecutionBlockHeight: 100,
+	}
+	require.NoError(t, store.SaveDepositSnapshot(ctx, want))
+	snapshot, err = store.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, snapshot)
+}
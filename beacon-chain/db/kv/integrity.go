@@ -0,0 +1,219 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// IntegrityReport lists the inconsistencies found between the buckets of the database. Orphaned
+// entries reference a block which is not in the database.
+type IntegrityReport struct {
+	Blocks                 int
+	States                 int
+	StateSummaries         int
+	FinalizedBlocks        int
+	OrphanedStates         [][32]byte
+	OrphanedStateSummaries [][32]byte
+	OrphanedSlotIndices    [][32]byte
+	OrphanedParentIndices  [][32]byte
+	MisalignedIndices      int
+	StateRootMismatches    [][32]byte
+	FinalizedChainErrors   []string
+}
+
+// Healthy returns true if no inconsistency was found.
+func (r *IntegrityReport) Healthy() bool {
+	return len(r.OrphanedStates) == 0 &&
+		len(r.OrphanedStateSummaries) == 0 &&
+		len(r.OrphanedSlotIndices) == 0 &&
+		len(r.OrphanedParentIndices) == 0 &&
+		r.MisalignedIndices == 0 &&
+		len(r.StateRootMismatches) == 0 &&
+		len(r.FinalizedChainErrors) == 0
+}
+
+// CheckIntegrity verifies the invariants linking the buckets of the database: the states, state
+// summaries and block indices reference saved blocks, and the finalized block roots index forms
+// a continuous chain from the finalized checkpoint to genesis or the origin checkpoint. If
+// verifyStateRoots is set, the hash tree root of every saved state is also compared to the state
+// root of its block, which requires decoding all the states.
+func (s *Store) CheckIntegrity(ctx context.Context, verifyStateRoots bool) (*IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.CheckIntegrity")
+	defer span.End()
+
+	report := &IntegrityReport{}
+	var stateRoots [][32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		blks := tx.Bucket(blocksBucket)
+		if err := blks.ForEach(func(k, _ []byte) error {
+			if len(k) == fieldparams.RootLength {
+				report.Blocks++
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		hasBlock := func(root []byte) bool {
+			return blks.Get(root) != nil
+		}
+
+		if err := tx.Bucket(stateBucket).ForEach(func(k, _ []byte) error {
+			if len(k) != fieldparams.RootLength {
+				return nil
+			}
+			report.States++
+			if !hasBlock(k) {
+				report.OrphanedStates = append(report.OrphanedStates, bytesutil.ToBytes32(k))
+				return nil
+			}
+			stateRoots = append(stateRoots, bytesutil.ToBytes32(k))
+			return nil
+		}); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSummaryBucket).ForEach(func(k, _ []byte) error {
+			report.StateSummaries++
+			if !hasBlock(k) {
+				report.OrphanedStateSummaries = append(report.OrphanedStateSummaries, bytesutil.ToBytes32(k))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		// Index entries hold the concatenated roots of the indexed blocks.
+		for _, idx := range []struct {
+			bkt      []byte
+			orphaned *[][32]byte
+		}{
+			{blockSlotIndicesBucket, &report.OrphanedSlotIndices},
+			{blockParentRootIndicesBucket, &report.OrphanedParentIndices},
+		} {
+			if err := tx.Bucket(idx.bkt).ForEach(func(_, v []byte) error {
+				roots, err := splitRoots(v)
+				if err != nil {
+					report.MisalignedIndices++
+					return nil
+				}
+				for _, root := range roots {
+					if !hasBlock(root[:]) {
+						*idx.orphaned = append(*idx.orphaned, root)
+					}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return s.checkFinalizedChain(ctx, tx, report)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check database integrity")
+	}
+
+	if verifyStateRoots {
+		for _, root := range stateRoots {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			match, err := s.stateRootMatchesBlock(ctx, root)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not verify state root of block %#x", root)
+			}
+			if !match {
+				report.StateRootMismatches = append(report.StateRootMismatches, root)
+			}
+		}
+	}
+	return report, nil
+}
+
+// checkFinalizedChain walks the finalized block roots index from the finalized checkpoint down to
+// genesis or the origin checkpoint, and reports every broken link.
+func (s *Store) checkFinalizedChain(ctx context.Context, tx *bolt.Tx, report *IntegrityReport) error {
+	enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey)
+	if enc == nil {
+		return nil
+	}
+	cp := &ethpb.Checkpoint{}
+	if err := decode(ctx, enc, cp); err != nil {
+		return err
+	}
+	blks := tx.Bucket(blocksBucket)
+	genesisRoot := blks.Get(genesisBlockRootKey)
+	originRoot := blks.Get(originCheckpointBlockRootKey)
+	finalized := tx.Bucket(finalizedBlockRootsIndexBucket)
+
+	root := cp.Root
+	var child []byte
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if bytes.Equal(root, genesisRoot) || bytesutil.ZeroRoot(root) {
+			return nil
+		}
+		if blks.Get(root) == nil {
+			report.FinalizedChainErrors = append(report.FinalizedChainErrors, fmt.Sprintf("missing finalized block %#x", root))
+		}
+		enc := finalized.Get(root)
+		if enc == nil || bytes.Equal(enc, containerFinalizedButNotCanonical) {
+			report.FinalizedChainErrors = append(report.FinalizedChainErrors, fmt.Sprintf("finalized chain is broken at block %#x", root))
+			return nil
+		}
+		container := &ethpb.FinalizedBlockRootContainer{}
+		if err := decode(ctx, enc, container); err != nil {
+			return err
+		}
+		report.FinalizedBlocks++
+		if child != nil && len(container.ChildRoot) > 0 && !bytes.Equal(container.ChildRoot, child) {
+			report.FinalizedChainErrors = append(report.FinalizedChainErrors,
+				fmt.Sprintf("finalized block %#x has child %#x in the index instead of %#x", root, container.ChildRoot, child))
+		}
+		if bytes.Equal(root, originRoot) {
+			return nil
+		}
+		child = root
+		root = container.ParentRoot
+	}
+}
+
+// stateRootMatchesBlock returns true if the state saved for the block root is the post state of the
+// block. A state advanced through empty slots after the block, as saved at epoch boundaries, must
+// have the state root of the block in its state roots history instead.
+func (s *Store) stateRootMatchesBlock(ctx context.Context, blockRoot [32]byte) (bool, error) {
+	blk, err := s.Block(ctx, blockRoot)
+	if err != nil {
+		return false, err
+	}
+	st, err := s.State(ctx, blockRoot)
+	if err != nil {
+		return false, err
+	}
+	if blk == nil || blk.IsNil() || st == nil || st.IsNil() {
+		return false, nil
+	}
+	if st.Slot() > blk.Block().Slot() {
+		if st.Slot()-blk.Block().Slot() > params.BeaconConfig().SlotsPerHistoricalRoot {
+			return false, nil
+		}
+		stateRoot, err := st.StateRootAtIndex(uint64(blk.Block().Slot() % params.BeaconConfig().SlotsPerHistoricalRoot))
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(stateRoot, blk.Block().StateRoot()), nil
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(stateRoot[:], blk.Block().StateRoot()), nil
+}
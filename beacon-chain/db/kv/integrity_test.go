@@ -0,0 +1,72 @@
+package kv
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_CheckIntegrity(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*2, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	root, err := blks[slotsPerEpoch].Block().HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, root))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: root[:]}))
+
+	report, err := db.CheckIntegrity(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, true, report.Healthy())
+	assert.Equal(t, len(blks), report.Blocks)
+	assert.Equal(t, 1, report.States)
+	assert.Equal(t, int(slotsPerEpoch)+1, report.FinalizedBlocks)
+
+	// The saved state is not the post state of its block.
+	report, err = db.CheckIntegrity(ctx, true)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][32]byte{root}, report.StateRootMismatches)
+
+	missingRoot, err := blks[20].Block().HashTreeRoot()
+	require.NoError(t, err)
+	unindexedRoot, err := blks[10].Block().HashTreeRoot()
+	require.NoError(t, err)
+	orphanRoot := [32]byte{'o', 'r', 'p', 'h', 'a', 'n'}
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(blocksBucket).Delete(missingRoot[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(finalizedBlockRootsIndexBucket).Delete(unindexedRoot[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateBucket).Put(orphanRoot[:], []byte{'s'}); err != nil {
+			return err
+		}
+		return tx.Bucket(stateSummaryBucket).Put(orphanRoot[:], []byte{'s'})
+	}))
+
+	report, err = db.CheckIntegrity(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, false, report.Healthy())
+	assert.DeepEqual(t, [][32]byte{orphanRoot}, report.OrphanedStates)
+	assert.DeepEqual(t, [][32]byte{orphanRoot}, report.OrphanedStateSummaries)
+	assert.DeepEqual(t, [][32]byte{missingRoot}, report.OrphanedSlotIndices)
+	assert.DeepEqual(t, [][32]byte{missingRoot}, report.OrphanedParentIndices)
+	// The walk goes past the missing block, and stops at the block missing from the finalized index.
+	require.Equal(t, 2, len(report.FinalizedChainErrors))
+	assert.Equal(t, true, strings.Contains(report.FinalizedChainErrors[0], "missing finalized block"))
+	assert.Equal(t, true, strings.Contains(report.FinalizedChainErrors[1], "broken"))
+}
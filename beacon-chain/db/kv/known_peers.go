@@ -0,0 +1,97 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// A known peer is stored under its peer ID as its score (8 bytes, IEEE 754), the unix time it was
+// last seen in nanoseconds (8 bytes), the length of its address (2 bytes), its address and its ENR.
+const knownPeerHeaderLength = 8 + 8 + 2
+
+// KnownPeers returns the peers saved by SaveKnownPeers.
+func (s *Store) KnownPeers(ctx context.Context) ([]*iface.KnownPeer, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.KnownPeers")
+	defer span.End()
+
+	peers := make([]*iface.KnownPeer, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(knownPeersBucket).ForEach(func(k, v []byte) error {
+			p, err := decodeKnownPeer(k, v)
+			if err != nil {
+				return err
+			}
+			peers = append(peers, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+// SaveKnownPeers replaces the saved known peers by the given ones.
+func (s *Store) SaveKnownPeers(ctx context.Context, peers []*iface.KnownPeer) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveKnownPeers")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(knownPeersBucket); err != nil {
+			return err
+		}
+		bkt, err := tx.CreateBucket(knownPeersBucket)
+		if err != nil {
+			return err
+		}
+		for _, p := range peers {
+			enc, err := encodeKnownPeer(p)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put([]byte(p.ID), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func encodeKnownPeer(p *iface.KnownPeer) ([]byte, error) {
+	if p.ID == "" {
+		return nil, fmt.Errorf("known peer has no ID")
+	}
+	if len(p.Address) > math.MaxUint16 {
+		return nil, fmt.Errorf("address of known peer %s is too long", p.ID)
+	}
+	enc := make([]byte, knownPeerHeaderLength, knownPeerHeaderLength+len(p.Address)+len(p.ENR))
+	binary.BigEndian.PutUint64(enc[0:8], math.Float64bits(p.Score))
+	binary.BigEndian.PutUint64(enc[8:16], uint64(p.LastSeen.UnixNano()))
+	binary.BigEndian.PutUint16(enc[16:18], uint16(len(p.Address)))
+	enc = append(enc, p.Address...)
+	return append(enc, p.ENR...), nil
+}
+
+func decodeKnownPeer(id, enc []byte) (*iface.KnownPeer, error) {
+	if len(enc) < knownPeerHeaderLength {
+		return nil, fmt.Errorf("invalid known peer length %d, expected at least %d", len(enc), knownPeerHeaderLength)
+	}
+	addrLen := int(binary.BigEndian.Uint16(enc[16:18]))
+	if len(enc) < knownPeerHeaderLength+addrLen {
+		return nil, fmt.Errorf("invalid known peer length %d for an address of length %d", len(enc), addrLen)
+	}
+	return &iface.KnownPeer{
+		ID:       string(id),
+		Address:  string(enc[knownPeerHeaderLength : knownPeerHeaderLength+addrLen]),
+		ENR:      string(enc[knownPeerHeaderLength+addrLen:]),
+		Score:    math.Float64frombits(binary.BigEndian.Uint64(enc[0:8])),
+		LastSeen: time.Unix(0, int64(binary.BigEndian.Uint64(enc[8:16]))),
+	}, nil
+}
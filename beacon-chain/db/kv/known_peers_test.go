@@ -0,0 +1,61 @@
+package kv
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestStore_KnownPeers(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	peers, err := db.KnownPeers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(peers))
+
+	lastSeen := time.Unix(0, 1660000000123456789)
+	saved := []*iface.KnownPeer{
+		{
+			ID:       "peer1",
+			Address:  "/ip4/127.0.0.1/tcp/13000/p2p/peer1",
+			ENR:      "enr:-abc",
+			Score:    -1.5,
+			LastSeen: lastSeen,
+		},
+		{
+			ID:       "peer2",
+			Address:  "/ip4/127.0.0.2/tcp/13000/p2p/peer2",
+			Score:    2,
+			LastSeen: lastSeen.Add(time.Minute),
+		},
+	}
+	require.NoError(t, db.SaveKnownPeers(ctx, saved))
+	peers, err = db.KnownPeers(ctx)
+	require.NoError(t, err)
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID < peers[j].ID
+	})
+	require.Equal(t, 2, len(peers))
+	for i := range saved {
+		assert.Equal(t, saved[i].ID, peers[i].ID)
+		assert.Equal(t, saved[i].Address, peers[i].Address)
+		assert.Equal(t, saved[i].ENR, peers[i].ENR)
+		assert.Equal(t, saved[i].Score, peers[i].Score)
+		assert.Equal(t, true, saved[i].LastSeen.Equal(peers[i].LastSeen))
+	}
+
+	// Saving replaces the previous peers.
+	require.NoError(t, db.SaveKnownPeers(ctx, saved[1:]))
+	peers, err = db.KnownPeers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(peers))
+	assert.Equal(t, "peer2", peers[0].ID)
+
+	require.ErrorContains(t, "known peer has no ID", db.SaveKnownPeers(ctx, []*iface.KnownPeer{{Address: "addr"}}))
+}
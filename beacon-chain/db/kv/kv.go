@@ -81,6 +81,9 @@ type Store struct {
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
 	ctx                 context.Context
+	syncPolicy          SyncPolicy
+	registerer          prometheus.Registerer
+	groupedWrites       *groupedWrites
 }
 
 // KVStoreDatafilePath is the canonical construction of a full
@@ -90,10 +93,18 @@ func KVStoreDatafilePath(dirPath string) string {
 	return path.Join(dirPath, DatabaseFileName)
 }
 
+// WithRegisterer sets the registry the metrics of the database are registered with. The default is
+// the global prometheus registry.
+func WithRegisterer(r prometheus.Registerer) KVStoreOption {
+	return func(s *Store) {
+		s.registerer = r
+	}
+}
+
 // NewKVStore initializes a new boltDB key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
-func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
+func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*Store, error) {
 	hasDir, err := file.HasDir(dirPath)
 	if err != nil {
 		return nil, err
@@ -103,16 +114,29 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			return nil, err
 		}
 	}
+	kv := &Store{
+		databasePath:      dirPath,
+		stateSummaryCache: newStateSummaryCache(),
+		ctx:               ctx,
+		registerer:        prometheus.DefaultRegisterer,
+		groupedWrites:     &groupedWrites{},
+	}
+	for _, opt := range opts {
+		opt(kv)
+	}
+	boltOpts := &bolt.Options{
+		Timeout:         1 * time.Second,
+		InitialMmapSize: mmapSize,
+	}
+	if kv.syncPolicy == SyncPerSlot {
+		log.Warn("Writes of execution chain data are only committed to the database at sync points")
+		// The freelist is rebuilt when opening the database instead of being written on every commit.
+		boltOpts.NoFreelistSync = true
+		boltOpts.FreelistType = bolt.FreelistMapType
+	}
 	datafile := KVStoreDatafilePath(dirPath)
 	log.Infof("Opening Bolt DB at %s", datafile)
-	boltDB, err := bolt.Open(
-		datafile,
-		params.BeaconIoConfig().ReadWritePermissions,
-		&bolt.Options{
-			Timeout:         1 * time.Second,
-			InitialMmapSize: mmapSize,
-		},
-	)
+	boltDB, err := bolt.Open(datafile, params.BeaconIoConfig().ReadWritePermissions, boltOpts)
 	if err != nil {
 		if errors.Is(err, bolt.ErrTimeout) {
 			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
@@ -138,14 +162,9 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 		return nil, err
 	}
 
-	kv := &Store{
-		db:                  boltDB,
-		databasePath:        dirPath,
-		blockCache:          blockCache,
-		validatorEntryCache: validatorCache,
-		stateSummaryCache:   newStateSummaryCache(),
-		ctx:                 ctx,
-	}
+	kv.db = boltDB
+	kv.blockCache = blockCache
+	kv.validatorEntryCache = validatorCache
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
 		return createBuckets(
 			tx,
@@ -157,7 +176,10 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			voluntaryExitsBucket,
 			chainMetadataBucket,
 			checkpointBucket,
+			checkpointHistoryBucket,
 			powchainBucket,
+			depositContainersBucket,
+			knownPeersBucket,
 			stateSummaryBucket,
 			stateValidatorsBucket,
 			// Indices buckets.
@@ -171,6 +193,8 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			blockParentRootIndicesBucket,
 			finalizedBlockRootsIndexBucket,
 			blockRootValidatorHashesBucket,
+			epochBoundaryRootsBucket,
+			depositContainerPubkeyIndicesBucket,
 			// State management service bucket.
 			newStateServiceCompatibleBucket,
 			// Migrations
@@ -182,7 +206,7 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 	}); err != nil {
 		return nil, err
 	}
-	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
+	if err = kv.registerer.Register(createBoltCollector(kv.db)); err != nil {
 		return nil, err
 	}
 	if err = kv.checkNeedsResync(); err != nil {
@@ -196,7 +220,8 @@ func (s *Store) ClearDB() error {
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(s.db))
+	s.registerer.Unregister(createBoltCollector(s.db))
+	s.groupedWrites.clear()
 	if err := os.Remove(path.Join(s.databasePath, DatabaseFileName)); err != nil {
 		return errors.Wrap(err, "could not remove database file")
 	}
@@ -205,16 +230,30 @@ func (s *Store) ClearDB() error {
 
 // Close closes the underlying BoltDB database.
 func (s *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(s.db))
+	s.registerer.Unregister(createBoltCollector(s.db))
 
+	if err := s.SyncPoint(SyncPointClose); err != nil {
+		return err
+	}
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
 		return err
 	}
-
 	return s.db.Close()
 }
 
+// Flush writes any cached state summaries to the database and forces an fsync of the
+// underlying BoltDB file, so that no data is lost if the process is stopped right after.
+func (s *Store) Flush(ctx context.Context) error {
+	if err := s.commitGroupedWrites(ctx); err != nil {
+		return err
+	}
+	if err := s.saveCachedStateSummariesDB(ctx); err != nil {
+		return err
+	}
+	return s.db.Sync()
+}
+
 // DatabasePath at which this database writes files.
 func (s *Store) DatabasePath() string {
 	return s.databasePath
@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prysmaticlabs/prysm/v3/config/features"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	bolt "go.etcd.io/bbolt"
 )
@@ -32,3 +34,36 @@ func Test_checkNeedsResync(t *testing.T) {
 	err := store.checkNeedsResync()
 	require.ErrorContains(t, "your node must resync", err)
 }
+
+func TestStore_Flush(t *testing.T) {
+	ctx := context.Background()
+	store := setupDB(t)
+	root := [32]byte{'A'}
+	require.NoError(t, store.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: root[:]}))
+	require.Equal(t, 1, store.stateSummaryCache.len())
+
+	require.NoError(t, store.Flush(ctx))
+	require.Equal(t, 0, store.stateSummaryCache.len())
+	require.Equal(t, true, store.HasStateSummary(ctx, root))
+}
+
+func TestStore_WithRegisterer(t *testing.T) {
+	ctx := context.Background()
+	// Stores using registries of their own can be open at the same time.
+	registries := []*prometheus.Registry{prometheus.NewRegistry(), prometheus.NewRegistry()}
+	stores := make([]*Store, len(registries))
+	for i, registry := range registries {
+		db, err := NewKVStore(ctx, t.TempDir(), WithRegisterer(registry))
+		require.NoError(t, err)
+		stores[i] = db
+	}
+	for i, registry := range registries {
+		metrics, err := registry.Gather()
+		require.NoError(t, err)
+		require.NotEqual(t, 0, len(metrics))
+		require.NoError(t, stores[i].Close())
+		metrics, err = registry.Gather()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(metrics))
+	}
+}
@@ -16,10 +16,13 @@ var (
 	voluntaryExitsBucket    = []byte("voluntary-exits")
 	chainMetadataBucket     = []byte("chain-metadata")
 	checkpointBucket        = []byte("check-point")
+	checkpointHistoryBucket = []byte("check-point-history")
 	powchainBucket          = []byte("powchain")
 	stateValidatorsBucket   = []byte("state-validators")
 	feeRecipientBucket      = []byte("fee-recipient")
 	registrationBucket      = []byte("registration")
+	depositContainersBucket = []byte("deposit-containers")
+	knownPeersBucket        = []byte("known-peers")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -37,6 +40,8 @@ var (
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
 	blockRootValidatorHashesBucket      = []byte("block-root-validator-hashes")
+	epochBoundaryRootsBucket            = []byte("epoch-boundary-roots")
+	depositContainerPubkeyIndicesBucket = []byte("deposit-container-pubkey-indices")
 
 	// Specific item keys.
 	headBlockRootKey           = []byte("head-root")
@@ -45,6 +50,7 @@ var (
 	justifiedCheckpointKey     = []byte("justified-checkpoint")
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
+	depositSnapshotKey         = []byte("deposit-snapshot")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
 
 	// Below keys are used to identify objects are to be fork compatible.
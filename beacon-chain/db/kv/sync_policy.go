@@ -0,0 +1,145 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SyncPolicy defines when the writes to the database are committed.
+type SyncPolicy uint8
+
+const (
+	// SyncEveryCommit commits every write when it is made.
+	SyncEveryCommit SyncPolicy = iota
+	// SyncPerSlot keeps the frequent writes of the execution chain data, which the node can rebuild,
+	// in memory and commits the last one at sync points: after the import of a block, after the
+	// attestation pool snapshot of fork choice attestations, and when the database is closed. Every
+	// other write, such as blocks, states and checkpoints, is committed and flushed to disk right
+	// away, and state summaries keep being written in batches by their cache. Commits don't write the
+	// freelist, which is rebuilt when the database is opened. A crash loses the execution chain data
+	// since the last sync point, but never corrupts the database.
+	SyncPerSlot
+)
+
+// Reasons of the sync points, which label the commits in metrics.
+const (
+	SyncPointBlockImport     = "block_import"
+	SyncPointAttestationPool = "attestation_pool"
+	SyncPointClose           = "close"
+)
+
+var syncPointDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "beacondb_sync_point_seconds",
+	Help:    "Time taken to commit the grouped database writes at a sync point",
+	Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+}, []string{"reason"})
+
+// ParseSyncPolicy returns the policy with the given name, "commit" or "slot". An empty name is the
+// default policy, SyncEveryCommit.
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	switch name {
+	case "", SyncEveryCommit.String():
+		return SyncEveryCommit, nil
+	case SyncPerSlot.String():
+		return SyncPerSlot, nil
+	default:
+		return 0, fmt.Errorf("unknown database sync policy %q, want %q or %q", name, SyncEveryCommit, SyncPerSlot)
+	}
+}
+
+// String returns the name of the policy.
+func (p SyncPolicy) String() string {
+	switch p {
+	case SyncEveryCommit:
+		return "commit"
+	case SyncPerSlot:
+		return "slot"
+	default:
+		return fmt.Sprintf("SyncPolicy(%d)", p)
+	}
+}
+
+// KVStoreOption configures the store created by NewKVStore.
+type KVStoreOption func(*Store)
+
+// WithSyncPolicy sets when the writes to the database are committed. The default is SyncEveryCommit.
+func WithSyncPolicy(p SyncPolicy) KVStoreOption {
+	return func(s *Store) {
+		s.syncPolicy = p
+	}
+}
+
+// groupedWrites holds the writes waiting for the next sync point.
+type groupedWrites struct {
+	lock sync.Mutex
+	// Encoded execution chain data, only the last one is committed.
+	executionChainData []byte
+}
+
+// setExecutionChainData replaces the execution chain data to commit at the next sync point.
+func (g *groupedWrites) setExecutionChainData(enc []byte) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.executionChainData = enc
+}
+
+// getExecutionChainData returns the execution chain data waiting for the next sync point, if any.
+func (g *groupedWrites) getExecutionChainData() []byte {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.executionChainData
+}
+
+// committedExecutionChainData drops the execution chain data waiting for the next sync point if it is
+// the given committed one.
+func (g *groupedWrites) committedExecutionChainData(enc []byte) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if bytes.Equal(g.executionChainData, enc) {
+		g.executionChainData = nil
+	}
+}
+
+// clear drops the writes waiting for the next sync point.
+func (g *groupedWrites) clear() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.executionChainData = nil
+}
+
+// SyncPoint commits the grouped writes, unless the sync policy commits every write when it is made.
+func (s *Store) SyncPoint(reason string) error {
+	if s.syncPolicy == SyncEveryCommit {
+		return nil
+	}
+	start := time.Now()
+	if err := s.commitGroupedWrites(s.ctx); err != nil {
+		return err
+	}
+	syncPointDuration.WithLabelValues(reason).Observe(time.Since(start).Seconds())
+	return nil
+}
+
+// commitGroupedWrites commits the grouped execution chain data. State summaries are left to their
+// cache, which is written to the database once it holds stateSummaryCachePruneCount summaries.
+func (s *Store) commitGroupedWrites(_ context.Context) error {
+	executionChainData := s.groupedWrites.getExecutionChainData()
+	if executionChainData == nil {
+		return nil
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(powchainBucket).Put(powchainDataKey, executionChainData)
+	}); err != nil {
+		return err
+	}
+	// Execution chain data saved meanwhile waits for the next sync point.
+	s.groupedWrites.committedExecutionChainData(executionChainData)
+	return nil
+}
@@ -0,0 +1,90 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestParseSyncPolicy(t *testing.T) {
+	p, err := ParseSyncPolicy("commit")
+	require.NoError(t, err)
+	assert.Equal(t, SyncEveryCommit, p)
+	p, err = ParseSyncPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SyncEveryCommit, p)
+	p, err = ParseSyncPolicy("slot")
+	require.NoError(t, err)
+	assert.Equal(t, SyncPerSlot, p)
+	_, err = ParseSyncPolicy("never")
+	require.ErrorContains(t, "unknown database sync policy \"never\"", err)
+}
+
+func TestStore_SyncPerSlot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewKVStore(ctx, dir, WithSyncPolicy(SyncPerSlot))
+	require.NoError(t, err)
+	assert.Equal(t, false, store.db.NoSync)
+	assert.Equal(t, true, store.db.NoFreelistSync)
+
+	data := &ethpb.ETH1ChainData{CurrentEth1Data: &ethpb.LatestETH1Data{BlockHeight: 10}}
+	require.NoError(t, store.SaveExecutionChainData(ctx, data))
+	root := [32]byte{'A'}
+	summary := &ethpb.StateSummary{Slot: 1, Root: root[:]}
+	require.NoError(t, store.SaveStateSummary(ctx, summary))
+
+	// The grouped writes are read back before they are committed. State summaries are left to their cache.
+	saved, err := store.ExecutionChainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), saved.CurrentEth1Data.BlockHeight)
+	assert.Equal(t, true, store.HasStateSummary(ctx, root))
+	require.NoError(t, store.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 0, len(tx.Bucket(powchainBucket).Get(powchainDataKey)))
+		assert.Equal(t, 0, len(tx.Bucket(stateSummaryBucket).Get(summary.Root)))
+		return nil
+	}))
+
+	require.NoError(t, store.SyncPoint(SyncPointBlockImport))
+	require.NoError(t, store.db.View(func(tx *bolt.Tx) error {
+		assert.NotEqual(t, 0, len(tx.Bucket(powchainBucket).Get(powchainDataKey)))
+		assert.Equal(t, 0, len(tx.Bucket(stateSummaryBucket).Get(summary.Root)))
+		return nil
+	}))
+	assert.Equal(t, 0, len(store.groupedWrites.getExecutionChainData()))
+	assert.Equal(t, 1, store.stateSummaryCache.len())
+
+	// Closing the store commits the writes grouped since the last sync point and the cached summaries.
+	data.CurrentEth1Data.BlockHeight = 11
+	require.NoError(t, store.SaveExecutionChainData(ctx, data))
+	require.NoError(t, store.Close())
+
+	store, err = NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	assert.Equal(t, false, store.db.NoFreelistSync)
+	saved, err = store.ExecutionChainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), saved.CurrentEth1Data.BlockHeight)
+	assert.Equal(t, true, store.HasStateSummary(ctx, root))
+}
+
+func TestStore_SyncEveryCommit(t *testing.T) {
+	ctx := context.Background()
+	store := setupDB(t)
+	assert.Equal(t, false, store.db.NoFreelistSync)
+
+	data := &ethpb.ETH1ChainData{CurrentEth1Data: &ethpb.LatestETH1Data{BlockHeight: 10}}
+	require.NoError(t, store.SaveExecutionChainData(ctx, data))
+	require.NoError(t, store.db.View(func(tx *bolt.Tx) error {
+		assert.NotEqual(t, 0, len(tx.Bucket(powchainBucket).Get(powchainDataKey)))
+		return nil
+	}))
+	assert.Equal(t, 0, len(store.groupedWrites.getExecutionChainData()))
+}
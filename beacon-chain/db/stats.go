@@ -0,0 +1,135 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BucketStats holds the number of keys stored in a bucket of the database, including
+// the keys of its nested buckets, and the number of bytes of the pages holding them.
+type BucketStats struct {
+	Name  string
+	Keys  int
+	Bytes int
+}
+
+// Stats prints, for the beacon node and slasher databases found in the data directory, the
+// number of keys and bytes used by every bucket.
+func Stats(cliCtx *cli.Context) error {
+	dbDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	found := false
+	for _, dbFile := range []string{kv.DatabaseFileName, slasherkv.DatabaseFileName} {
+		dbPath := path.Join(dbDir, dbFile)
+		if !file.FileExists(dbPath) {
+			continue
+		}
+		found = true
+		stats, err := DatabaseStats(dbPath)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(dbPath)
+		if err != nil {
+			return errors.Wrapf(err, "could not stat %s", dbPath)
+		}
+		if err := writeStats(os.Stdout, dbPath, info.Size(), stats); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return errors.Errorf("no database found in %s", dbDir)
+	}
+	return nil
+}
+
+// DatabaseStats walks the top level buckets of the bolt database at dbPath and returns their
+// statistics, largest bucket first. The database is opened read only, which fails if another
+// process, such as a running beacon node, holds it.
+func DatabaseStats(dbPath string) ([]*BucketStats, error) {
+	boltDB, err := bolt.Open(dbPath, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{
+		Timeout:  1 * time.Second,
+		ReadOnly: true,
+	})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, errors.Wrapf(err, "could not open %s", dbPath)
+	}
+	defer func() {
+		if err := boltDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	var stats []*BucketStats
+	if err := boltDB.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			s := b.Stats()
+			stats = append(stats, &BucketStats{
+				Name:  string(name),
+				Keys:  s.KeyN,
+				Bytes: s.BranchInuse + s.LeafInuse + s.InlineBucketInuse,
+			})
+			return nil
+		})
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not walk database buckets")
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Bytes == stats[j].Bytes {
+			return stats[i].Name < stats[j].Name
+		}
+		return stats[i].Bytes > stats[j].Bytes
+	})
+	return stats, nil
+}
+
+func writeStats(w io.Writer, dbPath string, fileSize int64, stats []*BucketStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	if _, err := fmt.Fprintf(tw, "%s (%s on disk)\n", dbPath, humanBytes(fileSize)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(tw, "bucket\tkeys\tsize\t"); err != nil {
+		return err
+	}
+	var keys, bytes int
+	for _, s := range stats {
+		keys += s.Keys
+		bytes += s.Bytes
+		if _, err := fmt.Fprintf(tw, "%s\t%d\t%s\t\n", s.Name, s.Keys, humanBytes(int64(s.Bytes))); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(tw, "total\t%d\t%s\t\n\n", keys, humanBytes(int64(bytes))); err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
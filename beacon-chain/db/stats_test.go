@@ -0,0 +1,58 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestDatabaseStats(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := kv.NewKVStore(ctx, dir)
+	require.NoError(t, err)
+	for i := 1; i <= 3; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = types.Slot(i)
+		b.Block.ProposerIndex = 1
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, store.SaveBlock(ctx, wsb))
+	}
+	require.NoError(t, store.Close())
+
+	stats, err := DatabaseStats(path.Join(dir, kv.DatabaseFileName))
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(stats))
+	var blocksStats *BucketStats
+	for i, s := range stats {
+		if i > 0 {
+			assert.Equal(t, true, stats[i-1].Bytes >= s.Bytes, "Buckets are not sorted by size")
+		}
+		if s.Name == "blocks" {
+			blocksStats = s
+		}
+	}
+	require.NotNil(t, blocksStats)
+	assert.Equal(t, 3, blocksStats.Keys)
+	assert.Equal(t, true, blocksStats.Bytes > 0)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeStats(buf, "beaconchain.db", 1<<20, stats))
+	assert.Equal(t, true, strings.Contains(buf.String(), "1.0 MiB on disk"))
+	assert.Equal(t, true, strings.Contains(buf.String(), "blocks"))
+}
+
+func TestDatabaseStats_MissingDatabase(t *testing.T) {
+	_, err := DatabaseStats(path.Join(t.TempDir(), kv.DatabaseFileName))
+	require.ErrorContains(t, "could not open", err)
+}
@@ -0,0 +1,161 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ExchangeCapabilitiesMethod request string for JSON-RPC.
+const ExchangeCapabilitiesMethod = "engine_exchangeCapabilities"
+
+// Versions of the engine methods called by the client, newest first. A call uses the newest version
+// advertised by the execution client.
+var (
+	newPayloadMethods        = []string{NewPayloadMethod}
+	forkchoiceUpdatedMethods = []string{ForkchoiceUpdatedMethod}
+	getPayloadMethods        = []string{GetPayloadMethod}
+)
+
+// supportedEngineMethods are the engine methods advertised to the execution client.
+var supportedEngineMethods = []string{
+	NewPayloadMethod,
+	ForkchoiceUpdatedMethod,
+	GetPayloadMethod,
+	ExchangeTransitionConfigurationMethod,
+}
+
+// EngineCapabilities is the result of the last exchange of the supported engine methods with the
+// execution client.
+type EngineCapabilities struct {
+	// Exchanged is false if the execution client does not implement engine_exchangeCapabilities,
+	// in which case it is assumed to support all the methods.
+	Exchanged bool `json:"exchanged"`
+	// Remote are the methods advertised by the execution client.
+	Remote []string `json:"remote"`
+	// Missing are the methods supported by this client and not advertised by the execution client.
+	Missing []string `json:"missing"`
+	// Unused are the methods advertised by the execution client and not supported by this client.
+	Unused    []string  `json:"unused"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	remote map[string]bool
+}
+
+// capabilities holds the engine capabilities of the execution client.
+type capabilities struct {
+	lock sync.RWMutex
+	last *EngineCapabilities
+}
+
+// exchangeCapabilities calls the engine_exchangeCapabilities method via JSON-RPC and records the
+// methods supported by the execution client, logging the mismatches with this client.
+func (s *Service) exchangeCapabilities(ctx context.Context) (*EngineCapabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineTimeout)
+	defer cancel()
+	var remote []string
+	err := handleRPCError(s.rpcClient.CallContext(ctx, &remote, ExchangeCapabilitiesMethod, supportedEngineMethods))
+	if err != nil && !errors.Is(err, ErrMethodNotFound) {
+		return nil, errors.Wrap(err, "could not exchange capabilities with execution client")
+	}
+	c := newEngineCapabilities(remote, err == nil)
+	s.capabilities.lock.Lock()
+	s.capabilities.last = c
+	s.capabilities.lock.Unlock()
+
+	if !c.Exchanged {
+		log.Debug("Execution client does not support exchanging capabilities, assuming all engine methods are supported")
+		return c, nil
+	}
+	if len(c.Missing) > 0 {
+		log.WithField("methods", c.Missing).Warn("Execution client does not support engine methods of this client, " +
+			"older versions are used when possible. Please update your execution client")
+	}
+	if len(c.Unused) > 0 {
+		log.WithField("methods", c.Unused).Debug("Execution client supports engine methods not used by this client")
+	}
+	return c, nil
+}
+
+func newEngineCapabilities(remote []string, exchanged bool) *EngineCapabilities {
+	c := &EngineCapabilities{
+		Exchanged: exchanged,
+		Remote:    remote,
+		Missing:   []string{},
+		Unused:    []string{},
+		UpdatedAt: time.Now(),
+		remote:    make(map[string]bool, len(remote)),
+	}
+	if !exchanged {
+		return c
+	}
+	for _, m := range remote {
+		c.remote[m] = true
+	}
+	local := make(map[string]bool, len(supportedEngineMethods))
+	for _, m := range supportedEngineMethods {
+		local[m] = true
+		if !c.remote[m] {
+			c.Missing = append(c.Missing, m)
+		}
+	}
+	for _, m := range remote {
+		if !local[m] {
+			c.Unused = append(c.Unused, m)
+		}
+	}
+	sort.Strings(c.Unused)
+	return c
+}
+
+// supports returns true if the execution client advertised the method, or did not advertise its
+// capabilities at all.
+func (c *EngineCapabilities) supports(method string) bool {
+	return c == nil || !c.Exchanged || c.remote[method]
+}
+
+// EngineCapabilities returns the result of the last exchange of capabilities with the execution
+// client, or nil if none happened yet.
+func (s *Service) EngineCapabilities() *EngineCapabilities {
+	s.capabilities.lock.RLock()
+	defer s.capabilities.lock.RUnlock()
+	return s.capabilities.last
+}
+
+// engineMethod returns the newest of the versions of a method advertised by the execution client.
+// The oldest version is used if none is advertised, so that the call fails with the error of the
+// execution client rather than being skipped.
+func (s *Service) engineMethod(versions []string) string {
+	c := s.EngineCapabilities()
+	for _, m := range versions {
+		if c.supports(m) {
+			return m
+		}
+	}
+	oldest := versions[len(versions)-1]
+	log.WithFields(logrus.Fields{
+		"method":    oldest,
+		"supported": c.Remote,
+	}).Debug("Execution client does not advertise any version of engine method")
+	return oldest
+}
+
+// CapabilitiesHandler serves the engine capabilities exchanged with the execution client as JSON.
+func (s *Service) CapabilitiesHandler(w http.ResponseWriter, _ *http.Request) {
+	c := s.EngineCapabilities()
+	if c == nil {
+		http.Error(w, "capabilities not exchanged with the execution client yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(c); err != nil {
+		log.WithError(err).Error("Failed to render execution capabilities page")
+	}
+}
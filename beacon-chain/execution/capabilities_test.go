@@ -0,0 +1,87 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func newCapabilitiesServer(t *testing.T, response map[string]interface{}) *Service {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		defer func() {
+			require.NoError(t, r.Body.Close())
+		}()
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(srv.Close)
+	rpcClient, err := rpc.DialHTTP(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+	return &Service{rpcClient: rpcClient}
+}
+
+func TestExchangeCapabilities(t *testing.T) {
+	ctx := context.Background()
+	t.Run("mismatches", func(t *testing.T) {
+		service := newCapabilitiesServer(t, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  []string{NewPayloadMethod, ForkchoiceUpdatedMethod, "engine_newPayloadV2"},
+		})
+		c, err := service.exchangeCapabilities(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, true, c.Exchanged)
+		assert.DeepEqual(t, []string{GetPayloadMethod, ExchangeTransitionConfigurationMethod}, c.Missing)
+		assert.DeepEqual(t, []string{"engine_newPayloadV2"}, c.Unused)
+		assert.Equal(t, c, service.EngineCapabilities())
+
+		assert.Equal(t, NewPayloadMethod, service.engineMethod(newPayloadMethods))
+		assert.Equal(t, "engine_newPayloadV2", service.engineMethod([]string{"engine_newPayloadV2", NewPayloadMethod}))
+		// The oldest version is used when none is advertised.
+		assert.Equal(t, GetPayloadMethod, service.engineMethod([]string{"engine_getPayloadV2", GetPayloadMethod}))
+	})
+	t.Run("not supported by execution client", func(t *testing.T) {
+		service := newCapabilitiesServer(t, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"error":   map[string]interface{}{"code": -32601, "message": "the method does not exist"},
+		})
+		c, err := service.exchangeCapabilities(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, false, c.Exchanged)
+		assert.Equal(t, "engine_newPayloadV2", service.engineMethod([]string{"engine_newPayloadV2", NewPayloadMethod}))
+	})
+	t.Run("internal error", func(t *testing.T) {
+		service := newCapabilitiesServer(t, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"error":   map[string]interface{}{"code": -32603, "message": "internal error"},
+		})
+		_, err := service.exchangeCapabilities(ctx)
+		require.ErrorContains(t, "could not exchange capabilities", err)
+		assert.Equal(t, (*EngineCapabilities)(nil), service.EngineCapabilities())
+	})
+}
+
+func TestCapabilitiesHandler(t *testing.T) {
+	service := &Service{}
+	rec := httptest.NewRecorder()
+	service.CapabilitiesHandler(rec, httptest.NewRequest(http.MethodGet, "/execution/capabilities", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	service.capabilities.last = newEngineCapabilities([]string{NewPayloadMethod}, true)
+	rec = httptest.NewRecorder()
+	service.CapabilitiesHandler(rec, httptest.NewRequest(http.MethodGet, "/execution/capabilities", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	got := &EngineCapabilities{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(got))
+	assert.DeepEqual(t, []string{NewPayloadMethod}, got.Remote)
+	assert.Equal(t, 3, len(got.Missing))
+}
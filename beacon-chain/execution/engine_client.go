@@ -93,7 +93,7 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 	if !ok {
 		return nil, errors.New("execution data must be an execution payload")
 	}
-	err := s.rpcClient.CallContext(ctx, result, NewPayloadMethod, payloadPb)
+	err := s.rpcClient.CallContext(ctx, result, s.engineMethod(newPayloadMethods), payloadPb)
 	if err != nil {
 		return nil, handleRPCError(err)
 	}
@@ -127,7 +127,7 @@ func (s *Service) ForkchoiceUpdated(
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
 	result := &ForkchoiceUpdatedResponse{}
-	err := s.rpcClient.CallContext(ctx, result, ForkchoiceUpdatedMethod, state, attrs)
+	err := s.rpcClient.CallContext(ctx, result, s.engineMethod(forkchoiceUpdatedMethods), state, attrs)
 	if err != nil {
 		return nil, nil, handleRPCError(err)
 	}
@@ -161,7 +161,7 @@ func (s *Service) GetPayload(ctx context.Context, payloadId [8]byte) (*pb.Execut
 	ctx, cancel := context.WithDeadline(ctx, d)
 	defer cancel()
 	result := &pb.ExecutionPayload{}
-	err := s.rpcClient.CallContext(ctx, result, GetPayloadMethod, pb.PayloadIDBytes(payloadId))
+	err := s.rpcClient.CallContext(ctx, result, s.engineMethod(getPayloadMethods), pb.PayloadIDBytes(payloadId))
 	return result, handleRPCError(err)
 }
 
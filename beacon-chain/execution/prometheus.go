@@ -19,6 +19,7 @@ type PowchainCollector struct {
 	sync.Mutex
 	ctx        context.Context
 	finishChan chan struct{}
+	registerer prometheus.Registerer
 }
 
 var _ BeaconNodeStatsUpdater = &PowchainCollector{}
@@ -73,10 +74,10 @@ func (pc *PowchainCollector) setLatestStats(bs clientstats.BeaconNodeStats) {
 	pc.Unlock()
 }
 
-// unregister returns true if the prometheus DefaultRegistry
+// unregister returns true if the prometheus registry
 // confirms that it was removed.
 func (pc *PowchainCollector) unregister() bool {
-	return prometheus.Unregister(pc)
+	return pc.registerer.Unregister(pc)
 }
 
 func (pc *PowchainCollector) latestStatsUpdateLoop() {
@@ -92,7 +93,7 @@ func (pc *PowchainCollector) latestStatsUpdateLoop() {
 	}
 }
 
-func NewPowchainCollector(ctx context.Context) (*PowchainCollector, error) {
+func NewPowchainCollector(ctx context.Context, registerer prometheus.Registerer) (*PowchainCollector, error) {
 	namespace := "powchain"
 	updateChan := make(chan clientstats.BeaconNodeStats, 2)
 	c := &PowchainCollector{
@@ -105,9 +106,10 @@ func NewPowchainCollector(ctx context.Context) (*PowchainCollector, error) {
 		updateChan: updateChan,
 		ctx:        ctx,
 		finishChan: make(chan struct{}, 1),
+		registerer: registerer,
 	}
 	go c.latestStatsUpdateLoop()
-	return c, prometheus.Register(c)
+	return c, registerer.Register(c)
 }
 
 type NopBeaconNodeStatsUpdater struct{}
@@ -14,7 +14,7 @@ import (
 // and the implicit methods within the collector implementation
 func TestCleanup(t *testing.T) {
 	ctx := context.Background()
-	pc, err := NewPowchainCollector(ctx)
+	pc, err := NewPowchainCollector(ctx, prometheus.DefaultRegisterer)
 	assert.NoError(t, err, "Uxpected error caling NewPowchainCollector")
 	unregistered := pc.unregister()
 	assert.Equal(t, true, unregistered, "PowchainCollector.unregister did not return true (via prometheus.DefaultRegistry)")
@@ -38,7 +38,7 @@ func TestCleanup(t *testing.T) {
 // PowchainCollector, just for this test.
 func TestCancelation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
-	pc, err := NewPowchainCollector(ctx)
+	pc, err := NewPowchainCollector(ctx, prometheus.DefaultRegisterer)
 	assert.NoError(t, err, "Uxpected error caling NewPowchainCollector")
 	ticker := time.NewTicker(10 * time.Second)
 	cancel()
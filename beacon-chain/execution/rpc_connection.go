@@ -39,6 +39,11 @@ func (s *Service) setupExecutionClientConnections(ctx context.Context, currEndpo
 		client.Close()
 		return errors.Wrap(err, "could not make initial request to verify execution chain ID")
 	}
+	// Older execution clients may not support the exchange, the engine methods are then assumed to be
+	// supported.
+	if _, err := s.exchangeCapabilities(ctx); err != nil {
+		log.WithError(err).Warn("Could not exchange engine capabilities with execution client")
+	}
 	s.updateConnectedETH1(true)
 	s.runError = nil
 	return nil
@@ -110,7 +115,7 @@ func (s *Service) newRPCClientWithAuth(ctx context.Context, endpoint network.End
 	}
 	switch u.Scheme {
 	case "http", "https":
-		client, err = gethRPC.DialHTTPWithClient(endpoint.Url, endpoint.HttpClient())
+		client, err = gethRPC.DialHTTPWithClient(endpoint.Url, endpoint.HttpClientWithTransport(s.httpTransport))
 		if err != nil {
 			return nil, err
 		}
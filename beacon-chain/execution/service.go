@@ -4,9 +4,11 @@
 package execution
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
+	"net/http"
 	"reflect"
 	"runtime/debug"
 	"sort"
@@ -128,6 +130,7 @@ type config struct {
 	eth1HeaderReqLimit      uint64
 	beaconNodeStatsUpdater  BeaconNodeStatsUpdater
 	currHttpEndpoint        network.Endpoint
+	httpTransportConfig     network.TransportConfig
 	finalizedStateAtStartup state.BeaconState
 }
 
@@ -149,6 +152,7 @@ type Service struct {
 	httpLogger              bind.ContractFilterer
 	eth1DataFetcher         RPCDataFetcher
 	rpcClient               RPCClient
+	httpTransport           *http.Transport
 	headerCache             *headerCache // cache to store block hash/block height.
 	latestEth1Data          *ethpb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
@@ -157,6 +161,7 @@ type Service struct {
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         state.BeaconState
+	capabilities            capabilities
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -179,6 +184,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		cfg: &config{
 			beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{},
 			eth1HeaderReqLimit:     defaultEth1HeaderReqLimit,
+			httpTransportConfig:    network.DefaultTransportConfig(),
 		},
 		latestEth1Data: &ethpb.LatestETH1Data{
 			BlockHeight:        0,
@@ -202,6 +208,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 			return nil, err
 		}
 	}
+	s.httpTransport = network.NewTransport(s.cfg.httpTransportConfig)
 
 	if err := s.ensureValidPowchainData(ctx); err != nil {
 		return nil, errors.Wrap(err, "unable to validate powchain data")
@@ -259,6 +266,9 @@ func (s *Service) Stop() error {
 	if s.eth1DataFetcher != nil {
 		s.eth1DataFetcher.Close()
 	}
+	if s.httpTransport != nil {
+		s.httpTransport.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -342,6 +352,19 @@ func (s *Service) followedBlockHeight(ctx context.Context) (uint64, error) {
 }
 
 func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositContainer) error {
+	// Nodes started from a deposit snapshot don't have the deposits of the snapshot,
+	// so the finalized deposits of the cache start from the snapshot instead.
+	if len(ctrs) == 0 || ctrs[0].Index > 0 {
+		snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve deposit snapshot")
+		}
+		if snapshot != nil {
+			if err := s.cfg.depositCache.InsertFinalizedDepositsSnapshot(ctx, snapshot); err != nil {
+				return errors.Wrap(err, "could not insert deposit snapshot")
+			}
+		}
+	}
 	if len(ctrs) == 0 {
 		return nil
 	}
@@ -387,12 +410,13 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositCo
 		}
 	}
 	validDepositsCount.Add(float64(currIndex))
-	// Only add pending deposits if the container slice length
-	// is more than the current index in state.
-	if uint64(len(ctrs)) > currIndex {
-		for _, c := range ctrs[currIndex:] {
-			s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
+	// Only add pending deposits for the containers which
+	// come after the current index in state.
+	for _, c := range ctrs {
+		if c.Index < int64(currIndex) { // lint:ignore uintcast -- deposit index will not exceed int64 in your lifetime.
+			continue
 		}
+		s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
 	}
 	return nil
 }
@@ -759,8 +783,8 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 }
 
 // Validates that all deposit containers are valid and have their relevant indices
-// in order.
-func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
+// in order, starting from the given index.
+func validateDepositContainers(ctrs []*ethpb.DepositContainer, startIndex int64) bool {
 	ctrLen := len(ctrs)
 	// Exit for empty containers.
 	if ctrLen == 0 {
@@ -770,7 +794,6 @@ func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
 	sort.Slice(ctrs, func(i, j int) bool {
 		return ctrs[i].Index < ctrs[j].Index
 	})
-	startIndex := int64(0)
 	for _, c := range ctrs {
 		if c.Index != startIndex {
 			log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
@@ -796,7 +819,14 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve eth1 data")
 	}
-	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers) {
+	startIndex, err := s.firstDepositContainerIndex(ctx, eth1Data)
+	if err != nil {
+		return err
+	}
+	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers, startIndex) {
+		if err := s.initializeFromDepositSnapshot(ctx); err != nil {
+			return err
+		}
 		var pbState *ethpb.BeaconState
 		var err error
 		if features.Get().EnableNativeState {
@@ -826,6 +856,64 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 	return nil
 }
 
+// Returns the index the persisted deposit containers are expected to start from. Nodes
+// started from a deposit snapshot only persist the deposits which come after the snapshot,
+// and the containers of stored finalized deposits are not persisted with the chain data.
+func (s *Service) firstDepositContainerIndex(ctx context.Context, eth1Data *ethpb.ETH1ChainData) (int64, error) {
+	if eth1Data == nil || eth1Data.Trie == nil {
+		return 0, nil
+	}
+	numOfItems := int64(len(eth1Data.Trie.OriginalItems))
+	if numOfItems == 1 && bytes.Equal(eth1Data.Trie.OriginalItems[0], params.BeaconConfig().ZeroHash[:]) {
+		numOfItems = 0
+	}
+	startIndex := numOfItems - int64(len(eth1Data.DepositContainers))
+	if startIndex <= 0 {
+		return 0, nil
+	}
+	// Finalized deposit containers are moved from the execution chain data to their own store.
+	first, last, err := s.cfg.beaconDB.DepositContainerIndexRange(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve stored deposit containers")
+	}
+	if last >= first && first <= startIndex && startIndex <= last+1 {
+		return startIndex, nil
+	}
+	snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve deposit snapshot")
+	}
+	if snapshot == nil || snapshot.DepositCount < uint64(startIndex) {
+		return 0, nil
+	}
+	return startIndex, nil
+}
+
+// Starts the deposit trie and the tracking of execution blocks from the deposit snapshot
+// saved by checkpoint sync, if any, so that the deposit logs of the snapshot are not replayed.
+func (s *Service) initializeFromDepositSnapshot(ctx context.Context) error {
+	snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve deposit snapshot")
+	}
+	if snapshot == nil {
+		return nil
+	}
+	depositTrie, err := trie.NewTrieFromSnapshot(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit trie from snapshot")
+	}
+	s.depositTrie = depositTrie
+	s.latestEth1Data.BlockHeight = snapshot.ExecutionBlockHeight
+	s.latestEth1Data.BlockHash = bytesutil.SafeCopyBytes(snapshot.ExecutionBlockHash[:])
+	s.latestEth1Data.LastRequestedBlock = snapshot.ExecutionBlockHeight
+	log.WithFields(logrus.Fields{
+		"depositCount":         snapshot.DepositCount,
+		"executionBlockHeight": snapshot.ExecutionBlockHeight,
+	}).Info("Starting deposit tracking from deposit snapshot")
+	return nil
+}
+
 func dedupEndpoints(endpoints []string) []string {
 	selectionMap := make(map[string]bool)
 	newEndpoints := make([]string, 0, len(endpoints))
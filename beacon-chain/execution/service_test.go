@@ -22,6 +22,7 @@ import (
 	mockExecution "github.com/prysmaticlabs/prysm/v3/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
 	contracts "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
 	"github.com/prysmaticlabs/prysm/v3/contracts/deposit/mock"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
@@ -661,6 +662,57 @@ func TestService_InitializeCorrectly(t *testing.T) {
 	assert.Equal(t, int64(-1), s1.lastReceivedMerkleIndex, "received incorrect last received merkle index")
 }
 
+func TestService_InitializeFromDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	cache, err := depositcache.New()
+	require.NoError(t, err)
+
+	srv, endpoint, err := mockExecution.SetupRPCServer()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		srv.Stop()
+	})
+	s1, err := NewService(ctx,
+		WithHttpEndpoint(endpoint),
+		WithDatabase(beaconDB),
+		WithDepositCache(cache),
+	)
+	require.NoError(t, err)
+	genState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, s1.cfg.beaconDB.SaveGenesisData(ctx, genState))
+
+	items := make([][]byte, 5)
+	for i := range items {
+		items[i] = bytesutil.PadTo([]byte{byte(i + 1)}, 32)
+	}
+	depositTrie, err := trie.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	snapshot, err := trie.NewDepositTreeSnapshot(depositTrie, 5, [32]byte{'a'}, 100)
+	require.NoError(t, err)
+	require.NoError(t, s1.cfg.beaconDB.SaveDepositSnapshot(ctx, snapshot))
+
+	require.NoError(t, s1.ensureValidPowchainData(ctx))
+	eth1Data, err := s1.cfg.beaconDB.ExecutionChainData(ctx)
+	require.NoError(t, err)
+	require.NoError(t, s1.initializeEth1Data(ctx, eth1Data))
+	assert.Equal(t, int64(4), s1.lastReceivedMerkleIndex)
+	assert.Equal(t, uint64(100), s1.latestEth1Data.LastRequestedBlock)
+	root, err := s1.depositTrie.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.DepositRoot, root)
+
+	// Deposits after the snapshot are persisted without the deposits of the snapshot.
+	eth1Data.DepositContainers = []*ethpb.DepositContainer{{Index: 5}, {Index: 6}}
+	eth1Data.Trie.OriginalItems = append(eth1Data.Trie.OriginalItems, items[0], items[1])
+	require.NoError(t, s1.cfg.beaconDB.SaveExecutionChainData(ctx, eth1Data))
+	require.NoError(t, s1.ensureValidPowchainData(ctx))
+	eth1Data, err = s1.cfg.beaconDB.ExecutionChainData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(eth1Data.DepositContainers))
+}
+
 func TestService_EnsureValidPowchainData(t *testing.T) {
 	beaconDB := dbutil.SetupDB(t)
 	cache, err := depositcache.New()
@@ -700,6 +752,7 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 	var tt = []struct {
 		name        string
 		ctrsFunc    func() []*ethpb.DepositContainer
+		startIndex  int64
 		expectedRes bool
 	}{
 		{
@@ -745,10 +798,22 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 			},
 			expectedRes: false,
 		},
+		{
+			name: "containers after deposit snapshot",
+			ctrsFunc: func() []*ethpb.DepositContainer {
+				ctrs := make([]*ethpb.DepositContainer, 0)
+				for i := 5; i < 10; i++ {
+					ctrs = append(ctrs, &ethpb.DepositContainer{Index: int64(i), Eth1BlockHeight: uint64(i + 10)})
+				}
+				return ctrs
+			},
+			startIndex:  5,
+			expectedRes: true,
+		},
 	}
 
 	for _, test := range tt {
-		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc()))
+		assert.Equal(t, test.expectedRes, validateDepositContainers(test.ctrsFunc(), test.startIndex))
 	}
 }
 
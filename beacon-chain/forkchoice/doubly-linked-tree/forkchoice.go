@@ -3,6 +3,7 @@ package doublylinkedtree
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
@@ -71,21 +72,35 @@ func (f *ForkChoice) Head(
 	if err := f.updateBalances(justifiedStateBalances); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update balances")
 	}
+	// The committee weight is only used to decide on proposer reorgs, which are skipped when it is unknown.
+	if committeeWeight, err := computeCommitteeWeight(justifiedStateBalances); err == nil {
+		f.store.committeeWeight = committeeWeight
+	}
 
 	if err := f.store.applyProposerBoostScore(justifiedStateBalances); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not apply proposer boost score")
 	}
 
+	start := time.Now()
 	if err := f.store.treeRootNode.applyWeightChanges(ctx); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not apply weight changes")
 	}
+	observeDuration(applyWeightChangesDuration, start)
 
 	jc := f.JustifiedCheckpoint()
 	fc := f.FinalizedCheckpoint()
+	start = time.Now()
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
-	return f.store.head(ctx)
+	observeDuration(updateBestDescendantDuration, start)
+
+	root, err := f.store.head(ctx)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	treeDepth.Set(float64(f.store.headNode.depth()))
+	return root, nil
 }
 
 // ProcessAttestation processes attestation for vote accounting, it iterates around validator indices
@@ -606,3 +621,33 @@ func (f *ForkChoice) JustifiedPayloadBlockHash() [32]byte {
 	}
 	return node.payloadHash
 }
+
+// Nodes returns a snapshot of the nodes of the fork choice tree, parents before their children.
+func (f *ForkChoice) Nodes() []*forkchoicetypes.Node {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	if f.store.treeRootNode == nil {
+		return nil
+	}
+	nodes := make([]*forkchoicetypes.Node, 0, len(f.store.nodeByRoot))
+	queue := []*Node{f.store.treeRootNode}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		fn := &forkchoicetypes.Node{
+			Slot:           n.slot,
+			Root:           n.root,
+			PayloadHash:    n.payloadHash,
+			JustifiedEpoch: n.justifiedEpoch,
+			FinalizedEpoch: n.finalizedEpoch,
+			Weight:         n.weight,
+			Optimistic:     n.optimistic,
+		}
+		if n.parent != nil {
+			fn.ParentRoot = n.parent.root
+		}
+		nodes = append(nodes, fn)
+		queue = append(queue, n.children...)
+	}
+	return nodes
+}
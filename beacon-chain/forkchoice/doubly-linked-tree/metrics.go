@@ -1,6 +1,8 @@
 package doublylinkedtree
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sirupsen/logrus"
@@ -51,4 +53,47 @@ var (
 			Help: "The number of times pruning happened.",
 		},
 	)
+	treeDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "doublylinkedtree_tree_depth",
+			Help: "The number of ancestors of the current head in the store.",
+		},
+	)
+	applyWeightChangesDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "doublylinkedtree_apply_weight_changes_milliseconds",
+			Help:    "Time to recompute the weights of all the nodes of the store when computing head.",
+			Buckets: durationBuckets,
+		},
+	)
+	updateBestDescendantDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "doublylinkedtree_update_best_descendant_milliseconds",
+			Help:    "Time to update the best descendant of all the nodes of the store when computing head.",
+			Buckets: durationBuckets,
+		},
+	)
+	insertDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "doublylinkedtree_insert_milliseconds",
+			Help:    "Time to insert a block node in the store.",
+			Buckets: durationBuckets,
+		},
+	)
+	pruneDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "doublylinkedtree_prune_milliseconds",
+			Help:    "Time to prune the store upon finalization.",
+			Buckets: durationBuckets,
+		},
+	)
 )
+
+// Fork choice operations usually complete well under a millisecond, the buckets
+// are fine grained enough to notice when they start taking longer.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100, 250}
+
+// observeDuration records the time elapsed since start in milliseconds, keeping sub millisecond precision.
+func observeDuration(h prometheus.Histogram, start time.Time) {
+	h.Observe(float64(time.Since(start).Microseconds()) / 1000)
+}
@@ -21,6 +21,18 @@ func (f *ForkChoice) ResetBoostedProposerRoot(_ context.Context) error {
 // IMPORTANT: The caller MUST pass in a list of validator balances where balances > 0 refer to active
 // validators while balances == 0 are for inactive validators.
 func computeProposerBoostScore(validatorBalances []uint64) (score uint64, err error) {
+	committeeWeight, err := computeCommitteeWeight(validatorBalances)
+	if err != nil {
+		return
+	}
+	score = (committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
+	return
+}
+
+// computeCommitteeWeight returns the weight of a committee, which is the total active balance
+// divided by the number of slots per epoch. The same assumptions on the balances as for
+// computeProposerBoostScore apply.
+func computeCommitteeWeight(validatorBalances []uint64) (uint64, error) {
 	totalActiveBalance := uint64(0)
 	numActive := uint64(0)
 	for _, balance := range validatorBalances {
@@ -34,10 +46,7 @@ func computeProposerBoostScore(validatorBalances []uint64) (score uint64, err er
 	}
 	if numActive == 0 {
 		// Should never happen.
-		err = errors.New("no active validators")
-		return
+		return 0, errors.New("no active validators")
 	}
-	committeeWeight := totalActiveBalance / uint64(params.BeaconConfig().SlotsPerEpoch)
-	score = (committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
-	return
+	return totalActiveBalance / uint64(params.BeaconConfig().SlotsPerEpoch), nil
 }
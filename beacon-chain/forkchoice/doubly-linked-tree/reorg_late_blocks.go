@@ -0,0 +1,107 @@
+package doublylinkedtree
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// orphanLateBlockProposingEarly is the number of seconds into its slot before which a proposer
+// is considered to propose early enough to receive the proposer boost, and thus to win the reorg
+// of a late head block.
+const orphanLateBlockProposingEarly = 2
+
+// ShouldOverrideFCU returns whether the current head is a late block of the current slot with a
+// weak attestation weight, which the proposer of the next slot may reorg by building on its
+// parent instead. In that case the execution engine should not be told about the new head, so that
+// it keeps building the payload of the next slot on the parent.
+//
+// This function must be called after fork choice computed the head, and only by a node serving
+// the proposer of the next slot. It only applies a heuristic: whether the head is actually reorged
+// is decided at proposal time by GetProposerHead.
+func (f *ForkChoice) ShouldOverrideFCU() bool {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	head := f.store.headNode
+	if head == nil || head.slot != slots.CurrentSlot(f.store.genesisTime) {
+		return false
+	}
+	// The parent weight is not checked, as the attestations of the current slot are only processed
+	// at the start of the next slot. GetProposerHead checks it once they are.
+	_, ok := f.store.reorgableParent(head, f.FinalizedCheckpoint().Epoch)
+	return ok
+}
+
+// GetProposerHead returns the root of the block a proposer of the current slot should build on.
+// This is the head of the chain, or its parent when the head is a late block of the previous
+// slot with a weak attestation weight whose parent is strongly attested, and the proposer is
+// early enough to get the proposer boost that makes the reorg succeed.
+//
+// This function must be called at proposal time, after fork choice computed the head.
+func (f *ForkChoice) GetProposerHead() [32]byte {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	head := f.store.headNode
+	if head == nil {
+		return [32]byte{}
+	}
+	currentSlot := slots.CurrentSlot(f.store.genesisTime)
+	if head.slot+1 != currentSlot {
+		return head.root
+	}
+	parent, ok := f.store.reorgableParent(head, f.FinalizedCheckpoint().Epoch)
+	if !ok {
+		return head.root
+	}
+	if parent.weight*100 < f.store.committeeWeight*params.BeaconConfig().ReorgParentWeightThreshold {
+		return head.root
+	}
+	if f.store.secondsIntoSlot(currentSlot, uint64(time.Now().Unix())) >= orphanLateBlockProposingEarly {
+		return head.root
+	}
+	return parent.root
+}
+
+// reorgableParent returns the parent of the head if the head may be reorged by a proposer of the
+// slot after it: the head arrived late, has a weak attestation weight, is the only block since its
+// parent, is not the last block of an epoch, does not change the unrealized justification of its
+// parent, and the chain is finalizing. This function requires a lock in Store.nodesLock.
+func (s *Store) reorgableParent(head *Node, finalizedEpoch types.Epoch) (*Node, bool) {
+	cfg := params.BeaconConfig()
+	parent := head.parent
+	if parent == nil || head.slot > parent.slot+1 {
+		return nil, false
+	}
+	// Do not reorg on epoch boundaries.
+	if (head.slot+1)%cfg.SlotsPerEpoch == 0 {
+		return nil, false
+	}
+	// Do not reorg a head that brings justification forward (is_ffg_competitive in the spec).
+	if head.unrealizedJustifiedEpoch != parent.unrealizedJustifiedEpoch {
+		return nil, false
+	}
+	if slots.ToEpoch(head.slot+1) > finalizedEpoch+cfg.ReorgMaxEpochsSinceFinalization {
+		return nil, false
+	}
+	if s.secondsIntoSlot(head.slot, head.timestamp) < cfg.SecondsPerSlot/cfg.IntervalsPerSlot {
+		return nil, false
+	}
+	if s.committeeWeight == 0 || head.weight*100 > s.committeeWeight*cfg.ReorgWeightThreshold {
+		return nil, false
+	}
+	return parent, true
+}
+
+// secondsIntoSlot returns the number of seconds elapsed since the start of the slot at the given
+// time, or 0 if the slot did not start yet.
+func (s *Store) secondsIntoSlot(slot types.Slot, now uint64) uint64 {
+	start := s.genesisTime + uint64(slot)*params.BeaconConfig().SecondsPerSlot
+	if now < start {
+		return 0
+	}
+	return now - start
+}
@@ -0,0 +1,120 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+// setupLateHead inserts blocks at slots 1 and 2, with the block at slot 2 as a late head that
+// is weakly attested and the block at slot 1 as a strongly attested parent.
+func setupLateHead(t *testing.T) *ForkChoice {
+	ctx := context.Background()
+	f := setup(0, 0)
+	zeroHash := params.BeaconConfig().ZeroHash
+	st, root, err := prepareForkchoiceState(ctx, 1, indexToHash(1), zeroHash, zeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 2, indexToHash(2), indexToHash(1), zeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+
+	driftGenesisTime(f, 2, 0)
+	head := f.store.nodeByRoot[indexToHash(2)]
+	head.timestamp = f.store.genesisTime + 2*params.BeaconConfig().SecondsPerSlot + 5
+	head.weight = 10
+	head.parent.weight = 200
+	f.store.headNode = head
+	f.store.committeeWeight = 100
+	return f
+}
+
+func TestForkChoice_ShouldOverrideFCU(t *testing.T) {
+	t.Run("late and weak head", func(t *testing.T) {
+		f := setupLateHead(t)
+		require.Equal(t, true, f.ShouldOverrideFCU())
+	})
+	t.Run("head not in current slot", func(t *testing.T) {
+		f := setupLateHead(t)
+		driftGenesisTime(f, 3, 0)
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("head on time", func(t *testing.T) {
+		f := setupLateHead(t)
+		f.store.headNode.timestamp = f.store.genesisTime + 2*params.BeaconConfig().SecondsPerSlot + 1
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("head strongly attested", func(t *testing.T) {
+		f := setupLateHead(t)
+		f.store.headNode.weight = 30
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("skipped slot before head", func(t *testing.T) {
+		f := setupLateHead(t)
+		f.store.headNode.slot = 3
+		driftGenesisTime(f, 3, 0)
+		f.store.headNode.timestamp = f.store.genesisTime + 3*params.BeaconConfig().SecondsPerSlot + 5
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("last slot of epoch", func(t *testing.T) {
+		f := setupLateHead(t)
+		slot := params.BeaconConfig().SlotsPerEpoch - 1
+		f.store.headNode.slot = slot
+		f.store.headNode.parent.slot = slot - 1
+		driftGenesisTime(f, slot, 0)
+		f.store.headNode.timestamp = f.store.genesisTime + uint64(slot)*params.BeaconConfig().SecondsPerSlot + 5
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("head changes unrealized justification", func(t *testing.T) {
+		f := setupLateHead(t)
+		f.store.headNode.unrealizedJustifiedEpoch = 1
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+	t.Run("chain not finalizing", func(t *testing.T) {
+		f := setupLateHead(t)
+		slot := types.Slot(3)*params.BeaconConfig().SlotsPerEpoch + 2
+		f.store.headNode.slot = slot
+		f.store.headNode.parent.slot = slot - 1
+		driftGenesisTime(f, slot, 0)
+		f.store.headNode.timestamp = f.store.genesisTime + uint64(slot)*params.BeaconConfig().SecondsPerSlot + 5
+		require.Equal(t, false, f.ShouldOverrideFCU())
+	})
+}
+
+func TestForkChoice_GetProposerHead(t *testing.T) {
+	t.Run("reorgs late and weak head", func(t *testing.T) {
+		f := setupLateHead(t)
+		driftGenesisTime(f, 3, 0)
+		require.Equal(t, indexToHash(1), f.GetProposerHead())
+	})
+	t.Run("head of current slot", func(t *testing.T) {
+		f := setupLateHead(t)
+		require.Equal(t, indexToHash(2), f.GetProposerHead())
+	})
+	t.Run("parent weakly attested", func(t *testing.T) {
+		f := setupLateHead(t)
+		driftGenesisTime(f, 3, 0)
+		f.store.headNode.parent.weight = 150
+		require.Equal(t, indexToHash(2), f.GetProposerHead())
+	})
+	t.Run("proposing late", func(t *testing.T) {
+		f := setupLateHead(t)
+		driftGenesisTime(f, 3, orphanLateBlockProposingEarly)
+		require.Equal(t, indexToHash(2), f.GetProposerHead())
+	})
+	t.Run("head changes unrealized justification", func(t *testing.T) {
+		f := setupLateHead(t)
+		driftGenesisTime(f, 3, 0)
+		f.store.headNode.unrealizedJustifiedEpoch = 1
+		require.Equal(t, indexToHash(2), f.GetProposerHead())
+	})
+	t.Run("head strongly attested", func(t *testing.T) {
+		f := setupLateHead(t)
+		driftGenesisTime(f, 3, 0)
+		f.store.headNode.weight = 30
+		require.Equal(t, indexToHash(2), f.GetProposerHead())
+	})
+}
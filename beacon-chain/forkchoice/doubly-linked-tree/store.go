@@ -121,6 +121,7 @@ func (s *Store) insert(ctx context.Context,
 	justifiedEpoch, finalizedEpoch types.Epoch) (*Node, error) {
 	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.insert")
 	defer span.End()
+	defer observeDuration(insertDuration, time.Now())
 
 	s.nodesLock.Lock()
 	defer s.nodesLock.Unlock()
@@ -142,6 +143,7 @@ func (s *Store) insert(ctx context.Context,
 		unrealizedFinalizedEpoch: finalizedEpoch,
 		optimistic:               true,
 		payloadHash:              payloadHash,
+		timestamp:                uint64(time.Now().Unix()),
 	}
 
 	s.nodeByPayload[payloadHash] = n
@@ -156,7 +158,7 @@ func (s *Store) insert(ctx context.Context,
 	} else {
 		parent.children = append(parent.children, n)
 		// Apply proposer boost
-		timeNow := uint64(time.Now().Unix())
+		timeNow := n.timestamp
 		if timeNow < s.genesisTime {
 			return n, nil
 		}
@@ -236,6 +238,7 @@ func (s *Store) prune(ctx context.Context) error {
 	}
 
 	// Prune nodeByRoot starting from root
+	start := time.Now()
 	if err := s.pruneFinalizedNodeByRootMap(ctx, s.treeRootNode, finalizedNode); err != nil {
 		return err
 	}
@@ -243,7 +246,9 @@ func (s *Store) prune(ctx context.Context) error {
 	finalizedNode.parent = nil
 	s.treeRootNode = finalizedNode
 
+	observeDuration(pruneDuration, start)
 	prunedCount.Inc()
+	nodeCount.Set(float64(len(s.nodeByRoot)))
 	return nil
 }
 
@@ -41,6 +41,7 @@ type Store struct {
 	highestReceivedSlot           types.Slot                            // The highest received slot in the chain.
 	receivedBlocksLastEpoch       [fieldparams.SlotsPerEpoch]types.Slot // Using `highestReceivedSlot`. The slot of blocks received in the last epoch.
 	allTipsAreInvalid             bool                                  // tracks if all tips are not viable for head
+	committeeWeight               uint64                                // tracks the total active validator balance divided by the number of slots per Epoch.
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
@@ -59,6 +60,7 @@ type Node struct {
 	weight                   uint64                       // weight of this node: the total balance including children
 	bestDescendant           *Node                        // bestDescendant node of this node.
 	optimistic               bool                         // whether the block has been fully validated or not
+	timestamp                uint64                       // The timestamp when the node was inserted.
 }
 
 // Vote defines an individual validator's vote.
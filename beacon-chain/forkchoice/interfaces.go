@@ -60,8 +60,11 @@ type Getter interface {
 	JustifiedPayloadBlockHash() [32]byte
 	BestJustifiedCheckpoint() *forkchoicetypes.Checkpoint
 	NodeCount() int
+	Nodes() []*forkchoicetypes.Node
 	HighestReceivedBlockSlot() types.Slot
 	ReceivedBlocksLastEpoch() (uint64, error)
+	ShouldOverrideFCU() bool
+	GetProposerHead() [32]byte
 }
 
 // Setter allows to set forkchoice information
@@ -1013,6 +1013,18 @@ func (f *ForkChoice) CachedHeadRoot() [32]byte {
 	return f.store.lastHeadRoot
 }
 
+// ShouldOverrideFCU always returns false, as proposer reorgs are only supported by the doubly
+// linked tree fork choice store.
+func (*ForkChoice) ShouldOverrideFCU() bool {
+	return false
+}
+
+// GetProposerHead returns the last cached head root, as proposer reorgs are only supported by
+// the doubly linked tree fork choice store.
+func (f *ForkChoice) GetProposerHead() [32]byte {
+	return f.CachedHeadRoot()
+}
+
 // FinalizedPayloadBlockHash returns the hash of the payload at the finalized checkpoint
 func (f *ForkChoice) FinalizedPayloadBlockHash() [32]byte {
 	f.store.nodesLock.RLock()
@@ -1069,3 +1081,25 @@ func (f *ForkChoice) ReceivedBlocksLastEpoch() (uint64, error) {
 	}
 	return count, nil
 }
+
+// Nodes returns a snapshot of the nodes of the fork choice tree, parents before their children.
+func (f *ForkChoice) Nodes() []*forkchoicetypes.Node {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	nodes := make([]*forkchoicetypes.Node, len(f.store.nodes))
+	for i, n := range f.store.nodes {
+		nodes[i] = &forkchoicetypes.Node{
+			Slot:           n.slot,
+			Root:           n.root,
+			PayloadHash:    n.payloadHash,
+			JustifiedEpoch: n.justifiedEpoch,
+			FinalizedEpoch: n.finalizedEpoch,
+			Weight:         n.weight,
+			Optimistic:     n.status == syncing,
+		}
+		if n.parent != NonExistentNode {
+			nodes[i].ParentRoot = f.store.nodes[n.parent].root
+		}
+	}
+	return nodes
+}
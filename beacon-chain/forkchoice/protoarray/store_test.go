@@ -1406,3 +1406,35 @@ func TestForkChoice_ReceivedBlocksLastEpoch(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint64(0), count)
 }
+
+func TestStore_Nodes(t *testing.T) {
+	f := setup(1, 1)
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	st, blkRoot, err := prepareForkchoiceState(ctx, 1, indexToHash(1), zeroHash, zeroHash, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 2, indexToHash(2), indexToHash(1), indexToHash(102), 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	st, blkRoot, err = prepareForkchoiceState(ctx, 3, indexToHash(3), indexToHash(1), zeroHash, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, blkRoot))
+	f.store.nodes[f.store.nodesIndices[indexToHash(1)]].status = valid
+
+	nodes := f.Nodes()
+	require.Equal(t, 4, len(nodes))
+	assert.Equal(t, zeroHash, nodes[0].Root)
+	assert.Equal(t, [32]byte{}, nodes[0].ParentRoot)
+	assert.Equal(t, indexToHash(1), nodes[1].Root)
+	assert.Equal(t, zeroHash, nodes[1].ParentRoot)
+	assert.Equal(t, false, nodes[1].Optimistic)
+	assert.Equal(t, types.Slot(2), nodes[2].Slot)
+	assert.Equal(t, indexToHash(2), nodes[2].Root)
+	assert.Equal(t, indexToHash(1), nodes[2].ParentRoot)
+	assert.Equal(t, indexToHash(102), nodes[2].PayloadHash)
+	assert.Equal(t, types.Epoch(1), nodes[2].JustifiedEpoch)
+	assert.Equal(t, true, nodes[2].Optimistic)
+	assert.Equal(t, indexToHash(3), nodes[3].Root)
+	assert.Equal(t, indexToHash(1), nodes[3].ParentRoot)
+}
@@ -29,3 +29,16 @@ type BlockAndCheckpoints struct {
 	JustifiedCheckpoint *ethpb.Checkpoint
 	FinalizedCheckpoint *ethpb.Checkpoint
 }
+
+// Node is a snapshot of a block node of the fork choice store, used to export the
+// fork choice tree outside of forkchoice.
+type Node struct {
+	Slot           types.Slot
+	Root           [fieldparams.RootLength]byte
+	ParentRoot     [fieldparams.RootLength]byte // zero for the root of the tree.
+	PayloadHash    [fieldparams.RootLength]byte
+	JustifiedEpoch types.Epoch
+	FinalizedEpoch types.Epoch
+	Weight         uint64
+	Optimistic     bool
+}
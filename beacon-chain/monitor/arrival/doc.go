@@ -0,0 +1,8 @@
+/*
+Package arrival records when the blocks and attestations of each slot arrive, relative to the
+start of the slot. Once the canonical block of a slot is known, the arrival delays of the block
+and of the attestations voting for it are aggregated in percentiles exposed as metrics and
+through a debug endpoint, so operators can tell whether missed head votes come from their own
+latency or from late proposers.
+*/
+package arrival
@@ -0,0 +1,29 @@
+package arrival
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	delayObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
+	blockArrivalDelay = promauto.NewSummary(prometheus.SummaryOpts{
+		Name:       "canonical_block_arrival_delay_milliseconds",
+		Help:       "Delay between the start of the slot and the arrival of its canonical block over gossip.",
+		Objectives: delayObjectives,
+	})
+	attestationArrivalDelay = promauto.NewSummary(prometheus.SummaryOpts{
+		Name:       "canonical_attestation_arrival_delay_milliseconds",
+		Help:       "Delay between the start of the slot and the arrival of the unaggregated attestations voting for its canonical block.",
+		Objectives: delayObjectives,
+	})
+	attestationsOtherHead = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attestations_voting_other_head_total",
+		Help: "Number of unaggregated attestations received which don't vote for the canonical block of their slot.",
+	})
+	slotsWithoutCanonicalBlock = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "arrival_slots_without_canonical_block_total",
+		Help: "Number of slots with arrivals for which no canonical block was received over gossip.",
+	})
+)
@@ -0,0 +1,276 @@
+package arrival
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+)
+
+const (
+	// trackedSlots is the number of most recent slots for which arrivals are kept.
+	trackedSlots = 64
+	// settleDelaySlots is the number of slots after which the arrivals of a slot are reported in
+	// metrics, most attestations of a slot being received during the slot and the next one.
+	settleDelaySlots = 2
+	// maxAttestationsPerSlot bounds the number of attestation arrivals kept for a slot.
+	maxAttestationsPerSlot = 8192
+)
+
+// Percentiles of arrival delays since the start of the slot.
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// SlotSummary holds the arrivals of a slot with respect to the canonical chain.
+type SlotSummary struct {
+	Slot types.Slot
+	// HeadRoot is the root of the canonical block which the attestations of the slot should vote
+	// for, that is the block of the slot or of the last slot before it with a block.
+	HeadRoot    [32]byte
+	HasHeadRoot bool
+	// BlockDelay is the arrival delay of the canonical block of the slot, if the slot has one
+	// which was received over gossip.
+	BlockDelay            time.Duration
+	HasBlock              bool
+	Attestations          int
+	AttestationsOtherHead int
+	AttestationDelay      Percentiles
+}
+
+// Summary holds the arrivals of the last slots and the percentiles of their delays.
+type Summary struct {
+	Slots            []*SlotSummary
+	BlockDelay       Percentiles
+	AttestationDelay Percentiles
+}
+
+type slotArrivals struct {
+	blocks       map[[32]byte]time.Duration
+	attestations map[[32]byte][]time.Duration
+	count        int
+	settled      bool
+}
+
+// Tracker records the arrival delays of the blocks and unaggregated attestations received over
+// gossip, and the canonical block of each slot. Recording on a nil tracker does nothing.
+type Tracker struct {
+	slots     map[types.Slot]*slotArrivals
+	canonical map[types.Slot][32]byte
+	latest    types.Slot
+	lock      sync.Mutex
+}
+
+// NewTracker creates a new arrival tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		slots:     make(map[types.Slot]*slotArrivals),
+		canonical: make(map[types.Slot][32]byte),
+	}
+}
+
+// RecordBlock records the arrival of a block, delay being the time elapsed since the start of
+// its slot.
+func (t *Tracker) RecordBlock(slot types.Slot, root [32]byte, delay time.Duration) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	a := t.arrivals(slot)
+	if a == nil {
+		return
+	}
+	if _, ok := a.blocks[root]; !ok {
+		a.blocks[root] = delay
+	}
+}
+
+// RecordAttestation records the arrival of an unaggregated attestation of the slot voting for
+// the given head block root, delay being the time elapsed since the start of the slot.
+func (t *Tracker) RecordAttestation(slot types.Slot, headRoot [32]byte, delay time.Duration) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	a := t.arrivals(slot)
+	if a == nil || a.count >= maxAttestationsPerSlot {
+		return
+	}
+	a.attestations[headRoot] = append(a.attestations[headRoot], delay)
+	a.count++
+}
+
+// RecordHead records that the block of the slot with the given root became the head of the
+// chain. Blocks of later slots recorded before are no longer canonical.
+func (t *Tracker) RecordHead(slot types.Slot, root [32]byte) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for s := range t.canonical {
+		if s > slot {
+			delete(t.canonical, s)
+		}
+	}
+	t.canonical[slot] = root
+	t.advance(slot)
+}
+
+// Summary returns the arrivals of the last n slots with recorded arrivals, oldest first, and the
+// percentiles of the delays of the canonical blocks and of the attestations voting for them.
+func (t *Tracker) Summary(n int) *Summary {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tracked := make([]types.Slot, 0, len(t.slots))
+	for s := range t.slots {
+		tracked = append(tracked, s)
+	}
+	sort.Slice(tracked, func(i, j int) bool {
+		return tracked[i] < tracked[j]
+	})
+	if n >= 0 && n < len(tracked) {
+		tracked = tracked[len(tracked)-n:]
+	}
+
+	summary := &Summary{Slots: make([]*SlotSummary, len(tracked))}
+	var blockDelays, attDelays []time.Duration
+	for i, s := range tracked {
+		ss, delays := t.slotSummary(s)
+		summary.Slots[i] = ss
+		if ss.HasBlock {
+			blockDelays = append(blockDelays, ss.BlockDelay)
+		}
+		attDelays = append(attDelays, delays...)
+	}
+	summary.BlockDelay = percentiles(blockDelays)
+	summary.AttestationDelay = percentiles(attDelays)
+	return summary
+}
+
+// slotSummary summarizes the arrivals of the slot, also returning the delays of the attestations
+// voting for the canonical head.
+func (t *Tracker) slotSummary(slot types.Slot) (*SlotSummary, []time.Duration) {
+	a := t.slots[slot]
+	ss := &SlotSummary{Slot: slot}
+	headSlot, root, ok := t.headAt(slot)
+	if !ok {
+		ss.AttestationsOtherHead = a.count
+		return ss, nil
+	}
+	ss.HeadRoot = root
+	ss.HasHeadRoot = true
+	if headSlot == slot {
+		ss.BlockDelay, ss.HasBlock = a.blocks[root]
+	}
+	delays := a.attestations[root]
+	ss.Attestations = len(delays)
+	ss.AttestationsOtherHead = a.count - len(delays)
+	ss.AttestationDelay = percentiles(delays)
+	return ss, delays
+}
+
+// headAt returns the slot and root of the canonical block which was the head at the given slot.
+func (t *Tracker) headAt(slot types.Slot) (types.Slot, [32]byte, bool) {
+	var headSlot types.Slot
+	var root [32]byte
+	found := false
+	for s, r := range t.canonical {
+		if s <= slot && (!found || s > headSlot) {
+			headSlot, root, found = s, r, true
+		}
+	}
+	return headSlot, root, found
+}
+
+// arrivals returns the arrivals of the slot, creating them if needed, or nil if the slot is too
+// old to be tracked.
+func (t *Tracker) arrivals(slot types.Slot) *slotArrivals {
+	if slot+trackedSlots <= t.latest {
+		return nil
+	}
+	a, ok := t.slots[slot]
+	if !ok {
+		a = &slotArrivals{
+			blocks:       make(map[[32]byte]time.Duration),
+			attestations: make(map[[32]byte][]time.Duration),
+		}
+		t.slots[slot] = a
+	}
+	t.advance(slot)
+	return a
+}
+
+// advance moves the latest slot seen, reporting the arrivals of the slots which are old enough
+// in metrics and pruning the slots which are no longer tracked.
+func (t *Tracker) advance(slot types.Slot) {
+	if slot <= t.latest {
+		return
+	}
+	t.latest = slot
+	for s, a := range t.slots {
+		if !a.settled && s+settleDelaySlots <= slot {
+			t.settle(s, a)
+		}
+	}
+	if slot < trackedSlots {
+		return
+	}
+	oldest := slot - trackedSlots + 1
+	for s := range t.slots {
+		if s < oldest {
+			delete(t.slots, s)
+		}
+	}
+	// The canonical block of a pruned slot may still be the head of the tracked slots after it.
+	headSlot, _, ok := t.headAt(oldest)
+	for s := range t.canonical {
+		if s < oldest && (!ok || s != headSlot) {
+			delete(t.canonical, s)
+		}
+	}
+}
+
+// settle reports the arrivals of the slot in metrics.
+func (t *Tracker) settle(slot types.Slot, a *slotArrivals) {
+	a.settled = true
+	ss, delays := t.slotSummary(slot)
+	if ss.HasBlock {
+		blockArrivalDelay.Observe(float64(ss.BlockDelay.Milliseconds()))
+	} else {
+		slotsWithoutCanonicalBlock.Inc()
+	}
+	for _, d := range delays {
+		attestationArrivalDelay.Observe(float64(d.Milliseconds()))
+	}
+	attestationsOtherHead.Add(float64(ss.AttestationsOtherHead))
+}
+
+// percentiles computes the nearest-rank percentiles of the delays.
+func percentiles(delays []time.Duration) Percentiles {
+	if len(delays) == 0 {
+		return Percentiles{}
+	}
+	sorted := make([]time.Duration, len(delays))
+	copy(sorted, delays)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := func(p int) time.Duration {
+		i := (p*len(sorted)+99)/100 - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return Percentiles{P50: rank(50), P90: rank(90), P99: rank(99)}
+}
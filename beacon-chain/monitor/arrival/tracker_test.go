@@ -0,0 +1,100 @@
+package arrival
+
+import (
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestTracker_Summary(t *testing.T) {
+	tr := NewTracker()
+	canonical, orphaned := [32]byte{'a'}, [32]byte{'b'}
+	tr.RecordBlock(1, canonical, 500*time.Millisecond)
+	tr.RecordBlock(1, orphaned, 300*time.Millisecond)
+	tr.RecordHead(1, orphaned)
+	// The later head at the same slot replaces the previous one.
+	tr.RecordHead(1, canonical)
+	for i := 1; i <= 10; i++ {
+		tr.RecordAttestation(1, canonical, time.Duration(i)*time.Second)
+	}
+	tr.RecordAttestation(1, orphaned, 4*time.Second)
+	// Slot 2 is skipped, its attestations vote for the block of slot 1.
+	tr.RecordAttestation(2, canonical, 3*time.Second)
+
+	summary := tr.Summary(-1)
+	require.Equal(t, 2, len(summary.Slots))
+	s := summary.Slots[0]
+	assert.Equal(t, types.Slot(1), s.Slot)
+	assert.Equal(t, canonical, s.HeadRoot)
+	assert.Equal(t, true, s.HasBlock)
+	assert.Equal(t, 500*time.Millisecond, s.BlockDelay)
+	assert.Equal(t, 10, s.Attestations)
+	assert.Equal(t, 1, s.AttestationsOtherHead)
+	assert.Equal(t, Percentiles{P50: 5 * time.Second, P90: 9 * time.Second, P99: 10 * time.Second}, s.AttestationDelay)
+
+	s = summary.Slots[1]
+	assert.Equal(t, types.Slot(2), s.Slot)
+	assert.Equal(t, canonical, s.HeadRoot)
+	assert.Equal(t, false, s.HasBlock)
+	assert.Equal(t, 1, s.Attestations)
+
+	assert.Equal(t, Percentiles{P50: 500 * time.Millisecond, P90: 500 * time.Millisecond, P99: 500 * time.Millisecond}, summary.BlockDelay)
+	assert.Equal(t, 5*time.Second, summary.AttestationDelay.P50)
+
+	summary = tr.Summary(1)
+	require.Equal(t, 1, len(summary.Slots))
+	assert.Equal(t, types.Slot(2), summary.Slots[0].Slot)
+
+	var nilTracker *Tracker
+	nilTracker.RecordBlock(1, canonical, time.Second)
+	nilTracker.RecordAttestation(1, canonical, time.Second)
+	nilTracker.RecordHead(1, canonical)
+}
+
+func TestTracker_Reorg(t *testing.T) {
+	tr := NewTracker()
+	tr.RecordBlock(1, [32]byte{1}, time.Second)
+	tr.RecordBlock(2, [32]byte{2}, time.Second)
+	tr.RecordHead(1, [32]byte{1})
+	tr.RecordHead(2, [32]byte{2})
+	// The block of slot 2 is reorged out by a block of slot 3 built on the block of slot 1.
+	tr.RecordHead(1, [32]byte{1})
+	tr.RecordBlock(3, [32]byte{3}, time.Second)
+	tr.RecordHead(3, [32]byte{3})
+
+	summary := tr.Summary(-1)
+	require.Equal(t, 3, len(summary.Slots))
+	assert.Equal(t, [32]byte{1}, summary.Slots[1].HeadRoot)
+	assert.Equal(t, false, summary.Slots[1].HasBlock)
+	assert.Equal(t, true, summary.Slots[2].HasBlock)
+}
+
+func TestTracker_Prune(t *testing.T) {
+	tr := NewTracker()
+	tr.RecordHead(0, [32]byte{'g'})
+	for i := types.Slot(1); i < 2*trackedSlots; i++ {
+		tr.RecordAttestation(i, [32]byte{'g'}, time.Second)
+	}
+	summary := tr.Summary(-1)
+	require.Equal(t, trackedSlots, len(summary.Slots))
+	assert.Equal(t, types.Slot(trackedSlots), summary.Slots[0].Slot)
+	// The canonical block of a pruned slot is kept while it is the head of tracked slots.
+	assert.Equal(t, [32]byte{'g'}, summary.Slots[0].HeadRoot)
+
+	// Arrivals of slots which are no longer tracked are ignored.
+	tr.RecordAttestation(1, [32]byte{'g'}, time.Second)
+	assert.Equal(t, trackedSlots, len(tr.Summary(-1).Slots))
+}
+
+func TestPercentiles(t *testing.T) {
+	assert.Equal(t, Percentiles{}, percentiles(nil))
+	assert.Equal(t, Percentiles{P50: time.Second, P90: time.Second, P99: time.Second}, percentiles([]time.Duration{time.Second}))
+	delays := make([]time.Duration, 0, 200)
+	for i := 200; i > 0; i-- {
+		delays = append(delays, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, Percentiles{P50: 100 * time.Millisecond, P90: 180 * time.Millisecond, P99: 198 * time.Millisecond}, percentiles(delays))
+}
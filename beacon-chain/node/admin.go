@@ -0,0 +1,52 @@
+package node
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
+)
+
+// registerAdminHandlers registers the admin endpoints of the REST API when an admin auth secret
+// is configured. Requests to them must carry a JWT token signed with the secret, as they change
+// the behavior of the node.
+func (b *BeaconNode) registerAdminHandlers() error {
+	secretFile := b.cliCtx.String(flags.AdminAuthSecretFlag.Name)
+	if secretFile == "" {
+		return nil
+	}
+	enc, err := file.ReadFileAsBytes(secretFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read admin auth secret")
+	}
+	secret, err := network.ParseJWTSecret(enc)
+	if err != nil {
+		return errors.Wrapf(err, "invalid admin auth secret in file %s", secretFile)
+	}
+	b.router.HandleFunc("/prysm/v1/admin/maintenance", network.WithAuthorization(secret, b.maintenanceHandler)).
+		Methods(http.MethodGet, http.MethodPost, http.MethodDelete)
+	b.router.HandleFunc("/prysm/v1/admin/resync", network.WithAuthorization(secret, b.resyncHandler)).
+		Methods(http.MethodPost)
+
+	h := debug.NewAdminHandlers(b.ctx, debug.NewProfiler())
+	b.router.HandleFunc("/prysm/v1/admin/profiles/{kind}", network.WithAuthorization(secret, h.StartProfile)).
+		Methods(http.MethodPost)
+	b.router.HandleFunc("/prysm/v1/admin/profiles/{kind}", network.WithAuthorization(secret, h.GetProfile)).
+		Methods(http.MethodGet)
+	b.router.HandleFunc("/prysm/v1/admin/runtime", network.WithAuthorization(secret, h.GetRuntimeSettings)).
+		Methods(http.MethodGet)
+	b.router.HandleFunc("/prysm/v1/admin/runtime", network.WithAuthorization(secret, h.TuneRuntimeSettings)).
+		Methods(http.MethodPost)
+
+	var p *p2p.Service
+	if err := b.services.FetchService(&p); err != nil {
+		return err
+	}
+	b.router.HandleFunc("/prysm/v1/admin/enr", network.WithAuthorization(secret, p.ENRHandler)).
+		Methods(http.MethodGet, http.MethodPost)
+	return nil
+}
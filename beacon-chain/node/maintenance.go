@@ -0,0 +1,201 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	regularsync "github.com/prysmaticlabs/prysm/v3/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+)
+
+// Maximum amount of time to wait for in-flight req/resp requests to complete
+// before flushing the node's caches and database.
+const maintenanceDrainTimeout = 30 * time.Second
+
+// Interval at which the number of in-flight req/resp requests is polled while draining.
+const maintenanceDrainPollInterval = 100 * time.Millisecond
+
+// maintenance keeps track of the progress of draining the node ahead of a planned restart.
+type maintenance struct {
+	lock        sync.RWMutex
+	enabled     bool
+	flushed     bool
+	err         error
+	cancelDrain context.CancelFunc
+}
+
+// maintenanceStatus is the JSON response served by the maintenance admin endpoint.
+type maintenanceStatus struct {
+	Enabled          bool   `json:"maintenance_mode"`
+	InFlightRequests int    `json:"in_flight_requests"`
+	Flushed          bool   `json:"flushed"`
+	SafeToStop       bool   `json:"safe_to_stop"`
+	Error            string `json:"error,omitempty"`
+}
+
+// maintenanceHandler serves the maintenance admin endpoint. A POST request puts the node into
+// maintenance mode: no new gossip subscriptions are accepted, in-flight req/resp requests are
+// allowed to finish and caches are flushed to the database. A DELETE request takes the node out
+// of maintenance mode, for instance when the planned restart is called off. Any request returns
+// the current drain status, including whether it is safe to stop the process.
+func (b *BeaconNode) maintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := b.enterMaintenanceMode(); err != nil {
+			log.WithError(err).Error("Could not enter maintenance mode")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		if err := b.exitMaintenanceMode(); err != nil {
+			log.WithError(err).Error("Could not exit maintenance mode")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	status := b.maintenanceStatus()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.WithError(err).Error("Failed to render maintenance status")
+	}
+}
+
+// enterMaintenanceMode stops the node from taking on new network work and starts draining it
+// in the background. Calling it more than once is a no-op.
+func (b *BeaconNode) enterMaintenanceMode() error {
+	b.maintenance.lock.Lock()
+	defer b.maintenance.lock.Unlock()
+	if b.maintenance.enabled {
+		return nil
+	}
+
+	var p *p2p.Service
+	if err := b.services.FetchService(&p); err != nil {
+		return errors.Wrap(err, "could not fetch p2p service")
+	}
+	var s *regularsync.Service
+	if err := b.services.FetchService(&s); err != nil {
+		return errors.Wrap(err, "could not fetch sync service")
+	}
+	p.EnterMaintenanceMode()
+	s.Drain()
+	ctx, cancel := context.WithCancel(b.ctx)
+	b.maintenance.enabled = true
+	b.maintenance.cancelDrain = cancel
+	log.Info("Entered maintenance mode, draining node")
+
+	go b.drain(ctx, s)
+	return nil
+}
+
+// exitMaintenanceMode stops draining the node and makes it accept new gossip subscriptions and
+// req/resp requests again. Calling it while the node is not in maintenance mode is a no-op.
+func (b *BeaconNode) exitMaintenanceMode() error {
+	b.maintenance.lock.Lock()
+	defer b.maintenance.lock.Unlock()
+	if !b.maintenance.enabled {
+		return nil
+	}
+
+	var p *p2p.Service
+	if err := b.services.FetchService(&p); err != nil {
+		return errors.Wrap(err, "could not fetch p2p service")
+	}
+	var s *regularsync.Service
+	if err := b.services.FetchService(&s); err != nil {
+		return errors.Wrap(err, "could not fetch sync service")
+	}
+	b.maintenance.cancelDrain()
+	s.Resume()
+	p.ExitMaintenanceMode()
+	b.maintenance.enabled = false
+	b.maintenance.flushed = false
+	b.maintenance.err = nil
+	b.maintenance.cancelDrain = nil
+	log.Info("Exited maintenance mode")
+	return nil
+}
+
+// drain waits for in-flight req/resp requests to complete and then flushes the node's caches
+// and database to disk. Draining stops when the context is canceled on exiting maintenance mode.
+func (b *BeaconNode) drain(ctx context.Context, s *regularsync.Service) {
+	waitCtx, cancel := context.WithTimeout(ctx, maintenanceDrainTimeout)
+	defer cancel()
+	ticker := time.NewTicker(maintenanceDrainPollInterval)
+	defer ticker.Stop()
+	for s.InFlightRPCs() > 0 {
+		select {
+		case <-ticker.C:
+		case <-waitCtx.Done():
+			if ctx.Err() == nil {
+				log.WithField("inFlightRequests", s.InFlightRPCs()).Warn("Timed out waiting for in-flight requests to complete")
+			}
+		}
+		if waitCtx.Err() != nil {
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	err := b.flush(ctx)
+	b.maintenance.lock.Lock()
+	defer b.maintenance.lock.Unlock()
+	if ctx.Err() != nil {
+		// Maintenance mode was exited while flushing.
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not flush node during maintenance")
+		b.maintenance.err = err
+		return
+	}
+	b.maintenance.flushed = true
+	log.Info("Node is drained and safe to stop")
+}
+
+// flush persists the last finalized state and any cached database data to disk.
+func (b *BeaconNode) flush(ctx context.Context) error {
+	finalized, err := b.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if b.stateGen != nil {
+		if err := b.stateGen.ForceCheckpoint(ctx, finalized.Root); err != nil {
+			return errors.Wrapf(err, "could not save finalized state %#x", bytesutil.Trunc(finalized.Root))
+		}
+	}
+	return b.db.Flush(ctx)
+}
+
+// maintenanceStatus returns the current drain status of the node.
+func (b *BeaconNode) maintenanceStatus() *maintenanceStatus {
+	b.maintenance.lock.RLock()
+	defer b.maintenance.lock.RUnlock()
+	status := &maintenanceStatus{
+		Enabled: b.maintenance.enabled,
+		Flushed: b.maintenance.flushed,
+	}
+	if b.maintenance.err != nil {
+		status.Error = b.maintenance.err.Error()
+	}
+	if status.Enabled && b.services != nil {
+		var s *regularsync.Service
+		if err := b.services.FetchService(&s); err == nil {
+			status.InFlightRequests = s.InFlightRPCs()
+		}
+	}
+	status.SafeToStop = status.Flushed && status.InFlightRequests == 0
+	return status
+}
@@ -0,0 +1,91 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dbtest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	regularsync "github.com/prysmaticlabs/prysm/v3/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v3/runtime"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestMaintenanceHandler_Status(t *testing.T) {
+	b := &BeaconNode{}
+
+	rr := httptest.NewRecorder()
+	b.maintenanceHandler(rr, httptest.NewRequest(http.MethodGet, "/maintenance", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	status := &maintenanceStatus{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), status))
+	require.Equal(t, false, status.Enabled)
+	require.Equal(t, false, status.SafeToStop)
+
+	b.maintenance.enabled = true
+	b.maintenance.flushed = true
+	rr = httptest.NewRecorder()
+	b.maintenanceHandler(rr, httptest.NewRequest(http.MethodGet, "/maintenance", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), status))
+	require.Equal(t, true, status.Enabled)
+	require.Equal(t, true, status.SafeToStop)
+
+	b.maintenance.flushed = false
+	b.maintenance.err = errors.New("flush failed")
+	rr = httptest.NewRecorder()
+	b.maintenanceHandler(rr, httptest.NewRequest(http.MethodGet, "/maintenance", nil))
+	status = &maintenanceStatus{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), status))
+	require.Equal(t, false, status.SafeToStop)
+	require.Equal(t, "flush failed", status.Error)
+}
+
+func TestMaintenanceHandler_MethodNotAllowed(t *testing.T) {
+	b := &BeaconNode{}
+	rr := httptest.NewRecorder()
+	b.maintenanceHandler(rr, httptest.NewRequest(http.MethodPut, "/maintenance", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestMaintenanceHandler_EnterExit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := &p2p.Service{}
+	s := &regularsync.Service{}
+	b := &BeaconNode{
+		ctx:      ctx,
+		db:       dbtest.SetupDB(t),
+		services: runtime.NewServiceRegistry(),
+	}
+	require.NoError(t, b.services.RegisterService(p))
+	require.NoError(t, b.services.RegisterService(s))
+
+	rr := httptest.NewRecorder()
+	b.maintenanceHandler(rr, httptest.NewRequest(http.MethodPost, "/maintenance", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, true, p.InMaintenanceMode())
+	require.Equal(t, true, s.Draining())
+	for i := 0; !b.maintenanceStatus().SafeToStop; i++ {
+		require.Equal(t, true, i < 500, "Timed out waiting for the node to be drained")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	rr = httptest.NewRecorder()
+	b.maintenanceHandler(rr, httptest.NewRequest(http.MethodDelete, "/maintenance", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	status := &maintenanceStatus{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), status))
+	require.Equal(t, false, status.Enabled)
+	require.Equal(t, false, status.SafeToStop)
+	require.Equal(t, false, p.InMaintenanceMode())
+	require.Equal(t, false, s.Draining())
+
+	// Exiting maintenance mode again is a no-op.
+	require.NoError(t, b.exitMaintenanceMode())
+}
@@ -16,7 +16,9 @@ import (
 	"syscall"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	prom "github.com/prometheus/client_golang/prometheus"
 	apigateway "github.com/prysmaticlabs/prysm/v3/api/gateway"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
@@ -33,14 +35,17 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/gateway"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/node/registration"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/archive"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
@@ -49,6 +54,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/sync/genesis"
 	initialsync "github.com/prysmaticlabs/prysm/v3/beacon-chain/sync/initial-sync"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/watchdog"
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v3/config/features"
@@ -70,6 +76,13 @@ const testSkipPowFlag = "test-skip-pow"
 // 128MB max message size when enabling debug endpoints.
 const debugGrpcMaxMsgSize = 1 << 27
 
+const (
+	// Default directory of the diagnostics bundles of the chain progress watchdog, in the data directory.
+	watchdogDirName = "diagnostics"
+	// Number of recent log entries added to the diagnostics bundles.
+	watchdogLogEntries = 2000
+)
+
 // Used as a struct to keep cli flag options for configuring services
 // for the beacon node. We keep this as a separate struct to not pollute the actual BeaconNode
 // struct, as it is merely used to pass down configuration options into the appropriate services.
@@ -93,16 +106,21 @@ type BeaconNode struct {
 	slasherDB               db.SlasherDatabase
 	attestationPool         attestations.Pool
 	exitPool                voluntaryexits.PoolManager
+	blsToExecPool           blstoexec.PoolManager
 	slashingsPool           slashings.PoolManager
 	syncCommitteePool       synccommittee.Pool
 	depositCache            *depositcache.DepositCache
 	proposerIdsCache        *cache.ProposerPayloadIDsCache
+	slotTimelineCache       *cache.SlotTimelineCache
+	arrivalTracker          *arrival.Tracker
 	stateFeed               *event.Feed
 	blockFeed               *event.Feed
 	opFeed                  *event.Feed
 	forkChoiceStore         forkchoice.ForkChoicer
 	stateGen                *stategen.State
 	collector               *bcnodeCollector
+	registerer              prom.Registerer
+	inMemoryP2PTransport    bool
 	slasherBlockHeadersFeed *event.Feed
 	slasherAttestationsFeed *event.Feed
 	finalizedStateAtStartUp state.BeaconState
@@ -110,6 +128,8 @@ type BeaconNode struct {
 	blockchainFlagOpts      []blockchain.Option
 	GenesisInitializer      genesis.Initializer
 	CheckpointInitializer   checkpoint.Initializer
+	maintenance             maintenance
+	router                  *mux.Router
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -171,12 +191,17 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		opFeed:                  new(event.Feed),
 		attestationPool:         attestations.NewPool(),
 		exitPool:                voluntaryexits.NewPool(),
+		blsToExecPool:           blstoexec.NewPool(),
 		slashingsPool:           slashings.NewPool(),
 		syncCommitteePool:       synccommittee.NewPool(),
 		slasherBlockHeadersFeed: new(event.Feed),
 		slasherAttestationsFeed: new(event.Feed),
 		serviceFlagOpts:         &serviceFlagOpts{},
 		proposerIdsCache:        cache.NewProposerPayloadIDsCache(),
+		slotTimelineCache:       cache.NewSlotTimelineCache(),
+		arrivalTracker:          arrival.NewTracker(),
+		router:                  mux.NewRouter(),
+		registerer:              prom.DefaultRegisterer,
 	}
 
 	for _, opt := range opts {
@@ -262,6 +287,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Admin Handlers")
+	if err := beacon.registerAdminHandlers(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering GRPC Gateway Service")
 	if err := beacon.registerGRPCGateway(); err != nil {
 		return nil, err
@@ -272,6 +302,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Chain Progress Watchdog Service")
+	if err := beacon.registerWatchdogService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -282,7 +317,7 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	// db.DatabasePath is the path to the containing directory
 	// db.NewDBFilename expands that to the canonical full path using
 	// the same construction as NewDB()
-	c, err := newBeaconNodePromCollector(db.NewDBFilename(beacon.db.DatabasePath()))
+	c, err := newBeaconNodePromCollector(db.NewDBFilename(beacon.db.DatabasePath()), beacon.registerer)
 	if err != nil {
 		return nil, err
 	}
@@ -369,9 +404,14 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	clearDB := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearDB := cliCtx.Bool(cmd.ForceClearDB.Name)
 
+	syncPolicy, err := kv.ParseSyncPolicy(cliCtx.String(flags.DBSyncPolicy.Name))
+	if err != nil {
+		return err
+	}
+
 	log.WithField("database-path", dbPath).Info("Checking DB")
 
-	d, err := db.NewDB(b.ctx, dbPath)
+	d, err := db.NewDB(b.ctx, dbPath, kv.WithSyncPolicy(syncPolicy), kv.WithRegisterer(b.registerer))
 	if err != nil {
 		return err
 	}
@@ -393,7 +433,7 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 		if err := d.ClearDB(); err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
-		d, err = db.NewDB(b.ctx, dbPath)
+		d, err = db.NewDB(b.ctx, dbPath, kv.WithSyncPolicy(syncPolicy), kv.WithRegisterer(b.registerer))
 		if err != nil {
 			return errors.Wrap(err, "could not create new database")
 		}
@@ -405,7 +445,7 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 
 	b.db = d
 
-	depositCache, err := depositcache.New()
+	depositCache, err := depositcache.New(depositcache.WithStore(d))
 	if err != nil {
 		return errors.Wrap(err, "could not create deposit cache")
 	}
@@ -535,24 +575,28 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	}
 
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
-		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
-		StaticPeers:       slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
-		BootstrapNodeAddr: bootstrapNodeAddrs,
-		RelayNodeAddr:     cliCtx.String(cmd.RelayNode.Name),
-		DataDir:           dataDir,
-		LocalIP:           cliCtx.String(cmd.P2PIP.Name),
-		HostAddress:       cliCtx.String(cmd.P2PHost.Name),
-		HostDNS:           cliCtx.String(cmd.P2PHostDNS.Name),
-		PrivateKey:        cliCtx.String(cmd.P2PPrivKey.Name),
-		MetaDataDir:       cliCtx.String(cmd.P2PMetadata.Name),
-		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
-		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
-		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
-		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
-		DenyListCIDR:      slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
-		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
-		StateNotifier:     b,
-		DB:                b.db,
+		NoDiscovery:        cliCtx.Bool(cmd.NoDiscovery.Name),
+		StaticPeers:        slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
+		BootstrapNodeAddr:  bootstrapNodeAddrs,
+		RelayNodeAddr:      cliCtx.String(cmd.RelayNode.Name),
+		DataDir:            dataDir,
+		LocalIP:            cliCtx.String(cmd.P2PIP.Name),
+		HostAddress:        cliCtx.String(cmd.P2PHost.Name),
+		HostDNS:            cliCtx.String(cmd.P2PHostDNS.Name),
+		HostDNSRefresh:     cliCtx.Duration(cmd.P2PHostDNSRefresh.Name),
+		PrivateKey:         cliCtx.String(cmd.P2PPrivKey.Name),
+		PreSharedKeyFile:   cliCtx.String(cmd.P2PPreSharedKey.Name),
+		MetaDataDir:        cliCtx.String(cmd.P2PMetadata.Name),
+		TCPPort:            cliCtx.Uint(cmd.P2PTCPPort.Name),
+		UDPPort:            cliCtx.Uint(cmd.P2PUDPPort.Name),
+		MaxPeers:           cliCtx.Uint(cmd.P2PMaxPeers.Name),
+		AllowListCIDR:      cliCtx.String(cmd.P2PAllowList.Name),
+		DenyListCIDR:       slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		GossipOutboundCaps: slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PGossipOutboundCaps.Name)),
+		InMemoryTransport:  b.inMemoryP2PTransport,
+		EnableUPnP:         cliCtx.Bool(cmd.EnableUPnPFlag.Name),
+		StateNotifier:      b,
+		DB:                 b.db,
 	})
 	if err != nil {
 		return err
@@ -579,6 +623,9 @@ func (b *BeaconNode) fetchBuilderService() *builder.Service {
 func (b *BeaconNode) registerAttestationPool() error {
 	s, err := attestations.NewService(b.ctx, &attestations.Config{
 		Pool: b.attestationPool,
+		SyncPoint: func() error {
+			return b.db.SyncPoint(kv.SyncPointAttestationPool)
+		},
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not register atts pool service")
@@ -606,6 +653,7 @@ func (b *BeaconNode) registerBlockchainService() error {
 		blockchain.WithExecutionEngineCaller(web3Service),
 		blockchain.WithAttestationPool(b.attestationPool),
 		blockchain.WithExitPool(b.exitPool),
+		blockchain.WithBLSToExecPool(b.blsToExecPool),
 		blockchain.WithSlashingPool(b.slashingsPool),
 		blockchain.WithP2PBroadcaster(b.fetchP2P()),
 		blockchain.WithStateNotifier(b),
@@ -615,6 +663,8 @@ func (b *BeaconNode) registerBlockchainService() error {
 		blockchain.WithSlasherAttestationsFeed(b.slasherAttestationsFeed),
 		blockchain.WithFinalizedStateAtStartUp(b.finalizedStateAtStartUp),
 		blockchain.WithProposerIdsCache(b.proposerIdsCache),
+		blockchain.WithSlotTimelineCache(b.slotTimelineCache),
+		blockchain.WithArrivalTracker(b.arrivalTracker),
 	)
 	blockchainService, err := blockchain.NewService(b.ctx, opts...)
 	if err != nil {
@@ -627,7 +677,7 @@ func (b *BeaconNode) registerPOWChainService() error {
 	if b.cliCtx.Bool(testSkipPowFlag) {
 		return b.services.RegisterService(&execution.Service{})
 	}
-	bs, err := execution.NewPowchainCollector(b.ctx)
+	bs, err := execution.NewPowchainCollector(b.ctx, b.registerer)
 	if err != nil {
 		return err
 	}
@@ -671,6 +721,11 @@ func (b *BeaconNode) registerSyncService() error {
 		return err
 	}
 
+	limits, err := regularsync.ParseGossipValidationLimits(b.cliCtx.StringSlice(flags.GossipValidationLimitsFlag.Name))
+	if err != nil {
+		return err
+	}
+
 	rs := regularsync.NewService(
 		b.ctx,
 		regularsync.WithDatabase(b.db),
@@ -683,12 +738,16 @@ func (b *BeaconNode) registerSyncService() error {
 		regularsync.WithOperationNotifier(b),
 		regularsync.WithAttestationPool(b.attestationPool),
 		regularsync.WithExitPool(b.exitPool),
+		regularsync.WithBLSToExecPool(b.blsToExecPool),
 		regularsync.WithSlashingPool(b.slashingsPool),
 		regularsync.WithSyncCommsPool(b.syncCommitteePool),
 		regularsync.WithStateGen(b.stateGen),
 		regularsync.WithSlasherAttestationsFeed(b.slasherAttestationsFeed),
 		regularsync.WithSlasherBlockHeadersFeed(b.slasherBlockHeadersFeed),
 		regularsync.WithExecutionPayloadReconstructor(web3Service),
+		regularsync.WithProposerSlotIndexCache(b.proposerIdsCache),
+		regularsync.WithGossipValidationLimits(limits),
+		regularsync.WithArrivalTracker(b.arrivalTracker),
 	)
 	return b.services.RegisterService(rs)
 }
@@ -700,11 +759,12 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	}
 
 	is := initialsync.NewService(b.ctx, &initialsync.Config{
-		DB:            b.db,
-		Chain:         chainService,
-		P2P:           b.fetchP2P(),
-		StateNotifier: b,
-		BlockNotifier: b,
+		DB:              b.db,
+		Chain:           chainService,
+		P2P:             b.fetchP2P(),
+		StateNotifier:   b,
+		BlockNotifier:   b,
+		ReplayBlocksDir: b.cliCtx.String(flags.ReplayBlocksDir.Name),
 	})
 	return b.services.RegisterService(is)
 }
@@ -792,6 +852,16 @@ func (b *BeaconNode) registerRPCService() error {
 		maxMsgSize = int(math.Max(float64(maxMsgSize), debugGrpcMaxMsgSize))
 	}
 
+	var remoteArchive *archive.Archive
+	if url := b.cliCtx.String(flags.RemoteArchiveURL.Name); url != "" {
+		var err error
+		remoteArchive, err = archive.New(url, b.db)
+		if err != nil {
+			return errors.Wrap(err, "could not set up remote archive")
+		}
+		log.WithField("url", url).Info("Reading blocks and states older than local retention from remote archive")
+	}
+
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		ExecutionEngineCaller:         web3Service,
@@ -811,6 +881,7 @@ func (b *BeaconNode) registerRPCService() error {
 		HeadUpdater:                   chainService,
 		HeadFetcher:                   chainService,
 		CanonicalFetcher:              chainService,
+		AncestryFetcher:               chainService,
 		ForkFetcher:                   chainService,
 		FinalizationFetcher:           chainService,
 		BlockReceiver:                 chainService,
@@ -820,6 +891,7 @@ func (b *BeaconNode) registerRPCService() error {
 		OptimisticModeFetcher:         chainService,
 		AttestationsPool:              b.attestationPool,
 		ExitPool:                      b.exitPool,
+		BLSToExecPool:                 b.blsToExecPool,
 		SlashingsPool:                 b.slashingsPool,
 		SlashingChecker:               slasherService,
 		SyncCommitteeObjectPool:       b.syncCommitteePool,
@@ -836,8 +908,13 @@ func (b *BeaconNode) registerRPCService() error {
 		StateGen:                      b.stateGen,
 		EnableDebugRPCEndpoints:       enableDebugRPCEndpoints,
 		MaxMsgSize:                    maxMsgSize,
+		SlowRequestThreshold:          b.cliCtx.Duration(flags.RPCSlowRequestThreshold.Name),
 		ProposerIdsCache:              b.proposerIdsCache,
+		SlotTimelineCache:             b.slotTimelineCache,
+		ArrivalTracker:                b.arrivalTracker,
 		BlockBuilder:                  b.fetchBuilderService(),
+		Router:                        b.router,
+		Archive:                       remoteArchive,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -850,6 +927,15 @@ func (b *BeaconNode) registerPrometheusService(cliCtx *cli.Context) error {
 		panic(err)
 	}
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p", Handler: p.InfoHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p/nat", Handler: p.NATHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p/bandwidth", Handler: p.BandwidthHandler})
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/features", Handler: features.FlagStatusesHandler})
+
+	var e *execution.Service
+	if err := b.services.FetchService(&e); err != nil {
+		panic(err)
+	}
+	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/execution/capabilities", Handler: e.CapabilitiesHandler})
 
 	var c *blockchain.Service
 	if err := b.services.FetchService(&c); err != nil {
@@ -895,6 +981,7 @@ func (b *BeaconNode) registerGRPCGateway() error {
 	}
 
 	opts := []apigateway.Option{
+		apigateway.WithRouter(b.router),
 		apigateway.WithGatewayAddr(gatewayAddress),
 		apigateway.WithRemoteAddr(selfAddress),
 		apigateway.WithPbHandlers(muxs),
@@ -972,6 +1059,37 @@ func (b *BeaconNode) registerValidatorMonitorService() error {
 	return b.services.RegisterService(svc)
 }
 
+func (b *BeaconNode) registerWatchdogService(cliCtx *cli.Context) error {
+	stallSlots := cliCtx.Uint64(flags.ChainProgressWatchdogSlots.Name)
+	if stallSlots == 0 {
+		return nil
+	}
+	outputDir := cliCtx.String(flags.ChainProgressWatchdogDir.Name)
+	if outputDir == "" {
+		outputDir = filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), watchdogDirName)
+	}
+
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	logs := watchdog.NewLogBuffer(watchdogLogEntries)
+	logrus.AddHook(logs)
+	svc, err := watchdog.NewService(b.ctx, &watchdog.Config{
+		StallSlots:    types.Slot(stallSlots),
+		OutputDir:     outputDir,
+		ChainInfo:     chainService,
+		ForkChoicer:   b.forkChoiceStore,
+		PeersProvider: b.fetchP2P(),
+		StateNotifier: b,
+		Logs:          logs,
+	})
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerBuilderService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
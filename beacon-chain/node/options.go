@@ -1,6 +1,7 @@
 package node
 
 import (
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
@@ -32,3 +33,21 @@ func WithBuilderFlagOptions(opts []builder.Option) Option {
 		return nil
 	}
 }
+
+// WithRegisterer sets the registry the metrics collectors of the node are registered with, instead of the
+// global prometheus registry. It allows running several nodes in the same process.
+func WithRegisterer(r prometheus.Registerer) Option {
+	return func(bn *BeaconNode) error {
+		bn.registerer = r
+		return nil
+	}
+}
+
+// WithInMemoryP2PTransport connects the node to the peers running in the same process through memory
+// instead of the network.
+func WithInMemoryP2PTransport() Option {
+	return func(bn *BeaconNode) error {
+		bn.inMemoryP2PTransport = true
+		return nil
+	}
+}
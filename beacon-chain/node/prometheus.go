@@ -10,9 +10,10 @@ import (
 type bcnodeCollector struct {
 	DiskBeaconchainBytesTotal *prometheus.Desc
 	dbPath                    string
+	registerer                prometheus.Registerer
 }
 
-func newBeaconNodePromCollector(dbPath string) (*bcnodeCollector, error) {
+func newBeaconNodePromCollector(dbPath string, registerer prometheus.Registerer) (*bcnodeCollector, error) {
 	namespace := "bcnode"
 	c := &bcnodeCollector{
 		DiskBeaconchainBytesTotal: prometheus.NewDesc(
@@ -21,13 +22,14 @@ func newBeaconNodePromCollector(dbPath string) (*bcnodeCollector, error) {
 			nil,
 			nil,
 		),
-		dbPath: dbPath,
+		dbPath:     dbPath,
+		registerer: registerer,
 	}
 	_, err := c.getCurrentDbBytes()
 	if err != nil {
 		return nil, err
 	}
-	return c, prometheus.Register(c)
+	return c, registerer.Register(c)
 }
 
 func (bc *bcnodeCollector) Describe(ch chan<- *prometheus.Desc) {
@@ -57,5 +59,5 @@ func (bc *bcnodeCollector) getCurrentDbBytes() (float64, error) {
 }
 
 func (bc *bcnodeCollector) unregister() {
-	prometheus.Unregister(bc)
+	bc.registerer.Unregister(bc)
 }
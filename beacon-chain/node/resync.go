@@ -0,0 +1,62 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	regularsync "github.com/prysmaticlabs/prysm/v3/beacon-chain/sync"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+)
+
+// resyncResponse is the JSON response served by the resync admin endpoint.
+type resyncResponse struct {
+	StartSlot     uint64 `json:"start_slot"`
+	EndSlot       uint64 `json:"end_slot"`
+	Peer          string `json:"peer"`
+	Blocks        int    `json:"blocks"`
+	DeletedStates int    `json:"deleted_states"`
+}
+
+// resyncHandler serves the /prysm/v1/admin/resync admin endpoint. A POST request with the start_slot and
+// end_slot query parameters re-downloads the finalized blocks of the slot range from peers,
+// overwriting the blocks stored for the range and deleting its states, to repair a corrupted
+// part of the database without wiping the whole data directory.
+func (b *BeaconNode) resyncHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	start, err := strconv.ParseUint(r.URL.Query().Get("start_slot"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid start_slot: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	end, err := strconv.ParseUint(r.URL.Query().Get("end_slot"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid end_slot: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var s *regularsync.Service
+	if err := b.services.FetchService(&s); err != nil {
+		http.Error(w, "could not fetch sync service: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result, err := s.ResyncRange(r.Context(), types.Slot(start), types.Slot(end))
+	if err != nil {
+		log.WithError(err).Error("Could not resync slot range")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&resyncResponse{
+		StartSlot:     uint64(result.StartSlot),
+		EndSlot:       uint64(result.EndSlot),
+		Peer:          result.Peer.String(),
+		Blocks:        result.Blocks,
+		DeletedStates: result.DeletedStates,
+	}); err != nil {
+		log.WithError(err).Error("Failed to render resync result")
+	}
+}
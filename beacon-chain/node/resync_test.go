@@ -0,0 +1,28 @@
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestResyncHandler_InvalidRequest(t *testing.T) {
+	b := &BeaconNode{}
+
+	rr := httptest.NewRecorder()
+	b.resyncHandler(rr, httptest.NewRequest(http.MethodGet, "/prysm/v1/admin/resync?start_slot=1&end_slot=2", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+	rr = httptest.NewRecorder()
+	b.resyncHandler(rr, httptest.NewRequest(http.MethodPost, "/prysm/v1/admin/resync?end_slot=2", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Equal(t, true, strings.Contains(rr.Body.String(), "invalid start_slot"))
+
+	rr = httptest.NewRecorder()
+	b.resyncHandler(rr, httptest.NewRequest(http.MethodPost, "/prysm/v1/admin/resync?start_slot=1&end_slot=a", nil))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Equal(t, true, strings.Contains(rr.Body.String(), "invalid end_slot"))
+}
@@ -28,6 +28,11 @@ func (s *Service) prepareForkChoiceAtts() {
 			if err := s.batchForkChoiceAtts(s.ctx); err != nil {
 				log.WithError(err).Error("Could not prepare attestations for fork choice")
 			}
+			if s.cfg.SyncPoint != nil {
+				if err := s.cfg.SyncPoint(); err != nil {
+					log.WithError(err).Error("Could not commit database writes")
+				}
+			}
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting routine")
 			return
@@ -26,7 +26,10 @@ type Service struct {
 
 // Config options for the service.
 type Config struct {
-	Pool          Pool
+	Pool Pool
+	// SyncPoint, if set, is called after every snapshot of the pool into fork choice attestations,
+	// to commit the grouped writes of the beacon database.
+	SyncPoint     func() error
 	pruneInterval time.Duration
 }
 
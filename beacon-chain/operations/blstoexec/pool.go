@@ -0,0 +1,117 @@
+package blstoexec
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// PoolManager maintains pending BLS to execution changes.
+// This pool is used by proposers to insert BLS to execution changes into new blocks.
+type PoolManager interface {
+	PendingBLSToExecChanges() []*ethpb.SignedBLSToExecutionChange
+	BLSToExecChangesForInclusion(st state.ReadOnlyBeaconState) []*ethpb.SignedBLSToExecutionChange
+	InsertBLSToExecChange(change *ethpb.SignedBLSToExecutionChange)
+	MarkIncluded(change *ethpb.SignedBLSToExecutionChange)
+	ValidatorExists(idx types.ValidatorIndex) bool
+}
+
+// Pool is a concrete implementation of PoolManager. Changes are kept in the order they were
+// received, and there is at most one change per validator.
+type Pool struct {
+	lock    sync.RWMutex
+	pending *list.List
+	m       map[types.ValidatorIndex]*list.Element
+}
+
+// NewPool returns an initialized BLS to execution change pool.
+func NewPool() *Pool {
+	return &Pool{
+		pending: list.New(),
+		m:       make(map[types.ValidatorIndex]*list.Element),
+	}
+}
+
+// PendingBLSToExecChanges returns all the changes of the pool, in the order they were received.
+func (p *Pool) PendingBLSToExecChanges() []*ethpb.SignedBLSToExecutionChange {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	changes := make([]*ethpb.SignedBLSToExecutionChange, 0, p.pending.Len())
+	for e := p.pending.Front(); e != nil; e = e.Next() {
+		changes = append(changes, e.Value.(*ethpb.SignedBLSToExecutionChange))
+	}
+	return changes
+}
+
+// BLSToExecChangesForInclusion returns the changes which are ready for inclusion in a block built on
+// top of the given state, oldest first. Changes of validators whose withdrawal credentials no longer
+// have the BLS prefix are skipped. This method will not return more than the block enforced
+// MaxBlsToExecutionChanges.
+func (p *Pool) BLSToExecChangesForInclusion(st state.ReadOnlyBeaconState) []*ethpb.SignedBLSToExecutionChange {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	maxChanges := params.BeaconConfig().MaxBlsToExecutionChanges
+	changes := make([]*ethpb.SignedBLSToExecutionChange, 0, maxChanges)
+	for e := p.pending.Front(); e != nil && uint64(len(changes)) < maxChanges; e = e.Next() {
+		change := e.Value.(*ethpb.SignedBLSToExecutionChange)
+		v, err := st.ValidatorAtIndexReadOnly(change.Message.ValidatorIndex)
+		if err != nil {
+			continue
+		}
+		cred := v.WithdrawalCredentials()
+		if len(cred) == 0 || cred[0] != params.BeaconConfig().BLSWithdrawalPrefixByte {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// InsertBLSToExecChange inserts a change into the pool. This method is a no-op if the pool already
+// has a change for the same validator, as only the first valid change of a validator can be applied.
+func (p *Pool) InsertBLSToExecChange(change *ethpb.SignedBLSToExecutionChange) {
+	// Prevent malformed messages from being inserted.
+	if change == nil || change.Message == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.m[change.Message.ValidatorIndex]; ok {
+		return
+	}
+	p.m[change.Message.ValidatorIndex] = p.pending.PushBack(change)
+}
+
+// MarkIncluded is used when a change has been included in a beacon block. Every block seen by this
+// node should call this method to include the change. This will remove the change of the validator
+// from the pool.
+func (p *Pool) MarkIncluded(change *ethpb.SignedBLSToExecutionChange) {
+	if change == nil || change.Message == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e, ok := p.m[change.Message.ValidatorIndex]
+	if !ok {
+		return
+	}
+	p.pending.Remove(e)
+	delete(p.m, change.Message.ValidatorIndex)
+}
+
+// ValidatorExists returns true if the pool has a change for the validator with the given index.
+func (p *Pool) ValidatorExists(idx types.ValidatorIndex) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	_, ok := p.m[idx]
+	return ok
+}
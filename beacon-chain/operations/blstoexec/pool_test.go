@@ -0,0 +1,87 @@
+package blstoexec
+
+import (
+	"testing"
+
+	v1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/v1"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func change(idx types.ValidatorIndex, address byte) *ethpb.SignedBLSToExecutionChange {
+	return &ethpb.SignedBLSToExecutionChange{
+		Message: &ethpb.BLSToExecutionChange{
+			ValidatorIndex:     idx,
+			FromBlsPubkey:      make([]byte, 48),
+			ToExecutionAddress: []byte{address},
+		},
+		Signature: make([]byte, 96),
+	}
+}
+
+func TestPool_InsertBLSToExecChange(t *testing.T) {
+	p := NewPool()
+	p.InsertBLSToExecChange(nil)
+	p.InsertBLSToExecChange(&ethpb.SignedBLSToExecutionChange{})
+	assert.Equal(t, 0, len(p.PendingBLSToExecChanges()))
+
+	p.InsertBLSToExecChange(change(2, 1))
+	p.InsertBLSToExecChange(change(1, 1))
+	// Only the first change of a validator is kept.
+	p.InsertBLSToExecChange(change(2, 2))
+
+	assert.DeepEqual(t, []*ethpb.SignedBLSToExecutionChange{change(2, 1), change(1, 1)}, p.PendingBLSToExecChanges())
+	assert.Equal(t, true, p.ValidatorExists(1))
+	assert.Equal(t, false, p.ValidatorExists(3))
+}
+
+func TestPool_MarkIncluded(t *testing.T) {
+	p := NewPool()
+	p.InsertBLSToExecChange(change(0, 1))
+	p.InsertBLSToExecChange(change(1, 1))
+	p.InsertBLSToExecChange(change(2, 1))
+
+	p.MarkIncluded(change(1, 1))
+	p.MarkIncluded(change(5, 1))
+	p.MarkIncluded(nil)
+	assert.DeepEqual(t, []*ethpb.SignedBLSToExecutionChange{change(0, 1), change(2, 1)}, p.PendingBLSToExecChanges())
+	assert.Equal(t, false, p.ValidatorExists(1))
+
+	// A new change of the validator can be inserted once the previous one was included.
+	p.InsertBLSToExecChange(change(1, 2))
+	assert.Equal(t, true, p.ValidatorExists(1))
+}
+
+func TestPool_BLSToExecChangesForInclusion(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxBlsToExecutionChanges = 2
+	params.OverrideBeaconConfig(cfg)
+
+	blsCred := make([]byte, 32)
+	blsCred[0] = params.BeaconConfig().BLSWithdrawalPrefixByte
+	eth1Cred := make([]byte, 32)
+	eth1Cred[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	st, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators: []*ethpb.Validator{
+			{WithdrawalCredentials: blsCred},
+			{WithdrawalCredentials: eth1Cred},
+			{WithdrawalCredentials: blsCred},
+			{WithdrawalCredentials: blsCred},
+		},
+	})
+	require.NoError(t, err)
+
+	p := NewPool()
+	assert.Equal(t, 0, len(p.BLSToExecChangesForInclusion(st)))
+	for _, idx := range []types.ValidatorIndex{5, 1, 3, 0, 2} {
+		p.InsertBLSToExecChange(change(idx, 1))
+	}
+	// Unknown validators and validators which already changed their withdrawal credentials are
+	// skipped, and the number of changes is capped.
+	assert.DeepEqual(t, []*ethpb.SignedBLSToExecutionChange{change(3, 1), change(0, 1)}, p.BLSToExecChangesForInclusion(st))
+	assert.Equal(t, 5, len(p.PendingBLSToExecChanges()))
+}
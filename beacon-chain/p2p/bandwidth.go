@@ -0,0 +1,312 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Directions of the bandwidth, which label the bandwidth metrics.
+const (
+	bandwidthSent     = "sent"
+	bandwidthReceived = "received"
+)
+
+var (
+	gossipTopicBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gossip_topic_bytes_total",
+		Help: "The number of bytes of gossip messages sent to or received from peers, per topic.",
+	}, []string{"topic", "direction"})
+	protocolBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_protocol_bytes_total",
+		Help: "The number of bytes sent to or received from peers on the streams of a protocol, " +
+			"which includes the req/resp protocols and the gossipsub protocol as a whole.",
+	}, []string{"protocol", "direction"})
+	gossipCappedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gossip_capped_messages_total",
+		Help: "The number of messages this node did not publish because the outbound cap of their topic was reached.",
+	}, []string{"topic"})
+)
+
+// errGossipCapReached is returned when a message is not published because the outbound cap of its
+// topic is reached.
+var errGossipCapReached = errors.New("outbound bandwidth cap of topic reached")
+
+// TopicBandwidth is the number of bytes of gossip messages sent and received on a topic.
+type TopicBandwidth struct {
+	Sent     uint64 `json:"sent"`
+	Received uint64 `json:"received"`
+}
+
+// ProtocolBandwidth is the number of bytes sent and received on the streams of a protocol, with the
+// current rates in bytes per second.
+type ProtocolBandwidth struct {
+	Sent         int64   `json:"sent"`
+	Received     int64   `json:"received"`
+	RateSent     float64 `json:"rate_sent"`
+	RateReceived float64 `json:"rate_received"`
+}
+
+// BandwidthStats is the bandwidth used by the node since it started, per gossip topic and per protocol.
+type BandwidthStats struct {
+	Topics    map[string]*TopicBandwidth    `json:"topics"`
+	Protocols map[string]*ProtocolBandwidth `json:"protocols"`
+	Total     *ProtocolBandwidth            `json:"total"`
+	Caps      map[string]uint64             `json:"caps,omitempty"`
+}
+
+// bandwidthCounter is the libp2p bandwidth reporter of the host, which also exports the bytes of the
+// protocol streams as metrics.
+type bandwidthCounter struct {
+	*metrics.BandwidthCounter
+}
+
+var _ = metrics.Reporter(&bandwidthCounter{})
+
+func newBandwidthCounter() *bandwidthCounter {
+	return &bandwidthCounter{BandwidthCounter: metrics.NewBandwidthCounter()}
+}
+
+// LogSentMessageStream records the bytes sent on a stream of the protocol.
+func (c *bandwidthCounter) LogSentMessageStream(size int64, proto protocol.ID, p peer.ID) {
+	c.BandwidthCounter.LogSentMessageStream(size, proto, p)
+	protocolBytes.WithLabelValues(string(proto), bandwidthSent).Add(float64(size))
+}
+
+// LogRecvMessageStream records the bytes received on a stream of the protocol.
+func (c *bandwidthCounter) LogRecvMessageStream(size int64, proto protocol.ID, p peer.ID) {
+	c.BandwidthCounter.LogRecvMessageStream(size, proto, p)
+	protocolBytes.WithLabelValues(string(proto), bandwidthReceived).Add(float64(size))
+}
+
+// gossipBandwidthTracer is a pubsub raw tracer accounting the bytes of the gossip messages sent to and
+// received from peers, per topic. Control messages are not accounted to a topic.
+type gossipBandwidthTracer struct {
+	lock   sync.RWMutex
+	topics map[string]*TopicBandwidth
+}
+
+var _ = pubsub.RawTracer(&gossipBandwidthTracer{})
+
+func newGossipBandwidthTracer() *gossipBandwidthTracer {
+	return &gossipBandwidthTracer{topics: make(map[string]*TopicBandwidth)}
+}
+
+// RecvRPC accounts the messages received from a peer.
+func (t *gossipBandwidthTracer) RecvRPC(rpc *pubsub.RPC) {
+	t.account(rpc, bandwidthReceived)
+}
+
+// SendRPC accounts the messages sent to a peer.
+func (t *gossipBandwidthTracer) SendRPC(rpc *pubsub.RPC, _ peer.ID) {
+	t.account(rpc, bandwidthSent)
+}
+
+func (t *gossipBandwidthTracer) account(rpc *pubsub.RPC, direction string) {
+	if rpc == nil || len(rpc.Publish) == 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, msg := range rpc.Publish {
+		topic := msg.GetTopic()
+		size := msg.Size()
+		bw, ok := t.topics[topic]
+		if !ok {
+			bw = &TopicBandwidth{}
+			t.topics[topic] = bw
+		}
+		if direction == bandwidthSent {
+			bw.Sent += uint64(size)
+		} else {
+			bw.Received += uint64(size)
+		}
+		gossipTopicBytes.WithLabelValues(topic, direction).Add(float64(size))
+	}
+}
+
+// stats returns a copy of the bandwidth of the topics.
+func (t *gossipBandwidthTracer) stats() map[string]*TopicBandwidth {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	topics := make(map[string]*TopicBandwidth, len(t.topics))
+	for topic, bw := range t.topics {
+		topics[topic] = &TopicBandwidth{Sent: bw.Sent, Received: bw.Received}
+	}
+	return topics
+}
+
+// AddPeer is a no-op.
+func (*gossipBandwidthTracer) AddPeer(peer.ID, protocol.ID) {}
+
+// RemovePeer is a no-op.
+func (*gossipBandwidthTracer) RemovePeer(peer.ID) {}
+
+// Join is a no-op.
+func (*gossipBandwidthTracer) Join(string) {}
+
+// Leave is a no-op.
+func (*gossipBandwidthTracer) Leave(string) {}
+
+// Graft is a no-op.
+func (*gossipBandwidthTracer) Graft(peer.ID, string) {}
+
+// Prune is a no-op.
+func (*gossipBandwidthTracer) Prune(peer.ID, string) {}
+
+// ValidateMessage is a no-op.
+func (*gossipBandwidthTracer) ValidateMessage(*pubsub.Message) {}
+
+// DeliverMessage is a no-op.
+func (*gossipBandwidthTracer) DeliverMessage(*pubsub.Message) {}
+
+// RejectMessage is a no-op.
+func (*gossipBandwidthTracer) RejectMessage(*pubsub.Message, string) {}
+
+// DuplicateMessage is a no-op.
+func (*gossipBandwidthTracer) DuplicateMessage(*pubsub.Message) {}
+
+// ThrottlePeer is a no-op.
+func (*gossipBandwidthTracer) ThrottlePeer(peer.ID) {}
+
+// DropRPC is a no-op.
+func (*gossipBandwidthTracer) DropRPC(*pubsub.RPC, peer.ID) {}
+
+// UndeliverableMessage is a no-op.
+func (*gossipBandwidthTracer) UndeliverableMessage(*pubsub.Message) {}
+
+// Topics carrying the blocks, attestations and sync committee messages of validator duties. Dropping
+// them would cost the validators of the node their rewards, so they cannot be capped.
+var uncappedGossipTopics = map[string]bool{
+	GossipBlockMessage:                true,
+	GossipAttestationMessage:          true,
+	GossipAggregateAndProofMessage:    true,
+	GossipSyncCommitteeMessage:        true,
+	GossipContributionAndProofMessage: true,
+}
+
+// gossipCaps limits the bytes of the messages published by this node per second on some topics. The
+// caps are keyed by topic name, as in "voluntary_exit", and apply to all the subnets and forks of the
+// topic. Messages forwarded on behalf of other peers are relayed by gossipsub itself and are not
+// capped. Topics carrying validator duties cannot be capped.
+type gossipCaps struct {
+	caps    map[string]uint64
+	buckets map[string]*leakybucket.Collector
+}
+
+// parseGossipCaps parses outbound caps given as "name=bytes", where the name is the name of a gossip
+// topic without the fork digest, encoding and subnet, and bytes the number of bytes per second.
+func parseGossipCaps(entries []string) (*gossipCaps, error) {
+	known := make(map[string]bool, len(gossipTopicMappings))
+	for topic := range gossipTopicMappings {
+		known[gossipTopicName(topic)] = true
+	}
+	c := &gossipCaps{
+		caps:    make(map[string]uint64, len(entries)),
+		buckets: make(map[string]*leakybucket.Collector, len(entries)),
+	}
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid gossip cap %q, want name=bytes", entry)
+		}
+		name = strings.TrimSpace(name)
+		if !known[name] {
+			return nil, errors.Errorf("invalid gossip cap %q, unknown topic %q", entry, name)
+		}
+		if uncappedGossipTopics[name] {
+			return nil, errors.Errorf("invalid gossip cap %q, topic %q carries validator duties and cannot be capped", entry, name)
+		}
+		limit, err := strconv.ParseUint(strings.TrimSpace(value), 10, 63)
+		if err != nil || limit == 0 {
+			return nil, errors.Errorf("invalid gossip cap %q, want a positive number of bytes per second", entry)
+		}
+		c.caps[name] = limit
+		c.buckets[name] = leakybucket.NewCollector(float64(limit), int64(limit), false /* deleteEmptyBuckets */)
+	}
+	return c, nil
+}
+
+// allow returns true if a message of the given size can be published on the topic, and accounts it
+// in the cap of the topic. A message larger than the cap is allowed once the cap is fully available,
+// so that no topic is blocked entirely.
+func (c *gossipCaps) allow(topic string, size int) bool {
+	if c == nil || len(c.buckets) == 0 {
+		return true
+	}
+	name := gossipTopicName(topic)
+	bucket, ok := c.buckets[name]
+	if !ok {
+		return true
+	}
+	if bucket.Remaining(name) < int64(size) && bucket.Count(name) > 0 {
+		return false
+	}
+	bucket.Add(name, int64(size))
+	return true
+}
+
+// gossipTopicName returns the name of a gossip topic, without the fork digest, encoding and subnet.
+// For instance "/eth2/%x/beacon_attestation_%d/ssz_snappy" is named "beacon_attestation".
+func gossipTopicName(topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) < 4 {
+		return topic
+	}
+	name := parts[3]
+	if i := strings.LastIndexByte(name, '_'); i > 0 {
+		suffix := name[i+1:]
+		if suffix == "%d" {
+			return name[:i]
+		}
+		if _, err := strconv.ParseUint(suffix, 10, 64); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// BandwidthStats returns the bandwidth used by the node since it started.
+func (s *Service) BandwidthStats() *BandwidthStats {
+	stats := &BandwidthStats{
+		Topics:    s.gossipBandwidth.stats(),
+		Protocols: make(map[string]*ProtocolBandwidth),
+		Total:     protocolBandwidth(s.bandwidth.GetBandwidthTotals()),
+	}
+	for proto, st := range s.bandwidth.GetBandwidthByProtocol() {
+		stats.Protocols[string(proto)] = protocolBandwidth(st)
+	}
+	if s.gossipCaps != nil && len(s.gossipCaps.caps) > 0 {
+		stats.Caps = s.gossipCaps.caps
+	}
+	return stats
+}
+
+func protocolBandwidth(st metrics.Stats) *ProtocolBandwidth {
+	return &ProtocolBandwidth{
+		Sent:         st.TotalOut,
+		Received:     st.TotalIn,
+		RateSent:     st.RateOut,
+		RateReceived: st.RateIn,
+	}
+}
+
+// BandwidthHandler serves the bandwidth used by the node per gossip topic and per protocol as JSON.
+func (s *Service) BandwidthHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(s.BandwidthStats()); err != nil {
+		log.WithError(err).Error("Failed to render bandwidth page")
+	}
+}
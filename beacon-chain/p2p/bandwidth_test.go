@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestGossipBandwidthTracer_AccountsPerTopic(t *testing.T) {
+	tracer := newGossipBandwidthTracer()
+	block := "/eth2/00000000/beacon_block/ssz_snappy"
+	att := "/eth2/00000000/beacon_attestation_3/ssz_snappy"
+	msg := func(topic string, data []byte) *pubsubpb.Message {
+		return &pubsubpb.Message{Topic: &topic, Data: data}
+	}
+	blockMsg := msg(block, make([]byte, 100))
+	attMsg := msg(att, make([]byte, 10))
+
+	tracer.SendRPC(&pubsub.RPC{RPC: pubsubpb.RPC{Publish: []*pubsubpb.Message{blockMsg, attMsg}}}, peer.ID("a"))
+	tracer.SendRPC(&pubsub.RPC{RPC: pubsubpb.RPC{Publish: []*pubsubpb.Message{blockMsg}}}, peer.ID("b"))
+	tracer.RecvRPC(&pubsub.RPC{RPC: pubsubpb.RPC{Publish: []*pubsubpb.Message{attMsg}}})
+	// Control messages are not accounted.
+	tracer.RecvRPC(&pubsub.RPC{RPC: pubsubpb.RPC{Control: &pubsubpb.ControlMessage{}}})
+
+	stats := tracer.stats()
+	require.Equal(t, 2, len(stats))
+	assert.Equal(t, uint64(2*blockMsg.Size()), stats[block].Sent)
+	assert.Equal(t, uint64(0), stats[block].Received)
+	assert.Equal(t, uint64(attMsg.Size()), stats[att].Sent)
+	assert.Equal(t, uint64(attMsg.Size()), stats[att].Received)
+}
+
+func TestGossipTopicName(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  string
+	}{
+		{topic: "/eth2/00000000/beacon_block/ssz_snappy", want: "beacon_block"},
+		{topic: "/eth2/00000000/beacon_attestation_12/ssz_snappy", want: "beacon_attestation"},
+		{topic: AttestationSubnetTopicFormat, want: "beacon_attestation"},
+		{topic: SyncCommitteeSubnetTopicFormat, want: "sync_committee"},
+		{topic: SyncContributionAndProofSubnetTopicFormat, want: "sync_committee_contribution_and_proof"},
+		{topic: "unknown", want: "unknown"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, gossipTopicName(tt.topic))
+	}
+}
+
+func TestParseGossipCaps(t *testing.T) {
+	c, err := parseGossipCaps([]string{"voluntary_exit=1000", " bls_to_execution_change = 2000"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), c.caps["voluntary_exit"])
+	assert.Equal(t, uint64(2000), c.caps["bls_to_execution_change"])
+
+	_, err = parseGossipCaps([]string{"voluntary_exit"})
+	assert.ErrorContains(t, "want name=bytes", err)
+	_, err = parseGossipCaps([]string{"voluntary_exits=10"})
+	assert.ErrorContains(t, "unknown topic", err)
+	_, err = parseGossipCaps([]string{"voluntary_exit=0"})
+	assert.ErrorContains(t, "positive number of bytes", err)
+	_, err = parseGossipCaps([]string{"voluntary_exit=-1"})
+	assert.ErrorContains(t, "positive number of bytes", err)
+
+	// Topics carrying validator duties cannot be capped.
+	for _, name := range []string{
+		"beacon_block",
+		"beacon_attestation",
+		"beacon_aggregate_and_proof",
+		"sync_committee",
+		"sync_committee_contribution_and_proof",
+	} {
+		_, err = parseGossipCaps([]string{name + "=1000"})
+		assert.ErrorContains(t, "carries validator duties and cannot be capped", err)
+	}
+}
+
+func TestGossipCaps_Allow(t *testing.T) {
+	c, err := parseGossipCaps([]string{"voluntary_exit=100"})
+	require.NoError(t, err)
+	fork1 := "/eth2/00000000/voluntary_exit/ssz_snappy"
+	fork2 := "/eth2/01000000/voluntary_exit/ssz_snappy"
+
+	// The cap is shared by the forks of the topic.
+	assert.Equal(t, true, c.allow(fork1, 60))
+	assert.Equal(t, false, c.allow(fork2, 60))
+	assert.Equal(t, true, c.allow(fork2, 30))
+	// Topics without a cap are not limited.
+	assert.Equal(t, true, c.allow("/eth2/00000000/beacon_block/ssz_snappy", 1000))
+	assert.Equal(t, true, c.allow("/eth2/00000000/beacon_attestation_1/ssz_snappy", 1000))
+
+	// A message larger than the cap is allowed when the cap is fully available.
+	c, err = parseGossipCaps([]string{"voluntary_exit=100"})
+	require.NoError(t, err)
+	assert.Equal(t, true, c.allow(fork1, 500))
+	assert.Equal(t, false, c.allow(fork1, 1))
+
+	// No caps allow everything.
+	var none *gossipCaps
+	assert.Equal(t, true, none.allow(fork1, 500))
+}
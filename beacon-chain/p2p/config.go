@@ -1,6 +1,8 @@
 package p2p
 
 import (
+	"time"
+
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 )
@@ -17,7 +19,9 @@ type Config struct {
 	LocalIP             string
 	HostAddress         string
 	HostDNS             string
+	HostDNSRefresh      time.Duration
 	PrivateKey          string
+	PreSharedKeyFile    string
 	DataDir             string
 	MetaDataDir         string
 	TCPPort             uint
@@ -25,6 +29,10 @@ type Config struct {
 	MaxPeers            uint
 	AllowListCIDR       string
 	DenyListCIDR        []string
-	StateNotifier       statefeed.Notifier
-	DB                  db.ReadOnlyDatabase
+	GossipOutboundCaps  []string
+	// InMemoryTransport connects the host to the hosts of the same process through memory instead of
+	// the network, for nodes run together in a single process.
+	InMemoryTransport bool
+	StateNotifier     statefeed.Notifier
+	DB                db.NoHeadAccessDatabase
 }
@@ -0,0 +1,55 @@
+package encoder
+
+import (
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Directions of the req/resp chunks, which label the encoding metrics.
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+var (
+	reqRespUncompressedBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_req_resp_uncompressed_bytes_total",
+		Help: "The number of bytes of req/resp chunks before compression, per encoding.",
+	}, []string{"encoding", "direction"})
+	reqRespWireBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_req_resp_wire_bytes_total",
+		Help: "The number of bytes of req/resp chunks on the wire, including their length prefixes, per encoding.",
+	}, []string{"encoding", "direction"})
+	reqRespCompressionSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "p2p_req_resp_compression_seconds",
+		Help:    "The time spent compressing sent req/resp chunks and decompressing received ones, per encoding.",
+		Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+	}, []string{"encoding", "direction"})
+)
+
+// recordEncoding records the sizes of a req/resp chunk before compression and on the wire, and the
+// time spent compressing or decompressing it.
+func recordEncoding(encoding, direction string, uncompressed, wire int, elapsed time.Duration) {
+	reqRespUncompressedBytes.WithLabelValues(encoding, direction).Add(float64(uncompressed))
+	reqRespWireBytes.WithLabelValues(encoding, direction).Add(float64(wire))
+	reqRespCompressionSeconds.WithLabelValues(encoding, direction).Observe(elapsed.Seconds())
+}
+
+// meteredReader counts the bytes read from the underlying reader and the time spent waiting for
+// them, so that the time spent decompressing a stream can be told apart from network latency.
+type meteredReader struct {
+	r    io.Reader
+	n    int
+	wait time.Duration
+}
+
+func (m *meteredReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := m.r.Read(p)
+	m.wait += time.Since(start)
+	m.n += n
+	return n, err
+}
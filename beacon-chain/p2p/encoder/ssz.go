@@ -1,9 +1,11 @@
 package encoder
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -67,12 +69,24 @@ func (_ SszNetworkEncoder) EncodeWithMaxLength(w io.Writer, msg fastssz.Marshale
 			MaxChunkSize,
 		)
 	}
+	start := time.Now()
+	compressed := new(bytes.Buffer)
+	num, err := writeSnappyBuffer(compressed, b)
+	if err != nil {
+		return 0, err
+	}
+	compressionTime := time.Since(start)
 	// write varint first
-	_, err = w.Write(proto.EncodeVarint(uint64(len(b))))
+	header := proto.EncodeVarint(uint64(len(b)))
+	_, err = w.Write(header)
 	if err != nil {
 		return 0, err
 	}
-	return writeSnappyBuffer(w, b)
+	if _, err := w.Write(compressed.Bytes()); err != nil {
+		return 0, err
+	}
+	recordEncoding(ProtocolSuffixSSZSnappy, directionSent, len(b), len(header)+compressed.Len(), compressionTime)
+	return num, nil
 }
 
 func doDecode(b []byte, to fastssz.Unmarshaler) error {
@@ -122,7 +136,8 @@ func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to fastssz.Unmarshal
 	if err != nil {
 		return err
 	}
-	limitedRdr := io.LimitReader(r, int64(msgMax))
+	limitedRdr := &meteredReader{r: io.LimitReader(r, int64(msgMax))}
+	start := time.Now()
 	r = newBufferedReader(limitedRdr)
 	defer bufReaderPool.Put(r)
 
@@ -134,6 +149,8 @@ func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to fastssz.Unmarshal
 	if err != nil {
 		return err
 	}
+	wireLen := len(proto.EncodeVarint(msgLen)) + limitedRdr.n
+	recordEncoding(ProtocolSuffixSSZSnappy, directionReceived, len(buf), wireLen, time.Since(start)-limitedRdr.wait)
 	return doDecode(buf, to)
 }
 
@@ -0,0 +1,193 @@
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	fastssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/math"
+)
+
+var _ NetworkEncoding = (*SszZstdNetworkEncoder)(nil)
+
+// ProtocolSuffixSSZZstd is the last part of the protocol ID to identify the experimental zstd encoding.
+const ProtocolSuffixSSZZstd = "ssz_zstd"
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// zstdCodecs returns the zstd encoder and decoder shared by all streams, which are safe for
+// concurrent use when compressing and decompressing whole buffers.
+func zstdCodecs() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+		if zstdErr != nil {
+			return
+		}
+		// Bound the memory used by a single decompression, whatever the sizes claimed by the peer.
+		zstdDecoder, zstdErr = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(params.BeaconNetworkConfig().MaxChunkSizeBellatrix))
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// SszZstdNetworkEncoder is an experimental req/resp encoding using SimpleSerialize with zstd
+// compression, negotiated with peers through the protocol ID suffix alongside snappy. A chunk is
+// made of the varint length of the uncompressed payload, the varint length of the compressed
+// payload and a single zstd frame. It is not used for gossip.
+type SszZstdNetworkEncoder struct{}
+
+// EncodeGossip the proto gossip message to the io.Writer.
+func (_ SszZstdNetworkEncoder) EncodeGossip(w io.Writer, msg fastssz.Marshaler) (int, error) {
+	if msg == nil {
+		return 0, nil
+	}
+	b, err := msg.MarshalSSZ()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(len(b)) > MaxGossipSize {
+		return 0, errors.Errorf("gossip message exceeds max gossip size: %d bytes > %d bytes", len(b), MaxGossipSize)
+	}
+	enc, _, err := zstdCodecs()
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(enc.EncodeAll(b, nil /*dst*/))
+}
+
+// DecodeGossip decodes the bytes to the protobuf gossip message provided.
+func (_ SszZstdNetworkEncoder) DecodeGossip(b []byte, to fastssz.Unmarshaler) error {
+	_, dec, err := zstdCodecs()
+	if err != nil {
+		return err
+	}
+	b, err = dec.DecodeAll(b, nil /*dst*/)
+	if err != nil {
+		return err
+	}
+	if uint64(len(b)) > MaxGossipSize {
+		return errors.Errorf("zstd message exceeds max size: %d bytes > %d bytes", len(b), MaxGossipSize)
+	}
+	return doDecode(b, to)
+}
+
+// EncodeWithMaxLength the proto message to the io.Writer. This encoding prefixes the compressed
+// message with the varint lengths of the message and of its compressed form. This checks that the
+// encoded message isn't larger than the provided max limit.
+func (_ SszZstdNetworkEncoder) EncodeWithMaxLength(w io.Writer, msg fastssz.Marshaler) (int, error) {
+	if msg == nil {
+		return 0, nil
+	}
+	b, err := msg.MarshalSSZ()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(len(b)) > MaxChunkSize {
+		return 0, fmt.Errorf(
+			"size of encoded message is %d which is larger than the provided max limit of %d",
+			len(b),
+			MaxChunkSize,
+		)
+	}
+	enc, _, err := zstdCodecs()
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	compressed := enc.EncodeAll(b, nil /*dst*/)
+	compressionTime := time.Since(start)
+
+	buf := bytes.NewBuffer(proto.EncodeVarint(uint64(len(b))))
+	buf.Write(proto.EncodeVarint(uint64(len(compressed))))
+	buf.Write(compressed)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	recordEncoding(ProtocolSuffixSSZZstd, directionSent, len(b), buf.Len(), compressionTime)
+	return len(b), nil
+}
+
+// DecodeWithMaxLength the bytes from io.Reader to the protobuf message provided.
+// This checks that the decoded message isn't larger than the provided max limit.
+func (e SszZstdNetworkEncoder) DecodeWithMaxLength(r io.Reader, to fastssz.Unmarshaler) error {
+	msgLen, err := readVarint(r)
+	if err != nil {
+		return err
+	}
+	if msgLen > MaxChunkSize {
+		return fmt.Errorf(
+			"remaining bytes %d goes over the provided max limit of %d",
+			msgLen,
+			MaxChunkSize,
+		)
+	}
+	compressedLen, err := readVarint(r)
+	if err != nil {
+		return err
+	}
+	msgMax, err := e.MaxLength(msgLen)
+	if err != nil {
+		return err
+	}
+	if compressedLen > uint64(msgMax) {
+		return fmt.Errorf(
+			"compressed length %d goes over the max length %d of a compressed message of %d bytes",
+			compressedLen,
+			msgMax,
+			msgLen,
+		)
+	}
+	compressed := make([]byte, compressedLen)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return err
+	}
+	_, dec, err := zstdCodecs()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	buf, err := dec.DecodeAll(compressed, make([]byte, 0, msgLen))
+	if err != nil {
+		return err
+	}
+	if uint64(len(buf)) != msgLen {
+		return errors.Errorf("decompressed message has %d bytes instead of %d", len(buf), msgLen)
+	}
+	wireLen := len(proto.EncodeVarint(msgLen)) + len(proto.EncodeVarint(compressedLen)) + len(compressed)
+	recordEncoding(ProtocolSuffixSSZZstd, directionReceived, len(buf), wireLen, time.Since(start))
+	return doDecode(buf, to)
+}
+
+// ProtocolSuffix returns the appropriate suffix for protocol IDs.
+func (_ SszZstdNetworkEncoder) ProtocolSuffix() string {
+	return "/" + ProtocolSuffixSSZZstd
+}
+
+// MaxLength specifies the maximum possible length of an encoded
+// chunk of data, following the compression bound of zstd.
+func (_ SszZstdNetworkEncoder) MaxLength(length uint64) (int, error) {
+	il, err := math.Int(length)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid length provided")
+	}
+	const smallInputLimit = 128 << 10
+	maxLen := il + il>>8
+	if il < smallInputLimit {
+		maxLen += (smallInputLimit - il) >> 11
+	}
+	if maxLen < 0 {
+		return 0, errors.Errorf("max encoded length is negative: %d", maxLen)
+	}
+	return maxLen, nil
+}
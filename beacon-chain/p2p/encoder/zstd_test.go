@@ -0,0 +1,99 @@
+package encoder_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	gogo "github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// setMaxChunkSize sets the max chunk size for the duration of the test.
+func setMaxChunkSize(t *testing.T, size uint64) {
+	prev := encoder.MaxChunkSize
+	encoder.MaxChunkSize = size
+	t.Cleanup(func() {
+		encoder.MaxChunkSize = prev
+	})
+}
+
+func TestSszZstdNetworkEncoder_RoundTrip(t *testing.T) {
+	setMaxChunkSize(t, params.BeaconNetworkConfig().MaxChunkSize)
+	e := &encoder.SszZstdNetworkEncoder{}
+	testRoundTripWithLength(t, e)
+	testRoundTripWithGossip(t, e)
+	assert.Equal(t, "/ssz_zstd", e.ProtocolSuffix())
+}
+
+func TestSszZstdNetworkEncoder_ConsecutiveChunks(t *testing.T) {
+	setMaxChunkSize(t, params.BeaconNetworkConfig().MaxChunkSize)
+	e := &encoder.SszZstdNetworkEncoder{}
+	buf := new(bytes.Buffer)
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 5
+	fork := &ethpb.Fork{PreviousVersion: []byte("fooo"), CurrentVersion: []byte("barr"), Epoch: 9001}
+	_, err := e.EncodeWithMaxLength(buf, blk)
+	require.NoError(t, err)
+	_, err = e.EncodeWithMaxLength(buf, fork)
+	require.NoError(t, err)
+	ssz, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	assert.Equal(t, true, buf.Len() < len(ssz), "zstd did not compress the block")
+
+	// Each chunk is read without consuming the bytes of the next one.
+	decodedBlk := &ethpb.SignedBeaconBlock{}
+	require.NoError(t, e.DecodeWithMaxLength(buf, decodedBlk))
+	assert.Equal(t, true, proto.Equal(blk, decodedBlk))
+	decodedFork := &ethpb.Fork{}
+	require.NoError(t, e.DecodeWithMaxLength(buf, decodedFork))
+	assert.Equal(t, true, proto.Equal(fork, decodedFork))
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestSszZstdNetworkEncoder_DecodeWithMaxLength(t *testing.T) {
+	e := &encoder.SszZstdNetworkEncoder{}
+	maxChunkSize := uint64(5)
+	setMaxChunkSize(t, maxChunkSize)
+
+	buf := bytes.NewBuffer(gogo.EncodeVarint(maxChunkSize + 1))
+	err := e.DecodeWithMaxLength(buf, &ethpb.Fork{})
+	assert.ErrorContains(t, fmt.Sprintf("goes over the provided max limit of %d", maxChunkSize), err)
+
+	// The compressed length claimed by the peer is bounded by the length of the message.
+	buf = bytes.NewBuffer(gogo.EncodeVarint(maxChunkSize))
+	buf.Write(gogo.EncodeVarint(1 << 20))
+	err = e.DecodeWithMaxLength(buf, &ethpb.Fork{})
+	assert.ErrorContains(t, "goes over the max length", err)
+}
+
+func TestSszZstdNetworkEncoder_DecodeWrongLength(t *testing.T) {
+	setMaxChunkSize(t, params.BeaconNetworkConfig().MaxChunkSize)
+	e := &encoder.SszZstdNetworkEncoder{}
+	fork := &ethpb.Fork{PreviousVersion: []byte("fooo"), CurrentVersion: []byte("barr"), Epoch: 9001}
+	compressed := new(bytes.Buffer)
+	_, err := e.EncodeGossip(compressed, fork)
+	require.NoError(t, err)
+
+	// The payload decompresses to 16 bytes while 10 are announced.
+	buf := bytes.NewBuffer(gogo.EncodeVarint(10))
+	buf.Write(gogo.EncodeVarint(uint64(compressed.Len())))
+	buf.Write(compressed.Bytes())
+	assert.ErrorContains(t, "decompressed message has 16 bytes instead of 10", e.DecodeWithMaxLength(buf, &ethpb.Fork{}))
+}
+
+func TestSszZstdNetworkEncoder_MaxLength(t *testing.T) {
+	e := &encoder.SszZstdNetworkEncoder{}
+	length, err := e.MaxLength(0)
+	require.NoError(t, err)
+	assert.Equal(t, 64, length)
+	length, err = e.MaxLength(1 << 20)
+	require.NoError(t, err)
+	assert.Equal(t, 1<<20+1<<12, length)
+}
@@ -0,0 +1,39 @@
+package p2p
+
+import (
+	"strings"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+)
+
+// zstdTopics are the req/resp topics for which the experimental zstd encoding can be negotiated.
+// Historical sync downloads blocks by range, which makes these responses the most worth compressing.
+var zstdTopics = map[string]bool{
+	RPCBlocksByRangeTopicV1: true,
+	RPCBlocksByRangeTopicV2: true,
+}
+
+var negotiatedEncodings = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "p2p_req_resp_negotiated_encoding_total",
+	Help: "The number of outbound req/resp streams offering the zstd encoding, per encoding negotiated with the peer.",
+}, []string{"encoding"})
+
+// ZstdEnabled tells whether the experimental zstd encoding is negotiated on the streams of the
+// req/resp topic, ahead of the default encoding.
+func ZstdEnabled(baseTopic string) bool {
+	return features.Get().EnableZstdReqResp && zstdTopics[baseTopic]
+}
+
+// StreamEncoding returns the encoding negotiated for the stream, given by the suffix of its
+// protocol ID. It is the encoding of the provider unless zstd was negotiated.
+func StreamEncoding(stream network.Stream, provider EncodingProvider) encoder.NetworkEncoding {
+	zstd := &encoder.SszZstdNetworkEncoder{}
+	if strings.HasSuffix(string(stream.Protocol()), zstd.ProtocolSuffix()) {
+		return zstd
+	}
+	return provider.Encoding()
+}
@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
+	testp2p "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// protocolStream is a stream negotiated for the given protocol.
+type protocolStream struct {
+	network.Stream
+	protocol protocol.ID
+}
+
+func (s *protocolStream) Protocol() protocol.ID {
+	return s.protocol
+}
+
+func TestStreamEncoding(t *testing.T) {
+	svc := &Service{}
+	e := StreamEncoding(&protocolStream{protocol: protocol.ID(RPCBlocksByRangeTopicV2 + "/ssz_zstd")}, svc)
+	assert.DeepEqual(t, &encoder.SszZstdNetworkEncoder{}, e)
+	e = StreamEncoding(&protocolStream{protocol: protocol.ID(RPCBlocksByRangeTopicV2 + "/ssz_snappy")}, svc)
+	assert.DeepEqual(t, svc.Encoding(), e)
+}
+
+func TestZstdEnabled(t *testing.T) {
+	assert.Equal(t, false, ZstdEnabled(RPCBlocksByRangeTopicV2))
+	resetCfg := features.InitWithReset(&features.Flags{EnableZstdReqResp: true})
+	defer resetCfg()
+	assert.Equal(t, true, ZstdEnabled(RPCBlocksByRangeTopicV1))
+	assert.Equal(t, true, ZstdEnabled(RPCBlocksByRangeTopicV2))
+	assert.Equal(t, false, ZstdEnabled(RPCBlocksByRootTopicV2))
+	assert.Equal(t, false, ZstdEnabled(RPCStatusTopicV1))
+}
+
+func TestService_Send_Zstd(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	resetCfg := features.InitWithReset(&features.Flags{EnableZstdReqResp: true})
+	defer resetCfg()
+	topic := "/testing/zstd/1"
+	RPCTopicMappings[topic] = new(ethpb.Fork)
+	zstdTopics[topic] = true
+	defer func() {
+		delete(RPCTopicMappings, topic)
+		delete(zstdTopics, topic)
+	}()
+	msg := &ethpb.Fork{
+		CurrentVersion:  []byte("fooo"),
+		PreviousVersion: []byte("barr"),
+		Epoch:           55,
+	}
+
+	for _, suffix := range []string{"/ssz_zstd", "/ssz_snappy"} {
+		t.Run(suffix, func(t *testing.T) {
+			p1 := testp2p.NewTestP2P(t)
+			p2 := testp2p.NewTestP2P(t)
+			p1.Connect(p2)
+			svc := &Service{
+				host: p1.BHost,
+				cfg:  &Config{},
+			}
+
+			// The peer only supports the encoding of the suffix and repeats the message back.
+			var wg sync.WaitGroup
+			wg.Add(1)
+			p2.SetStreamHandler(topic+suffix, func(stream network.Stream) {
+				e := StreamEncoding(stream, svc)
+				rcvd := &ethpb.Fork{}
+				require.NoError(t, e.DecodeWithMaxLength(stream, rcvd))
+				_, err := e.EncodeWithMaxLength(stream, rcvd)
+				require.NoError(t, err)
+				assert.NoError(t, stream.Close())
+				wg.Done()
+			})
+
+			stream, err := svc.Send(context.Background(), msg, topic, p2.BHost.ID())
+			require.NoError(t, err)
+			assert.Equal(t, protocol.ID(topic+suffix), stream.Protocol())
+
+			util.WaitTimeout(&wg, 1*time.Second)
+
+			rcvd := &ethpb.Fork{}
+			require.NoError(t, StreamEncoding(stream, svc).DecodeWithMaxLength(stream, rcvd))
+			if !proto.Equal(rcvd, msg) {
+				t.Errorf("Expected identical message to be received. got %v want %v", rcvd, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	gcache "github.com/patrickmn/go-cache"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
+)
+
+// The key under which subnets pinned by the operator are stored in the persistent subnets cache,
+// alongside the subnets of attached validators which are keyed by public key.
+const pinnedSubnetsKey = "operator-pinned-subnets"
+
+var (
+	// ErrDiscoveryNotRunning is returned when updating the ENR of a node running without discovery.
+	ErrDiscoveryNotRunning = errors.New("discovery is not running")
+	// ErrReservedENRKey is returned when a custom ENR entry would overwrite an entry managed by the node.
+	ErrReservedENRKey = errors.New("ENR key is managed by the node")
+	// ErrMetadataNotInitialized is returned when pinning subnets before the metadata of the node is initialized.
+	ErrMetadataNotInitialized = errors.New("metadata is not initialized")
+)
+
+// ENRUpdate holds the changes to apply to the ENR advertised by the node. Fields left to their
+// zero value are not updated.
+type ENRUpdate struct {
+	IP      net.IP
+	TCPPort uint
+	UDPPort uint
+	// Custom entries to set in the record, the values are RLP encoded as byte strings.
+	Entries map[string][]byte
+}
+
+// UpdateENR applies the update to the ENR of the node and returns the resulting record. The
+// sequence number of the record is increased, which makes discovery peers fetch the new record
+// the next time they exchange messages with the node.
+func (s *Service) UpdateENR(update *ENRUpdate) (*enr.Record, error) {
+	if s.dv5Listener == nil {
+		return nil, ErrDiscoveryNotRunning
+	}
+	for k := range update.Entries {
+		if isReservedENRKey(k) {
+			return nil, errors.Wrap(ErrReservedENRKey, k)
+		}
+	}
+	if update.IP != nil && update.IP.To16() == nil {
+		return nil, errors.Errorf("invalid ip address %s", update.IP)
+	}
+
+	localNode := s.dv5Listener.LocalNode()
+	if update.IP != nil {
+		localNode.SetStaticIP(update.IP)
+	}
+	if update.UDPPort != 0 {
+		localNode.SetFallbackUDP(int(update.UDPPort))
+	}
+	if update.TCPPort != 0 {
+		localNode.Set(enr.TCP(update.TCPPort))
+	}
+	for k, v := range update.Entries {
+		localNode.Set(enr.WithEntry(k, v))
+	}
+	record := localNode.Node().Record()
+	log.WithField("seq", record.Seq()).Info("Updated ENR")
+	return record, nil
+}
+
+// PinAttestationSubnets makes the node subscribe to the given attestation subnets and advertise
+// them in both its ENR and metadata, on top of the subnets required by attached validators. An
+// empty list removes the previously pinned subnets.
+func (s *Service) PinAttestationSubnets(subnets []uint64) error {
+	if err := checkAttestationSubnets(subnets); err != nil {
+		return err
+	}
+	if s.metaData == nil || s.metaData.IsNil() {
+		return ErrMetadataNotInitialized
+	}
+	cache.SubnetIDs.AddPersistentCommittee([]byte(pinnedSubnetsKey), subnets, gcache.NoExpiration)
+	// Update the ENR and metadata right away rather than at the next periodic refresh.
+	s.RefreshENR()
+	return nil
+}
+
+// refreshHostDNS resolves the host DNS name of the node again and updates the IP address
+// advertised in the ENR if the name now resolves to a different address.
+func (s *Service) refreshHostDNS() {
+	if s.dv5Listener == nil {
+		return
+	}
+	ips, err := net.LookupIP(s.cfg.HostDNS)
+	if err != nil {
+		log.WithError(err).WithField("host", s.cfg.HostDNS).Error("Could not resolve host address")
+		return
+	}
+	if len(ips) == 0 || ips[0].Equal(s.dv5Listener.Self().IP()) {
+		return
+	}
+	if _, err := s.UpdateENR(&ENRUpdate{IP: ips[0]}); err != nil {
+		log.WithError(err).Error("Could not update ENR with resolved host address")
+		return
+	}
+	log.WithField("host", s.cfg.HostDNS).WithField("ip", ips[0]).Info("Host address changed, advertising new address")
+}
+
+func checkAttestationSubnets(subnets []uint64) error {
+	for _, subnet := range subnets {
+		if subnet >= attestationSubnetCount {
+			return errors.Errorf("attestation subnet %d out of range, there are %d subnets", subnet, attestationSubnetCount)
+		}
+	}
+	return nil
+}
+
+func isReservedENRKey(k string) bool {
+	switch k {
+	case "id", "secp256k1", "ip", "ip6", "tcp", "tcp6", "udp", "udp6",
+		eth2ENRKey, attSubnetEnrKey, syncCommsSubnetEnrKey:
+		return true
+	}
+	return false
+}
+
+// enrUpdateRequest is the JSON body accepted by the ENR admin endpoint.
+type enrUpdateRequest struct {
+	IP      string            `json:"ip"`
+	TCPPort uint              `json:"tcp_port"`
+	UDPPort uint              `json:"udp_port"`
+	Entries map[string]string `json:"entries"`
+	// Pointer to tell an empty list, which removes the pinned subnets, from a missing field.
+	AttestationSubnets *[]uint64 `json:"attestation_subnets"`
+}
+
+// enrResponse is the JSON response served by the ENR admin endpoint.
+type enrResponse struct {
+	ENR         string `json:"enr"`
+	Seq         uint64 `json:"seq"`
+	MetadataSeq uint64 `json:"metadata_seq"`
+}
+
+// ENRHandler serves the ENR admin endpoint of the REST API. A POST request updates the advertised ENR
+// fields, custom ENR entries given as hex strings, and the pinned attestation subnets without
+// restarting the node. Any request returns the current ENR and metadata sequence numbers.
+func (s *Service) ENRHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		req := &enrUpdateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, errors.Wrap(err, "could not decode request body").Error(), http.StatusBadRequest)
+			return
+		}
+		if code, err := s.applyENRUpdateRequest(req); err != nil {
+			http.Error(w, err.Error(), code)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if s.dv5Listener == nil {
+		http.Error(w, ErrDiscoveryNotRunning.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	record := s.dv5Listener.Self().Record()
+	enrString, err := SerializeENR(record)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	resp := &enrResponse{ENR: "enr:" + enrString, Seq: record.Seq(), MetadataSeq: s.MetadataSeq()}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.WithError(err).Error("Failed to render ENR page")
+	}
+}
+
+// applyENRUpdateRequest applies the changes of an ENR update request, returning the HTTP status
+// code to answer with on failure.
+func (s *Service) applyENRUpdateRequest(req *enrUpdateRequest) (int, error) {
+	update := &ENRUpdate{
+		TCPPort: req.TCPPort,
+		UDPPort: req.UDPPort,
+		Entries: make(map[string][]byte, len(req.Entries)),
+	}
+	if req.IP != "" {
+		update.IP = net.ParseIP(req.IP)
+		if update.IP == nil {
+			return http.StatusBadRequest, errors.Errorf("invalid ip address %s", req.IP)
+		}
+	}
+	for k, v := range req.Entries {
+		value, err := hexutil.Decode(v)
+		if err != nil {
+			return http.StatusBadRequest, errors.Wrapf(err, "could not decode value of ENR entry %s", k)
+		}
+		update.Entries[k] = value
+	}
+	if req.AttestationSubnets != nil {
+		if err := checkAttestationSubnets(*req.AttestationSubnets); err != nil {
+			return http.StatusBadRequest, err
+		}
+	}
+	if update.IP != nil || update.TCPPort != 0 || update.UDPPort != 0 || len(update.Entries) > 0 {
+		if _, err := s.UpdateENR(update); err != nil {
+			switch {
+			case errors.Is(err, ErrDiscoveryNotRunning):
+				return http.StatusServiceUnavailable, err
+			case errors.Is(err, ErrReservedENRKey):
+				return http.StatusBadRequest, err
+			default:
+				return http.StatusInternalServerError, err
+			}
+		}
+	}
+	if req.AttestationSubnets != nil {
+		if err := s.PinAttestationSubnets(*req.AttestationSubnets); err != nil {
+			if errors.Is(err, ErrMetadataNotInitialized) {
+				return http.StatusServiceUnavailable, err
+			}
+			return http.StatusBadRequest, err
+		}
+	}
+	return 0, nil
+}
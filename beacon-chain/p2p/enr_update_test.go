@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestUpdateENR(t *testing.T) {
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		cfg:                   &Config{UDPPort: 3100, TCPPort: 3101},
+	}
+	listener, err := s.createListener(ipAddr, pkey)
+	require.NoError(t, err)
+	defer listener.Close()
+	s.dv5Listener = listener
+	seq := listener.Self().Seq()
+
+	newIP := net.ParseIP("192.0.2.10")
+	record, err := s.UpdateENR(&ENRUpdate{
+		IP:      newIP,
+		TCPPort: 4101,
+		UDPPort: 4100,
+		Entries: map[string][]byte{"custom": {0x01, 0x02}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, record.Seq() > seq, "Sequence number not increased")
+	assert.Equal(t, true, listener.Self().IP().Equal(newIP), "IP address not updated")
+	assert.Equal(t, 4101, listener.Self().TCP())
+	assert.Equal(t, 4100, listener.Self().UDP())
+	var custom []byte
+	require.NoError(t, record.Load(enr.WithEntry("custom", &custom)))
+	assert.DeepEqual(t, []byte{0x01, 0x02}, custom)
+
+	_, err = s.UpdateENR(&ENRUpdate{Entries: map[string][]byte{attSubnetEnrKey: {0x01}}})
+	assert.ErrorContains(t, ErrReservedENRKey.Error(), err)
+}
+
+func TestUpdateENR_NoDiscovery(t *testing.T) {
+	s := &Service{cfg: &Config{}}
+	_, err := s.UpdateENR(&ENRUpdate{TCPPort: 4000})
+	assert.ErrorContains(t, ErrDiscoveryNotRunning.Error(), err)
+}
+
+func TestPinAttestationSubnets_NoMetadata(t *testing.T) {
+	defer cache.SubnetIDs.EmptyAllCaches()
+	s := &Service{cfg: &Config{}}
+	assert.ErrorContains(t, ErrMetadataNotInitialized.Error(), s.PinAttestationSubnets([]uint64{1}))
+	assert.Equal(t, 0, len(cache.SubnetIDs.GetAllSubnets()))
+}
+
+func TestENRHandler(t *testing.T) {
+	defer cache.SubnetIDs.EmptyAllCaches()
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		cfg:                   &Config{UDPPort: 3200, TCPPort: 3201},
+		metaData:              wrapper.WrappedMetadataV0(&ethpb.MetaDataV0{}),
+	}
+	listener, err := s.createListener(ipAddr, pkey)
+	require.NoError(t, err)
+	defer listener.Close()
+	s.dv5Listener = listener
+
+	t.Run("update", func(t *testing.T) {
+		body, err := json.Marshal(map[string]interface{}{
+			"tcp_port":            4201,
+			"entries":             map[string]string{"custom": "0x0102"},
+			"attestation_subnets": []uint64{3, 7},
+		})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+		s.ENRHandler(rec, httptest.NewRequest(http.MethodPost, "/prysm/v1/admin/enr", bytes.NewReader(body)))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		resp := &enrResponse{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(resp))
+		assert.Equal(t, listener.Self().Seq(), resp.Seq)
+		assert.Equal(t, 4201, listener.Self().TCP())
+		assert.DeepEqual(t, []uint64{3, 7}, cache.SubnetIDs.GetAllSubnets())
+	})
+	t.Run("reserved key", func(t *testing.T) {
+		body := []byte(`{"entries":{"eth2":"0x01"}}`)
+		rec := httptest.NewRecorder()
+		s.ENRHandler(rec, httptest.NewRequest(http.MethodPost, "/prysm/v1/admin/enr", bytes.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("subnet out of range", func(t *testing.T) {
+		body := []byte(`{"attestation_subnets":[64]}`)
+		rec := httptest.NewRecorder()
+		s.ENRHandler(rec, httptest.NewRequest(http.MethodPost, "/prysm/v1/admin/enr", bytes.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("wrong method", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.ENRHandler(rec, httptest.NewRequest(http.MethodDelete, "/prysm/v1/admin/enr", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
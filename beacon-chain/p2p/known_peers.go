@@ -0,0 +1,167 @@
+package p2p
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxKnownPeers is the number of known peers kept across restarts.
+	maxKnownPeers = 256
+	// knownPeerMaxAge is the time after which a known peer which hasn't been seen is forgotten.
+	knownPeerMaxAge = 7 * 24 * time.Hour
+	// knownPeersSaveInterval is the interval at which the connected peers are saved as known peers.
+	knownPeersSaveInterval = 5 * time.Minute
+)
+
+// dialKnownPeers dials the known peers saved before the last shutdown of the node, so that it
+// connects to the network without waiting for discovery to find peers.
+func (s *Service) dialKnownPeers() {
+	if s.cfg.DB == nil {
+		return
+	}
+	known, err := s.cfg.DB.KnownPeers(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get known peers")
+		return
+	}
+	now := time.Now()
+	addrs := make([]ma.Multiaddr, 0, len(known))
+	for _, p := range knownPeersByPriority(known, now) {
+		addr, err := knownPeerAddress(p)
+		if err != nil {
+			log.WithError(err).WithField("peer", p.ID).Debug("Could not get address of known peer")
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 {
+		return
+	}
+	log.WithField("peers", len(addrs)).Info("Dialing known peers of the previous run")
+	knownPeersDialed.Add(float64(len(addrs)))
+	s.connectWithAllPeers(addrs)
+}
+
+// saveKnownPeers saves the connected peers with a non negative score as known peers, along with the
+// previously known peers which were seen recently enough. The address of inbound peers is not
+// saved, as their remote port is ephemeral, so they are only saved when their ENR has a TCP port.
+func (s *Service) saveKnownPeers() {
+	if s.cfg.DB == nil {
+		return
+	}
+	previous, err := s.cfg.DB.KnownPeers(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get known peers")
+		return
+	}
+	now := time.Now()
+	current := make([]*iface.KnownPeer, 0)
+	for _, pid := range s.peers.Connected() {
+		score := s.peers.Scorers().Score(pid)
+		if score < 0 || s.peers.IsBad(pid) {
+			continue
+		}
+		known := &iface.KnownPeer{
+			ID:       pid.String(),
+			Score:    score,
+			LastSeen: now,
+		}
+		if record, err := s.peers.ENR(pid); err == nil && record != nil {
+			if node, err := enode.New(enode.ValidSchemes, record); err == nil && node.TCP() != 0 {
+				known.ENR = node.String()
+			}
+		}
+		if direction, err := s.peers.Direction(pid); err == nil && direction == network.DirOutbound {
+			if addr, err := s.peers.Address(pid); err == nil && addr != nil {
+				if p2pAddr, err := ma.NewMultiaddr("/p2p/" + pid.String()); err == nil {
+					known.Address = addr.Encapsulate(p2pAddr).String()
+				}
+			}
+		}
+		if known.ENR == "" && known.Address == "" {
+			continue
+		}
+		current = append(current, known)
+	}
+	merged := mergeKnownPeers(previous, current, now)
+	if err := s.cfg.DB.SaveKnownPeers(s.ctx, merged); err != nil {
+		log.WithError(err).Error("Could not save known peers")
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"connected": len(current),
+		"saved":     len(merged),
+	}).Debug("Saved known peers")
+}
+
+// mergeKnownPeers returns the current peers and the previous peers which are not current, by priority,
+// capped to maxKnownPeers.
+func mergeKnownPeers(previous, current []*iface.KnownPeer, now time.Time) []*iface.KnownPeer {
+	seen := make(map[string]bool, len(current))
+	merged := make([]*iface.KnownPeer, 0, len(previous)+len(current))
+	for _, p := range current {
+		seen[p.ID] = true
+		merged = append(merged, p)
+	}
+	for _, p := range previous {
+		if !seen[p.ID] {
+			merged = append(merged, p)
+		}
+	}
+	return knownPeersByPriority(merged, now)
+}
+
+// knownPeersByPriority drops the peers which weren't seen for knownPeerMaxAge, and returns the others
+// by last seen time then score, capped to maxKnownPeers.
+func knownPeersByPriority(known []*iface.KnownPeer, now time.Time) []*iface.KnownPeer {
+	kept := make([]*iface.KnownPeer, 0, len(known))
+	for _, p := range known {
+		if now.Sub(p.LastSeen) <= knownPeerMaxAge {
+			kept = append(kept, p)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if !kept[i].LastSeen.Equal(kept[j].LastSeen) {
+			return kept[i].LastSeen.After(kept[j].LastSeen)
+		}
+		return kept[i].Score > kept[j].Score
+	})
+	if len(kept) > maxKnownPeers {
+		kept = kept[:maxKnownPeers]
+	}
+	return kept
+}
+
+// knownPeerAddress returns the address of a known peer, from its ENR when it advertises a TCP port,
+// as the peer listens on it, or else from the address it was dialed at.
+func knownPeerAddress(p *iface.KnownPeer) (ma.Multiaddr, error) {
+	if p.ENR != "" {
+		node, err := enode.Parse(enode.ValidSchemes, p.ENR)
+		if err == nil && node.TCP() != 0 {
+			return convertToSingleMultiAddr(node)
+		}
+		if p.Address == "" {
+			if err != nil {
+				return nil, err
+			}
+			return nil, errors.New("known peer has no address and no TCP port in its ENR")
+		}
+	}
+	addr, err := ma.NewMultiaddr(p.Address)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := peer.AddrInfoFromP2pAddr(addr); err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
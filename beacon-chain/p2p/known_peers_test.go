@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	gethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestMergeKnownPeers(t *testing.T) {
+	now := time.Now()
+	previous := []*iface.KnownPeer{
+		{ID: "old", LastSeen: now.Add(-knownPeerMaxAge - time.Second)},
+		{ID: "recent", Score: 1, LastSeen: now.Add(-time.Hour)},
+		{ID: "recent-low", Score: 0, LastSeen: now.Add(-time.Hour)},
+		{ID: "connected", Score: 5, LastSeen: now.Add(-2 * time.Hour)},
+	}
+	current := []*iface.KnownPeer{
+		{ID: "connected", Score: 2, LastSeen: now},
+		{ID: "new", Score: 3, LastSeen: now},
+	}
+	merged := mergeKnownPeers(previous, current, now)
+	ids := make([]string, len(merged))
+	for i, p := range merged {
+		ids[i] = p.ID
+	}
+	assert.DeepEqual(t, []string{"new", "connected", "recent", "recent-low"}, ids)
+	assert.Equal(t, float64(2), merged[1].Score)
+}
+
+func TestKnownPeersByPriority_Cap(t *testing.T) {
+	now := time.Now()
+	known := make([]*iface.KnownPeer, maxKnownPeers+10)
+	for i := range known {
+		known[i] = &iface.KnownPeer{ID: fmt.Sprintf("peer%d", i), LastSeen: now.Add(-time.Duration(i) * time.Second)}
+	}
+	kept := knownPeersByPriority(known, now)
+	require.Equal(t, maxKnownPeers, len(kept))
+	assert.Equal(t, "peer0", kept[0].ID)
+	assert.Equal(t, fmt.Sprintf("peer%d", maxKnownPeers-1), kept[maxKnownPeers-1].ID)
+}
+
+func TestKnownPeerAddress(t *testing.T) {
+	addr, err := knownPeerAddress(&iface.KnownPeer{
+		Address: "/ip4/127.0.0.1/tcp/13000/p2p/16Uiu2HAmRrhnqEfybLYimCiAYer2AtZKDGamQrL1VwRCyeh2YiFc",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/13000/p2p/16Uiu2HAmRrhnqEfybLYimCiAYer2AtZKDGamQrL1VwRCyeh2YiFc", addr.String())
+
+	_, err = knownPeerAddress(&iface.KnownPeer{Address: "/ip4/127.0.0.1/tcp/13000"})
+	assert.NotNil(t, err)
+
+	// The address is taken from the ENR when the peer has no address.
+	key, err := gethCrypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	lNode := enode.NewLocalNode(db, key)
+	lNode.Set(enr.IPv4{127, 0, 0, 1})
+	lNode.Set(enr.TCP(13000))
+	addr, err = knownPeerAddress(&iface.KnownPeer{ENR: lNode.Node().String()})
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.HasPrefix(addr.String(), "/ip4/127.0.0.1/tcp/13000"))
+
+	// The ENR is preferred to the address, which may be the ephemeral port of an inbound peer.
+	addr, err = knownPeerAddress(&iface.KnownPeer{
+		Address: "/ip4/127.0.0.1/tcp/40123/p2p/16Uiu2HAmRrhnqEfybLYimCiAYer2AtZKDGamQrL1VwRCyeh2YiFc",
+		ENR:     lNode.Node().String(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.HasPrefix(addr.String(), "/ip4/127.0.0.1/tcp/13000"))
+
+	// The address is used when the ENR has no TCP port.
+	lNode.Delete(enr.TCP(0))
+	addr, err = knownPeerAddress(&iface.KnownPeer{
+		Address: "/ip4/127.0.0.1/tcp/40123/p2p/16Uiu2HAmRrhnqEfybLYimCiAYer2AtZKDGamQrL1VwRCyeh2YiFc",
+		ENR:     lNode.Node().String(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/40123/p2p/16Uiu2HAmRrhnqEfybLYimCiAYer2AtZKDGamQrL1VwRCyeh2YiFc", addr.String())
+	_, err = knownPeerAddress(&iface.KnownPeer{ENR: lNode.Node().String()})
+	assert.ErrorContains(t, "no TCP port", err)
+}
@@ -0,0 +1,24 @@
+package p2p
+
+import "github.com/pkg/errors"
+
+// ErrMaintenanceMode is returned when a new gossip subscription is requested
+// while the node is draining in preparation for a restart.
+var ErrMaintenanceMode = errors.New("node is in maintenance mode, not accepting new subscriptions")
+
+// EnterMaintenanceMode stops the service from accepting new gossip topic subscriptions.
+// Existing subscriptions and peer connections are left untouched so that the node keeps
+// serving the network while in-flight work completes.
+func (s *Service) EnterMaintenanceMode() {
+	s.maintenanceMode.Set()
+}
+
+// InMaintenanceMode returns true if the service has been put into maintenance mode.
+func (s *Service) InMaintenanceMode() bool {
+	return s.maintenanceMode.IsSet()
+}
+
+// ExitMaintenanceMode makes the service accept new gossip topic subscriptions again.
+func (s *Service) ExitMaintenanceMode() {
+	s.maintenanceMode.UnSet()
+}
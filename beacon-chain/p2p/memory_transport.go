@@ -0,0 +1,327 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/transport"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+)
+
+// First port of the addresses given to the dialing side of in-memory connections, mimicking
+// ephemeral ports.
+const memoryDialPortStart = 49152
+
+var (
+	errNoMemoryListener = errors.New("no in-memory listener at address")
+	errListenerClosed   = errors.New("in-memory listener closed")
+)
+
+// Listeners of the in-memory transport of the process, by listen address.
+var memoryListeners = struct {
+	sync.Mutex
+	byAddr map[string]*memoryListener
+}{byAddr: make(map[string]*memoryListener)}
+
+var memoryDialPort = uint32(memoryDialPortStart)
+
+// memoryTransport is a libp2p transport connecting the hosts of the same process through in-memory
+// buffers instead of sockets. Hosts keep listening and dialing on tcp multiaddresses, so that peers
+// are configured as usual, but an address can only be dialed by the exact multiaddress it is listened
+// on. Connections are secured and multiplexed like tcp connections.
+type memoryTransport struct {
+	upgrader transport.Upgrader
+	rcmgr    network.ResourceManager
+}
+
+var _ transport.Transport = (*memoryTransport)(nil)
+
+func newMemoryTransport(upgrader transport.Upgrader, rcmgr network.ResourceManager) *memoryTransport {
+	if rcmgr == nil {
+		rcmgr = network.NullResourceManager
+	}
+	return &memoryTransport{upgrader: upgrader, rcmgr: rcmgr}
+}
+
+// Dial connects to the in-memory listener of the remote address.
+func (t *memoryTransport) Dial(ctx context.Context, raddr ma.Multiaddr, p peer.ID) (transport.CapableConn, error) {
+	connScope, err := t.rcmgr.OpenConnection(network.DirOutbound, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := connScope.SetPeer(p); err != nil {
+		connScope.Done()
+		return nil, err
+	}
+	conn, err := dialMemory(ctx, raddr)
+	if err != nil {
+		connScope.Done()
+		return nil, err
+	}
+	return t.upgrader.Upgrade(ctx, t, conn, network.DirOutbound, p, connScope)
+}
+
+// CanDial returns true for tcp multiaddresses.
+func (_ *memoryTransport) CanDial(addr ma.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(ma.P_TCP)
+	return err == nil
+}
+
+// Listen registers an in-memory listener at the given tcp multiaddress.
+func (t *memoryTransport) Listen(laddr ma.Multiaddr) (transport.Listener, error) {
+	l, err := listenMemory(laddr)
+	if err != nil {
+		return nil, err
+	}
+	return t.upgrader.UpgradeListener(t, l), nil
+}
+
+// Protocols returns the protocols dialed by the transport.
+func (_ *memoryTransport) Protocols() []int {
+	return []int{ma.P_TCP}
+}
+
+// Proxy returns false, the transport connects to peers directly.
+func (_ *memoryTransport) Proxy() bool {
+	return false
+}
+
+func (_ *memoryTransport) String() string {
+	return "memory"
+}
+
+func listenMemory(laddr ma.Multiaddr) (*memoryListener, error) {
+	memoryListeners.Lock()
+	defer memoryListeners.Unlock()
+	if _, ok := memoryListeners.byAddr[laddr.String()]; ok {
+		return nil, errors.Errorf("in-memory address %s already in use", laddr)
+	}
+	l := &memoryListener{
+		laddr:  laddr,
+		conns:  make(chan manet.Conn),
+		closed: make(chan struct{}),
+	}
+	memoryListeners.byAddr[laddr.String()] = l
+	return l, nil
+}
+
+func dialMemory(ctx context.Context, raddr ma.Multiaddr) (manet.Conn, error) {
+	memoryListeners.Lock()
+	l, ok := memoryListeners.byAddr[raddr.String()]
+	memoryListeners.Unlock()
+	if !ok {
+		return nil, errors.Wrap(errNoMemoryListener, raddr.String())
+	}
+	ip, err := manet.ToIP(l.laddr)
+	if err != nil {
+		return nil, err
+	}
+	laddr, err := MultiAddressBuilder(ip.String(), uint(atomic.AddUint32(&memoryDialPort, 1)))
+	if err != nil {
+		return nil, err
+	}
+	dialerSide, listenerSide := newMemoryConnPair(laddr, raddr)
+	select {
+	case l.conns <- listenerSide:
+		return dialerSide, nil
+	case <-l.closed:
+		return nil, errors.Wrap(errListenerClosed, raddr.String())
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// memoryListener accepts the in-memory connections dialed to its address.
+type memoryListener struct {
+	laddr     ma.Multiaddr
+	conns     chan manet.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Accept waits for the next in-memory connection to the listener.
+func (l *memoryListener) Accept() (manet.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errListenerClosed
+	}
+}
+
+// Close stops accepting connections and frees the address of the listener.
+func (l *memoryListener) Close() error {
+	l.closeOnce.Do(func() {
+		memoryListeners.Lock()
+		delete(memoryListeners.byAddr, l.laddr.String())
+		memoryListeners.Unlock()
+		close(l.closed)
+	})
+	return nil
+}
+
+// Multiaddr returns the address of the listener.
+func (l *memoryListener) Multiaddr() ma.Multiaddr {
+	return l.laddr
+}
+
+// Addr returns the address of the listener.
+func (l *memoryListener) Addr() net.Addr {
+	addr, err := manet.ToNetAddr(l.laddr)
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
+// memoryConn is one end of an in-memory connection. Writes never block, the data is buffered until
+// the other end reads it.
+type memoryConn struct {
+	incoming *memoryBuffer
+	outgoing *memoryBuffer
+	laddr    ma.Multiaddr
+	raddr    ma.Multiaddr
+}
+
+var _ manet.Conn = (*memoryConn)(nil)
+
+func newMemoryConnPair(dialerAddr, listenerAddr ma.Multiaddr) (*memoryConn, *memoryConn) {
+	toListener, toDialer := newMemoryBuffer(), newMemoryBuffer()
+	return &memoryConn{incoming: toDialer, outgoing: toListener, laddr: dialerAddr, raddr: listenerAddr},
+		&memoryConn{incoming: toListener, outgoing: toDialer, laddr: listenerAddr, raddr: dialerAddr}
+}
+
+func (c *memoryConn) Read(b []byte) (int, error) {
+	return c.incoming.read(b)
+}
+
+func (c *memoryConn) Write(b []byte) (int, error) {
+	return c.outgoing.write(b)
+}
+
+// Close closes both directions of the connection. The other end can still read the data written
+// before closing.
+func (c *memoryConn) Close() error {
+	c.incoming.close()
+	c.outgoing.close()
+	return nil
+}
+
+func (c *memoryConn) LocalAddr() net.Addr {
+	addr, err := manet.ToNetAddr(c.laddr)
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
+func (c *memoryConn) RemoteAddr() net.Addr {
+	addr, err := manet.ToNetAddr(c.raddr)
+	if err != nil {
+		return nil
+	}
+	return addr
+}
+
+func (c *memoryConn) LocalMultiaddr() ma.Multiaddr {
+	return c.laddr
+}
+
+func (c *memoryConn) RemoteMultiaddr() ma.Multiaddr {
+	return c.raddr
+}
+
+func (c *memoryConn) SetDeadline(t time.Time) error {
+	return c.SetReadDeadline(t)
+}
+
+func (c *memoryConn) SetReadDeadline(t time.Time) error {
+	c.incoming.setReadDeadline(t)
+	return nil
+}
+
+// SetWriteDeadline is a no-op, writes never block.
+func (_ *memoryConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+// memoryBuffer holds the data written to one direction of an in-memory connection.
+type memoryBuffer struct {
+	lock     sync.Mutex
+	cond     *sync.Cond
+	buf      bytes.Buffer
+	closed   bool
+	deadline time.Time
+	timer    *time.Timer
+}
+
+func newMemoryBuffer() *memoryBuffer {
+	b := &memoryBuffer{}
+	b.cond = sync.NewCond(&b.lock)
+	return b
+}
+
+func (b *memoryBuffer) read(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for b.buf.Len() == 0 {
+		if b.closed {
+			return 0, io.EOF
+		}
+		if !b.deadline.IsZero() && !time.Now().Before(b.deadline) {
+			return 0, os.ErrDeadlineExceeded
+		}
+		b.cond.Wait()
+	}
+	return b.buf.Read(p)
+}
+
+func (b *memoryBuffer) write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err := b.buf.Write(p)
+	b.cond.Broadcast()
+	return n, err
+}
+
+func (b *memoryBuffer) close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.closed = true
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.cond.Broadcast()
+}
+
+// Sets the time after which blocked and future reads fail, readers are woken up when it passes.
+func (b *memoryBuffer) setReadDeadline(t time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.deadline = t
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if !t.IsZero() {
+		b.timer = time.AfterFunc(time.Until(t), func() {
+			b.lock.Lock()
+			b.cond.Broadcast()
+			b.lock.Unlock()
+		})
+	}
+	b.cond.Broadcast()
+}
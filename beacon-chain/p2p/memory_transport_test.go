@@ -0,0 +1,127 @@
+package p2p
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestMemoryTransport_HostsConnect(t *testing.T) {
+	_, pkey := createAddrAndPrivKey(t)
+	_, pkey2 := createAddrAndPrivKey(t)
+	listen, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/2000")
+	require.NoError(t, err)
+	listen2, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/3000")
+	require.NoError(t, err)
+
+	h1, err := libp2p.New(privKeyOption(pkey), libp2p.ListenAddrs(listen), libp2p.Transport(newMemoryTransport))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, h1.Close())
+	}()
+	h2, err := libp2p.New(privKeyOption(pkey2), libp2p.ListenAddrs(listen2), libp2p.Transport(newMemoryTransport))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, h2.Close())
+	}()
+
+	received := make(chan []byte, 1)
+	h1.SetStreamHandler("/test/1", func(stream network.Stream) {
+		defer func() {
+			_ = stream.Close()
+		}()
+		b, err := io.ReadAll(stream)
+		if err != nil {
+			return
+		}
+		received <- b
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, h2.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: []ma.Multiaddr{listen}}))
+	stream, err := h2.NewStream(ctx, h1.ID(), "/test/1")
+	require.NoError(t, err)
+	_, err = stream.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseWrite())
+
+	select {
+	case b := <-received:
+		assert.DeepEqual(t, []byte("hello"), b)
+	case <-ctx.Done():
+		t.Fatal("Did not receive the message")
+	}
+}
+
+func TestMemoryTransport_DialUnknownAddress(t *testing.T) {
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/4000")
+	require.NoError(t, err)
+	_, err = dialMemory(context.Background(), addr)
+	require.ErrorIs(t, err, errNoMemoryListener)
+}
+
+func TestMemoryTransport_ListenerClose(t *testing.T) {
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/5000")
+	require.NoError(t, err)
+	l, err := listenMemory(addr)
+	require.NoError(t, err)
+	_, err = listenMemory(addr)
+	require.ErrorContains(t, "already in use", err)
+
+	require.NoError(t, l.Close())
+	_, err = l.Accept()
+	require.ErrorIs(t, err, errListenerClosed)
+	_, err = dialMemory(context.Background(), addr)
+	require.ErrorIs(t, err, errNoMemoryListener)
+
+	// The address can be listened on again once freed.
+	l, err = listenMemory(addr)
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+}
+
+func TestMemoryConn_ReadWrite(t *testing.T) {
+	laddr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/6000")
+	require.NoError(t, err)
+	raddr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/6001")
+	require.NoError(t, err)
+	dialer, listener := newMemoryConnPair(laddr, raddr)
+	assert.Equal(t, true, dialer.LocalMultiaddr().Equal(listener.RemoteMultiaddr()))
+	assert.Equal(t, true, dialer.RemoteMultiaddr().Equal(listener.LocalMultiaddr()))
+
+	// Writes don't wait for the other end to read.
+	_, err = dialer.Write([]byte("ping"))
+	require.NoError(t, err)
+	_, err = dialer.Write([]byte("pong"))
+	require.NoError(t, err)
+	b := make([]byte, 8)
+	n, err := io.ReadFull(listener, b)
+	require.NoError(t, err)
+	assert.Equal(t, "pingpong", string(b[:n]))
+
+	require.NoError(t, listener.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err = listener.Read(b)
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	require.NoError(t, listener.SetReadDeadline(time.Time{}))
+
+	_, err = dialer.Write([]byte("bye"))
+	require.NoError(t, err)
+	require.NoError(t, dialer.Close())
+	n, err = listener.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "bye", string(b[:n]))
+	_, err = listener.Read(b)
+	require.ErrorIs(t, err, io.EOF)
+	_, err = listener.Write([]byte("late"))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
@@ -43,6 +43,10 @@ var (
 		Name: "p2p_sync_committee_subnet_attempted_broadcasts",
 		Help: "The number of sync committee that were attempted to be broadcast.",
 	})
+	knownPeersDialed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_known_peers_dialed_total",
+		Help: "The number of peers known from a previous run of the node dialed on startup.",
+	})
 )
 
 func (s *Service) updateMetrics() {
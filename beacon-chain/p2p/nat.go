@@ -0,0 +1,207 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// Lifetime requested for port mappings, they are renewed well before they expire.
+	natMappingLifetime = 20 * time.Minute
+	// Interval at which port mappings are renewed and the external IP address is checked.
+	natRefreshInterval = 5 * time.Minute
+	// Description of the port mappings shown by the gateway.
+	natMappingName = "prysm p2p"
+)
+
+var (
+	natMappingActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_nat_mapping_active",
+		Help: "Whether the port mapping of the given protocol is currently established on the gateway.",
+	}, []string{"protocol"})
+	natMappingFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_nat_mapping_failures_total",
+		Help: "The number of failed attempts to establish or renew a port mapping on the gateway.",
+	}, []string{"protocol"})
+	natExternalIPChanges = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "p2p_nat_external_ip_changes_total",
+		Help: "The number of times the external IP address reported by the gateway changed.",
+	})
+)
+
+// natMapping is the state of the port mapping of one protocol.
+type natMapping struct {
+	Protocol    string    `json:"protocol"`
+	Port        int       `json:"port"`
+	Mapped      bool      `json:"mapped"`
+	LastRefresh time.Time `json:"last_refresh"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// natStatus is the JSON response served by the /p2p/nat handler.
+type natStatus struct {
+	Method     string        `json:"method"`
+	ExternalIP string        `json:"external_ip,omitempty"`
+	Mappings   []*natMapping `json:"mappings"`
+}
+
+// natManager negotiates port mappings for the p2p ports with the gateway, using UPnP or
+// NAT-PMP, and keeps track of the external IP address of the gateway.
+type natManager struct {
+	iface      nat.Interface
+	lock       sync.RWMutex
+	externalIP net.IP
+	mappings   []*natMapping
+}
+
+func newNATManager(iface nat.Interface, tcpPort, udpPort uint) *natManager {
+	return &natManager{
+		iface: iface,
+		mappings: []*natMapping{
+			{Protocol: "TCP", Port: int(tcpPort)},
+			{Protocol: "UDP", Port: int(udpPort)},
+		},
+	}
+}
+
+// refresh establishes or renews the port mappings and queries the external IP address of the
+// gateway. It returns the external IP address and whether it changed since the last refresh.
+func (m *natManager) refresh() (net.IP, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, mapping := range m.mappings {
+		err := m.iface.AddMapping(mapping.Protocol, mapping.Port, mapping.Port, natMappingName, natMappingLifetime)
+		mapping.LastRefresh = time.Now()
+		if err != nil {
+			if mapping.Mapped || mapping.Error == "" {
+				log.WithError(err).WithField("protocol", mapping.Protocol).WithField("port", mapping.Port).
+					Warn("Could not map port on the gateway")
+			}
+			mapping.Mapped = false
+			mapping.Error = err.Error()
+			natMappingFailures.WithLabelValues(mapping.Protocol).Inc()
+			natMappingActive.WithLabelValues(mapping.Protocol).Set(0)
+			continue
+		}
+		if !mapping.Mapped {
+			log.WithField("protocol", mapping.Protocol).WithField("port", mapping.Port).
+				WithField("method", m.iface.String()).Info("Mapped port on the gateway")
+		}
+		mapping.Mapped = true
+		mapping.Error = ""
+		natMappingActive.WithLabelValues(mapping.Protocol).Set(1)
+	}
+
+	ip, err := m.iface.ExternalIP()
+	if err != nil {
+		log.WithError(err).Debug("Could not get external IP address from the gateway")
+		return m.externalIP, false
+	}
+	if ip.Equal(m.externalIP) {
+		return ip, false
+	}
+	if m.externalIP != nil {
+		natExternalIPChanges.Inc()
+	}
+	m.externalIP = ip
+	return ip, true
+}
+
+// close removes the port mappings from the gateway.
+func (m *natManager) close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, mapping := range m.mappings {
+		if !mapping.Mapped {
+			continue
+		}
+		if err := m.iface.DeleteMapping(mapping.Protocol, mapping.Port, mapping.Port); err != nil {
+			log.WithError(err).WithField("protocol", mapping.Protocol).Debug("Could not delete port mapping")
+		}
+		mapping.Mapped = false
+		natMappingActive.WithLabelValues(mapping.Protocol).Set(0)
+	}
+}
+
+// externalAddr returns the external IP address of the gateway, nil if it isn't known yet.
+func (m *natManager) externalAddr() net.IP {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.externalIP
+}
+
+func (m *natManager) status() *natStatus {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	status := &natStatus{Method: m.iface.String()}
+	if m.externalIP != nil {
+		status.ExternalIP = m.externalIP.String()
+	}
+	for _, mapping := range m.mappings {
+		mappingCopy := *mapping
+		status.Mappings = append(status.Mappings, &mappingCopy)
+	}
+	return status
+}
+
+// maintainNATMappings keeps the port mappings alive for as long as the service runs and
+// advertises the external IP address of the gateway in the ENR when it changes. The address
+// isn't advertised if the node was given a host address or DNS name to advertise.
+func (s *Service) maintainNATMappings() {
+	ticker := time.NewTicker(natRefreshInterval)
+	defer ticker.Stop()
+	defer s.nat.close()
+	for {
+		ip, changed := s.nat.refresh()
+		if changed && s.cfg.HostAddress == "" && s.cfg.HostDNS == "" {
+			log.WithField("ip", ip).Info("Gateway external IP address changed")
+			if s.dv5Listener != nil {
+				if _, err := s.UpdateENR(&ENRUpdate{IP: ip}); err != nil {
+					log.WithError(err).Error("Could not advertise external IP address in ENR")
+				}
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// natAddrsFactory adds the external address of the gateway to the addresses advertised by
+// the libp2p host, once it is known.
+func (s *Service) natAddrsFactory(addrs []ma.Multiaddr) []ma.Multiaddr {
+	ip := s.nat.externalAddr()
+	if ip == nil {
+		return addrs
+	}
+	external, err := MultiAddressBuilder(ip.String(), s.cfg.TCPPort)
+	if err != nil {
+		log.WithError(err).Error("Unable to create external multiaddress")
+		return addrs
+	}
+	return append(addrs, external)
+}
+
+// NATHandler serves the /p2p/nat page in metrics, reporting the state of the port mappings
+// negotiated with the gateway.
+func (s *Service) NATHandler(w http.ResponseWriter, _ *http.Request) {
+	if s.nat == nil {
+		http.Error(w, "port mapping is disabled, it can be enabled with --enable-upnp", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(s.nat.status()); err != nil {
+		log.WithError(err).Error("Failed to render NAT status page")
+	}
+}
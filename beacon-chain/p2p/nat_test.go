@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+type mockNAT struct {
+	externalIP net.IP
+	mapErr     error
+	mapped     map[string]int
+}
+
+func (m *mockNAT) AddMapping(protocol string, extport, _ int, _ string, _ time.Duration) error {
+	if m.mapErr != nil {
+		return m.mapErr
+	}
+	m.mapped[protocol] = extport
+	return nil
+}
+
+func (m *mockNAT) DeleteMapping(protocol string, _, _ int) error {
+	delete(m.mapped, protocol)
+	return nil
+}
+
+func (m *mockNAT) ExternalIP() (net.IP, error) {
+	return m.externalIP, nil
+}
+
+func (*mockNAT) String() string {
+	return "mock"
+}
+
+func TestNATManager_Refresh(t *testing.T) {
+	iface := &mockNAT{externalIP: net.ParseIP("192.0.2.1"), mapped: make(map[string]int)}
+	m := newNATManager(iface, 13000, 12000)
+
+	ip, changed := m.refresh()
+	assert.Equal(t, true, changed)
+	assert.Equal(t, true, ip.Equal(iface.externalIP))
+	assert.Equal(t, 13000, iface.mapped["TCP"])
+	assert.Equal(t, 12000, iface.mapped["UDP"])
+
+	_, changed = m.refresh()
+	assert.Equal(t, false, changed, "Unchanged external IP reported as changed")
+
+	iface.externalIP = net.ParseIP("192.0.2.2")
+	ip, changed = m.refresh()
+	assert.Equal(t, true, changed)
+	assert.Equal(t, true, ip.Equal(m.externalAddr()))
+
+	iface.mapErr = errors.New("gateway unreachable")
+	m.refresh()
+	status := m.status()
+	require.Equal(t, 2, len(status.Mappings))
+	for _, mapping := range status.Mappings {
+		assert.Equal(t, false, mapping.Mapped)
+		assert.Equal(t, "gateway unreachable", mapping.Error)
+	}
+
+	iface.mapErr = nil
+	m.refresh()
+	m.close()
+	assert.Equal(t, 0, len(iface.mapped), "Mappings not deleted")
+}
+
+func TestNATHandler(t *testing.T) {
+	s := &Service{cfg: &Config{}}
+	rec := httptest.NewRecorder()
+	s.NATHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/nat", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	iface := &mockNAT{externalIP: net.ParseIP("192.0.2.1"), mapped: make(map[string]int)}
+	s.nat = newNATManager(iface, 13000, 12000)
+	s.nat.refresh()
+	rec = httptest.NewRecorder()
+	s.NATHandler(rec, httptest.NewRequest(http.MethodGet, "/p2p/nat", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	status := &natStatus{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(status))
+	assert.Equal(t, "mock", status.Method)
+	assert.Equal(t, "192.0.2.1", status.ExternalIP)
+	require.Equal(t, 2, len(status.Mappings))
+	assert.Equal(t, true, status.Mappings[0].Mapped)
+}
@@ -59,15 +59,26 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		libp2p.ListenAddrs(listen),
 		libp2p.UserAgent(version.BuildData()),
 		libp2p.ConnectionGater(s),
-		libp2p.Transport(tcp.NewTCPTransport),
 		libp2p.Muxer("/mplex/6.7.0", mplex.DefaultTransport),
 		libp2p.DefaultMuxers,
 	}
+	if cfg.InMemoryTransport {
+		options = append(options, libp2p.Transport(newMemoryTransport))
+	} else {
+		options = append(options, libp2p.Transport(tcp.NewTCPTransport))
+	}
 
 	options = append(options, libp2p.Security(noise.ID, noise.New))
 
-	if cfg.EnableUPnP {
-		options = append(options, libp2p.NATPortMap()) // Allow to use UPnP
+	if s.psk != nil {
+		log.Info("Running in private network mode, only peers holding the same pre-shared key can connect")
+		options = append(options, libp2p.PrivateNetwork(s.psk))
+	}
+
+	// Port mappings are negotiated by the service, the external address of the gateway is advertised
+	// unless another address factory is in use.
+	if s.nat != nil && cfg.RelayNodeAddr == "" && cfg.HostAddress == "" && cfg.HostDNS == "" {
+		options = append(options, libp2p.AddrsFactory(s.natAddrsFactory))
 	}
 	if cfg.RelayNodeAddr != "" {
 		options = append(options, libp2p.AddrsFactory(withRelayAddrs(cfg.RelayNodeAddr)))
@@ -97,6 +108,9 @@ func (s *Service) buildOptions(ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 			return addrs
 		}))
 	}
+	if s.bandwidth != nil {
+		options = append(options, libp2p.BandwidthReporter(s.bandwidth))
+	}
 	// Disable Ping Service.
 	options = append(options, libp2p.Ping(false))
 	return options
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"net"
 	"os"
+	"path"
 	"testing"
 
 	gethCrypto "github.com/ethereum/go-ethereum/crypto"
@@ -49,6 +50,31 @@ func TestPrivateKeyLoading(t *testing.T) {
 	assert.DeepEqual(t, rawBytes, newRaw, "Private keys do not match")
 }
 
+func TestPreSharedKeyLoading(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	psk, err := preSharedKey(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, true, psk == nil, "Expected no pre-shared key when the file is not set")
+
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	require.NoError(t, err)
+	pskPath := path.Join(t.TempDir(), "swarm.key")
+	contents := "/key/swarm/psk/1.0.0/\n/base16/\n" + hex.EncodeToString(key)
+	require.NoError(t, os.WriteFile(pskPath, []byte(contents), params.BeaconIoConfig().ReadWritePermissions))
+	psk, err = preSharedKey(&Config{PreSharedKeyFile: pskPath})
+	require.NoError(t, err)
+	assert.DeepEqual(t, key, []byte(psk))
+
+	invalidPath := path.Join(t.TempDir(), "invalid.key")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("invalid"), params.BeaconIoConfig().ReadWritePermissions))
+	_, err = preSharedKey(&Config{PreSharedKeyFile: invalidPath})
+	require.ErrorContains(t, "could not decode pre-shared key", err)
+
+	_, err = preSharedKey(&Config{PreSharedKeyFile: path.Join(t.TempDir(), "missing.key")})
+	require.ErrorContains(t, "could not open pre-shared key file", err)
+}
+
 func TestIPV6Support(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	key, err := gethCrypto.GenerateKey()
@@ -0,0 +1,133 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
+)
+
+// Messages published by this node that have not been seen again from a peer
+// within this period are considered unconfirmed.
+const publishConfirmationTimeout = 12 * time.Second
+
+var (
+	publishConfirmationLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "p2p_publish_confirmation_latency_milliseconds",
+		Help: "Time between publishing a message and first receiving it back as a duplicate from a peer, " +
+			"used as a proxy for the propagation latency of messages published by this node.",
+		Buckets: []float64{25, 50, 100, 200, 400, 800, 1600, 3200, 6400, 12000},
+	}, []string{"topic"})
+	publishUnconfirmedCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_publish_unconfirmed_total",
+		Help: "The number of messages published by this node that were never received back from a peer.",
+	}, []string{"topic"})
+)
+
+type publishedMessage struct {
+	topic string
+	time  time.Time
+}
+
+// publishTracer is a pubsub raw tracer recording how long it takes for the messages published
+// by this node to be received back from one of its peers. With gossipsub a peer relays a message
+// to the rest of its mesh, so a duplicate coming back means the message has made it at least
+// one hop past our direct peers.
+type publishTracer struct {
+	host    peer.ID
+	lock    sync.Mutex
+	pending map[string]publishedMessage
+}
+
+var _ = pubsub.RawTracer(&publishTracer{})
+
+func newPublishTracer(host peer.ID) *publishTracer {
+	return &publishTracer{
+		host:    host,
+		pending: make(map[string]publishedMessage),
+	}
+}
+
+// DeliverMessage records the publication time of the messages originating from this node.
+func (t *publishTracer) DeliverMessage(msg *pubsub.Message) {
+	if msg.ReceivedFrom != t.host || msg.Topic == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := prysmTime.Now()
+	t.prune(now)
+	t.pending[msg.ID] = publishedMessage{topic: *msg.Topic, time: now}
+}
+
+// DuplicateMessage observes the confirmation latency the first time a message published by this
+// node is received from a peer.
+func (t *publishTracer) DuplicateMessage(msg *pubsub.Message) {
+	if msg.ReceivedFrom == t.host {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	published, ok := t.pending[msg.ID]
+	if !ok {
+		return
+	}
+	delete(t.pending, msg.ID)
+	latency := prysmTime.Since(published.time)
+	publishConfirmationLatency.WithLabelValues(published.topic).Observe(float64(latency.Milliseconds()))
+}
+
+// This removes the messages published more than publishConfirmationTimeout ago, counting them
+// as unconfirmed. The caller must hold the lock.
+func (t *publishTracer) prune(now time.Time) {
+	for id, published := range t.pending {
+		if now.Sub(published.time) > publishConfirmationTimeout {
+			delete(t.pending, id)
+			publishUnconfirmedCount.WithLabelValues(published.topic).Inc()
+		}
+	}
+}
+
+// AddPeer is a no-op.
+func (*publishTracer) AddPeer(peer.ID, protocol.ID) {}
+
+// RemovePeer is a no-op.
+func (*publishTracer) RemovePeer(peer.ID) {}
+
+// Join is a no-op.
+func (*publishTracer) Join(string) {}
+
+// Leave is a no-op.
+func (*publishTracer) Leave(string) {}
+
+// Graft is a no-op.
+func (*publishTracer) Graft(peer.ID, string) {}
+
+// Prune is a no-op.
+func (*publishTracer) Prune(peer.ID, string) {}
+
+// ValidateMessage is a no-op.
+func (*publishTracer) ValidateMessage(*pubsub.Message) {}
+
+// RejectMessage is a no-op.
+func (*publishTracer) RejectMessage(*pubsub.Message, string) {}
+
+// ThrottlePeer is a no-op.
+func (*publishTracer) ThrottlePeer(peer.ID) {}
+
+// RecvRPC is a no-op.
+func (*publishTracer) RecvRPC(*pubsub.RPC) {}
+
+// SendRPC is a no-op.
+func (*publishTracer) SendRPC(*pubsub.RPC, peer.ID) {}
+
+// DropRPC is a no-op.
+func (*publishTracer) DropRPC(*pubsub.RPC, peer.ID) {}
+
+// UndeliverableMessage is a no-op.
+func (*publishTracer) UndeliverableMessage(*pubsub.Message) {}
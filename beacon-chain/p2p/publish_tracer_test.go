@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestPublishTracer_ConfirmsOwnMessages(t *testing.T) {
+	host := peer.ID("host")
+	tracer := newPublishTracer(host)
+	topic := "/eth2/00000000/beacon_block/ssz_snappy"
+	msg := func(id string, from peer.ID) *pubsub.Message {
+		return &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic}, ID: id, ReceivedFrom: from}
+	}
+
+	// Messages received from peers are not tracked.
+	tracer.DeliverMessage(msg("remote", peer.ID("a")))
+	assert.Equal(t, 0, len(tracer.pending))
+
+	tracer.DeliverMessage(msg("own", host))
+	require.Equal(t, 1, len(tracer.pending))
+	assert.Equal(t, topic, tracer.pending["own"].topic)
+
+	// Duplicates of other messages leave the pending message untouched.
+	tracer.DuplicateMessage(msg("remote", peer.ID("b")))
+	assert.Equal(t, 1, len(tracer.pending))
+
+	tracer.DuplicateMessage(msg("own", peer.ID("b")))
+	assert.Equal(t, 0, len(tracer.pending))
+}
+
+func TestPublishTracer_PrunesUnconfirmedMessages(t *testing.T) {
+	host := peer.ID("host")
+	tracer := newPublishTracer(host)
+	topic := "/eth2/00000000/beacon_aggregate_and_proof/ssz_snappy"
+	tracer.pending["old"] = publishedMessage{topic: topic, time: time.Now().Add(-2 * publishConfirmationTimeout)}
+	tracer.pending["recent"] = publishedMessage{topic: topic, time: time.Now()}
+
+	tracer.DeliverMessage(&pubsub.Message{Message: &pubsubpb.Message{Topic: &topic}, ID: "new", ReceivedFrom: host})
+	require.Equal(t, 2, len(tracer.pending))
+	_, ok := tracer.pending["old"]
+	assert.Equal(t, false, ok)
+	_, ok = tracer.pending["new"]
+	assert.Equal(t, true, ok)
+}
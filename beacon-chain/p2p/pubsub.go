@@ -74,8 +74,13 @@ func (s *Service) LeaveTopic(topic string) error {
 	return nil
 }
 
-// PublishToTopic joins (if necessary) and publishes a message to a PubSub topic.
+// PublishToTopic joins (if necessary) and publishes a message to a PubSub topic. Messages exceeding the
+// outbound cap of their topic are not published.
 func (s *Service) PublishToTopic(ctx context.Context, topic string, data []byte, opts ...pubsub.PubOpt) error {
+	if !s.gossipCaps.allow(topic, len(data)) {
+		gossipCappedMessages.WithLabelValues(topic).Inc()
+		return errors.Wrapf(errGossipCapReached, "could not publish to topic %s", topic)
+	}
 	topicHandle, err := s.JoinTopic(topic)
 	if err != nil {
 		return err
@@ -97,6 +102,9 @@ func (s *Service) PublishToTopic(ctx context.Context, topic string, data []byte,
 
 // SubscribeToTopic joins (if necessary) and subscribes to PubSub topic.
 func (s *Service) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub.Subscription, error) {
+	if s.InMaintenanceMode() {
+		return nil, ErrMaintenanceMode
+	}
 	s.awaitStateInitialized() // Genesis time and genesis validators root are required to subscribe.
 
 	topicHandle, err := s.JoinTopic(topic)
@@ -134,3 +134,16 @@ func BenchmarkExtractGossipDigest(b *testing.B) {
 		}
 	}
 }
+
+func TestService_SubscribeToTopic_MaintenanceMode(t *testing.T) {
+	s := &Service{}
+	require.Equal(t, false, s.InMaintenanceMode())
+	s.EnterMaintenanceMode()
+	require.Equal(t, true, s.InMaintenanceMode())
+
+	_, err := s.SubscribeToTopic("/eth2/00000000/beacon_block/ssz_snappy")
+	require.ErrorIs(t, err, ErrMaintenanceMode)
+
+	s.ExitMaintenanceMode()
+	require.Equal(t, false, s.InMaintenanceMode())
+}
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"strings"
 
 	"github.com/kr/pretty"
 	"github.com/libp2p/go-libp2p-core/network"
@@ -9,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -36,18 +38,29 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 	ctx, cancel := context.WithTimeout(ctx, maxDialTimeout)
 	defer cancel()
 
-	stream, err := s.host.NewStream(ctx, pid, protocol.ID(topic))
+	protocols := []protocol.ID{protocol.ID(topic)}
+	zstd := ZstdEnabled(baseTopic)
+	if zstd {
+		// Offer the experimental zstd encoding first, the peer picks the default one if it doesn't support it.
+		zstdTopic := baseTopic + (&encoder.SszZstdNetworkEncoder{}).ProtocolSuffix()
+		protocols = append([]protocol.ID{protocol.ID(zstdTopic)}, protocols...)
+	}
+	stream, err := s.host.NewStream(ctx, pid, protocols...)
 	if err != nil {
 		tracing.AnnotateError(span, err)
 		return nil, err
 	}
+	encoding := StreamEncoding(stream, s)
+	if zstd {
+		negotiatedEncodings.WithLabelValues(strings.TrimPrefix(encoding.ProtocolSuffix(), "/")).Inc()
+	}
 	// do not encode anything if we are sending a metadata request
 	if baseTopic != RPCMetaDataTopicV1 && baseTopic != RPCMetaDataTopicV2 {
 		castedMsg, ok := message.(ssz.Marshaler)
 		if !ok {
 			return nil, errors.Errorf("%T does not support the ssz marshaller interface", message)
 		}
-		if _, err := s.Encoding().EncodeWithMaxLength(stream, castedMsg); err != nil {
+		if _, err := encoding.EncodeWithMaxLength(stream, castedMsg); err != nil {
 			tracing.AnnotateError(span, err)
 			_err := stream.Reset()
 			_ = _err
@@ -11,11 +11,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/pnet"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
@@ -23,6 +25,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/async"
+	"github.com/prysmaticlabs/prysm/v3/async/abool"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
@@ -70,6 +73,7 @@ type Service struct {
 	addrFilter            *multiaddr.Filters
 	ipLimiter             *leakybucket.Collector
 	privKey               *ecdsa.PrivateKey
+	psk                   pnet.PSK
 	metaData              metadata.Metadata
 	pubsub                *pubsub.PubSub
 	joinedTopics          map[string]*pubsub.Topic
@@ -85,6 +89,11 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	maintenanceMode       abool.AtomicBool
+	nat                   *natManager
+	bandwidth             *bandwidthCounter
+	gossipBandwidth       *gossipBandwidthTracer
+	gossipCaps            *gossipCaps
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -95,13 +104,15 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop().
 
 	s := &Service{
-		ctx:           ctx,
-		stateNotifier: cfg.StateNotifier,
-		cancel:        cancel,
-		cfg:           cfg,
-		isPreGenesis:  true,
-		joinedTopics:  make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
-		subnetsLock:   make(map[uint64]*sync.RWMutex),
+		ctx:             ctx,
+		stateNotifier:   cfg.StateNotifier,
+		cancel:          cancel,
+		cfg:             cfg,
+		isPreGenesis:    true,
+		joinedTopics:    make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
+		subnetsLock:     make(map[uint64]*sync.RWMutex),
+		bandwidth:       newBandwidthCounter(),
+		gossipBandwidth: newGossipBandwidthTracer(),
 	}
 
 	dv5Nodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
@@ -114,6 +125,14 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		log.WithError(err).Error("Failed to generate p2p private key")
 		return nil, err
 	}
+	s.psk, err = preSharedKey(s.cfg)
+	if err != nil {
+		log.WithError(err).Error("Failed to load p2p pre-shared key")
+		return nil, err
+	}
+	if s.cfg.EnableUPnP {
+		s.nat = newNATManager(nat.Any(), s.cfg.TCPPort, s.cfg.UDPPort)
+	}
 	s.metaData, err = metaDataFromConfig(s.cfg)
 	if err != nil {
 		log.WithError(err).Error("Failed to create peer metadata")
@@ -125,6 +144,14 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		return nil, err
 	}
 	s.ipLimiter = leakybucket.NewCollector(ipLimit, ipBurst, true /* deleteEmptyBuckets */)
+	s.gossipCaps, err = parseGossipCaps(s.cfg.GossipOutboundCaps)
+	if err != nil {
+		log.WithError(err).Error("Failed to parse gossip outbound caps")
+		return nil, err
+	}
+	if len(s.gossipCaps.caps) > 0 {
+		log.WithField("caps", s.gossipCaps.caps).Info("Capping the bytes per second of messages published on gossip topics")
+	}
 
 	opts := s.buildOptions(ipAddr, s.privKey)
 	h, err := libp2p.New(opts...)
@@ -151,6 +178,8 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		pubsub.WithPeerScore(peerScoringParams()),
 		pubsub.WithPeerScoreInspect(s.peerInspector, time.Minute),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
+		pubsub.WithRawTracer(newPublishTracer(h.ID())),
+		pubsub.WithRawTracer(s.gossipBandwidth),
 	}
 	// Set the pubsub global parameters that we require.
 	setPubSubParameters()
@@ -231,6 +260,7 @@ func (s *Service) Start() {
 		}
 		s.connectWithAllPeers(addrs)
 	}
+	s.dialKnownPeers()
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -244,10 +274,17 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, knownPeersSaveInterval, s.saveKnownPeers)
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, func() {
 		s.RefreshENR()
 	})
+	if s.nat != nil {
+		go s.maintainNATMappings()
+	}
+	if s.cfg.HostDNS != "" && s.cfg.HostDNSRefresh > 0 {
+		async.RunEvery(s.ctx, s.cfg.HostDNSRefresh, s.refreshHostDNS)
+	}
 	async.RunEvery(s.ctx, 1*time.Minute, func() {
 		log.WithFields(logrus.Fields{
 			"inbound":     len(s.peers.InboundConnected()),
@@ -277,6 +314,9 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if s.started {
+		s.saveKnownPeers()
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -14,6 +14,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/pnet"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/wrapper"
@@ -68,6 +69,28 @@ func privKey(cfg *Config) (*ecdsa.PrivateKey, error) {
 	return privKeyFromFile(privateKeyPath)
 }
 
+// Retrieves the libp2p pre-shared key from the file set in the p2p service's
+// configuration struct. No key is returned if the file is not set.
+func preSharedKey(cfg *Config) (pnet.PSK, error) {
+	if cfg.PreSharedKeyFile == "" {
+		return nil, nil
+	}
+	f, err := os.Open(cfg.PreSharedKeyFile) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open pre-shared key file")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close pre-shared key file")
+		}
+	}()
+	psk, err := pnet.DecodeV1PSK(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode pre-shared key")
+	}
+	return psk, nil
+}
+
 // Retrieves a p2p networking private key from a file path.
 func privKeyFromFile(path string) (*ecdsa.PrivateKey, error) {
 	src, err := os.ReadFile(path) // #nosec G304
@@ -0,0 +1,219 @@
+// Package archive serves blocks and states which are older than what the beacon node retains
+// locally by reading them through from a remote archive, such as another beacon node or an era
+// file server exposing the beacon API.
+package archive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/ssz/detect"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// Number of archived blocks kept in memory.
+	blockCacheSize = 256
+	// Number of archived states kept in memory. States are large, so only a few of them are cached.
+	stateCacheSize = 4
+	// Timeout of requests to the remote archive. States of busy networks take a while to download.
+	requestTimeout = 2 * time.Minute
+)
+
+// Archive reads blocks and states through from a remote archive and caches them in memory.
+type Archive struct {
+	client   *beacon.Client
+	beaconDB db.ReadOnlyDatabase
+	blocks   *lru.Cache
+	states   *lru.Cache
+}
+
+// New creates an archive reading from the beacon API served at the given URL. The database is
+// used to determine which blocks and states are not available locally.
+func New(url string, beaconDB db.ReadOnlyDatabase) (*Archive, error) {
+	client, err := beacon.NewClient(url, beacon.WithTimeout(requestTimeout))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create remote archive client")
+	}
+	blockCache, err := lru.New(blockCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	stateCache, err := lru.New(stateCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Archive{
+		client:   client,
+		beaconDB: beaconDB,
+		blocks:   blockCache,
+		states:   stateCache,
+	}, nil
+}
+
+// LowestLocalSlot returns the slot of the oldest block held in the local database. It is the
+// slot of the checkpoint sync origin block, or the genesis slot when the node synced from genesis.
+func (a *Archive) LowestLocalSlot(ctx context.Context) (types.Slot, error) {
+	root, err := a.beaconDB.OriginCheckpointBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get origin checkpoint block root")
+	}
+	b, err := a.beaconDB.Block(ctx, root)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get origin checkpoint block")
+	}
+	if err := blocks.BeaconBlockIsNil(b); err != nil {
+		return 0, errors.Wrap(err, "could not get origin checkpoint block")
+	}
+	return b.Block().Slot(), nil
+}
+
+// BlockByRoot reads the block with the given root from the remote archive. It is meant to be
+// called for roots which are not found locally. Nil is returned if the archive doesn't have the block.
+func (a *Archive) BlockByRoot(ctx context.Context, root [32]byte) (interfaces.SignedBeaconBlock, error) {
+	return a.block(ctx, beacon.IdFromRoot(root), func(b interfaces.SignedBeaconBlock) error {
+		r, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if r != root {
+			return fmt.Errorf("archived block root %#x does not match requested root %#x", r, root)
+		}
+		return nil
+	})
+}
+
+// BlockBySlot reads the canonical block at the given slot from the remote archive, if the slot
+// is older than the oldest block held locally. Nil is returned otherwise, or if the slot is empty.
+func (a *Archive) BlockBySlot(ctx context.Context, slot types.Slot) (interfaces.SignedBeaconBlock, error) {
+	lowest, err := a.LowestLocalSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slot >= lowest {
+		return nil, nil
+	}
+	return a.block(ctx, beacon.IdFromSlot(slot), func(b interfaces.SignedBeaconBlock) error {
+		if b.Block().Slot() != slot {
+			return fmt.Errorf("archived block slot %d does not match requested slot %d", b.Block().Slot(), slot)
+		}
+		return nil
+	})
+}
+
+// StateByRoot reads the state with the given state root from the remote archive. It is meant to be
+// called for roots which are not found locally. Nil is returned if the archive doesn't have the state.
+func (a *Archive) StateByRoot(ctx context.Context, stateRoot [32]byte) (state.BeaconState, error) {
+	return a.state(ctx, beacon.IdFromRoot(stateRoot), func(st state.BeaconState) error {
+		r, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if r != stateRoot {
+			return fmt.Errorf("archived state root %#x does not match requested root %#x", r, stateRoot)
+		}
+		return nil
+	})
+}
+
+// StateBySlot reads the state at the given slot from the remote archive, if the slot is older than
+// the oldest block held locally. Nil is returned otherwise.
+func (a *Archive) StateBySlot(ctx context.Context, slot types.Slot) (state.BeaconState, error) {
+	lowest, err := a.LowestLocalSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slot >= lowest {
+		return nil, nil
+	}
+	return a.state(ctx, beacon.IdFromSlot(slot), func(st state.BeaconState) error {
+		if st.Slot() != slot {
+			return fmt.Errorf("archived state slot %d does not match requested slot %d", st.Slot(), slot)
+		}
+		return nil
+	})
+}
+
+// Reads the block with the given id from the cache or the remote archive. Blocks read from the remote
+// archive are only cached once verified, as the archive is not trusted to serve what was requested.
+func (a *Archive) block(
+	ctx context.Context, id beacon.StateOrBlockId, verify func(interfaces.SignedBeaconBlock) error,
+) (interfaces.SignedBeaconBlock, error) {
+	ctx, span := trace.StartSpan(ctx, "archive.block")
+	defer span.End()
+
+	if b, ok := a.blocks.Get(id); ok {
+		archiveCacheHits.Inc()
+		return b.(interfaces.SignedBeaconBlock), nil
+	}
+	archiveCacheMisses.Inc()
+	marshaled, err := a.client.GetBlock(ctx, id)
+	if errors.Is(err, beacon.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block from remote archive")
+	}
+	vu, err := detect.FromBlock(marshaled)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect fork of archived block")
+	}
+	b, err := vu.UnmarshalBeaconBlock(marshaled)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal archived block")
+	}
+	if err := verify(b); err != nil {
+		return nil, errors.Wrap(err, "invalid block from remote archive")
+	}
+	a.blocks.Add(id, b)
+	log.WithField("blockId", id).Debug("Read block from remote archive")
+	return b, nil
+}
+
+// Reads the state with the given id from the cache or the remote archive. States read from the remote
+// archive are only cached once verified, as the archive is not trusted to serve what was requested.
+func (a *Archive) state(
+	ctx context.Context, id beacon.StateOrBlockId, verify func(state.BeaconState) error,
+) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "archive.state")
+	defer span.End()
+
+	if st, ok := a.states.Get(id); ok {
+		archiveCacheHits.Inc()
+		return st.(state.BeaconState).Copy(), nil
+	}
+	archiveCacheMisses.Inc()
+	marshaled, err := a.client.GetState(ctx, id)
+	if errors.Is(err, beacon.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state from remote archive")
+	}
+	vu, err := detect.FromState(marshaled)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect fork of archived state")
+	}
+	st, err := vu.UnmarshalBeaconState(marshaled)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal archived state")
+	}
+	if err := verify(st); err != nil {
+		return nil, errors.Wrap(err, "invalid state from remote archive")
+	}
+	a.states.Add(id, st)
+	log.WithField("stateId", id).Debug("Read state from remote archive")
+	return st.Copy(), nil
+}
@@ -0,0 +1,193 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func setupArchive(t *testing.T, originSlot types.Slot) (*Archive, map[string]int) {
+	ctx := context.Background()
+	beaconDB, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, beaconDB.Close())
+	})
+	if originSlot > 0 {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = originSlot
+		util.SaveBlock(t, ctx, beaconDB, b)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveOriginCheckpointBlockRoot(ctx, root))
+	}
+
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 10
+	marshaledBlock, err := b.MarshalSSZ()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(10))
+	marshaledState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	blockRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	requests := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		// The archive also serves the block and state at slot 10 for slot 12 and for an unrelated
+		// state root, which must be rejected.
+		case "/eth/v2/beacon/blocks/10", "/eth/v2/beacon/blocks/12", fmt.Sprintf("/eth/v2/beacon/blocks/%#x", blockRoot):
+			_, err := w.Write(marshaledBlock)
+			require.NoError(t, err)
+		case "/eth/v2/debug/beacon/states/10", "/eth/v2/debug/beacon/states/12",
+			fmt.Sprintf("/eth/v2/debug/beacon/states/%#x", stateRoot), fmt.Sprintf("/eth/v2/debug/beacon/states/%#x", [32]byte{'b'}):
+			_, err := w.Write(marshaledState)
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	a, err := New(srv.URL, beaconDB)
+	require.NoError(t, err)
+	return a, requests
+}
+
+func TestArchive_LowestLocalSlot(t *testing.T) {
+	t.Run("synced from genesis", func(t *testing.T) {
+		a, _ := setupArchive(t, 0)
+		slot, err := a.LowestLocalSlot(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, types.Slot(0), slot)
+	})
+	t.Run("checkpoint synced", func(t *testing.T) {
+		a, _ := setupArchive(t, 100)
+		slot, err := a.LowestLocalSlot(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, types.Slot(100), slot)
+	})
+}
+
+func TestArchive_BlockBySlot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("held locally", func(t *testing.T) {
+		a, requests := setupArchive(t, 0)
+		b, err := a.BlockBySlot(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, true, b == nil)
+		assert.Equal(t, 0, len(requests))
+	})
+	t.Run("read through and cached", func(t *testing.T) {
+		a, requests := setupArchive(t, 100)
+		b, err := a.BlockBySlot(ctx, 10)
+		require.NoError(t, err)
+		require.NoError(t, blocks.BeaconBlockIsNil(b))
+		assert.Equal(t, types.Slot(10), b.Block().Slot())
+
+		b, err = a.BlockBySlot(ctx, 10)
+		require.NoError(t, err)
+		require.NoError(t, blocks.BeaconBlockIsNil(b))
+		assert.Equal(t, 1, requests["/eth/v2/beacon/blocks/10"])
+	})
+	t.Run("not in archive", func(t *testing.T) {
+		a, _ := setupArchive(t, 100)
+		b, err := a.BlockBySlot(ctx, 11)
+		require.NoError(t, err)
+		assert.Equal(t, true, b == nil)
+	})
+	t.Run("wrong slot", func(t *testing.T) {
+		a, requests := setupArchive(t, 100)
+		_, err := a.BlockBySlot(ctx, 12)
+		require.ErrorContains(t, "archived block slot 10 does not match requested slot 12", err)
+		_, err = a.BlockBySlot(ctx, 12)
+		require.ErrorContains(t, "does not match", err)
+		assert.Equal(t, 2, requests["/eth/v2/beacon/blocks/12"])
+	})
+}
+
+func TestArchive_BlockByRoot(t *testing.T) {
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 10
+	root, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	a, _ := setupArchive(t, 100)
+	blk, err := a.BlockByRoot(context.Background(), root)
+	require.NoError(t, err)
+	require.NoError(t, blocks.BeaconBlockIsNil(blk))
+	assert.Equal(t, types.Slot(10), blk.Block().Slot())
+}
+
+func TestArchive_BlockByRoot_NotFound(t *testing.T) {
+	a, requests := setupArchive(t, 100)
+	b, err := a.BlockByRoot(context.Background(), [32]byte{'a'})
+	require.NoError(t, err)
+	assert.Equal(t, true, b == nil)
+	assert.Equal(t, 1, len(requests))
+}
+
+func TestArchive_StateBySlot(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("held locally", func(t *testing.T) {
+		a, requests := setupArchive(t, 0)
+		st, err := a.StateBySlot(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, true, st == nil)
+		assert.Equal(t, 0, len(requests))
+	})
+	t.Run("read through and cached", func(t *testing.T) {
+		a, requests := setupArchive(t, 100)
+		st, err := a.StateBySlot(ctx, 10)
+		require.NoError(t, err)
+		require.NotNil(t, st)
+		assert.Equal(t, types.Slot(10), st.Slot())
+
+		// The cached state must not be affected by changes to the returned copy.
+		require.NoError(t, st.SetSlot(11))
+		st, err = a.StateBySlot(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, types.Slot(10), st.Slot())
+		assert.Equal(t, 1, requests["/eth/v2/debug/beacon/states/10"])
+	})
+	t.Run("wrong slot", func(t *testing.T) {
+		a, _ := setupArchive(t, 100)
+		_, err := a.StateBySlot(ctx, 12)
+		require.ErrorContains(t, "archived state slot 10 does not match requested slot 12", err)
+	})
+}
+
+func TestArchive_StateByRoot(t *testing.T) {
+	ctx := context.Background()
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(10))
+	root, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	a, _ := setupArchive(t, 100)
+
+	archived, err := a.StateByRoot(ctx, root)
+	require.NoError(t, err)
+	require.NotNil(t, archived)
+	assert.Equal(t, types.Slot(10), archived.Slot())
+
+	_, err = a.StateByRoot(ctx, [32]byte{'b'})
+	require.ErrorContains(t, "does not match requested root", err)
+}
@@ -0,0 +1,17 @@
+package archive
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	archiveCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_archive_cache_hit",
+		Help: "The number of blocks and states served from the remote archive cache.",
+	})
+	archiveCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_archive_cache_miss",
+		Help: "The number of blocks and states requested from the remote archive.",
+	})
+)
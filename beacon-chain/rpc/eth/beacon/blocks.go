@@ -585,7 +585,15 @@ func (bs *Server) GetBlockSSZV2(ctx context.Context, req *ethpbv2.BlockRequestV2
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not marshal block into SSZ: %v", err)
 		}
-		return &ethpbv2.SSZContainer{Version: ethpbv2.Version_BELLATRIX, Data: sszData}, nil
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get block root: %v", err)
+		}
+		isOptimistic, err := bs.OptimisticModeFetcher.IsOptimisticForRoot(ctx, root)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not check if block is optimistic: %v", err)
+		}
+		return &ethpbv2.SSZContainer{Version: ethpbv2.Version_BELLATRIX, Data: sszData, ExecutionOptimistic: isOptimistic}, nil
 	}
 
 	if _, err = blk.PbBlindedBellatrixBlock(); err == nil {
@@ -613,9 +621,18 @@ func (bs *Server) GetBlockSSZV2(ctx context.Context, req *ethpbv2.BlockRequestV2
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Could not marshal block into SSZ: %v", err)
 		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get block root: %v", err)
+		}
+		isOptimistic, err := bs.OptimisticModeFetcher.IsOptimisticForRoot(ctx, root)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not check if block is optimistic: %v", err)
+		}
 		return &ethpbv2.SSZContainer{
-			Version: ethpbv2.Version_BELLATRIX,
-			Data:    sszData,
+			Version:             ethpbv2.Version_BELLATRIX,
+			Data:                sszData,
+			ExecutionOptimistic: isOptimistic,
 		}, nil
 	}
 	// ErrUnsupportedGetter means that we have another block type
@@ -811,6 +828,12 @@ func (bs *Server) blockFromBlockID(ctx context.Context, blockId []byte) (interfa
 			if err != nil {
 				return nil, errors.Wrap(err, "could not retrieve block")
 			}
+			if blk == nil && bs.Archive != nil {
+				blk, err = bs.Archive.BlockByRoot(ctx, bytesutil.ToBytes32(blockId))
+				if err != nil {
+					return nil, errors.Wrap(err, "could not retrieve block from remote archive")
+				}
+			}
 		} else {
 			slot, err := strconv.ParseUint(string(blockId), 10, 64)
 			if err != nil {
@@ -827,7 +850,14 @@ func (bs *Server) blockFromBlockID(ctx context.Context, blockId []byte) (interfa
 			}
 			numBlks := len(blks)
 			if numBlks == 0 {
-				return nil, nil
+				if bs.Archive == nil {
+					return nil, nil
+				}
+				blk, err = bs.Archive.BlockBySlot(ctx, types.Slot(slot))
+				if err != nil {
+					return nil, errors.Wrap(err, "could not retrieve block from remote archive")
+				}
+				return blk, nil
 			}
 			for i, b := range blks {
 				canonical, err := bs.ChainInfoFetcher.IsCanonical(ctx, roots[i])
@@ -0,0 +1,171 @@
+package beacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"go.opencensus.io/trace"
+)
+
+const executionAddressLength = 20
+
+// BLSToExecutionChangesPoolResponse is the response of the endpoint listing the BLS to execution
+// changes of the pool.
+type BLSToExecutionChangesPoolResponse struct {
+	Data []*SignedBLSToExecutionChangeJson `json:"data"`
+}
+
+// SignedBLSToExecutionChangeJson is the JSON representation of a signed BLS to execution change.
+type SignedBLSToExecutionChangeJson struct {
+	Message   *BLSToExecutionChangeJson `json:"message"`
+	Signature string                    `json:"signature"`
+}
+
+// BLSToExecutionChangeJson is the JSON representation of a BLS to execution change.
+type BLSToExecutionChangeJson struct {
+	ValidatorIndex     string `json:"validator_index"`
+	FromBLSPubkey      string `json:"from_bls_pubkey"`
+	ToExecutionAddress string `json:"to_execution_address"`
+}
+
+// IndexedVerificationFailureErrorJson is returned when some objects of a request fail validation.
+type IndexedVerificationFailureErrorJson struct {
+	network.DefaultErrorJson
+	helpers.IndexedVerificationFailure
+}
+
+// ListBLSToExecutionChanges lists the BLS to execution changes of the pool, waiting to be included
+// in a block.
+func (bs *Server) ListBLSToExecutionChanges(w http.ResponseWriter, _ *http.Request) {
+	changes := bs.BLSToExecPool.PendingBLSToExecChanges()
+	data := make([]*SignedBLSToExecutionChangeJson, len(changes))
+	for i, c := range changes {
+		data[i] = &SignedBLSToExecutionChangeJson{
+			Message: &BLSToExecutionChangeJson{
+				ValidatorIndex:     strconv.FormatUint(uint64(c.Message.ValidatorIndex), 10),
+				FromBLSPubkey:      hexutil.Encode(c.Message.FromBlsPubkey),
+				ToExecutionAddress: hexutil.Encode(c.Message.ToExecutionAddress),
+			},
+			Signature: hexutil.Encode(c.Signature),
+		}
+	}
+	network.WriteJson(w, &BLSToExecutionChangesPoolResponse{Data: data})
+}
+
+// SubmitBLSToExecutionChanges validates the BLS to execution changes of the request against the
+// head state and inserts the valid ones in the pool. The changes are broadcast to peers from the
+// Capella fork on. If any change fails validation, the failures are listed in the response and the
+// other changes are still processed.
+func (bs *Server) SubmitBLSToExecutionChanges(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.SubmitBLSToExecutionChanges")
+	defer span.End()
+
+	var req []*SignedBLSToExecutionChangeJson
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not decode request body").Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if len(req) == 0 {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "no BLS to execution changes provided",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	st, err := bs.ChainInfoFetcher.HeadState(ctx)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get head state").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	broadcast := slots.ToEpoch(bs.GenesisTimeFetcher.CurrentSlot()) >= params.BeaconConfig().CapellaForkEpoch
+
+	var failures []*helpers.SingleIndexedVerificationFailure
+	for i, c := range req {
+		change, err := c.toProto()
+		if err == nil {
+			_, err = blocks.ValidateBLSToExecutionChange(st, change)
+		}
+		if err != nil {
+			failures = append(failures, &helpers.SingleIndexedVerificationFailure{Index: i, Message: err.Error()})
+			continue
+		}
+		bs.BLSToExecPool.InsertBLSToExecChange(change)
+		if !broadcast {
+			continue
+		}
+		if err := bs.Broadcaster.Broadcast(ctx, change); err != nil {
+			log.WithError(err).WithField("validatorIndex", change.Message.ValidatorIndex).Error("Could not broadcast BLS to execution change")
+			failures = append(failures, &helpers.SingleIndexedVerificationFailure{
+				Index:   i,
+				Message: errors.Wrap(err, "could not broadcast").Error(),
+			})
+		}
+	}
+	if len(failures) > 0 {
+		writeIndexedVerificationFailure(w, &IndexedVerificationFailureErrorJson{
+			DefaultErrorJson: network.DefaultErrorJson{
+				Message: "One or more BLS to execution changes failed validation",
+				Code:    http.StatusBadRequest,
+			},
+			IndexedVerificationFailure: helpers.IndexedVerificationFailure{Failures: failures},
+		})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *SignedBLSToExecutionChangeJson) toProto() (*ethpb.SignedBLSToExecutionChange, error) {
+	if c == nil || c.Message == nil {
+		return nil, errors.New("missing BLS to execution change message")
+	}
+	index, err := strconv.ParseUint(c.Message.ValidatorIndex, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid validator index")
+	}
+	pubkey, err := hexutil.Decode(c.Message.FromBLSPubkey)
+	if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+		return nil, fmt.Errorf("invalid BLS public key %q", c.Message.FromBLSPubkey)
+	}
+	address, err := hexutil.Decode(c.Message.ToExecutionAddress)
+	if err != nil || len(address) != executionAddressLength {
+		return nil, fmt.Errorf("invalid execution address %q", c.Message.ToExecutionAddress)
+	}
+	sig, err := hexutil.Decode(c.Signature)
+	if err != nil || len(sig) != fieldparams.BLSSignatureLength {
+		return nil, fmt.Errorf("invalid signature %q", c.Signature)
+	}
+	return &ethpb.SignedBLSToExecutionChange{
+		Message: &ethpb.BLSToExecutionChange{
+			ValidatorIndex:     types.ValidatorIndex(index),
+			FromBlsPubkey:      pubkey,
+			ToExecutionAddress: address,
+		},
+		Signature: sig,
+	}, nil
+}
+
+func writeIndexedVerificationFailure(w http.ResponseWriter, errJson *IndexedVerificationFailureErrorJson) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errJson.Code)
+	if err := json.NewEncoder(w).Encode(errJson); err != nil {
+		log.WithError(err).Error("Could not write error message")
+	}
+}
@@ -0,0 +1,104 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	coreTime "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
+	p2pMock "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	v1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/v1"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash/htr"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestSubmitBLSToExecutionChanges(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	pubkey := priv.PublicKey().Marshal()
+	pubkeyChunks := [][32]byte{bytesutil.ToBytes32(pubkey[:32]), bytesutil.ToBytes32(pubkey[32:])}
+	digest := make([][32]byte, 1)
+	htr.VectorizedSha256(pubkeyChunks, digest)
+	digest[0][0] = params.BeaconConfig().BLSWithdrawalPrefixByte
+	st, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators: []*ethpb.Validator{{WithdrawalCredentials: digest[0][:]}, {WithdrawalCredentials: digest[0][:]}},
+		Fork: &ethpb.Fork{
+			CurrentVersion:  params.BeaconConfig().GenesisForkVersion,
+			PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+		},
+	})
+	require.NoError(t, err)
+	address := bytes.Repeat([]byte{0x01}, 20)
+	msg := &ethpb.BLSToExecutionChange{ValidatorIndex: 1, FromBlsPubkey: pubkey, ToExecutionAddress: address}
+	sig, err := signing.ComputeDomainAndSign(st, coreTime.CurrentEpoch(st), msg, params.BeaconConfig().DomainBLSToExecutionChange, priv)
+	require.NoError(t, err)
+
+	broadcaster := &p2pMock.MockBroadcaster{}
+	pool := blstoexec.NewPool()
+	s := &Server{
+		ChainInfoFetcher:   &mock.ChainService{State: st},
+		GenesisTimeFetcher: &mock.ChainService{Genesis: time.Now()},
+		Broadcaster:        broadcaster,
+		BLSToExecPool:      pool,
+	}
+	req := []*SignedBLSToExecutionChangeJson{
+		{
+			Message: &BLSToExecutionChangeJson{
+				ValidatorIndex:     "1",
+				FromBLSPubkey:      hexutil.Encode(pubkey),
+				ToExecutionAddress: hexutil.Encode(address),
+			},
+			Signature: hexutil.Encode(sig),
+		},
+		{
+			Message: &BLSToExecutionChangeJson{
+				ValidatorIndex:     "0",
+				FromBLSPubkey:      hexutil.Encode(pubkey),
+				ToExecutionAddress: "0x01",
+			},
+			Signature: hexutil.Encode(sig),
+		},
+	}
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	s.SubmitBLSToExecutionChanges(w, httptest.NewRequest(http.MethodPost, "/eth/v1/beacon/pool/bls_to_execution_changes", bytes.NewReader(body)))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	resp := &IndexedVerificationFailureErrorJson{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Failures))
+	assert.Equal(t, 1, resp.Failures[0].Index)
+	assert.Equal(t, true, strings.Contains(resp.Failures[0].Message, "invalid execution address"))
+
+	// The valid change was inserted and broadcast.
+	assert.Equal(t, true, pool.ValidatorExists(1))
+	assert.Equal(t, false, pool.ValidatorExists(0))
+	assert.Equal(t, true, broadcaster.BroadcastCalled)
+
+	w = httptest.NewRecorder()
+	s.ListBLSToExecutionChanges(w, httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/pool/bls_to_execution_changes", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	list := &BLSToExecutionChangesPoolResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), list))
+	require.Equal(t, 1, len(list.Data))
+	assert.DeepEqual(t, req[0], list.Data[0])
+}
@@ -103,6 +103,9 @@ func TestGetSpec(t *testing.T) {
 	config.TerminalBlockHash = common.HexToHash("TerminalBlockHash")
 	config.TerminalBlockHashActivationEpoch = 72
 	config.TerminalTotalDifficulty = "73"
+	config.MaxBlsToExecutionChanges = 74
+	config.MaxWithdrawalsPerPayload = 75
+	config.MaxValidatorsPerWithdrawalsSweep = 76
 	config.DefaultFeeRecipient = common.HexToAddress("DefaultFeeRecipient")
 
 	var dbp [4]byte
@@ -136,7 +139,7 @@ func TestGetSpec(t *testing.T) {
 	resp, err := server.GetSpec(context.Background(), &emptypb.Empty{})
 	require.NoError(t, err)
 
-	assert.Equal(t, 102, len(resp.Data))
+	assert.Equal(t, 105, len(resp.Data))
 	for k, v := range resp.Data {
 		switch k {
 		case "CONFIG_NAME":
@@ -273,6 +276,12 @@ func TestGetSpec(t *testing.T) {
 			assert.Equal(t, "51", v)
 		case "MAX_VOLUNTARY_EXITS":
 			assert.Equal(t, "52", v)
+		case "MAX_BLS_TO_EXECUTION_CHANGES":
+			assert.Equal(t, "74", v)
+		case "MAX_WITHDRAWALS_PER_PAYLOAD":
+			assert.Equal(t, "75", v)
+		case "MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP":
+			assert.Equal(t, "76", v)
 		case "TIMELY_HEAD_FLAG_INDEX":
 			assert.Equal(t, "0x35", v)
 		case "TIMELY_SOURCE_FLAG_INDEX":
@@ -0,0 +1,78 @@
+package beacon
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"go.opencensus.io/trace"
+)
+
+const octetStreamMediaType = "application/octet-stream"
+
+// DepositSnapshotResponse is the response of the endpoint serving the deposit tree snapshot.
+type DepositSnapshotResponse struct {
+	Data *DepositSnapshotJson `json:"data"`
+}
+
+// DepositSnapshotJson is the JSON representation of an EIP-4881 deposit tree snapshot.
+type DepositSnapshotJson struct {
+	Finalized            []string `json:"finalized"`
+	DepositRoot          string   `json:"deposit_root"`
+	DepositCount         string   `json:"deposit_count"`
+	ExecutionBlockHash   string   `json:"execution_block_hash"`
+	ExecutionBlockHeight string   `json:"execution_block_height"`
+}
+
+// GetDepositSnapshot serves the EIP-4881 snapshot of the deposit tree at the last finalized
+// execution block known to the node. The snapshot is SSZ encoded when requested with the
+// application/octet-stream media type.
+func (bs *Server) GetDepositSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetDepositSnapshot")
+	defer span.End()
+
+	snapshot, err := bs.BeaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not retrieve deposit snapshot").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if snapshot == nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "no deposit snapshot available",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), octetStreamMediaType) {
+		enc, err := snapshot.MarshalSSZ()
+		if err != nil {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "could not encode deposit snapshot").Error(),
+				Code:    http.StatusInternalServerError,
+			})
+			return
+		}
+		w.Header().Set("Content-Type", octetStreamMediaType)
+		if _, err := w.Write(enc); err != nil {
+			log.WithError(err).Error("Could not write deposit snapshot")
+		}
+		return
+	}
+	finalized := make([]string, len(snapshot.Finalized))
+	for i, f := range snapshot.Finalized {
+		finalized[i] = hexutil.Encode(f[:])
+	}
+	network.WriteJson(w, &DepositSnapshotResponse{Data: &DepositSnapshotJson{
+		Finalized:            finalized,
+		DepositRoot:          hexutil.Encode(snapshot.DepositRoot[:]),
+		DepositCount:         strconv.FormatUint(snapshot.DepositCount, 10),
+		ExecutionBlockHash:   hexutil.Encode(snapshot.ExecutionBlockHash[:]),
+		ExecutionBlockHeight: strconv.FormatUint(snapshot.ExecutionBlockHeight, 10),
+	}})
+}
@@ -0,0 +1,54 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	dbTest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestGetDepositSnapshot(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	s := &Server{BeaconDB: db}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+	writer := httptest.NewRecorder()
+	s.GetDepositSnapshot(writer, request)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	items := [][]byte{bytesutil.PadTo([]byte{1}, 32), bytesutil.PadTo([]byte{2}, 32), bytesutil.PadTo([]byte{3}, 32)}
+	depositTrie, err := trie.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	snapshot, err := trie.NewDepositTreeSnapshot(depositTrie, 3, [32]byte{'a'}, 100)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveDepositSnapshot(context.Background(), snapshot))
+
+	writer = httptest.NewRecorder()
+	s.GetDepositSnapshot(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &DepositSnapshotResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data.Finalized))
+	assert.Equal(t, hexutil.Encode(snapshot.Finalized[0][:]), resp.Data.Finalized[0])
+	assert.Equal(t, hexutil.Encode(snapshot.DepositRoot[:]), resp.Data.DepositRoot)
+	assert.Equal(t, "3", resp.Data.DepositCount)
+	assert.Equal(t, hexutil.Encode(snapshot.ExecutionBlockHash[:]), resp.Data.ExecutionBlockHash)
+	assert.Equal(t, "100", resp.Data.ExecutionBlockHeight)
+
+	request.Header.Set("Accept", octetStreamMediaType)
+	writer = httptest.NewRecorder()
+	s.GetDepositSnapshot(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	decoded := &trie.DepositTreeSnapshot{}
+	require.NoError(t, decoded.UnmarshalSSZ(writer.Body.Bytes()))
+	assert.DeepEqual(t, snapshot, decoded)
+}
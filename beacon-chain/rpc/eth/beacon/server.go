@@ -10,9 +10,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/archive"
 	v1alpha1validator "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/validator"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/statefetcher"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
@@ -32,6 +34,7 @@ type Server struct {
 	AttestationsPool              attestations.Pool
 	SlashingsPool                 slashings.PoolManager
 	VoluntaryExitsPool            voluntaryexits.PoolManager
+	BLSToExecPool                 blstoexec.PoolManager
 	StateGenService               stategen.StateManager
 	StateFetcher                  statefetcher.Fetcher
 	HeadFetcher                   blockchain.HeadFetcher
@@ -41,4 +44,5 @@ type Server struct {
 	CanonicalHistory              *stategen.CanonicalHistory
 	HeadUpdater                   blockchain.HeadUpdater
 	ExecutionPayloadReconstructor execution.ExecutionPayloadReconstructor
+	Archive                       *archive.Archive
 }
@@ -0,0 +1,84 @@
+package builder
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/statefetcher"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+)
+
+// ExpectedWithdrawals returns the withdrawals the execution payload of the block built on top of
+// the requested state is expected to carry. States before Capella don't track the position of the
+// withdrawals sweep, so they are rejected.
+func (s *Server) ExpectedWithdrawals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	stateId := mux.Vars(r)["state_id"]
+	st, err := s.StateFetcher.State(ctx, []byte(stateId))
+	if err != nil {
+		writeStateFetchError(w, err)
+		return
+	}
+	if st.Version() <= version.Bellatrix {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("expected withdrawals are not supported for %s states", version.String(st.Version())),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	isOptimistic, err := helpers.IsOptimistic(ctx, st, s.OptimisticModeFetcher)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not check if state is optimistic").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	// The sweep position is to be read from the state once states of the Capella fork are supported.
+	withdrawals, err := state.ExpectedWithdrawals(st, 0, 0)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get expected withdrawals").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	data := make([]*WithdrawalJson, len(withdrawals))
+	for i, wd := range withdrawals {
+		data[i] = &WithdrawalJson{
+			Index:          strconv.FormatUint(wd.Index, 10),
+			ValidatorIndex: strconv.FormatUint(uint64(wd.ValidatorIndex), 10),
+			Address:        hexutil.Encode(wd.Address),
+			Amount:         strconv.FormatUint(wd.Amount, 10),
+		}
+	}
+	network.WriteJson(w, &ExpectedWithdrawalsResponse{
+		ExecutionOptimistic: isOptimistic,
+		Data:                data,
+	})
+}
+
+// writeStateFetchError writes the error response matching the error returned when fetching a state.
+func writeStateFetchError(w http.ResponseWriter, err error) {
+	errJson := &network.DefaultErrorJson{
+		Message: errors.Wrap(err, "could not get state").Error(),
+		Code:    http.StatusInternalServerError,
+	}
+	var notFoundErr *statefetcher.StateNotFoundError
+	var parseErr *statefetcher.StateIdParseError
+	switch {
+	case errors.Is(err, stategen.ErrNoDataForSlot), errors.As(err, &notFoundErr):
+		errJson.Code = http.StatusNotFound
+	case errors.As(err, &parseErr):
+		errJson.Code = http.StatusBadRequest
+	}
+	network.WriteError(w, errJson)
+}
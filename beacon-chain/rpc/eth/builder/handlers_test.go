@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestExpectedWithdrawals_PreCapella(t *testing.T) {
+	phase0State, _ := util.DeterministicGenesisState(t, 8)
+	altairState, _ := util.DeterministicGenesisStateAltair(t, 8)
+	bellatrixState, _ := util.DeterministicGenesisStateBellatrix(t, 8)
+	for _, st := range []state.BeaconState{phase0State, altairState, bellatrixState} {
+		s := &Server{
+			StateFetcher:          &testutil.MockFetcher{BeaconState: st},
+			OptimisticModeFetcher: &mock.ChainService{},
+		}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/builder/states/head/expected_withdrawals", nil)
+		request = mux.SetURLVars(request, map[string]string{"state_id": "head"})
+		writer := httptest.NewRecorder()
+		s.ExpectedWithdrawals(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "expected withdrawals are not supported for "+version.String(st.Version())+" states", e.Message)
+	}
+}
@@ -0,0 +1,14 @@
+// Package builder defines the HTTP endpoints of the builder namespace of the
+// beacon API, serving data needed to build execution payloads.
+package builder
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/statefetcher"
+)
+
+// Server defines a server implementation of the builder namespace endpoints.
+type Server struct {
+	StateFetcher          statefetcher.Fetcher
+	OptimisticModeFetcher blockchain.OptimisticModeFetcher
+}
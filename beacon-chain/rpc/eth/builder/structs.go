@@ -0,0 +1,15 @@
+package builder
+
+// ExpectedWithdrawalsResponse is the response of the expected withdrawals endpoint.
+type ExpectedWithdrawalsResponse struct {
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Data                []*WithdrawalJson `json:"data"`
+}
+
+// WithdrawalJson is a withdrawal of validator balance to an execution address.
+type WithdrawalJson struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validator_index"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+}
@@ -113,8 +113,12 @@ func (ds *Server) GetBeaconStateSSZV2(ctx context.Context, req *ethpbv2.BeaconSt
 	default:
 		return nil, status.Error(codes.Internal, "Unsupported state version")
 	}
+	isOptimistic, err := helpers.IsOptimistic(ctx, st, ds.OptimisticModeFetcher)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not check if slot's block is optimistic: %v", err)
+	}
 
-	return &ethpbv2.SSZContainer{Data: sszState, Version: ver}, nil
+	return &ethpbv2.SSZContainer{Data: sszState, Version: ver, ExecutionOptimistic: isOptimistic}, nil
 }
 
 // ListForkChoiceHeadsV2 retrieves the leaves of the current fork choice tree.
@@ -131,6 +131,7 @@ func TestGetBeaconStateSSZV2(t *testing.T) {
 			StateFetcher: &testutil.MockFetcher{
 				BeaconState: fakeState,
 			},
+			OptimisticModeFetcher: &blockchainmock.ChainService{},
 		}
 		resp, err := server.GetBeaconStateSSZV2(context.Background(), &ethpbv2.BeaconStateRequestV2{
 			StateId: make([]byte, 0),
@@ -150,6 +151,7 @@ func TestGetBeaconStateSSZV2(t *testing.T) {
 			StateFetcher: &testutil.MockFetcher{
 				BeaconState: fakeState,
 			},
+			OptimisticModeFetcher: &blockchainmock.ChainService{},
 		}
 		resp, err := server.GetBeaconStateSSZV2(context.Background(), &ethpbv2.BeaconStateRequestV2{
 			StateId: make([]byte, 0),
@@ -169,6 +171,7 @@ func TestGetBeaconStateSSZV2(t *testing.T) {
 			StateFetcher: &testutil.MockFetcher{
 				BeaconState: fakeState,
 			},
+			OptimisticModeFetcher: &blockchainmock.ChainService{},
 		}
 		resp, err := server.GetBeaconStateSSZV2(context.Background(), &ethpbv2.BeaconStateRequestV2{
 			StateId: make([]byte, 0),
@@ -179,6 +182,22 @@ func TestGetBeaconStateSSZV2(t *testing.T) {
 		assert.DeepEqual(t, sszState, resp.Data)
 		assert.Equal(t, ethpbv2.Version_BELLATRIX, resp.Version)
 	})
+	t.Run("execution optimistic", func(t *testing.T) {
+		fakeState, _ := util.DeterministicGenesisStateBellatrix(t, 1)
+
+		server := &Server{
+			StateFetcher: &testutil.MockFetcher{
+				BeaconState: fakeState,
+			},
+			OptimisticModeFetcher: &blockchainmock.ChainService{Optimistic: true},
+		}
+		resp, err := server.GetBeaconStateSSZV2(context.Background(), &ethpbv2.BeaconStateRequestV2{
+			StateId: make([]byte, 0),
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+	})
 }
 
 func TestListForkChoiceHeadsV2(t *testing.T) {
@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	grpcutil "github.com/prysmaticlabs/prysm/v3/api/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var executionOptimisticResponsesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "api_execution_optimistic_responses_total",
+	Help: "The number of API responses reporting whether their data depends on optimistic blocks, by method and optimistic status.",
+}, []string{"method", "optimistic"})
+
+// executionOptimisticResponse is implemented by the responses of the endpoints reporting whether their data
+// depends on optimistic blocks.
+type executionOptimisticResponse interface {
+	GetExecutionOptimistic() bool
+}
+
+// ExecutionOptimisticUnaryInterceptor sets the ExecutionOptimisticMetadataKey header on the responses reporting
+// whether their data depends on optimistic blocks, so that consumers of data not wrapped in a JSON object,
+// such as SSZ, can tell as well, and counts how often optimistic data is served.
+func ExecutionOptimisticUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	r, ok := resp.(executionOptimisticResponse)
+	if !ok {
+		return resp, nil
+	}
+	isOptimistic := strconv.FormatBool(r.GetExecutionOptimistic())
+	executionOptimisticResponsesCount.WithLabelValues(info.FullMethod, isOptimistic).Inc()
+	// Failing to set a non-gRPC related header should not cause the gRPC call to fail.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(grpcutil.ExecutionOptimisticMetadataKey, isOptimistic))
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	grpcutil "github.com/prysmaticlabs/prysm/v3/api/grpc"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestExecutionOptimisticUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v2.BeaconDebug/GetBeaconStateSSZV2"}
+	header := func(ctx context.Context) []string {
+		sts, ok := grpc.ServerTransportStreamFromContext(ctx).(*runtime.ServerTransportStream)
+		require.Equal(t, true, ok, "type assertion failed")
+		return sts.Header()[strings.ToLower(grpcutil.ExecutionOptimisticMetadataKey)]
+	}
+
+	t.Run("optimistic", func(t *testing.T) {
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+		counter := executionOptimisticResponsesCount.WithLabelValues(info.FullMethod, "true")
+		before := testutil.ToFloat64(counter)
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			return &ethpbv2.SSZContainer{ExecutionOptimistic: true}, nil
+		}
+		_, err := ExecutionOptimisticUnaryInterceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []string{"true"}, header(ctx))
+		assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	})
+	t.Run("not optimistic", func(t *testing.T) {
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			return &ethpbv2.SSZContainer{}, nil
+		}
+		_, err := ExecutionOptimisticUnaryInterceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []string{"false"}, header(ctx))
+	})
+	t.Run("not reported", func(t *testing.T) {
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			return &emptypb.Empty{}, nil
+		}
+		_, err := ExecutionOptimisticUnaryInterceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(header(ctx)))
+	})
+	t.Run("error", func(t *testing.T) {
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			return nil, errors.New("bad")
+		}
+		_, err := ExecutionOptimisticUnaryInterceptor(ctx, nil, info, handler)
+		assert.ErrorContains(t, "bad", err)
+		assert.Equal(t, 0, len(header(ctx)))
+	})
+	t.Run("no transport stream", func(t *testing.T) {
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			return &ethpbv2.SSZContainer{ExecutionOptimistic: true}, nil
+		}
+		resp, err := ExecutionOptimisticUnaryInterceptor(context.Background(), nil, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, true, resp.(*ethpbv2.SSZContainer).ExecutionOptimistic)
+	})
+}
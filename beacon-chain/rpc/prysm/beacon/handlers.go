@@ -0,0 +1,53 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/network"
+)
+
+const (
+	defaultCheckpointHistoryLimit = 100
+	maxCheckpointHistoryLimit     = 10000
+)
+
+// GetCheckpointHistory returns the most recent changes of the justified and finalized checkpoints
+// of the node, oldest first, along with the time at which they happened. The number of changes is
+// given by the optional limit query parameter.
+func (s *Server) GetCheckpointHistory(w http.ResponseWriter, r *http.Request) {
+	limit := defaultCheckpointHistoryLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 || l > maxCheckpointHistoryLimit {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("limit %s is not a number between 1 and %d", raw, maxCheckpointHistoryLimit),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		limit = l
+	}
+	changes, err := s.BeaconDB.CheckpointHistory(r.Context(), limit)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get checkpoint history").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	data := make([]*CheckpointChange, len(changes))
+	for i, c := range changes {
+		data[i] = &CheckpointChange{
+			Kind:  c.Kind.String(),
+			Epoch: strconv.FormatUint(uint64(c.Epoch), 10),
+			Root:  hexutil.Encode(c.Root[:]),
+			Time:  c.Time.UTC().Format(time.RFC3339Nano),
+		}
+	}
+	network.WriteJson(w, &CheckpointHistoryResponse{Data: data})
+}
@@ -0,0 +1,71 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	dbTest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestGetCheckpointHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	genesis := [32]byte{'g'}
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesis))
+	blk := util.NewBeaconBlock()
+	blk.Block.ParentRoot = genesis[:]
+	blk.Block.Slot = 64
+	wsb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveState(ctx, st, root))
+	require.NoError(t, beaconDB.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: root[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: root[:]}))
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("all changes", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetCheckpointHistory(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/checkpoints/history", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &CheckpointHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "justified", resp.Data[0].Kind)
+		assert.Equal(t, "2", resp.Data[0].Epoch)
+		assert.Equal(t, hexutil.Encode(root[:]), resp.Data[0].Root)
+		assert.Equal(t, "finalized", resp.Data[1].Kind)
+		assert.Equal(t, "1", resp.Data[1].Epoch)
+		assert.NotEqual(t, "", resp.Data[1].Time)
+	})
+	t.Run("limit", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetCheckpointHistory(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/checkpoints/history?limit=1", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &CheckpointHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "finalized", resp.Data[0].Kind)
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetCheckpointHistory(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/beacon/checkpoints/history?limit=0", nil))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "limit 0 is not a number between 1 and 10000", e.Message)
+	})
+}
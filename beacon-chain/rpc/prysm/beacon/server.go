@@ -0,0 +1,12 @@
+// Package beacon defines Prysm-specific HTTP endpoints exposing
+// information about the beacon chain as seen by the node.
+package beacon
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
+)
+
+// Server defines a server implementation of Prysm-specific beacon chain HTTP endpoints.
+type Server struct {
+	BeaconDB db.ReadOnlyDatabase
+}
@@ -0,0 +1,14 @@
+package beacon
+
+// CheckpointHistoryResponse is the response of the checkpoint history endpoint.
+type CheckpointHistoryResponse struct {
+	Data []*CheckpointChange `json:"data"`
+}
+
+// CheckpointChange is a change of the justified or finalized checkpoint of the node.
+type CheckpointChange struct {
+	Kind  string `json:"kind"`
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+	Time  string `json:"time"`
+}
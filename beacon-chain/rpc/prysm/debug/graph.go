@@ -0,0 +1,168 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/types"
+)
+
+const (
+	graphFormatJSON    = "json"
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+)
+
+// forkChoiceGraph is a snapshot of the fork choice tree, with the canonical chain leading to the
+// head and the checkpoints of the store.
+type forkChoiceGraph struct {
+	nodes         []*forkchoicetypes.Node
+	head          [32]byte
+	proposerBoost [32]byte
+	justified     *forkchoicetypes.Checkpoint
+	finalized     *forkchoicetypes.Checkpoint
+	canonical     map[[32]byte]bool
+}
+
+func newForkChoiceGraph(fc forkchoice.ForkChoicer) *forkChoiceGraph {
+	g := &forkChoiceGraph{
+		nodes:         fc.Nodes(),
+		head:          fc.CachedHeadRoot(),
+		proposerBoost: fc.ProposerBoost(),
+		justified:     fc.JustifiedCheckpoint(),
+		finalized:     fc.FinalizedCheckpoint(),
+		canonical:     make(map[[32]byte]bool),
+	}
+	parents := make(map[[32]byte][32]byte, len(g.nodes))
+	for _, n := range g.nodes {
+		parents[n.Root] = n.ParentRoot
+	}
+	root := g.head
+	for {
+		parent, ok := parents[root]
+		if !ok || g.canonical[root] {
+			break
+		}
+		g.canonical[root] = true
+		root = parent
+	}
+	return g
+}
+
+// label describes the node with its slot, short root, weight, checkpoints and status, one item
+// per line.
+func (g *forkChoiceGraph) label(n *forkchoicetypes.Node) []string {
+	lines := []string{
+		fmt.Sprintf("slot %d", n.Slot),
+		hexutil.Encode(n.Root[:4]),
+		fmt.Sprintf("weight %d Gwei", n.Weight),
+		fmt.Sprintf("justified %d, finalized %d", n.JustifiedEpoch, n.FinalizedEpoch),
+	}
+	if n.Root == g.head {
+		lines = append(lines, "head")
+	}
+	if n.Root == g.justified.Root {
+		lines = append(lines, "justified checkpoint")
+	}
+	if n.Root == g.finalized.Root {
+		lines = append(lines, "finalized checkpoint")
+	}
+	if n.Root == g.proposerBoost {
+		lines = append(lines, "proposer boost")
+	}
+	if n.Optimistic {
+		lines = append(lines, "optimistic")
+	}
+	return lines
+}
+
+// hasParent tells whether the parent of the node is in the tree, which is not the case for the
+// root of the tree.
+func (g *forkChoiceGraph) hasParent(n *forkchoicetypes.Node, ids map[[32]byte]string) bool {
+	_, ok := ids[n.ParentRoot]
+	return ok && n.ParentRoot != n.Root
+}
+
+// dot renders the tree in the Graphviz DOT format. The head is filled, optimistic nodes are dashed
+// and the edges of the canonical chain are bold.
+func (g *forkChoiceGraph) dot() string {
+	ids := make(map[[32]byte]string, len(g.nodes))
+	for _, n := range g.nodes {
+		ids[n.Root] = hexutil.Encode(n.Root[:])
+	}
+	var b strings.Builder
+	b.WriteString("digraph forkchoice {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fillcolor=white, fontname=monospace];\n")
+	for _, n := range g.nodes {
+		attrs := fmt.Sprintf("label=%q", strings.Join(g.label(n), "\n"))
+		if n.Optimistic {
+			attrs += ", style=\"rounded,filled,dashed\""
+		}
+		if n.Root == g.head {
+			attrs += ", fillcolor=gold"
+		} else if g.canonical[n.Root] {
+			attrs += ", fillcolor=lightblue"
+		}
+		if n.Root == g.justified.Root || n.Root == g.finalized.Root {
+			attrs += ", peripheries=2"
+		}
+		fmt.Fprintf(&b, "\t%q [%s];\n", ids[n.Root], attrs)
+	}
+	for _, n := range g.nodes {
+		if !g.hasParent(n, ids) {
+			continue
+		}
+		attrs := ""
+		if g.canonical[n.Root] {
+			attrs = " [penwidth=3]"
+		}
+		fmt.Fprintf(&b, "\t%q -> %q%s;\n", ids[n.ParentRoot], ids[n.Root], attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaid renders the tree as a Mermaid flowchart. The head is filled, optimistic nodes are dashed
+// and the edges of the canonical chain are thick.
+func (g *forkChoiceGraph) mermaid() string {
+	ids := make(map[[32]byte]string, len(g.nodes))
+	for i, n := range g.nodes {
+		ids[n.Root] = fmt.Sprintf("n%d", i)
+	}
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	b.WriteString("\tclassDef head fill:#ffd700\n")
+	b.WriteString("\tclassDef canonical fill:#add8e6\n")
+	b.WriteString("\tclassDef optimistic stroke-dasharray:5 5\n")
+	for _, n := range g.nodes {
+		fmt.Fprintf(&b, "\t%s[\"%s\"]\n", ids[n.Root], strings.Join(g.label(n), "<br/>"))
+	}
+	for _, n := range g.nodes {
+		if !g.hasParent(n, ids) {
+			continue
+		}
+		link := "-->"
+		if g.canonical[n.Root] {
+			link = "==>"
+		}
+		fmt.Fprintf(&b, "\t%s %s %s\n", ids[n.ParentRoot], link, ids[n.Root])
+	}
+	for _, n := range g.nodes {
+		var classes []string
+		if n.Root == g.head {
+			classes = append(classes, "head")
+		} else if g.canonical[n.Root] {
+			classes = append(classes, "canonical")
+		}
+		if n.Optimistic {
+			classes = append(classes, "optimistic")
+		}
+		for _, c := range classes {
+			fmt.Fprintf(&b, "\tclass %s %s\n", ids[n.Root], c)
+		}
+	}
+	return b.String()
+}
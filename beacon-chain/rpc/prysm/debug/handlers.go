@@ -0,0 +1,252 @@
+package debug
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+const (
+	defaultSlotTimelineSlots  = 32
+	defaultArrivalTimingSlots = 32
+)
+
+// IsAncestor checks whether the block with the root given in the ancestor query parameter
+// is an ancestor of the block with the root given in the descendant query parameter.
+func (s *Server) IsAncestor(w http.ResponseWriter, r *http.Request) {
+	ancestor, ok := rootFromQuery(w, r, "ancestor")
+	if !ok {
+		return
+	}
+	descendant, ok := rootFromQuery(w, r, "descendant")
+	if !ok {
+		return
+	}
+	isAncestor, err := s.AncestryFetcher.IsAncestor(r.Context(), ancestor, descendant)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, blockchain.ErrUnknownBlockRoot) {
+			code = http.StatusNotFound
+		}
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not check ancestry").Error(),
+			Code:    code,
+		})
+		return
+	}
+	network.WriteJson(w, &IsAncestorResponse{Data: &IsAncestor{IsAncestor: isAncestor}})
+}
+
+// CommonAncestor returns the most recent common ancestor of the blocks with the roots given
+// in the root1 and root2 query parameters.
+func (s *Server) CommonAncestor(w http.ResponseWriter, r *http.Request) {
+	root1, ok := rootFromQuery(w, r, "root1")
+	if !ok {
+		return
+	}
+	root2, ok := rootFromQuery(w, r, "root2")
+	if !ok {
+		return
+	}
+	root, slot, err := s.AncestryFetcher.CommonAncestor(r.Context(), root1, root2)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, forkchoice.ErrUnknownCommonAncestor) {
+			code = http.StatusNotFound
+		}
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get common ancestor").Error(),
+			Code:    code,
+		})
+		return
+	}
+	network.WriteJson(w, &CommonAncestorResponse{Data: &CommonAncestor{
+		Root: hexutil.Encode(root[:]),
+		Slot: strconv.FormatUint(uint64(slot), 10),
+	}})
+}
+
+// GetSlotTimeline returns the timelines of the last slots processed by the node, oldest first, so
+// that operators can see where time goes within each slot. The number of slots is given by the
+// optional slots query parameter.
+func (s *Server) GetSlotTimeline(w http.ResponseWriter, r *http.Request) {
+	n, ok := slotsFromQuery(w, r, defaultSlotTimelineSlots)
+	if !ok {
+		return
+	}
+	genesis := uint64(s.GenesisTimeFetcher.GenesisTime().Unix())
+	timelines := s.SlotTimelineCache.Timelines(n)
+	data := make([]*SlotTimeline, len(timelines))
+	for i, t := range timelines {
+		start := slots.StartTime(genesis, t.Slot)
+		data[i] = &SlotTimeline{
+			Slot:               strconv.FormatUint(uint64(t.Slot), 10),
+			BlockReceived:      slotOffset(start, t.BlockReceived),
+			SignatureVerified:  slotOffset(start, t.SignatureVerified),
+			PayloadVerified:    slotOffset(start, t.PayloadVerified),
+			HeadUpdated:        slotOffset(start, t.HeadUpdated),
+			AttestationsPacked: slotOffset(start, t.AttestationsPacked),
+		}
+	}
+	network.WriteJson(w, &SlotTimelineResponse{Data: data})
+}
+
+// GetArrivalTiming returns the arrival delays, since the start of the slot, of the canonical blocks
+// of the last slots and of the attestations voting for them, oldest slot first, with their
+// percentiles. The number of slots is given by the optional slots query parameter.
+func (s *Server) GetArrivalTiming(w http.ResponseWriter, r *http.Request) {
+	n, ok := slotsFromQuery(w, r, defaultArrivalTimingSlots)
+	if !ok {
+		return
+	}
+	summary := s.ArrivalTracker.Summary(n)
+	data := &ArrivalTiming{
+		BlockDelay:       delayPercentiles(summary.BlockDelay),
+		AttestationDelay: delayPercentiles(summary.AttestationDelay),
+		Slots:            make([]*SlotArrivals, len(summary.Slots)),
+	}
+	for i, ss := range summary.Slots {
+		a := &SlotArrivals{
+			Slot:                  strconv.FormatUint(uint64(ss.Slot), 10),
+			Attestations:          strconv.Itoa(ss.Attestations),
+			AttestationsOtherHead: strconv.Itoa(ss.AttestationsOtherHead),
+		}
+		if ss.HasHeadRoot {
+			a.HeadRoot = hexutil.Encode(ss.HeadRoot[:])
+		}
+		if ss.HasBlock {
+			a.BlockDelay = strconv.FormatInt(ss.BlockDelay.Milliseconds(), 10)
+		}
+		if ss.Attestations > 0 {
+			a.AttestationDelay = delayPercentiles(ss.AttestationDelay)
+		}
+		data.Slots[i] = a
+	}
+	network.WriteJson(w, &ArrivalTimingResponse{Data: data})
+}
+
+// GetForkChoice returns the fork choice tree of the node, with the weight and the optimistic status
+// of each block. The optional format query parameter selects json (the default), or a dot or
+// mermaid rendering of the tree highlighting the head and the canonical chain.
+func (s *Server) GetForkChoice(w http.ResponseWriter, r *http.Request) {
+	g := newForkChoiceGraph(s.ForkFetcher.ForkChoicer())
+	switch format := r.URL.Query().Get("format"); format {
+	case "", graphFormatJSON:
+		data := &ForkChoice{
+			HeadRoot:            hexutil.Encode(g.head[:]),
+			ProposerBoostRoot:   hexutil.Encode(g.proposerBoost[:]),
+			JustifiedCheckpoint: checkpoint(g.justified),
+			FinalizedCheckpoint: checkpoint(g.finalized),
+			Nodes:               make([]*ForkChoiceNode, len(g.nodes)),
+		}
+		for i, n := range g.nodes {
+			data.Nodes[i] = &ForkChoiceNode{
+				Slot:           strconv.FormatUint(uint64(n.Slot), 10),
+				Root:           hexutil.Encode(n.Root[:]),
+				ParentRoot:     hexutil.Encode(n.ParentRoot[:]),
+				PayloadHash:    hexutil.Encode(n.PayloadHash[:]),
+				JustifiedEpoch: strconv.FormatUint(uint64(n.JustifiedEpoch), 10),
+				FinalizedEpoch: strconv.FormatUint(uint64(n.FinalizedEpoch), 10),
+				Weight:         strconv.FormatUint(n.Weight, 10),
+				Optimistic:     n.Optimistic,
+				Canonical:      g.canonical[n.Root],
+			}
+		}
+		network.WriteJson(w, &ForkChoiceResponse{Data: data})
+	case graphFormatDOT:
+		writeText(w, "text/vnd.graphviz", g.dot())
+	case graphFormatMermaid:
+		writeText(w, "text/plain", g.mermaid())
+	default:
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("format %s is not one of %s, %s or %s", format, graphFormatJSON, graphFormatDOT, graphFormatMermaid),
+			Code:    http.StatusBadRequest,
+		})
+	}
+}
+
+func checkpoint(cp *forkchoicetypes.Checkpoint) *Checkpoint {
+	return &Checkpoint{
+		Epoch: strconv.FormatUint(uint64(cp.Epoch), 10),
+		Root:  hexutil.Encode(cp.Root[:]),
+	}
+}
+
+// writeText writes a plain text response with the given content type.
+func writeText(w http.ResponseWriter, contentType, text string) {
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, text); err != nil {
+		log.WithError(err).Error("Could not write response message")
+	}
+}
+
+func delayPercentiles(p arrival.Percentiles) *DelayPercentiles {
+	return &DelayPercentiles{
+		P50: strconv.FormatInt(p.P50.Milliseconds(), 10),
+		P90: strconv.FormatInt(p.P90.Milliseconds(), 10),
+		P99: strconv.FormatInt(p.P99.Milliseconds(), 10),
+	}
+}
+
+// slotsFromQuery returns the number of slots given by the optional slots query parameter, or the
+// default value if it is missing. An error response is written when the parameter is invalid.
+func slotsFromQuery(w http.ResponseWriter, r *http.Request, defaultSlots int) (int, bool) {
+	raw := r.URL.Query().Get("slots")
+	if raw == "" {
+		return defaultSlots, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("slots %s is not a positive number", raw),
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return n, true
+}
+
+// slotOffset returns the number of milliseconds between the start of the slot and the event, or
+// an empty string if the event didn't happen.
+func slotOffset(start, event time.Time) string {
+	if event.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(event.Sub(start).Milliseconds(), 10)
+}
+
+// rootFromQuery decodes the block root held by the query parameter with the given name. An error
+// response is written when the parameter is missing or invalid.
+func rootFromQuery(w http.ResponseWriter, r *http.Request, name string) ([32]byte, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("%s is required", name),
+			Code:    http.StatusBadRequest,
+		})
+		return [32]byte{}, false
+	}
+	root, err := hexutil.Decode(raw)
+	if err != nil || len(root) != fieldparams.RootLength {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("%s %s is not a valid block root", name, raw),
+			Code:    http.StatusBadRequest,
+		})
+		return [32]byte{}, false
+	}
+	return bytesutil.ToBytes32(root), true
+}
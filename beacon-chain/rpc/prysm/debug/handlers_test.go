@@ -0,0 +1,323 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// mockAncestryFetcher knows about a single chain in which every block is the parent of the next one.
+type mockAncestryFetcher struct {
+	chain [][32]byte
+}
+
+func (m *mockAncestryFetcher) index(r [32]byte) int {
+	for i, root := range m.chain {
+		if root == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *mockAncestryFetcher) IsAncestor(_ context.Context, ancestorRoot, descendantRoot [32]byte) (bool, error) {
+	a, d := m.index(ancestorRoot), m.index(descendantRoot)
+	if a < 0 || d < 0 {
+		return false, blockchain.ErrUnknownBlockRoot
+	}
+	return a <= d, nil
+}
+
+func (m *mockAncestryFetcher) CommonAncestor(_ context.Context, r1, r2 [32]byte) ([32]byte, types.Slot, error) {
+	i1, i2 := m.index(r1), m.index(r2)
+	if i1 < 0 || i2 < 0 {
+		return [32]byte{}, 0, errors.Wrap(forkchoice.ErrUnknownCommonAncestor, "unknown root")
+	}
+	if i2 < i1 {
+		i1 = i2
+	}
+	return m.chain[i1], types.Slot(i1), nil
+}
+
+func TestIsAncestor(t *testing.T) {
+	chain := [][32]byte{{'a'}, {'b'}, {'c'}}
+	s := &Server{AncestryFetcher: &mockAncestryFetcher{chain: chain}}
+
+	t.Run("ancestor", func(t *testing.T) {
+		url := "http://example.com/prysm/v1/debug/forkchoice/is_ancestor?ancestor=" + hexutil.Encode(chain[0][:]) + "&descendant=" + hexutil.Encode(chain[2][:])
+		writer := httptest.NewRecorder()
+		s.IsAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &IsAncestorResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Data.IsAncestor)
+	})
+	t.Run("descendant", func(t *testing.T) {
+		url := "http://example.com/prysm/v1/debug/forkchoice/is_ancestor?ancestor=" + hexutil.Encode(chain[2][:]) + "&descendant=" + hexutil.Encode(chain[1][:])
+		writer := httptest.NewRecorder()
+		s.IsAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &IsAncestorResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, false, resp.Data.IsAncestor)
+	})
+	t.Run("unknown root", func(t *testing.T) {
+		unknown := [32]byte{'d'}
+		url := "http://example.com/prysm/v1/debug/forkchoice/is_ancestor?ancestor=" + hexutil.Encode(unknown[:]) + "&descendant=" + hexutil.Encode(chain[1][:])
+		writer := httptest.NewRecorder()
+		s.IsAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		url := "http://example.com/prysm/v1/debug/forkchoice/is_ancestor?ancestor=0x01&descendant=" + hexutil.Encode(chain[1][:])
+		writer := httptest.NewRecorder()
+		s.IsAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "ancestor 0x01 is not a valid block root", e.Message)
+	})
+	t.Run("missing root", func(t *testing.T) {
+		url := "http://example.com/prysm/v1/debug/forkchoice/is_ancestor?ancestor=" + hexutil.Encode(chain[1][:])
+		writer := httptest.NewRecorder()
+		s.IsAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "descendant is required", e.Message)
+	})
+}
+
+func TestCommonAncestor(t *testing.T) {
+	chain := [][32]byte{{'a'}, {'b'}, {'c'}}
+	s := &Server{AncestryFetcher: &mockAncestryFetcher{chain: chain}}
+
+	t.Run("known roots", func(t *testing.T) {
+		url := "http://example.com/prysm/v1/debug/forkchoice/common_ancestor?root1=" + hexutil.Encode(chain[2][:]) + "&root2=" + hexutil.Encode(chain[1][:])
+		writer := httptest.NewRecorder()
+		s.CommonAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &CommonAncestorResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, hexutil.Encode(chain[1][:]), resp.Data.Root)
+		assert.Equal(t, "1", resp.Data.Slot)
+	})
+	t.Run("unknown root", func(t *testing.T) {
+		unknown := [32]byte{'d'}
+		url := "http://example.com/prysm/v1/debug/forkchoice/common_ancestor?root1=" + hexutil.Encode(chain[2][:]) + "&root2=" + hexutil.Encode(unknown[:])
+		writer := httptest.NewRecorder()
+		s.CommonAncestor(writer, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestGetSlotTimeline(t *testing.T) {
+	genesis := time.Now().Add(-time.Hour).Truncate(time.Second)
+	timelines := cache.NewSlotTimelineCache()
+	slot1 := slots.StartTime(uint64(genesis.Unix()), 1)
+	timelines.Record(1, cache.BlockReceived, slot1.Add(500*time.Millisecond))
+	timelines.Record(1, cache.HeadUpdated, slot1.Add(800*time.Millisecond))
+	slot2 := slots.StartTime(uint64(genesis.Unix()), 2)
+	timelines.Record(2, cache.AttestationsPacked, slot2.Add(100*time.Millisecond))
+	s := &Server{
+		GenesisTimeFetcher: &mock.ChainService{Genesis: genesis},
+		SlotTimelineCache:  timelines,
+	}
+
+	t.Run("all slots", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetSlotTimeline(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/slot_timeline", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SlotTimelineResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.DeepEqual(t, &SlotTimeline{Slot: "1", BlockReceived: "500", HeadUpdated: "800"}, resp.Data[0])
+		assert.DeepEqual(t, &SlotTimeline{Slot: "2", AttestationsPacked: "100"}, resp.Data[1])
+	})
+	t.Run("last slot", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetSlotTimeline(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/slot_timeline?slots=1", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SlotTimelineResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "2", resp.Data[0].Slot)
+	})
+	t.Run("invalid slots", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetSlotTimeline(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/slot_timeline?slots=foo", nil))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestGetArrivalTiming(t *testing.T) {
+	tracker := arrival.NewTracker()
+	root := [32]byte{'a'}
+	tracker.RecordBlock(1, root, 500*time.Millisecond)
+	tracker.RecordHead(1, root)
+	tracker.RecordAttestation(1, root, 2*time.Second)
+	tracker.RecordAttestation(1, [32]byte{'b'}, 3*time.Second)
+	tracker.RecordAttestation(2, [32]byte{'b'}, time.Second)
+	s := &Server{ArrivalTracker: tracker}
+
+	t.Run("all slots", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetArrivalTiming(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/arrival_timing", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ArrivalTimingResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, &DelayPercentiles{P50: "500", P90: "500", P99: "500"}, resp.Data.BlockDelay)
+		assert.DeepEqual(t, &DelayPercentiles{P50: "2000", P90: "2000", P99: "2000"}, resp.Data.AttestationDelay)
+		require.Equal(t, 2, len(resp.Data.Slots))
+		assert.DeepEqual(t, &SlotArrivals{
+			Slot:                  "1",
+			HeadRoot:              hexutil.Encode(root[:]),
+			BlockDelay:            "500",
+			Attestations:          "1",
+			AttestationsOtherHead: "1",
+			AttestationDelay:      &DelayPercentiles{P50: "2000", P90: "2000", P99: "2000"},
+		}, resp.Data.Slots[0])
+		assert.DeepEqual(t, &SlotArrivals{
+			Slot:                  "2",
+			HeadRoot:              hexutil.Encode(root[:]),
+			Attestations:          "0",
+			AttestationsOtherHead: "1",
+		}, resp.Data.Slots[1])
+	})
+	t.Run("last slot", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetArrivalTiming(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/arrival_timing?slots=1", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ArrivalTimingResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data.Slots))
+		assert.Equal(t, "2", resp.Data.Slots[0].Slot)
+	})
+	t.Run("invalid slots", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetArrivalTiming(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/arrival_timing?slots=0", nil))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+// mockForkChoicer returns a fixed fork choice tree.
+type mockForkChoicer struct {
+	forkchoice.ForkChoicer
+	nodes     []*forkchoicetypes.Node
+	head      [32]byte
+	justified *forkchoicetypes.Checkpoint
+}
+
+func (m *mockForkChoicer) Nodes() []*forkchoicetypes.Node {
+	return m.nodes
+}
+
+func (m *mockForkChoicer) CachedHeadRoot() [32]byte {
+	return m.head
+}
+
+func (m *mockForkChoicer) ProposerBoost() [32]byte {
+	return [32]byte{}
+}
+
+func (m *mockForkChoicer) JustifiedCheckpoint() *forkchoicetypes.Checkpoint {
+	return m.justified
+}
+
+func (m *mockForkChoicer) FinalizedCheckpoint() *forkchoicetypes.Checkpoint {
+	return m.justified
+}
+
+func TestGetForkChoice(t *testing.T) {
+	genesis, canonical, head, fork := [32]byte{'a'}, [32]byte{'b'}, [32]byte{'c'}, [32]byte{'d'}
+	fc := &mockForkChoicer{
+		nodes: []*forkchoicetypes.Node{
+			{Slot: 0, Root: genesis, Weight: 96},
+			{Slot: 1, Root: canonical, ParentRoot: genesis, Weight: 64},
+			{Slot: 2, Root: fork, ParentRoot: genesis, Weight: 32},
+			{Slot: 2, Root: head, ParentRoot: canonical, Weight: 64, Optimistic: true, JustifiedEpoch: 1},
+		},
+		head:      head,
+		justified: &forkchoicetypes.Checkpoint{Root: genesis},
+	}
+	s := &Server{ForkFetcher: &mock.ChainService{ForkChoiceStore: fc}}
+
+	t.Run("json", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetForkChoice(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/forkchoice", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &ForkChoiceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, hexutil.Encode(head[:]), resp.Data.HeadRoot)
+		assert.DeepEqual(t, &Checkpoint{Epoch: "0", Root: hexutil.Encode(genesis[:])}, resp.Data.FinalizedCheckpoint)
+		require.Equal(t, 4, len(resp.Data.Nodes))
+		assert.Equal(t, true, resp.Data.Nodes[1].Canonical)
+		assert.Equal(t, false, resp.Data.Nodes[2].Canonical)
+		assert.DeepEqual(t, &ForkChoiceNode{
+			Slot:           "2",
+			Root:           hexutil.Encode(head[:]),
+			ParentRoot:     hexutil.Encode(canonical[:]),
+			PayloadHash:    hexutil.Encode(make([]byte, 32)),
+			JustifiedEpoch: "1",
+			FinalizedEpoch: "0",
+			Weight:         "64",
+			Optimistic:     true,
+			Canonical:      true,
+		}, resp.Data.Nodes[3])
+	})
+	t.Run("dot", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetForkChoice(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/forkchoice?format=dot", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		dot := writer.Body.String()
+		for _, want := range []string{
+			"digraph forkchoice {",
+			fmt.Sprintf("%q -> %q [penwidth=3];", hexutil.Encode(canonical[:]), hexutil.Encode(head[:])),
+			fmt.Sprintf("%q -> %q;", hexutil.Encode(genesis[:]), hexutil.Encode(fork[:])),
+			"weight 64 Gwei\\njustified 1, finalized 0\\nhead\\noptimistic\", style=\"rounded,filled,dashed\", fillcolor=gold];",
+		} {
+			assert.Equal(t, true, strings.Contains(dot, want), "missing %s in %s", want, dot)
+		}
+	})
+	t.Run("mermaid", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetForkChoice(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/forkchoice?format=mermaid", nil))
+		require.Equal(t, http.StatusOK, writer.Code)
+		mermaid := writer.Body.String()
+		for _, want := range []string{
+			"graph LR\n",
+			"n0 ==> n1\n",
+			"n0 --> n2\n",
+			"n1 ==> n3\n",
+			"class n3 head\n",
+			"class n3 optimistic\n",
+			"class n1 canonical\n",
+		} {
+			assert.Equal(t, true, strings.Contains(mermaid, want), "missing %s in %s", want, mermaid)
+		}
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		s.GetForkChoice(writer, httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/debug/forkchoice?format=svg", nil))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -0,0 +1,19 @@
+// Package debug defines Prysm-specific HTTP endpoints exposing
+// fork choice and block processing information which is useful
+// for debugging and tooling.
+package debug
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
+)
+
+// Server defines a server implementation of Prysm-specific debug HTTP endpoints.
+type Server struct {
+	AncestryFetcher    blockchain.AncestryFetcher
+	ForkFetcher        blockchain.ForkFetcher
+	GenesisTimeFetcher blockchain.TimeFetcher
+	SlotTimelineCache  *cache.SlotTimelineCache
+	ArrivalTracker     *arrival.Tracker
+}
@@ -0,0 +1,104 @@
+package debug
+
+// IsAncestorResponse is the response of the ancestry check endpoint.
+type IsAncestorResponse struct {
+	Data *IsAncestor `json:"data"`
+}
+
+// IsAncestor tells whether a block is an ancestor of another block.
+type IsAncestor struct {
+	IsAncestor bool `json:"is_ancestor"`
+}
+
+// CommonAncestorResponse is the response of the common ancestor endpoint.
+type CommonAncestorResponse struct {
+	Data *CommonAncestor `json:"data"`
+}
+
+// CommonAncestor is the most recent common ancestor of two blocks.
+type CommonAncestor struct {
+	Root string `json:"root"`
+	Slot string `json:"slot"`
+}
+
+// SlotTimelineResponse is the response of the slot timeline endpoint.
+type SlotTimelineResponse struct {
+	Data []*SlotTimeline `json:"data"`
+}
+
+// SlotTimeline holds the times at which the steps of the processing of a slot happened, in
+// milliseconds since the start of the slot. Steps which didn't happen are omitted.
+type SlotTimeline struct {
+	Slot               string `json:"slot"`
+	BlockReceived      string `json:"block_received,omitempty"`
+	SignatureVerified  string `json:"signature_verified,omitempty"`
+	PayloadVerified    string `json:"payload_verified,omitempty"`
+	HeadUpdated        string `json:"head_updated,omitempty"`
+	AttestationsPacked string `json:"attestations_packed,omitempty"`
+}
+
+// ArrivalTimingResponse is the response of the arrival timing endpoint.
+type ArrivalTimingResponse struct {
+	Data *ArrivalTiming `json:"data"`
+}
+
+// ArrivalTiming holds the arrival delays of the canonical blocks of the last slots and of the
+// attestations voting for them, in milliseconds since the start of the slot.
+type ArrivalTiming struct {
+	BlockDelay       *DelayPercentiles `json:"block_delay"`
+	AttestationDelay *DelayPercentiles `json:"attestation_delay"`
+	Slots            []*SlotArrivals   `json:"slots"`
+}
+
+// DelayPercentiles are percentiles of arrival delays, in milliseconds.
+type DelayPercentiles struct {
+	P50 string `json:"p50"`
+	P90 string `json:"p90"`
+	P99 string `json:"p99"`
+}
+
+// SlotArrivals holds the arrivals of a slot. The head root is the canonical block which the
+// attestations of the slot should vote for, the block delay is omitted when the slot has no
+// canonical block received over gossip.
+type SlotArrivals struct {
+	Slot                  string            `json:"slot"`
+	HeadRoot              string            `json:"head_root,omitempty"`
+	BlockDelay            string            `json:"block_delay,omitempty"`
+	Attestations          string            `json:"attestations"`
+	AttestationsOtherHead string            `json:"attestations_other_head"`
+	AttestationDelay      *DelayPercentiles `json:"attestation_delay,omitempty"`
+}
+
+// ForkChoiceResponse is the response of the fork choice endpoint.
+type ForkChoiceResponse struct {
+	Data *ForkChoice `json:"data"`
+}
+
+// ForkChoice is a snapshot of the fork choice tree, parents before their children.
+type ForkChoice struct {
+	HeadRoot            string            `json:"head_root"`
+	ProposerBoostRoot   string            `json:"proposer_boost_root"`
+	JustifiedCheckpoint *Checkpoint       `json:"justified_checkpoint"`
+	FinalizedCheckpoint *Checkpoint       `json:"finalized_checkpoint"`
+	Nodes               []*ForkChoiceNode `json:"nodes"`
+}
+
+// Checkpoint is a checkpoint of the fork choice store.
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// ForkChoiceNode is a block of the fork choice tree. The weight is in Gwei, canonical tells whether
+// the block is in the chain leading to the head.
+type ForkChoiceNode struct {
+	Slot           string `json:"slot"`
+	Root           string `json:"root"`
+	ParentRoot     string `json:"parent_root"`
+	PayloadHash    string `json:"payload_hash"`
+	JustifiedEpoch string `json:"justified_epoch"`
+	FinalizedEpoch string `json:"finalized_epoch"`
+	Weight         string `json:"weight"`
+	Optimistic     bool   `json:"optimistic"`
+	Canonical      bool   `json:"canonical"`
+}
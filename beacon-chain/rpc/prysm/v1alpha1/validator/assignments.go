@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
@@ -52,6 +53,8 @@ func (vs *Server) StreamDuties(req *ethpb.DutiesRequest, stream ethpb.BeaconNode
 		currentEpoch = slots.EpochsSinceGenesis(vs.TimeFetcher.GenesisTime())
 	}
 	req.Epoch = currentEpoch
+	// Subscribers receive complete duties, as the request is reused for every epoch.
+	req.DependentRoot = nil
 	res, err := vs.duties(stream.Context(), req)
 	if err != nil {
 		return status.Errorf(codes.Internal, "Could not compute validator duties: %v", err)
@@ -133,12 +136,23 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 			return nil, status.Errorf(codes.Internal, "Could not process slots up to %d: %v", epochStartSlot, err)
 		}
 	}
-	committeeAssignments, proposerIndexToSlots, err := helpers.CommitteeAssignments(ctx, s, req.Epoch)
+	currentDependentRoot, err := vs.attesterDependentRoot(ctx, s, req.Epoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get dependent root: %v", err)
+	}
+	nextDependentRoot, err := vs.attesterDependentRoot(ctx, s, req.Epoch+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get next epoch dependent root: %v", err)
+	}
+	// The caller already knows the committee assignments of the current epoch when they depend on the same block.
+	omitCommittees := len(req.DependentRoot) != 0 && bytes.Equal(req.DependentRoot, currentDependentRoot)
+
+	committeeAssignments, proposerIndexToSlots, err := vs.assignments(ctx, s, req.Epoch, currentDependentRoot)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
 	}
 	// Query the next epoch assignments for committee subnet subscriptions.
-	nextCommitteeAssignments, nextProposerIndexToSlots, err := helpers.CommitteeAssignments(ctx, s, req.Epoch+1)
+	nextCommitteeAssignments, nextProposerIndexToSlots, err := vs.assignments(ctx, s, req.Epoch+1, nextDependentRoot)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not compute next committee assignments: %v", err)
 	}
@@ -168,7 +182,7 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 			nextAssignment.Status = s
 
 			ca, ok := committeeAssignments[idx]
-			if ok {
+			if ok && !omitCommittees {
 				assignment.Committee = ca.Committee
 				assignment.AttesterSlot = ca.AttesterSlot
 				assignment.CommitteeIndex = ca.CommitteeIndex
@@ -212,10 +226,10 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 
 			// Next epoch sync committee duty is assigned with next period sync committee only during
 			// sync period epoch boundary (ie. EPOCHS_PER_SYNC_COMMITTEE_PERIOD - 1). Else wise
-			// next epoch sync committee duty is the same as current epoch.
-			nextSlotToEpoch := slots.ToEpoch(s.Slot() + 1)
-			currentEpoch := coreTime.CurrentEpoch(s)
-			if slots.SyncCommitteePeriod(nextSlotToEpoch) == slots.SyncCommitteePeriod(currentEpoch)+1 {
+			// next epoch sync committee duty is the same as current epoch. The boundary is checked on the
+			// requested epoch, as the assignments are computed on copies of the state which is left at the
+			// start of the epoch instead of being moved to its last slot.
+			if slots.SyncCommitteePeriod(req.Epoch+1) == slots.SyncCommitteePeriod(req.Epoch)+1 {
 				nextAssignment.IsSyncCommittee, err = helpers.IsNextPeriodSyncCommittee(s, idx)
 				if err != nil {
 					return nil, status.Errorf(codes.Internal, "Could not determine next epoch sync committee: %v", err)
@@ -236,12 +250,151 @@ func (vs *Server) duties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.
 	}
 
 	return &ethpb.DutiesResponse{
-		Duties:             validatorAssignments,
-		CurrentEpochDuties: validatorAssignments,
-		NextEpochDuties:    nextValidatorAssignments,
+		Duties:                    validatorAssignments,
+		CurrentEpochDuties:        validatorAssignments,
+		NextEpochDuties:           nextValidatorAssignments,
+		CurrentEpochDependentRoot: currentDependentRoot,
+		NextEpochDependentRoot:    nextDependentRoot,
 	}, nil
 }
 
+// assignments returns the committee and proposer assignments of the epoch, each from the duties
+// cache when they were already computed for the same dependent root.
+func (vs *Server) assignments(
+	ctx context.Context,
+	s beaconState.BeaconState,
+	epoch types.Epoch,
+	attesterRoot []byte,
+) (map[types.ValidatorIndex]*helpers.CommitteeAssignmentContainer, map[types.ValidatorIndex][]types.Slot, error) {
+	proposerRoot, err := vs.proposerDependentRoot(ctx, s, epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get proposer dependent root")
+	}
+	committees, ok := vs.DutiesCache.getCommittees(epoch, attesterRoot)
+	if !ok {
+		committees, err = helpers.AttesterAssignments(ctx, s, epoch)
+		if err != nil {
+			return nil, nil, err
+		}
+		vs.DutiesCache.putCommittees(epoch, &committeeAssignments{dependentRoot: attesterRoot, committees: committees})
+	}
+	proposers, ok := vs.DutiesCache.getProposers(epoch, proposerRoot)
+	if !ok {
+		// Computing the proposers changes the slot of the state, use a copy so that the state is the
+		// same whether the assignments were cached or not.
+		proposers, err = helpers.ProposerAssignments(ctx, s.Copy(), epoch)
+		if err != nil {
+			return nil, nil, err
+		}
+		vs.DutiesCache.putProposers(epoch, &proposerAssignments{dependentRoot: proposerRoot, proposers: proposers})
+	}
+	return committees, proposers, nil
+}
+
+// PrecomputeDuties keeps the duties cache consistent with the head until the server context is
+// canceled. Cached assignments are dropped when a reorg changes their dependent roots, and the
+// assignments of the next two epochs are computed at the last slot of an epoch, as soon as their
+// dependent roots are known, rather than for all validator clients at the epoch boundary.
+func (vs *Server) PrecomputeDuties() {
+	if vs.DutiesCache == nil {
+		return
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := vs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case ev := <-stateChannel:
+			if ev.Type != statefeed.NewHead {
+				continue
+			}
+			head, ok := ev.Data.(*ethpbv1.EventHead)
+			if !ok {
+				log.Errorf("Received incorrect data type over head feed: %T", ev.Data)
+				continue
+			}
+			vs.invalidateDuties(head)
+			if !vs.dutiesPrecomputationDue(head) {
+				continue
+			}
+			// Do not block the state feed while computing the assignments.
+			go func(epoch types.Epoch) {
+				if _, err := vs.duties(vs.Ctx, &ethpb.DutiesRequest{Epoch: epoch}); err != nil {
+					log.WithError(err).WithField("epoch", epoch).Debug("Could not precompute validator duties")
+				}
+			}(slots.ToEpoch(head.Slot) + 1)
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case <-vs.Ctx.Done():
+			return
+		}
+	}
+}
+
+// invalidateDuties drops the cached assignments depending on blocks which the new head doesn't
+// descend from. The head event provides the dependent roots of the epoch of the head, the block
+// at the last slot of an epoch being the dependent root of the assignments of later epochs.
+func (vs *Server) invalidateDuties(head *ethpbv1.EventHead) {
+	epoch := slots.ToEpoch(head.Slot)
+	vs.DutiesCache.invalidate(epoch, head.PreviousDutyDependentRoot, head.CurrentDutyDependentRoot)
+	vs.DutiesCache.invalidate(epoch+1, head.CurrentDutyDependentRoot, nil)
+	if slots.IsEpochEnd(head.Slot) {
+		vs.DutiesCache.invalidate(epoch+1, nil, head.Block)
+		vs.DutiesCache.invalidate(epoch+2, head.Block, nil)
+	}
+}
+
+// dutiesPrecomputationDue tells whether the assignments of the next two epochs should be computed
+// for the head, which is the case when it is the block of the current slot, the last one of the
+// epoch, and validator clients requested the duties of the epoch.
+func (vs *Server) dutiesPrecomputationDue(head *ethpbv1.EventHead) bool {
+	if !slots.IsEpochEnd(head.Slot) || head.Slot != vs.TimeFetcher.CurrentSlot() {
+		return false
+	}
+	if vs.SyncChecker.Syncing() {
+		return false
+	}
+	return vs.DutiesCache.has(slots.ToEpoch(head.Slot))
+}
+
+// attesterDependentRoot returns the block root at the last slot of the epoch before the previous one,
+// on which the committee assignments of the epoch depend, or the genesis block root in case of underflow.
+// The head root is returned when the state is not past that slot yet.
+func (vs *Server) attesterDependentRoot(ctx context.Context, s beaconState.ReadOnlyBeaconState, epoch types.Epoch) ([]byte, error) {
+	var dependentRootSlot types.Slot
+	if epoch > 1 {
+		prevEpochStartSlot, err := slots.EpochStart(epoch - 1)
+		if err != nil {
+			return nil, err
+		}
+		dependentRootSlot = prevEpochStartSlot - 1
+	}
+	return vs.dependentRoot(ctx, s, dependentRootSlot)
+}
+
+// proposerDependentRoot returns the block root at the last slot of the previous epoch, on which the
+// proposer assignments of the epoch depend, or the genesis block root in case of underflow.
+// The head root is returned when the state is not past that slot yet.
+func (vs *Server) proposerDependentRoot(ctx context.Context, s beaconState.ReadOnlyBeaconState, epoch types.Epoch) ([]byte, error) {
+	var dependentRootSlot types.Slot
+	if epoch > 0 {
+		epochStartSlot, err := slots.EpochStart(epoch)
+		if err != nil {
+			return nil, err
+		}
+		dependentRootSlot = epochStartSlot - 1
+	}
+	return vs.dependentRoot(ctx, s, dependentRootSlot)
+}
+
+func (vs *Server) dependentRoot(ctx context.Context, s beaconState.ReadOnlyBeaconState, slot types.Slot) ([]byte, error) {
+	if slot >= s.Slot() {
+		return vs.HeadFetcher.HeadRoot(ctx)
+	}
+	return helpers.BlockRootAtSlot(s, slot)
+}
+
 // AssignValidatorToSubnet checks the status and pubkey of a particular validator
 // to discern whether persistent subnets need to be registered for them.
 func (vs *Server) AssignValidatorToSubnet(pubkey []byte, status ethpb.ValidatorStatus) {
@@ -101,6 +101,175 @@ func TestGetDuties_OK(t *testing.T) {
 	}
 }
 
+func TestGetDuties_OmitsKnownCommitteeAssignments(t *testing.T) {
+	genesis := util.NewBeaconBlock()
+	deposits, _, err := util.DeterministicDepositsAndKeys(params.BeaconConfig().MinGenesisActiveValidatorCount)
+	require.NoError(t, err)
+	eth1Data, err := util.DeterministicEth1Data(len(deposits))
+	require.NoError(t, err)
+	bs, err := transition.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
+	require.NoError(t, err, "Could not setup genesis bs")
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err, "Could not get signing root")
+
+	chain := &mockChain.ChainService{
+		State: bs, Root: genesisRoot[:], Genesis: time.Now(),
+	}
+	vs := &Server{
+		HeadFetcher:            chain,
+		TimeFetcher:            chain,
+		SyncChecker:            &mockSync.Sync{IsSyncing: false},
+		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
+	}
+
+	req := &ethpb.DutiesRequest{
+		PublicKeys: [][]byte{deposits[0].Data.PublicKey},
+	}
+	res, err := vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	assert.DeepEqual(t, genesisRoot[:], res.CurrentEpochDependentRoot)
+	assert.DeepEqual(t, genesisRoot[:], res.NextEpochDependentRoot)
+	require.NotEqual(t, 0, len(res.CurrentEpochDuties[0].Committee))
+
+	// A different dependent root gets the complete duties.
+	req.DependentRoot = bytesutil.PadTo([]byte("other"), 32)
+	res, err = vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(res.CurrentEpochDuties[0].Committee))
+
+	req.DependentRoot = genesisRoot[:]
+	res, err = vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(res.CurrentEpochDuties[0].Committee))
+	assert.Equal(t, types.Slot(0), res.CurrentEpochDuties[0].AttesterSlot)
+	assert.Equal(t, types.ValidatorIndex(0), res.CurrentEpochDuties[0].ValidatorIndex)
+	assert.Equal(t, ethpb.ValidatorStatus_ACTIVE, res.CurrentEpochDuties[0].Status)
+	assert.NotEqual(t, 0, len(res.NextEpochDuties[0].Committee))
+}
+
+func TestGetDuties_CachedAssignments(t *testing.T) {
+	genesis := util.NewBeaconBlock()
+	deposits, _, err := util.DeterministicDepositsAndKeys(params.BeaconConfig().MinGenesisActiveValidatorCount)
+	require.NoError(t, err)
+	eth1Data, err := util.DeterministicEth1Data(len(deposits))
+	require.NoError(t, err)
+	bs, err := transition.GenesisBeaconState(context.Background(), deposits, 0, eth1Data)
+	require.NoError(t, err, "Could not setup genesis bs")
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err, "Could not get signing root")
+
+	chain := &mockChain.ChainService{
+		State: bs, Root: genesisRoot[:], Genesis: time.Now(),
+	}
+	vs := &Server{
+		HeadFetcher:            chain,
+		TimeFetcher:            chain,
+		SyncChecker:            &mockSync.Sync{IsSyncing: false},
+		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
+		DutiesCache:            NewDutiesCache(),
+	}
+
+	req := &ethpb.DutiesRequest{
+		PublicKeys: [][]byte{deposits[0].Data.PublicKey},
+	}
+	res, err := vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(res.CurrentEpochDuties[0].Committee))
+	assert.Equal(t, true, vs.DutiesCache.has(0))
+	assert.Equal(t, true, vs.DutiesCache.has(1))
+	cachedRes, err := vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	assert.DeepEqual(t, res, cachedRes)
+
+	// The cached assignments are served for the same dependent roots.
+	vs.DutiesCache.putCommittees(0, &committeeAssignments{dependentRoot: genesisRoot[:]})
+	res, err = vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(res.CurrentEpochDuties[0].Committee))
+	assert.NotEqual(t, 0, len(res.NextEpochDuties[0].Committee))
+
+	other := bytesutil.PadTo([]byte("other"), 32)
+	vs.DutiesCache.putCommittees(0, &committeeAssignments{dependentRoot: other})
+	res, err = vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, len(res.CurrentEpochDuties[0].Committee))
+
+	// Committee and proposer assignments are cached on their own dependent roots.
+	vs.DutiesCache.putProposers(0, &proposerAssignments{dependentRoot: genesisRoot[:]})
+	res, err = vs.GetDuties(context.Background(), req)
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, len(res.CurrentEpochDuties[0].Committee))
+	assert.Equal(t, 0, len(res.CurrentEpochDuties[0].ProposerSlots))
+}
+
+func TestServer_InvalidateDuties(t *testing.T) {
+	root := func(b byte) []byte {
+		return bytesutil.PadTo([]byte{b}, 32)
+	}
+	vs := &Server{DutiesCache: NewDutiesCache()}
+	hasProposers := func(epoch types.Epoch) bool {
+		_, ok := vs.DutiesCache.proposers[epoch]
+		return ok
+	}
+	for e := types.Epoch(1); e <= 3; e++ {
+		vs.DutiesCache.putCommittees(e, &committeeAssignments{dependentRoot: root(byte(e))})
+		vs.DutiesCache.putProposers(e, &proposerAssignments{dependentRoot: root(byte(e) + 1)})
+	}
+	epochEnd := 2*params.BeaconConfig().SlotsPerEpoch - 1
+
+	// A head building on the dependent blocks keeps the assignments.
+	vs.invalidateDuties(&ethpbv1.EventHead{
+		Slot: epochEnd - 1, Block: root(9), PreviousDutyDependentRoot: root(1), CurrentDutyDependentRoot: root(2),
+	})
+	vs.invalidateDuties(&ethpbv1.EventHead{
+		Slot: epochEnd, Block: root(3), PreviousDutyDependentRoot: root(1), CurrentDutyDependentRoot: root(2),
+	})
+	for e := types.Epoch(1); e <= 3; e++ {
+		assert.Equal(t, true, vs.DutiesCache.has(e))
+		assert.Equal(t, true, hasProposers(e))
+	}
+
+	// A reorg of the last block of the epoch drops the assignments depending on it: the proposers of the
+	// next epoch and the committees of the one after.
+	vs.invalidateDuties(&ethpbv1.EventHead{
+		Slot: epochEnd, Block: root(5), PreviousDutyDependentRoot: root(1), CurrentDutyDependentRoot: root(2),
+	})
+	assert.Equal(t, true, vs.DutiesCache.has(1))
+	assert.Equal(t, true, hasProposers(1))
+	assert.Equal(t, true, vs.DutiesCache.has(2))
+	assert.Equal(t, false, hasProposers(2))
+	assert.Equal(t, false, vs.DutiesCache.has(3))
+	assert.Equal(t, true, hasProposers(3))
+
+	vs.invalidateDuties(&ethpbv1.EventHead{
+		Slot: epochEnd - 1, Block: root(9), PreviousDutyDependentRoot: root(6), CurrentDutyDependentRoot: root(2),
+	})
+	assert.Equal(t, false, vs.DutiesCache.has(1))
+	assert.Equal(t, true, hasProposers(1))
+}
+
+func TestServer_DutiesPrecomputationDue(t *testing.T) {
+	slot := params.BeaconConfig().SlotsPerEpoch - 1
+	vs := &Server{
+		TimeFetcher: &mockChain.ChainService{Slot: &slot},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+		DutiesCache: NewDutiesCache(),
+	}
+	head := &ethpbv1.EventHead{Slot: slot}
+	// No validator client requested the duties of the epoch.
+	assert.Equal(t, false, vs.dutiesPrecomputationDue(head))
+
+	vs.DutiesCache.putCommittees(0, &committeeAssignments{})
+	assert.Equal(t, true, vs.dutiesPrecomputationDue(head))
+	assert.Equal(t, false, vs.dutiesPrecomputationDue(&ethpbv1.EventHead{Slot: slot - 1}))
+	vs.SyncChecker = &mockSync.Sync{IsSyncing: true}
+	assert.Equal(t, false, vs.dutiesPrecomputationDue(head))
+	vs.SyncChecker = &mockSync.Sync{IsSyncing: false}
+	// The head is a late block of the previous slot.
+	slot++
+	assert.Equal(t, false, vs.dutiesPrecomputationDue(head))
+}
+
 func TestGetAltairDuties_SyncCommitteeOK(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	cfg := params.MainnetConfig().Copy()
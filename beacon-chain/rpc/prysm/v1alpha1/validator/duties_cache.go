@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+)
+
+// dutiesCacheEpochs is the number of epochs kept in the duties cache, from the current epoch to
+// the precomputed one two epochs ahead.
+const dutiesCacheEpochs = 3
+
+var (
+	dutiesCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "duties_cache_hit",
+		Help: "The number of duties requests served from the cached epoch assignments.",
+	})
+	dutiesCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "duties_cache_miss",
+		Help: "The number of duties requests computing the epoch assignments.",
+	})
+)
+
+// committeeAssignments are the committee assignments of all validators for an epoch, along with
+// the dependent root they were computed for.
+type committeeAssignments struct {
+	dependentRoot []byte
+	committees    map[types.ValidatorIndex]*helpers.CommitteeAssignmentContainer
+}
+
+// proposerAssignments are the proposer assignments of all validators for an epoch, along with the
+// dependent root they were computed for.
+type proposerAssignments struct {
+	dependentRoot []byte
+	proposers     map[types.ValidatorIndex][]types.Slot
+}
+
+// DutiesCache keeps the assignments of the latest epochs, so that they are computed once for all
+// validator clients and ahead of the epoch boundary. Committee and proposer assignments depend on
+// different blocks, they are cached separately and only served for the dependent root they were
+// computed for, a nil cache disabling it.
+type DutiesCache struct {
+	lock       sync.RWMutex
+	committees map[types.Epoch]*committeeAssignments
+	proposers  map[types.Epoch]*proposerAssignments
+}
+
+// NewDutiesCache creates a new duties cache.
+func NewDutiesCache() *DutiesCache {
+	return &DutiesCache{
+		committees: make(map[types.Epoch]*committeeAssignments),
+		proposers:  make(map[types.Epoch]*proposerAssignments),
+	}
+}
+
+// getCommittees returns the committee assignments of the epoch if they were computed for the
+// dependent root.
+func (c *DutiesCache) getCommittees(epoch types.Epoch, dependentRoot []byte) (map[types.ValidatorIndex]*helpers.CommitteeAssignmentContainer, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	a, ok := c.committees[epoch]
+	if !ok || !bytes.Equal(a.dependentRoot, dependentRoot) {
+		dutiesCacheMiss.Inc()
+		return nil, false
+	}
+	dutiesCacheHit.Inc()
+	return a.committees, true
+}
+
+// getProposers returns the proposer assignments of the epoch if they were computed for the
+// dependent root.
+func (c *DutiesCache) getProposers(epoch types.Epoch, dependentRoot []byte) (map[types.ValidatorIndex][]types.Slot, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	a, ok := c.proposers[epoch]
+	if !ok || !bytes.Equal(a.dependentRoot, dependentRoot) {
+		dutiesCacheMiss.Inc()
+		return nil, false
+	}
+	dutiesCacheHit.Inc()
+	return a.proposers, true
+}
+
+// putCommittees saves the committee assignments of the epoch, replacing the previous ones, and
+// prunes the epochs which are too old.
+func (c *DutiesCache) putCommittees(epoch types.Epoch, a *committeeAssignments) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.committees[epoch] = a
+	for e := range c.committees {
+		if e+dutiesCacheEpochs <= epoch {
+			delete(c.committees, e)
+		}
+	}
+}
+
+// putProposers saves the proposer assignments of the epoch, replacing the previous ones, and
+// prunes the epochs which are too old.
+func (c *DutiesCache) putProposers(epoch types.Epoch, a *proposerAssignments) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.proposers[epoch] = a
+	for e := range c.proposers {
+		if e+dutiesCacheEpochs <= epoch {
+			delete(c.proposers, e)
+		}
+	}
+}
+
+// has tells whether committee assignments are cached for the epoch, whatever their dependent root.
+func (c *DutiesCache) has(epoch types.Epoch) bool {
+	if c == nil {
+		return false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	_, ok := c.committees[epoch]
+	return ok
+}
+
+// invalidate drops the committee and proposer assignments of the epoch which were computed for other
+// dependent roots than the ones provided, a nil root matching any. It returns whether assignments
+// were dropped.
+func (c *DutiesCache) invalidate(epoch types.Epoch, attesterRoot, proposerRoot []byte) bool {
+	if c == nil {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var dropped bool
+	if a, ok := c.committees[epoch]; ok && attesterRoot != nil && !bytes.Equal(a.dependentRoot, attesterRoot) {
+		delete(c.committees, epoch)
+		dropped = true
+	}
+	if a, ok := c.proposers[epoch]; ok && proposerRoot != nil && !bytes.Equal(a.dependentRoot, proposerRoot) {
+		delete(c.proposers, epoch)
+		dropped = true
+	}
+	return dropped
+}
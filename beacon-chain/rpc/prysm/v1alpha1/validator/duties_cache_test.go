@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestDutiesCache_GetPut(t *testing.T) {
+	c := NewDutiesCache()
+	attesterRoot, proposerRoot, other := []byte{'a'}, []byte{'p'}, []byte{'o'}
+	_, ok := c.getCommittees(1, attesterRoot)
+	assert.Equal(t, false, ok)
+	_, ok = c.getProposers(1, proposerRoot)
+	assert.Equal(t, false, ok)
+
+	committees := map[types.ValidatorIndex]*helpers.CommitteeAssignmentContainer{1: {AttesterSlot: 34}}
+	proposers := map[types.ValidatorIndex][]types.Slot{1: {33}}
+	c.putCommittees(1, &committeeAssignments{dependentRoot: attesterRoot, committees: committees})
+	c.putProposers(1, &proposerAssignments{dependentRoot: proposerRoot, proposers: proposers})
+	cachedCommittees, ok := c.getCommittees(1, attesterRoot)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, committees, cachedCommittees)
+	cachedProposers, ok := c.getProposers(1, proposerRoot)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, proposers, cachedProposers)
+	_, ok = c.getCommittees(1, other)
+	assert.Equal(t, false, ok)
+	_, ok = c.getProposers(1, other)
+	assert.Equal(t, false, ok)
+	_, ok = c.getCommittees(2, attesterRoot)
+	assert.Equal(t, false, ok)
+	_, ok = c.getProposers(2, proposerRoot)
+	assert.Equal(t, false, ok)
+
+	var nilCache *DutiesCache
+	nilCache.putCommittees(1, &committeeAssignments{dependentRoot: attesterRoot, committees: committees})
+	nilCache.putProposers(1, &proposerAssignments{dependentRoot: proposerRoot, proposers: proposers})
+	_, ok = nilCache.getCommittees(1, attesterRoot)
+	assert.Equal(t, false, ok)
+	_, ok = nilCache.getProposers(1, proposerRoot)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, false, nilCache.has(1))
+	assert.Equal(t, false, nilCache.invalidate(1, other, other))
+}
+
+func TestDutiesCache_Prune(t *testing.T) {
+	c := NewDutiesCache()
+	for e := types.Epoch(0); e < 5; e++ {
+		c.putCommittees(e, &committeeAssignments{})
+		c.putProposers(e, &proposerAssignments{})
+	}
+	assert.Equal(t, false, c.has(0))
+	assert.Equal(t, false, c.has(1))
+	assert.Equal(t, true, c.has(2))
+	assert.Equal(t, true, c.has(3))
+	assert.Equal(t, true, c.has(4))
+	assert.Equal(t, 3, len(c.proposers))
+	// Assignments of a reorged epoch replace the previous ones without pruning later epochs.
+	c.putCommittees(3, &committeeAssignments{})
+	c.putProposers(3, &proposerAssignments{})
+	assert.Equal(t, true, c.has(4))
+	assert.Equal(t, 3, len(c.proposers))
+}
+
+func TestDutiesCache_Invalidate(t *testing.T) {
+	c := NewDutiesCache()
+	attesterRoot, proposerRoot, other := []byte{'a'}, []byte{'p'}, []byte{'o'}
+	c.putCommittees(1, &committeeAssignments{dependentRoot: attesterRoot})
+	c.putProposers(1, &proposerAssignments{dependentRoot: proposerRoot})
+	assert.Equal(t, false, c.invalidate(2, other, other))
+	assert.Equal(t, false, c.invalidate(1, attesterRoot, proposerRoot))
+	assert.Equal(t, false, c.invalidate(1, nil, proposerRoot))
+	assert.Equal(t, false, c.invalidate(1, attesterRoot, nil))
+	require.Equal(t, true, c.has(1))
+
+	// A new proposer dependent root keeps the committee assignments.
+	assert.Equal(t, true, c.invalidate(1, nil, other))
+	assert.Equal(t, true, c.has(1))
+	_, ok := c.getProposers(1, proposerRoot)
+	assert.Equal(t, false, ok)
+
+	// A new attester dependent root keeps the proposer assignments.
+	c.putProposers(1, &proposerAssignments{dependentRoot: proposerRoot})
+	assert.Equal(t, true, c.invalidate(1, other, nil))
+	assert.Equal(t, false, c.has(1))
+	_, ok = c.getProposers(1, proposerRoot)
+	assert.Equal(t, true, ok)
+}
@@ -26,14 +26,21 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 	defer span.End()
 
 	atts := vs.AttPool.AggregatedAttestations()
-	atts, err := vs.validateAndDeleteAttsInPool(ctx, latestState, atts)
+	uAtts, err := vs.AttPool.UnaggregatedAttestations()
 	if err != nil {
-		return nil, errors.Wrap(err, "could not filter attestations")
+		return nil, errors.Wrap(err, "could not get unaggregated attestations")
 	}
 
-	uAtts, err := vs.AttPool.UnaggregatedAttestations()
+	// Attesters are collected before the attestations are validated, as this processes them on the state.
+	poolAtts := make([]*ethpb.Attestation, 0, len(atts)+len(uAtts))
+	known, err := vs.knownAttesters(ctx, latestState, append(append(poolAtts, atts...), uAtts...))
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get unaggregated attestations")
+		return nil, errors.Wrap(err, "could not get known attesters")
+	}
+
+	atts, err = vs.validateAndDeleteAttsInPool(ctx, latestState, atts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not filter attestations")
 	}
 	uAtts, err = vs.validateAndDeleteAttsInPool(ctx, latestState, uAtts)
 	if err != nil {
@@ -41,9 +48,10 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 	}
 	atts = append(atts, uAtts...)
 
-	// Remove duplicates from both aggregated/unaggregated attestations. This
-	// prevents inefficient aggregates being created.
-	atts, err = proposerAtts(atts).dedup()
+	// Remove attestations of slashed validators or of validators whose participation is already recorded,
+	// as well as duplicates from both aggregated/unaggregated attestations. This prevents inefficient
+	// aggregates being created.
+	atts, err = proposerAtts(atts).filterKnown(known).dedup()
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +77,13 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 	if err != nil {
 		return nil, err
 	}
-	sorted, err := deduped.sortByProfitability()
+	sorted, err := deduped.filterKnown(known).sortByProfitability(known)
 	if err != nil {
 		return nil, err
 	}
-	atts = sorted.limitToMaxAttestations()
-	return atts, nil
+	packed := sorted.limitToMaxAttestations()
+	wastedAttestationBits.Observe(float64(packed.wastedBits(known)))
+	return packed, nil
 }
 
 // filter separates attestation list into two groups: valid and invalid attestations.
@@ -111,17 +120,18 @@ func (a proposerAtts) filter(ctx context.Context, st state.BeaconState) (propose
 	return validAtts, invalidAtts
 }
 
-// sortByProfitability orders attestations by highest slot and by highest aggregation bit count.
-func (a proposerAtts) sortByProfitability() (proposerAtts, error) {
+// sortByProfitability orders attestations by highest slot and by highest count of aggregation bits bringing
+// new participation.
+func (a proposerAtts) sortByProfitability(known knownAttesters) (proposerAtts, error) {
 	if len(a) < 2 {
 		return a, nil
 	}
-	return a.sortByProfitabilityUsingMaxCover()
+	return a.sortByProfitabilityUsingMaxCover(known)
 }
 
-// sortByProfitabilityUsingMaxCover orders attestations by highest slot and by highest aggregation bit count.
-// Duplicate bits are counted only once, using max-cover algorithm.
-func (a proposerAtts) sortByProfitabilityUsingMaxCover() (proposerAtts, error) {
+// sortByProfitabilityUsingMaxCover orders attestations by highest slot and by highest count of aggregation bits
+// bringing new participation. Duplicate bits of a committee are counted only once, using max-cover algorithm.
+func (a proposerAtts) sortByProfitabilityUsingMaxCover(known knownAttesters) (proposerAtts, error) {
 	// Separate attestations by slot, as slot number takes higher precedence when sorting.
 	var slots []types.Slot
 	attsBySlot := map[types.Slot]proposerAtts{}
@@ -136,36 +146,57 @@ func (a proposerAtts) sortByProfitabilityUsingMaxCover() (proposerAtts, error) {
 		if len(atts) < 2 {
 			return atts, nil
 		}
-		candidates := make([]*bitfield.Bitlist64, len(atts))
-		for i := 0; i < len(atts); i++ {
-			var err error
-			candidates[i], err = atts[i].AggregationBits.ToBitlist64()
-			if err != nil {
-				return nil, err
+		// Separate attestations by committee, as the same bits of different committees stand for different validators.
+		var committees []types.CommitteeIndex
+		attsByCommittee := map[types.CommitteeIndex]proposerAtts{}
+		for _, att := range atts {
+			if _, ok := attsByCommittee[att.Data.CommitteeIndex]; !ok {
+				committees = append(committees, att.Data.CommitteeIndex)
 			}
+			attsByCommittee[att.Data.CommitteeIndex] = append(attsByCommittee[att.Data.CommitteeIndex], att)
 		}
+		sort.Slice(committees, func(i, j int) bool {
+			return committees[i] < committees[j]
+		})
+
 		// Add selected candidates on top, those that are not selected - append at bottom.
-		selectedKeys, _, err := aggregation.MaxCover(candidates, len(candidates), true /* allowOverlaps */)
-		if err == nil {
-			// Pick selected attestations first, leftover attestations will be appended at the end.
-			// Both lists will be sorted by number of bits set.
-			selectedAtts := make(proposerAtts, selectedKeys.Count())
-			leftoverAtts := make(proposerAtts, selectedKeys.Not().Count())
-			for i, key := range selectedKeys.BitIndices() {
-				selectedAtts[i] = atts[key]
+		// Both lists will be sorted by number of new bits set.
+		newBitsCount := make(map[*ethpb.Attestation]uint64, len(atts))
+		var selectedAtts, leftoverAtts proposerAtts
+		for _, c := range committees {
+			committeeAtts := attsByCommittee[c]
+			candidates := make([]*bitfield.Bitlist64, len(committeeAtts))
+			for i := 0; i < len(committeeAtts); i++ {
+				var err error
+				candidates[i], err = known.newBits(committeeAtts[i]).ToBitlist64()
+				if err != nil {
+					return nil, err
+				}
+				newBitsCount[committeeAtts[i]] = candidates[i].Count()
+			}
+			if len(committeeAtts) < 2 {
+				selectedAtts = append(selectedAtts, committeeAtts...)
+				continue
+			}
+			selectedKeys, _, err := aggregation.MaxCover(candidates, len(candidates), true /* allowOverlaps */)
+			if err != nil {
+				selectedAtts = append(selectedAtts, committeeAtts...)
+				continue
+			}
+			for _, key := range selectedKeys.BitIndices() {
+				selectedAtts = append(selectedAtts, committeeAtts[key])
 			}
-			for i, key := range selectedKeys.Not().BitIndices() {
-				leftoverAtts[i] = atts[key]
+			for _, key := range selectedKeys.Not().BitIndices() {
+				leftoverAtts = append(leftoverAtts, committeeAtts[key])
 			}
-			sort.Slice(selectedAtts, func(i, j int) bool {
-				return selectedAtts[i].AggregationBits.Count() > selectedAtts[j].AggregationBits.Count()
-			})
-			sort.Slice(leftoverAtts, func(i, j int) bool {
-				return leftoverAtts[i].AggregationBits.Count() > leftoverAtts[j].AggregationBits.Count()
-			})
-			return append(selectedAtts, leftoverAtts...), nil
 		}
-		return atts, nil
+		sort.Slice(selectedAtts, func(i, j int) bool {
+			return newBitsCount[selectedAtts[i]] > newBitsCount[selectedAtts[j]]
+		})
+		sort.Slice(leftoverAtts, func(i, j int) bool {
+			return newBitsCount[leftoverAtts[i]] > newBitsCount[leftoverAtts[j]]
+		})
+		return append(selectedAtts, leftoverAtts...), nil
 	}
 
 	// Select attestations. Slots are sorted from higher to lower at this point. Within slots attestations
@@ -30,7 +30,7 @@ func TestProposer_ProposerAtts_sortByProfitability(t *testing.T) {
 		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11100000}}),
 		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11000000}}),
 	})
-	atts, err := atts.sortByProfitability()
+	atts, err := atts.sortByProfitability(nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -54,7 +54,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 	t.Run("no atts", func(t *testing.T) {
 		atts := getAtts([]testData{})
 		want := getAtts([]testData{})
-		atts, err := atts.sortByProfitability()
+		atts, err := atts.sortByProfitability(nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -68,7 +68,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 		want := getAtts([]testData{
 			{4, bitfield.Bitlist{0b11100000, 0b1}},
 		})
-		atts, err := atts.sortByProfitability()
+		atts, err := atts.sortByProfitability(nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -84,7 +84,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 			{4, bitfield.Bitlist{0b11100000, 0b1}},
 			{1, bitfield.Bitlist{0b11000000, 0b1}},
 		})
-		atts, err := atts.sortByProfitability()
+		atts, err := atts.sortByProfitability(nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -102,7 +102,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 			{4, bitfield.Bitlist{0b11100000, 0b1}},
 			{1, bitfield.Bitlist{0b11000000, 0b1}},
 		})
-		atts, err := atts.sortByProfitability()
+		atts, err := atts.sortByProfitability(nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -123,7 +123,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 				{1, bitfield.Bitlist{0b00001100, 0b1}},
 				{1, bitfield.Bitlist{0b11001000, 0b1}},
 			})
-			atts, err := atts.sortByProfitability()
+			atts, err := atts.sortByProfitability(nil)
 			if err != nil {
 				t.Error(err)
 			}
@@ -148,7 +148,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 			{1, bitfield.Bitlist{0b11100000, 0b1}},
 			{1, bitfield.Bitlist{0b11000000, 0b1}},
 		})
-		atts, err := atts.sortByProfitability()
+		atts, err := atts.sortByProfitability(nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -180,7 +180,7 @@ func TestProposer_ProposerAtts_sortByProfitabilityUsingMaxCover(t *testing.T) {
 			{1, bitfield.Bitlist{0b11100000, 0b1}},
 			{1, bitfield.Bitlist{0b11000000, 0b1}},
 		})
-		atts, err := atts.sortByProfitability()
+		atts, err := atts.sortByProfitability(nil)
 		if err != nil {
 			t.Error(err)
 		}
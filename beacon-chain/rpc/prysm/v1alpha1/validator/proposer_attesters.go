@@ -0,0 +1,204 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/container/slice"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"go.opencensus.io/trace"
+)
+
+var wastedAttestationBits = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "proposer_wasted_attestation_bits",
+	Help:    "The number of aggregation bits of the attestations packed in a block which bring no new participation.",
+	Buckets: []float64{0, 1, 4, 16, 64, 256, 1024, 4096},
+})
+
+// attCommittee identifies the committee of an attestation.
+type attCommittee struct {
+	slot  types.Slot
+	index types.CommitteeIndex
+}
+
+func committeeOf(att *ethpb.Attestation) attCommittee {
+	return attCommittee{slot: att.Data.Slot, index: att.Data.CommitteeIndex}
+}
+
+// knownAttesters holds the bits of the committee members whose attestation brings no new participation to a
+// block, either because they are slashed or about to be slashed, or because their participation is already
+// recorded in the state.
+type knownAttesters map[attCommittee]bitfield.Bitlist
+
+// newBits returns the aggregation bits of the attestation which bring new participation to a block.
+func (k knownAttesters) newBits(att *ethpb.Attestation) bitfield.Bitlist {
+	known, ok := k[committeeOf(att)]
+	if !ok {
+		return att.AggregationBits
+	}
+	bits, err := att.AggregationBits.And(known.Not())
+	if err != nil {
+		return att.AggregationBits
+	}
+	return bits
+}
+
+// knownAttesters returns the known attesters of the committees of the given attestations. It must be called
+// before the attestations are processed on the state, as this records their participation.
+func (vs *Server) knownAttesters(ctx context.Context, st state.BeaconState, atts []*ethpb.Attestation) (knownAttesters, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.knownAttesters")
+	defer span.End()
+
+	equivocating := vs.equivocatingValidators(ctx, st)
+
+	// Attestations included on chain are recorded as pending attestations before Altair, and as participation
+	// flags after.
+	var included knownAttesters
+	var currentParticipation, previousParticipation []byte
+	var err error
+	if st.Version() == version.Phase0 {
+		included, err = includedAttesters(st)
+	} else {
+		currentParticipation, err = st.CurrentEpochParticipation()
+		if err == nil {
+			previousParticipation, err = st.PreviousEpochParticipation()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	currentEpoch := slots.ToEpoch(st.Slot())
+	known := make(knownAttesters)
+	for _, att := range atts {
+		c := committeeOf(att)
+		if _, ok := known[c]; ok {
+			continue
+		}
+		// Attestations older than the previous epoch cannot be included and are filtered out later on.
+		if slots.ToEpoch(c.slot)+1 < currentEpoch {
+			continue
+		}
+		committee, err := helpers.BeaconCommitteeFromState(ctx, st, c.slot, c.index)
+		if err != nil {
+			continue
+		}
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		if pending, ok := included[c]; ok && pending.Len() == bits.Len() {
+			copy(bits, pending)
+		}
+		participation := currentParticipation
+		if slots.ToEpoch(c.slot) < currentEpoch {
+			participation = previousParticipation
+		}
+		for i, idx := range committee {
+			if equivocating[idx] {
+				bits.SetBitAt(uint64(i), true)
+				continue
+			}
+			v, err := st.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				return nil, err
+			}
+			if v.Slashed() {
+				bits.SetBitAt(uint64(i), true)
+				continue
+			}
+			// A validator whose timely target flag is set earned most of the rewards an attestation gives.
+			if uint64(idx) < uint64(len(participation)) {
+				has, err := altair.HasValidatorFlag(participation[idx], params.BeaconConfig().TimelyTargetFlagIndex)
+				if err != nil {
+					return nil, err
+				}
+				if has {
+					bits.SetBitAt(uint64(i), true)
+				}
+			}
+		}
+		known[c] = bits
+	}
+	return known, nil
+}
+
+// equivocatingValidators returns the validators with a pending slashing in the pool.
+func (vs *Server) equivocatingValidators(ctx context.Context, st state.ReadOnlyBeaconState) map[types.ValidatorIndex]bool {
+	equivocating := make(map[types.ValidatorIndex]bool)
+	for _, s := range vs.SlashingsPool.PendingAttesterSlashings(ctx, st, true /*noLimit*/) {
+		for _, idx := range slice.IntersectionUint64(s.Attestation_1.AttestingIndices, s.Attestation_2.AttestingIndices) {
+			equivocating[types.ValidatorIndex(idx)] = true
+		}
+	}
+	for _, s := range vs.SlashingsPool.PendingProposerSlashings(ctx, st, true /*noLimit*/) {
+		equivocating[s.Header_1.Header.ProposerIndex] = true
+	}
+	return equivocating
+}
+
+// includedAttesters returns the committee members whose attestation is recorded in a phase 0 state.
+func includedAttesters(st state.ReadOnlyBeaconState) (knownAttesters, error) {
+	previous, err := st.PreviousEpochAttestations()
+	if err != nil {
+		return nil, err
+	}
+	current, err := st.CurrentEpochAttestations()
+	if err != nil {
+		return nil, err
+	}
+	included := make(knownAttesters)
+	for _, a := range append(previous, current...) {
+		c := attCommittee{slot: a.Data.Slot, index: a.Data.CommitteeIndex}
+		bits, ok := included[c]
+		if !ok {
+			included[c] = a.AggregationBits
+			continue
+		}
+		if merged, err := bits.Or(a.AggregationBits); err == nil {
+			included[c] = merged
+		}
+	}
+	return included, nil
+}
+
+// filterKnown removes the attestations which bring no new participation.
+func (a proposerAtts) filterKnown(known knownAttesters) proposerAtts {
+	filtered := make(proposerAtts, 0, len(a))
+	for _, att := range a {
+		if known.newBits(att).Count() > 0 {
+			filtered = append(filtered, att)
+		}
+	}
+	return filtered
+}
+
+// wastedBits returns the number of aggregation bits of the attestations which bring no new participation,
+// either because they are known or because an attestation before them has them too.
+func (a proposerAtts) wastedBits(known knownAttesters) uint64 {
+	seen := make(knownAttesters, len(known))
+	for c, bits := range known {
+		seen[c] = bits
+	}
+	var wasted uint64
+	for _, att := range a {
+		c := committeeOf(att)
+		newBits := seen.newBits(att)
+		wasted += att.AggregationBits.Count() - newBits.Count()
+		bits, ok := seen[c]
+		if !ok {
+			seen[c] = att.AggregationBits
+			continue
+		}
+		if merged, err := bits.Or(att.AggregationBits); err == nil {
+			seen[c] = merged
+		}
+	}
+	return wasted
+}
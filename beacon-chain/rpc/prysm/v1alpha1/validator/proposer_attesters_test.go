@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	slashingsmock "github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings/mock"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestServer_knownAttesters(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 256)
+	require.NoError(t, st.SetSlot(2))
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, true, len(committee) > 4)
+
+	participation := make([]byte, 256)
+	participation[committee[0]] = 1 << params.BeaconConfig().TimelyTargetFlagIndex
+	participation[committee[4]] = 1 << params.BeaconConfig().TimelySourceFlagIndex
+	require.NoError(t, st.SetCurrentParticipationBits(participation))
+	v, err := st.ValidatorAtIndex(committee[1])
+	require.NoError(t, err)
+	v.Slashed = true
+	require.NoError(t, st.UpdateValidatorAtIndex(committee[1], v))
+
+	vs := &Server{SlashingsPool: &slashingsmock.PoolMock{
+		PendingAttSlashings: []*ethpb.AttesterSlashing{{
+			Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{uint64(committee[2]), uint64(committee[4])}},
+			Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{uint64(committee[2])}},
+		}},
+		PendingPropSlashings: []*ethpb.ProposerSlashing{{
+			Header_1: &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: committee[3]}},
+		}},
+	}}
+	att := util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}})
+	known, err := vs.knownAttesters(ctx, st, []*ethpb.Attestation{att})
+	require.NoError(t, err)
+
+	bits, ok := known[committeeOf(att)]
+	require.Equal(t, true, ok)
+	require.Equal(t, uint64(len(committee)), bits.Len())
+	assert.DeepEqual(t, []int{0, 1, 2, 3}, bits.BitIndices())
+}
+
+func TestServer_knownAttesters_Phase0(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 256)
+	require.NoError(t, st.SetSlot(2))
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 1, 0)
+	require.NoError(t, err)
+
+	included := bitfield.NewBitlist(uint64(len(committee)))
+	included.SetBitAt(2, true)
+	require.NoError(t, st.AppendCurrentEpochAttestations(&ethpb.PendingAttestation{
+		Data:            util.HydrateAttestationData(&ethpb.AttestationData{Slot: 1}),
+		AggregationBits: included,
+	}))
+
+	vs := &Server{SlashingsPool: &slashingsmock.PoolMock{}}
+	att := util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}})
+	known, err := vs.knownAttesters(ctx, st, []*ethpb.Attestation{att})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []int{2}, known[committeeOf(att)].BitIndices())
+}
+
+func TestProposer_ProposerAtts_filterKnown(t *testing.T) {
+	atts := proposerAtts([]*ethpb.Attestation{
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11000000, 0b1}}),
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b00000011, 0b1}}),
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 2}, AggregationBits: bitfield.Bitlist{0b00000011, 0b1}}),
+	})
+	known := knownAttesters{
+		{slot: 1}: bitfield.Bitlist{0b11000000, 0b1},
+	}
+	assert.DeepEqual(t, atts[1:], atts.filterKnown(known))
+	assert.DeepEqual(t, atts, atts.filterKnown(nil))
+}
+
+func TestProposer_ProposerAtts_wastedBits(t *testing.T) {
+	atts := proposerAtts([]*ethpb.Attestation{
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11000011, 0b1}}),
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b00000110, 0b1}}),
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1, CommitteeIndex: 1}, AggregationBits: bitfield.Bitlist{0b00000011, 0b1}}),
+	})
+	assert.Equal(t, uint64(1), atts.wastedBits(nil))
+	known := knownAttesters{
+		{slot: 1}: bitfield.Bitlist{0b10000000, 0b1},
+	}
+	assert.Equal(t, uint64(2), atts.wastedBits(known))
+}
+
+func TestProposer_ProposerAtts_sortByProfitability_KnownAttesters(t *testing.T) {
+	atts := proposerAtts([]*ethpb.Attestation{
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11110000, 0b1}}),
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b00000111, 0b1}}),
+		util.HydrateAttestation(&ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1, CommitteeIndex: 1}, AggregationBits: bitfield.Bitlist{0b11110000, 0b1}}),
+	})
+	// The known bits leave one new bit to the first attestation, so that the others come first.
+	known := knownAttesters{
+		{slot: 1}: bitfield.Bitlist{0b11100000, 0b1},
+	}
+	want := proposerAtts{atts[2], atts[1], atts[0]}
+	sorted, err := atts.sortByProfitability(known)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, sorted)
+}
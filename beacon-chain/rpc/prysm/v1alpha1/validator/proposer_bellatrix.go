@@ -43,7 +43,8 @@ func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockR
 	}
 
 	registered, err := vs.validatorRegistered(ctx, altairBlk.ProposerIndex)
-	if registered && err == nil {
+	// Builders build on the payload of the head block, so blocks reorging the head are built locally.
+	if registered && err == nil && !vs.isReorgingHead(ctx, bytesutil.ToBytes32(altairBlk.ParentRoot)) {
 		builderReady, b, err := vs.getAndBuildBlindBlock(ctx, altairBlk)
 		if err != nil {
 			// In the event of an error, the node should fall back to default execution engine for building block.
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/container/trie"
@@ -44,6 +46,7 @@ func (vs *Server) packDepositsAndAttestations(ctx context.Context, head state.Be
 		if err != nil {
 			return status.Errorf(codes.Internal, "Could not get attestations to pack into block: %v", err)
 		}
+		vs.SlotTimelineCache.Record(head.Slot(), cache.AttestationsPacked, time.Now())
 		// if the original context is cancelled, then cancel this routine too
 		select {
 		case <-egctx.Done():
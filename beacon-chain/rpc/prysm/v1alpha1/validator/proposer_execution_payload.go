@@ -48,7 +48,7 @@ func (vs *Server) getExecutionPayload(ctx context.Context, slot types.Slot, vIdx
 		return vs.ExecutionEngineCaller.GetPayload(ctx, pid)
 	}
 
-	st, err := vs.HeadFetcher.HeadState(ctx)
+	st, err := vs.proposalParentState(ctx, headRoot)
 	if err != nil {
 		return nil, err
 	}
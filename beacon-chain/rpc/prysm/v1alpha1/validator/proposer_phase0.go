@@ -15,20 +15,22 @@ import (
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"go.opencensus.io/trace"
 )
 
 // blockData required to create a beacon block.
 type blockData struct {
-	ParentRoot        []byte
-	Graffiti          [32]byte
-	ProposerIdx       types.ValidatorIndex
-	Eth1Data          *ethpb.Eth1Data
-	Deposits          []*ethpb.Deposit
-	Attestations      []*ethpb.Attestation
-	ProposerSlashings []*ethpb.ProposerSlashing
-	AttesterSlashings []*ethpb.AttesterSlashing
-	VoluntaryExits    []*ethpb.SignedVoluntaryExit
+	ParentRoot            []byte
+	Graffiti              [32]byte
+	ProposerIdx           types.ValidatorIndex
+	Eth1Data              *ethpb.Eth1Data
+	Deposits              []*ethpb.Deposit
+	Attestations          []*ethpb.Attestation
+	ProposerSlashings     []*ethpb.ProposerSlashing
+	AttesterSlashings     []*ethpb.AttesterSlashing
+	VoluntaryExits        []*ethpb.SignedVoluntaryExit
+	BLSToExecutionChanges []*ethpb.SignedBLSToExecutionChange
 }
 
 func (vs *Server) getPhase0BeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.BeaconBlock, error) {
@@ -86,13 +88,17 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 		log.WithError(err).Error("Could not process attestations and update head")
 	}
 
-	// Retrieve the parent block as the current head of the canonical chain.
+	// Retrieve the parent block as the current head of the canonical chain, or its parent
+	// when the head is a late block to reorg.
 	parentRoot, err := vs.HeadFetcher.HeadRoot(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve head root: %v", err)
 	}
+	if proposerHead, ok := vs.proposerReorgHead(parentRoot); ok {
+		parentRoot = proposerHead[:]
+	}
 
-	head, err := vs.HeadFetcher.HeadState(ctx)
+	head, err := vs.proposalParentState(ctx, bytesutil.ToBytes32(parentRoot))
 	if err != nil {
 		return nil, fmt.Errorf("could not get head state %v", err)
 	}
@@ -156,15 +162,30 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 		validExits = append(validExits, exit)
 	}
 
+	// BLS to execution changes can only be included in blocks from the Capella fork on.
+	var validChanges []*ethpb.SignedBLSToExecutionChange
+	if slots.ToEpoch(req.Slot) >= params.BeaconConfig().CapellaForkEpoch {
+		changes := vs.BLSToExecPool.BLSToExecChangesForInclusion(head)
+		validChanges = make([]*ethpb.SignedBLSToExecutionChange, 0, len(changes))
+		for _, change := range changes {
+			if _, err := blocks.ValidateBLSToExecutionChange(head, change); err != nil {
+				log.WithError(err).Warn("Proposer: invalid BLS to execution change")
+				continue
+			}
+			validChanges = append(validChanges, change)
+		}
+	}
+
 	return &blockData{
-		ParentRoot:        parentRoot,
-		Graffiti:          graffiti,
-		ProposerIdx:       idx,
-		Eth1Data:          eth1Data,
-		Deposits:          deposits,
-		Attestations:      atts,
-		ProposerSlashings: validProposerSlashings,
-		AttesterSlashings: validAttSlashings,
-		VoluntaryExits:    validExits,
+		ParentRoot:            parentRoot,
+		Graffiti:              graffiti,
+		ProposerIdx:           idx,
+		Eth1Data:              eth1Data,
+		Deposits:              deposits,
+		Attestations:          atts,
+		ProposerSlashings:     validProposerSlashings,
+		AttesterSlashings:     validAttSlashings,
+		VoluntaryExits:        validExits,
+		BLSToExecutionChanges: validChanges,
 	}, nil
 }
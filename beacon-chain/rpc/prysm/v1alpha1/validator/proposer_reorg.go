@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+// proposerReorgHead returns the root of the parent of the head block if proposer reorgs are
+// enabled and fork choice decides that the head block, which arrived late and is weakly attested,
+// should be reorged by the block being proposed.
+func (vs *Server) proposerReorgHead(headRoot []byte) ([32]byte, bool) {
+	if !features.Get().EnableProposerReorgs {
+		return [32]byte{}, false
+	}
+	proposerHead := vs.ForkFetcher.ForkChoicer().GetProposerHead()
+	if proposerHead == [32]byte{} || bytes.Equal(proposerHead[:], headRoot) {
+		return [32]byte{}, false
+	}
+	log.WithFields(logrus.Fields{
+		"headRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(headRoot)),
+		"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(proposerHead[:])),
+	}).Info("Proposing on the parent of a late head block")
+	return proposerHead, true
+}
+
+// isReorgingHead returns true if a block proposed on the given parent root reorgs the head block.
+func (vs *Server) isReorgingHead(ctx context.Context, parentRoot [32]byte) bool {
+	if !features.Get().EnableProposerReorgs {
+		return false
+	}
+	headRoot, err := vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return false
+	}
+	return !bytes.Equal(headRoot, parentRoot[:])
+}
+
+// proposalParentState returns the state of the block with the given root, on which a block is
+// proposed. This is the head state, unless the proposed block reorgs the head block.
+func (vs *Server) proposalParentState(ctx context.Context, parentRoot [32]byte) (state.BeaconState, error) {
+	if !vs.isReorgingHead(ctx, parentRoot) {
+		return vs.HeadFetcher.HeadState(ctx)
+	}
+	st, err := vs.StateGen.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get state of block %#x", bytesutil.Trunc(parentRoot[:]))
+	}
+	return st, nil
+}
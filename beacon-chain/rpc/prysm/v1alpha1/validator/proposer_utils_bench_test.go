@@ -49,7 +49,7 @@ func BenchmarkProposerAtts_sortByProfitability(b *testing.B) {
 		for i, att := range atts {
 			attsCopy[i] = ethpb.CopyAttestation(att)
 		}
-		_, err := attsCopy.sortByProfitability()
+		_, err := attsCopy.sortByProfitability(nil)
 		require.NoError(b, err, "Could not sort attestations by profitability")
 	}
 
@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// maxInclusionQueries is the maximum number of attestations queried in a single request.
+const maxInclusionQueries = 1024
+
+// inclusionQuery is a parsed attestation of an inclusion request.
+type inclusionQuery struct {
+	slot           types.Slot
+	committeeIndex types.CommitteeIndex
+	dataRoot       [32]byte
+	bits           bitfield.Bitlist
+}
+
+// blockAttestation is an attestation included in a canonical block.
+type blockAttestation struct {
+	blockSlot types.Slot
+	att       *ethpb.Attestation
+	dataRoot  [32]byte
+}
+
+// GetAttestationInclusion reports, for each queried attestation, whether it was included in a canonical
+// block and whether it is in the attestation pool of the node. An attestation counts as included or pooled
+// when an attestation with the same data has all its aggregation bits set. Only the blocks of the last two
+// epochs are searched.
+func (s *Server) GetAttestationInclusion(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetAttestationInclusion")
+	defer span.End()
+
+	req := &AttestationInclusionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not decode request body").Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if len(req.Attestations) == 0 || len(req.Attestations) > maxInclusionQueries {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("between 1 and %d attestations must be provided", maxInclusionQueries),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	queries := make([]*inclusionQuery, len(req.Attestations))
+	for i, a := range req.Attestations {
+		q, err := parseInclusionQuery(a)
+		if err != nil {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "invalid attestation at index %d", i).Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		queries[i] = q
+	}
+
+	earliest := queries[0].slot
+	for _, q := range queries[1:] {
+		if q.slot < earliest {
+			earliest = q.slot
+		}
+	}
+	blockAtts, err := s.canonicalBlockAttestations(ctx, earliest)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get canonical block attestations").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	statuses := make([]*AttestationInclusionStatus, len(queries))
+	for i, q := range queries {
+		status := &AttestationInclusionStatus{}
+		// Block attestations are ordered by decreasing block slot, the last match is the first inclusion.
+		for _, ba := range blockAtts {
+			if q.matches(ba.att, ba.dataRoot) {
+				status.Included = true
+				status.InclusionSlot = strconv.FormatUint(uint64(ba.blockSlot), 10)
+			}
+		}
+		if s.AttestationsPool != nil {
+			pooled := append(
+				s.AttestationsPool.UnaggregatedAttestationsBySlotIndex(ctx, q.slot, q.committeeIndex),
+				s.AttestationsPool.AggregatedAttestationsBySlotIndex(ctx, q.slot, q.committeeIndex)...,
+			)
+			for _, att := range pooled {
+				root, err := att.Data.HashTreeRoot()
+				if err == nil && q.matches(att, root) {
+					status.InPool = true
+					break
+				}
+			}
+		}
+		statuses[i] = status
+	}
+	network.WriteJson(w, &AttestationInclusionResponse{Data: statuses})
+}
+
+// canonicalBlockAttestations returns the attestations of the canonical blocks after the given slot, walking
+// back from the head block for at most two epochs.
+func (s *Server) canonicalBlockAttestations(ctx context.Context, slot types.Slot) ([]*blockAttestation, error) {
+	blk, err := s.HeadFetcher.HeadBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, errors.New("nil head block")
+	}
+	lookback := 2 * params.BeaconConfig().SlotsPerEpoch
+	if headSlot := blk.Block().Slot(); headSlot > lookback && slot < headSlot-lookback {
+		slot = headSlot - lookback
+	}
+	var atts []*blockAttestation
+	for blk != nil && !blk.IsNil() && blk.Block().Slot() > slot {
+		for _, att := range blk.Block().Body().Attestations() {
+			if att == nil || att.Data == nil {
+				continue
+			}
+			root, err := att.Data.HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not hash attestation data")
+			}
+			atts = append(atts, &blockAttestation{blockSlot: blk.Block().Slot(), att: att, dataRoot: root})
+		}
+		if s.BeaconDB == nil {
+			break
+		}
+		blk, err = s.BeaconDB.Block(ctx, bytesutil.ToBytes32(blk.Block().ParentRoot()))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get parent block")
+		}
+	}
+	return atts, nil
+}
+
+func parseInclusionQuery(a *AttestationInclusionQuery) (*inclusionQuery, error) {
+	if a == nil {
+		return nil, errors.New("nil attestation")
+	}
+	slot, err := strconv.ParseUint(a.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse slot %s", a.Slot)
+	}
+	committeeIndex, err := strconv.ParseUint(a.CommitteeIndex, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse committee index %s", a.CommitteeIndex)
+	}
+	root, err := hexutil.Decode(a.DataRoot)
+	if err != nil || len(root) != 32 {
+		return nil, errors.Errorf("invalid data root %s", a.DataRoot)
+	}
+	bits, err := hexutil.Decode(a.AggregationBits)
+	if err != nil || len(bits) == 0 || bits[len(bits)-1] == 0 {
+		return nil, errors.Errorf("invalid aggregation bits %s", a.AggregationBits)
+	}
+	return &inclusionQuery{
+		slot:           types.Slot(slot),
+		committeeIndex: types.CommitteeIndex(committeeIndex),
+		dataRoot:       bytesutil.ToBytes32(root),
+		bits:           bits,
+	}, nil
+}
+
+// matches returns true if the attestation has the data of the query and all the bits of the query set.
+func (q *inclusionQuery) matches(att *ethpb.Attestation, dataRoot [32]byte) bool {
+	if att.Data.Slot != q.slot || att.Data.CommitteeIndex != q.committeeIndex || dataRoot != q.dataRoot {
+		return false
+	}
+	contains, err := att.AggregationBits.Contains(q.bits)
+	return err == nil && contains
+}
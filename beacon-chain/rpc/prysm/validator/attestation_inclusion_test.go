@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestGetAttestationInclusion(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+
+	data := util.HydrateAttestationData(&ethpb.AttestationData{Slot: 9, CommitteeIndex: 1})
+	dataRoot, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	includedBits := bitfield.NewBitlist(4)
+	includedBits.SetBitAt(0, true)
+	includedBits.SetBitAt(2, true)
+	parent := util.NewBeaconBlock()
+	parent.Block.Slot = 10
+	parent.Block.Body.Attestations = []*ethpb.Attestation{util.HydrateAttestation(&ethpb.Attestation{Data: data, AggregationBits: includedBits})}
+	wsbParent := util.SaveBlock(t, ctx, beaconDB, parent)
+	parentRoot, err := wsbParent.Block().HashTreeRoot()
+	require.NoError(t, err)
+	head := util.NewBeaconBlock()
+	head.Block.Slot = 12
+	head.Block.ParentRoot = parentRoot[:]
+	wsbHead := util.SaveBlock(t, ctx, beaconDB, head)
+
+	pool := attestations.NewPool()
+	pooledBits := bitfield.NewBitlist(4)
+	pooledBits.SetBitAt(1, true)
+	require.NoError(t, pool.SaveUnaggregatedAttestation(util.HydrateAttestation(&ethpb.Attestation{Data: data, AggregationBits: pooledBits})))
+
+	s := &Server{
+		HeadFetcher:      &mock.ChainService{Block: wsbHead},
+		BeaconDB:         beaconDB,
+		AttestationsPool: pool,
+	}
+	query := func(bit uint64, root [32]byte) *AttestationInclusionQuery {
+		bits := bitfield.NewBitlist(4)
+		bits.SetBitAt(bit, true)
+		return &AttestationInclusionQuery{
+			Slot:            "9",
+			CommitteeIndex:  "1",
+			DataRoot:        hexutil.Encode(root[:]),
+			AggregationBits: hexutil.Encode(bits),
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		body, err := json.Marshal(&AttestationInclusionRequest{Attestations: []*AttestationInclusionQuery{
+			query(0, dataRoot),
+			query(1, dataRoot),
+			query(0, [32]byte{'x'}),
+		}})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/attestation_inclusion", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.GetAttestationInclusion(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationInclusionResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		assert.DeepEqual(t, &AttestationInclusionStatus{Included: true, InclusionSlot: "10"}, resp.Data[0])
+		assert.DeepEqual(t, &AttestationInclusionStatus{InPool: true}, resp.Data[1])
+		assert.DeepEqual(t, &AttestationInclusionStatus{}, resp.Data[2])
+	})
+	t.Run("invalid bits", func(t *testing.T) {
+		q := query(0, dataRoot)
+		q.AggregationBits = "0x00"
+		body, err := json.Marshal(&AttestationInclusionRequest{Attestations: []*AttestationInclusionQuery{q}})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/attestation_inclusion", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.GetAttestationInclusion(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, true, strings.Contains(e.Message, "invalid aggregation bits"))
+	})
+	t.Run("no attestations", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/validators/attestation_inclusion", bytes.NewReader([]byte(`{"attestations":[]}`)))
+		writer := httptest.NewRecorder()
+		s.GetAttestationInclusion(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
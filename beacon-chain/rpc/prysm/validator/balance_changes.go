@@ -0,0 +1,210 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/pagination"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// GetBalanceChanges returns the balance of validators at the start of two epochs and the change
+// between them, in Gwei. The states are read from the database and regenerated by replaying the
+// blocks when needed, so clients don't have to download both full states. The validators are
+// filtered by the optional index query parameters, given as repeated parameters or as a comma
+// separated list, and paginated with the page_token and page_size query parameters.
+func (s *Server) GetBalanceChanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	startEpoch, err := parseEpoch(query.Get("start_epoch"))
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not parse start_epoch").Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	endEpoch, err := parseEpoch(query.Get("end_epoch"))
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not parse end_epoch").Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if startEpoch >= endEpoch {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("start_epoch %d must be lower than end_epoch %d", startEpoch, endEpoch),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if headEpoch := slots.ToEpoch(s.HeadFetcher.HeadSlot()); endEpoch > headEpoch {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("end_epoch %d is after the head epoch %d", endEpoch, headEpoch),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	indices, err := parseIndices(query["index"])
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	pageSize := 0
+	if raw := query.Get("page_size"); raw != "" {
+		pageSize, err = strconv.Atoi(raw)
+		if err != nil || pageSize <= 0 || pageSize > cmd.Get().MaxRPCPageSize {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("page_size %s is not a number between 1 and %d", raw, cmd.Get().MaxRPCPageSize),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	endState, err := s.epochState(r.Context(), endEpoch)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state of epoch %d", endEpoch).Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if len(indices) == 0 {
+		indices = make([]types.ValidatorIndex, endState.NumValidators())
+		for i := range indices {
+			indices[i] = types.ValidatorIndex(i)
+		}
+	} else if last := indices[len(indices)-1]; uint64(last) >= uint64(endState.NumValidators()) {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrapf(errInvalidValidatorIndex, "%d is out of range", last).Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	resp := &BalanceChangesResponse{
+		StartEpoch: strconv.FormatUint(uint64(startEpoch), 10),
+		EndEpoch:   strconv.FormatUint(uint64(endEpoch), 10),
+		Data:       make([]*BalanceChange, 0),
+		TotalSize:  strconv.Itoa(len(indices)),
+	}
+	if len(indices) == 0 {
+		network.WriteJson(w, resp)
+		return
+	}
+	start, end, nextPageToken, err := pagination.StartAndEndPage(query.Get("page_token"), pageSize, len(indices))
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not paginate results").Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	resp.NextPageToken = nextPageToken
+	indices = indices[start:end]
+
+	startState, err := s.epochState(r.Context(), startEpoch)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state of epoch %d", startEpoch).Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	changes, err := balanceChanges(startState, endState, indices)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not compute balance changes").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	resp.Data = changes
+	network.WriteJson(w, resp)
+}
+
+// epochState returns the state at the start slot of the epoch.
+func (s *Server) epochState(ctx context.Context, epoch types.Epoch) (state.BeaconState, error) {
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	return s.StateFetcher.StateBySlot(ctx, slot)
+}
+
+// balanceChanges returns the balance changes of the validators between the states. Validators which
+// were not yet in the start state have a start balance of 0.
+func balanceChanges(startState, endState state.ReadOnlyBeaconState, indices []types.ValidatorIndex) ([]*BalanceChange, error) {
+	changes := make([]*BalanceChange, len(indices))
+	for i, index := range indices {
+		endBalance, err := endState.BalanceAtIndex(index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get balance of validator %d", index)
+		}
+		var startBalance uint64
+		if uint64(index) < uint64(startState.NumValidators()) {
+			startBalance, err = startState.BalanceAtIndex(index)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get balance of validator %d", index)
+			}
+		}
+		delta := strconv.FormatUint(endBalance-startBalance, 10)
+		if endBalance < startBalance {
+			delta = "-" + strconv.FormatUint(startBalance-endBalance, 10)
+		}
+		changes[i] = &BalanceChange{
+			ValidatorIndex: strconv.FormatUint(uint64(index), 10),
+			StartBalance:   strconv.FormatUint(startBalance, 10),
+			EndBalance:     strconv.FormatUint(endBalance, 10),
+			Delta:          delta,
+		}
+	}
+	return changes, nil
+}
+
+func parseEpoch(raw string) (types.Epoch, error) {
+	if raw == "" {
+		return 0, errors.New("epoch is required")
+	}
+	epoch, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return types.Epoch(epoch), nil
+}
+
+// parseIndices returns the sorted and deduplicated validator indices of the query values, each
+// value being an index or a comma separated list of indices.
+func parseIndices(values []string) ([]types.ValidatorIndex, error) {
+	seen := make(map[types.ValidatorIndex]bool)
+	var indices []types.ValidatorIndex
+	for _, value := range values {
+		for _, raw := range strings.Split(value, ",") {
+			index, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse validator index %s", raw)
+			}
+			if seen[types.ValidatorIndex(index)] {
+				continue
+			}
+			seen[types.ValidatorIndex(index)] = true
+			indices = append(indices, types.ValidatorIndex(index))
+		}
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+	return indices, nil
+}
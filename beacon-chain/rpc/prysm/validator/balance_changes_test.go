@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+// slotStateFetcher returns the states by slot.
+type slotStateFetcher struct {
+	states map[types.Slot]state.BeaconState
+}
+
+func (*slotStateFetcher) State(context.Context, []byte) (state.BeaconState, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (*slotStateFetcher) StateRoot(context.Context, []byte) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *slotStateFetcher) StateBySlot(_ context.Context, slot types.Slot) (state.BeaconState, error) {
+	st, ok := f.states[slot]
+	if !ok {
+		return nil, errors.Errorf("no state at slot %d", slot)
+	}
+	return st, nil
+}
+
+func TestGetBalanceChanges(t *testing.T) {
+	newState := func(slot types.Slot, balances []uint64) state.BeaconState {
+		st, err := util.NewBeaconState(func(s *ethpb.BeaconState) error {
+			s.Slot = slot
+			s.Validators = make([]*ethpb.Validator, len(balances))
+			for i := range s.Validators {
+				s.Validators[i] = &ethpb.Validator{}
+			}
+			s.Balances = balances
+			return nil
+		})
+		require.NoError(t, err)
+		return st
+	}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	startState := newState(slotsPerEpoch, []uint64{32, 32, 40})
+	endState := newState(3*slotsPerEpoch, []uint64{33, 31, 40, 16})
+	s := &Server{
+		HeadFetcher: &mock.ChainService{State: endState},
+		StateFetcher: &slotStateFetcher{states: map[types.Slot]state.BeaconState{
+			slotsPerEpoch:     startState,
+			3 * slotsPerEpoch: endState,
+		}},
+	}
+
+	getChanges := func(t *testing.T, query string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/balance_changes?"+query, nil)
+		writer := httptest.NewRecorder()
+		s.GetBalanceChanges(writer, request)
+		return writer
+	}
+
+	t.Run("all validators", func(t *testing.T) {
+		writer := getChanges(t, "start_epoch=1&end_epoch=3")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &BalanceChangesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "4", resp.TotalSize)
+		assert.Equal(t, "", resp.NextPageToken)
+		require.Equal(t, 4, len(resp.Data))
+		assert.DeepEqual(t, &BalanceChange{ValidatorIndex: "0", StartBalance: "32", EndBalance: "33", Delta: "1"}, resp.Data[0])
+		assert.DeepEqual(t, &BalanceChange{ValidatorIndex: "1", StartBalance: "32", EndBalance: "31", Delta: "-1"}, resp.Data[1])
+		assert.DeepEqual(t, &BalanceChange{ValidatorIndex: "2", StartBalance: "40", EndBalance: "40", Delta: "0"}, resp.Data[2])
+		assert.DeepEqual(t, &BalanceChange{ValidatorIndex: "3", StartBalance: "0", EndBalance: "16", Delta: "16"}, resp.Data[3])
+	})
+	t.Run("filtered and paginated", func(t *testing.T) {
+		writer := getChanges(t, "start_epoch=1&end_epoch=3&index=3,1&index=0&index=1&page_size=2")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &BalanceChangesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "3", resp.TotalSize)
+		assert.Equal(t, "1", resp.NextPageToken)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].ValidatorIndex)
+		assert.Equal(t, "1", resp.Data[1].ValidatorIndex)
+
+		writer = getChanges(t, "start_epoch=1&end_epoch=3&index=3,1&index=0&page_size=2&page_token=1")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &BalanceChangesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "", resp.NextPageToken)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "3", resp.Data[0].ValidatorIndex)
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		for query, msg := range map[string]string{
+			"end_epoch=3":                           "could not parse start_epoch",
+			"start_epoch=3&end_epoch=1":             "start_epoch 3 must be lower than end_epoch 1",
+			"start_epoch=1&end_epoch=4":             "end_epoch 4 is after the head epoch 3",
+			"start_epoch=1&end_epoch=3&index=a":     "could not parse validator index a",
+			"start_epoch=1&end_epoch=3&index=4":     "4 is out of range",
+			"start_epoch=1&end_epoch=3&page_size=0": "page_size 0 is not a number",
+		} {
+			writer := getChanges(t, query)
+			require.Equal(t, http.StatusBadRequest, writer.Code, query)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.Equal(t, true, strings.Contains(e.Message, msg), e.Message)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+var errInvalidValidatorIndex = errors.New("invalid validator index")
+
+// EstimateSyncCommitteeRewards estimates the sync committee rewards of a validator for the upcoming
+// sync committee period, based on the current balances in the head state. The optional
+// participation_rate query parameter, a number between 0 and 1, is the share of sync committee
+// duties the validator is expected to fulfill. It defaults to 1.
+func (s *Server) EstimateSyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rawIndex := mux.Vars(r)["validator_index"]
+	index, err := strconv.ParseUint(rawIndex, 10, 64)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not parse validator index %s", rawIndex).Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	participationRate := 1.0
+	if rawRate := r.URL.Query().Get("participation_rate"); rawRate != "" {
+		participationRate, err = strconv.ParseFloat(rawRate, 64)
+		if err != nil || participationRate < 0 || participationRate > 1 {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("participation rate %s must be a number between 0 and 1", rawRate),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	st, err := s.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get head state").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	if st.Version() < version.Altair {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "sync committees are not available before the Altair fork",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	estimate, err := estimateSyncCommitteeRewards(st, types.ValidatorIndex(index), participationRate)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, errInvalidValidatorIndex) {
+			code = http.StatusBadRequest
+		}
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not estimate sync committee rewards").Error(),
+			Code:    code,
+		})
+		return
+	}
+	network.WriteJson(w, &SyncCommitteeRewardEstimateResponse{Data: estimate})
+}
+
+// estimateSyncCommitteeRewards computes the rewards a validator is expected to earn during the sync committee
+// period following the one of the given state. A validator may hold several positions in the same committee,
+// each of which is rewarded or penalized independently every slot.
+func estimateSyncCommitteeRewards(
+	st state.BeaconState,
+	index types.ValidatorIndex,
+	participationRate float64,
+) (*SyncCommitteeRewardEstimate, error) {
+	if uint64(index) >= uint64(st.NumValidators()) {
+		return nil, errors.Wrapf(errInvalidValidatorIndex, "%d is out of range", index)
+	}
+	cfg := params.BeaconConfig()
+	currentEpoch := slots.ToEpoch(st.Slot())
+	period := slots.SyncCommitteePeriod(currentEpoch) + 1
+	startEpoch := types.Epoch(period) * cfg.EpochsPerSyncCommitteePeriod
+	endEpoch := startEpoch + cfg.EpochsPerSyncCommitteePeriod - 1
+
+	committee, err := st.NextSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get next sync committee")
+	}
+	pubkey := st.PubkeyAtIndex(index)
+	var positions uint64
+	for _, pk := range committee.Pubkeys {
+		if bytes.Equal(pk, pubkey[:]) {
+			positions++
+		}
+	}
+
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync reward")
+	}
+
+	maxReward := positions * uint64(cfg.EpochsPerSyncCommitteePeriod) * uint64(cfg.SlotsPerEpoch) * participantReward
+	expectedReward := uint64(math.Round(float64(maxReward) * participationRate))
+	expectedPenalty := maxReward - expectedReward
+	return &SyncCommitteeRewardEstimate{
+		ValidatorIndex:     strconv.FormatUint(uint64(index), 10),
+		Period:             strconv.FormatUint(period, 10),
+		StartEpoch:         strconv.FormatUint(uint64(startEpoch), 10),
+		EndEpoch:           strconv.FormatUint(uint64(endEpoch), 10),
+		InSyncCommittee:    positions > 0,
+		CommitteePositions: strconv.FormatUint(positions, 10),
+		ParticipationRate:  strconv.FormatFloat(participationRate, 'f', -1, 64),
+		RewardPerSlot:      strconv.FormatUint(positions*participantReward, 10),
+		ExpectedReward:     strconv.FormatUint(expectedReward, 10),
+		ExpectedPenalty:    strconv.FormatUint(expectedPenalty, 10),
+		ExpectedNetReward:  strconv.FormatInt(int64(expectedReward)-int64(expectedPenalty), 10),
+	}, nil
+}
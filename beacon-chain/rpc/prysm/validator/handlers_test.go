@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func syncCommitteeTestState(t *testing.T, numValidators int) state.BeaconState {
+	cfg := params.BeaconConfig()
+	validators := make([]*ethpb.Validator, numValidators)
+	balances := make([]uint64, numValidators)
+	for i := range validators {
+		pubkey := make([]byte, fieldparams.BLSPubkeyLength)
+		pubkey[0] = byte(i)
+		validators[i] = &ethpb.Validator{
+			PublicKey:             pubkey,
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      cfg.MaxEffectiveBalance,
+			ExitEpoch:             cfg.FarFutureEpoch,
+			WithdrawableEpoch:     cfg.FarFutureEpoch,
+		}
+		balances[i] = cfg.MaxEffectiveBalance
+	}
+	// Validator 1 holds two positions in the next sync committee, validator 2 holds none.
+	committee := make([][]byte, cfg.SyncCommitteeSize)
+	for i := range committee {
+		committee[i] = validators[0].PublicKey
+	}
+	committee[0] = validators[1].PublicKey
+	committee[1] = validators[1].PublicKey
+	st, err := util.NewBeaconStateAltair(func(s *ethpb.BeaconStateAltair) error {
+		s.Validators = validators
+		s.Balances = balances
+		s.NextSyncCommittee = &ethpb.SyncCommittee{
+			Pubkeys:         committee,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestEstimateSyncCommitteeRewards(t *testing.T) {
+	st := syncCommitteeTestState(t, 4)
+	s := &Server{HeadFetcher: &mock.ChainService{State: st}}
+	_, participantReward, err := altair.SyncRewards(4 * params.BeaconConfig().MaxEffectiveBalance)
+	require.NoError(t, err)
+	slotsInPeriod := uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * uint64(params.BeaconConfig().SlotsPerEpoch)
+
+	t.Run("member of next committee", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/1/sync_committee_rewards/estimate", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		s.EstimateSyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardEstimateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, "1", resp.Data.Period)
+		assert.Equal(t, true, resp.Data.InSyncCommittee)
+		assert.Equal(t, "2", resp.Data.CommitteePositions)
+		assert.Equal(t, strconv.FormatUint(2*slotsInPeriod*participantReward, 10), resp.Data.ExpectedReward)
+		assert.Equal(t, "0", resp.Data.ExpectedPenalty)
+	})
+	t.Run("partial participation", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/1/sync_committee_rewards/estimate?participation_rate=0.5", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		s.EstimateSyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardEstimateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, resp.Data.ExpectedReward, resp.Data.ExpectedPenalty)
+		assert.Equal(t, "0", resp.Data.ExpectedNetReward)
+	})
+	t.Run("not a member of next committee", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/2/sync_committee_rewards/estimate", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "2"})
+		writer := httptest.NewRecorder()
+		s.EstimateSyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardEstimateResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, false, resp.Data.InSyncCommittee)
+		assert.Equal(t, "0", resp.Data.ExpectedReward)
+	})
+	t.Run("invalid validator index", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/10/sync_committee_rewards/estimate", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "10"})
+		writer := httptest.NewRecorder()
+		s.EstimateSyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, true, strings.Contains(e.Message, "invalid validator index"))
+	})
+	t.Run("invalid participation rate", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/1/sync_committee_rewards/estimate?participation_rate=2", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": "1"})
+		writer := httptest.NewRecorder()
+		s.EstimateSyncCommitteeRewards(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
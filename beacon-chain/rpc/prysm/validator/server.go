@@ -0,0 +1,19 @@
+// Package validator defines Prysm-specific HTTP endpoints serving
+// validator related data which is not covered by the standard beacon API.
+package validator
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/statefetcher"
+)
+
+// Server defines a server implementation of Prysm-specific HTTP endpoints
+// intended for validator operators.
+type Server struct {
+	HeadFetcher      blockchain.HeadFetcher
+	BeaconDB         db.ReadOnlyDatabase
+	AttestationsPool attestations.Pool
+	StateFetcher     statefetcher.Fetcher
+}
@@ -0,0 +1,86 @@
+package validator
+
+// SyncCommitteeRewardEstimateResponse is the response of the sync committee reward estimation endpoint.
+type SyncCommitteeRewardEstimateResponse struct {
+	Data *SyncCommitteeRewardEstimate `json:"data"`
+}
+
+// SyncCommitteeRewardEstimate holds the expected sync committee rewards of a validator for the upcoming
+// sync committee period. All amounts are denominated in Gwei.
+type SyncCommitteeRewardEstimate struct {
+	ValidatorIndex     string `json:"validator_index"`
+	Period             string `json:"period"`
+	StartEpoch         string `json:"start_epoch"`
+	EndEpoch           string `json:"end_epoch"`
+	InSyncCommittee    bool   `json:"in_sync_committee"`
+	CommitteePositions string `json:"committee_positions"`
+	ParticipationRate  string `json:"participation_rate"`
+	RewardPerSlot      string `json:"reward_per_slot"`
+	ExpectedReward     string `json:"expected_reward"`
+	ExpectedPenalty    string `json:"expected_penalty"`
+	ExpectedNetReward  string `json:"expected_net_reward"`
+}
+
+// WithdrawalInfoResponse is the response of the validator withdrawal endpoint.
+type WithdrawalInfoResponse struct {
+	Data *WithdrawalInfo `json:"data"`
+}
+
+// WithdrawalInfo describes the withdrawal credentials of a validator and when the withdrawals sweep
+// is expected to reach it. The execution address is only set for execution withdrawal credentials,
+// and the next withdrawal epoch only when a withdrawal is expected.
+type WithdrawalInfo struct {
+	ValidatorIndex            string `json:"validator_index"`
+	WithdrawalCredentials     string `json:"withdrawal_credentials"`
+	WithdrawalCredentialsType string `json:"withdrawal_credentials_type"`
+	ExecutionAddress          string `json:"execution_address,omitempty"`
+	WithdrawableEpoch         string `json:"withdrawable_epoch"`
+	NextWithdrawalEpoch       string `json:"next_withdrawal_epoch,omitempty"`
+}
+
+// AttestationInclusionRequest is the request of the attestation inclusion endpoint.
+type AttestationInclusionRequest struct {
+	Attestations []*AttestationInclusionQuery `json:"attestations"`
+}
+
+// AttestationInclusionQuery identifies an attestation by its data root. The aggregation bits are those
+// of the attestation sent by the validator client, as a hex encoded SSZ bitlist.
+type AttestationInclusionQuery struct {
+	Slot            string `json:"slot"`
+	CommitteeIndex  string `json:"committee_index"`
+	DataRoot        string `json:"data_root"`
+	AggregationBits string `json:"aggregation_bits"`
+}
+
+// AttestationInclusionResponse is the response of the attestation inclusion endpoint, with one status per
+// queried attestation, in the order of the request.
+type AttestationInclusionResponse struct {
+	Data []*AttestationInclusionStatus `json:"data"`
+}
+
+// AttestationInclusionStatus tells whether an attestation was included in a canonical block, at which
+// slot it was first included, and whether it is in the attestation pool of the node.
+type AttestationInclusionStatus struct {
+	Included      bool   `json:"included"`
+	InclusionSlot string `json:"inclusion_slot,omitempty"`
+	InPool        bool   `json:"in_pool"`
+}
+
+// BalanceChangesResponse is the response of the balance changes endpoint. The total size is the number
+// of validators matching the request, across all pages.
+type BalanceChangesResponse struct {
+	StartEpoch    string           `json:"start_epoch"`
+	EndEpoch      string           `json:"end_epoch"`
+	Data          []*BalanceChange `json:"data"`
+	NextPageToken string           `json:"next_page_token"`
+	TotalSize     string           `json:"total_size"`
+}
+
+// BalanceChange is the balance of a validator at the start of the two epochs, and the signed difference
+// between them, in Gwei.
+type BalanceChange struct {
+	ValidatorIndex string `json:"validator_index"`
+	StartBalance   string `json:"start_balance"`
+	EndBalance     string `json:"end_balance"`
+	Delta          string `json:"delta"`
+}
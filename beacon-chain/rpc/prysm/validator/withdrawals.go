@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// GetWithdrawalInfo returns the withdrawal credentials of a validator in the head state, along
+// with the epoch at which the withdrawals sweep is expected to withdraw from it. The prediction
+// assumes balances don't change and that the sweep starts at validator index 0 at the Capella fork,
+// as the head state doesn't track the position of the sweep yet.
+func (s *Server) GetWithdrawalInfo(w http.ResponseWriter, r *http.Request) {
+	rawIndex := mux.Vars(r)["validator_index"]
+	index, err := strconv.ParseUint(rawIndex, 10, 64)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not parse validator index %s", rawIndex).Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	st, err := s.HeadFetcher.HeadState(r.Context())
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get head state").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	info, err := withdrawalInfo(st, types.ValidatorIndex(index))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, errInvalidValidatorIndex) {
+			code = http.StatusBadRequest
+		}
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get withdrawal info").Error(),
+			Code:    code,
+		})
+		return
+	}
+	network.WriteJson(w, &WithdrawalInfoResponse{Data: info})
+}
+
+func withdrawalInfo(st state.BeaconState, index types.ValidatorIndex) (*WithdrawalInfo, error) {
+	if uint64(index) >= uint64(st.NumValidators()) {
+		return nil, errors.Wrapf(errInvalidValidatorIndex, "%d is out of range", index)
+	}
+	val, err := st.ValidatorAtIndexReadOnly(index)
+	if err != nil {
+		return nil, err
+	}
+	creds := val.WithdrawalCredentials()
+	info := &WithdrawalInfo{
+		ValidatorIndex:            strconv.FormatUint(uint64(index), 10),
+		WithdrawalCredentials:     hexutil.Encode(creds),
+		WithdrawalCredentialsType: "bls",
+		WithdrawableEpoch:         strconv.FormatUint(uint64(val.WithdrawableEpoch()), 10),
+	}
+	if !state.HasETH1WithdrawalCredential(val) {
+		return info, nil
+	}
+	info.WithdrawalCredentialsType = "execution"
+	info.ExecutionAddress = hexutil.Encode(creds[12:])
+
+	capellaEpoch := params.BeaconConfig().CapellaForkEpoch
+	if capellaEpoch == params.BeaconConfig().FarFutureEpoch {
+		return info, nil
+	}
+	startSlot, err := slots.EpochStart(capellaEpoch)
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() >= startSlot {
+		startSlot = st.Slot() + 1
+	}
+	slot, ok, err := state.PredictWithdrawalSlot(st, startSlot, 0, index)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		info.NextWithdrawalEpoch = strconv.FormatUint(uint64(slots.ToEpoch(slot)), 10)
+	}
+	return info, nil
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestGetWithdrawalInfo(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 10
+	cfg.MaxWithdrawalsPerPayload = 1
+	params.OverrideBeaconConfig(cfg)
+
+	eth1Creds := make([]byte, 32)
+	eth1Creds[0] = cfg.ETH1AddressWithdrawalPrefixByte
+	eth1Creds[31] = 'a'
+	validators := make([]*ethpb.Validator, 3)
+	balances := make([]uint64, 3)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			WithdrawalCredentials: eth1Creds,
+			EffectiveBalance:      cfg.MaxEffectiveBalance,
+			ExitEpoch:             cfg.FarFutureEpoch,
+			WithdrawableEpoch:     cfg.FarFutureEpoch,
+		}
+		balances[i] = cfg.MaxEffectiveBalance + 1
+	}
+	validators[0].WithdrawalCredentials = make([]byte, 32)
+	st, err := util.NewBeaconState(func(s *ethpb.BeaconState) error {
+		s.Validators = validators
+		s.Balances = balances
+		return nil
+	})
+	require.NoError(t, err)
+	s := &Server{HeadFetcher: &mock.ChainService{State: st}}
+
+	getInfo := func(t *testing.T, index string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/"+index+"/withdrawal", nil)
+		request = mux.SetURLVars(request, map[string]string{"validator_index": index})
+		writer := httptest.NewRecorder()
+		s.GetWithdrawalInfo(writer, request)
+		return writer
+	}
+
+	t.Run("bls credentials", func(t *testing.T) {
+		writer := getInfo(t, "0")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &WithdrawalInfoResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "bls", resp.Data.WithdrawalCredentialsType)
+		assert.Equal(t, "", resp.Data.ExecutionAddress)
+		assert.Equal(t, "", resp.Data.NextWithdrawalEpoch)
+	})
+	t.Run("execution credentials", func(t *testing.T) {
+		writer := getInfo(t, "2")
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &WithdrawalInfoResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "execution", resp.Data.WithdrawalCredentialsType)
+		assert.Equal(t, hexutil.Encode(eth1Creds[12:]), resp.Data.ExecutionAddress)
+		// Validator 1 is withdrawn in the first slot of Capella, validator 2 in the second one.
+		assert.Equal(t, "10", resp.Data.NextWithdrawalEpoch)
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		writer := getInfo(t, "3")
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
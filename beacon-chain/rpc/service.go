@@ -6,13 +6,17 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"sync"
+	"time"
 
+	"github.com/gorilla/mux"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpcopentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/pkg/errors"
+	grpcutil "github.com/prysmaticlabs/prysm/v3/api/grpc"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
@@ -22,20 +26,28 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/archive"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/beacon"
+	builderv1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/events"
+	rpchelpers "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/eth/validator"
+	beaconprysm "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/beacon"
+	debugprysm "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/debug"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/node"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/v1alpha1/validator"
+	validatorprysm "github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/prysm/validator"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/rpc/statefetcher"
 	slasherservice "github.com/prysmaticlabs/prysm/v3/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state/stategen"
@@ -50,6 +62,8 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	// Register the gzip compressor, used by validator clients to compress duties responses.
+	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
 )
@@ -83,6 +97,7 @@ type Config struct {
 	HeadUpdater                   blockchain.HeadUpdater
 	HeadFetcher                   blockchain.HeadFetcher
 	CanonicalFetcher              blockchain.CanonicalFetcher
+	AncestryFetcher               blockchain.AncestryFetcher
 	ForkFetcher                   blockchain.ForkFetcher
 	FinalizationFetcher           blockchain.FinalizationFetcher
 	AttestationReceiver           blockchain.AttestationReceiver
@@ -96,6 +111,7 @@ type Config struct {
 	MockEth1Votes                 bool
 	AttestationsPool              attestations.Pool
 	ExitPool                      voluntaryexits.PoolManager
+	BLSToExecPool                 blstoexec.PoolManager
 	SlashingsPool                 slashings.PoolManager
 	SlashingChecker               slasherservice.SlashingChecker
 	SyncCommitteeObjectPool       synccommittee.Pool
@@ -111,10 +127,15 @@ type Config struct {
 	OperationNotifier             opfeed.Notifier
 	StateGen                      *stategen.State
 	MaxMsgSize                    int
+	SlowRequestThreshold          time.Duration
 	ExecutionEngineCaller         execution.EngineCaller
 	ProposerIdsCache              *cache.ProposerPayloadIDsCache
+	SlotTimelineCache             *cache.SlotTimelineCache
+	ArrivalTracker                *arrival.Tracker
 	OptimisticModeFetcher         blockchain.OptimisticModeFetcher
 	BlockBuilder                  builder.BlockBuilder
+	Router                        *mux.Router
+	Archive                       *archive.Archive
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -154,6 +175,8 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 			grpcprometheus.UnaryServerInterceptor,
 			grpcopentracing.UnaryServerInterceptor(),
 			s.validatorUnaryConnectionInterceptor,
+			rpchelpers.ExecutionOptimisticUnaryInterceptor,
+			grpcutil.AuditUnaryServerInterceptor(s.cfg.SlowRequestThreshold),
 		)),
 		grpc.MaxRecvMsgSize(s.cfg.MaxMsgSize),
 	}
@@ -186,13 +209,18 @@ func (s *Service) Start() {
 		stateCache = s.cfg.StateGen.CombinedCache()
 	}
 	withCache := stategen.WithCache(stateCache)
-	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, withCache)
+	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, withCache, stategen.WithEpochBoundaryIndex(s.cfg.BeaconDB))
+	var stateArchive statefetcher.Archive
+	if s.cfg.Archive != nil {
+		stateArchive = s.cfg.Archive
+	}
 
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
 		AttestationCache:       cache.NewAttestationCache(),
 		AttPool:                s.cfg.AttestationsPool,
 		ExitPool:               s.cfg.ExitPool,
+		BLSToExecPool:          s.cfg.BLSToExecPool,
 		HeadFetcher:            s.cfg.HeadFetcher,
 		HeadUpdater:            s.cfg.HeadUpdater,
 		ForkFetcher:            s.cfg.ForkFetcher,
@@ -220,6 +248,8 @@ func (s *Service) Start() {
 		ExecutionEngineCaller:  s.cfg.ExecutionEngineCaller,
 		BeaconDB:               s.cfg.BeaconDB,
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
+		SlotTimelineCache:      s.cfg.SlotTimelineCache,
+		DutiesCache:            validatorv1alpha1.NewDutiesCache(),
 		BlockBuilder:           s.cfg.BlockBuilder,
 	}
 	validatorServerV1 := &validator.Server{
@@ -238,6 +268,7 @@ func (s *Service) Start() {
 			GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
 			StateGenService:    s.cfg.StateGen,
 			ReplayerBuilder:    ch,
+			Archive:            stateArchive,
 		},
 		SyncCommitteePool: s.cfg.SyncCommitteeObjectPool,
 	}
@@ -310,13 +341,16 @@ func (s *Service) Start() {
 			GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
 			StateGenService:    s.cfg.StateGen,
 			ReplayerBuilder:    ch,
+			Archive:            stateArchive,
 		},
 		OptimisticModeFetcher:         s.cfg.OptimisticModeFetcher,
 		HeadFetcher:                   s.cfg.HeadFetcher,
 		VoluntaryExitsPool:            s.cfg.ExitPool,
+		BLSToExecPool:                 s.cfg.BLSToExecPool,
 		V1Alpha1ValidatorServer:       validatorServer,
 		SyncChecker:                   s.cfg.SyncService,
 		ExecutionPayloadReconstructor: s.cfg.ExecutionPayloadReconstructor,
+		Archive:                       s.cfg.Archive,
 	}
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
@@ -349,6 +383,7 @@ func (s *Service) Start() {
 				GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
 				StateGenService:    s.cfg.StateGen,
 				ReplayerBuilder:    ch,
+				Archive:            stateArchive,
 			},
 			OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		}
@@ -356,7 +391,46 @@ func (s *Service) Start() {
 		ethpbservice.RegisterBeaconDebugServer(s.grpcServer, debugServerV1)
 	}
 	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+	go validatorServer.PrecomputeDuties()
 	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
+	if s.cfg.Router != nil {
+		validatorServerPrysm := &validatorprysm.Server{
+			HeadFetcher:      s.cfg.HeadFetcher,
+			BeaconDB:         s.cfg.BeaconDB,
+			AttestationsPool: s.cfg.AttestationsPool,
+			StateFetcher:     beaconChainServerV1.StateFetcher,
+		}
+		s.cfg.Router.HandleFunc("/prysm/v1/validators/{validator_index}/sync_committee_rewards/estimate", validatorServerPrysm.EstimateSyncCommitteeRewards).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/prysm/v1/validators/{validator_index}/withdrawal", validatorServerPrysm.GetWithdrawalInfo).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/prysm/v1/validators/attestation_inclusion", validatorServerPrysm.GetAttestationInclusion).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/prysm/v1/validators/balance_changes", validatorServerPrysm.GetBalanceChanges).Methods(http.MethodGet)
+		beaconChainServerPrysm := &beaconprysm.Server{
+			BeaconDB: s.cfg.BeaconDB,
+		}
+		s.cfg.Router.HandleFunc("/prysm/v1/beacon/checkpoints/history", beaconChainServerPrysm.GetCheckpointHistory).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/pool/bls_to_execution_changes", beaconChainServerV1.ListBLSToExecutionChanges).Methods(http.MethodGet)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/pool/bls_to_execution_changes", beaconChainServerV1.SubmitBLSToExecutionChanges).Methods(http.MethodPost)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/deposit_snapshot", beaconChainServerV1.GetDepositSnapshot).Methods(http.MethodGet)
+		builderServerV1 := &builderv1.Server{
+			StateFetcher:          beaconChainServerV1.StateFetcher,
+			OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
+		}
+		s.cfg.Router.HandleFunc("/eth/v1/builder/states/{state_id}/expected_withdrawals", builderServerV1.ExpectedWithdrawals).Methods(http.MethodGet)
+		if s.cfg.EnableDebugRPCEndpoints {
+			debugServerPrysm := &debugprysm.Server{
+				AncestryFetcher:    s.cfg.AncestryFetcher,
+				ForkFetcher:        s.cfg.ForkFetcher,
+				GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
+				SlotTimelineCache:  s.cfg.SlotTimelineCache,
+				ArrivalTracker:     s.cfg.ArrivalTracker,
+			}
+			s.cfg.Router.HandleFunc("/prysm/v1/debug/forkchoice", debugServerPrysm.GetForkChoice).Methods(http.MethodGet)
+			s.cfg.Router.HandleFunc("/prysm/v1/debug/forkchoice/is_ancestor", debugServerPrysm.IsAncestor).Methods(http.MethodGet)
+			s.cfg.Router.HandleFunc("/prysm/v1/debug/forkchoice/common_ancestor", debugServerPrysm.CommonAncestor).Methods(http.MethodGet)
+			s.cfg.Router.HandleFunc("/prysm/v1/debug/slot_timeline", debugServerPrysm.GetSlotTimeline).Methods(http.MethodGet)
+			s.cfg.Router.HandleFunc("/prysm/v1/debug/arrival_timing", debugServerPrysm.GetArrivalTiming).Methods(http.MethodGet)
+		}
+	}
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
 
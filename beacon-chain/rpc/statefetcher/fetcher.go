@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"go.opencensus.io/trace"
 )
 
@@ -77,6 +78,13 @@ type Fetcher interface {
 	StateBySlot(ctx context.Context, slot types.Slot) (state.BeaconState, error)
 }
 
+// Archive provides states which are older than what the beacon node retains locally.
+// A nil state is returned when the archive doesn't serve the requested state.
+type Archive interface {
+	StateByRoot(ctx context.Context, stateRoot [32]byte) (state.BeaconState, error)
+	StateBySlot(ctx context.Context, slot types.Slot) (state.BeaconState, error)
+}
+
 // StateProvider is a real implementation of Fetcher.
 type StateProvider struct {
 	BeaconDB           db.ReadOnlyDatabase
@@ -84,6 +92,8 @@ type StateProvider struct {
 	GenesisTimeFetcher blockchain.TimeFetcher
 	StateGenService    stategen.StateManager
 	ReplayerBuilder    stategen.ReplayerBuilder
+	// Archive is optional. When set, states older than the local retention are read through from it.
+	Archive Archive
 }
 
 // State returns the BeaconState for a given identifier. The identifier can be one of:
@@ -186,6 +196,15 @@ func (p *StateProvider) stateByRoot(ctx context.Context, stateRoot []byte) (stat
 			return p.StateGenService.StateByRoot(ctx, bytesutil.ToBytes32(blockRoot))
 		}
 	}
+	if p.Archive != nil {
+		st, err := p.Archive.StateByRoot(ctx, bytesutil.ToBytes32(stateRoot))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get state from remote archive")
+		}
+		if st != nil {
+			return st, nil
+		}
+	}
 
 	stateNotFoundErr := NewStateNotFoundError(len(headState.StateRoots()))
 	return nil, &stateNotFoundErr
@@ -206,6 +225,15 @@ func (p *StateProvider) StateBySlot(ctx context.Context, target types.Slot) (sta
 	if target > p.ChainInfoFetcher.HeadSlot() {
 		return nil, errors.New("requested slot number is higher than head slot number")
 	}
+	if p.Archive != nil {
+		st, err := p.Archive.StateBySlot(ctx, target)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get state from remote archive")
+		}
+		if st != nil {
+			return st, nil
+		}
+	}
 
 	st, err := p.ReplayerBuilder.ReplayerForSlot(target).ReplayBlocks(ctx)
 	if err != nil {
@@ -289,6 +317,15 @@ func (p *StateProvider) stateRootBySlot(ctx context.Context, slot types.Slot) ([
 	if slot > currentSlot {
 		return nil, errors.New("slot cannot be in the future")
 	}
+	if slot%params.BeaconConfig().SlotsPerEpoch == 0 {
+		roots, err := p.BeaconDB.EpochBoundaryRoots(ctx, slots.ToEpoch(slot))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get epoch boundary roots")
+		}
+		if roots != nil {
+			return roots.StateRoot[:], nil
+		}
+	}
 	blks, err := p.BeaconDB.BlocksBySlot(ctx, slot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get blocks")
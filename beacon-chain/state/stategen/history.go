@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"go.opencensus.io/trace"
 )
 
@@ -21,6 +22,15 @@ func WithCache(c CachedGetter) CanonicalHistoryOption {
 	}
 }
 
+// WithEpochBoundaryIndex lets the canonical history look up the canonical blocks at the start of
+// finalized epochs in the given index, bounding the search for the canonical block of a slot to
+// the epoch of the slot.
+func WithEpochBoundaryIndex(idx EpochBoundaryIndex) CanonicalHistoryOption {
+	return func(h *CanonicalHistory) {
+		h.index = idx
+	}
+}
+
 type CanonicalHistoryOption func(*CanonicalHistory)
 
 func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlotter, opts ...CanonicalHistoryOption) *CanonicalHistory {
@@ -40,6 +50,7 @@ type CanonicalHistory struct {
 	cc    CanonicalChecker
 	cs    CurrentSlotter
 	cache CachedGetter
+	index EpochBoundaryIndex
 }
 
 func (c *CanonicalHistory) ReplayerForSlot(target types.Slot) Replayer {
@@ -51,6 +62,10 @@ func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target types.Sl
 		return [32]byte{}, errors.Wrap(ErrFutureSlotRequested, fmt.Sprintf("requested=%d, current=%d", target, currentSlot))
 	}
 
+	boundary, err := c.epochBoundary(ctx, target)
+	if err != nil {
+		return [32]byte{}, err
+	}
 	slotAbove := target + 1
 	// don't bother searching for candidate roots when we know the target slot is genesis
 	for slotAbove > 1 {
@@ -64,6 +79,10 @@ func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target types.Sl
 		if len(roots) == 0 {
 			return [32]byte{}, errors.Wrap(ErrNoBlocksBelowSlot, fmt.Sprintf("slot=%d", slotAbove))
 		}
+		// the canonical block at the start of the epoch is known, no need to look further back.
+		if boundary != nil && slot <= boundary.slot {
+			return boundary.root, nil
+		}
 		r, err := c.bestForSlot(ctx, roots)
 		if err == nil {
 			// we found a valid, canonical block!
@@ -100,6 +119,32 @@ func (c *CanonicalHistory) bestForSlot(ctx context.Context, roots [][32]byte) ([
 	return [32]byte{}, errors.Wrap(ErrNoCanonicalBlockForSlot, "no good block for slot")
 }
 
+type indexedBoundary struct {
+	slot types.Slot
+	root [32]byte
+}
+
+// epochBoundary returns the start slot of the epoch of the target slot and the root of the canonical
+// block at that slot, if the epoch is indexed.
+func (c *CanonicalHistory) epochBoundary(ctx context.Context, target types.Slot) (*indexedBoundary, error) {
+	if c.index == nil {
+		return nil, nil
+	}
+	epoch := slots.ToEpoch(target)
+	roots, err := c.index.EpochBoundaryRoots(ctx, epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get boundary roots of epoch %d", epoch)
+	}
+	if roots == nil {
+		return nil, nil
+	}
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return nil, err
+	}
+	return &indexedBoundary{slot: start, root: roots.BlockRoot}, nil
+}
+
 // ChainForSlot creates a value that satisfies the Replayer interface via db queries
 // and the stategen transition helper methods. This implementation uses the following algorithm:
 // - find the highest canonical block <= the target slot
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/mock"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
@@ -580,3 +582,38 @@ func mockBlocks(n int, iter func(int, chan uint32)) []interfaces.SignedBeaconBlo
 	}
 	return mb
 }
+
+type mockEpochBoundaryIndex struct {
+	roots map[types.Epoch]*db.EpochBoundaryRoots
+}
+
+func (m *mockEpochBoundaryIndex) EpochBoundaryRoots(_ context.Context, epoch types.Epoch) (*db.EpochBoundaryRoots, error) {
+	return m.roots[epoch], nil
+}
+
+func TestCanonicalBlockForSlot_EpochBoundaryIndex(t *testing.T) {
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	begin, end := 3*slotsPerEpoch+1, 4*slotsPerEpoch+1
+	specs := []mockHistorySpec{
+		{slot: begin},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+1)
+	// the block at begin is not canonical according to the checker, but the index knows it was
+	// the latest block at the start of epoch 4 when the epoch was finalized.
+	idx := &mockEpochBoundaryIndex{roots: map[types.Epoch]*db.EpochBoundaryRoots{
+		4: {Epoch: 4, BlockRoot: hist.slotMap[begin]},
+	}}
+	ch := NewCanonicalHistory(hist, hist, hist, WithEpochBoundaryIndex(idx))
+
+	cr, err := ch.BlockRootForSlot(ctx, end)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[end], cr)
+	cr, err = ch.BlockRootForSlot(ctx, end-1)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[begin], cr)
+	cr, err = ch.BlockRootForSlot(ctx, begin)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[0], cr, "unindexed epochs should fall back to the canonical checker")
+}
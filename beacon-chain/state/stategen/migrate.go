@@ -5,8 +5,12 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -110,7 +114,54 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 	}
 	if ok {
 		s.SaveFinalizedState(fSlot, fRoot, fInfo.state)
+		if err := s.saveEpochBoundaryRoots(ctx, oldFSlot, fRoot, fInfo.state); err != nil {
+			log.WithError(err).Warn("Could not index epoch boundary roots")
+		}
 	}
 
 	return nil
 }
+
+// saveEpochBoundaryRoots indexes the block and state roots at the start of every epoch since the
+// previous finalized slot, up to the slot of the finalized state. The roots are read from the
+// historical roots of the finalized state, whose latest block is the finalized block.
+func (s *State) saveEpochBoundaryRoots(ctx context.Context, oldFSlot types.Slot, fRoot [32]byte, fState state.BeaconState) error {
+	historyLength := params.BeaconConfig().SlotsPerHistoricalRoot
+	lowest := oldFSlot
+	if fState.Slot() > historyLength && fState.Slot()-historyLength > lowest {
+		lowest = fState.Slot() - historyLength
+	}
+	epoch := slots.ToEpoch(lowest)
+	if lowest%params.BeaconConfig().SlotsPerEpoch != 0 {
+		epoch++
+	}
+	stateRoots := fState.StateRoots()
+	roots := make([]*db.EpochBoundaryRoots, 0)
+	for ; ; epoch++ {
+		start, err := slots.EpochStart(epoch)
+		if err != nil {
+			return err
+		}
+		if start > fState.Slot() {
+			break
+		}
+		r := &db.EpochBoundaryRoots{Epoch: epoch}
+		if start == fState.Slot() {
+			r.BlockRoot = fRoot
+			r.StateRoot, err = fState.HashTreeRoot(ctx)
+			if err != nil {
+				return err
+			}
+		} else {
+			idx := start % historyLength
+			blockRoot, err := fState.BlockRootAtIndex(uint64(idx))
+			if err != nil {
+				return err
+			}
+			r.BlockRoot = bytesutil.ToBytes32(blockRoot)
+			r.StateRoot = bytesutil.ToBytes32(stateRoots[idx])
+		}
+		roots = append(roots, r)
+	}
+	return s.beaconDB.SaveEpochBoundaryRoots(ctx, roots)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
 	testDB "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
@@ -134,3 +135,38 @@ func TestMigrateToCold_StateExistsInDB(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{{1}, {2}, {3}, {4}}, service.saveHotStateDB.blockRootsOfSavedStates)
 	assert.LogsDoNotContain(t, hook, "Saved state in DB")
 }
+
+func TestSaveEpochBoundaryRoots(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	service := New(beaconDB)
+
+	beaconState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	fSlot := 3 * slotsPerEpoch
+	for i := slotsPerEpoch; i < fSlot; i += slotsPerEpoch {
+		require.NoError(t, beaconState.UpdateBlockRootAtIndex(uint64(i), [32]byte{'b', byte(i)}))
+		require.NoError(t, beaconState.UpdateStateRootAtIndex(uint64(i), [32]byte{'s', byte(i)}))
+	}
+	require.NoError(t, beaconState.SetSlot(fSlot))
+	fRoot := [32]byte{'f'}
+	require.NoError(t, service.saveEpochBoundaryRoots(ctx, slotsPerEpoch-1, fRoot, beaconState))
+
+	roots, err := beaconDB.EpochBoundaryRoots(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, true, roots == nil, "Epochs before the previous finalized slot should not be indexed")
+	for _, epoch := range []types.Epoch{1, 2} {
+		roots, err = beaconDB.EpochBoundaryRoots(ctx, epoch)
+		require.NoError(t, err)
+		slot := types.Slot(epoch) * slotsPerEpoch
+		assert.Equal(t, [32]byte{'b', byte(slot)}, roots.BlockRoot)
+		assert.Equal(t, [32]byte{'s', byte(slot)}, roots.StateRoot)
+	}
+	roots, err = beaconDB.EpochBoundaryRoots(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, fRoot, roots.BlockRoot)
+	stateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stateRoot, roots.StateRoot)
+}
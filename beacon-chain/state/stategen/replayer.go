@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
@@ -32,6 +33,11 @@ type HistoryAccessor interface {
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 }
 
+// EpochBoundaryIndex gives the roots of the canonical block and state at the start of finalized epochs.
+type EpochBoundaryIndex interface {
+	EpochBoundaryRoots(ctx context.Context, epoch types.Epoch) (*db.EpochBoundaryRoots, error)
+}
+
 // CanonicalChecker determines whether the given block root is canonical.
 // In practice this should be satisfied by a type that uses the fork choice store.
 type CanonicalChecker interface {
@@ -0,0 +1,180 @@
+package state
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// Withdrawal is a withdrawal of validator balance to the execution address of its withdrawal
+// credentials, as produced by the withdrawals sweep.
+type Withdrawal struct {
+	Index          uint64
+	ValidatorIndex types.ValidatorIndex
+	Address        []byte
+	Amount         uint64
+}
+
+// HasETH1WithdrawalCredential returns true if the validator has withdrawal credentials with the
+// execution address prefix.
+//
+// Spec pseudocode definition:
+//
+//	def has_eth1_withdrawal_credential(validator: Validator) -> bool:
+//	    return validator.withdrawal_credentials[:1] == ETH1_ADDRESS_WITHDRAWAL_PREFIX
+func HasETH1WithdrawalCredential(val ReadOnlyValidator) bool {
+	creds := val.WithdrawalCredentials()
+	return len(creds) > 0 && creds[0] == params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+}
+
+// IsFullyWithdrawableValidator returns true if the whole balance of the validator can be withdrawn at the given epoch.
+//
+// Spec pseudocode definition:
+//
+//	def is_fully_withdrawable_validator(validator: Validator, balance: Gwei, epoch: Epoch) -> bool:
+//	    return (
+//	        has_eth1_withdrawal_credential(validator)
+//	        and validator.withdrawable_epoch <= epoch
+//	        and balance > 0
+//	    )
+func IsFullyWithdrawableValidator(val ReadOnlyValidator, balance uint64, epoch types.Epoch) bool {
+	return HasETH1WithdrawalCredential(val) && val.WithdrawableEpoch() <= epoch && balance > 0
+}
+
+// IsPartiallyWithdrawableValidator returns true if the balance of the validator above the maximum
+// effective balance can be withdrawn.
+//
+// Spec pseudocode definition:
+//
+//	def is_partially_withdrawable_validator(validator: Validator, balance: Gwei) -> bool:
+//	    has_max_effective_balance = validator.effective_balance == MAX_EFFECTIVE_BALANCE
+//	    has_excess_balance = balance > MAX_EFFECTIVE_BALANCE
+//	    return has_eth1_withdrawal_credential(validator) and has_max_effective_balance and has_excess_balance
+func IsPartiallyWithdrawableValidator(val ReadOnlyValidator, balance uint64) bool {
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	return HasETH1WithdrawalCredential(val) && val.EffectiveBalance() == maxBalance && balance > maxBalance
+}
+
+// ExpectedWithdrawals returns the withdrawals the next execution payload built on top of the state
+// would carry, sweeping the validators from nextValidatorIndex and numbering the withdrawals from
+// nextWithdrawalIndex.
+//
+// Spec pseudocode definition:
+//
+//	def get_expected_withdrawals(state: BeaconState) -> Sequence[Withdrawal]:
+//	    epoch = get_current_epoch(state)
+//	    withdrawal_index = state.next_withdrawal_index
+//	    validator_index = state.next_withdrawal_validator_index
+//	    withdrawals: List[Withdrawal] = []
+//	    bound = min(len(state.validators), MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP)
+//	    for _ in range(bound):
+//	        validator = state.validators[validator_index]
+//	        balance = state.balances[validator_index]
+//	        if is_fully_withdrawable_validator(validator, balance, epoch):
+//	            withdrawals.append(Withdrawal(
+//	                index=withdrawal_index,
+//	                validator_index=validator_index,
+//	                address=ExecutionAddress(validator.withdrawal_credentials[12:]),
+//	                amount=balance,
+//	            ))
+//	            withdrawal_index += WithdrawalIndex(1)
+//	        elif is_partially_withdrawable_validator(validator, balance):
+//	            withdrawals.append(Withdrawal(
+//	                index=withdrawal_index,
+//	                validator_index=validator_index,
+//	                address=ExecutionAddress(validator.withdrawal_credentials[12:]),
+//	                amount=balance - MAX_EFFECTIVE_BALANCE,
+//	            ))
+//	            withdrawal_index += WithdrawalIndex(1)
+//	        if len(withdrawals) == MAX_WITHDRAWALS_PER_PAYLOAD:
+//	            break
+//	        validator_index = ValidatorIndex((validator_index + 1) % len(state.validators))
+//	    return withdrawals
+func ExpectedWithdrawals(st ReadOnlyBeaconState, nextWithdrawalIndex uint64, nextValidatorIndex types.ValidatorIndex) ([]*Withdrawal, error) {
+	cfg := params.BeaconConfig()
+	numVals := uint64(st.NumValidators())
+	withdrawals := make([]*Withdrawal, 0)
+	if numVals == 0 {
+		return withdrawals, nil
+	}
+	if uint64(nextValidatorIndex) >= numVals {
+		return nil, errors.Errorf("validator index %d is out of range", nextValidatorIndex)
+	}
+	epoch := slots.ToEpoch(st.Slot())
+	bound := numVals
+	if cfg.MaxValidatorsPerWithdrawalsSweep < bound {
+		bound = cfg.MaxValidatorsPerWithdrawalsSweep
+	}
+	withdrawalIndex := nextWithdrawalIndex
+	validatorIndex := nextValidatorIndex
+	for i := uint64(0); i < bound; i++ {
+		val, err := st.ValidatorAtIndexReadOnly(validatorIndex)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := st.BalanceAtIndex(validatorIndex)
+		if err != nil {
+			return nil, err
+		}
+		amount := uint64(0)
+		if IsFullyWithdrawableValidator(val, balance, epoch) {
+			amount = balance
+		} else if IsPartiallyWithdrawableValidator(val, balance) {
+			amount = balance - cfg.MaxEffectiveBalance
+		}
+		if amount > 0 {
+			withdrawals = append(withdrawals, &Withdrawal{
+				Index:          withdrawalIndex,
+				ValidatorIndex: validatorIndex,
+				Address:        val.WithdrawalCredentials()[12:],
+				Amount:         amount,
+			})
+			withdrawalIndex++
+		}
+		if uint64(len(withdrawals)) == cfg.MaxWithdrawalsPerPayload {
+			break
+		}
+		validatorIndex = types.ValidatorIndex((uint64(validatorIndex) + 1) % numVals)
+	}
+	return withdrawals, nil
+}
+
+// PredictWithdrawalSlot returns the slot at which the validator with index idx is expected to be
+// withdrawn by the sweep, if it starts from nextValidatorIndex at slot startSlot and no balance or
+// validator changes in the meantime. It returns false if the validator would not be withdrawn
+// during one full pass of the sweep over the validator set.
+func PredictWithdrawalSlot(st ReadOnlyBeaconState, startSlot types.Slot, nextValidatorIndex, idx types.ValidatorIndex) (types.Slot, bool, error) {
+	cfg := params.BeaconConfig()
+	numVals := uint64(st.NumValidators())
+	if uint64(idx) >= numVals || uint64(nextValidatorIndex) >= numVals {
+		return 0, false, errors.Errorf("validator index %d is out of range", idx)
+	}
+	slot := startSlot
+	swept, withdrawals := uint64(0), uint64(0)
+	validatorIndex := nextValidatorIndex
+	for i := uint64(0); i < numVals; i++ {
+		val, err := st.ValidatorAtIndexReadOnly(validatorIndex)
+		if err != nil {
+			return 0, false, err
+		}
+		balance, err := st.BalanceAtIndex(validatorIndex)
+		if err != nil {
+			return 0, false, err
+		}
+		withdrawable := IsFullyWithdrawableValidator(val, balance, slots.ToEpoch(slot)) || IsPartiallyWithdrawableValidator(val, balance)
+		if validatorIndex == idx {
+			return slot, withdrawable, nil
+		}
+		swept++
+		if withdrawable {
+			withdrawals++
+		}
+		if withdrawals == cfg.MaxWithdrawalsPerPayload || swept == cfg.MaxValidatorsPerWithdrawalsSweep {
+			slot++
+			swept, withdrawals = 0, 0
+		}
+		validatorIndex = types.ValidatorIndex((uint64(validatorIndex) + 1) % numVals)
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,127 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func withdrawalsTestState(t *testing.T) state.BeaconState {
+	cfg := params.BeaconConfig()
+	eth1Creds := func(i int) []byte {
+		creds := make([]byte, 32)
+		creds[0] = cfg.ETH1AddressWithdrawalPrefixByte
+		creds[31] = byte(i)
+		return creds
+	}
+	validators := []*ethpb.Validator{
+		// BLS credentials are never withdrawn.
+		{WithdrawalCredentials: make([]byte, 32), EffectiveBalance: cfg.MaxEffectiveBalance, WithdrawableEpoch: 0},
+		// Fully withdrawable.
+		{WithdrawalCredentials: eth1Creds(1), EffectiveBalance: cfg.MaxEffectiveBalance, WithdrawableEpoch: 0},
+		// Partially withdrawable.
+		{WithdrawalCredentials: eth1Creds(2), EffectiveBalance: cfg.MaxEffectiveBalance, WithdrawableEpoch: cfg.FarFutureEpoch},
+		// No excess balance.
+		{WithdrawalCredentials: eth1Creds(3), EffectiveBalance: cfg.MaxEffectiveBalance, WithdrawableEpoch: cfg.FarFutureEpoch},
+		// Partially withdrawable.
+		{WithdrawalCredentials: eth1Creds(4), EffectiveBalance: cfg.MaxEffectiveBalance, WithdrawableEpoch: cfg.FarFutureEpoch},
+		// Fully withdrawable.
+		{WithdrawalCredentials: eth1Creds(5), EffectiveBalance: cfg.MaxEffectiveBalance, WithdrawableEpoch: 0},
+	}
+	balances := []uint64{
+		cfg.MaxEffectiveBalance + 1,
+		10,
+		cfg.MaxEffectiveBalance + 1,
+		cfg.MaxEffectiveBalance,
+		cfg.MaxEffectiveBalance + 5,
+		7,
+	}
+	st, err := util.NewBeaconState(func(s *ethpb.BeaconState) error {
+		s.Validators = validators
+		s.Balances = balances
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestExpectedWithdrawals(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxWithdrawalsPerPayload = 2
+	cfg.MaxValidatorsPerWithdrawalsSweep = 4
+	params.OverrideBeaconConfig(cfg)
+	st := withdrawalsTestState(t)
+
+	withdrawals, err := state.ExpectedWithdrawals(st, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(withdrawals))
+	assert.Equal(t, uint64(10), withdrawals[0].Index)
+	assert.Equal(t, types.ValidatorIndex(1), withdrawals[0].ValidatorIndex)
+	assert.Equal(t, uint64(10), withdrawals[0].Amount)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{}, 19), withdrawals[0].Address[:19])
+	assert.Equal(t, byte(1), withdrawals[0].Address[19])
+	assert.Equal(t, uint64(11), withdrawals[1].Index)
+	assert.Equal(t, types.ValidatorIndex(2), withdrawals[1].ValidatorIndex)
+	assert.Equal(t, uint64(1), withdrawals[1].Amount)
+
+	// The sweep wraps around the validator set.
+	withdrawals, err = state.ExpectedWithdrawals(st, 0, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(withdrawals))
+	assert.Equal(t, types.ValidatorIndex(5), withdrawals[0].ValidatorIndex)
+	assert.Equal(t, uint64(7), withdrawals[0].Amount)
+	assert.Equal(t, types.ValidatorIndex(1), withdrawals[1].ValidatorIndex)
+
+	// The sweep is bounded by the number of validators it checks, validator 1 is out of reach.
+	cfg.MaxWithdrawalsPerPayload = 16
+	params.OverrideBeaconConfig(cfg)
+	withdrawals, err = state.ExpectedWithdrawals(st, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(withdrawals))
+	assert.Equal(t, types.ValidatorIndex(4), withdrawals[0].ValidatorIndex)
+	assert.Equal(t, uint64(5), withdrawals[0].Amount)
+	assert.Equal(t, types.ValidatorIndex(5), withdrawals[1].ValidatorIndex)
+
+	_, err = state.ExpectedWithdrawals(st, 0, 6)
+	require.ErrorContains(t, "out of range", err)
+}
+
+func TestPredictWithdrawalSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxWithdrawalsPerPayload = 2
+	cfg.MaxValidatorsPerWithdrawalsSweep = 4
+	params.OverrideBeaconConfig(cfg)
+	st := withdrawalsTestState(t)
+
+	tests := []struct {
+		index        types.ValidatorIndex
+		slot         types.Slot
+		withdrawable bool
+	}{
+		{index: 0, slot: 100, withdrawable: false},
+		{index: 1, slot: 100, withdrawable: true},
+		{index: 2, slot: 100, withdrawable: true},
+		// The first payload is full after validator 2.
+		{index: 3, slot: 101, withdrawable: false},
+		{index: 4, slot: 101, withdrawable: true},
+		{index: 5, slot: 101, withdrawable: true},
+	}
+	for _, tt := range tests {
+		slot, ok, err := state.PredictWithdrawalSlot(st, 100, 0, tt.index)
+		require.NoError(t, err)
+		assert.Equal(t, tt.withdrawable, ok, "validator %d", tt.index)
+		assert.Equal(t, tt.slot, slot, "validator %d", tt.index)
+	}
+
+	_, _, err := state.PredictWithdrawalSlot(st, 100, 0, 6)
+	require.ErrorContains(t, "out of range", err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -42,5 +43,47 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
-	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
+	if err := d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes()); err != nil {
+		return err
+	}
+	// The deposit snapshot is optional, without it deposits are replayed from the deposit contract logs.
+	if err := dl.saveDepositSnapshot(ctx, d); err != nil {
+		log.WithError(err).Warn("Could not initialize deposits from the deposit snapshot of the remote beacon node")
+	}
+	return nil
+}
+
+// Downloads the deposit snapshot of the remote beacon node and saves it, after checking it against
+// the deposits of the origin state when they cover the same deposits.
+func (dl *APIInitializer) saveDepositSnapshot(ctx context.Context, d db.Database) error {
+	snapshot, err := dl.c.GetDepositSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	origin, err := d.OriginCheckpointBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve origin checkpoint root")
+	}
+	st, err := d.State(ctx, origin)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve origin state")
+	}
+	if st == nil || st.IsNil() {
+		return errors.Errorf("origin state with root %#x not found", origin)
+	}
+	eth1Data := st.Eth1Data()
+	if eth1Data == nil {
+		return errors.New("origin state has no eth1 data")
+	}
+	if snapshot.DepositCount < eth1Data.DepositCount {
+		return errors.Errorf("deposit snapshot count %d is lower than the deposit count %d of the origin state", snapshot.DepositCount, eth1Data.DepositCount)
+	}
+	if snapshot.DepositCount == eth1Data.DepositCount && snapshot.DepositRoot != bytesutil.ToBytes32(eth1Data.DepositRoot) {
+		return errors.Errorf("deposit snapshot root %#x does not match the deposit root %#x of the origin state", snapshot.DepositRoot, eth1Data.DepositRoot)
+	}
+	if err := d.SaveDepositSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save deposit snapshot")
+	}
+	log.WithField("depositCount", snapshot.DepositCount).Info("Saved deposit snapshot from the remote beacon node")
+	return nil
 }
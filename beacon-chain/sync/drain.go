@@ -0,0 +1,50 @@
+package sync
+
+// Drain stops the service from accepting any new req/resp requests. Requests
+// which are already being served are allowed to complete, their progress can be
+// followed with InFlightRPCs.
+func (s *Service) Drain() {
+	s.drainLock.Lock()
+	defer s.drainLock.Unlock()
+	s.draining = true
+}
+
+// Resume makes the service accept new req/resp requests again after Drain.
+func (s *Service) Resume() {
+	s.drainLock.Lock()
+	defer s.drainLock.Unlock()
+	s.draining = false
+}
+
+// Draining returns true if the service has stopped accepting new req/resp requests.
+func (s *Service) Draining() bool {
+	s.drainLock.Lock()
+	defer s.drainLock.Unlock()
+	return s.draining
+}
+
+// InFlightRPCs returns the number of req/resp requests currently being served.
+func (s *Service) InFlightRPCs() int {
+	s.drainLock.Lock()
+	defer s.drainLock.Unlock()
+	return s.inFlightRPCs
+}
+
+// beginRPC marks the start of an incoming req/resp request. It returns false if
+// the service is draining, in which case the request must be rejected.
+func (s *Service) beginRPC() bool {
+	s.drainLock.Lock()
+	defer s.drainLock.Unlock()
+	if s.draining {
+		return false
+	}
+	s.inFlightRPCs++
+	return true
+}
+
+// endRPC marks the completion of a request started with beginRPC.
+func (s *Service) endRPC() {
+	s.drainLock.Lock()
+	defer s.drainLock.Unlock()
+	s.inFlightRPCs--
+}
@@ -0,0 +1,33 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestService_Drain(t *testing.T) {
+	s := &Service{}
+	require.Equal(t, true, s.beginRPC())
+	require.Equal(t, true, s.beginRPC())
+	require.Equal(t, 2, s.InFlightRPCs())
+
+	s.Drain()
+	require.Equal(t, true, s.Draining())
+	require.Equal(t, false, s.beginRPC(), "Expected new requests to be rejected while draining")
+	require.Equal(t, 2, s.InFlightRPCs())
+
+	s.endRPC()
+	s.endRPC()
+	require.Equal(t, 0, s.InFlightRPCs())
+}
+
+func TestService_Resume(t *testing.T) {
+	s := &Service{}
+	s.Drain()
+	require.Equal(t, false, s.beginRPC())
+	s.Resume()
+	require.Equal(t, false, s.Draining())
+	require.Equal(t, true, s.beginRPC())
+	require.Equal(t, 1, s.InFlightRPCs())
+}
@@ -0,0 +1,60 @@
+package sync
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+)
+
+// The factor by which the number of blocks served per second to a peer is reduced while
+// duty priority is active.
+const dutyPriorityServingFactor = 4
+
+// hasImminentDuty returns true if a validator attached to this node has to propose a block in
+// the next slot. Proposers are known from the proposer slots registered when validators request
+// their duties, which are keyed by an empty head root, and from the payload IDs prepared for the
+// current head. Sync committee members have a duty in every slot of a period, which is too long
+// to prioritize over other work, so they don't count.
+func (s *Service) hasImminentDuty() bool {
+	if s.cfg.proposerSlotIndexCache == nil {
+		return false
+	}
+	nextSlot := s.cfg.chain.CurrentSlot() + 1
+	if _, _, ok := s.cfg.proposerSlotIndexCache.GetProposerPayloadIDs(nextSlot, [32]byte{} /* head root */); ok {
+		return true
+	}
+	headRoot, err := s.cfg.chain.HeadRoot(s.ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not get head root to look up the next proposer")
+		return false
+	}
+	_, _, ok := s.cfg.proposerSlotIndexCache.GetProposerPayloadIDs(nextSlot, bytesutil.ToBytes32(headRoot))
+	return ok
+}
+
+// dutyPriorityActive returns true when the work leading to the duties of attached validators,
+// such as importing the parent of a proposal, should take priority over low priority work, such
+// as serving historical blocks to peers.
+func (s *Service) dutyPriorityActive() bool {
+	if !flags.Get().DutyPriority {
+		return false
+	}
+	active := s.hasImminentDuty()
+	if active {
+		dutyPriorityActive.Set(1)
+	} else {
+		dutyPriorityActive.Set(0)
+	}
+	return active
+}
+
+// servingBatchLimit returns the number of blocks that can be served to a peer in the next batch,
+// which is reduced while duty priority is active.
+func (s *Service) servingBatchLimit(limit uint64) uint64 {
+	if !s.dutyPriorityActive() {
+		return limit
+	}
+	if limit < dutyPriorityServingFactor {
+		return 1
+	}
+	return limit / dutyPriorityServingFactor
+}
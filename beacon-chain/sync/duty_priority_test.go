@@ -0,0 +1,48 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	chainMock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+)
+
+func TestServingBatchLimit_DutyPriority(t *testing.T) {
+	resetFlags := flags.Get()
+	defer flags.Init(resetFlags)
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+
+	slot := types.Slot(10)
+	proposerCache := cache.NewProposerPayloadIDsCache()
+	s := &Service{cfg: &config{
+		chain:                  &chainMock.ChainService{Slot: &slot},
+		proposerSlotIndexCache: proposerCache,
+	}}
+
+	flags.Init(&flags.GlobalFlags{DutyPriority: false})
+	proposerCache.SetProposerAndPayloadIDs(slot+1, 1, [8]byte{}, [32]byte{})
+	assert.Equal(t, uint64(64), s.servingBatchLimit(64), "Limit reduced with duty priority disabled")
+
+	flags.Init(&flags.GlobalFlags{DutyPriority: true})
+	assert.Equal(t, uint64(16), s.servingBatchLimit(64), "Limit not reduced for an upcoming proposal")
+	assert.Equal(t, uint64(1), s.servingBatchLimit(2), "Limit reduced below one block")
+
+	proposerCache.PrunePayloadIDs(slot + 2)
+	assert.Equal(t, uint64(64), s.servingBatchLimit(64), "Limit reduced without any upcoming duty")
+
+	proposerCache.SetProposerAndPayloadIDs(slot+2, 1, [8]byte{}, [32]byte{})
+	assert.Equal(t, uint64(64), s.servingBatchLimit(64), "Limit reduced for a proposal after the next slot")
+
+	headRoot := [32]byte{'a'}
+	s.cfg.chain = &chainMock.ChainService{Slot: &slot, Root: headRoot[:]}
+	proposerCache.SetProposerAndPayloadIDs(slot+1, 1, [8]byte{'b'}, headRoot)
+	assert.Equal(t, uint64(16), s.servingBatchLimit(64), "Limit not reduced for a proposal prepared on the head")
+
+	proposerCache.PrunePayloadIDs(slot + 2)
+	cache.SyncSubnetIDs.AddSyncCommitteeSubnets([]byte("pubkey"), 0, []uint64{0}, 10*time.Second)
+	assert.Equal(t, uint64(64), s.servingBatchLimit(64), "Limit reduced for a sync committee membership")
+}
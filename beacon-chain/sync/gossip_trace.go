@@ -0,0 +1,34 @@
+package sync
+
+import (
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"go.opencensus.io/trace"
+)
+
+// Number of validated gossip messages whose trace context is kept until they are handled.
+const gossipTraceSize = 1024
+
+// saveGossipTraceContext keeps the trace context of the validation of a sampled gossip message,
+// so that the handling of the message continues the same trace. This lets a block be followed
+// from its gossip receipt through its state transition, execution payload and fork choice
+// insertion as a single trace.
+func (s *Service) saveGossipTraceContext(msg *pubsub.Message, sc trace.SpanContext) {
+	if s.gossipTraceCache == nil || !sc.IsSampled() {
+		return
+	}
+	s.gossipTraceCache.Add(msg.ID, sc)
+}
+
+// gossipTraceContext returns the trace context saved for the validation of the gossip message.
+func (s *Service) gossipTraceContext(msg *pubsub.Message) (trace.SpanContext, bool) {
+	if s.gossipTraceCache == nil {
+		return trace.SpanContext{}, false
+	}
+	v, ok := s.gossipTraceCache.Get(msg.ID)
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	s.gossipTraceCache.Remove(msg.ID)
+	sc, ok := v.(trace.SpanContext)
+	return sc, ok
+}
@@ -0,0 +1,33 @@
+package sync
+
+import (
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"go.opencensus.io/trace"
+)
+
+func TestGossipTraceContext(t *testing.T) {
+	s := &Service{}
+	msg := &pubsub.Message{ID: "id"}
+	sampled := trace.SpanContext{TraceID: trace.TraceID{'a'}, SpanID: trace.SpanID{'b'}, TraceOptions: 1}
+
+	// Without a cache, no trace context is kept.
+	s.saveGossipTraceContext(msg, sampled)
+	_, ok := s.gossipTraceContext(msg)
+	assert.Equal(t, false, ok)
+
+	s.gossipTraceCache = lruwrpr.New(gossipTraceSize)
+	s.saveGossipTraceContext(msg, trace.SpanContext{TraceID: trace.TraceID{'a'}})
+	_, ok = s.gossipTraceContext(msg)
+	assert.Equal(t, false, ok, "Unsampled trace context should not be kept")
+
+	s.saveGossipTraceContext(msg, sampled)
+	sc, ok := s.gossipTraceContext(msg)
+	assert.Equal(t, true, ok)
+	assert.DeepEqual(t, sampled, sc)
+	_, ok = s.gossipTraceContext(msg)
+	assert.Equal(t, false, ok, "Trace context should only be returned once")
+}
@@ -0,0 +1,70 @@
+package initialsync
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	"go.opencensus.io/trace"
+)
+
+// preparedBatchesLookahead is the number of fetched batches which are prepared concurrently, ahead
+// of the batch being processed.
+const preparedBatchesLookahead = 4
+
+// preparedBatch is a batch of fetched blocks along with their roots. The roots and the error are
+// set once the batch is ready.
+type preparedBatch struct {
+	data  *blocksQueueFetchedData
+	roots [][32]byte
+	err   error
+	ready chan struct{}
+}
+
+// prepareBatches prepares the batches fetched by the queue concurrently, as soon as they are
+// received, and returns them in the same order. The state transitions of the batches remain
+// sequential, but they no longer wait for the preparation of their batch. The signatures of the
+// blocks are batch verified once, while the batch is transitioned. The pre-state of a batch isn't
+// fetched ahead, as it is the post-state of the batch before it, which is still the head state
+// once that batch is processed.
+func (s *Service) prepareBatches(
+	ctx context.Context, fetched <-chan *blocksQueueFetchedData) <-chan *preparedBatch {
+	prepared := make(chan *preparedBatch, preparedBatchesLookahead)
+	go func() {
+		defer close(prepared)
+		for data := range fetched {
+			batch := &preparedBatch{
+				data:  data,
+				ready: make(chan struct{}),
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case prepared <- batch:
+			}
+			go func() {
+				defer close(batch.ready)
+				batch.roots, batch.err = prepareBlocks(ctx, batch.data.blocks)
+			}()
+		}
+	}()
+	return prepared
+}
+
+// prepareBlocks computes the roots of the blocks.
+func prepareBlocks(ctx context.Context, blks []interfaces.SignedBeaconBlock) ([][32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.prepareBlocks")
+	defer span.End()
+
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		root, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		roots[i] = root
+	}
+	return roots, nil
+}
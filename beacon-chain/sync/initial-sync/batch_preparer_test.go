@@ -0,0 +1,62 @@
+package initialsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestService_prepareBatches(t *testing.T) {
+	s := &Service{cfg: &Config{}}
+
+	newBatch := func(start types.Slot, count int) []interfaces.SignedBeaconBlock {
+		var blks []interfaces.SignedBeaconBlock
+		for i := 0; i < count; i++ {
+			b := util.NewBeaconBlock()
+			b.Block.Slot = start + types.Slot(i)
+			wsb, err := blocks.NewSignedBeaconBlock(b)
+			require.NoError(t, err)
+			blks = append(blks, wsb)
+		}
+		return blks
+	}
+
+	fetched := make(chan *blocksQueueFetchedData, 3)
+	fetched <- &blocksQueueFetchedData{pid: "a", blocks: newBatch(1, 4)}
+	fetched <- &blocksQueueFetchedData{pid: "b", blocks: newBatch(5, 4)}
+	fetched <- &blocksQueueFetchedData{pid: "c", blocks: newBatch(9, 4)}
+	close(fetched)
+
+	var prepared []*preparedBatch
+	for batch := range s.prepareBatches(context.Background(), fetched) {
+		<-batch.ready
+		prepared = append(prepared, batch)
+	}
+	require.Equal(t, 3, len(prepared))
+	for i, pid := range []string{"a", "b", "c"} {
+		assert.Equal(t, pid, string(prepared[i].data.pid))
+		require.NoError(t, prepared[i].err)
+		require.Equal(t, len(prepared[i].data.blocks), len(prepared[i].roots))
+		for j, b := range prepared[i].data.blocks {
+			root, err := b.Block().HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, root, prepared[i].roots[j])
+		}
+	}
+}
+
+func TestService_prepareBatches_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := util.NewBeaconBlock()
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	_, err = prepareBlocks(ctx, []interfaces.SignedBeaconBlock{wsb})
+	require.ErrorIs(t, err, context.Canceled)
+}
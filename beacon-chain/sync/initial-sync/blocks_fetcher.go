@@ -77,6 +77,7 @@ type blocksFetcher struct {
 	blocksPerSecond uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
+	throughput      *peerThroughput
 	fetchRequests   chan *fetchRequestParams
 	fetchResponses  chan *fetchRequestResponse
 	capacityWeight  float64       // how remaining capacity affects peer selection
@@ -132,6 +133,7 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		blocksPerSecond: uint64(blocksPerSecond),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
+		throughput:      newPeerThroughput(),
 		fetchRequests:   make(chan *fetchRequestParams, maxPendingRequests),
 		fetchResponses:  make(chan *fetchRequestResponse, maxPendingRequests),
 		capacityWeight:  capacityWeight,
@@ -283,6 +285,11 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	defer span.End()
 
 	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
+	// Finalized blocks are the same on all peers, so the range is split among peers when the best
+	// peer is too slow to serve it, rather than holding back the whole batch.
+	if f.mode == modeStopOnFinalizedEpoch && len(peers) > 1 && f.throughput.slotsPerRequest(peers[0], count) < count {
+		return f.fetchBlocksFromPeers(ctx, start, count, peers)
+	}
 	req := &p2ppb.BeaconBlocksByRangeRequest{
 		StartSlot: start,
 		Count:     count,
@@ -300,6 +307,82 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	return nil, "", errNoPeersAvailable
 }
 
+// fetchBlocksFromPeers fetches blocks by splitting the range among peers, each peer being
+// requested the slots it is expected to serve in time. Parts of the range are fetched concurrently,
+// and a part which a peer fails to serve is requested from the other peers in turn. The returned
+// peer is the one which served the most blocks.
+func (f *blocksFetcher) fetchBlocksFromPeers(
+	ctx context.Context,
+	start types.Slot, count uint64,
+	peers []peer.ID,
+) ([]interfaces.SignedBeaconBlock, peer.ID, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlocksFromPeers")
+	defer span.End()
+
+	type rangePart struct {
+		req       *p2ppb.BeaconBlocksByRangeRequest
+		firstPeer int
+		blocks    []interfaces.SignedBeaconBlock
+		pid       peer.ID
+		err       error
+	}
+	var parts []*rangePart
+	for slot, end := start, start.Add(count); slot < end; {
+		i := len(parts) % len(peers)
+		n := f.throughput.slotsPerRequest(peers[i], uint64(end-slot))
+		parts = append(parts, &rangePart{
+			req: &p2ppb.BeaconBlocksByRangeRequest{
+				StartSlot: slot,
+				Count:     n,
+				Step:      1,
+			},
+			firstPeer: i,
+		})
+		slot = slot.Add(n)
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, part := range parts {
+		wg.Add(1)
+		go func(part *rangePart) {
+			defer wg.Done()
+			part.err = errNoPeersAvailable
+			for i := 0; i < len(peers); i++ {
+				pid := peers[(part.firstPeer+i)%len(peers)]
+				blocks, err := f.requestBlocks(ctx, part.req, pid)
+				if err != nil {
+					log.WithError(err).Debug("Could not request blocks by range")
+					continue
+				}
+				f.p2p.Peers().Scorers().BlockProviderScorer().Touch(pid)
+				part.blocks, part.pid, part.err = blocks, pid, nil
+				return
+			}
+		}(part)
+	}
+	wg.Wait()
+
+	blocks := make([]interfaces.SignedBeaconBlock, 0, count)
+	served := make(map[peer.ID]int)
+	var pid peer.ID
+	for _, part := range parts {
+		if part.err != nil {
+			return nil, "", part.err
+		}
+		blocks = append(blocks, part.blocks...)
+		served[part.pid] += len(part.blocks)
+		if pid == "" || served[part.pid] > served[pid] {
+			pid = part.pid
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"start": start,
+		"count": count,
+		"parts": len(parts),
+	}).Debug("Fetched blocks from several peers")
+	return blocks, pid, nil
+}
+
 // requestBlocks is a wrapper for handling BeaconBlocksByRangeRequest requests/streams.
 func (f *blocksFetcher) requestBlocks(
 	ctx context.Context,
@@ -327,7 +410,13 @@ func (f *blocksFetcher) requestBlocks(
 	}
 	f.rateLimiter.Add(pid.String(), int64(req.Count))
 	l.Unlock()
-	return prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	start := time.Now()
+	blocks, err := prysmsync.SendBeaconBlocksByRangeRequest(ctx, f.chain, f.p2p, pid, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	f.throughput.record(pid, req.Count, time.Since(start))
+	return blocks, nil
 }
 
 // requestBlocksByRoot is a wrapper for handling BeaconBlockByRootsReq requests/streams.
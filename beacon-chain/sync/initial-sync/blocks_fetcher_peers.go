@@ -40,6 +40,7 @@ func (f *blocksFetcher) removeStalePeerLocks(age time.Duration) {
 		if time.Since(lock.accessed) >= age {
 			lock.Lock()
 			delete(f.peerLocks, peerID)
+			f.throughput.forget(peerID)
 			lock.Unlock()
 		}
 	}
@@ -0,0 +1,78 @@
+package initialsync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// minSlotsPerPeerRequest is the smallest number of slots requested from a peer, when a range
+	// is split among peers according to their throughput.
+	minSlotsPerPeerRequest = 8
+	// peerRequestTargetDuration is the duration in which a peer is expected to serve its part of
+	// a range, slower peers being requested fewer slots.
+	peerRequestTargetDuration = 2 * time.Second
+	// throughputSmoothing is the weight of the latest measurement in the moving average of a
+	// peer's throughput.
+	throughputSmoothing = 0.3
+)
+
+// peerThroughput keeps the moving average of the number of slots per second served by peers.
+type peerThroughput struct {
+	sync.RWMutex
+	rates map[peer.ID]float64
+}
+
+func newPeerThroughput() *peerThroughput {
+	return &peerThroughput{
+		rates: make(map[peer.ID]float64),
+	}
+}
+
+// record updates the throughput of a peer which served a range of count slots in elapsed time.
+func (t *peerThroughput) record(pid peer.ID, count uint64, elapsed time.Duration) {
+	if count == 0 || elapsed <= 0 {
+		return
+	}
+	rate := float64(count) / elapsed.Seconds()
+	t.Lock()
+	defer t.Unlock()
+	if previous, ok := t.rates[pid]; ok {
+		rate = throughputSmoothing*rate + (1-throughputSmoothing)*previous
+	}
+	t.rates[pid] = rate
+}
+
+// rate returns the number of slots per second served by a peer, if any range was served by it.
+func (t *peerThroughput) rate(pid peer.ID) (float64, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	rate, ok := t.rates[pid]
+	return rate, ok
+}
+
+// slotsPerRequest returns the number of slots, at most count, a peer is expected to serve within
+// the target duration. Peers which served no range yet are requested all of them.
+func (t *peerThroughput) slotsPerRequest(pid peer.ID, count uint64) uint64 {
+	rate, ok := t.rate(pid)
+	if !ok {
+		return count
+	}
+	slots := uint64(rate * peerRequestTargetDuration.Seconds())
+	if slots < minSlotsPerPeerRequest {
+		slots = minSlotsPerPeerRequest
+	}
+	if slots > count {
+		slots = count
+	}
+	return slots
+}
+
+// forget drops the throughput of a peer.
+func (t *peerThroughput) forget(pid peer.ID) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.rates, pid)
+}
@@ -0,0 +1,49 @@
+package initialsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestPeerThroughput(t *testing.T) {
+	tp := newPeerThroughput()
+	fast, slow, unknown := peer.ID("fast"), peer.ID("slow"), peer.ID("unknown")
+
+	tp.record(fast, 64, 500*time.Millisecond)
+	tp.record(slow, 64, 8*time.Second)
+	tp.record(slow, 0, time.Second)
+	tp.record(slow, 64, 0)
+
+	rate, ok := tp.rate(fast)
+	require.Equal(t, true, ok)
+	assert.Equal(t, float64(128), rate)
+	rate, ok = tp.rate(slow)
+	require.Equal(t, true, ok)
+	assert.Equal(t, float64(8), rate)
+	_, ok = tp.rate(unknown)
+	assert.Equal(t, false, ok)
+
+	// Peers serve the slots they are expected to serve within the target duration.
+	assert.Equal(t, uint64(64), tp.slotsPerRequest(fast, 64))
+	assert.Equal(t, uint64(16), tp.slotsPerRequest(slow, 64))
+	assert.Equal(t, uint64(10), tp.slotsPerRequest(slow, 10))
+	assert.Equal(t, uint64(64), tp.slotsPerRequest(unknown, 64))
+
+	// The throughput is a moving average of the measurements.
+	tp.record(slow, 64, 2*time.Second)
+	latest, previous := float64(32), float64(8)
+	rate, _ = tp.rate(slow)
+	assert.Equal(t, throughputSmoothing*latest+(1-throughputSmoothing)*previous, rate)
+
+	// Very slow peers are still requested a minimum of slots.
+	tp.record(unknown, 1, time.Minute)
+	assert.Equal(t, uint64(minSlotsPerPeerRequest), tp.slotsPerRequest(unknown, 64))
+
+	tp.forget(fast)
+	_, ok = tp.rate(fast)
+	assert.Equal(t, false, ok)
+}
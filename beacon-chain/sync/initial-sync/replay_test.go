@@ -0,0 +1,113 @@
+package initialsync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v3/beacon-chain/db/testing"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+// writeReplayChain writes a chain of blocks to the directory, in files named in the reverse
+// order of the slots, and returns the block roots.
+func writeReplayChain(t *testing.T, dir string, parent [32]byte, n int) [][32]byte {
+	roots := make([][32]byte, n)
+	for i := 0; i < n; i++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = types.Slot(i + 1)
+		b.Block.ParentRoot = parent[:]
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		enc, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		name := string(rune('z'-i)) + replayBlockExtension
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), enc, 0600))
+		roots[i] = root
+		parent = root
+	}
+	return roots
+}
+
+func TestReadReplayBlocks(t *testing.T) {
+	dir := t.TempDir()
+	roots := writeReplayChain(t, dir, [32]byte{'a'}, 3)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a block"), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"+replayBlockExtension), 0700))
+
+	blks, err := readReplayBlocks(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(blks))
+	for i, b := range blks {
+		assert.Equal(t, types.Slot(i+1), b.block.Block().Slot())
+		assert.Equal(t, roots[i], b.root)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad"+replayBlockExtension), []byte{1, 2, 3}, 0600))
+	_, err = readReplayBlocks(dir)
+	require.ErrorContains(t, "bad"+replayBlockExtension, err)
+}
+
+func TestService_ReplayBlocksFromDir(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	dir := t.TempDir()
+	genesisRoot := [32]byte{'a'}
+	roots := writeReplayChain(t, dir, genesisRoot, 3)
+
+	// The first block is already known.
+	blks, err := readReplayBlocks(dir)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, blks[0].block))
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	chain := &mock.ChainService{
+		State: st,
+		Root:  roots[0][:],
+		DB:    beaconDB,
+	}
+	s := NewService(ctx, &Config{
+		DB:              beaconDB,
+		Chain:           chain,
+		StateNotifier:   chain.StateNotifier(),
+		ReplayBlocksDir: dir,
+	})
+	require.NoError(t, s.replayBlocksFromDir(dir))
+	require.Equal(t, 2, len(chain.BlocksReceived))
+	assert.Equal(t, types.Slot(2), chain.BlocksReceived[0].Block().Slot())
+	assert.Equal(t, types.Slot(3), chain.BlocksReceived[1].Block().Slot())
+
+	// Replaying again skips all the blocks.
+	require.NoError(t, s.replayBlocksFromDir(dir))
+	require.Equal(t, 2, len(chain.BlocksReceived))
+}
+
+func TestService_ReplayBlocksFromDir_ProcessingFailure(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	dir := t.TempDir()
+	writeReplayChain(t, dir, [32]byte{'a'}, 2)
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	// The parent of the first block is unknown.
+	chain := &mock.ChainService{
+		State: st,
+		Root:  make([]byte, 32),
+		DB:    beaconDB,
+	}
+	s := NewService(ctx, &Config{
+		DB:            beaconDB,
+		Chain:         chain,
+		StateNotifier: chain.StateNotifier(),
+	})
+	err = s.replayBlocksFromDir(dir)
+	require.ErrorContains(t, "could not process block of slot 1", err)
+	assert.Equal(t, 0, len(chain.BlocksReceived))
+}
@@ -82,8 +82,9 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 		return err
 	}
 
-	for data := range queue.fetchedData {
-		s.processFetchedData(ctx, genesis, s.cfg.Chain.HeadSlot(), data)
+	// Batches are prepared concurrently, while their state transitions remain sequential.
+	for batch := range s.prepareBatches(ctx, queue.fetchedData) {
+		s.processFetchedData(ctx, genesis, s.cfg.Chain.HeadSlot(), batch)
 	}
 
 	log.WithFields(logrus.Fields{
@@ -124,13 +125,18 @@ func (s *Service) syncToNonFinalizedEpoch(ctx context.Context, genesis time.Time
 	return nil
 }
 
-// processFetchedData processes data received from queue.
+// processFetchedData processes data received from queue, once it is prepared.
 func (s *Service) processFetchedData(
-	ctx context.Context, genesis time.Time, startSlot types.Slot, data *blocksQueueFetchedData) {
-	defer s.updatePeerScorerStats(data.pid, startSlot)
+	ctx context.Context, genesis time.Time, startSlot types.Slot, batch *preparedBatch) {
+	defer s.updatePeerScorerStats(batch.data.pid, startSlot)
 
+	<-batch.ready
+	if batch.err != nil {
+		log.WithError(batch.err).WithField("peer", batch.data.pid).Warn("Batch is not processed")
+		return
+	}
 	// Use Batch Block Verify to process and verify batches directly.
-	if err := s.processBatchedBlocks(ctx, genesis, data.blocks, s.cfg.Chain.ReceiveBlockBatch); err != nil {
+	if err := s.processPreparedBlocks(ctx, genesis, batch.data.blocks, batch.roots, s.cfg.Chain.ReceiveBlockBatch); err != nil {
 		log.WithError(err).Warn("Batch is not processed")
 	}
 }
@@ -249,44 +255,45 @@ func (s *Service) processBlock(
 
 func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
 	blks []interfaces.SignedBeaconBlock, bFunc batchBlockReceiverFn) error {
+	blockRoots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		blkRoot, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		blockRoots[i] = blkRoot
+	}
+	return s.processPreparedBlocks(ctx, genesis, blks, blockRoots, bFunc)
+}
+
+// processPreparedBlocks skips the blocks of a batch which are already processed, checks that the
+// remaining blocks form a chain and triggers the batch receiver function.
+func (s *Service) processPreparedBlocks(ctx context.Context, genesis time.Time,
+	blks []interfaces.SignedBeaconBlock, blockRoots [][32]byte, bFunc batchBlockReceiverFn) error {
 	if len(blks) == 0 {
 		return errors.New("0 blocks provided into method")
 	}
-	firstBlock := blks[0]
-	blkRoot, err := firstBlock.Block().HashTreeRoot()
-	if err != nil {
-		return err
+	if len(blks) != len(blockRoots) {
+		return fmt.Errorf("%d blocks provided with %d roots", len(blks), len(blockRoots))
 	}
 	headSlot := s.cfg.Chain.HeadSlot()
-	for headSlot >= firstBlock.Block().Slot() && s.isProcessedBlock(ctx, firstBlock, blkRoot) {
+	for headSlot >= blks[0].Block().Slot() && s.isProcessedBlock(ctx, blks[0], blockRoots[0]) {
 		if len(blks) == 1 {
 			return errors.New("no good blocks in batch")
 		}
-		blks = blks[1:]
-		firstBlock = blks[0]
-		blkRoot, err = firstBlock.Block().HashTreeRoot()
-		if err != nil {
-			return err
-		}
+		blks, blockRoots = blks[1:], blockRoots[1:]
 	}
-	s.logBatchSyncStatus(genesis, blks, blkRoot)
+	firstBlock := blks[0]
+	s.logBatchSyncStatus(genesis, blks, blockRoots[0])
 	parentRoot := bytesutil.ToBytes32(firstBlock.Block().ParentRoot())
 	if !s.cfg.Chain.HasBlock(ctx, parentRoot) {
 		return fmt.Errorf("%w: %#x (in processBatchedBlocks, slot=%d)", errParentDoesNotExist, firstBlock.Block().ParentRoot(), firstBlock.Block().Slot())
 	}
-	blockRoots := make([][32]byte, len(blks))
-	blockRoots[0] = blkRoot
 	for i := 1; i < len(blks); i++ {
-		b := blks[i]
-		if !bytes.Equal(b.Block().ParentRoot(), blockRoots[i-1][:]) {
+		if !bytes.Equal(blks[i].Block().ParentRoot(), blockRoots[i-1][:]) {
 			return fmt.Errorf("expected linear block list with parent root of %#x but received %#x",
-				blockRoots[i-1][:], b.Block().ParentRoot())
+				blockRoots[i-1][:], blks[i].Block().ParentRoot())
 		}
-		blkRoot, err := b.Block().HashTreeRoot()
-		if err != nil {
-			return err
-		}
-		blockRoots[i] = blkRoot
 	}
 	return bFunc(ctx, blks, blockRoots)
 }
@@ -39,6 +39,9 @@ type Config struct {
 	Chain         blockchainService
 	StateNotifier statefeed.Notifier
 	BlockNotifier blockfeed.Notifier
+	// ReplayBlocksDir is a directory of SSZ encoded blocks processed on startup, before syncing
+	// from the network.
+	ReplayBlocksDir string
 }
 
 // Service service.
@@ -82,6 +85,14 @@ func (s *Service) Start() {
 		log.Debug("Exiting Initial Sync Service")
 		return
 	}
+	if s.cfg.ReplayBlocksDir != "" {
+		if err := s.replayBlocksFromDir(s.cfg.ReplayBlocksDir); err != nil {
+			if errors.Is(s.ctx.Err(), context.Canceled) {
+				return
+			}
+			log.WithError(err).Fatal("Could not replay blocks from directory")
+		}
+	}
 	if genesis.After(prysmTime.Now()) {
 		s.markSynced(genesis)
 		log.WithField("genesisTime", genesis).Info("Genesis time has not arrived - not syncing")
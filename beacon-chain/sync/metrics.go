@@ -16,6 +16,30 @@ import (
 )
 
 var (
+	dutyPriorityActive = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "sync_duty_priority_active",
+			Help: "Whether low priority work is throttled for a duty of an attached validator in the next slot.",
+		},
+	)
+	attestationSubnetsInGracePeriod = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "attestation_subnets_in_grace_period",
+			Help: "The number of attestation subnets kept subscribed after the end of the aggregation duties on them.",
+		},
+	)
+	attestationSubnetGraceMessages = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "attestation_subnet_grace_period_messages_total",
+			Help: "Count of messages received on attestation subnets kept subscribed for their grace period.",
+		},
+	)
+	attestationSubnetGraceBytes = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "attestation_subnet_grace_period_bytes_total",
+			Help: "Count of bytes received on attestation subnets kept subscribed for their grace period.",
+		},
+	)
 	topicPeerCount = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "p2p_topic_peer_count",
@@ -56,6 +80,19 @@ var (
 		},
 		[]string{"topic"},
 	)
+	gossipValidationQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "p2p_validation_queue_depth",
+			Help: "The number of messages waiting for a validation worker.",
+		}, []string{"topic"},
+	)
+	gossipValidationDroppedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_validation_queue_dropped_total",
+			Help: "Count of messages dropped from a full validation queue or which timed out waiting for a validation worker.",
+		},
+		[]string{"topic"},
+	)
 	numberOfTimesResyncedCounter = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "number_of_times_resynced",
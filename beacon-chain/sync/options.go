@@ -2,12 +2,15 @@ package sync
 
 import (
 	"github.com/prysmaticlabs/prysm/v3/async/event"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	blockfeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
@@ -52,6 +55,13 @@ func WithExitPool(exitPool voluntaryexits.PoolManager) Option {
 	}
 }
 
+func WithBLSToExecPool(blsToExecPool blstoexec.PoolManager) Option {
+	return func(s *Service) error {
+		s.cfg.blsToExecPool = blsToExecPool
+		return nil
+	}
+}
+
 func WithSlashingPool(slashingPool slashings.PoolManager) Option {
 	return func(s *Service) error {
 		s.cfg.slashingPool = slashingPool
@@ -128,3 +138,28 @@ func WithExecutionPayloadReconstructor(r execution.ExecutionPayloadReconstructor
 		return nil
 	}
 }
+
+func WithProposerSlotIndexCache(c *cache.ProposerPayloadIDsCache) Option {
+	return func(s *Service) error {
+		s.cfg.proposerSlotIndexCache = c
+		return nil
+	}
+}
+
+// WithGossipValidationLimits sets the validation limits of the gossip topics, by topic name.
+// Topics without a limit are validated with the default concurrency of pubsub.
+func WithGossipValidationLimits(limits map[string]*GossipValidationLimit) Option {
+	return func(s *Service) error {
+		s.cfg.gossipValidationLimits = limits
+		return nil
+	}
+}
+
+// WithArrivalTracker sets the tracker recording the arrival times of the blocks and
+// attestations received over gossip.
+func WithArrivalTracker(t *arrival.Tracker) Option {
+	return func(s *Service) error {
+		s.cfg.arrivalTracker = t
+		return nil
+	}
+}
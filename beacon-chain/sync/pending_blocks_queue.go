@@ -27,24 +27,34 @@ import (
 
 var processPendingBlocksPeriod = slots.DivideSlotBy(3 /* times per slot */)
 
+// The pending blocks queue is processed at this shorter period while duty priority is active, so
+// that the parent of a proposal is imported as soon as its own parent arrives.
+var dutyPendingBlocksPeriod = slots.DivideSlotBy(12 /* times per slot */)
+
 const maxPeerRequest = 50
 const numOfTries = 5
 const maxBlocksPerSlot = 3
 
-// processes pending blocks queue on every processPendingBlocksPeriod
+// processes pending blocks queue on every processPendingBlocksPeriod, or every dutyPendingBlocksPeriod
+// while duty priority is active.
 func (s *Service) processPendingBlocksQueue() {
 	// Prevents multiple queue processing goroutines (invoked by RunEvery) from contending for data.
 	locker := new(sync.Mutex)
-	async.RunEvery(s.ctx, processPendingBlocksPeriod, func() {
+	var lastProcessed time.Time
+	async.RunEvery(s.ctx, dutyPendingBlocksPeriod, func() {
 		// Don't process the pending blocks if genesis time has not been set. The chain is not ready.
 		if !s.isGenesisTimeSet() {
 			return
 		}
 		locker.Lock()
+		defer locker.Unlock()
+		if time.Since(lastProcessed) < processPendingBlocksPeriod && !s.dutyPriorityActive() {
+			return
+		}
+		lastProcessed = time.Now()
 		if err := s.processPendingBlocks(s.ctx); err != nil {
 			log.WithError(err).Debug("Could not process pending blocks")
 		}
-		locker.Unlock()
 	})
 }
 
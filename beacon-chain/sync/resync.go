@@ -0,0 +1,188 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// ResyncResult describes the outcome of a targeted resync of a slot range.
+type ResyncResult struct {
+	StartSlot     types.Slot
+	EndSlot       types.Slot
+	Peer          peer.ID
+	Blocks        int
+	DeletedStates int
+}
+
+// ResyncRange repairs the finalized slot range [start, end] of the database without wiping it.
+// The canonical blocks of the range are downloaded again from peers and overwrite the blocks
+// stored for the range, and the states saved for the range are deleted so that they get
+// regenerated from the repaired blocks when needed.
+//
+// Downloaded blocks are trusted only if they link the first readable canonical block after the
+// range to the last canonical block before it, and carry a valid proposer signature.
+func (s *Service) ResyncRange(ctx context.Context, start, end types.Slot) (*ResyncResult, error) {
+	ctx, span := trace.StartSpan(ctx, "sync.ResyncRange")
+	defer span.End()
+
+	if start == 0 || end < start {
+		return nil, errors.Errorf("invalid slot range [%d, %d]", start, end)
+	}
+	if count := uint64(end-start) + 1; count > params.BeaconNetworkConfig().MaxRequestBlocks {
+		return nil, errors.Errorf("slot range of %d slots exceeds the maximum of %d", count, params.BeaconNetworkConfig().MaxRequestBlocks)
+	}
+	cp := s.cfg.chain.FinalizedCheckpt()
+	finalizedSlot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	if end >= finalizedSlot {
+		return nil, errors.Errorf("slot range must end before the finalized slot %d", finalizedSlot)
+	}
+
+	child, err := s.canonicalBlockAfter(ctx, end, finalizedSlot)
+	if err != nil {
+		return nil, err
+	}
+	parentRoot, err := s.canonicalRootBefore(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	headState, err := s.cfg.chain.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+
+	_, pids := s.cfg.p2p.Peers().BestFinalized(maxPeerRequest, cp.Epoch)
+	if len(pids) == 0 {
+		return nil, errors.New("no peers to resync from")
+	}
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     uint64(end-start) + 1,
+		Step:      1,
+	}
+	for _, pid := range pids {
+		blks, err := SendBeaconBlocksByRangeRequest(ctx, s.cfg.chain, s.cfg.p2p, pid, req, nil)
+		if err == nil {
+			err = verifyResyncedBlocks(blks, parentRoot, bytesutil.ToBytes32(child.Block().ParentRoot()), func(blk interfaces.SignedBeaconBlock) error {
+				return blocks.VerifyBlockSignatureUsingCurrentFork(headState, blk)
+			})
+			if err != nil {
+				s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			}
+		}
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not resync slot range from peer")
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		if err := s.cfg.beaconDB.ReplaceBlocks(ctx, blks); err != nil {
+			return nil, errors.Wrap(err, "could not save resynced blocks")
+		}
+		result := &ResyncResult{StartSlot: start, EndSlot: end, Peer: pid, Blocks: len(blks)}
+		for _, blk := range blks {
+			root, err := blk.Block().HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			if root == bytesutil.ToBytes32(cp.Root) || !s.cfg.beaconDB.HasState(ctx, root) {
+				continue
+			}
+			if err := s.cfg.beaconDB.DeleteState(ctx, root); err != nil {
+				return nil, errors.Wrapf(err, "could not delete state of block %#x", bytesutil.Trunc(root[:]))
+			}
+			result.DeletedStates++
+		}
+		log.WithFields(logrus.Fields{
+			"startSlot":     start,
+			"endSlot":       end,
+			"peer":          pid,
+			"blocks":        result.Blocks,
+			"deletedStates": result.DeletedStates,
+		}).Info("Resynced slot range")
+		return result, nil
+	}
+	return nil, errors.Errorf("could not resync slot range [%d, %d] from any of %d peers", start, end, len(pids))
+}
+
+// canonicalBlockAfter returns the first canonical block after the given slot, up to maxSlot. The
+// block must be readable, as its parent root anchors the verification of the resynced blocks.
+func (s *Service) canonicalBlockAfter(ctx context.Context, slot, maxSlot types.Slot) (interfaces.SignedBeaconBlock, error) {
+	for sl := slot + 1; sl <= maxSlot; sl++ {
+		_, roots, err := s.cfg.beaconDB.BlockRootsBySlot(ctx, sl)
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range roots {
+			if !s.cfg.beaconDB.IsFinalizedBlock(ctx, root) {
+				continue
+			}
+			blk, err := s.cfg.beaconDB.Block(ctx, root)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read canonical block at slot %d, include it in the resynced range", sl)
+			}
+			if blk == nil || blk.IsNil() {
+				return nil, errors.Errorf("canonical block at slot %d is missing, include it in the resynced range", sl)
+			}
+			return blk, nil
+		}
+	}
+	return nil, errors.Errorf("no canonical block found between slots %d and %d", slot+1, maxSlot)
+}
+
+// canonicalRootBefore returns the root of the last canonical block before the given slot.
+func (s *Service) canonicalRootBefore(ctx context.Context, slot types.Slot) ([32]byte, error) {
+	for {
+		sl, roots, err := s.cfg.beaconDB.HighestRootsBelowSlot(ctx, slot)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		for _, root := range roots {
+			if s.cfg.beaconDB.IsFinalizedBlock(ctx, root) {
+				return root, nil
+			}
+		}
+		if sl == 0 || len(roots) == 0 {
+			return [32]byte{}, errors.Errorf("no canonical block found before slot %d", slot)
+		}
+		slot = sl
+	}
+}
+
+// verifyResyncedBlocks checks that the blocks, sorted by slot, form the chain from the block with
+// root parentRoot to the block with root childParentRoot, and that each block passes verify.
+func verifyResyncedBlocks(blks []interfaces.SignedBeaconBlock, parentRoot, childParentRoot [32]byte, verify func(interfaces.SignedBeaconBlock) error) error {
+	expected := childParentRoot
+	for i := len(blks) - 1; i >= 0; i-- {
+		root, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if root != expected {
+			return errors.Errorf("block at slot %d has root %#x, expected %#x", blks[i].Block().Slot(), bytesutil.Trunc(root[:]), bytesutil.Trunc(expected[:]))
+		}
+		if err := verify(blks[i]); err != nil {
+			return errors.Wrapf(err, "could not verify block at slot %d", blks[i].Block().Slot())
+		}
+		expected = bytesutil.ToBytes32(blks[i].Block().ParentRoot())
+	}
+	if expected != parentRoot {
+		return errors.Errorf("blocks do not link to the canonical block %#x before the range", bytesutil.Trunc(parentRoot[:]))
+	}
+	return nil
+}
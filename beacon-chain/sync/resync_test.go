@@ -0,0 +1,44 @@
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestVerifyResyncedBlocks(t *testing.T) {
+	parentRoot := [32]byte{'p'}
+	blks := make([]interfaces.SignedBeaconBlock, 0)
+	prev := parentRoot
+	for _, slot := range []types.Slot{3, 4, 6} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = prev[:]
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks = append(blks, blk)
+		prev, err = blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+	}
+	childParentRoot := prev
+	noop := func(interfaces.SignedBeaconBlock) error { return nil }
+
+	require.NoError(t, verifyResyncedBlocks(blks, parentRoot, childParentRoot, noop))
+	require.NoError(t, verifyResyncedBlocks(nil, parentRoot, parentRoot, noop))
+
+	err := verifyResyncedBlocks(blks[1:], parentRoot, childParentRoot, noop)
+	require.ErrorContains(t, "blocks do not link to the canonical block", err)
+	err = verifyResyncedBlocks(blks[:2], parentRoot, childParentRoot, noop)
+	require.ErrorContains(t, "block at slot 4 has root", err)
+	err = verifyResyncedBlocks([]interfaces.SignedBeaconBlock{blks[0], blks[2]}, parentRoot, childParentRoot, noop)
+	require.ErrorContains(t, "block at slot 3 has root", err)
+	err = verifyResyncedBlocks(blks, parentRoot, childParentRoot, func(interfaces.SignedBeaconBlock) error {
+		return errors.New("invalid signature")
+	})
+	require.ErrorContains(t, "could not verify block at slot 6: invalid signature", err)
+}
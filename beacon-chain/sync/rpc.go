@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/encoder"
 	p2ptypes "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
@@ -103,19 +104,30 @@ func (s *Service) unregisterPhase0Handlers() {
 	s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(fullBlockRangeTopic))
 	s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(fullBlockRootTopic))
 	s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(fullMetadataTopic))
+	if p2p.ZstdEnabled(p2p.RPCBlocksByRangeTopicV1) {
+		zstdBlockRangeTopic := p2p.RPCBlocksByRangeTopicV1 + (&encoder.SszZstdNetworkEncoder{}).ProtocolSuffix()
+		s.cfg.p2p.Host().RemoveStreamHandler(protocol.ID(zstdBlockRangeTopic))
+	}
 }
 
 // registerRPC for a given topic with an expected protobuf message type.
 func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 	topic := baseTopic + s.cfg.p2p.Encoding().ProtocolSuffix()
 	log := log.WithField("topic", topic)
-	s.cfg.p2p.SetStreamHandler(topic, func(stream network.Stream) {
+	handler := func(stream network.Stream) {
 		defer func() {
 			if r := recover(); r != nil {
 				log.WithField("error", r).Error("Panic occurred")
 				log.Errorf("%s", debug.Stack())
 			}
 		}()
+		// Reject new requests once the node has started draining for maintenance.
+		if !s.beginRPC() {
+			_err := stream.Reset()
+			_ = _err
+			return
+		}
+		defer s.endRPC()
 		ctx, cancel := context.WithTimeout(s.ctx, ttfbTimeout)
 		defer cancel()
 
@@ -188,7 +200,7 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 				log.Errorf("message of %T does not support marshaller interface", msg)
 				return
 			}
-			if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+			if err := p2p.StreamEncoding(stream, s.cfg.p2p).DecodeWithMaxLength(stream, msg); err != nil {
 				log.WithError(err).WithField("topic", topic).Debug("Could not decode stream message")
 				tracing.AnnotateError(span, err)
 				s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
@@ -208,7 +220,7 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 				log.Errorf("message of %T does not support marshaller interface", msg)
 				return
 			}
-			if err := s.cfg.p2p.Encoding().DecodeWithMaxLength(stream, msg); err != nil {
+			if err := p2p.StreamEncoding(stream, s.cfg.p2p).DecodeWithMaxLength(stream, msg); err != nil {
 				log.WithError(err).WithField("topic", topic).Debug("Could not decode stream message")
 				tracing.AnnotateError(span, err)
 				s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
@@ -222,5 +234,11 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 				tracing.AnnotateError(span, err)
 			}
 		}
-	})
+	}
+	s.cfg.p2p.SetStreamHandler(topic, handler)
+	if p2p.ZstdEnabled(baseTopic) {
+		// Peers negotiating the experimental zstd encoding are served by the same handler, which
+		// encodes the responses with the encoding of the stream.
+		s.cfg.p2p.SetStreamHandler(baseTopic+(&encoder.SszZstdNetworkEncoder{}).ProtocolSuffix(), handler)
+	}
 }
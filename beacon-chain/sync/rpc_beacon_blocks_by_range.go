@@ -48,8 +48,8 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 	// The initial count for the first batch to be returned back.
 	count := m.Count
 	allowedBlocksPerSecond := uint64(flags.Get().BlockBatchLimit)
-	if count > allowedBlocksPerSecond {
-		count = allowedBlocksPerSecond
+	if limit := s.servingBatchLimit(allowedBlocksPerSecond); count > limit {
+		count = limit
 	}
 	// initial batch start and end slots to be returned to remote peer.
 	startSlot := m.StartSlot
@@ -104,7 +104,7 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 
 		// Recalculate start and end slots for the next batch to be returned to the remote peer.
 		startSlot = endSlot.Add(m.Step)
-		endSlot = startSlot.Add(m.Step * (allowedBlocksPerSecond - 1))
+		endSlot = startSlot.Add(m.Step * (s.servingBatchLimit(allowedBlocksPerSecond) - 1))
 		if endSlot > endReqSlot {
 			endSlot = endReqSlot
 		}
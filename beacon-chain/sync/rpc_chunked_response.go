@@ -20,7 +20,7 @@ import (
 // response_chunk  ::= <result> | <context-bytes> | <encoding-dependent-header> | <encoded-payload>
 func (s *Service) chunkBlockWriter(stream libp2pcore.Stream, blk interfaces.SignedBeaconBlock) error {
 	SetStreamWriteDeadline(stream, defaultWriteDuration)
-	return WriteBlockChunk(stream, s.cfg.chain, s.cfg.p2p.Encoding(), blk)
+	return WriteBlockChunk(stream, s.cfg.chain, p2p.StreamEncoding(stream, s.cfg.p2p), blk)
 }
 
 // WriteBlockChunk writes block chunk object to stream.
@@ -64,19 +64,20 @@ func WriteBlockChunk(stream libp2pcore.Stream, chain blockchain.ChainInfoFetcher
 
 // ReadChunkedBlock handles each response chunk that is sent by the
 // peer and converts it into a beacon block.
-func ReadChunkedBlock(stream libp2pcore.Stream, chain blockchain.ForkFetcher, p2p p2p.EncodingProvider, isFirstChunk bool) (interfaces.SignedBeaconBlock, error) {
+func ReadChunkedBlock(stream libp2pcore.Stream, chain blockchain.ForkFetcher, p2pProvider p2p.EncodingProvider, isFirstChunk bool) (interfaces.SignedBeaconBlock, error) {
+	encoding := p2p.StreamEncoding(stream, p2pProvider)
 	// Handle deadlines differently for first chunk
 	if isFirstChunk {
-		return readFirstChunkedBlock(stream, chain, p2p)
+		return readFirstChunkedBlock(stream, chain, encoding)
 	}
 
-	return readResponseChunk(stream, chain, p2p)
+	return readResponseChunk(stream, chain, encoding)
 }
 
 // readFirstChunkedBlock reads the first chunked block and applies the appropriate deadlines to
 // it.
-func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ForkFetcher, p2p p2p.EncodingProvider) (interfaces.SignedBeaconBlock, error) {
-	code, errMsg, err := ReadStatusCode(stream, p2p.Encoding())
+func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ForkFetcher, encoding encoder.NetworkEncoding) (interfaces.SignedBeaconBlock, error) {
+	code, errMsg, err := ReadStatusCode(stream, encoding)
 	if err != nil {
 		return nil, err
 	}
@@ -91,15 +92,15 @@ func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ForkFetche
 	if err != nil {
 		return nil, err
 	}
-	err = p2p.Encoding().DecodeWithMaxLength(stream, blk)
+	err = encoding.DecodeWithMaxLength(stream, blk)
 	return blk, err
 }
 
 // readResponseChunk reads the response from the stream and decodes it into the
 // provided message type.
-func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ForkFetcher, p2p p2p.EncodingProvider) (interfaces.SignedBeaconBlock, error) {
+func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ForkFetcher, encoding encoder.NetworkEncoding) (interfaces.SignedBeaconBlock, error) {
 	SetStreamReadDeadline(stream, respTimeout)
-	code, errMsg, err := readStatusCodeNoDeadline(stream, p2p.Encoding())
+	code, errMsg, err := readStatusCodeNoDeadline(stream, encoding)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +116,7 @@ func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ForkFetcher, p
 	if err != nil {
 		return nil, err
 	}
-	err = p2p.Encoding().DecodeWithMaxLength(stream, blk)
+	err = encoding.DecodeWithMaxLength(stream, blk)
 	return blk, err
 }
 
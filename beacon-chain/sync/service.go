@@ -19,13 +19,16 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/async/abool"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/monitor/arrival"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/synccommittee"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/voluntaryexits"
@@ -73,6 +76,7 @@ type config struct {
 	beaconDB                      db.NoHeadAccessDatabase
 	attPool                       attestations.Pool
 	exitPool                      voluntaryexits.PoolManager
+	blsToExecPool                 blstoexec.PoolManager
 	slashingPool                  slashings.PoolManager
 	syncCommsPool                 synccommittee.Pool
 	chain                         blockchainService
@@ -84,6 +88,9 @@ type config struct {
 	stateGen                      *stategen.State
 	slasherAttestationsFeed       *event.Feed
 	slasherBlockHeadersFeed       *event.Feed
+	proposerSlotIndexCache        *cache.ProposerPayloadIDsCache
+	gossipValidationLimits        map[string]*GossipValidationLimit
+	arrivalTracker                *arrival.Tracker
 }
 
 // This defines the interface for interacting with block chain service
@@ -133,9 +140,16 @@ type Service struct {
 	seenSyncContributionCache        *lru.Cache
 	badBlockCache                    *lru.Cache
 	badBlockLock                     sync.RWMutex
+	gossipTraceCache                 *lru.Cache
 	syncContributionBitsOverlapLock  sync.RWMutex
 	syncContributionBitsOverlapCache *lru.Cache
+	attestationCommitteeCache        *lru.Cache
 	signatureChan                    chan *signatureVerifier
+	drainLock                        sync.Mutex
+	draining                         bool
+	inFlightRPCs                     int
+	graceSubnetsLock                 sync.RWMutex
+	graceSubnets                     map[uint64]bool
 }
 
 // NewService initializes new regular sync service.
@@ -146,7 +160,7 @@ func NewService(ctx context.Context, opts ...Option) *Service {
 		ctx:                  ctx,
 		cancel:               cancel,
 		chainStarted:         abool.New(),
-		cfg:                  &config{},
+		cfg:                  &config{gossipValidationLimits: defaultGossipValidationLimits()},
 		slotToPendingBlocks:  c,
 		seenPendingBlocks:    make(map[[32]byte]bool),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
@@ -226,6 +240,8 @@ func (s *Service) initCaches() {
 	s.seenAttesterSlashingCache = make(map[uint64]bool)
 	s.seenProposerSlashingCache = lruwrpr.New(seenProposerSlashingSize)
 	s.badBlockCache = lruwrpr.New(badBlockSize)
+	s.gossipTraceCache = lruwrpr.New(gossipTraceSize)
+	s.attestationCommitteeCache = lruwrpr.New(attestationCommitteeSize)
 }
 
 func (s *Service) registerHandlers() {
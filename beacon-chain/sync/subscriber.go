@@ -118,6 +118,15 @@ func (s *Service) registerSubscribers(epoch types.Epoch, digest [4]byte) {
 			)
 		}
 	}
+	// Capella Fork Version
+	if epoch >= params.BeaconConfig().CapellaForkEpoch {
+		s.subscribe(
+			p2p.BlsToExecutionChangeSubnetTopicFormat,
+			s.validateBlsToExecutionChange,
+			s.blsToExecutionChangeSubscriber,
+			digest,
+		)
+	}
 }
 
 // subscribe to a given topic with a given validator and subscription handler.
@@ -148,7 +157,15 @@ func (s *Service) subscribeWithBase(topic string, validator wrappedVal, handle s
 		return nil
 	}
 
-	if err := s.cfg.p2p.PubSub().RegisterTopicValidator(s.wrapAndReportValidation(topic, validator)); err != nil {
+	name, val := s.wrapAndReportValidation(topic, validator)
+	var opts []pubsub.ValidatorOpt
+	if q := s.validationQueue(topic); q != nil {
+		val = q.wrap(val)
+		// Messages waiting in the queue count against the concurrency limit of pubsub, which
+		// would otherwise drop them before the queue policy applies.
+		opts = append(opts, pubsub.WithValidatorConcurrency(q.limit.Workers+q.limit.QueueSize))
+	}
+	if err := s.cfg.p2p.PubSub().RegisterTopicValidator(name, val, opts...); err != nil {
 		log.WithError(err).Error("Could not register validator for topic")
 		return nil
 	}
@@ -168,7 +185,13 @@ func (s *Service) subscribeWithBase(topic string, validator wrappedVal, handle s
 	pipeline := func(msg *pubsub.Message) {
 		ctx, cancel := context.WithTimeout(s.ctx, pubsubMessageTimeout)
 		defer cancel()
-		ctx, span := trace.StartSpan(ctx, "sync.pubsub")
+		var span *trace.Span
+		if sc, ok := s.gossipTraceContext(msg); ok {
+			// Continue the trace started when the message was validated.
+			ctx, span = trace.StartSpanWithRemoteParent(ctx, "sync.pubsub", sc)
+		} else {
+			ctx, span = trace.StartSpan(ctx, "sync.pubsub")
+		}
 		defer span.End()
 
 		defer func() {
@@ -231,6 +254,9 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 		res = pubsub.ValidationIgnore // Default: ignore any message that panics.
 		ctx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
 		defer cancel()
+		ctx, span := trace.StartSpan(ctx, "sync.validateGossip")
+		defer span.End()
+		span.AddAttributes(trace.StringAttribute("topic", topic), trace.StringAttribute("peer", pid.String()))
 		messageReceivedCounter.WithLabelValues(topic).Inc()
 		if msg.Topic == nil {
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
@@ -278,6 +304,9 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 			}
 			messageIgnoredValidationCounter.WithLabelValues(topic).Inc()
 		}
+		if b == pubsub.ValidationAccept {
+			s.saveGossipTraceContext(msg, span.SpanContext())
+		}
 		return b
 	}
 }
@@ -395,6 +424,10 @@ func (s *Service) subscribeDynamicWithSubnets(
 					return
 				}
 				wantedSubs := s.retrievePersistentSubs(currentSlot)
+				// Keep the subnets of recently ended duties for their grace period.
+				graceSubs := s.graceSubnetIndices(currentSlot, wantedSubs)
+				s.setGraceSubnets(graceSubs)
+				wantedSubs = append(wantedSubs, graceSubs...)
 				// Resize as appropriate.
 				s.reValidateSubscriptions(subscriptions, wantedSubs, topicFormat, digest)
 
@@ -446,7 +479,7 @@ func (s *Service) subscribeAggregatorSubnet(
 	subnetTopic := fmt.Sprintf(topic, digest, idx)
 	// check if subscription exists and if not subscribe the relevant subnet.
 	if _, exists := subscriptions[idx]; !exists {
-		subscriptions[idx] = s.subscribeWithBase(subnetTopic, validate, handle)
+		subscriptions[idx] = s.subscribeWithBase(subnetTopic, s.withGraceAccounting(idx, validate), handle)
 	}
 	if !s.validPeersExist(subnetTopic) {
 		log.Debugf("No peers found subscribed to attestation gossip subnet with "+
@@ -673,6 +706,7 @@ func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 	currSlot := s.cfg.chain.CurrentSlot()
 	wantedSubs := s.retrievePersistentSubs(currSlot)
 	wantedSubs = slice.SetUint64(append(wantedSubs, s.attesterSubnetIndices(currSlot)...))
+	wantedSubs = append(wantedSubs, s.graceSubnetIndices(currSlot, wantedSubs)...)
 	topic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.Attestation{})]
 
 	// Map of peers in subnets
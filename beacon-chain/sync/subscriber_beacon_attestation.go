@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/container/slice"
@@ -59,3 +62,49 @@ func (_ *Service) attesterSubnetIndices(currentSlot types.Slot) []uint64 {
 	}
 	return slice.SetUint64(commIds)
 }
+
+// graceSubnetIndices returns the aggregator subnets of the duties which ended within the grace period
+// before the current slot, and which are not wanted anymore.
+func (s *Service) graceSubnetIndices(currentSlot types.Slot, wantedSubs []uint64) []uint64 {
+	grace := flags.Get().SubnetGracePeriod
+	if grace == 0 {
+		return nil
+	}
+	startSlot := types.Slot(0)
+	if currentSlot > grace {
+		startSlot = currentSlot - grace
+	}
+	var commIds []uint64
+	for i := startSlot; i < currentSlot; i++ {
+		commIds = append(commIds, cache.SubnetIDs.GetAggregatorSubnetIDs(i)...)
+	}
+	return slice.NotUint64(wantedSubs, slice.SetUint64(commIds))
+}
+
+// setGraceSubnets records the subnets the node stays subscribed to only for their grace period.
+func (s *Service) setGraceSubnets(subnets []uint64) {
+	s.graceSubnetsLock.Lock()
+	defer s.graceSubnetsLock.Unlock()
+	s.graceSubnets = make(map[uint64]bool, len(subnets))
+	for _, idx := range subnets {
+		s.graceSubnets[idx] = true
+	}
+	attestationSubnetsInGracePeriod.Set(float64(len(subnets)))
+}
+
+func (s *Service) inGracePeriod(subnet uint64) bool {
+	s.graceSubnetsLock.RLock()
+	defer s.graceSubnetsLock.RUnlock()
+	return s.graceSubnets[subnet]
+}
+
+// withGraceAccounting counts the messages received on the subnet while it is only kept for its grace period.
+func (s *Service) withGraceAccounting(subnet uint64, validate wrappedVal) wrappedVal {
+	return func(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+		if s.inGracePeriod(subnet) {
+			attestationSubnetGraceMessages.Inc()
+			attestationSubnetGraceBytes.Add(float64(len(msg.Data)))
+		}
+		return validate(ctx, pid, msg)
+	}
+}
@@ -28,6 +28,18 @@ func (s *Service) voluntaryExitSubscriber(ctx context.Context, msg proto.Message
 	return nil
 }
 
+func (s *Service) blsToExecutionChangeSubscriber(_ context.Context, msg proto.Message) error {
+	change, ok := msg.(*ethpb.SignedBLSToExecutionChange)
+	if !ok {
+		return fmt.Errorf("wrong type, expected: *ethpb.SignedBLSToExecutionChange got: %T", msg)
+	}
+	if change.Message == nil {
+		return errors.New("BLS to execution change can't be nil")
+	}
+	s.cfg.blsToExecPool.InsertBLSToExecChange(change)
+	return nil
+}
+
 func (s *Service) attesterSlashingSubscriber(ctx context.Context, msg proto.Message) error {
 	aSlashing, ok := msg.(*ethpb.AttesterSlashing)
 	if !ok {
@@ -11,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prysmaticlabs/prysm/v3/async/abool"
 	mockChain "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/cache"
@@ -709,3 +710,47 @@ func createPeer(t *testing.T, topics ...string) *p2ptest.TestP2P {
 	}
 	return p
 }
+
+func TestGraceSubnetIndices(t *testing.T) {
+	flags.Init(&flags.GlobalFlags{SubnetGracePeriod: 4})
+	defer flags.Init(new(flags.GlobalFlags))
+	defer cache.SubnetIDs.EmptyAllCaches()
+	r := Service{}
+
+	cache.SubnetIDs.AddAggregatorSubnetID(95, 1)
+	cache.SubnetIDs.AddAggregatorSubnetID(97, 2)
+	cache.SubnetIDs.AddAggregatorSubnetID(99, 3)
+	cache.SubnetIDs.AddAggregatorSubnetID(99, 4)
+	cache.SubnetIDs.AddAggregatorSubnetID(100, 5)
+
+	// Subnet 1 ended before the grace period, subnet 4 is still wanted.
+	graceSubs := r.graceSubnetIndices(100, []uint64{4, 5})
+	assert.DeepEqual(t, []uint64{2, 3}, graceSubs)
+	assert.Equal(t, 0, len(r.graceSubnetIndices(2, nil)))
+
+	flags.Init(new(flags.GlobalFlags))
+	assert.Equal(t, 0, len(r.graceSubnetIndices(100, nil)))
+}
+
+func TestWithGraceAccounting(t *testing.T) {
+	r := Service{}
+	var validated int
+	validate := func(_ context.Context, _ peer.ID, _ *pubsub.Message) (pubsub.ValidationResult, error) {
+		validated++
+		return pubsub.ValidationAccept, nil
+	}
+	msg := &pubsub.Message{Message: &pubsubpb.Message{Data: make([]byte, 10)}}
+	r.setGraceSubnets([]uint64{2})
+
+	before := testutil.ToFloat64(attestationSubnetGraceBytes)
+	res, err := r.withGraceAccounting(1, validate)(context.Background(), "", msg)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	assert.Equal(t, before, testutil.ToFloat64(attestationSubnetGraceBytes))
+
+	_, err = r.withGraceAccounting(2, validate)(context.Background(), "", msg)
+	require.NoError(t, err)
+	assert.Equal(t, before+10, testutil.ToFloat64(attestationSubnetGraceBytes))
+	assert.Equal(t, 2, validated)
+	assert.Equal(t, float64(1), testutil.ToFloat64(attestationSubnetsInGracePeriod))
+}
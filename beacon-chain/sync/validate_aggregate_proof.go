@@ -111,6 +111,11 @@ func (s *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	if seen {
 		return pubsub.ValidationIgnore, nil
 	}
+	// Reject malformed aggregates before any state access.
+	if err := s.preValidateAttestationCommittee(m.Message.Aggregate, &m.Message.AggregatorIndex); err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
 	if !s.validateBlockInAttestation(ctx, m) {
 		return pubsub.ValidationIgnore, nil
 	}
@@ -166,6 +171,19 @@ func (s *Service) validateAggregatedAtt(ctx context.Context, signed *ethpb.Signe
 		}
 	}
 
+	committee, err := helpers.BeaconCommitteeFromState(ctx, bs, attSlot, signed.Message.Aggregate.Data.CommitteeIndex)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationIgnore, err
+	}
+	s.setAttestationCommittee(signed.Message.Aggregate.Data, committee)
+
+	// Verify number of aggregation bits matches the committee size.
+	if err := helpers.VerifyBitfieldLength(signed.Message.Aggregate.AggregationBits, uint64(len(committee))); err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
 	// Verify validator index is within the beacon committee.
 	if err := validateIndexInCommittee(ctx, bs, signed.Message.Aggregate, signed.Message.AggregatorIndex); err != nil {
 		wrappedErr := errors.Wrapf(err, "Could not validate index in committee")
@@ -0,0 +1,86 @@
+package sync
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// The committees of two epochs at the maximum committee count per slot.
+const attestationCommitteeSize = 4096
+
+var errEmptyAggregationBits = errors.New("attestation has no participants")
+
+// attestationCommittee returns the committee of the attestation if it was computed by a previous
+// validation. The committee of an attestation only depends on its target checkpoint, slot and
+// committee index, as the target state determines the shuffling of the target epoch.
+func (s *Service) attestationCommittee(data *eth.AttestationData) ([]types.ValidatorIndex, bool) {
+	if s.attestationCommitteeCache == nil {
+		return nil, false
+	}
+	v, ok := s.attestationCommitteeCache.Get(attestationCommitteeKey(data))
+	if !ok {
+		return nil, false
+	}
+	committee, ok := v.([]types.ValidatorIndex)
+	return committee, ok
+}
+
+// setAttestationCommittee caches the committee of the attestation, computed from its target state.
+func (s *Service) setAttestationCommittee(data *eth.AttestationData, committee []types.ValidatorIndex) {
+	if s.attestationCommitteeCache == nil {
+		return
+	}
+	s.attestationCommitteeCache.Add(attestationCommitteeKey(data), committee)
+}
+
+func attestationCommitteeKey(data *eth.AttestationData) string {
+	b := append(bytesutil.SafeCopyBytes(data.Target.Root), bytesutil.Bytes8(uint64(data.Target.Epoch))...)
+	b = append(b, bytesutil.Bytes8(uint64(data.Slot))...)
+	return string(append(b, bytesutil.Bytes8(uint64(data.CommitteeIndex))...))
+}
+
+// preValidateAttestationCommittee checks the aggregation bits of an attestation, and the aggregator index of an
+// aggregate, against the committee of the attestation without accessing any state. The checks which need the
+// committee only run if it is cached, otherwise they are left to the validation against the target state.
+// A nil aggregator index means the attestation is unaggregated and must have a single participant.
+func (s *Service) preValidateAttestationCommittee(a *eth.Attestation, aggregatorIndex *types.ValidatorIndex) error {
+	if a.Data.CommitteeIndex >= types.CommitteeIndex(params.BeaconConfig().MaxCommitteesPerSlot) {
+		return errors.Errorf("committee index %d >= %d", a.Data.CommitteeIndex, params.BeaconConfig().MaxCommitteesPerSlot)
+	}
+	if err := validateAggregationBitsCount(a, aggregatorIndex == nil); err != nil {
+		return err
+	}
+	committee, ok := s.attestationCommittee(a.Data)
+	if !ok {
+		return nil
+	}
+	if err := helpers.VerifyBitfieldLength(a.AggregationBits, uint64(len(committee))); err != nil {
+		return err
+	}
+	if aggregatorIndex != nil {
+		for _, i := range committee {
+			if i == *aggregatorIndex {
+				return nil
+			}
+		}
+		return errors.Errorf("validator index %d is not within the committee", *aggregatorIndex)
+	}
+	return nil
+}
+
+// validateAggregationBitsCount verifies that an unaggregated attestation has exactly one participant,
+// and that an aggregate attestation has at least one.
+func validateAggregationBitsCount(a *eth.Attestation, unaggregated bool) error {
+	count := a.AggregationBits.Count()
+	if unaggregated && count != 1 {
+		return errors.New("attestation bitfield is invalid")
+	}
+	if count == 0 {
+		return errEmptyAggregationBits
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func TestPreValidateAttestationCommittee(t *testing.T) {
+	s := &Service{}
+	s.initCaches()
+	committee := []types.ValidatorIndex{3, 5, 7, 9}
+
+	newAtt := func(bits bitfield.Bitlist) *ethpb.Attestation {
+		att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bits})
+		att.Data.Slot = 5
+		att.Data.CommitteeIndex = 1
+		att.Data.Target.Root = bytesutil.PadTo([]byte{'A'}, 32)
+		return att
+	}
+	single := bitfield.NewBitlist(4)
+	single.SetBitAt(1, true)
+	double := bitfield.NewBitlist(4)
+	double.SetBitAt(1, true)
+	double.SetBitAt(2, true)
+	tooLong := bitfield.NewBitlist(5)
+	tooLong.SetBitAt(1, true)
+
+	t.Run("committee index out of range", func(t *testing.T) {
+		att := newAtt(single)
+		att.Data.CommitteeIndex = types.CommitteeIndex(params.BeaconConfig().MaxCommitteesPerSlot)
+		require.ErrorContains(t, "committee index", s.preValidateAttestationCommittee(att, nil))
+	})
+	t.Run("unaggregated without a single participant", func(t *testing.T) {
+		require.ErrorContains(t, "attestation bitfield is invalid", s.preValidateAttestationCommittee(newAtt(double), nil))
+	})
+	t.Run("aggregate without participants", func(t *testing.T) {
+		index := types.ValidatorIndex(5)
+		require.ErrorIs(t, s.preValidateAttestationCommittee(newAtt(bitfield.NewBitlist(4)), &index), errEmptyAggregationBits)
+	})
+	t.Run("unknown committee", func(t *testing.T) {
+		index := types.ValidatorIndex(4)
+		require.NoError(t, s.preValidateAttestationCommittee(newAtt(tooLong), nil))
+		require.NoError(t, s.preValidateAttestationCommittee(newAtt(tooLong), &index))
+	})
+
+	s.setAttestationCommittee(newAtt(single).Data, committee)
+	t.Run("bitfield length mismatch", func(t *testing.T) {
+		require.ErrorContains(t, "participants bitfield length", s.preValidateAttestationCommittee(newAtt(tooLong), nil))
+	})
+	t.Run("aggregator not in committee", func(t *testing.T) {
+		index := types.ValidatorIndex(4)
+		require.ErrorContains(t, "not within the committee", s.preValidateAttestationCommittee(newAtt(double), &index))
+	})
+	t.Run("valid", func(t *testing.T) {
+		index := types.ValidatorIndex(5)
+		require.NoError(t, s.preValidateAttestationCommittee(newAtt(single), nil))
+		require.NoError(t, s.preValidateAttestationCommittee(newAtt(double), &index))
+	})
+	t.Run("other target", func(t *testing.T) {
+		att := newAtt(tooLong)
+		att.Data.Target.Root = bytesutil.PadTo([]byte{'B'}, 32)
+		require.NoError(t, s.preValidateAttestationCommittee(att, nil))
+	})
+}
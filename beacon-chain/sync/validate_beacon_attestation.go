@@ -21,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
 	eth "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/attestation"
+	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	"go.opencensus.io/trace"
 )
@@ -35,6 +36,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if pid == s.cfg.p2p.PeerID() {
 		return pubsub.ValidationAccept, nil
 	}
+	receivedTime := prysmTime.Now()
 	// Attestation processing requires the target block to be present in the database, so we'll skip
 	// validating or processing attestations until fully synced.
 	if s.cfg.initialSync.Syncing() {
@@ -137,6 +139,12 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 		return pubsub.ValidationReject, errors.New("attestation data references bad block root")
 	}
 
+	// Reject malformed attestations before any state access.
+	if err := s.preValidateAttestationCommittee(att, nil); err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
 	// Verify the block being voted and the processed state is in beaconDB and the block has passed validation if it's in the beaconDB.
 	blockRoot := bytesutil.ToBytes32(att.Data.BeaconBlockRoot)
 	if !s.hasBlockAndState(ctx, blockRoot) {
@@ -171,6 +179,8 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	}
 
 	s.setSeenCommitteeIndicesSlot(att.Data.Slot, att.Data.CommitteeIndex, att.AggregationBits)
+	startTime := slots.StartTime(uint64(s.cfg.chain.GenesisTime().Unix()), att.Data.Slot)
+	s.cfg.arrivalTracker.RecordAttestation(att.Data.Slot, blockRoot, receivedTime.Sub(startTime))
 
 	msg.ValidatorData = att
 
@@ -216,6 +226,7 @@ func (s *Service) validateUnaggregatedAttWithState(ctx context.Context, a *eth.A
 		tracing.AnnotateError(span, err)
 		return pubsub.ValidationIgnore, err
 	}
+	s.setAttestationCommittee(a.Data, committee)
 
 	// Verify number of aggregation bits matches the committee size.
 	if err := helpers.VerifyBitfieldLength(a.AggregationBits, uint64(len(committee))); err != nil {
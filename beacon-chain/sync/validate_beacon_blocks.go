@@ -204,6 +204,7 @@ func (s *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		"proposerIndex":      blk.Block().ProposerIndex(),
 		"graffiti":           string(blk.Block().Body().Graffiti()),
 	}).Debug("Received block")
+	s.cfg.arrivalTracker.RecordBlock(blk.Block().Slot(), blockRoot, receivedTime.Sub(startTime))
 	return pubsub.ValidationAccept, nil
 }
 
@@ -0,0 +1,64 @@
+package sync
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// Clients who receive a BLS to execution change on this topic MUST validate the conditions within
+// process_bls_to_execution_change before forwarding it across the network. Only the first valid
+// change of a validator is forwarded.
+func (s *Service) validateBlsToExecutionChange(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The head state will be too far away to validate any BLS to execution change.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	ctx, span := trace.StartSpan(ctx, "sync.validateBlsToExecutionChange")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+
+	change, ok := m.(*ethpb.SignedBLSToExecutionChange)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	if change.Message == nil {
+		return pubsub.ValidationReject, errNilMessage
+	}
+	if slots.ToEpoch(s.cfg.chain.CurrentSlot()) < params.BeaconConfig().CapellaForkEpoch {
+		return pubsub.ValidationIgnore, nil
+	}
+	if s.cfg.blsToExecPool.ValidatorExists(change.Message.ValidatorIndex) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	headState, err := s.cfg.chain.HeadState(ctx)
+	if err != nil {
+		return pubsub.ValidationIgnore, err
+	}
+	if _, err := blocks.ValidateBLSToExecutionChange(headState, change); err != nil {
+		return pubsub.ValidationReject, err
+	}
+
+	msg.ValidatorData = change // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
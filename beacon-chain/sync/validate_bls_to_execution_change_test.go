@@ -0,0 +1,135 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	coreTime "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/v1"
+	mockSync "github.com/prysmaticlabs/prysm/v3/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func setupValidBlsToExecutionChange(t *testing.T) (*ethpb.SignedBLSToExecutionChange, state.BeaconState) {
+	priv, err := bls.RandKey()
+	require.NoError(t, err)
+	pubkey := priv.PublicKey().Marshal()
+	cred := hash.Hash(pubkey)
+	cred[0] = params.BeaconConfig().BLSWithdrawalPrefixByte
+	st, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Validators: []*ethpb.Validator{{WithdrawalCredentials: cred[:]}},
+		Fork: &ethpb.Fork{
+			CurrentVersion:  params.BeaconConfig().GenesisForkVersion,
+			PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+		},
+		Slot: params.BeaconConfig().SlotsPerEpoch * 5,
+	})
+	require.NoError(t, err)
+
+	change := &ethpb.SignedBLSToExecutionChange{
+		Message: &ethpb.BLSToExecutionChange{
+			ValidatorIndex:     0,
+			FromBlsPubkey:      pubkey,
+			ToExecutionAddress: bytes.Repeat([]byte{0x01}, 20),
+		},
+	}
+	change.Signature, err = signing.ComputeDomainAndSign(st, coreTime.CurrentEpoch(st), change.Message, params.BeaconConfig().DomainBLSToExecutionChange, priv)
+	require.NoError(t, err)
+	return change, st
+}
+
+func blsToExecutionChangeMessage(t *testing.T, r *Service, change *ethpb.SignedBLSToExecutionChange) *pubsub.Message {
+	buf := new(bytes.Buffer)
+	_, err := r.cfg.p2p.Encoding().EncodeGossip(buf, change)
+	require.NoError(t, err)
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(change)]
+	d, err := r.currentForkDigest()
+	require.NoError(t, err)
+	topic = r.addDigestToTopic(topic, d)
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		},
+	}
+}
+
+func TestValidateBlsToExecutionChange(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+
+	change, st := setupValidBlsToExecutionChange(t)
+	pool := blstoexec.NewPool()
+	r := &Service{
+		cfg: &config{
+			p2p: p2ptest.NewTestP2P(t),
+			chain: &mock.ChainService{
+				State:   st,
+				Genesis: time.Now(),
+			},
+			initialSync:   &mockSync.Sync{IsSyncing: false},
+			blsToExecPool: pool,
+		},
+	}
+
+	m := blsToExecutionChangeMessage(t, r, change)
+	res, err := r.validateBlsToExecutionChange(ctx, "", m)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	assert.NotNil(t, m.ValidatorData, "Decoded message was not set on the message validator data")
+
+	// Only the first change of a validator is forwarded.
+	require.NoError(t, r.blsToExecutionChangeSubscriber(ctx, change))
+	res, err = r.validateBlsToExecutionChange(ctx, "", blsToExecutionChangeMessage(t, r, change))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+
+	// A change signed by another key is rejected.
+	invalid, _ := setupValidBlsToExecutionChange(t)
+	r.cfg.blsToExecPool = blstoexec.NewPool()
+	res, err = r.validateBlsToExecutionChange(ctx, "", blsToExecutionChangeMessage(t, r, invalid))
+	require.ErrorContains(t, "withdrawal credentials do not match", err)
+	assert.Equal(t, pubsub.ValidationReject, res)
+}
+
+func TestValidateBlsToExecutionChange_BeforeCapella(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 100
+	params.OverrideBeaconConfig(cfg)
+
+	change, st := setupValidBlsToExecutionChange(t)
+	r := &Service{
+		cfg: &config{
+			p2p: p2ptest.NewTestP2P(t),
+			chain: &mock.ChainService{
+				State:   st,
+				Genesis: time.Now(),
+			},
+			initialSync:   &mockSync.Sync{IsSyncing: false},
+			blsToExecPool: blstoexec.NewPool(),
+		},
+	}
+	res, err := r.validateBlsToExecutionChange(context.Background(), "", blsToExecutionChangeMessage(t, r, change))
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+}
@@ -0,0 +1,216 @@
+package sync
+
+import (
+	"container/list"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+)
+
+// GossipQueuePolicy tells which message is dropped when a new message arrives while the
+// validation queue of its topic is full.
+type GossipQueuePolicy uint8
+
+const (
+	// DropNewest drops the new message, keeping the messages which already wait for validation.
+	DropNewest GossipQueuePolicy = iota
+	// DropOldest drops the message which waited the longest for validation, as it is the most
+	// likely to be stale by the time it is validated.
+	DropOldest
+)
+
+// String returns the name of the policy, as given in the gossip validation limits flag.
+func (p GossipQueuePolicy) String() string {
+	switch p {
+	case DropNewest:
+		return "drop-new"
+	case DropOldest:
+		return "drop-oldest"
+	default:
+		return "unknown"
+	}
+}
+
+// GossipValidationLimit bounds the validation of the messages of a gossip topic. At most Workers
+// messages of the topic are validated concurrently, and up to QueueSize more messages wait for
+// a worker. Messages are dropped according to the policy when the queue is full.
+type GossipValidationLimit struct {
+	Workers   int
+	QueueSize int
+	Policy    GossipQueuePolicy
+}
+
+// Attestations arrive in large bursts at the start of each slot, when the oldest queued
+// attestations are the least useful ones to validate.
+func defaultGossipValidationLimits() map[string]*GossipValidationLimit {
+	return map[string]*GossipValidationLimit{
+		"beacon_attestation":         {Workers: 1024, QueueSize: 1024, Policy: DropOldest},
+		"beacon_aggregate_and_proof": {Workers: 1024, QueueSize: 1024, Policy: DropOldest},
+	}
+}
+
+// ParseGossipValidationLimits parses the gossip validation limits given by the user, in the
+// format <topic>=<workers>:<queue size>[:drop-new|drop-oldest], on top of the default limits.
+// Topics are given by name without fork digest, encoding or subnet, such as beacon_block or
+// beacon_attestation.
+func ParseGossipValidationLimits(entries []string) (map[string]*GossipValidationLimit, error) {
+	limits := defaultGossipValidationLimits()
+	for _, entry := range entries {
+		name, raw, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return nil, errors.Errorf("gossip validation limit %q is not in the format <topic>=<workers>:<queue size>[:policy]", entry)
+		}
+		parts := strings.Split(raw, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, errors.Errorf("gossip validation limit %q is not in the format <topic>=<workers>:<queue size>[:policy]", entry)
+		}
+		workers, err := strconv.Atoi(parts[0])
+		if err != nil || workers <= 0 {
+			return nil, errors.Errorf("invalid number of validation workers %q for topic %s", parts[0], name)
+		}
+		queueSize, err := strconv.Atoi(parts[1])
+		if err != nil || queueSize < 0 {
+			return nil, errors.Errorf("invalid validation queue size %q for topic %s", parts[1], name)
+		}
+		limit := &GossipValidationLimit{Workers: workers, QueueSize: queueSize, Policy: DropNewest}
+		if len(parts) == 3 {
+			switch parts[2] {
+			case DropNewest.String():
+			case DropOldest.String():
+				limit.Policy = DropOldest
+			default:
+				return nil, errors.Errorf("unknown validation queue policy %q for topic %s", parts[2], name)
+			}
+		}
+		limits[name] = limit
+	}
+	return limits, nil
+}
+
+var subnetSuffix = regexp.MustCompile(`_\d+$`)
+
+// gossipTopicName returns the name of a full gossip topic, without fork digest, encoding or subnet.
+func gossipTopicName(topic string) string {
+	parts := strings.Split(topic, "/")
+	if len(parts) < 4 {
+		return ""
+	}
+	return subnetSuffix.ReplaceAllString(parts[3], "")
+}
+
+// validationQueue limits the number of concurrent validations of the messages of a topic, with
+// a bounded queue of messages waiting for a validation worker.
+type validationQueue struct {
+	topic   string
+	limit   *GossipValidationLimit
+	workers chan struct{}
+	lock    sync.Mutex
+	waiting *list.List // of chan bool, receiving whether the waiting message got a worker.
+}
+
+func newValidationQueue(topic string, limit *GossipValidationLimit) *validationQueue {
+	return &validationQueue{
+		topic:   topic,
+		limit:   limit,
+		workers: make(chan struct{}, limit.Workers),
+		waiting: list.New(),
+	}
+}
+
+// validationQueue returns the validation queue of the topic, or nil if the validation of the
+// topic isn't limited.
+func (s *Service) validationQueue(topic string) *validationQueue {
+	limit, ok := s.cfg.gossipValidationLimits[gossipTopicName(topic)]
+	if !ok || limit == nil {
+		return nil
+	}
+	return newValidationQueue(topic, limit)
+}
+
+// wrap returns a validator which waits for a worker of the queue before running the validator.
+// Messages dropped from the queue are ignored.
+func (q *validationQueue) wrap(v pubsub.ValidatorEx) pubsub.ValidatorEx {
+	return func(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		waitCtx, cancel := context.WithTimeout(ctx, pubsubMessageTimeout)
+		ok := q.acquire(waitCtx)
+		cancel()
+		if !ok {
+			return pubsub.ValidationIgnore
+		}
+		defer q.release()
+		return v(ctx, pid, msg)
+	}
+}
+
+// acquire waits for a validation worker, and returns false if the message was dropped from the
+// queue or the context expired before a worker was available.
+func (q *validationQueue) acquire(ctx context.Context) bool {
+	select {
+	case q.workers <- struct{}{}:
+		return true
+	default:
+	}
+	q.lock.Lock()
+	// A worker may have been released in the meantime.
+	select {
+	case q.workers <- struct{}{}:
+		q.lock.Unlock()
+		return true
+	default:
+	}
+	if q.waiting.Len() >= q.limit.QueueSize {
+		if q.limit.Policy == DropNewest || q.waiting.Len() == 0 {
+			q.lock.Unlock()
+			gossipValidationDroppedCounter.WithLabelValues(q.topic).Inc()
+			return false
+		}
+		oldest := q.waiting.Remove(q.waiting.Front()).(chan bool)
+		oldest <- false
+		gossipValidationDroppedCounter.WithLabelValues(q.topic).Inc()
+	}
+	ready := make(chan bool, 1)
+	elem := q.waiting.PushBack(ready)
+	gossipValidationQueueDepth.WithLabelValues(q.topic).Set(float64(q.waiting.Len()))
+	q.lock.Unlock()
+
+	select {
+	case ok := <-ready:
+		return ok
+	case <-ctx.Done():
+	}
+	q.lock.Lock()
+	// The message may have been handed a worker or dropped while the context expired.
+	select {
+	case ok := <-ready:
+		q.lock.Unlock()
+		if ok {
+			q.release()
+		}
+		return false
+	default:
+	}
+	q.waiting.Remove(elem)
+	gossipValidationQueueDepth.WithLabelValues(q.topic).Set(float64(q.waiting.Len()))
+	q.lock.Unlock()
+	gossipValidationDroppedCounter.WithLabelValues(q.topic).Inc()
+	return false
+}
+
+// release hands the worker over to the next waiting message, or frees it if no message waits.
+func (q *validationQueue) release() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if front := q.waiting.Front(); front != nil {
+		q.waiting.Remove(front)
+		gossipValidationQueueDepth.WithLabelValues(q.topic).Set(float64(q.waiting.Len()))
+		front.Value.(chan bool) <- true
+		return
+	}
+	<-q.workers
+}
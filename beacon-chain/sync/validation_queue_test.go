@@ -0,0 +1,104 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestParseGossipValidationLimits(t *testing.T) {
+	limits, err := ParseGossipValidationLimits(nil)
+	require.NoError(t, err)
+	assert.DeepEqual(t, defaultGossipValidationLimits(), limits)
+
+	limits, err = ParseGossipValidationLimits([]string{"beacon_attestation=8:16", "beacon_block=2:4:drop-oldest"})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &GossipValidationLimit{Workers: 8, QueueSize: 16, Policy: DropNewest}, limits["beacon_attestation"])
+	assert.DeepEqual(t, &GossipValidationLimit{Workers: 2, QueueSize: 4, Policy: DropOldest}, limits["beacon_block"])
+	assert.DeepEqual(t, defaultGossipValidationLimits()["beacon_aggregate_and_proof"], limits["beacon_aggregate_and_proof"])
+
+	for _, entry := range []string{"beacon_block", "=1:1", "beacon_block=1", "beacon_block=0:1", "beacon_block=1:-1", "beacon_block=1:1:drop-all"} {
+		_, err = ParseGossipValidationLimits([]string{entry})
+		assert.NotNil(t, err, entry)
+	}
+}
+
+func TestGossipTopicName(t *testing.T) {
+	assert.Equal(t, "beacon_attestation", gossipTopicName("/eth2/01020304/beacon_attestation_12/ssz_snappy"))
+	assert.Equal(t, "beacon_block", gossipTopicName("/eth2/01020304/beacon_block/ssz_snappy"))
+	assert.Equal(t, "sync_committee", gossipTopicName("/eth2/01020304/sync_committee_3/ssz_snappy"))
+	assert.Equal(t, "", gossipTopicName("beacon_block"))
+}
+
+func TestValidationQueue_Policies(t *testing.T) {
+	for _, policy := range []GossipQueuePolicy{DropNewest, DropOldest} {
+		t.Run(policy.String(), func(t *testing.T) {
+			ctx := context.Background()
+			q := newValidationQueue("topic", &GossipValidationLimit{Workers: 1, QueueSize: 1, Policy: policy})
+			require.Equal(t, true, q.acquire(ctx))
+
+			queued := make(chan bool)
+			go func() {
+				queued <- q.acquire(ctx)
+			}()
+			require.NoError(t, waitForQueueDepth(q, 1))
+
+			// The queue is full, one of the waiting messages is dropped.
+			newest := make(chan bool)
+			go func() {
+				newest <- q.acquire(ctx)
+			}()
+			if policy == DropNewest {
+				assert.Equal(t, false, <-newest)
+				q.release()
+				assert.Equal(t, true, <-queued)
+			} else {
+				assert.Equal(t, false, <-queued)
+				q.release()
+				assert.Equal(t, true, <-newest)
+			}
+			q.release()
+			assert.Equal(t, 0, len(q.workers))
+		})
+	}
+}
+
+func TestValidationQueue_ContextExpired(t *testing.T) {
+	q := newValidationQueue("topic", &GossipValidationLimit{Workers: 1, QueueSize: 1, Policy: DropOldest})
+	require.Equal(t, true, q.acquire(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, false, q.acquire(ctx))
+	assert.Equal(t, 0, q.waiting.Len())
+	q.release()
+	assert.Equal(t, 0, len(q.workers))
+}
+
+func TestValidationQueue_Wrap(t *testing.T) {
+	q := newValidationQueue("topic", &GossipValidationLimit{Workers: 1, QueueSize: 0, Policy: DropNewest})
+	v := q.wrap(func(_ context.Context, _ peer.ID, _ *pubsub.Message) pubsub.ValidationResult {
+		// The worker is held while the message is validated.
+		assert.Equal(t, false, q.acquire(context.Background()))
+		return pubsub.ValidationAccept
+	})
+	assert.Equal(t, pubsub.ValidationAccept, v(context.Background(), "", &pubsub.Message{}))
+	assert.Equal(t, 0, len(q.workers))
+}
+
+func waitForQueueDepth(q *validationQueue, depth int) error {
+	for i := 0; i < 100; i++ {
+		q.lock.Lock()
+		n := q.waiting.Len()
+		q.lock.Unlock()
+		if n == depth {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return context.DeadlineExceeded
+}
@@ -0,0 +1,189 @@
+package watchdog
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+type bundleSummary struct {
+	Time         time.Time  `json:"time"`
+	HeadSlot     types.Slot `json:"head_slot"`
+	CurrentSlot  types.Slot `json:"current_slot"`
+	StallSlots   types.Slot `json:"stall_slots"`
+	LastProgress types.Slot `json:"last_progress_slot"`
+}
+
+type forkChoiceCheckpoint struct {
+	Epoch types.Epoch `json:"epoch"`
+	Root  string      `json:"root"`
+}
+
+type forkChoiceTip struct {
+	Root string     `json:"root"`
+	Slot types.Slot `json:"slot"`
+}
+
+type forkChoiceSummary struct {
+	HeadRoot                 string                `json:"head_root"`
+	JustifiedCheckpoint      *forkChoiceCheckpoint `json:"justified_checkpoint"`
+	FinalizedCheckpoint      *forkChoiceCheckpoint `json:"finalized_checkpoint"`
+	NodeCount                int                   `json:"node_count"`
+	HighestReceivedBlockSlot types.Slot            `json:"highest_received_block_slot"`
+	ProposerBoostRoot        string                `json:"proposer_boost_root"`
+	AllTipsAreInvalid        bool                  `json:"all_tips_are_invalid"`
+	Tips                     []*forkChoiceTip      `json:"tips"`
+}
+
+type peerSummary struct {
+	ID             string      `json:"id"`
+	Address        string      `json:"address,omitempty"`
+	Direction      string      `json:"direction,omitempty"`
+	State          string      `json:"state,omitempty"`
+	HeadSlot       types.Slot  `json:"head_slot"`
+	FinalizedEpoch types.Epoch `json:"finalized_epoch"`
+	Bad            bool        `json:"bad"`
+}
+
+// writeBundle writes the diagnostics archive of a stall and returns its path.
+func (s *Service) writeBundle(headSlot, currentSlot types.Slot, now time.Time) (string, error) {
+	if err := file.MkdirAll(s.cfg.OutputDir); err != nil {
+		return "", errors.Wrap(err, "could not create diagnostics directory")
+	}
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+
+	s.lock.Lock()
+	summary := &bundleSummary{
+		Time:         now.UTC(),
+		HeadSlot:     headSlot,
+		CurrentSlot:  currentSlot,
+		StallSlots:   s.cfg.StallSlots,
+		LastProgress: s.lastProgress,
+	}
+	s.lock.Unlock()
+	if err := addJSON(tw, "summary.json", summary, now); err != nil {
+		return "", err
+	}
+	stacks := new(bytes.Buffer)
+	if err := pprof.Lookup("goroutine").WriteTo(stacks, 2); err != nil {
+		return "", errors.Wrap(err, "could not dump goroutine stacks")
+	}
+	if err := addFile(tw, "goroutines.txt", stacks.Bytes(), now); err != nil {
+		return "", err
+	}
+	if s.cfg.ForkChoicer != nil {
+		if err := addJSON(tw, "forkchoice.json", s.forkChoiceSummary(), now); err != nil {
+			return "", err
+		}
+	}
+	if s.cfg.PeersProvider != nil {
+		if err := addJSON(tw, "peers.json", s.peerSummaries(), now); err != nil {
+			return "", err
+		}
+	}
+	if s.cfg.Logs != nil {
+		if err := addFile(tw, "logs.txt", s.cfg.Logs.Bytes(), now); err != nil {
+			return "", err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return "", errors.Wrap(err, "could not close archive")
+	}
+	if err := gz.Close(); err != nil {
+		return "", errors.Wrap(err, "could not compress archive")
+	}
+
+	name := fmt.Sprintf("diagnostics-%s-slot-%d.tar.gz", now.UTC().Format("20060102T150405Z"), currentSlot)
+	path := filepath.Join(s.cfg.OutputDir, name)
+	if err := file.WriteFile(path, buf.Bytes()); err != nil {
+		return "", errors.Wrap(err, "could not write diagnostics bundle")
+	}
+	return path, nil
+}
+
+func (s *Service) forkChoiceSummary() *forkChoiceSummary {
+	fc := s.cfg.ForkChoicer
+	headRoot := fc.CachedHeadRoot()
+	boost := fc.ProposerBoost()
+	summary := &forkChoiceSummary{
+		HeadRoot:                 fmt.Sprintf("%#x", headRoot),
+		NodeCount:                fc.NodeCount(),
+		HighestReceivedBlockSlot: fc.HighestReceivedBlockSlot(),
+		ProposerBoostRoot:        fmt.Sprintf("%#x", boost),
+		AllTipsAreInvalid:        fc.AllTipsAreInvalid(),
+	}
+	if cp := fc.JustifiedCheckpoint(); cp != nil {
+		summary.JustifiedCheckpoint = &forkChoiceCheckpoint{Epoch: cp.Epoch, Root: fmt.Sprintf("%#x", cp.Root)}
+	}
+	if cp := fc.FinalizedCheckpoint(); cp != nil {
+		summary.FinalizedCheckpoint = &forkChoiceCheckpoint{Epoch: cp.Epoch, Root: fmt.Sprintf("%#x", cp.Root)}
+	}
+	roots, slots := fc.Tips()
+	for i := range roots {
+		summary.Tips = append(summary.Tips, &forkChoiceTip{Root: fmt.Sprintf("%#x", roots[i]), Slot: slots[i]})
+	}
+	sort.Slice(summary.Tips, func(i, j int) bool {
+		return summary.Tips[i].Slot > summary.Tips[j].Slot
+	})
+	return summary
+}
+
+func (s *Service) peerSummaries() []*peerSummary {
+	status := s.cfg.PeersProvider.Peers()
+	pids := status.All()
+	summaries := make([]*peerSummary, 0, len(pids))
+	for _, pid := range pids {
+		summary := &peerSummary{ID: pid.String(), Bad: status.IsBad(pid)}
+		if addr, err := status.Address(pid); err == nil && addr != nil {
+			summary.Address = addr.String()
+		}
+		if dir, err := status.Direction(pid); err == nil {
+			summary.Direction = dir.String()
+		}
+		if state, err := status.ConnectionState(pid); err == nil {
+			summary.State = ethpb.ConnectionState(state).String()
+		}
+		if cs, err := status.ChainState(pid); err == nil && cs != nil {
+			summary.HeadSlot = cs.HeadSlot
+			summary.FinalizedEpoch = cs.FinalizedEpoch
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+func addJSON(tw *tar.Writer, name string, v interface{}, now time.Time) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "could not encode %s", name)
+	}
+	return addFile(tw, name, data, now)
+}
+
+func addFile(tw *tar.Writer, name string, data []byte, now time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: now,
+	}); err != nil {
+		return errors.Wrapf(err, "could not add %s to archive", name)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Wrapf(err, "could not add %s to archive", name)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+/*
+Package watchdog defines a runtime service which watches the progress of the
+head of the chain. When the head has not advanced for a configured number of
+slots, it collects a diagnostics bundle with the goroutine stacks, a fork choice
+summary, the peer list and the recent logs of the node into a timestamped
+archive, and sends a ChainStalled event to speed up the triage of the incident.
+*/
+package watchdog
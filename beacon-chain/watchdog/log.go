@@ -0,0 +1,5 @@
+package watchdog
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "watchdog")
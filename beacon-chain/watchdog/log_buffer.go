@@ -0,0 +1,65 @@
+package watchdog
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogBuffer is a logrus hook keeping the latest log entries of the node in memory, so
+// that they can be added to the diagnostics bundle.
+type LogBuffer struct {
+	lock      sync.Mutex
+	formatter logrus.Formatter
+	entries   [][]byte
+	next      int
+	full      bool
+}
+
+// NewLogBuffer returns a hook keeping the last size log entries.
+func NewLogBuffer(size int) *LogBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &LogBuffer{
+		formatter: &logrus.TextFormatter{DisableColors: true, FullTimestamp: true},
+		entries:   make([][]byte, size),
+	}
+}
+
+// Levels of the entries kept by the buffer.
+func (b *LogBuffer) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire formats the entry and keeps it, replacing the oldest one when the buffer is full.
+func (b *LogBuffer) Fire(entry *logrus.Entry) error {
+	line, err := b.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.entries[b.next] = line
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+	return nil
+}
+
+// Bytes returns the kept entries, from the oldest to the latest.
+func (b *LogBuffer) Bytes() []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	var out []byte
+	if b.full {
+		for _, line := range b.entries[b.next:] {
+			out = append(out, line...)
+		}
+	}
+	for _, line := range b.entries[:b.next] {
+		out = append(out, line...)
+	}
+	return out
+}
@@ -0,0 +1,17 @@
+package watchdog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	chainStallsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chain_progress_watchdog_stalls_total",
+		Help: "The number of times the head of the chain stopped advancing for the configured number of slots.",
+	})
+	diagnosticsBundleFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chain_progress_watchdog_bundle_failures_total",
+		Help: "The number of diagnostics bundles that could not be written.",
+	})
+)
@@ -0,0 +1,158 @@
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+// ChainInfoFetcher retrieves the head and the wall clock slot of the chain.
+type ChainInfoFetcher interface {
+	HeadSlot() types.Slot
+	CurrentSlot() types.Slot
+	GenesisTime() time.Time
+}
+
+// PeersProvider retrieves the status of the known peers.
+type PeersProvider interface {
+	Peers() *peers.Status
+}
+
+// Config of the watchdog service.
+type Config struct {
+	// StallSlots is the number of slots without head progress after which the chain is stalled.
+	StallSlots types.Slot
+	// OutputDir is the directory the diagnostics bundles are written to.
+	OutputDir     string
+	ChainInfo     ChainInfoFetcher
+	ForkChoicer   forkchoice.ForkChoicer
+	PeersProvider PeersProvider
+	StateNotifier statefeed.Notifier
+	// Logs keeps the recent logs of the node, it may be nil.
+	Logs *LogBuffer
+}
+
+// Service watches the progress of the head of the chain.
+type Service struct {
+	cfg    *Config
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Locks access to lastHead, lastProgress, seen and stalled.
+	lock         sync.Mutex
+	lastHead     types.Slot
+	lastProgress types.Slot
+	seen         bool
+	stalled      bool
+}
+
+// NewService returns a watchdog service for the config.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.StallSlots == 0 {
+		return nil, errors.New("number of stall slots must be positive")
+	}
+	if cfg.ChainInfo == nil {
+		return nil, errors.New("nil chain info fetcher")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}, nil
+}
+
+// Start the watchdog loop.
+func (s *Service) Start() {
+	log.WithFields(logrus.Fields{
+		"stallSlots": s.cfg.StallSlots,
+		"outputDir":  s.cfg.OutputDir,
+	}).Info("Starting chain progress watchdog")
+	go s.run()
+}
+
+// Stop the watchdog loop.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the watchdog, an error once the chain is stalled.
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stalled {
+		return errors.Errorf("head has not advanced past slot %d", s.lastHead)
+	}
+	return nil
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if s.cfg.ChainInfo.GenesisTime().IsZero() {
+				continue
+			}
+			s.check(s.cfg.ChainInfo.HeadSlot(), s.cfg.ChainInfo.CurrentSlot())
+		}
+	}
+}
+
+// check records the progress of the head, and collects the diagnostics the first time the head
+// has not advanced for the configured number of slots.
+func (s *Service) check(headSlot, currentSlot types.Slot) {
+	s.lock.Lock()
+	if !s.seen || headSlot != s.lastHead {
+		s.seen = true
+		s.lastHead = headSlot
+		s.lastProgress = currentSlot
+		if s.stalled {
+			log.WithField("headSlot", headSlot).Info("Head of the chain is advancing again")
+		}
+		s.stalled = false
+		s.lock.Unlock()
+		return
+	}
+	if s.stalled || currentSlot < s.lastProgress+s.cfg.StallSlots {
+		s.lock.Unlock()
+		return
+	}
+	s.stalled = true
+	s.lock.Unlock()
+
+	chainStallsTotal.Inc()
+	path, err := s.writeBundle(headSlot, currentSlot, time.Now())
+	if err != nil {
+		diagnosticsBundleFailures.Inc()
+		log.WithError(err).Error("Could not write diagnostics bundle")
+	}
+	log.WithFields(logrus.Fields{
+		"headSlot":    headSlot,
+		"currentSlot": currentSlot,
+		"bundle":      path,
+	}).Errorf("Head of the chain has not advanced for %d slots", currentSlot-headSlot)
+	if s.cfg.StateNotifier != nil {
+		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.ChainStalled,
+			Data: &statefeed.ChainStalledData{
+				HeadSlot:    headSlot,
+				CurrentSlot: currentSlot,
+				BundlePath:  path,
+			},
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package watchdog
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mock "github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain/testing"
+	statefeed "github.com/prysmaticlabs/prysm/v3/beacon-chain/core/feed/state"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/p2p/peers/scorers"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/sirupsen/logrus"
+)
+
+type mockPeersProvider struct {
+	status *peers.Status
+}
+
+func (m *mockPeersProvider) Peers() *peers.Status {
+	return m.status
+}
+
+func setupService(t *testing.T, stallSlots types.Slot) (*Service, *mock.MockStateNotifier) {
+	status := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		ScorerParams: &scorers.Config{},
+	})
+	status.Add(nil, peer.ID("peer"), nil, network.DirOutbound)
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	logs := NewLogBuffer(2)
+	require.NoError(t, logs.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "recent log", Data: logrus.Fields{}}))
+	s, err := NewService(context.Background(), &Config{
+		StallSlots:    stallSlots,
+		OutputDir:     filepath.Join(t.TempDir(), "diagnostics"),
+		ChainInfo:     &mock.ChainService{},
+		ForkChoicer:   doublylinkedtree.New(),
+		PeersProvider: &mockPeersProvider{status: status},
+		StateNotifier: notifier,
+		Logs:          logs,
+	})
+	require.NoError(t, err)
+	// Subscribe before the first event is sent.
+	notifier.StateFeed()
+	return s, notifier
+}
+
+func TestNewService_ZeroStallSlots(t *testing.T) {
+	_, err := NewService(context.Background(), &Config{ChainInfo: &mock.ChainService{}})
+	assert.ErrorContains(t, "number of stall slots must be positive", err)
+}
+
+func TestService_Check(t *testing.T) {
+	s, notifier := setupService(t, 3)
+
+	s.check(10, 10)
+	s.check(10, 12)
+	require.NoError(t, s.Status())
+	s.check(11, 13)
+	s.check(11, 15)
+	require.NoError(t, s.Status(), "Head advanced at slot 13")
+	s.check(11, 16)
+	require.ErrorContains(t, "head has not advanced past slot 11", s.Status())
+	// A stall is only reported once.
+	s.check(11, 20)
+	s.check(12, 21)
+	require.NoError(t, s.Status())
+
+	time.Sleep(100 * time.Millisecond)
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, statefeed.ChainStalled, int(events[0].Type))
+	data, ok := events[0].Data.(*statefeed.ChainStalledData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, types.Slot(11), data.HeadSlot)
+	assert.Equal(t, types.Slot(16), data.CurrentSlot)
+	assert.Equal(t, s.cfg.OutputDir, filepath.Dir(data.BundlePath))
+	_, err := os.Stat(data.BundlePath)
+	require.NoError(t, err)
+}
+
+func TestService_WriteBundle(t *testing.T) {
+	s, _ := setupService(t, 3)
+	now := time.Date(2022, 10, 1, 12, 30, 0, 0, time.UTC)
+	path, err := s.writeBundle(5, 9, now)
+	require.NoError(t, err)
+	assert.Equal(t, "diagnostics-20221001T123000Z-slot-9.tar.gz", filepath.Base(path))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+	require.Equal(t, 5, len(files))
+	assert.Equal(t, true, strings.Contains(files["summary.json"], `"head_slot": 5`))
+	assert.Equal(t, true, strings.Contains(files["goroutines.txt"], "goroutine"))
+	assert.Equal(t, true, strings.Contains(files["forkchoice.json"], `"node_count": 0`))
+	assert.Equal(t, true, strings.Contains(files["peers.json"], peer.ID("peer").String()))
+	assert.Equal(t, true, strings.Contains(files["logs.txt"], "recent log"))
+}
+
+func TestLogBuffer(t *testing.T) {
+	b := NewLogBuffer(2)
+	assert.Equal(t, "", string(b.Bytes()))
+	for _, msg := range []string{"first", "second", "third"} {
+		require.NoError(t, b.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: msg, Data: logrus.Fields{}}))
+	}
+	logs := string(b.Bytes())
+	assert.Equal(t, false, strings.Contains(logs, "first"))
+	require.Equal(t, true, strings.Index(logs, "second") >= 0)
+	assert.Equal(t, true, strings.Index(logs, "second") < strings.Index(logs, "third"))
+}
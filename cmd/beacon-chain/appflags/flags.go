@@ -0,0 +1,126 @@
+// Package appflags defines the flags of the beacon-chain binary. They are exported so that beacon
+// nodes run in process, such as the ones of prysmctl test networks, are configured like the binary.
+package appflags
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/network"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/checkpoint"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/genesis"
+	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
+	"github.com/urfave/cli/v2"
+)
+
+// Flags of the beacon-chain binary, feature flags excluded.
+var Flags = []cli.Flag{
+	flags.DepositContractFlag,
+	flags.ExecutionEngineEndpoint,
+	flags.ExecutionJWTSecretFlag,
+	flags.AdminAuthSecretFlag,
+	flags.ExecutionMaxIdleConnsFlag,
+	flags.ExecutionKeepAliveFlag,
+	flags.RPCHost,
+	flags.RPCPort,
+	flags.RPCSlowRequestThreshold,
+	flags.CertFlag,
+	flags.KeyFlag,
+	flags.HTTPModules,
+	flags.RemoteArchiveURL,
+	flags.DisableGRPCGateway,
+	flags.GRPCGatewayHost,
+	flags.GRPCGatewayPort,
+	flags.GPRCGatewayCorsDomain,
+	flags.MinSyncPeers,
+	flags.ContractDeploymentBlock,
+	flags.SetGCPercent,
+	flags.BlockBatchLimit,
+	flags.BlockBatchLimitBurstFactor,
+	flags.DutyPriority,
+	flags.GossipValidationLimitsFlag,
+	flags.InteropMockEth1DataVotesFlag,
+	flags.InteropGenesisStateFlag,
+	flags.InteropNumValidatorsFlag,
+	flags.InteropGenesisTimeFlag,
+	flags.SlotsPerArchivedPoint,
+	flags.DBSyncPolicy,
+	flags.ReplayBlocksDir,
+	flags.CheckpointStateCacheSize,
+	flags.EnableDebugRPCEndpoints,
+	flags.SubscribeToAllSubnets,
+	flags.HistoricalSlasherNode,
+	flags.ChainID,
+	flags.NetworkID,
+	flags.WeakSubjectivityCheckpoint,
+	flags.Eth1HeaderReqLimit,
+	flags.MinPeersPerSubnet,
+	flags.AttestationSubnetGracePeriod,
+	flags.ChainProgressWatchdogSlots,
+	flags.ChainProgressWatchdogDir,
+	flags.SuggestedFeeRecipient,
+	flags.TerminalTotalDifficultyOverride,
+	flags.TerminalBlockHashOverride,
+	flags.TerminalBlockHashActivationEpochOverride,
+	flags.MevRelayEndpoint,
+	flags.MaxBuilderEpochMissedSlots,
+	flags.MaxBuilderConsecutiveMissedSlots,
+	cmd.BackupWebhookOutputDir,
+	cmd.MinimalConfigFlag,
+	cmd.E2EConfigFlag,
+	cmd.RPCMaxPageSizeFlag,
+	cmd.BootstrapNode,
+	cmd.NoDiscovery,
+	cmd.StaticPeers,
+	cmd.RelayNode,
+	cmd.P2PUDPPort,
+	cmd.P2PTCPPort,
+	cmd.P2PIP,
+	cmd.P2PHost,
+	cmd.P2PHostDNS,
+	cmd.P2PHostDNSRefresh,
+	cmd.P2PMaxPeers,
+	cmd.P2PPrivKey,
+	cmd.P2PPreSharedKey,
+	cmd.P2PMetadata,
+	cmd.P2PAllowList,
+	cmd.P2PDenyList,
+	cmd.P2PGossipOutboundCaps,
+	cmd.DataDirFlag,
+	cmd.VerbosityFlag,
+	cmd.EnableTracingFlag,
+	cmd.TracingProcessNameFlag,
+	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TraceSampleFractionFlag,
+	cmd.MonitoringHostFlag,
+	flags.MonitoringPortFlag,
+	cmd.DisableMonitoringFlag,
+	cmd.ClearDB,
+	cmd.ForceClearDB,
+	cmd.LogFormat,
+	cmd.MaxGoroutines,
+	debug.PProfFlag,
+	debug.PProfAddrFlag,
+	debug.PProfPortFlag,
+	debug.MemProfileRateFlag,
+	debug.CPUProfileFlag,
+	debug.TraceFlag,
+	debug.BlockProfileRateFlag,
+	debug.MutexProfileFractionFlag,
+	cmd.LogFileName,
+	cmd.EnableUPnPFlag,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.GrpcMaxCallRecvMsgSizeFlag,
+	cmd.AcceptTosFlag,
+	cmd.RestoreSourceFileFlag,
+	cmd.RestoreTargetDirFlag,
+	cmd.ValidatorMonitorIndicesFlag,
+	cmd.ApiTimeoutFlag,
+	checkpoint.BlockPath,
+	checkpoint.StatePath,
+	checkpoint.RemoteURL,
+	genesis.StatePath,
+	genesis.BeaconAPIURL,
+	network.NameFlag,
+}
@@ -19,6 +19,7 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 	opts := []blockchain.Option{
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
+		blockchain.WithCheckpointStateCacheMaxBytes(c.Uint64(flags.CheckpointStateCacheSize.Name) * 1024 * 1024),
 	}
 	return opts, nil
 }
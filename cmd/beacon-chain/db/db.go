@@ -31,5 +31,47 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "stats",
+			Description: `reports the number of keys and the disk space used by every bucket of the beacon node and slasher databases`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.Stats(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not report database stats")
+				}
+				return nil
+			},
+		},
+		{
+			Name: "inspect",
+			Description: `reports the disk space used by every bucket of the beacon node database, and verifies the links between its buckets: ` +
+				`states, state summaries and block indices must reference saved blocks, and the finalized blocks must form a continuous chain. ` +
+				`The beacon node must be stopped`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.VerifyStateRootsFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.Inspect(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not inspect database")
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "compact",
+			Description: `rewrites the beacon node and slasher databases without the space freed by deleted data. The beacon node must be stopped`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := beacondb.Compact(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not compact database")
+				}
+				return nil
+			},
+		},
 	},
 }
@@ -1,14 +1,13 @@
 package execution
 
 import (
-	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/network"
 	"github.com/urfave/cli/v2"
 )
 
@@ -25,6 +24,7 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	opts := []execution.Option{
 		execution.WithHttpEndpoint(endpoint),
 		execution.WithEth1HeaderRequestLimit(c.Uint64(flags.Eth1HeaderReqLimit.Name)),
+		execution.WithHttpTransportConfig(parseHttpTransportConfig(c)),
 	}
 	if len(jwtSecret) > 0 {
 		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
@@ -49,20 +49,26 @@ func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	strData := strings.TrimSpace(string(enc))
-	if len(strData) == 0 {
-		return nil, fmt.Errorf("provided JWT secret in file %s cannot be empty", jwtSecretFile)
-	}
-	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
+	secret, err := network.ParseJWTSecret(enc)
 	if err != nil {
-		return nil, err
-	}
-	if len(secret) < 32 {
-		return nil, errors.New("provided JWT secret should be a hex string of at least 32 bytes")
+		return nil, errors.Wrapf(err, "invalid JWT secret in file %s", jwtSecretFile)
 	}
 	return secret, nil
 }
 
+// Parses the connection pooling of the HTTP connections to the execution node. Flags which are
+// not set keep their default value.
+func parseHttpTransportConfig(c *cli.Context) network.TransportConfig {
+	cfg := network.DefaultTransportConfig()
+	if c.IsSet(flags.ExecutionMaxIdleConnsFlag.Name) {
+		cfg.MaxIdleConns = c.Int(flags.ExecutionMaxIdleConnsFlag.Name)
+	}
+	if c.IsSet(flags.ExecutionKeepAliveFlag.Name) {
+		cfg.KeepAlive = c.Duration(flags.ExecutionKeepAliveFlag.Name)
+	}
+	return cfg
+}
+
 func parseExecutionChainEndpoint(c *cli.Context) (string, error) {
 	if c.String(flags.ExecutionEngineEndpoint.Name) == "" {
 		return "", fmt.Errorf(
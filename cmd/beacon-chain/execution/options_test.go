@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/network"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/urfave/cli/v2"
@@ -99,3 +101,19 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	_, err := parseExecutionChainEndpoint(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
+
+func Test_parseHttpTransportConfig(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Int(flags.ExecutionMaxIdleConnsFlag.Name, 0, "")
+	set.Duration(flags.ExecutionKeepAliveFlag.Name, 0, "")
+	ctx := cli.NewContext(&app, set, nil)
+	assert.DeepEqual(t, network.DefaultTransportConfig(), parseHttpTransportConfig(ctx))
+
+	require.NoError(t, set.Set(flags.ExecutionMaxIdleConnsFlag.Name, "8"))
+	require.NoError(t, set.Set(flags.ExecutionKeepAliveFlag.Name, "1m"))
+	cfg := parseHttpTransportConfig(ctx)
+	assert.Equal(t, 8, cfg.MaxIdleConns)
+	assert.Equal(t, time.Minute, cfg.KeepAlive)
+	assert.Equal(t, network.DefaultTransportConfig().IdleConnTimeout, cfg.IdleConnTimeout)
+}
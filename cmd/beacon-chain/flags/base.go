@@ -4,6 +4,7 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/urfave/cli/v2"
@@ -44,6 +45,26 @@ var (
 			"This is not required if using an IPC connection.",
 		Value: "",
 	}
+	// AdminAuthSecretFlag provides a path to a file containing a hex-encoded secret which enables the
+	// admin endpoints of the beacon node, and authenticates the requests made to them.
+	AdminAuthSecretFlag = &cli.StringFlag{
+		Name: "admin-auth-secret",
+		Usage: "Provides a path to a file containing a hex-encoded string representing a 32 byte secret, as created by " +
+			"the generate-auth-secret command. When set, the admin endpoints of the REST API are enabled, and requests " +
+			"to them must carry a JWT token signed with the secret in their Authorization header.",
+	}
+	// ExecutionMaxIdleConnsFlag sets the number of idle HTTP connections kept open to the execution node.
+	ExecutionMaxIdleConnsFlag = &cli.IntFlag{
+		Name:  "execution-max-idle-conns",
+		Usage: "Maximum number of idle HTTP connections kept open to the execution node, which are reused by engine API calls",
+		Value: 64,
+	}
+	// ExecutionKeepAliveFlag sets the TCP keep-alive interval of the HTTP connections to the execution node.
+	ExecutionKeepAliveFlag = &cli.DurationFlag{
+		Name:  "execution-keep-alive",
+		Usage: "Interval between TCP keep-alive probes of the HTTP connections to the execution node",
+		Value: 30 * time.Second,
+	}
 	// DepositContractFlag defines a flag for the deposit contract address.
 	DepositContractFlag = &cli.StringFlag{
 		Name:  "deposit-contract",
@@ -62,6 +83,13 @@ var (
 		Usage: "RPC port exposed by a beacon node",
 		Value: 4000,
 	}
+	// RPCSlowRequestThreshold defines the duration above which the gRPC requests are logged as slow.
+	RPCSlowRequestThreshold = &cli.DurationFlag{
+		Name: "rpc-slow-request-threshold",
+		Usage: "The duration above which gRPC requests are logged with their method, caller and duration, " +
+			"to find which API consumers are expensive to serve. Disabled when 0",
+		Value: 2 * time.Second,
+	}
 	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
 	MonitoringPortFlag = &cli.IntFlag{
 		Name:  "monitoring-port",
@@ -84,6 +112,13 @@ var (
 		Usage: "Comma-separated list of API module names. Possible values: `" + PrysmAPIModule + `,` + EthAPIModule + "`.",
 		Value: strings.Join([]string{PrysmAPIModule, EthAPIModule}, ","),
 	}
+	// RemoteArchiveURL defines a beacon API endpoint from which blocks and states older than the local
+	// retention are read through when requested over the API.
+	RemoteArchiveURL = &cli.StringFlag{
+		Name: "remote-archive-url",
+		Usage: "Beacon API endpoint of a remote archive, such as another beacon node or an era file server, " +
+			"from which blocks and states older than what this node stores are read through when requested over the API",
+	}
 	// DisableGRPCGateway for JSON-HTTP requests to the beacon node.
 	DisableGRPCGateway = &cli.BoolFlag{
 		Name:  "disable-grpc-gateway",
@@ -142,6 +177,28 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// ReplayBlocksDir specifies a directory of blocks to process on startup.
+	ReplayBlocksDir = &cli.StringFlag{
+		Name: "replay-blocks-dir",
+		Usage: "A directory of SSZ encoded signed blocks (*.ssz) which are processed in slot order on startup, " +
+			"before syncing from the network. Blocks already known are skipped",
+	}
+	// DBSyncPolicy specifies when the writes to the beacon database are committed.
+	DBSyncPolicy = &cli.StringFlag{
+		Name: "db-sync-policy",
+		Usage: "When the writes to the beacon database are committed. 'commit' commits every write when it is made. " +
+			"'slot' groups the frequent writes of execution chain data, and commits them after block imports and " +
+			"attestation pool snapshots, which reduces disk operations on slow disks. Blocks, " +
+			"states and checkpoints are always committed right away, and a crash only loses the grouped writes",
+		Value: "commit",
+	}
+	// CheckpointStateCacheSize bounds the memory used by the checkpoint state cache.
+	CheckpointStateCacheSize = &cli.Uint64Flag{
+		Name: "checkpoint-state-cache-size-mb",
+		Usage: "The estimated memory, in megabytes, that the states held by the checkpoint state cache can use. " +
+			"The least recently used states are evicted past this size.",
+		Value: 1024,
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -154,6 +211,21 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 10,
 	}
+	// DutyPriority prioritizes the work leading to proposals of attached validators in the next slot.
+	DutyPriority = &cli.BoolFlag{
+		Name: "duty-priority",
+		Usage: "While a validator attached to this node proposes a block in the next slot, processes blocks " +
+			"pending their parent more often and throttles serving historical blocks to peers, leaving the CPU " +
+			"to the import of the block the proposal builds on.",
+	}
+	// GossipValidationLimitsFlag sets the validation worker counts, queue sizes and queue policies of gossip topics.
+	GossipValidationLimitsFlag = &cli.StringSliceFlag{
+		Name: "gossip-validation-limits",
+		Usage: "Limits the concurrent validation of the messages of a gossip topic, in the format " +
+			"<topic>=<workers>:<queue size>[:drop-new|drop-oldest], such as beacon_attestation=256:512:drop-oldest. " +
+			"Messages beyond the workers wait in the queue, and the policy tells which message is dropped when it is full. " +
+			"Attestation topics default to 1024 workers and a queue of 1024 messages dropping the oldest ones.",
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
@@ -199,6 +271,25 @@ var (
 		Usage: "Sets the minimum number of peers that a node will attempt to peer with that are subscribed to a subnet.",
 		Value: 6,
 	}
+	// AttestationSubnetGracePeriod keeps the attestation subnets of ended aggregation duties subscribed.
+	AttestationSubnetGracePeriod = &cli.Uint64Flag{
+		Name: "attestation-subnet-grace-period",
+		Usage: "The number of slots the node stays subscribed to an attestation subnet after the last aggregation duty " +
+			"of its validators on the subnet, to keep forwarding attestations for the mesh. The cost is shown by the " +
+			"attestation_subnet_grace_period_bytes_total metric. Disabled when 0",
+	}
+	// ChainProgressWatchdogSlots collects diagnostics when the head of the chain stops advancing.
+	ChainProgressWatchdogSlots = &cli.Uint64Flag{
+		Name: "chain-progress-watchdog-slots",
+		Usage: "The number of slots without progress of the head of the chain after which a diagnostics bundle " +
+			"(goroutine stacks, fork choice summary, peer list and recent logs) is written and a chain stalled " +
+			"event is sent. Disabled when 0",
+	}
+	// ChainProgressWatchdogDir sets the directory of the diagnostics bundles of the chain progress watchdog.
+	ChainProgressWatchdogDir = &cli.StringFlag{
+		Name:  "chain-progress-watchdog-dir",
+		Usage: "The directory the chain progress watchdog writes the diagnostics bundles to. Defaults to the diagnostics directory in the data directory",
+	}
 	// SuggestedFeeRecipient specifies the fee recipient for the transaction fees.
 	SuggestedFeeRecipient = &cli.StringFlag{
 		Name:  "suggested-fee-recipient",
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"github.com/prysmaticlabs/prysm/v3/cmd"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
 )
 
@@ -13,6 +14,8 @@ type GlobalFlags struct {
 	MinimumPeersPerSubnet      int
 	BlockBatchLimit            int
 	BlockBatchLimitBurstFactor int
+	DutyPriority               bool
+	SubnetGracePeriod          types.Slot
 }
 
 var globalConfig *GlobalFlags
@@ -41,6 +44,8 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
 	cfg.BlockBatchLimitBurstFactor = ctx.Int(BlockBatchLimitBurstFactor.Name)
 	cfg.MinimumPeersPerSubnet = ctx.Int(MinPeersPerSubnet.Name)
+	cfg.DutyPriority = ctx.Bool(DutyPriority.Name)
+	cfg.SubnetGracePeriod = types.Slot(ctx.Uint64(AttestationSubnetGracePeriod.Name))
 	configureMinimumPeers(ctx, cfg)
 
 	Init(cfg)
@@ -14,11 +14,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/appflags"
 	blockchaincmd "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/blockchain"
 	dbcommands "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
 	jwtcommands "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/jwt"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/network"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/genesis"
 	"github.com/prysmaticlabs/prysm/v3/config/features"
@@ -35,99 +37,7 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-var appFlags = []cli.Flag{
-	flags.DepositContractFlag,
-	flags.ExecutionEngineEndpoint,
-	flags.ExecutionJWTSecretFlag,
-	flags.RPCHost,
-	flags.RPCPort,
-	flags.CertFlag,
-	flags.KeyFlag,
-	flags.HTTPModules,
-	flags.DisableGRPCGateway,
-	flags.GRPCGatewayHost,
-	flags.GRPCGatewayPort,
-	flags.GPRCGatewayCorsDomain,
-	flags.MinSyncPeers,
-	flags.ContractDeploymentBlock,
-	flags.SetGCPercent,
-	flags.BlockBatchLimit,
-	flags.BlockBatchLimitBurstFactor,
-	flags.InteropMockEth1DataVotesFlag,
-	flags.InteropGenesisStateFlag,
-	flags.InteropNumValidatorsFlag,
-	flags.InteropGenesisTimeFlag,
-	flags.SlotsPerArchivedPoint,
-	flags.EnableDebugRPCEndpoints,
-	flags.SubscribeToAllSubnets,
-	flags.HistoricalSlasherNode,
-	flags.ChainID,
-	flags.NetworkID,
-	flags.WeakSubjectivityCheckpoint,
-	flags.Eth1HeaderReqLimit,
-	flags.MinPeersPerSubnet,
-	flags.SuggestedFeeRecipient,
-	flags.TerminalTotalDifficultyOverride,
-	flags.TerminalBlockHashOverride,
-	flags.TerminalBlockHashActivationEpochOverride,
-	flags.MevRelayEndpoint,
-	flags.MaxBuilderEpochMissedSlots,
-	flags.MaxBuilderConsecutiveMissedSlots,
-	cmd.BackupWebhookOutputDir,
-	cmd.MinimalConfigFlag,
-	cmd.E2EConfigFlag,
-	cmd.RPCMaxPageSizeFlag,
-	cmd.BootstrapNode,
-	cmd.NoDiscovery,
-	cmd.StaticPeers,
-	cmd.RelayNode,
-	cmd.P2PUDPPort,
-	cmd.P2PTCPPort,
-	cmd.P2PIP,
-	cmd.P2PHost,
-	cmd.P2PHostDNS,
-	cmd.P2PMaxPeers,
-	cmd.P2PPrivKey,
-	cmd.P2PMetadata,
-	cmd.P2PAllowList,
-	cmd.P2PDenyList,
-	cmd.DataDirFlag,
-	cmd.VerbosityFlag,
-	cmd.EnableTracingFlag,
-	cmd.TracingProcessNameFlag,
-	cmd.TracingEndpointFlag,
-	cmd.TraceSampleFractionFlag,
-	cmd.MonitoringHostFlag,
-	flags.MonitoringPortFlag,
-	cmd.DisableMonitoringFlag,
-	cmd.ClearDB,
-	cmd.ForceClearDB,
-	cmd.LogFormat,
-	cmd.MaxGoroutines,
-	debug.PProfFlag,
-	debug.PProfAddrFlag,
-	debug.PProfPortFlag,
-	debug.MemProfileRateFlag,
-	debug.CPUProfileFlag,
-	debug.TraceFlag,
-	debug.BlockProfileRateFlag,
-	debug.MutexProfileFractionFlag,
-	cmd.LogFileName,
-	cmd.EnableUPnPFlag,
-	cmd.ConfigFileFlag,
-	cmd.ChainConfigFileFlag,
-	cmd.GrpcMaxCallRecvMsgSizeFlag,
-	cmd.AcceptTosFlag,
-	cmd.RestoreSourceFileFlag,
-	cmd.RestoreTargetDirFlag,
-	cmd.ValidatorMonitorIndicesFlag,
-	cmd.ApiTimeoutFlag,
-	checkpoint.BlockPath,
-	checkpoint.StatePath,
-	checkpoint.RemoteURL,
-	genesis.StatePath,
-	genesis.BeaconAPIURL,
-}
+var appFlags = appflags.Flags
 
 func init() {
 	appFlags = cmd.WrapFlags(append(appFlags, features.BeaconChainFlags...))
@@ -142,6 +52,8 @@ func main() {
 	app.Commands = []*cli.Command{
 		dbcommands.Commands,
 		jwtcommands.Commands,
+		network.Commands,
+		cmd.ConfigCommand(appFlags),
 	}
 
 	app.Flags = appFlags
@@ -220,6 +132,11 @@ func startNode(ctx *cli.Context) error {
 		return err
 	}
 
+	// Set the flags of the custom network being joined, before any of them is read.
+	if err := network.ConfigureFlags(ctx); err != nil {
+		return err
+	}
+
 	// verify if ToS accepted
 	if err := tos.VerifyTosAcceptedOrPrompt(ctx); err != nil {
 		return err
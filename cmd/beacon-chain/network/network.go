@@ -0,0 +1,239 @@
+// Package network registers custom networks in the data directory of the beacon node, so that a
+// custom network is joined with the network flag alone.
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/genesis"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// NameFlag joins a custom network registered in the data directory.
+	NameFlag = &cli.StringFlag{
+		Name: "network",
+		Usage: "Name of a custom network registered with the `network register` command, to join it without " +
+			"its chain config, genesis state, bootstrap nodes and deposit contract flags. The network is remembered, " +
+			"so that restarts need no flag, until it is left with the `network leave` command",
+	}
+	nameFlag = &cli.StringFlag{
+		Name:     "name",
+		Usage:    "Name of the custom network, made of lowercase letters, digits, - and _",
+		Required: true,
+	}
+	genesisStateURLFlag = &cli.StringFlag{
+		Name:  "genesis-state-url",
+		Usage: "URL from which the SSZ encoded genesis state of the custom network is downloaded, when no genesis state file is provided",
+	}
+)
+
+// Commands for managing the custom networks of a data directory.
+var Commands = &cli.Command{
+	Name:     "network",
+	Category: "network",
+	Usage:    "defines commands for registering custom networks, which are then joined with the --network flag",
+	Subcommands: []*cli.Command{
+		{
+			Name: "register",
+			Description: `saves a custom network in the data directory, along with a copy of its chain config and genesis state. ` +
+				`The genesis state is read from --genesis-state or downloaded from --genesis-state-url`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				nameFlag,
+				cmd.ChainConfigFileFlag,
+				genesis.StatePath,
+				genesisStateURLFlag,
+				cmd.BootstrapNode,
+				flags.DepositContractFlag,
+				flags.ContractDeploymentBlock,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := register(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not register network")
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "list",
+			Description: `lists the custom networks registered in the data directory, and the one joined by the beacon node`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := list(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not list networks")
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "remove",
+			Description: `deletes a custom network from the data directory`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				nameFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := NewRegistry(cliCtx.String(cmd.DataDirFlag.Name)).Remove(cliCtx.String(nameFlag.Name)); err != nil {
+					log.WithError(err).Fatal("Could not remove network")
+				}
+				return nil
+			},
+		},
+		{
+			Name: "leave",
+			Description: `forgets the custom network joined by the beacon node, which otherwise keeps joining it on restart. ` +
+				`The database of the data directory belongs to the custom network and must be cleared before joining another network`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+			Action: func(cliCtx *cli.Context) error {
+				if err := NewRegistry(cliCtx.String(cmd.DataDirFlag.Name)).Leave(); err != nil {
+					log.WithError(err).Fatal("Could not leave network")
+				}
+				return nil
+			},
+		},
+	},
+}
+
+func register(cliCtx *cli.Context) error {
+	if !cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		return fmt.Errorf("--%s is required", cmd.ChainConfigFileFlag.Name)
+	}
+	def := &Definition{
+		Name:            cliCtx.String(nameFlag.Name),
+		GenesisStateURL: cliCtx.String(genesisStateURLFlag.Name),
+	}
+	// The defaults of these flags are the ones of mainnet, so they are only saved when set.
+	if cliCtx.IsSet(cmd.BootstrapNode.Name) {
+		def.BootstrapNodes = cliCtx.StringSlice(cmd.BootstrapNode.Name)
+	}
+	if cliCtx.IsSet(flags.DepositContractFlag.Name) {
+		def.DepositContract = cliCtx.String(flags.DepositContractFlag.Name)
+	}
+	if cliCtx.IsSet(flags.ContractDeploymentBlock.Name) {
+		block := cliCtx.Int(flags.ContractDeploymentBlock.Name)
+		if block < 0 {
+			return fmt.Errorf("invalid deposit contract deployment block %d", block)
+		}
+		def.DepositContractBlock = uint64(block)
+	}
+	r := NewRegistry(cliCtx.String(cmd.DataDirFlag.Name))
+	if err := r.Register(cliCtx.Context, def, cliCtx.String(cmd.ChainConfigFileFlag.Name), cliCtx.Path(genesis.StatePath.Name)); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"network":               def.Name,
+		"configName":            def.ChainConfigName,
+		"genesisValidatorsRoot": def.GenesisValidatorsRoot,
+		"bootstrapNodes":        len(def.BootstrapNodes),
+	}).Infof("Registered network, join it with --%s=%s", NameFlag.Name, def.Name)
+	return nil
+}
+
+func list(cliCtx *cli.Context) error {
+	r := NewRegistry(cliCtx.String(cmd.DataDirFlag.Name))
+	defs, err := r.List()
+	if err != nil {
+		return err
+	}
+	joined, err := r.Joined()
+	if err != nil {
+		return err
+	}
+	for _, def := range defs {
+		marker := " "
+		if def.Name == joined {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\tconfig: %s\tgenesis validators root: %s\tbootstrap nodes: %d\n",
+			marker, def.Name, def.ChainConfigName, def.GenesisValidatorsRoot, len(def.BootstrapNodes))
+	}
+	return nil
+}
+
+// ConfigureFlags sets the chain config, genesis state, bootstrap nodes and deposit contract flags
+// from the custom network given by the network flag, or the one joined on a previous run. Flags
+// set explicitly take precedence over the network definition. The network given by the network
+// flag is recorded as joined in the data directory.
+func ConfigureFlags(cliCtx *cli.Context) error {
+	r := NewRegistry(cliCtx.String(cmd.DataDirFlag.Name))
+	joined, err := r.Joined()
+	if err != nil {
+		return errors.Wrap(err, "could not read joined network")
+	}
+	name := cliCtx.String(NameFlag.Name)
+	if name == "" {
+		if joined == "" {
+			return nil
+		}
+		name = joined
+	}
+	for _, f := range []cli.Flag{features.PraterTestnet, features.RopstenTestnet, features.SepoliaTestnet} {
+		if cliCtx.Bool(f.Names()[0]) {
+			return fmt.Errorf("custom network %s can't be joined with --%s, run the `network leave` command "+
+				"and clear the database to join another network", name, f.Names()[0])
+		}
+	}
+	def, err := r.Load(name)
+	if err != nil {
+		return err
+	}
+
+	set := func(flag, value string) error {
+		return errors.Wrapf(cliCtx.Set(flag, value), "could not set --%s", flag)
+	}
+	if !cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := set(cmd.ChainConfigFileFlag.Name, def.ChainConfigFile()); err != nil {
+			return err
+		}
+	}
+	if !cliCtx.IsSet(genesis.StatePath.Name) && !cliCtx.IsSet(genesis.BeaconAPIURL.Name) {
+		if err := set(genesis.StatePath.Name, def.GenesisStateFile()); err != nil {
+			return err
+		}
+	}
+	if !cliCtx.IsSet(cmd.BootstrapNode.Name) {
+		for _, node := range def.BootstrapNodes {
+			if err := set(cmd.BootstrapNode.Name, node); err != nil {
+				return err
+			}
+		}
+	}
+	if def.DepositContract != "" && !cliCtx.IsSet(flags.DepositContractFlag.Name) {
+		if err := set(flags.DepositContractFlag.Name, def.DepositContract); err != nil {
+			return err
+		}
+	}
+	if def.DepositContractBlock != 0 && !cliCtx.IsSet(flags.ContractDeploymentBlock.Name) {
+		if err := set(flags.ContractDeploymentBlock.Name, strconv.FormatUint(def.DepositContractBlock, 10)); err != nil {
+			return err
+		}
+	}
+
+	if name != joined {
+		if joined != "" {
+			log.WithFields(logrus.Fields{
+				"previous": joined,
+				"network":  name,
+			}).Warn("Joining another custom network, the database must be cleared if it belongs to the previous network")
+		}
+		if err := r.Join(name); err != nil {
+			return errors.Wrap(err, "could not record joined network")
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"network":    name,
+		"configName": def.ChainConfigName,
+	}).Info("Joining custom network")
+	return nil
+}
@@ -0,0 +1,93 @@
+package network
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/genesis"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func newTestContext(t *testing.T, dataDir string, args ...string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dataDir, "")
+	set.String(NameFlag.Name, "", "")
+	set.String(cmd.ChainConfigFileFlag.Name, "", "")
+	set.String(genesis.StatePath.Name, "", "")
+	set.String(genesis.BeaconAPIURL.Name, "", "")
+	set.Var(&cli.StringSlice{}, cmd.BootstrapNode.Name, "")
+	set.String(flags.DepositContractFlag.Name, "", "")
+	set.Int(flags.ContractDeploymentBlock.Name, 0, "")
+	set.Bool(features.PraterTestnet.Name, false, "")
+	set.Bool(features.RopstenTestnet.Name, false, "")
+	set.Bool(features.SepoliaTestnet.Name, false, "")
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestConfigureFlags(t *testing.T) {
+	dataDir := t.TempDir()
+	configFile, genesisFile := writeTestNetwork(t, t.TempDir(), []byte{0x10, 0, 0, 0x01})
+	r := NewRegistry(dataDir)
+	require.NoError(t, r.Register(context.Background(), &Definition{
+		Name:                 "customnet",
+		BootstrapNodes:       []string{"enr:-node1", "enr:-node2"},
+		DepositContract:      "0x4242424242424242424242424242424242424242",
+		DepositContractBlock: 100,
+	}, configFile, genesisFile))
+	def, err := r.Load("customnet")
+	require.NoError(t, err)
+
+	t.Run("no network", func(t *testing.T) {
+		cliCtx := newTestContext(t, t.TempDir())
+		require.NoError(t, ConfigureFlags(cliCtx))
+		assert.Equal(t, false, cliCtx.IsSet(cmd.ChainConfigFileFlag.Name))
+	})
+	t.Run("unknown network", func(t *testing.T) {
+		cliCtx := newTestContext(t, dataDir, "--network=othernet")
+		require.ErrorIs(t, ConfigureFlags(cliCtx), ErrUnknownNetwork)
+	})
+	t.Run("conflicting network flag", func(t *testing.T) {
+		cliCtx := newTestContext(t, dataDir, "--network=customnet", "--prater")
+		require.ErrorContains(t, "can't be joined with --prater", ConfigureFlags(cliCtx))
+	})
+	t.Run("flags from definition", func(t *testing.T) {
+		cliCtx := newTestContext(t, dataDir, "--network=customnet")
+		require.NoError(t, ConfigureFlags(cliCtx))
+		assert.Equal(t, def.ChainConfigFile(), cliCtx.String(cmd.ChainConfigFileFlag.Name))
+		assert.Equal(t, def.GenesisStateFile(), cliCtx.String(genesis.StatePath.Name))
+		assert.DeepEqual(t, []string{"enr:-node1", "enr:-node2"}, cliCtx.StringSlice(cmd.BootstrapNode.Name))
+		assert.Equal(t, def.DepositContract, cliCtx.String(flags.DepositContractFlag.Name))
+		assert.Equal(t, 100, cliCtx.Int(flags.ContractDeploymentBlock.Name))
+
+		joined, err := r.Joined()
+		require.NoError(t, err)
+		assert.Equal(t, "customnet", joined)
+	})
+	t.Run("explicit flags take precedence", func(t *testing.T) {
+		cliCtx := newTestContext(t, dataDir,
+			"--network=customnet",
+			"--genesis-beacon-api-url=http://localhost:3500",
+			"--bootstrap-node=enr:-other",
+			"--contract-deployment-block=7",
+		)
+		require.NoError(t, ConfigureFlags(cliCtx))
+		assert.Equal(t, def.ChainConfigFile(), cliCtx.String(cmd.ChainConfigFileFlag.Name))
+		assert.Equal(t, "", cliCtx.String(genesis.StatePath.Name))
+		assert.DeepEqual(t, []string{"enr:-other"}, cliCtx.StringSlice(cmd.BootstrapNode.Name))
+		assert.Equal(t, 7, cliCtx.Int(flags.ContractDeploymentBlock.Name))
+	})
+	t.Run("joined network without flag", func(t *testing.T) {
+		cliCtx := newTestContext(t, dataDir)
+		require.NoError(t, ConfigureFlags(cliCtx))
+		assert.Equal(t, def.ChainConfigFile(), cliCtx.String(cmd.ChainConfigFileFlag.Name))
+		assert.Equal(t, def.GenesisStateFile(), cliCtx.String(genesis.StatePath.Name))
+	})
+}
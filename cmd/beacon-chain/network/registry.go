@@ -0,0 +1,319 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	networksDirName      = "networks"
+	definitionFileName   = "network.yaml"
+	chainConfigFileName  = "config.yaml"
+	genesisStateFileName = "genesis.ssz"
+	// joinedFileName records the name of the network joined by the beacon node of the data directory.
+	joinedFileName = "joined"
+	// genesisDownloadTimeout bounds the download of a genesis state, which is large for networks
+	// with many validators at genesis.
+	genesisDownloadTimeout = 10 * time.Minute
+	// maxGenesisStateSize bounds the size of a downloaded genesis state, as it is kept in memory.
+	maxGenesisStateSize = 1 << 30
+)
+
+var (
+	// ErrUnknownNetwork is returned for a network which is not registered in the data directory.
+	ErrUnknownNetwork = errors.New("unknown network")
+	// ErrNetworkExists is returned when registering a network under a name which is already registered.
+	ErrNetworkExists = errors.New("network is already registered")
+	errInvalidName   = errors.New("invalid network name")
+)
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// reservedNames are the names of the networks known by the beacon node, which have flags of their own.
+var reservedNames = map[string]bool{
+	"mainnet": true,
+	"prater":  true,
+	"goerli":  true,
+	"ropsten": true,
+	"sepolia": true,
+}
+
+// Definition is a custom network registered in a data directory. Its chain config and genesis
+// state are saved next to the definition, so that the network is joined without any other file.
+type Definition struct {
+	Name                  string   `yaml:"name"`
+	BootstrapNodes        []string `yaml:"bootstrap_nodes,omitempty"`
+	DepositContract       string   `yaml:"deposit_contract,omitempty"`
+	DepositContractBlock  uint64   `yaml:"deposit_contract_block,omitempty"`
+	GenesisStateURL       string   `yaml:"genesis_state_url,omitempty"`
+	GenesisValidatorsRoot string   `yaml:"genesis_validators_root,omitempty"`
+	ChainConfigName       string   `yaml:"chain_config_name,omitempty"`
+
+	dir string
+}
+
+// ChainConfigFile is the path of the chain config YAML file of the network.
+func (d *Definition) ChainConfigFile() string {
+	return filepath.Join(d.dir, chainConfigFileName)
+}
+
+// GenesisStateFile is the path of the SSZ encoded genesis state of the network.
+func (d *Definition) GenesisStateFile() string {
+	return filepath.Join(d.dir, genesisStateFileName)
+}
+
+// Registry is the set of custom networks registered in a data directory.
+type Registry struct {
+	dir string
+}
+
+// NewRegistry returns the registry of custom networks of the data directory.
+func NewRegistry(dataDir string) *Registry {
+	return &Registry{dir: filepath.Join(dataDir, networksDirName)}
+}
+
+// Register saves the definition of a custom network along with a copy of its chain config and
+// genesis state. The genesis state is read from the file when one is provided, and downloaded
+// from the genesis state URL of the definition otherwise. The chain config and the genesis state
+// are checked to match before anything is saved.
+func (r *Registry) Register(ctx context.Context, def *Definition, chainConfigFile, genesisStateFile string) error {
+	if err := checkName(def.Name); err != nil {
+		return err
+	}
+	if def.DepositContract != "" && !common.IsHexAddress(def.DepositContract) {
+		return fmt.Errorf("deposit contract %s is not a valid address", def.DepositContract)
+	}
+	dir := filepath.Join(r.dir, def.Name)
+	if exists, err := file.HasDir(dir); err != nil {
+		return err
+	} else if exists {
+		return errors.Wrap(ErrNetworkExists, def.Name)
+	}
+
+	chainConfigFile, err := file.ExpandPath(chainConfigFile)
+	if err != nil {
+		return err
+	}
+	chainConfig, err := file.ReadFileAsBytes(chainConfigFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read chain config")
+	}
+	config, err := params.UnmarshalConfigFile(chainConfigFile, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not load chain config")
+	}
+
+	var genesisState []byte
+	switch {
+	case genesisStateFile != "":
+		genesisStateFile, err = file.ExpandPath(genesisStateFile)
+		if err != nil {
+			return err
+		}
+		genesisState, err = file.ReadFileAsBytes(genesisStateFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read genesis state")
+		}
+	case def.GenesisStateURL != "":
+		genesisState, err = downloadGenesisState(ctx, def.GenesisStateURL)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("a genesis state file or URL is required")
+	}
+	// The genesis state of a network may be of any fork, as long as its fork version is the one the
+	// chain config schedules for that fork.
+	vu, err := detect.FromStateWithConfig(config, genesisState)
+	if errors.Is(err, detect.ErrForkNotFound) {
+		return errors.Wrap(err, "genesis state fork version does not match a fork version of the chain config")
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not decode genesis state")
+	}
+	st, err := vu.UnmarshalBeaconState(genesisState)
+	if err != nil {
+		return errors.Wrap(err, "could not decode genesis state")
+	}
+
+	def.ChainConfigName = config.ConfigName
+	def.GenesisValidatorsRoot = fmt.Sprintf("%#x", st.GenesisValidatorsRoot())
+	definition, err := yaml.Marshal(def)
+	if err != nil {
+		return err
+	}
+
+	// The network is written in a temporary directory first, so that a failed registration
+	// doesn't leave a partial network behind.
+	tmp := filepath.Join(r.dir, "."+def.Name+".tmp")
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := file.MkdirAll(tmp); err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		definitionFileName:   definition,
+		chainConfigFileName:  chainConfig,
+		genesisStateFileName: genesisState,
+	}
+	for name, data := range files {
+		if err := file.WriteFile(filepath.Join(tmp, name), data); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return errors.Wrap(err, "could not save network")
+	}
+	def.dir = dir
+	return nil
+}
+
+// Load returns the definition of a registered network.
+func (r *Registry) Load(name string) (*Definition, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(r.dir, name)
+	data, err := os.ReadFile(filepath.Join(dir, definitionFileName)) // #nosec G304 -- The name is checked.
+	if os.IsNotExist(err) {
+		return nil, errors.Wrap(ErrUnknownNetwork, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	def := &Definition{}
+	if err := yaml.UnmarshalStrict(data, def); err != nil {
+		return nil, errors.Wrapf(err, "could not decode definition of network %s", name)
+	}
+	if def.Name != name {
+		return nil, fmt.Errorf("network %s is defined with name %s", name, def.Name)
+	}
+	def.dir = dir
+	return def, nil
+}
+
+// List returns the definitions of the registered networks, sorted by name.
+func (r *Registry) List() ([]*Definition, error) {
+	entries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var defs []*Definition
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		def, err := r.Load(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+	return defs, nil
+}
+
+// Remove deletes a registered network. The network joined by the beacon node can't be removed.
+func (r *Registry) Remove(name string) error {
+	if _, err := r.Load(name); err != nil {
+		return err
+	}
+	joined, err := r.Joined()
+	if err != nil {
+		return err
+	}
+	if joined == name {
+		return fmt.Errorf("network %s is joined by the beacon node of the data directory, it must be left first", name)
+	}
+	return os.RemoveAll(filepath.Join(r.dir, name))
+}
+
+// Joined returns the name of the network joined by the beacon node of the data directory, which
+// is empty when no custom network was joined.
+func (r *Registry) Joined() (string, error) {
+	data, err := os.ReadFile(filepath.Join(r.dir, joinedFileName))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Join records the network as joined by the beacon node of the data directory, so that the
+// beacon node keeps using it on restart.
+func (r *Registry) Join(name string) error {
+	if _, err := r.Load(name); err != nil {
+		return err
+	}
+	return file.WriteFile(filepath.Join(r.dir, joinedFileName), []byte(name+"\n"))
+}
+
+// Leave forgets the network joined by the beacon node of the data directory.
+func (r *Registry) Leave() error {
+	if err := os.Remove(filepath.Join(r.dir, joinedFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func checkName(name string) error {
+	if !validName.MatchString(name) {
+		return errors.Wrapf(errInvalidName, "%q must be lowercase letters, digits, - and _", name)
+	}
+	if reservedNames[name] {
+		return errors.Wrapf(errInvalidName, "%s is a network known by the beacon node", name)
+	}
+	return nil
+}
+
+func downloadGenesisState(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, genesisDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not download genesis state")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download genesis state from %s: %s", url, resp.Status)
+	}
+	genesisState, err := io.ReadAll(io.LimitReader(resp.Body, maxGenesisStateSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not download genesis state")
+	}
+	if len(genesisState) > maxGenesisStateSize {
+		return nil, fmt.Errorf("genesis state from %s is larger than %d bytes", url, maxGenesisStateSize)
+	}
+	return genesisState, nil
+}
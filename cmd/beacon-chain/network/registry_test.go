@@ -0,0 +1,169 @@
+package network
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+const testChainConfig = "CONFIG_NAME: 'customnet'\nGENESIS_FORK_VERSION: 0x10000001\n"
+
+// writeTestNetwork writes a chain config and a genesis state matching it in the directory.
+func writeTestNetwork(t *testing.T, dir string, forkVersion []byte) (string, string) {
+	st, err := util.NewBeaconState(func(s *ethpb.BeaconState) error {
+		s.Fork.CurrentVersion = forkVersion
+		s.GenesisValidatorsRoot = []byte{31: 'g'}
+		return nil
+	})
+	require.NoError(t, err)
+	genesisState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	configFile, genesisFile := filepath.Join(dir, "config.yaml"), filepath.Join(dir, "genesis.ssz")
+	require.NoError(t, os.WriteFile(configFile, []byte(testChainConfig), 0600))
+	require.NoError(t, os.WriteFile(genesisFile, genesisState, 0600))
+	return configFile, genesisFile
+}
+
+func TestRegistry_Register(t *testing.T) {
+	ctx := context.Background()
+	configFile, genesisFile := writeTestNetwork(t, t.TempDir(), []byte{0x10, 0, 0, 0x01})
+	r := NewRegistry(t.TempDir())
+
+	def := &Definition{
+		Name:                 "customnet",
+		BootstrapNodes:       []string{"enr:-node1", "enr:-node2"},
+		DepositContract:      "0x4242424242424242424242424242424242424242",
+		DepositContractBlock: 100,
+	}
+	require.NoError(t, r.Register(ctx, def, configFile, genesisFile))
+	assert.Equal(t, "customnet", def.ChainConfigName)
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000067", def.GenesisValidatorsRoot)
+
+	loaded, err := r.Load("customnet")
+	require.NoError(t, err)
+	assert.DeepEqual(t, def, loaded)
+	assert.Equal(t, true, file.FileExists(loaded.ChainConfigFile()))
+	genesisState, err := os.ReadFile(genesisFile)
+	require.NoError(t, err)
+	saved, err := os.ReadFile(loaded.GenesisStateFile())
+	require.NoError(t, err)
+	assert.DeepEqual(t, genesisState, saved)
+
+	err = r.Register(ctx, &Definition{Name: "customnet"}, configFile, genesisFile)
+	require.ErrorIs(t, err, ErrNetworkExists)
+
+	defs, err := r.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(defs))
+	assert.Equal(t, "customnet", defs[0].Name)
+}
+
+func TestRegistry_Register_Invalid(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	configFile, genesisFile := writeTestNetwork(t, dir, []byte{0x10, 0, 0, 0x01})
+	r := NewRegistry(t.TempDir())
+
+	require.ErrorIs(t, r.Register(ctx, &Definition{Name: "Custom Net"}, configFile, genesisFile), errInvalidName)
+	require.ErrorIs(t, r.Register(ctx, &Definition{Name: "sepolia"}, configFile, genesisFile), errInvalidName)
+	err := r.Register(ctx, &Definition{Name: "customnet", DepositContract: "0x42"}, configFile, genesisFile)
+	require.ErrorContains(t, "not a valid address", err)
+	err = r.Register(ctx, &Definition{Name: "customnet"}, configFile, "")
+	require.ErrorContains(t, "genesis state file or URL is required", err)
+
+	otherConfigFile, otherGenesisFile := writeTestNetwork(t, t.TempDir(), []byte{0x20, 0, 0, 0x01})
+	err = r.Register(ctx, &Definition{Name: "customnet"}, configFile, otherGenesisFile)
+	require.ErrorContains(t, "does not match a fork version of the chain config", err)
+	require.NoError(t, os.WriteFile(otherGenesisFile, []byte("not a state"), 0600))
+	err = r.Register(ctx, &Definition{Name: "customnet"}, otherConfigFile, otherGenesisFile)
+	require.ErrorContains(t, "could not decode genesis state", err)
+
+	// Nothing is left behind by failed registrations.
+	defs, err := r.List()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(defs))
+}
+
+func TestRegistry_Register_BellatrixGenesis(t *testing.T) {
+	dir := t.TempDir()
+	st, err := util.NewBeaconStateBellatrix(func(s *ethpb.BeaconStateBellatrix) error {
+		s.Fork.CurrentVersion = []byte{0x10, 0, 0, 0x03}
+		s.GenesisValidatorsRoot = []byte{31: 'g'}
+		return nil
+	})
+	require.NoError(t, err)
+	genesisState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	configFile, genesisFile := filepath.Join(dir, "config.yaml"), filepath.Join(dir, "genesis.ssz")
+	config := testChainConfig + "ALTAIR_FORK_VERSION: 0x10000002\nALTAIR_FORK_EPOCH: 0\n" +
+		"BELLATRIX_FORK_VERSION: 0x10000003\nBELLATRIX_FORK_EPOCH: 0\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0600))
+	require.NoError(t, os.WriteFile(genesisFile, genesisState, 0600))
+	r := NewRegistry(t.TempDir())
+
+	def := &Definition{Name: "customnet"}
+	require.NoError(t, r.Register(context.Background(), def, configFile, genesisFile))
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000067", def.GenesisValidatorsRoot)
+}
+
+func TestRegistry_Register_GenesisStateURL(t *testing.T) {
+	ctx := context.Background()
+	configFile, genesisFile := writeTestNetwork(t, t.TempDir(), []byte{0x10, 0, 0, 0x01})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/genesis.ssz" {
+			http.NotFound(w, req)
+			return
+		}
+		http.ServeFile(w, req, genesisFile)
+	}))
+	defer srv.Close()
+	r := NewRegistry(t.TempDir())
+
+	err := r.Register(ctx, &Definition{Name: "customnet", GenesisStateURL: srv.URL + "/missing.ssz"}, configFile, "")
+	require.ErrorContains(t, "404 Not Found", err)
+
+	require.NoError(t, r.Register(ctx, &Definition{Name: "customnet", GenesisStateURL: srv.URL + "/genesis.ssz"}, configFile, ""))
+	def, err := r.Load("customnet")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/genesis.ssz", def.GenesisStateURL)
+	genesisState, err := os.ReadFile(genesisFile)
+	require.NoError(t, err)
+	saved, err := os.ReadFile(def.GenesisStateFile())
+	require.NoError(t, err)
+	assert.DeepEqual(t, genesisState, saved)
+}
+
+func TestRegistry_JoinLeave(t *testing.T) {
+	configFile, genesisFile := writeTestNetwork(t, t.TempDir(), []byte{0x10, 0, 0, 0x01})
+	r := NewRegistry(t.TempDir())
+
+	joined, err := r.Joined()
+	require.NoError(t, err)
+	assert.Equal(t, "", joined)
+	require.ErrorIs(t, r.Join("customnet"), ErrUnknownNetwork)
+
+	require.NoError(t, r.Register(context.Background(), &Definition{Name: "customnet"}, configFile, genesisFile))
+	require.NoError(t, r.Join("customnet"))
+	joined, err = r.Joined()
+	require.NoError(t, err)
+	assert.Equal(t, "customnet", joined)
+	require.ErrorContains(t, "must be left first", r.Remove("customnet"))
+
+	require.NoError(t, r.Leave())
+	require.NoError(t, r.Leave())
+	joined, err = r.Joined()
+	require.NoError(t, err)
+	assert.Equal(t, "", joined)
+	require.NoError(t, r.Remove("customnet"))
+	_, err = r.Load("customnet")
+	require.ErrorIs(t, err, ErrUnknownNetwork)
+}
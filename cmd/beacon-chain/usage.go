@@ -7,6 +7,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/network"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/checkpoint"
 	"github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/sync/genesis"
 	"github.com/prysmaticlabs/prysm/v3/config/features"
@@ -59,6 +60,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
+			cmd.TracingExporterFlag,
 			cmd.TraceSampleFractionFlag,
 			cmd.MonitoringHostFlag,
 			cmd.BackupWebhookOutputDir,
@@ -99,19 +101,29 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ContractDeploymentBlock,
 			flags.RPCHost,
 			flags.RPCPort,
+			flags.RPCSlowRequestThreshold,
 			flags.CertFlag,
 			flags.KeyFlag,
 			flags.HTTPModules,
+			flags.RemoteArchiveURL,
 			flags.DisableGRPCGateway,
 			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionJWTSecretFlag,
+			flags.AdminAuthSecretFlag,
+			flags.ExecutionMaxIdleConnsFlag,
+			flags.ExecutionKeepAliveFlag,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.DBSyncPolicy,
+			flags.ReplayBlocksDir,
+			flags.CheckpointStateCacheSize,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.DutyPriority,
+			flags.GossipValidationLimitsFlag,
 			flags.EnableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
@@ -120,6 +132,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.WeakSubjectivityCheckpoint,
 			flags.Eth1HeaderReqLimit,
 			flags.MinPeersPerSubnet,
+			flags.AttestationSubnetGracePeriod,
+			flags.ChainProgressWatchdogSlots,
+			flags.ChainProgressWatchdogDir,
 			flags.MevRelayEndpoint,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
@@ -128,6 +143,7 @@ var appHelpFlagGroups = []flagGroup{
 			checkpoint.RemoteURL,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
+			network.NameFlag,
 		},
 	},
 	{
@@ -145,11 +161,14 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PIP,
 			cmd.P2PHost,
 			cmd.P2PHostDNS,
+			cmd.P2PHostDNSRefresh,
 			cmd.P2PMaxPeers,
 			cmd.P2PPrivKey,
+			cmd.P2PPreSharedKey,
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
+			cmd.P2PGossipOutboundCaps,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
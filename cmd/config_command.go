@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigCommand returns the command for inspecting the configuration of an application with the
+// given flags.
+func ConfigCommand(flags []cli.Flag) *cli.Command {
+	return &cli.Command{
+		Name:     "config",
+		Category: "config",
+		Usage:    "defines commands for inspecting the node configuration",
+		Subcommands: []*cli.Command{
+			{
+				Name: "dump",
+				Description: `prints the effective configuration as yaml, which can be used as a --config-file. ` +
+					`Flags set on the command line take precedence over the config file, which takes precedence over the flag defaults`,
+				Flags: flags,
+				Before: func(cliCtx *cli.Context) error {
+					return LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					return DumpConfig(os.Stdout, cliCtx, cliCtx.Command.Flags)
+				},
+			},
+		},
+	}
+}
+
+// DumpConfig writes the values of the flags as yaml, keyed by flag name. Hidden flags are only
+// written if they are set, and the config file and help flags are left out.
+func DumpConfig(w io.Writer, cliCtx *cli.Context, flags []cli.Flag) error {
+	values := make(map[string]interface{}, len(flags))
+	for _, f := range flags {
+		name := f.Names()[0]
+		if name == ConfigFileFlag.Name || name == cli.HelpFlag.Names()[0] {
+			continue
+		}
+		if vf, ok := f.(cli.VisibleFlag); ok && !vf.IsVisible() && !cliCtx.IsSet(name) {
+			continue
+		}
+		v, err := flagValue(cliCtx, f)
+		if err != nil {
+			return err
+		}
+		values[name] = v
+	}
+	b, err := yaml.Marshal(values)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal config")
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// flagValue returns the value of the flag in a form that can be loaded back from a config file.
+func flagValue(cliCtx *cli.Context, f cli.Flag) (interface{}, error) {
+	name := f.Names()[0]
+	switch t := f.(type) {
+	case *altsrc.BoolFlag:
+		f = t.BoolFlag
+	case *altsrc.DurationFlag:
+		f = t.DurationFlag
+	case *altsrc.GenericFlag:
+		f = t.GenericFlag
+	case *altsrc.Float64Flag:
+		f = t.Float64Flag
+	case *altsrc.IntFlag:
+		f = t.IntFlag
+	case *altsrc.StringFlag:
+		f = t.StringFlag
+	case *altsrc.StringSliceFlag:
+		f = t.StringSliceFlag
+	case *altsrc.Uint64Flag:
+		f = t.Uint64Flag
+	case *altsrc.UintFlag:
+		f = t.UintFlag
+	case *altsrc.PathFlag:
+		f = t.PathFlag
+	case *altsrc.IntSliceFlag:
+		f = t.IntSliceFlag
+	}
+	switch f.(type) {
+	case *cli.BoolFlag:
+		return cliCtx.Bool(name), nil
+	case *cli.DurationFlag:
+		return cliCtx.Duration(name).String(), nil
+	case *cli.GenericFlag:
+		return fmt.Sprintf("%v", cliCtx.Generic(name)), nil
+	case *cli.Float64Flag:
+		return cliCtx.Float64(name), nil
+	case *cli.IntFlag:
+		return cliCtx.Int(name), nil
+	case *cli.StringFlag:
+		return cliCtx.String(name), nil
+	case *cli.StringSliceFlag:
+		return cliCtx.StringSlice(name), nil
+	case *cli.Uint64Flag:
+		return cliCtx.Uint64(name), nil
+	case *cli.UintFlag:
+		return cliCtx.Uint(name), nil
+	case *cli.PathFlag:
+		return cliCtx.Path(name), nil
+	case *cli.IntSliceFlag:
+		return cliCtx.IntSlice(name), nil
+	case *cli.Int64Flag:
+		return cliCtx.Int64(name), nil
+	default:
+		return nil, fmt.Errorf("unsupported flag type %T", f)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestDumpConfig(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	context := cli.NewContext(&app, set, nil)
+
+	configFile := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("int-flag: 100\nslice-flag:\n- a\n- b\n"), 0666))
+
+	require.NoError(t, set.Parse([]string{"test-command", "--" + ConfigFileFlag.Name, configFile, "--string-flag", "cli"}))
+	var out bytes.Buffer
+	command := &cli.Command{
+		Name: "test-command",
+		Flags: WrapFlags([]cli.Flag{
+			&cli.StringFlag{
+				Name: ConfigFileFlag.Name,
+			},
+			&cli.IntFlag{Name: "int-flag"},
+			&cli.StringFlag{Name: "string-flag"},
+			&cli.StringSliceFlag{Name: "slice-flag"},
+			&cli.DurationFlag{Name: "duration-flag", Value: time.Minute},
+			&cli.BoolFlag{Name: "hidden-flag", Hidden: true},
+		}),
+		Before: func(cliCtx *cli.Context) error {
+			return LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+		},
+		Action: func(cliCtx *cli.Context) error {
+			return DumpConfig(&out, cliCtx, cliCtx.Command.Flags)
+		},
+	}
+	require.NoError(t, command.Run(context))
+
+	want := `duration-flag: 1m0s
+int-flag: 100
+slice-flag:
+- a
+- b
+string-flag: cli
+`
+	assert.Equal(t, want, out.String())
+
+	// The dump can be used as a config file.
+	dumpFile := filepath.Join(t.TempDir(), "dump.yaml")
+	require.NoError(t, os.WriteFile(dumpFile, out.Bytes(), 0666))
+	_, err := loadConfigFile(dumpFile, command.Flags)
+	require.NoError(t, err)
+}
@@ -3,11 +3,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
+	"gopkg.in/yaml.v2"
 )
 
 var (
@@ -65,6 +69,13 @@ var (
 		Usage: "Tracing endpoint defines where beacon chain traces are exposed to Jaeger.",
 		Value: "http://127.0.0.1:14268/api/traces",
 	}
+	// TracingExporterFlag defines the exporter traces are sent with.
+	TracingExporterFlag = &cli.StringFlag{
+		Name: "tracing-exporter",
+		Usage: "Exporter traces are sent with to the tracing endpoint: jaeger, or otlp to send them to an " +
+			"OpenTelemetry collector with OTLP/HTTP, such as http://127.0.0.1:4318/v1/traces.",
+		Value: "jaeger",
+	}
 	// TraceSampleFractionFlag defines a flag to indicate what fraction of p2p
 	// messages are sampled for tracing.
 	TraceSampleFractionFlag = &cli.Float64Flag{
@@ -137,12 +148,25 @@ var (
 		Usage: "The DNS address advertised by libp2p. This may be used to advertise an external DNS.",
 		Value: "",
 	}
+	// P2PHostDNSRefresh defines the interval at which the host DNS is resolved again.
+	P2PHostDNSRefresh = &cli.DurationFlag{
+		Name: "p2p-host-dns-refresh",
+		Usage: "The interval at which the host DNS is resolved again, updating the IP address advertised in the ENR " +
+			"when it changes. Useful for nodes behind a dynamic IP address. Disabled when 0.",
+	}
 	// P2PPrivKey defines a flag to specify the location of the private key file for libp2p.
 	P2PPrivKey = &cli.StringFlag{
 		Name:  "p2p-priv-key",
 		Usage: "The file containing the private key to use in communications with other peers.",
 		Value: "",
 	}
+	// P2PPreSharedKey defines a flag to specify the location of a libp2p pre-shared key file.
+	P2PPreSharedKey = &cli.StringFlag{
+		Name: "p2p-psk-file",
+		Usage: "The file containing a libp2p pre-shared key, in the /key/swarm/psk/1.0.0/ format. When set, " +
+			"the node only connects to peers holding the same key, isolating a private network at the transport layer.",
+		Value: "",
+	}
 	// P2PMetadata defines a flag to specify the location of the peer metadata file.
 	P2PMetadata = &cli.StringFlag{
 		Name:  "p2p-metadata",
@@ -171,6 +195,15 @@ var (
 			"192.168.0.0/16 would deny connections from peers on your local network only. The " +
 			"default is to accept all connections.",
 	}
+	// P2PGossipOutboundCaps defines the caps of the bytes per second published by the node on gossip topics.
+	P2PGossipOutboundCaps = &cli.StringSliceFlag{
+		Name: "p2p-gossip-outbound-caps",
+		Usage: "Caps of the bytes per second of the messages published by the node on gossip topics, for nodes on " +
+			"metered connections, as name=bytes entries. The name is the topic name without fork digest, encoding " +
+			"and subnet, for instance voluntary_exit=65536. Messages over the cap are not published, messages " +
+			"relayed for other peers are not capped. Topics carrying validator duties, blocks, attestations, " +
+			"aggregates and sync committee messages, cannot be capped.",
+	}
 	// ForceClearDB removes any previously stored data at the data directory.
 	ForceClearDB = &cli.BoolFlag{
 		Name:  "force-clear-db",
@@ -200,13 +233,15 @@ var (
 	}
 	// EnableUPnPFlag specifies if UPnP should be enabled or not. The default value is false.
 	EnableUPnPFlag = &cli.BoolFlag{
-		Name:  "enable-upnp",
-		Usage: "Enable the service (Beacon chain or Validator) to use UPnP when possible.",
+		Name: "enable-upnp",
+		Usage: "Enable the service (Beacon chain or Validator) to use UPnP when possible. The beacon node maps its p2p " +
+			"ports on the gateway using UPnP or NAT-PMP, renews the mappings and advertises the external IP address in its ENR.",
 	}
 	// ConfigFileFlag specifies the filepath to load flag values.
 	ConfigFileFlag = &cli.StringFlag{
-		Name:  "config-file",
-		Usage: "The filepath to a yaml file with flag values",
+		Name: "config-file",
+		Usage: "The filepath to a yaml file with flag values, keyed by flag name. Flags set on the command line " +
+			"take precedence over the file, which takes precedence over the flag defaults",
 	}
 	// ChainConfigFileFlag specifies the filepath to load flag values.
 	ChainConfigFileFlag = &cli.StringFlag{
@@ -243,6 +278,17 @@ var (
 		Usage: "Target directory of the restored database",
 		Value: DefaultDataDir(),
 	}
+	// RestoreOverwriteFlag overwrites an existing database in the restore target directory without prompting.
+	RestoreOverwriteFlag = &cli.BoolFlag{
+		Name:    "restore-overwrite",
+		Usage:   "Overwrite an existing database in the target directory without asking for confirmation",
+		EnvVars: []string{"PRYSM_RESTORE_OVERWRITE"},
+	}
+	// VerifyStateRootsFlag makes the database inspection compare every saved state to the state root of its block.
+	VerifyStateRootsFlag = &cli.BoolFlag{
+		Name:  "verify-state-roots",
+		Usage: "Verify that every state saved in the database matches the state root of its block. This decodes and hashes all the saved states",
+	}
 	// ApiTimeoutFlag specifies the timeout value for API requests in seconds. A timeout of zero means no timeout.
 	ApiTimeoutFlag = &cli.IntFlag{
 		Name:  "api-timeout",
@@ -257,16 +303,127 @@ var (
 	}
 )
 
-// LoadFlagsFromConfig sets flags values from config file if ConfigFileFlag is set.
+// LoadFlagsFromConfig sets flags values from config file if ConfigFileFlag is set. Values set on
+// the command line are kept. The keys of the file must be names of flags of the application or of
+// one of its commands, as the file may be shared by them.
 func LoadFlagsFromConfig(cliCtx *cli.Context, flags []cli.Flag) error {
 	if cliCtx.IsSet(ConfigFileFlag.Name) {
-		if err := altsrc.InitInputSourceWithContext(flags, altsrc.NewYamlSourceFromFlagFunc(ConfigFileFlag.Name))(cliCtx); err != nil {
+		configFile := cliCtx.String(ConfigFileFlag.Name)
+		knownFlags := flags
+		if cliCtx.App != nil {
+			knownFlags = append(append(append([]cli.Flag{}, flags...), cliCtx.App.Flags...), commandsFlags(cliCtx.App.Commands)...)
+		}
+		source, err := loadConfigFile(configFile, knownFlags)
+		if err != nil {
 			return err
 		}
+		setOnCommandLine := make(map[string]bool)
+		for _, f := range flags {
+			setOnCommandLine[f.Names()[0]] = cliCtx.IsSet(f.Names()[0])
+		}
+		if err := altsrc.InitInputSourceWithContext(flags, func(*cli.Context) (altsrc.InputSourceContext, error) {
+			return source, nil
+		})(cliCtx); err != nil {
+			return errors.Wrapf(err, "could not load flags from config file %s", configFile)
+		}
+		if cliCtx.App != nil {
+			if cliCtx.App.Metadata == nil {
+				cliCtx.App.Metadata = make(map[string]interface{})
+			}
+			fromFile, ok := cliCtx.App.Metadata[configFileFlagsKey].(map[string]bool)
+			if !ok {
+				fromFile = make(map[string]bool)
+				cliCtx.App.Metadata[configFileFlagsKey] = fromFile
+			}
+			for name, set := range setOnCommandLine {
+				if !set && cliCtx.IsSet(name) {
+					fromFile[name] = true
+				}
+			}
+		}
 	}
 	return nil
 }
 
+// Sources of flag values, as returned by FlagSource.
+const (
+	FlagSourceDefault     = "default"
+	FlagSourceCommandLine = "command_line"
+	FlagSourceConfigFile  = "config_file"
+)
+
+// configFileFlagsKey is the key of the application metadata holding the names of the flags set
+// from the config file.
+const configFileFlagsKey = "config-file-flags"
+
+// FlagSource returns where the value of the flag comes from. Values set from environment variables
+// are reported as set on the command line.
+func FlagSource(cliCtx *cli.Context, name string) string {
+	if !cliCtx.IsSet(name) {
+		return FlagSourceDefault
+	}
+	if cliCtx.App != nil {
+		if fromFile, ok := cliCtx.App.Metadata[configFileFlagsKey].(map[string]bool); ok && fromFile[name] {
+			return FlagSourceConfigFile
+		}
+	}
+	return FlagSourceCommandLine
+}
+
+// commandsFlags returns the flags of the commands and of their subcommands.
+func commandsFlags(commands []*cli.Command) []cli.Flag {
+	var flags []cli.Flag
+	for _, c := range commands {
+		flags = append(flags, c.Flags...)
+		flags = append(flags, commandsFlags(c.Subcommands)...)
+	}
+	return flags
+}
+
+// loadConfigFile reads the config file as an input source of flag values. Keys are names or
+// aliases of the flags, aliases being replaced by the flag names as altsrc only looks values up by
+// name. Unknown keys would otherwise be silently ignored, so a warning is logged for them, which
+// still lets a file written for a newer release be used.
+func loadConfigFile(configFile string, flags []cli.Flag) (altsrc.InputSourceContext, error) {
+	b, err := os.ReadFile(configFile) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read config file")
+	}
+	var values map[interface{}]interface{}
+	if err := yaml.Unmarshal(b, &values); err != nil {
+		return nil, errors.Wrapf(err, "could not parse config file %s", configFile)
+	}
+	names := make(map[string]string)
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			names[name] = f.Names()[0]
+		}
+	}
+	resolved := make(map[interface{}]interface{}, len(values))
+	setBy := make(map[string]string, len(values))
+	var unknown []string
+	for k, value := range values {
+		key := fmt.Sprint(k)
+		name, ok := names[key]
+		switch {
+		case key == ConfigFileFlag.Name:
+			return nil, fmt.Errorf("invalid config file %s: %s cannot be set from a config file", configFile, key)
+		case !ok:
+			unknown = append(unknown, key)
+			continue
+		case setBy[name] != "":
+			return nil, fmt.Errorf("invalid config file %s: flag %s is set by both %s and %s", configFile, name, setBy[name], key)
+		}
+		setBy[name] = key
+		resolved[name] = value
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		log.WithField("configFile", configFile).Warnf("Ignoring unknown flags in config file: %s", strings.Join(unknown, ", "))
+	}
+	return altsrc.NewMapInputSource(configFile, resolved), nil
+}
+
 // ValidateNoArgs insures that the application is not run with erroneous arguments or flags.
 // This function should be used in the app.Before, whenever the application supports a default command.
 func ValidateNoArgs(ctx *cli.Context) error {
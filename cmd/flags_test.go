@@ -3,9 +3,12 @@ package cmd
 import (
 	"flag"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli/v2"
 )
 
@@ -236,3 +239,185 @@ func TestValidateNoArgs_SubcommandFlags(t *testing.T) {
 	err = app.Run([]string{"command", "bar", "subComm2", "--barfoo100", "garbage", "subComm4"})
 	require.ErrorContains(t, "unrecognized argument: garbage", err)
 }
+
+func TestLoadFlagsFromConfig_Precedence(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	context := cli.NewContext(&app, set, nil)
+
+	configFile := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("from-file: 100\nfrom-cli: 200\n"), 0666))
+
+	require.NoError(t, set.Parse([]string{"test-command", "--" + ConfigFileFlag.Name, configFile, "--from-cli", "300"}))
+	command := &cli.Command{
+		Name: "test-command",
+		Flags: WrapFlags([]cli.Flag{
+			&cli.StringFlag{
+				Name: ConfigFileFlag.Name,
+			},
+			&cli.IntFlag{Name: "from-file", Value: 1},
+			&cli.IntFlag{Name: "from-cli", Value: 2},
+			&cli.IntFlag{Name: "from-default", Value: 3},
+		}),
+		Before: func(cliCtx *cli.Context) error {
+			return LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+		},
+		Action: func(cliCtx *cli.Context) error {
+			assert.Equal(t, 100, cliCtx.Int("from-file"))
+			assert.Equal(t, 300, cliCtx.Int("from-cli"))
+			assert.Equal(t, 3, cliCtx.Int("from-default"))
+			return nil
+		},
+	}
+	require.NoError(t, command.Run(context))
+}
+
+func TestLoadFlagsFromConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		errMsg string
+	}{
+		{
+			name:   "name and alias",
+			config: "testflag: 100\nt: 200\n",
+			errMsg: "flag testflag is set by both",
+		},
+		{
+			name:   "config file",
+			config: ConfigFileFlag.Name + ": other.yaml\n",
+			errMsg: "config-file cannot be set from a config file",
+		},
+		{
+			name:   "wrong type",
+			config: "testflag: abc\n",
+			errMsg: "could not load flags from config file",
+		},
+		{
+			name:   "malformed",
+			config: "testflag: [100\n",
+			errMsg: "could not parse config file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.App{}
+			set := flag.NewFlagSet("test", 0)
+			context := cli.NewContext(&app, set, nil)
+
+			configFile := filepath.Join(t.TempDir(), "flags.yaml")
+			require.NoError(t, os.WriteFile(configFile, []byte(tt.config), 0666))
+
+			require.NoError(t, set.Parse([]string{"test-command", "--" + ConfigFileFlag.Name, configFile}))
+			command := &cli.Command{
+				Name: "test-command",
+				Flags: WrapFlags([]cli.Flag{
+					&cli.StringFlag{
+						Name: ConfigFileFlag.Name,
+					},
+					&cli.IntFlag{
+						Name:    "testflag",
+						Aliases: []string{"t"},
+					},
+				}),
+				Before: func(cliCtx *cli.Context) error {
+					return LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					return nil
+				},
+			}
+			require.ErrorContains(t, tt.errMsg, command.Run(context))
+		})
+	}
+}
+
+func TestLoadFlagsFromConfig_AliasAndUnknownKeys(t *testing.T) {
+	hook := logTest.NewGlobal()
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	context := cli.NewContext(&app, set, nil)
+
+	configFile := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("t: 100\nunknown: 1\nother: abc\n"), 0666))
+
+	require.NoError(t, set.Parse([]string{"test-command", "--" + ConfigFileFlag.Name, configFile}))
+	command := &cli.Command{
+		Name: "test-command",
+		Flags: WrapFlags([]cli.Flag{
+			&cli.StringFlag{
+				Name: ConfigFileFlag.Name,
+			},
+			&cli.IntFlag{
+				Name:    "testflag",
+				Aliases: []string{"t"},
+			},
+		}),
+		Before: func(cliCtx *cli.Context) error {
+			return LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+		},
+		Action: func(cliCtx *cli.Context) error {
+			assert.Equal(t, 100, cliCtx.Int("testflag"))
+			assert.Equal(t, FlagSourceConfigFile, FlagSource(cliCtx, "testflag"))
+			return nil
+		},
+	}
+	require.NoError(t, command.Run(context))
+	require.LogsContain(t, hook, "Ignoring unknown flags in config file: other, unknown")
+}
+
+func TestLoadFlagsFromConfig_CommandFlags(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("app-flag: 1\ncommand-flag: 2\n"), 0666))
+
+	var appFlag int
+	app := &cli.App{
+		Flags: WrapFlags([]cli.Flag{
+			&cli.StringFlag{Name: ConfigFileFlag.Name},
+			&cli.IntFlag{Name: "app-flag"},
+		}),
+		Commands: []*cli.Command{
+			{
+				Name:  "command",
+				Flags: []cli.Flag{&cli.IntFlag{Name: "command-flag"}},
+			},
+		},
+	}
+	app.Before = func(cliCtx *cli.Context) error {
+		return LoadFlagsFromConfig(cliCtx, app.Flags)
+	}
+	app.Action = func(cliCtx *cli.Context) error {
+		appFlag = cliCtx.Int("app-flag")
+		return nil
+	}
+	require.NoError(t, app.Run([]string{"app", "--" + ConfigFileFlag.Name, configFile}))
+	assert.Equal(t, 1, appFlag)
+}
+
+func TestFlagSource(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("from-file: 1\nfrom-cli: 2\n"), 0666))
+
+	sources := make(map[string]string)
+	app := &cli.App{
+		Flags: WrapFlags([]cli.Flag{
+			&cli.StringFlag{Name: ConfigFileFlag.Name},
+			&cli.IntFlag{Name: "from-file"},
+			&cli.IntFlag{Name: "from-cli"},
+			&cli.IntFlag{Name: "from-default"},
+		}),
+	}
+	app.Before = func(cliCtx *cli.Context) error {
+		return LoadFlagsFromConfig(cliCtx, app.Flags)
+	}
+	app.Action = func(cliCtx *cli.Context) error {
+		for _, name := range []string{"from-file", "from-cli", "from-default"} {
+			sources[name] = FlagSource(cliCtx, name)
+		}
+		return nil
+	}
+	require.NoError(t, app.Run([]string{"app", "--" + ConfigFileFlag.Name, configFile, "--from-cli", "3"}))
+	assert.Equal(t, FlagSourceConfigFile, sources["from-file"])
+	assert.Equal(t, FlagSourceCommandLine, sources["from-cli"])
+	assert.Equal(t, FlagSourceDefault, sources["from-default"])
+}
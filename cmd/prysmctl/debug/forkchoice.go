@@ -0,0 +1,81 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var forkChoiceFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+	Format         string
+	Output         string
+}{}
+
+var forkChoiceCmd = &cli.Command{
+	Name: "forkchoice",
+	Usage: "Render the fork choice tree of a beacon node in the Graphviz DOT or Mermaid format, with the weight " +
+		"and optimistic status of each block. The head and the canonical chain are highlighted.",
+	Action: cliActionForkChoice,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &forkChoiceFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &forkChoiceFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format, one of dot, mermaid or json",
+			Destination: &forkChoiceFlags.Format,
+			Value:       "dot",
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "file to write the fork choice tree to, printed to stdout when empty",
+			Destination: &forkChoiceFlags.Output,
+		},
+	},
+}
+
+func cliActionForkChoice(_ *cli.Context) error {
+	ctx := context.Background()
+	f := forkChoiceFlags
+	switch f.Format {
+	case "dot", "mermaid", "json":
+	default:
+		return errors.Errorf("format %s is not one of dot, mermaid or json", f.Format)
+	}
+
+	opts := []beacon.ClientOpt{beacon.WithTimeout(f.Timeout)}
+	client, err := beacon.NewClient(f.BeaconNodeHost, opts...)
+	if err != nil {
+		return err
+	}
+	b, err := client.GetForkChoice(ctx, f.Format)
+	if err != nil {
+		return err
+	}
+	if f.Output == "" {
+		fmt.Print(string(b))
+		return nil
+	}
+	if err := file.WriteFile(f.Output, b); err != nil {
+		return errors.Wrapf(err, "could not write fork choice to %s", f.Output)
+	}
+	log.Printf("fork choice written to %s", f.Output)
+	return nil
+}
@@ -0,0 +1,171 @@
+package testnet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/testnet/mockengine"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v3/runtime/tos"
+	"github.com/urfave/cli/v2"
+)
+
+var generateAndRunFlags = struct {
+	NumNodes          uint64
+	ValidatorsPerNode uint64
+	GenesisDelay      time.Duration
+	DataDir           string
+	BasePort          uint64
+	Verbosity         string
+	EngineFaults      string
+}{}
+
+var generateAndRunCmd = &cli.Command{
+	Name: "generate-and-run",
+	Usage: "Generate an interop genesis state and run a network of beacon nodes and validator clients in this process. " +
+		"The nodes peer with each other through an in-memory transport and use a mock execution engine.",
+	Action: cliActionGenerateAndRun,
+	Flags: []cli.Flag{
+		&cli.Uint64Flag{
+			Name:        "num-nodes",
+			Usage:       "Number of beacon nodes to run, each with a validator client attached",
+			Destination: &generateAndRunFlags.NumNodes,
+			Value:       4,
+		},
+		&cli.Uint64Flag{
+			Name:        "validators-per-node",
+			Usage:       "Number of interop validators run by the validator client of each beacon node",
+			Destination: &generateAndRunFlags.ValidatorsPerNode,
+			Value:       16,
+		},
+		&cli.DurationFlag{
+			Name:        "genesis-delay",
+			Usage:       "Time between starting the network and its genesis (uses duration format, ex: 1m30s)",
+			Destination: &generateAndRunFlags.GenesisDelay,
+			Value:       30 * time.Second,
+		},
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "Directory holding the genesis state and the data of every node. A temporary directory is used if not set",
+			Destination: &generateAndRunFlags.DataDir,
+		},
+		&cli.Uint64Flag{
+			Name: "base-port",
+			Usage: "First port used by the network. Every beacon node uses four consecutive ports from it, " +
+				"for the p2p addresses (tcp and udp), gRPC and the gRPC gateway",
+			Destination: &generateAndRunFlags.BasePort,
+			Value:       13000,
+		},
+		&cli.StringFlag{
+			Name:        "verbosity",
+			Usage:       "Logging verbosity of the nodes (trace, debug, info=default, warn, error, fatal, panic)",
+			Destination: &generateAndRunFlags.Verbosity,
+			Value:       "info",
+		},
+		engineFaultsFlag(&generateAndRunFlags.EngineFaults),
+		cmd.MinimalConfigFlag,
+		cmd.ChainConfigFileFlag,
+		cmd.AcceptTosFlag,
+	},
+}
+
+func cliActionGenerateAndRun(cliCtx *cli.Context) error {
+	f := generateAndRunFlags
+	if f.NumNodes == 0 {
+		return errors.New("at least one node is needed to run a network")
+	}
+	if f.ValidatorsPerNode == 0 {
+		return errors.New("at least one validator per node is needed to run a network")
+	}
+	if err := tos.VerifyTosAcceptedOrPrompt(cliCtx); err != nil {
+		return err
+	}
+	if err := configureChain(cliCtx); err != nil {
+		return err
+	}
+
+	dataDir := f.DataDir
+	if dataDir == "" {
+		var err error
+		dataDir, err = os.MkdirTemp("", "prysm-testnet")
+		if err != nil {
+			return errors.Wrap(err, "could not create data directory")
+		}
+	}
+	genesisPath := filepath.Join(dataDir, "genesis.ssz")
+	genesisTime := uint64(time.Now().Add(f.GenesisDelay).Unix())
+	if err := writeGenesisState(cliCtx.Context, genesisPath, genesisTime, f.NumNodes*f.ValidatorsPerNode); err != nil {
+		return err
+	}
+
+	engineOpts := []mockengine.Option{mockengine.WithGenesisTime(genesisTime)}
+	if f.EngineFaults != "" {
+		faults, err := mockengine.LoadFaults(f.EngineFaults)
+		if err != nil {
+			return err
+		}
+		engineOpts = append(engineOpts, mockengine.WithFaults(faults))
+	}
+	engine, err := mockengine.New("127.0.0.1:0", engineOpts...)
+	if err != nil {
+		return err
+	}
+	engine.Start()
+	defer func() {
+		if err := engine.Stop(); err != nil {
+			log.WithError(err).Error("Could not stop mock execution engine")
+		}
+	}()
+
+	net := &network{
+		dataDir:           dataDir,
+		genesisPath:       genesisPath,
+		engineURL:         engine.URL(),
+		basePort:          f.BasePort,
+		validatorsPerNode: f.ValidatorsPerNode,
+		verbosity:         f.Verbosity,
+		minimalConfig:     cliCtx.Bool(cmd.MinimalConfigFlag.Name),
+		chainConfigFile:   cliCtx.String(cmd.ChainConfigFileFlag.Name),
+	}
+	var wg sync.WaitGroup
+	for i := uint64(0); i < f.NumNodes; i++ {
+		starters, err := net.newNode(cliCtx.Context, i)
+		if err != nil {
+			return errors.Wrapf(err, "could not create node %d", i)
+		}
+		for _, start := range starters {
+			wg.Add(1)
+			go func(start func()) {
+				defer wg.Done()
+				start()
+			}(start)
+		}
+	}
+	log.WithField("nodes", f.NumNodes).
+		WithField("validators", f.NumNodes*f.ValidatorsPerNode).
+		WithField("genesisTime", time.Unix(int64(genesisTime), 0)).
+		WithField("dataDir", dataDir).
+		Info("Test network started, interrupt to stop it")
+	wg.Wait()
+	return nil
+}
+
+// writeGenesisState generates an interop genesis state with the given number of validators and saves it
+// ssz encoded to path.
+func writeGenesisState(ctx context.Context, path string, genesisTime, numValidators uint64) error {
+	st, _, err := interop.GenerateGenesisState(ctx, genesisTime, numValidators)
+	if err != nil {
+		return errors.Wrap(err, "could not generate genesis state")
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal genesis state")
+	}
+	return file.WriteFile(path, enc)
+}
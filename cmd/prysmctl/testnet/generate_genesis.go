@@ -0,0 +1,186 @@
+package testnet
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/urfave/cli/v2"
+)
+
+var generateGenesisFlags = struct {
+	NumValidators      uint64
+	GenesisTime        uint64
+	GenesisDelay       time.Duration
+	AltairForkEpoch    uint64
+	BellatrixForkEpoch uint64
+	CapellaForkEpoch   uint64
+	ExecutionGenesis   string
+	OutputSSZ          string
+	OutputConfig       string
+}{}
+
+var generateGenesisCmd = &cli.Command{
+	Name: "generate-genesis",
+	Usage: "Generate a genesis state of validators with deterministic interop keys, for bootstrapping devnets. " +
+		"The genesis state is of the latest fork scheduled at epoch 0, with the header of the execution genesis block " +
+		"embedded for Bellatrix",
+	Action: cliActionGenerateGenesis,
+	Flags: []cli.Flag{
+		&cli.Uint64Flag{
+			Name:        "num-validators",
+			Usage:       "Number of validators in the genesis state, with the deterministic keys of indices 0 to num-validators-1",
+			Destination: &generateGenesisFlags.NumValidators,
+			Value:       64,
+		},
+		&cli.Uint64Flag{
+			Name:        "genesis-time",
+			Usage:       "Genesis time of the chain as a unix timestamp. The current time plus the genesis delay is used if not set",
+			Destination: &generateGenesisFlags.GenesisTime,
+		},
+		&cli.DurationFlag{
+			Name:        "genesis-delay",
+			Usage:       "Time between now and the genesis when the genesis time is not set (uses duration format, ex: 1m30s)",
+			Destination: &generateGenesisFlags.GenesisDelay,
+		},
+		&cli.Uint64Flag{
+			Name:        "altair-fork-epoch",
+			Usage:       "Overrides the Altair fork epoch of the chain config",
+			Destination: &generateGenesisFlags.AltairForkEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "bellatrix-fork-epoch",
+			Usage:       "Overrides the Bellatrix fork epoch of the chain config",
+			Destination: &generateGenesisFlags.BellatrixForkEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "capella-fork-epoch",
+			Usage:       "Overrides the Capella fork epoch of the chain config",
+			Destination: &generateGenesisFlags.CapellaForkEpoch,
+		},
+		&cli.StringFlag{
+			Name:        "execution-genesis",
+			Usage:       "Path to the genesis.json of the execution chain, whose genesis block header is embedded in Bellatrix genesis states",
+			Destination: &generateGenesisFlags.ExecutionGenesis,
+		},
+		&cli.StringFlag{
+			Name:        "output-ssz",
+			Usage:       "Path the SSZ encoded genesis state is written to",
+			Destination: &generateGenesisFlags.OutputSSZ,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output-config",
+			Usage:       "Path the chain config, with the fork epoch overrides, is written to as YAML. Not written if not set",
+			Destination: &generateGenesisFlags.OutputConfig,
+		},
+		cmd.MinimalConfigFlag,
+		cmd.ChainConfigFileFlag,
+	},
+}
+
+func cliActionGenerateGenesis(cliCtx *cli.Context) error {
+	f := generateGenesisFlags
+	if f.NumValidators == 0 {
+		return errors.New("at least one validator is needed in the genesis state")
+	}
+	if err := configureChain(cliCtx); err != nil {
+		return err
+	}
+	cfg := params.BeaconConfig().Copy()
+	if cliCtx.IsSet("altair-fork-epoch") {
+		cfg.AltairForkEpoch = types.Epoch(f.AltairForkEpoch)
+	}
+	if cliCtx.IsSet("bellatrix-fork-epoch") {
+		cfg.BellatrixForkEpoch = types.Epoch(f.BellatrixForkEpoch)
+	}
+	if cliCtx.IsSet("capella-fork-epoch") {
+		cfg.CapellaForkEpoch = types.Epoch(f.CapellaForkEpoch)
+	}
+	v, err := genesisVersion(cfg)
+	if err != nil {
+		return err
+	}
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	var header *enginev1.ExecutionPayloadHeader
+	if f.ExecutionGenesis != "" {
+		if v < version.Bellatrix {
+			log.Warn("Ignoring execution genesis, which is only used by bellatrix genesis states")
+		} else if header, err = executionGenesisHeader(f.ExecutionGenesis); err != nil {
+			return err
+		}
+	} else if v >= version.Bellatrix {
+		return errors.New("a bellatrix genesis state needs the execution genesis")
+	}
+
+	genesisTime := f.GenesisTime
+	if genesisTime == 0 {
+		genesisTime = uint64(time.Now().Add(f.GenesisDelay).Unix())
+	}
+	st, err := interop.GenerateGenesisStateForVersion(cliCtx.Context, genesisTime, f.NumValidators, v, header)
+	if err != nil {
+		return err
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal genesis state")
+	}
+	if err := file.WriteFile(f.OutputSSZ, enc); err != nil {
+		return errors.Wrap(err, "could not write genesis state")
+	}
+	if f.OutputConfig != "" {
+		if err := file.WriteFile(f.OutputConfig, params.ConfigToYaml(cfg)); err != nil {
+			return errors.Wrap(err, "could not write chain config")
+		}
+	}
+	log.WithField("fork", version.String(v)).
+		WithField("validators", f.NumValidators).
+		WithField("genesisTime", time.Unix(int64(genesisTime), 0)).
+		WithField("path", f.OutputSSZ).
+		Info("Genesis state generated")
+	return nil
+}
+
+// genesisVersion returns the version of the genesis state, the latest fork scheduled at epoch 0.
+func genesisVersion(cfg *params.BeaconChainConfig) (int, error) {
+	if cfg.AltairForkEpoch > cfg.BellatrixForkEpoch || cfg.BellatrixForkEpoch > cfg.CapellaForkEpoch {
+		return 0, errors.Errorf(
+			"fork epochs must be ordered, got altair %d, bellatrix %d and capella %d",
+			cfg.AltairForkEpoch, cfg.BellatrixForkEpoch, cfg.CapellaForkEpoch,
+		)
+	}
+	switch {
+	case cfg.CapellaForkEpoch == 0:
+		return 0, errors.New("capella genesis states are not supported yet")
+	case cfg.BellatrixForkEpoch == 0:
+		return version.Bellatrix, nil
+	case cfg.AltairForkEpoch == 0:
+		return version.Altair, nil
+	default:
+		return version.Phase0, nil
+	}
+}
+
+// executionGenesisHeader returns the header of the genesis block of the execution genesis.json at path.
+func executionGenesisHeader(path string) (*enginev1.ExecutionPayloadHeader, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is set by the operator.
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read execution genesis")
+	}
+	g := &core.Genesis{}
+	if err := json.Unmarshal(data, g); err != nil {
+		return nil, errors.Wrap(err, "could not decode execution genesis")
+	}
+	return interop.ExecutionPayloadHeaderFromGenesis(g)
+}
@@ -0,0 +1,5 @@
+package testnet
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "prysmctl-testnet")
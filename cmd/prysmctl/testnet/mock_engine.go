@@ -0,0 +1,90 @@
+package testnet
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/prysmctl/testnet/mockengine"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/urfave/cli/v2"
+)
+
+var mockEngineFlags = struct {
+	Addr        string
+	GenesisTime uint64
+	Faults      string
+}{}
+
+var mockEngineCmd = &cli.Command{
+	Name: "mock-engine",
+	Usage: "Run a mock execution client which builds empty payloads and considers every payload valid. Faults can " +
+		"be scripted on specific slots to test the way beacon nodes deal with a misbehaving execution client.",
+	Action: cliActionMockEngine,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "addr",
+			Usage:       "host:port the mock execution client serves JSON-RPC requests on",
+			Destination: &mockEngineFlags.Addr,
+			Value:       "127.0.0.1:8551",
+		},
+		&cli.Uint64Flag{
+			Name:        "genesis-time",
+			Usage:       "Genesis time of the beacon chain as a unix timestamp, used to find the slots faults apply to",
+			Destination: &mockEngineFlags.GenesisTime,
+		},
+		engineFaultsFlag(&mockEngineFlags.Faults),
+		cmd.MinimalConfigFlag,
+		cmd.ChainConfigFileFlag,
+	},
+}
+
+func engineFaultsFlag(destination *string) cli.Flag {
+	return &cli.StringFlag{
+		Name: "engine-faults",
+		Usage: "Path to a YAML file scripting faults of the mock execution client (delay, timeout, syncing, invalid, " +
+			"corrupt) on specific slots and engine API methods",
+		Destination: destination,
+	}
+}
+
+func cliActionMockEngine(cliCtx *cli.Context) error {
+	f := mockEngineFlags
+	if err := configureChain(cliCtx); err != nil {
+		return err
+	}
+	opts := []mockengine.Option{mockengine.WithGenesisTime(f.GenesisTime)}
+	if f.Faults != "" {
+		faults, err := mockengine.LoadFaults(f.Faults)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, mockengine.WithFaults(faults))
+	}
+	engine, err := mockengine.New(f.Addr, opts...)
+	if err != nil {
+		return err
+	}
+	engine.Start()
+	log.WithField("url", engine.URL()).Info("Mock execution engine started, interrupt to stop it")
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	<-sigc
+	return engine.Stop()
+}
+
+// configureChain sets the beacon config the nodes and the mock execution engine run with.
+func configureChain(cliCtx *cli.Context) error {
+	if cliCtx.Bool(cmd.MinimalConfigFlag.Name) {
+		if err := params.SetActive(params.MinimalSpecConfig().Copy()); err != nil {
+			return err
+		}
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		return params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package mockengine
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Method names of the namespaces follow the go-ethereum rpc conventions, e.g. ethAPI.ChainId serves eth_chainId.
+
+type ethAPI struct {
+	engine *Engine
+}
+
+func (*ethAPI) ChainId(_ context.Context) *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(params.BeaconConfig().DepositChainID))
+}
+
+func (a *ethAPI) BlockNumber(_ context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(a.engine.headBlock().Number.Uint64())
+}
+
+func (*ethAPI) Syncing(_ context.Context) bool {
+	return false
+}
+
+func (a *ethAPI) GetBlockByNumber(_ context.Context, number string, _ bool) (*pb.ExecutionBlock, error) {
+	switch number {
+	case "latest", "safe", "finalized", "pending":
+		return a.engine.headBlock(), nil
+	case "earliest":
+		return a.engine.blockByNumber(0), nil
+	}
+	n, err := hexutil.DecodeUint64(number)
+	if err != nil {
+		return nil, err
+	}
+	return a.engine.blockByNumber(n), nil
+}
+
+func (a *ethAPI) GetBlockByHash(_ context.Context, hash common.Hash, _ bool) *pb.ExecutionBlock {
+	return a.engine.block(hash)
+}
+
+func (*ethAPI) GetLogs(_ context.Context, _ map[string]interface{}) []gethTypes.Log {
+	return []gethTypes.Log{}
+}
+
+type netAPI struct{}
+
+func (*netAPI) Version(_ context.Context) string {
+	return fmt.Sprintf("%d", params.BeaconConfig().DepositNetworkID)
+}
+
+// forkchoiceUpdatedResponse is the result of engine_forkchoiceUpdatedV1.
+type forkchoiceUpdatedResponse struct {
+	Status    *pb.PayloadStatus  `json:"payloadStatus"`
+	PayloadId *pb.PayloadIDBytes `json:"payloadId"`
+}
+
+// unknownPayloadError is the engine API error returned for unknown payload IDs.
+type unknownPayloadError struct{}
+
+func (unknownPayloadError) Error() string  { return "Unknown payload" }
+func (unknownPayloadError) ErrorCode() int { return -38001 }
+
+type engineAPI struct {
+	engine *Engine
+}
+
+func (a *engineAPI) NewPayloadV1(ctx context.Context, payload *pb.ExecutionPayload) (*pb.PayloadStatus, error) {
+	if fault := a.engine.faults.match(NewPayloadMethod, a.engine.slotAt(payload.Timestamp)); fault != nil {
+		logFault(NewPayloadMethod, fault)
+		if err := fault.wait(ctx); err != nil {
+			return nil, err
+		}
+		switch fault.Action {
+		case ActionSyncing:
+			return &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}, nil
+		case ActionInvalid:
+			return &pb.PayloadStatus{
+				Status:          pb.PayloadStatus_INVALID,
+				LatestValidHash: payload.ParentHash,
+				ValidationError: "invalid payload injected by the mock execution engine",
+			}, nil
+		case ActionCorrupt:
+			return &pb.PayloadStatus{Status: pb.PayloadStatus_UNKNOWN}, nil
+		}
+	}
+	if a.engine.block(common.BytesToHash(payload.ParentHash)) == nil {
+		return &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}, nil
+	}
+	a.engine.savePayload(payload)
+	return &pb.PayloadStatus{Status: pb.PayloadStatus_VALID, LatestValidHash: payload.BlockHash}, nil
+}
+
+func (a *engineAPI) ForkchoiceUpdatedV1(
+	ctx context.Context, state *pb.ForkchoiceState, attrs *pb.PayloadAttributes,
+) (*forkchoiceUpdatedResponse, error) {
+	var timestamp uint64
+	if attrs != nil {
+		timestamp = attrs.Timestamp
+	}
+	if fault := a.engine.faults.match(ForkchoiceUpdatedMethod, a.engine.slotAt(timestamp)); fault != nil {
+		logFault(ForkchoiceUpdatedMethod, fault)
+		if err := fault.wait(ctx); err != nil {
+			return nil, err
+		}
+		switch fault.Action {
+		case ActionSyncing:
+			return &forkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}}, nil
+		case ActionInvalid:
+			latestValid := a.engine.headBlock()
+			return &forkchoiceUpdatedResponse{Status: &pb.PayloadStatus{
+				Status:          pb.PayloadStatus_INVALID,
+				LatestValidHash: latestValid.Hash.Bytes(),
+				ValidationError: "invalid head injected by the mock execution engine",
+			}}, nil
+		case ActionCorrupt:
+			return &forkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: pb.PayloadStatus_UNKNOWN}}, nil
+		}
+	}
+	head := a.engine.block(common.BytesToHash(state.HeadBlockHash))
+	if head == nil {
+		return &forkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}}, nil
+	}
+	a.engine.setHead(head.Hash)
+	resp := &forkchoiceUpdatedResponse{
+		Status: &pb.PayloadStatus{Status: pb.PayloadStatus_VALID, LatestValidHash: head.Hash.Bytes()},
+	}
+	if attrs != nil {
+		id := a.engine.buildPayload(head, attrs)
+		resp.PayloadId = &id
+	}
+	return resp, nil
+}
+
+func (a *engineAPI) GetPayloadV1(ctx context.Context, id pb.PayloadIDBytes) (*pb.ExecutionPayload, error) {
+	payload := a.engine.payload(id)
+	if payload == nil {
+		return nil, unknownPayloadError{}
+	}
+	if fault := a.engine.faults.match(GetPayloadMethod, a.engine.slotAt(payload.Timestamp)); fault != nil {
+		logFault(GetPayloadMethod, fault)
+		if err := fault.wait(ctx); err != nil {
+			return nil, err
+		}
+		if fault.Action == ActionCorrupt {
+			corrupted, ok := proto.Clone(payload).(*pb.ExecutionPayload)
+			if !ok {
+				return nil, errors.New("could not copy payload")
+			}
+			corrupted.ParentHash = bytesutil.PadTo([]byte("corrupted parent hash"), 32)
+			corrupted.BlockHash = bytesutil.PadTo([]byte("corrupted block hash"), 32)
+			return corrupted, nil
+		}
+	}
+	return payload, nil
+}
+
+func (*engineAPI) ExchangeTransitionConfigurationV1(
+	_ context.Context, cfg *pb.TransitionConfiguration,
+) *pb.TransitionConfiguration {
+	return cfg
+}
+
+func logFault(method string, f *Fault) {
+	l := log.WithField("method", method).WithField("action", f.Action)
+	if f.Action == ActionDelay {
+		l = l.WithField("delay", f.Delay)
+	}
+	l.Info("Injecting fault")
+}
@@ -0,0 +1,246 @@
+// Package mockengine implements a minimal execution client serving the JSON-RPC methods a beacon node calls,
+// with no transactions nor state. Faults can be scripted on specific slots to exercise the way a beacon node
+// deals with a misbehaving execution client.
+package mockengine
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	pb "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+)
+
+const (
+	gasLimit = 30_000_000
+	baseFee  = 1_000_000_000
+)
+
+// Option for configuring the mock execution engine.
+type Option func(e *Engine) error
+
+// WithFaults scripts the faults the mock execution engine injects into its responses.
+func WithFaults(f *Faults) Option {
+	return func(e *Engine) error {
+		e.faults = f
+		return nil
+	}
+}
+
+// WithGenesisTime sets the genesis time of the beacon chain, which is needed to find the slots faults apply to.
+func WithGenesisTime(genesisTime uint64) Option {
+	return func(e *Engine) error {
+		e.genesisTime = genesisTime
+		return nil
+	}
+}
+
+// Engine is a mock execution client. It starts from a terminal proof of work block and builds empty payloads on
+// top of it, considering every payload it is given valid.
+type Engine struct {
+	server      *http.Server
+	listener    net.Listener
+	faults      *Faults
+	genesisTime uint64
+
+	lock     sync.RWMutex
+	blocks   map[common.Hash]*pb.ExecutionBlock
+	head     common.Hash
+	payloads map[pb.PayloadIDBytes]*pb.ExecutionPayload
+}
+
+// New creates a mock execution engine listening for JSON-RPC requests on the given address.
+func New(addr string, opts ...Option) (*Engine, error) {
+	e := &Engine{
+		faults:   &Faults{},
+		blocks:   make(map[common.Hash]*pb.ExecutionBlock),
+		payloads: make(map[pb.PayloadIDBytes]*pb.ExecutionPayload),
+	}
+	for _, o := range opts {
+		if err := o(e); err != nil {
+			return nil, err
+		}
+	}
+
+	terminal, err := terminalBlock()
+	if err != nil {
+		return nil, err
+	}
+	e.blocks[terminal.Hash] = terminal
+	e.head = terminal.Hash
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", &ethAPI{engine: e}); err != nil {
+		return nil, errors.Wrap(err, "could not register eth namespace")
+	}
+	if err := srv.RegisterName("net", &netAPI{}); err != nil {
+		return nil, errors.Wrap(err, "could not register net namespace")
+	}
+	if err := srv.RegisterName("engine", &engineAPI{engine: e}); err != nil {
+		return nil, errors.Wrap(err, "could not register engine namespace")
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not listen for mock execution engine requests")
+	}
+	e.listener = listener
+	e.server = &http.Server{Handler: srv, ReadHeaderTimeout: time.Second}
+	return e, nil
+}
+
+// URL of the mock execution engine's JSON-RPC endpoint.
+func (e *Engine) URL() string {
+	return fmt.Sprintf("http://%s", e.listener.Addr())
+}
+
+// Start serves JSON-RPC requests until the mock execution engine is stopped.
+func (e *Engine) Start() {
+	go func() {
+		if err := e.server.Serve(e.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Mock execution engine stopped serving requests")
+		}
+	}()
+}
+
+// Stop the mock execution engine.
+func (e *Engine) Stop() error {
+	return e.server.Close()
+}
+
+// The terminal proof of work block reaches the terminal total difficulty of the beacon config, so that the merge
+// transition happens on the first payload built after the Bellatrix fork.
+func terminalBlock() (*pb.ExecutionBlock, error) {
+	ttd, ok := new(big.Int).SetString(params.BeaconConfig().TerminalTotalDifficulty, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid terminal total difficulty %s", params.BeaconConfig().TerminalTotalDifficulty)
+	}
+	header := gethTypes.Header{
+		Number:     big.NewInt(0),
+		Difficulty: ttd,
+		GasLimit:   gasLimit,
+		Time:       uint64(time.Now().Unix()),
+	}
+	return &pb.ExecutionBlock{
+		Header:          header,
+		Hash:            header.Hash(),
+		TotalDifficulty: fmt.Sprintf("%#x", ttd),
+	}, nil
+}
+
+// Returns the slot of the given timestamp, or the current slot if the timestamp is 0.
+func (e *Engine) slotAt(timestamp uint64) types.Slot {
+	if timestamp == 0 {
+		timestamp = uint64(time.Now().Unix())
+	}
+	if timestamp < e.genesisTime {
+		return 0
+	}
+	return types.Slot((timestamp - e.genesisTime) / params.BeaconConfig().SecondsPerSlot)
+}
+
+func (e *Engine) block(hash common.Hash) *pb.ExecutionBlock {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.blocks[hash]
+}
+
+func (e *Engine) headBlock() *pb.ExecutionBlock {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.blocks[e.head]
+}
+
+func (e *Engine) blockByNumber(number uint64) *pb.ExecutionBlock {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	for b := e.blocks[e.head]; b != nil; b = e.blocks[b.ParentHash] {
+		if b.Number.Uint64() == number {
+			return b
+		}
+	}
+	return nil
+}
+
+// Saves the block of an execution payload given to the engine.
+func (e *Engine) savePayload(payload *pb.ExecutionPayload) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	hash := common.BytesToHash(payload.BlockHash)
+	if _, ok := e.blocks[hash]; ok {
+		return
+	}
+	parent := e.blocks[common.BytesToHash(payload.ParentHash)]
+	e.blocks[hash] = &pb.ExecutionBlock{
+		Header: gethTypes.Header{
+			ParentHash:  common.BytesToHash(payload.ParentHash),
+			Coinbase:    common.BytesToAddress(payload.FeeRecipient),
+			Root:        common.BytesToHash(payload.StateRoot),
+			ReceiptHash: common.BytesToHash(payload.ReceiptsRoot),
+			MixDigest:   common.BytesToHash(payload.PrevRandao),
+			Difficulty:  big.NewInt(0),
+			Number:      new(big.Int).SetUint64(payload.BlockNumber),
+			GasLimit:    payload.GasLimit,
+			GasUsed:     payload.GasUsed,
+			Time:        payload.Timestamp,
+			Extra:       payload.ExtraData,
+			BaseFee:     new(big.Int).SetBytes(bytesutil.ReverseByteOrder(payload.BaseFeePerGas)),
+		},
+		Hash:            hash,
+		TotalDifficulty: parent.TotalDifficulty,
+	}
+}
+
+// Builds an empty payload on top of the given parent and returns its ID.
+func (e *Engine) buildPayload(parent *pb.ExecutionBlock, attrs *pb.PayloadAttributes) pb.PayloadIDBytes {
+	payload := &pb.ExecutionPayload{
+		ParentHash:    parent.Hash.Bytes(),
+		FeeRecipient:  attrs.SuggestedFeeRecipient,
+		StateRoot:     parent.Root.Bytes(),
+		ReceiptsRoot:  gethTypes.EmptyRootHash.Bytes(),
+		LogsBloom:     make([]byte, gethTypes.BloomByteLength),
+		PrevRandao:    attrs.PrevRandao,
+		BlockNumber:   parent.Number.Uint64() + 1,
+		GasLimit:      gasLimit,
+		Timestamp:     attrs.Timestamp,
+		ExtraData:     []byte{},
+		BaseFeePerGas: bytesutil.PadTo(bytesutil.ReverseByteOrder(big.NewInt(baseFee).Bytes()), 32),
+		Transactions:  [][]byte{},
+	}
+	// The mock engine doesn't execute anything, a hash of the payload's inputs is unique enough to identify it.
+	payload.BlockHash = crypto.Keccak256(
+		payload.ParentHash,
+		bytesutil.Uint64ToBytesBigEndian(payload.Timestamp),
+		payload.PrevRandao,
+		payload.FeeRecipient,
+	)
+	var id pb.PayloadIDBytes
+	copy(id[:], payload.BlockHash)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.payloads[id] = payload
+	return id
+}
+
+func (e *Engine) payload(id pb.PayloadIDBytes) *pb.ExecutionPayload {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.payloads[id]
+}
+
+func (e *Engine) setHead(hash common.Hash) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.head = hash
+}
@@ -0,0 +1,136 @@
+package mockengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	pb "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func setupEngine(t *testing.T, opts ...Option) (*Engine, *rpc.Client) {
+	e, err := New("127.0.0.1:0", opts...)
+	require.NoError(t, err)
+	e.Start()
+	t.Cleanup(func() {
+		require.NoError(t, e.Stop())
+	})
+	client, err := rpc.Dial(e.URL())
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return e, client
+}
+
+// Builds a payload on top of the head of the engine and imports it, as a beacon node proposing a block would.
+func buildAndImport(t *testing.T, client *rpc.Client, head common.Hash, timestamp uint64) *pb.ExecutionPayload {
+	ctx := context.Background()
+	fcu := &forkchoiceUpdatedResponse{}
+	attrs := &pb.PayloadAttributes{
+		Timestamp:             timestamp,
+		PrevRandao:            make([]byte, 32),
+		SuggestedFeeRecipient: make([]byte, 20),
+	}
+	state := &pb.ForkchoiceState{HeadBlockHash: head[:], SafeBlockHash: head[:], FinalizedBlockHash: make([]byte, 32)}
+	require.NoError(t, client.CallContext(ctx, fcu, ForkchoiceUpdatedMethod, state, attrs))
+	require.Equal(t, pb.PayloadStatus_VALID, fcu.Status.Status)
+	require.NotNil(t, fcu.PayloadId)
+
+	payload := &pb.ExecutionPayload{}
+	require.NoError(t, client.CallContext(ctx, payload, GetPayloadMethod, fcu.PayloadId))
+	assert.DeepEqual(t, head[:], payload.ParentHash)
+	assert.Equal(t, timestamp, payload.Timestamp)
+
+	status := &pb.PayloadStatus{}
+	require.NoError(t, client.CallContext(ctx, status, NewPayloadMethod, payload))
+	require.Equal(t, pb.PayloadStatus_VALID, status.Status)
+	return payload
+}
+
+func TestEngine_BuildsOnHead(t *testing.T) {
+	e, client := setupEngine(t)
+	terminal := e.headBlock()
+
+	payload := buildAndImport(t, client, terminal.Hash, 12)
+	block := &pb.ExecutionBlock{}
+	require.NoError(t, client.CallContext(context.Background(), block, "eth_getBlockByHash", common.BytesToHash(payload.BlockHash), false))
+	assert.Equal(t, uint64(1), block.Number.Uint64())
+	assert.Equal(t, terminal.TotalDifficulty, block.TotalDifficulty)
+
+	next := buildAndImport(t, client, common.BytesToHash(payload.BlockHash), 24)
+	assert.Equal(t, uint64(2), next.BlockNumber)
+	require.NoError(t, client.CallContext(context.Background(), block, "eth_getBlockByNumber", "latest", false))
+	assert.Equal(t, common.BytesToHash(payload.BlockHash), block.Hash, "head only moves on forkchoice updates")
+}
+
+func TestEngine_UnknownPayload(t *testing.T) {
+	_, client := setupEngine(t)
+	err := client.CallContext(context.Background(), &pb.ExecutionPayload{}, GetPayloadMethod, pb.PayloadIDBytes{1})
+	require.NotNil(t, err)
+	rpcErr, ok := err.(rpc.Error)
+	require.Equal(t, true, ok)
+	assert.Equal(t, -38001, rpcErr.ErrorCode())
+}
+
+func TestEngine_Faults(t *testing.T) {
+	faults := &Faults{Faults: []*Fault{
+		{Slot: 1, Method: NewPayloadMethod, Action: ActionSyncing},
+		{Slot: 2, Method: NewPayloadMethod, Action: ActionInvalid},
+		{Slot: 3, Method: ForkchoiceUpdatedMethod, Action: ActionCorrupt},
+		{Slot: 4, Method: GetPayloadMethod, Action: ActionCorrupt},
+		{Slot: 5, Method: GetPayloadMethod, Action: ActionTimeout},
+	}}
+	e, client := setupEngine(t, WithFaults(faults), WithGenesisTime(0))
+	ctx := context.Background()
+	head := e.headBlock().Hash
+	seconds := func(slot uint64) uint64 { return slot * 12 }
+
+	payload := &pb.ExecutionPayload{
+		ParentHash:    head[:],
+		FeeRecipient:  make([]byte, 20),
+		StateRoot:     make([]byte, 32),
+		ReceiptsRoot:  make([]byte, 32),
+		LogsBloom:     make([]byte, 256),
+		PrevRandao:    make([]byte, 32),
+		BaseFeePerGas: make([]byte, 32),
+		BlockHash:     common.Hash{'a'}.Bytes(),
+		ExtraData:     []byte{},
+		Transactions:  [][]byte{},
+	}
+	status := &pb.PayloadStatus{}
+	payload.Timestamp = seconds(1)
+	require.NoError(t, client.CallContext(ctx, status, NewPayloadMethod, payload))
+	assert.Equal(t, pb.PayloadStatus_SYNCING, status.Status)
+
+	payload.Timestamp = seconds(2)
+	require.NoError(t, client.CallContext(ctx, status, NewPayloadMethod, payload))
+	assert.Equal(t, pb.PayloadStatus_INVALID, status.Status)
+	assert.DeepEqual(t, head[:], status.LatestValidHash)
+	assert.Equal(t, true, e.block(common.Hash{'a'}) == nil)
+
+	fcu := &forkchoiceUpdatedResponse{}
+	state := &pb.ForkchoiceState{HeadBlockHash: head[:], SafeBlockHash: head[:], FinalizedBlockHash: make([]byte, 32)}
+	attrs := &pb.PayloadAttributes{Timestamp: seconds(3), PrevRandao: make([]byte, 32), SuggestedFeeRecipient: make([]byte, 20)}
+	require.NoError(t, client.CallContext(ctx, fcu, ForkchoiceUpdatedMethod, state, attrs))
+	assert.Equal(t, pb.PayloadStatus_UNKNOWN, fcu.Status.Status)
+
+	attrs.Timestamp = seconds(4)
+	require.NoError(t, client.CallContext(ctx, fcu, ForkchoiceUpdatedMethod, state, attrs))
+	require.Equal(t, pb.PayloadStatus_VALID, fcu.Status.Status)
+	built := &pb.ExecutionPayload{}
+	require.NoError(t, client.CallContext(ctx, built, GetPayloadMethod, fcu.PayloadId))
+	assert.NotEqual(t, head, common.BytesToHash(built.ParentHash))
+	assert.DeepEqual(t, e.payload(*fcu.PayloadId).Timestamp, built.Timestamp)
+	assert.NotEqual(t, common.BytesToHash(e.payload(*fcu.PayloadId).BlockHash), common.BytesToHash(built.BlockHash))
+
+	attrs.Timestamp = seconds(5)
+	require.NoError(t, client.CallContext(ctx, fcu, ForkchoiceUpdatedMethod, state, attrs))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err := client.CallContext(timeoutCtx, built, GetPayloadMethod, fcu.PayloadId)
+	assert.Equal(t, true, errors.Is(err, context.DeadlineExceeded))
+}
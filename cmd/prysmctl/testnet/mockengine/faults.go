@@ -0,0 +1,147 @@
+package mockengine
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"gopkg.in/yaml.v2"
+)
+
+// Engine API methods faults can be scripted for.
+const (
+	NewPayloadMethod        = "engine_newPayloadV1"
+	ForkchoiceUpdatedMethod = "engine_forkchoiceUpdatedV1"
+	GetPayloadMethod        = "engine_getPayloadV1"
+)
+
+// Action of a scripted fault.
+type Action string
+
+const (
+	// ActionDelay delays the response by the delay of the fault.
+	ActionDelay Action = "delay"
+	// ActionTimeout never responds, until the caller gives up on the request.
+	ActionTimeout Action = "timeout"
+	// ActionSyncing responds with a SYNCING payload status.
+	ActionSyncing Action = "syncing"
+	// ActionInvalid responds with an INVALID payload status, the parent of the payload being the latest valid one.
+	ActionInvalid Action = "invalid"
+	// ActionCorrupt responds with a payload with a wrong parent and block hash to engine_getPayloadV1, and with
+	// an unknown payload status to the other methods.
+	ActionCorrupt Action = "corrupt"
+)
+
+// Fault injected by the mock execution engine in its responses to requests for the slots of the fault.
+type Fault struct {
+	// Slot is the first slot the fault applies to.
+	Slot types.Slot `yaml:"slot"`
+	// ToSlot is the last slot the fault applies to. The fault only applies to Slot if not set.
+	ToSlot types.Slot `yaml:"to_slot"`
+	// Method the fault applies to. The fault applies to all the methods if not set.
+	Method string `yaml:"method"`
+	// Action taken by the mock execution engine.
+	Action Action `yaml:"action"`
+	// Delay of the response for the delay action, ex: 2s.
+	Delay time.Duration `yaml:"delay"`
+}
+
+// Faults scripted for the mock execution engine. The first fault matching a request applies.
+type Faults struct {
+	Faults []*Fault `yaml:"faults"`
+}
+
+// LoadFaults reads faults from a YAML file, for example:
+//
+//	faults:
+//	  - slot: 40
+//	    method: engine_newPayloadV1
+//	    action: syncing
+//	  - slot: 64
+//	    to_slot: 70
+//	    action: delay
+//	    delay: 3s
+func LoadFaults(path string) (*Faults, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read faults file")
+	}
+	f := &Faults{}
+	if err := yaml.UnmarshalStrict(enc, f); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal faults")
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *Faults) validate() error {
+	for i, fault := range f.Faults {
+		if fault.ToSlot != 0 && fault.ToSlot < fault.Slot {
+			return errors.Errorf("fault %d: to_slot %d is before slot %d", i, fault.ToSlot, fault.Slot)
+		}
+		switch fault.Method {
+		case "", NewPayloadMethod, ForkchoiceUpdatedMethod, GetPayloadMethod:
+		default:
+			return errors.Errorf("fault %d: unsupported method %s", i, fault.Method)
+		}
+		switch fault.Action {
+		case ActionDelay:
+			if fault.Delay <= 0 {
+				return errors.Errorf("fault %d: delay action needs a positive delay", i)
+			}
+		case ActionSyncing, ActionInvalid:
+			if fault.Method == GetPayloadMethod {
+				return errors.Errorf("fault %d: %s action doesn't apply to %s", i, fault.Action, GetPayloadMethod)
+			}
+		case ActionTimeout, ActionCorrupt:
+		default:
+			return errors.Errorf("fault %d: unsupported action %q", i, fault.Action)
+		}
+	}
+	return nil
+}
+
+// Returns the fault applying to a call of the given method for the given slot, or nil if there is none.
+func (f *Faults) match(method string, slot types.Slot) *Fault {
+	for _, fault := range f.Faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		last := fault.ToSlot
+		if last == 0 {
+			last = fault.Slot
+		}
+		if slot < fault.Slot || slot > last {
+			continue
+		}
+		// Payload statuses can't be returned by engine_getPayloadV1.
+		if method == GetPayloadMethod && (fault.Action == ActionSyncing || fault.Action == ActionInvalid) {
+			continue
+		}
+		return fault
+	}
+	return nil
+}
+
+// Blocks for the delay of the fault, or until the request is abandoned for the timeout action. Other actions are
+// taken by the callers as they depend on the method.
+func (f *Fault) wait(ctx context.Context) error {
+	switch f.Action {
+	case ActionDelay:
+		select {
+		case <-time.After(f.Delay):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case ActionTimeout:
+		<-ctx.Done()
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
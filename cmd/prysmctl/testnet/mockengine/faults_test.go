@@ -0,0 +1,115 @@
+package mockengine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func writeFaults(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "faults.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+	return path
+}
+
+func TestLoadFaults(t *testing.T) {
+	path := writeFaults(t, `
+faults:
+  - slot: 40
+    method: engine_newPayloadV1
+    action: syncing
+  - slot: 64
+    to_slot: 70
+    action: delay
+    delay: 3s
+`)
+	f, err := LoadFaults(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(f.Faults))
+	assert.DeepEqual(t, &Fault{Slot: 40, Method: NewPayloadMethod, Action: ActionSyncing}, f.Faults[0])
+	assert.DeepEqual(t, &Fault{Slot: 64, ToSlot: 70, Action: ActionDelay, Delay: 3 * time.Second}, f.Faults[1])
+}
+
+func TestLoadFaults_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "faults:\n  - slot: 1\n    action: syncing\n    slots: 2\n",
+			wantErr: "could not unmarshal faults",
+		},
+		{
+			name:    "unsupported action",
+			content: "faults:\n  - slot: 1\n    action: crash\n",
+			wantErr: "unsupported action",
+		},
+		{
+			name:    "unsupported method",
+			content: "faults:\n  - slot: 1\n    method: eth_chainId\n    action: timeout\n",
+			wantErr: "unsupported method",
+		},
+		{
+			name:    "delay without duration",
+			content: "faults:\n  - slot: 1\n    action: delay\n",
+			wantErr: "positive delay",
+		},
+		{
+			name:    "slot range backwards",
+			content: "faults:\n  - slot: 10\n    to_slot: 5\n    action: timeout\n",
+			wantErr: "is before slot",
+		},
+		{
+			name:    "status for get payload",
+			content: "faults:\n  - slot: 1\n    method: engine_getPayloadV1\n    action: invalid\n",
+			wantErr: "doesn't apply",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFaults(writeFaults(t, tt.content))
+			assert.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestFaults_Match(t *testing.T) {
+	f := &Faults{Faults: []*Fault{
+		{Slot: 5, Method: NewPayloadMethod, Action: ActionInvalid},
+		{Slot: 10, ToSlot: 12, Action: ActionSyncing},
+		{Slot: 10, Action: ActionCorrupt},
+	}}
+	tests := []struct {
+		method string
+		slot   types.Slot
+		want   *Fault
+	}{
+		{method: NewPayloadMethod, slot: 5, want: f.Faults[0]},
+		{method: ForkchoiceUpdatedMethod, slot: 5},
+		{method: NewPayloadMethod, slot: 4},
+		{method: ForkchoiceUpdatedMethod, slot: 11, want: f.Faults[1]},
+		{method: NewPayloadMethod, slot: 12, want: f.Faults[1]},
+		{method: NewPayloadMethod, slot: 13},
+		{method: GetPayloadMethod, slot: 10, want: f.Faults[2]},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, f.match(tt.method, tt.slot), "%s at slot %d", tt.method, tt.slot)
+	}
+}
+
+func TestFault_Wait(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, true, errors.Is((&Fault{Action: ActionTimeout}).wait(ctx), context.DeadlineExceeded))
+	assert.NoError(t, (&Fault{Action: ActionDelay, Delay: time.Millisecond}).wait(context.Background()))
+	assert.NoError(t, (&Fault{Action: ActionSyncing}).wait(context.Background()))
+}
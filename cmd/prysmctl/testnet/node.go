@@ -0,0 +1,194 @@
+package testnet
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/builder"
+	beaconnode "github.com/prysmaticlabs/prysm/v3/beacon-chain/node"
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	beaconappflags "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/appflags"
+	blockchaincmd "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/blockchain"
+	executioncmd "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/execution"
+	beaconflags "github.com/prysmaticlabs/prysm/v3/cmd/beacon-chain/flags"
+	validatorappflags "github.com/prysmaticlabs/prysm/v3/cmd/validator/appflags"
+	validatorflags "github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	validatornode "github.com/prysmaticlabs/prysm/v3/validator/node"
+	"github.com/urfave/cli/v2"
+)
+
+// Flags of the beacon nodes and validator clients of the network. Unlike the binaries, the nodes are configured by
+// flag sets built here, so the flags of the binaries are applied for the nodes to read their default values.
+var (
+	beaconNodeFlags = append(append([]cli.Flag{}, beaconappflags.Flags...), features.BeaconChainFlags...)
+	validatorFlags  = append(append([]cli.Flag{}, validatorappflags.Flags...), features.ValidatorFlags...)
+)
+
+// network holds the configuration shared by all the nodes of a test network.
+type network struct {
+	dataDir           string
+	genesisPath       string
+	engineURL         string
+	basePort          uint64
+	validatorsPerNode uint64
+	verbosity         string
+	minimalConfig     bool
+	chainConfigFile   string
+	peers             []string
+}
+
+// newNode creates the beacon node with the given index along with its validator client. The beacon node peers with
+// all the beacon nodes created before it. The returned functions start the nodes and block until they are stopped.
+func (n *network) newNode(ctx context.Context, index uint64) ([]func(), error) {
+	nodeDir := filepath.Join(n.dataDir, fmt.Sprintf("node-%d", index))
+	p2pPort := n.basePort + 4*index
+	rpcPort := p2pPort + 2
+	gatewayPort := p2pPort + 3
+
+	keyPath := filepath.Join(nodeDir, "p2p.key")
+	peerID, err := writeP2PKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	beaconArgs := []string{
+		flagArg(cmd.DataDirFlag.Name, filepath.Join(nodeDir, "beacon")),
+		flagArg(cmd.ForceClearDB.Name, "true"),
+		flagArg(cmd.VerbosityFlag.Name, n.verbosity),
+		flagArg(cmd.MinimalConfigFlag.Name, fmt.Sprintf("%t", n.minimalConfig)),
+		flagArg(cmd.DisableMonitoringFlag.Name, "true"),
+		flagArg(cmd.NoDiscovery.Name, "true"),
+		flagArg(cmd.P2PIP.Name, "127.0.0.1"),
+		flagArg(cmd.P2PTCPPort.Name, fmt.Sprintf("%d", p2pPort)),
+		flagArg(cmd.P2PUDPPort.Name, fmt.Sprintf("%d", p2pPort+1)),
+		flagArg(cmd.P2PPrivKey.Name, keyPath),
+		flagArg(beaconflags.RPCHost.Name, "127.0.0.1"),
+		flagArg(beaconflags.RPCPort.Name, fmt.Sprintf("%d", rpcPort)),
+		flagArg(beaconflags.GRPCGatewayHost.Name, "127.0.0.1"),
+		flagArg(beaconflags.GRPCGatewayPort.Name, fmt.Sprintf("%d", gatewayPort)),
+		flagArg(beaconflags.ExecutionEngineEndpoint.Name, n.engineURL),
+		flagArg(beaconflags.InteropGenesisStateFlag.Name, n.genesisPath),
+		flagArg(beaconflags.InteropMockEth1DataVotesFlag.Name, "true"),
+		flagArg(beaconflags.MinSyncPeers.Name, "0"),
+		flagArg(beaconflags.SubscribeToAllSubnets.Name, "true"),
+	}
+	for _, p := range n.peers {
+		beaconArgs = append(beaconArgs, flagArg(cmd.StaticPeers.Name, p))
+	}
+	if n.chainConfigFile != "" {
+		beaconArgs = append(beaconArgs, flagArg(cmd.ChainConfigFileFlag.Name, n.chainConfigFile))
+	}
+	beaconCtx, err := newCLIContext(ctx, beaconNodeFlags, beaconArgs)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := beaconNodeOptions(beaconCtx)
+	if err != nil {
+		return nil, err
+	}
+	// Nodes register prometheus collectors under fixed names, which can only be registered once per registry.
+	// Every node of the network gets a registry of its own, monitoring is disabled anyway.
+	opts = append(opts, beaconnode.WithRegisterer(prometheus.NewRegistry()))
+	// Nodes peer through memory, their p2p addresses are only used to dial each other.
+	opts = append(opts, beaconnode.WithInMemoryP2PTransport())
+	beacon, err := beaconnode.New(beaconCtx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create beacon node")
+	}
+	n.peers = append(n.peers, fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", p2pPort, peerID))
+
+	validatorArgs := []string{
+		flagArg(cmd.DataDirFlag.Name, filepath.Join(nodeDir, "validator")),
+		flagArg(cmd.ForceClearDB.Name, "true"),
+		flagArg(cmd.VerbosityFlag.Name, n.verbosity),
+		flagArg(cmd.MinimalConfigFlag.Name, fmt.Sprintf("%t", n.minimalConfig)),
+		flagArg(cmd.DisableMonitoringFlag.Name, "true"),
+		flagArg(validatorflags.BeaconRPCProviderFlag.Name, fmt.Sprintf("127.0.0.1:%d", rpcPort)),
+		flagArg(validatorflags.BeaconRPCGatewayProviderFlag.Name, fmt.Sprintf("127.0.0.1:%d", gatewayPort)),
+		flagArg(validatorflags.InteropStartIndex.Name, fmt.Sprintf("%d", index*n.validatorsPerNode)),
+		flagArg(validatorflags.InteropNumValidators.Name, fmt.Sprintf("%d", n.validatorsPerNode)),
+	}
+	if n.chainConfigFile != "" {
+		validatorArgs = append(validatorArgs, flagArg(cmd.ChainConfigFileFlag.Name, n.chainConfigFile))
+	}
+	validatorCtx, err := newCLIContext(ctx, validatorFlags, validatorArgs)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := validatornode.NewValidatorClient(validatorCtx, validatornode.WithRegisterer(prometheus.NewRegistry()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create validator client")
+	}
+	return []func(){beacon.Start, validator.Start}, nil
+}
+
+// beaconNodeOptions builds the beacon node options the beacon-chain binary derives from its flags.
+func beaconNodeOptions(cliCtx *cli.Context) ([]beaconnode.Option, error) {
+	blockchainFlagOpts, err := blockchaincmd.FlagOptions(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+	executionFlagOpts, err := executioncmd.FlagOptions(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+	builderFlagOpts, err := builder.FlagOptions(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+	return []beaconnode.Option{
+		beaconnode.WithBlockchainFlagOptions(blockchainFlagOpts),
+		beaconnode.WithExecutionChainOptions(executionFlagOpts),
+		beaconnode.WithBuilderFlagOptions(builderFlagOpts),
+	}, nil
+}
+
+// newCLIContext parses args into a flag set holding the given flags, with their default values for the ones not
+// present in args.
+func newCLIContext(ctx context.Context, flags []cli.Flag, args []string) (*cli.Context, error) {
+	set := flag.NewFlagSet("", flag.ContinueOnError)
+	for _, f := range flags {
+		if err := f.Apply(set); err != nil {
+			return nil, errors.Wrapf(err, "could not apply flag %s", f.Names()[0])
+		}
+	}
+	if err := set.Parse(args); err != nil {
+		return nil, errors.Wrap(err, "could not parse node flags")
+	}
+	cliCtx := cli.NewContext(&cli.App{}, set, nil)
+	cliCtx.Context = ctx
+	return cliCtx, nil
+}
+
+func flagArg(name, value string) string {
+	return fmt.Sprintf("--%s=%s", name, value)
+}
+
+// writeP2PKey generates a p2p private key and saves it to path in the format expected by the p2p-priv-key flag.
+// The peer ID derived from the key is returned, so that other nodes can peer with the node using the key.
+func writeP2PKey(path string) (peer.ID, error) {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	if err != nil {
+		return "", errors.Wrap(err, "could not generate p2p key")
+	}
+	raw, err := priv.Raw()
+	if err != nil {
+		return "", err
+	}
+	if err := file.MkdirAll(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+	if err := file.WriteFile(path, []byte(hex.EncodeToString(raw))); err != nil {
+		return "", errors.Wrap(err, "could not save p2p key")
+	}
+	return peer.IDFromPrivateKey(priv)
+}
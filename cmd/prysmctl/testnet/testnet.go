@@ -0,0 +1,15 @@
+package testnet
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "testnet",
+		Usage: "commands for running local test networks",
+		Subcommands: []*cli.Command{
+			generateAndRunCmd,
+			generateGenesisCmd,
+			mockEngineCmd,
+		},
+	},
+}
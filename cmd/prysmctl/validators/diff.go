@@ -0,0 +1,237 @@
+package validators
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/urfave/cli/v2"
+)
+
+var diffFlags = struct {
+	BeaconNodeHost       string
+	Timeout              time.Duration
+	BalanceThresholdGwei uint64
+}{}
+
+var diffCmd = &cli.Command{
+	Name:      "diff",
+	Usage:     "Print the differences between the validator sets and balances of two beacon states.",
+	ArgsUsage: "<state a> <state b>, each a path to an ssz-encoded state file or a state id to request from the beacon node",
+	Action:    cliActionDiff,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for the beacon node to request states by id from",
+			Destination: &diffFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 4m",
+			Destination: &diffFlags.Timeout,
+			Value:       time.Minute * 4,
+		},
+		&cli.Uint64Flag{
+			Name:        "balance-threshold-gwei",
+			Usage:       "only print balance changes of validators larger than this amount in gwei",
+			Destination: &diffFlags.BalanceThresholdGwei,
+		},
+	},
+}
+
+func cliActionDiff(c *cli.Context) error {
+	ctx := context.Background()
+	f := diffFlags
+	if c.NArg() != 2 {
+		return errors.New("expected two states to compare")
+	}
+	var client *beacon.Client
+	states := make([]state.BeaconState, 2)
+	for i, arg := range c.Args().Slice() {
+		path, err := file.ExpandPath(arg)
+		if err != nil {
+			return err
+		}
+		var marshaled []byte
+		if file.FileExists(path) {
+			marshaled, err = os.ReadFile(path) // #nosec G304
+			if err != nil {
+				return errors.Wrapf(err, "could not read state file %s", path)
+			}
+		} else {
+			if client == nil {
+				client, err = beacon.NewClient(f.BeaconNodeHost, beacon.WithTimeout(f.Timeout))
+				if err != nil {
+					return err
+				}
+			}
+			marshaled, err = client.GetState(ctx, beacon.StateOrBlockId(arg))
+			if err != nil {
+				return err
+			}
+		}
+		unmarshaler, err := detect.FromState(marshaled)
+		if err != nil {
+			return errors.Wrapf(err, "could not detect the fork of state %s", arg)
+		}
+		states[i], err = unmarshaler.UnmarshalBeaconState(marshaled)
+		if err != nil {
+			return errors.Wrapf(err, "could not unmarshal state %s", arg)
+		}
+	}
+	return writeValidatorsDiff(os.Stdout, states[0], states[1], f.BalanceThresholdGwei)
+}
+
+// writeValidatorsDiff writes a summary of the differences between the validator sets and balances
+// of the two states, followed by a line for every validator which differs. Validators whose only
+// change is a balance change of at most the threshold are not listed.
+func writeValidatorsDiff(w io.Writer, a, b state.ReadOnlyBeaconState, balanceThreshold uint64) error {
+	validatorsA, validatorsB := a.Validators(), b.Validators()
+	balancesA, balancesB := a.Balances(), b.Balances()
+	totalA, totalB := sum(balancesA), sum(balancesB)
+
+	var lines []string
+	var added, removed, changed, balanceChanges int
+	for i := 0; i < len(validatorsA) || i < len(validatorsB); i++ {
+		var line string
+		switch {
+		case i >= len(validatorsA):
+			added++
+			line = "added, " + describeValidator(validatorsB[i], balanceAt(balancesB, i))
+		case i >= len(validatorsB):
+			removed++
+			line = "removed, " + describeValidator(validatorsA[i], balanceAt(balancesA, i))
+		default:
+			changes := validatorChanges(validatorsA[i], validatorsB[i])
+			if len(changes) > 0 {
+				changed++
+			}
+			balanceA, balanceB := balanceAt(balancesA, i), balanceAt(balancesB, i)
+			if balanceA != balanceB {
+				balanceChanges++
+				if len(changes) > 0 || absDiff(balanceA, balanceB) > balanceThreshold {
+					changes = append(changes, fmt.Sprintf("balance %d -> %d (%s)", balanceA, balanceB, signedDiff(balanceA, balanceB)))
+				}
+			}
+			line = strings.Join(changes, ", ")
+		}
+		if line != "" {
+			lines = append(lines, fmt.Sprintf("index %d %#x: %s", i, bytesutil.Trunc(pubKeyAt(validatorsA, validatorsB, i)), line))
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "state a: slot %d, %d validators, total balance %d gwei\n", a.Slot(), len(validatorsA), totalA); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "state b: slot %d, %d validators, total balance %d gwei\n", b.Slot(), len(validatorsB), totalB); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(
+		w, "validators added: %d, removed: %d, changed: %d, balance changes: %d, total balance change: %s gwei\n",
+		added, removed, changed, balanceChanges, signedDiff(totalA, totalB),
+	); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatorChanges describes the fields of the validator record which differ between the states.
+func validatorChanges(a, b *ethpb.Validator) []string {
+	var changes []string
+	if !bytes.Equal(a.PublicKey, b.PublicKey) {
+		changes = append(changes, fmt.Sprintf("public key %#x -> %#x", a.PublicKey, b.PublicKey))
+	}
+	if !bytes.Equal(a.WithdrawalCredentials, b.WithdrawalCredentials) {
+		changes = append(changes, fmt.Sprintf("withdrawal credentials %#x -> %#x", a.WithdrawalCredentials, b.WithdrawalCredentials))
+	}
+	if a.EffectiveBalance != b.EffectiveBalance {
+		changes = append(changes, fmt.Sprintf("effective balance %d -> %d", a.EffectiveBalance, b.EffectiveBalance))
+	}
+	if a.Slashed != b.Slashed {
+		changes = append(changes, fmt.Sprintf("slashed %t -> %t", a.Slashed, b.Slashed))
+	}
+	epochs := []struct {
+		name string
+		a, b types.Epoch
+	}{
+		{"activation eligibility epoch", a.ActivationEligibilityEpoch, b.ActivationEligibilityEpoch},
+		{"activation epoch", a.ActivationEpoch, b.ActivationEpoch},
+		{"exit epoch", a.ExitEpoch, b.ExitEpoch},
+		{"withdrawable epoch", a.WithdrawableEpoch, b.WithdrawableEpoch},
+	}
+	for _, e := range epochs {
+		if e.a != e.b {
+			changes = append(changes, fmt.Sprintf("%s %s -> %s", e.name, formatEpoch(e.a), formatEpoch(e.b)))
+		}
+	}
+	return changes
+}
+
+func describeValidator(v *ethpb.Validator, balance uint64) string {
+	return fmt.Sprintf(
+		"balance %d, effective balance %d, slashed %t, activation epoch %s, exit epoch %s",
+		balance, v.EffectiveBalance, v.Slashed, formatEpoch(v.ActivationEpoch), formatEpoch(v.ExitEpoch),
+	)
+}
+
+func formatEpoch(e types.Epoch) string {
+	if e == params.BeaconConfig().FarFutureEpoch {
+		return "far_future"
+	}
+	return fmt.Sprintf("%d", e)
+}
+
+func pubKeyAt(a, b []*ethpb.Validator, i int) []byte {
+	if i < len(b) {
+		return b[i].PublicKey
+	}
+	return a[i].PublicKey
+}
+
+func balanceAt(balances []uint64, i int) uint64 {
+	if i < len(balances) {
+		return balances[i]
+	}
+	return 0
+}
+
+func sum(balances []uint64) uint64 {
+	var total uint64
+	for _, b := range balances {
+		total += b
+	}
+	return total
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func signedDiff(a, b uint64) string {
+	if b >= a {
+		return fmt.Sprintf("+%d", b-a)
+	}
+	return fmt.Sprintf("-%d", a-b)
+}
@@ -0,0 +1,62 @@
+package validators
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func testValidator(i byte) *ethpb.Validator {
+	return &ethpb.Validator{
+		PublicKey:                  bytes.Repeat([]byte{i}, 48),
+		WithdrawalCredentials:      make([]byte, 32),
+		EffectiveBalance:           params.BeaconConfig().MaxEffectiveBalance,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            0,
+		ExitEpoch:                  params.BeaconConfig().FarFutureEpoch,
+		WithdrawableEpoch:          params.BeaconConfig().FarFutureEpoch,
+	}
+}
+
+func TestWriteValidatorsDiff(t *testing.T) {
+	a, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, a.SetSlot(10))
+	require.NoError(t, a.SetValidators([]*ethpb.Validator{testValidator(1), testValidator(2), testValidator(3)}))
+	require.NoError(t, a.SetBalances([]uint64{32e9, 32e9, 32e9}))
+
+	b, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, b.SetSlot(20))
+	exited := testValidator(2)
+	exited.ExitEpoch = 5
+	exited.Slashed = true
+	require.NoError(t, b.SetValidators([]*ethpb.Validator{testValidator(1), exited, testValidator(3), testValidator(4)}))
+	require.NoError(t, b.SetBalances([]uint64{32e9 + 10, 31e9, 32e9 + 1000, 1e9}))
+
+	var out bytes.Buffer
+	require.NoError(t, writeValidatorsDiff(&out, a, b, 100))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Equal(t, 6, len(lines), out.String())
+	assert.Equal(t, "state a: slot 10, 3 validators, total balance 96000000000 gwei", lines[0])
+	assert.Equal(t, "state b: slot 20, 4 validators, total balance 96000001010 gwei", lines[1])
+	assert.Equal(t, "validators added: 1, removed: 0, changed: 1, balance changes: 3, total balance change: +1010 gwei", lines[2])
+	assert.Equal(t, "index 1 0x020202020202: slashed false -> true, exit epoch far_future -> 5, "+
+		"balance 32000000000 -> 31000000000 (-1000000000)", lines[3])
+	// The balance change of index 0 is below the threshold.
+	assert.Equal(t, "index 2 0x030303030303: balance 32000000000 -> 32000001000 (+1000)", lines[4])
+	assert.Equal(t, "index 3 0x040404040404: added, balance 1000000000, effective balance 32000000000, slashed false, "+
+		"activation epoch 0, exit epoch far_future", lines[5])
+
+	out.Reset()
+	require.NoError(t, writeValidatorsDiff(&out, b, a, 0))
+	assert.Equal(t, true, strings.Contains(out.String(), "validators added: 0, removed: 1, changed: 1, balance changes: 3, total balance change: -1010 gwei"))
+	assert.Equal(t, true, strings.Contains(out.String(), "index 0 0x010101010101: balance 32000000010 -> 32000000000 (-10)"))
+	assert.Equal(t, true, strings.Contains(out.String(), "index 3 0x040404040404: removed, balance 1000000000"))
+}
@@ -0,0 +1,13 @@
+package validators
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "validators",
+		Usage: "commands for inspecting the validator set of beacon states",
+		Subcommands: []*cli.Command{
+			diffCmd,
+		},
+	},
+}
@@ -0,0 +1,241 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/contracts/deposit"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager/derived"
+	"github.com/urfave/cli/v2"
+)
+
+// maxIndices bounds the number of keys derived at once.
+const maxIndices = 10000
+
+var deriveFlags = struct {
+	MnemonicFile          string
+	Mnemonic25thWordFile  string
+	Indices               string
+	Network               string
+	WithdrawalAddress     string
+	AmountGwei            uint64
+	KeystoresPasswordFile string
+	OutputDir             string
+}{}
+
+var deriveCmd = &cli.Command{
+	Name: "derive",
+	Usage: "Derive validator keys from a mnemonic at the given account indices according to EIP-2334, " +
+		"writing their EIP-2335 keystores and a deposit data file for the staking launchpad.",
+	Action: cliActionDerive,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "mnemonic-file",
+			Usage:       "path to a plain-text file containing the mnemonic to derive keys from",
+			Destination: &deriveFlags.MnemonicFile,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "mnemonic-25th-word-file",
+			Usage:       "(advanced) path to a plain-text file containing a 25th word passphrase for the mnemonic",
+			Destination: &deriveFlags.Mnemonic25thWordFile,
+		},
+		&cli.StringFlag{
+			Name:        "indices",
+			Usage:       "comma separated account indices or ranges of indices to derive keys at, ex: 0-9,12",
+			Destination: &deriveFlags.Indices,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "network",
+			Usage:       "name of the network the deposits are signed for, ex: mainnet, prater, sepolia",
+			Destination: &deriveFlags.Network,
+			Value:       params.MainnetName,
+		},
+		&cli.StringFlag{
+			Name: "withdrawal-address",
+			Usage: "execution layer address to withdraw to. If not set, the withdrawal credentials are " +
+				"derived from the withdrawal key of each account",
+			Destination: &deriveFlags.WithdrawalAddress,
+		},
+		&cli.Uint64Flag{
+			Name:        "amount-gwei",
+			Usage:       "amount of each deposit in gwei. default: the max effective balance",
+			Destination: &deriveFlags.AmountGwei,
+		},
+		&cli.StringFlag{
+			Name:        "keystores-password-file",
+			Usage:       "path to a plain-text file containing the password to encrypt the keystores with",
+			Destination: &deriveFlags.KeystoresPasswordFile,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output-dir",
+			Usage:       "directory to write the keystores and the deposit data file to",
+			Destination: &deriveFlags.OutputDir,
+			Required:    true,
+		},
+	},
+}
+
+func cliActionDerive(_ *cli.Context) error {
+	f := deriveFlags
+	cfg, err := params.ByName(f.Network)
+	if err != nil {
+		return errors.Wrapf(err, "unknown network %s", f.Network)
+	}
+	undo, err := params.SetActiveWithUndo(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := undo(); err != nil {
+			log.WithError(err).Error("Could not restore the beacon chain config")
+		}
+	}()
+
+	indices, err := parseIndices(f.Indices)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := readTrimmedFile(f.MnemonicFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read mnemonic file")
+	}
+	var mnemonicPassphrase string
+	if f.Mnemonic25thWordFile != "" {
+		mnemonicPassphrase, err = readTrimmedFile(f.Mnemonic25thWordFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read mnemonic 25th word file")
+		}
+	}
+	password, err := readTrimmedFile(f.KeystoresPasswordFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read keystores password file")
+	}
+	var withdrawalCredentials []byte
+	if f.WithdrawalAddress != "" {
+		if !common.IsHexAddress(f.WithdrawalAddress) {
+			return fmt.Errorf("invalid withdrawal address %s", f.WithdrawalAddress)
+		}
+		withdrawalCredentials = deposit.ExecutionAddressWithdrawalCredentials(common.HexToAddress(f.WithdrawalAddress))
+	}
+	amount := f.AmountGwei
+	if amount == 0 {
+		amount = params.BeaconConfig().MaxEffectiveBalance
+	}
+	outputDir, err := file.ExpandPath(f.OutputDir)
+	if err != nil {
+		return err
+	}
+	return deriveKeys(mnemonic, mnemonicPassphrase, indices, withdrawalCredentials, amount, password, outputDir)
+}
+
+// deriveKeys writes the keystores of the keys derived at the indices and the deposit data file of
+// their deposits to the output directory. The withdrawal credentials of each deposit are derived
+// from the withdrawal key of its account unless given.
+func deriveKeys(
+	mnemonic, mnemonicPassphrase string,
+	indices []uint64,
+	withdrawalCredentials []byte,
+	amountInGwei uint64,
+	password, outputDir string,
+) error {
+	accounts, err := derived.DeriveAccountKeys(mnemonic, mnemonicPassphrase, indices)
+	if err != nil {
+		return err
+	}
+	if err := file.MkdirAll(outputDir); err != nil {
+		return errors.Wrapf(err, "could not create output directory %s", outputDir)
+	}
+	timestamp := time.Now().Unix()
+	deposits := make([]*deposit.LaunchpadDepositData, len(accounts))
+	for i, account := range accounts {
+		keystore, err := account.ValidatingKeystore(password)
+		if err != nil {
+			return err
+		}
+		encoded, err := json.MarshalIndent(keystore, "", "\t")
+		if err != nil {
+			return errors.Wrap(err, "could not marshal keystore")
+		}
+		// Keystores are named after their derivation path, as the staking deposit CLI does.
+		name := fmt.Sprintf("keystore-%s-%d.json", strings.ReplaceAll(account.ValidatingKeyPath(), "/", "_"), timestamp)
+		if err := file.WriteFile(filepath.Join(outputDir, name), encoded); err != nil {
+			return errors.Wrapf(err, "could not write keystore of account %d", account.Index)
+		}
+
+		credentials := withdrawalCredentials
+		if credentials == nil {
+			credentials = deposit.WithdrawalCredentialsHash(account.WithdrawalKey)
+		}
+		deposits[i], err = deposit.NewLaunchpadDepositData(account.ValidatingKey, credentials, amountInGwei)
+		if err != nil {
+			return errors.Wrapf(err, "could not sign deposit of account %d", account.Index)
+		}
+	}
+	encoded, err := json.Marshal(deposits)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal deposit data")
+	}
+	depositDataFile := filepath.Join(outputDir, fmt.Sprintf("deposit_data-%d.json", timestamp))
+	if err := file.WriteFile(depositDataFile, encoded); err != nil {
+		return errors.Wrap(err, "could not write deposit data")
+	}
+	log.WithField("outputDir", outputDir).Infof("Derived %d validator keys", len(accounts))
+	return nil
+}
+
+// parseIndices parses comma separated indices and inclusive ranges of indices, as in "0-9,12".
+// Indices are returned in the given order and each at most once.
+func parseIndices(s string) ([]uint64, error) {
+	var indices []uint64
+	seen := make(map[uint64]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		start, end := part, part
+		if i := strings.IndexByte(part, '-'); i >= 0 {
+			start, end = part[:i], part[i+1:]
+		}
+		first, err := strconv.ParseUint(start, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index %q", part)
+		}
+		last, err := strconv.ParseUint(end, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index %q", part)
+		}
+		if last < first {
+			return nil, fmt.Errorf("invalid range of indices %q", part)
+		}
+		if last-first >= maxIndices-uint64(len(indices)) {
+			return nil, fmt.Errorf("cannot derive more than %d keys at once", maxIndices)
+		}
+		for index := first; ; index++ {
+			if !seen[index] {
+				seen[index] = true
+				indices = append(indices, index)
+			}
+			if index == last {
+				break
+			}
+		}
+	}
+	return indices, nil
+}
+
+func readTrimmedFile(path string) (string, error) {
+	content, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
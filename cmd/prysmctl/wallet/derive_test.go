@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/contracts/deposit"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager/derived"
+)
+
+func TestParseIndices(t *testing.T) {
+	indices, err := parseIndices("3, 0-2,1,10-10")
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{3, 0, 1, 2, 10}, indices)
+
+	_, err = parseIndices("2-1")
+	assert.ErrorContains(t, "invalid range of indices", err)
+	_, err = parseIndices("a")
+	assert.ErrorContains(t, "invalid index", err)
+	_, err = parseIndices("")
+	assert.ErrorContains(t, "invalid index", err)
+	_, err = parseIndices("0-18446744073709551615")
+	assert.ErrorContains(t, "cannot derive more than", err)
+}
+
+func TestDeriveKeys(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	outputDir := filepath.Join(t.TempDir(), "keys")
+	indices := []uint64{4, 7}
+	require.NoError(t, deriveKeys(mnemonic, "", indices, nil, 32e9, "password", outputDir))
+
+	accounts, err := derived.DeriveAccountKeys(mnemonic, "", indices)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	var deposits []*deposit.LaunchpadDepositData
+	keystores := make(map[string]*keymanager.Keystore)
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(outputDir, entry.Name()))
+		require.NoError(t, err)
+		if strings.HasPrefix(entry.Name(), "deposit_data-") {
+			require.NoError(t, json.Unmarshal(content, &deposits))
+			continue
+		}
+		keystore := &keymanager.Keystore{}
+		require.NoError(t, json.Unmarshal(content, keystore))
+		// Strip the timestamp from the name.
+		keystores[entry.Name()[:strings.LastIndexByte(entry.Name(), '-')]] = keystore
+	}
+	require.Equal(t, 2, len(deposits))
+	for i, account := range accounts {
+		keystore, ok := keystores["keystore-"+strings.ReplaceAll(account.ValidatingKeyPath(), "/", "_")]
+		require.Equal(t, true, ok)
+		assert.Equal(t, account.ValidatingKeyPath(), keystore.Path)
+		assert.Equal(t, deposits[i].PubKey, keystore.Pubkey)
+		want, err := deposit.NewLaunchpadDepositData(
+			account.ValidatingKey, deposit.WithdrawalCredentialsHash(account.WithdrawalKey), 32e9,
+		)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, deposits[i])
+	}
+}
@@ -0,0 +1,5 @@
+package wallet
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "wallet")
@@ -0,0 +1,13 @@
+package wallet
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "wallet",
+		Usage: "commands for managing validator keys",
+		Subcommands: []*cli.Command{
+			deriveCmd,
+		},
+	},
+}
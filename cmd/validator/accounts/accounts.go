@@ -25,6 +25,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				flags.WalletDirFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.DeletePublicKeysFlag,
 				features.Mainnet,
 				features.PraterTestnet,
@@ -54,6 +55,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				flags.WalletDirFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.ShowDepositDataFlag,
 				flags.ShowPrivateKeysFlag,
 				flags.ListValidatorIndices,
@@ -94,6 +96,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				flags.WalletDirFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.BackupDirFlag,
 				flags.BackupPublicKeysFlag,
 				flags.BackupPasswordFile,
@@ -126,8 +129,11 @@ var Commands = &cli.Command{
 				flags.WalletDirFlag,
 				flags.KeysDirFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.AccountPasswordFileFlag,
+				flags.AccountPasswordSecretFlag,
 				flags.ImportPrivateKeyFileFlag,
+				flags.ImportDryRunFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.RopstenTestnet,
@@ -156,6 +162,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				flags.WalletDirFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.AccountPasswordFileFlag,
 				flags.VoluntaryExitPublicKeysFlag,
 				flags.BeaconRPCProviderFlag,
@@ -165,6 +172,10 @@ var Commands = &cli.Command{
 				flags.GrpcRetriesFlag,
 				flags.GrpcRetryDelayFlag,
 				flags.ExitAllFlag,
+				flags.VoluntaryExitIndicesFlag,
+				flags.PresignedExitsOutputFlag,
+				flags.VoluntaryExitEpochFlag,
+				flags.ForceExitFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.RopstenTestnet,
@@ -187,5 +198,38 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "broadcast-exits",
+			Description: "Broadcasts signed voluntary exits saved with voluntary-exit --presigned-exits-output",
+			Flags: cmd.WrapFlags([]cli.Flag{
+				flags.SignedExitsFileFlag,
+				flags.BeaconRPCProviderFlag,
+				cmd.GrpcMaxCallRecvMsgSizeFlag,
+				flags.CertFlag,
+				flags.GrpcHeadersFlag,
+				flags.GrpcRetriesFlag,
+				flags.GrpcRetryDelayFlag,
+				features.Mainnet,
+				features.PraterTestnet,
+				features.RopstenTestnet,
+				features.SepoliaTestnet,
+				cmd.AcceptTosFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				if err := cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags); err != nil {
+					return err
+				}
+				if err := tos.VerifyTosAcceptedOrPrompt(cliCtx); err != nil {
+					return err
+				}
+				return features.ConfigureValidator(cliCtx)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := accountsBroadcastExits(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not broadcast voluntary exits")
+				}
+				return nil
+			},
+		},
 	},
 }
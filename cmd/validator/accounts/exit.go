@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts"
 	"github.com/prysmaticlabs/prysm/v3/validator/client"
 	"github.com/urfave/cli/v2"
@@ -41,6 +42,28 @@ func accountsExit(c *cli.Context, r io.Reader) error {
 	if len(validatingPublicKeys) == 0 {
 		return errors.New("wallet is empty, no accounts to delete")
 	}
+	if c.IsSet(flags.VoluntaryExitIndicesFlag.Name) {
+		indices, err := accounts.ParseValidatorIndices(c.String(flags.VoluntaryExitIndicesFlag.Name))
+		if err != nil {
+			return err
+		}
+		resolver, err := accounts.NewCLIManager(opts...)
+		if err != nil {
+			return err
+		}
+		validatingPublicKeys, err = resolver.ValidatingKeysByIndices(c.Context, validatingPublicKeys, indices)
+		if err != nil {
+			return errors.Wrap(err, "could not select accounts by validator index")
+		}
+	}
+	if c.IsSet(flags.PresignedExitsOutputFlag.Name) {
+		opts = append(opts, accounts.WithExitsFile(c.String(flags.PresignedExitsOutputFlag.Name)))
+		if c.IsSet(flags.VoluntaryExitEpochFlag.Name) {
+			opts = append(opts, accounts.WithExitEpoch(types.Epoch(c.Uint64(flags.VoluntaryExitEpochFlag.Name))))
+		}
+	} else if c.IsSet(flags.VoluntaryExitEpochFlag.Name) {
+		return errors.New("--exit-epoch can only be used along with --presigned-exits-output")
+	}
 	// Filter keys either from CLI flag or from interactive session.
 	rawPubKey, formattedPubKeys, err := accounts.FilterExitAccountsFromUserInput(c, r, validatingPublicKeys)
 	if err != nil {
@@ -55,3 +78,27 @@ func accountsExit(c *cli.Context, r io.Reader) error {
 	}
 	return acc.Exit(c.Context)
 }
+
+func accountsBroadcastExits(c *cli.Context) error {
+	if !c.IsSet(flags.SignedExitsFileFlag.Name) {
+		return errors.New("no signed voluntary exits file specified, use --signed-exits-file")
+	}
+	dialOpts := client.ConstructDialOptions(
+		c.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+		c.String(flags.CertFlag.Name),
+		c.Uint(flags.GrpcRetriesFlag.Name),
+		c.Duration(flags.GrpcRetryDelayFlag.Name),
+	)
+	grpcHeaders := strings.Split(c.String(flags.GrpcHeadersFlag.Name), ",")
+
+	acc, err := accounts.NewCLIManager(
+		accounts.WithGRPCDialOpts(dialOpts),
+		accounts.WithBeaconRPCProvider(c.String(flags.BeaconRPCProviderFlag.Name)),
+		accounts.WithGRPCHeaders(grpcHeaders),
+		accounts.WithExitsFile(c.String(flags.SignedExitsFileFlag.Name)),
+	)
+	if err != nil {
+		return err
+	}
+	return acc.BroadcastExits(c.Context)
+}
@@ -42,9 +42,11 @@ func accountsImport(c *cli.Context) error {
 	}
 
 	opts = append(opts, accounts.WithImportPrivateKeys(c.IsSet(flags.ImportPrivateKeyFileFlag.Name)))
+	opts = append(opts, accounts.WithDryRun(c.Bool(flags.ImportDryRunFlag.Name)))
 	opts = append(opts, accounts.WithPrivateKeyFile(c.String(flags.ImportPrivateKeyFileFlag.Name)))
 	opts = append(opts, accounts.WithReadPasswordFile(c.IsSet(flags.AccountPasswordFileFlag.Name)))
 	opts = append(opts, accounts.WithPasswordFilePath(c.String(flags.AccountPasswordFileFlag.Name)))
+	opts = append(opts, accounts.WithPasswordSecret(c.String(flags.AccountPasswordSecretFlag.Name)))
 
 	keysDir, err := userprompt.InputDirectory(c, userprompt.ImportKeysDirPromptText, flags.KeysDirFlag)
 	if err != nil {
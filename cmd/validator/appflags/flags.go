@@ -0,0 +1,87 @@
+// Package appflags defines the flags of the validator binary. They are exported so that validator
+// clients run in process, such as the ones of prysmctl test networks, are configured like the binary.
+package appflags
+
+import (
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
+	"github.com/urfave/cli/v2"
+)
+
+// Flags of the validator binary, feature flags excluded.
+var Flags = []cli.Flag{
+	flags.BeaconRPCProviderFlag,
+	flags.BeaconRPCGatewayProviderFlag,
+	flags.BeaconRESTApiProviderFlag,
+	flags.CertFlag,
+	flags.GraffitiFlag,
+	flags.DisablePenaltyRewardLogFlag,
+	flags.InteropStartIndex,
+	flags.InteropNumValidators,
+	flags.EnableRPCFlag,
+	flags.RPCHost,
+	flags.RPCPort,
+	flags.GRPCGatewayPort,
+	flags.GRPCGatewayHost,
+	flags.GrpcRetriesFlag,
+	flags.GrpcRetryDelayFlag,
+	flags.GrpcHeadersFlag,
+	flags.GPRCGatewayCorsDomain,
+	flags.DisableAccountMetricsFlag,
+	flags.MonitoringPortFlag,
+	flags.SlasherRPCProviderFlag,
+	flags.SlasherCertFlag,
+	flags.WalletPasswordFileFlag,
+	flags.WalletPasswordSecretFlag,
+	flags.WalletDirFlag,
+	flags.EnableWebFlag,
+	flags.GraffitiFileFlag,
+	// Consensys' Web3Signer flags
+	flags.Web3SignerURLFlag,
+	flags.Web3SignerPublicValidatorKeysFlag,
+	flags.SuggestedFeeRecipientFlag,
+	flags.ProposerSettingsURLFlag,
+	flags.ProposerSettingsFlag,
+	flags.EnableBuilderFlag,
+	flags.BuilderGasLimitFlag,
+	flags.ProposerCoordinationURLsFlag,
+	flags.ProposerCoordinationTokenFileFlag,
+	flags.ProposerCoordinationStrictFlag,
+	flags.AttestationRebroadcastSlotsFlag,
+	flags.AttestationRebroadcastProviderFlag,
+	flags.FinalityGuardFlag,
+	flags.FinalityGuardCheckpointFlag,
+	////////////////////
+	cmd.DisableMonitoringFlag,
+	cmd.MonitoringHostFlag,
+	cmd.BackupWebhookOutputDir,
+	cmd.EnableBackupWebhookFlag,
+	cmd.MinimalConfigFlag,
+	cmd.E2EConfigFlag,
+	cmd.VerbosityFlag,
+	cmd.DataDirFlag,
+	cmd.ClearDB,
+	cmd.ForceClearDB,
+	cmd.EnableTracingFlag,
+	cmd.TracingProcessNameFlag,
+	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TraceSampleFractionFlag,
+	cmd.LogFormat,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.GrpcMaxCallRecvMsgSizeFlag,
+	cmd.ApiTimeoutFlag,
+	debug.PProfFlag,
+	debug.PProfAddrFlag,
+	debug.PProfPortFlag,
+	debug.MemProfileRateFlag,
+	debug.CPUProfileFlag,
+	debug.TraceFlag,
+	debug.BlockProfileRateFlag,
+	debug.MutexProfileFractionFlag,
+	cmd.AcceptTosFlag,
+	flags.NonInteractiveFlag,
+}
@@ -22,6 +22,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.RestoreSourceFileFlag,
 				cmd.RestoreTargetDirFlag,
+				cmd.RestoreOverwriteFlag,
 			}),
 			Before: tos.VerifyTosAcceptedOrPrompt,
 			Action: func(cliCtx *cli.Context) error {
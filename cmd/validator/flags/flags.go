@@ -29,8 +29,10 @@ var (
 	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. Multiple comma-separated endpoints may be provided, " +
+			"in which case duties are fetched from the healthiest beacon node and signed objects " +
+			"are broadcast to all healthy beacon nodes",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -39,6 +41,12 @@ var (
 		Usage: "Beacon node RPC gateway provider endpoint",
 		Value: "127.0.0.1:3500",
 	}
+	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint.
+	BeaconRESTApiProviderFlag = &cli.StringFlag{
+		Name:  "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint. Only used with --enable-beacon-rest-api",
+		Value: "http://127.0.0.1:3500",
+	}
 	// CertFlag defines a flag for the node's TLS certificate.
 	CertFlag = &cli.StringFlag{
 		Name:  "tls-cert",
@@ -80,8 +88,9 @@ var (
 	}
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
-		Name:  "graffiti",
-		Usage: "String to include in proposed blocks",
+		Name: "graffiti",
+		Usage: "String to include in proposed blocks. It may reference the variables {version}, {slot}, {epoch}, " +
+			"{index} and the tags of the graffiti file as {tag:<name>}, rendered at proposal time",
 	}
 	// GrpcRetriesFlag defines the number of times to retry a failed gRPC request.
 	GrpcRetriesFlag = &cli.UintFlag{
@@ -141,6 +150,20 @@ var (
 		Name:  "wallet-password-file",
 		Usage: "Path to a plain-text, .txt file containing your wallet password",
 	}
+	// WalletPasswordSecretFlag references a wallet password stored in an external secret manager.
+	WalletPasswordSecretFlag = &cli.StringFlag{
+		Name: "wallet-password-secret",
+		Usage: "Reference to your wallet password in an external secret manager, used instead of --wallet-password-file: " +
+			"vault://<path>[#<field>] reads a HashiCorp Vault secret with VAULT_ADDR and VAULT_TOKEN, " +
+			"aws-sm://<secret id>[#<field>] reads an AWS Secrets Manager secret with the AWS_* environment credentials, " +
+			"exec://<command> [args] reads the output of a command",
+	}
+	// AccountPasswordSecretFlag references an account password stored in an external secret manager.
+	AccountPasswordSecretFlag = &cli.StringFlag{
+		Name: "account-password-secret",
+		Usage: "Reference to the password of the imported keystores in an external secret manager, used instead of " +
+			"--account-password-file. Supports the same references as --wallet-password-secret",
+	}
 	// Mnemonic25thWordFileFlag defines a path to a file containing a "25th" word mnemonic passphrase for advanced users.
 	Mnemonic25thWordFileFlag = &cli.StringFlag{
 		Name:  "mnemonic-25th-word-file",
@@ -156,6 +179,11 @@ var (
 		Name:  "import-private-key-file",
 		Usage: "Path to a plain-text, .txt file containing a hex string representation of a private key to import",
 	}
+	// ImportDryRunFlag verifies the keystores to import without importing them.
+	ImportDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Decrypt and verify the keystores to import, without importing them into the wallet",
+	}
 	// MnemonicFileFlag is used to enter a file to mnemonic phrase for new wallet creation, non-interactively.
 	MnemonicFileFlag = &cli.StringFlag{
 		Name:  "mnemonic-file",
@@ -213,6 +241,37 @@ var (
 		Name:  "exit-all",
 		Usage: "Exit all validators. This will still require the staker to confirm a userprompt for the action",
 	}
+	// ForceExitFlag skips the confirmation phrase of voluntary exits, for non-interactive environments.
+	ForceExitFlag = &cli.BoolFlag{
+		Name:    "force-exit",
+		Usage:   "Exit the selected validators without asking for the confirmation phrase. Exits are irreversible",
+		EnvVars: []string{"PRYSM_FORCE_EXIT"},
+	}
+	// VoluntaryExitIndicesFlag defines a comma-separated list of validator indices, or ranges of
+	// indices, of the accounts on which a user wants to perform a voluntary exit.
+	VoluntaryExitIndicesFlag = &cli.StringFlag{
+		Name: "validator-indices",
+		Usage: "Comma-separated list of validator indices, or inclusive ranges of indices (ex: 10,12,20-30), " +
+			"to specify on which validator accounts to perform a voluntary exit. The indices are resolved by the beacon node",
+		Value: "",
+	}
+	// VoluntaryExitEpochFlag defines the epoch at which pre-signed voluntary exits take effect.
+	VoluntaryExitEpochFlag = &cli.Uint64Flag{
+		Name:  "exit-epoch",
+		Usage: "Epoch at which the pre-signed voluntary exits take effect. Defaults to the current epoch",
+	}
+	// PresignedExitsOutputFlag defines the file where pre-signed voluntary exits are saved instead of
+	// being broadcast.
+	PresignedExitsOutputFlag = &cli.StringFlag{
+		Name: "presigned-exits-output",
+		Usage: "Path to a .json file where the signed voluntary exits are saved instead of being broadcast. " +
+			"Anyone holding the file can exit the validators, keep it safe",
+	}
+	// SignedExitsFileFlag defines the file of signed voluntary exits to broadcast.
+	SignedExitsFileFlag = &cli.StringFlag{
+		Name:  "signed-exits-file",
+		Usage: "Path to a .json file of signed voluntary exits, as saved with --presigned-exits-output, to broadcast",
+	}
 	// BackupPasswordFile for encrypting accounts a user wishes to back up.
 	BackupPasswordFile = &cli.StringFlag{
 		Name:  "backup-password-file",
@@ -351,6 +410,60 @@ var (
 		Usage: "Sets gas limit for the builder to use for constructing a payload for all the validators",
 		Value: int(params.BeaconConfig().DefaultBuilderGasLimit),
 	}
+
+	// ProposerCoordinationURLsFlag defines the web API URLs of peer validator clients running the same keys,
+	// which are asked whether they already proposed for a slot before a block is broadcast.
+	ProposerCoordinationURLsFlag = &cli.StringSliceFlag{
+		Name: "proposer-coordination-urls",
+		Usage: "Comma separated list of web API URLs of redundant validator clients running the same keys (i.e. --proposer-coordination-urls=http://standby:7500)." +
+			" Before broadcasting a block, each of them is asked whether its slashing protection history allows it",
+	}
+	// ProposerCoordinationTokenFileFlag defines the path to the file with the auth token of the peer validator clients.
+	ProposerCoordinationTokenFileFlag = &cli.StringFlag{
+		Name:  "proposer-coordination-token-file",
+		Usage: "Path to a file containing the auth token used to query the validator clients of --proposer-coordination-urls",
+	}
+	// ProposerCoordinationStrictFlag aborts proposals when a peer validator client can't be reached.
+	ProposerCoordinationStrictFlag = &cli.BoolFlag{
+		Name:  "proposer-coordination-strict",
+		Usage: "Do not propose when any of the validator clients of --proposer-coordination-urls can't be reached, instead of only logging a warning",
+	}
+	// AttestationRebroadcastSlotsFlag defines after how many slots attestations which were not included in a block are broadcast again.
+	AttestationRebroadcastSlotsFlag = &cli.Uint64Flag{
+		Name: "attestation-rebroadcast-slots",
+		Usage: "Number of slots after which attestations and aggregates not included in a block are broadcast again through the beacon node of --" +
+			"attestation-rebroadcast-provider, at every slot until they are included. The inclusion status is read from the beacon node REST API of --" +
+			BeaconRESTApiProviderFlag.Name + ". Disabled when 0",
+	}
+	// AttestationRebroadcastProviderFlag defines the beacon node attestations which were not included in a block are broadcast again through.
+	AttestationRebroadcastProviderFlag = &cli.StringFlag{
+		Name: "attestation-rebroadcast-provider",
+		Usage: "Beacon node RPC endpoint attestations and aggregates are broadcast again through, which must not be one of --" + BeaconRPCProviderFlag.Name +
+			". Beacon nodes and their peers drop the messages they have already seen for longer than an attestation can be included, so attestations " +
+			"are broadcast again through another beacon node, which publishes those which never propagated to its own peers",
+	}
+	// FinalityGuardFlag refuses signing while the beacon node conflicts with a pinned finalized checkpoint.
+	FinalityGuardFlag = &cli.BoolFlag{
+		Name: "finality-guard",
+		Usage: "Refuse to sign blocks and attestations which don't descend from a pinned finalized checkpoint, or while the beacon node reports " +
+			"checkpoints conflicting with it, protecting against a compromised beacon node or one following a fork. The parent of proposed blocks and " +
+			"the source and target of attestations are checked by walking the block headers back to the pinned block, hashing them locally. " +
+			"The checkpoint of --finality-guard-checkpoint, or else the first finalized checkpoint reported by the beacon node, is pinned and then " +
+			"follows the finality of the beacon node when its finalized block descends from the pinned one",
+	}
+	// FinalityGuardCheckpointFlag defines the finalized checkpoint pinned at startup by the finality guard.
+	FinalityGuardCheckpointFlag = &cli.StringFlag{
+		Name: "finality-guard-checkpoint",
+		Usage: "Finalized checkpoint pinned at startup, in the block_root:epoch_number format. Enables --finality-guard. " +
+			"The beacon node must know the block of the checkpoint",
+	}
+	// NonInteractiveFlag makes commands fail instead of waiting for user input.
+	NonInteractiveFlag = &cli.BoolFlag{
+		Name: "non-interactive",
+		Usage: "Fail right away instead of prompting when an input is missing, for use in scripts and orchestration. " +
+			"Every prompt has a corresponding flag, such as --wallet-password-file or --accept-terms-of-use",
+		EnvVars: []string{"PRYSM_NON_INTERACTIVE"},
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
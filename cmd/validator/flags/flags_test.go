@@ -83,3 +83,19 @@ func TestLoadFlagsFromConfig_EnableBuilderHasDefaultValue(t *testing.T) {
 	require.NoError(t, command.Run(context))
 	require.NoError(t, os.Remove("flags_test.yaml"))
 }
+
+func TestNonInteractiveFlags_EnvVars(t *testing.T) {
+	t.Setenv("PRYSM_NON_INTERACTIVE", "true")
+	t.Setenv("PRYSM_FORCE_EXIT", "true")
+	t.Setenv("PRYSM_RESTORE_OVERWRITE", "true")
+	app := &cli.App{
+		Flags: []cli.Flag{NonInteractiveFlag, ForceExitFlag, cmd.RestoreOverwriteFlag},
+		Action: func(cliCtx *cli.Context) error {
+			require.Equal(t, true, cliCtx.Bool(NonInteractiveFlag.Name))
+			require.Equal(t, true, cliCtx.Bool(ForceExitFlag.Name))
+			require.Equal(t, true, cliCtx.Bool(cmd.RestoreOverwriteFlag.Name))
+			return nil
+		},
+	}
+	require.NoError(t, app.Run([]string{"validator"}))
+}
@@ -13,6 +13,7 @@ import (
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	accountcommands "github.com/prysmaticlabs/prysm/v3/cmd/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v3/cmd/validator/appflags"
 	dbcommands "github.com/prysmaticlabs/prysm/v3/cmd/validator/db"
 	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
 	slashingprotectioncommands "github.com/prysmaticlabs/prysm/v3/cmd/validator/slashing-protection"
@@ -21,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/io/file"
 	"github.com/prysmaticlabs/prysm/v3/io/logs"
+	"github.com/prysmaticlabs/prysm/v3/io/prompt"
 	"github.com/prysmaticlabs/prysm/v3/monitoring/journald"
 	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
 	prefixed "github.com/prysmaticlabs/prysm/v3/runtime/logging/logrus-prefixed-formatter"
@@ -46,70 +48,7 @@ func startNode(ctx *cli.Context) error {
 	return nil
 }
 
-var appFlags = []cli.Flag{
-	flags.BeaconRPCProviderFlag,
-	flags.BeaconRPCGatewayProviderFlag,
-	flags.CertFlag,
-	flags.GraffitiFlag,
-	flags.DisablePenaltyRewardLogFlag,
-	flags.InteropStartIndex,
-	flags.InteropNumValidators,
-	flags.EnableRPCFlag,
-	flags.RPCHost,
-	flags.RPCPort,
-	flags.GRPCGatewayPort,
-	flags.GRPCGatewayHost,
-	flags.GrpcRetriesFlag,
-	flags.GrpcRetryDelayFlag,
-	flags.GrpcHeadersFlag,
-	flags.GPRCGatewayCorsDomain,
-	flags.DisableAccountMetricsFlag,
-	flags.MonitoringPortFlag,
-	flags.SlasherRPCProviderFlag,
-	flags.SlasherCertFlag,
-	flags.WalletPasswordFileFlag,
-	flags.WalletDirFlag,
-	flags.EnableWebFlag,
-	flags.GraffitiFileFlag,
-	// Consensys' Web3Signer flags
-	flags.Web3SignerURLFlag,
-	flags.Web3SignerPublicValidatorKeysFlag,
-	flags.SuggestedFeeRecipientFlag,
-	flags.ProposerSettingsURLFlag,
-	flags.ProposerSettingsFlag,
-	flags.EnableBuilderFlag,
-	flags.BuilderGasLimitFlag,
-	////////////////////
-	cmd.DisableMonitoringFlag,
-	cmd.MonitoringHostFlag,
-	cmd.BackupWebhookOutputDir,
-	cmd.EnableBackupWebhookFlag,
-	cmd.MinimalConfigFlag,
-	cmd.E2EConfigFlag,
-	cmd.VerbosityFlag,
-	cmd.DataDirFlag,
-	cmd.ClearDB,
-	cmd.ForceClearDB,
-	cmd.EnableTracingFlag,
-	cmd.TracingProcessNameFlag,
-	cmd.TracingEndpointFlag,
-	cmd.TraceSampleFractionFlag,
-	cmd.LogFormat,
-	cmd.LogFileName,
-	cmd.ConfigFileFlag,
-	cmd.ChainConfigFileFlag,
-	cmd.GrpcMaxCallRecvMsgSizeFlag,
-	cmd.ApiTimeoutFlag,
-	debug.PProfFlag,
-	debug.PProfAddrFlag,
-	debug.PProfPortFlag,
-	debug.MemProfileRateFlag,
-	debug.CPUProfileFlag,
-	debug.TraceFlag,
-	debug.BlockProfileRateFlag,
-	debug.MutexProfileFractionFlag,
-	cmd.AcceptTosFlag,
-}
+var appFlags = appflags.Flags
 
 func init() {
 	appFlags = cmd.WrapFlags(append(appFlags, features.ValidatorFlags...))
@@ -127,6 +66,7 @@ func main() {
 		slashingprotectioncommands.Commands,
 		dbcommands.Commands,
 		web.Commands,
+		cmd.ConfigCommand(appFlags),
 	}
 
 	app.Flags = appFlags
@@ -170,6 +110,8 @@ func main() {
 			}
 		}
 
+		prompt.SetNonInteractive(ctx.Bool(flags.NonInteractiveFlag.Name))
+
 		// Fix data dir for Windows users.
 		outdatedDataDir := filepath.Join(file.HomeDir(), "AppData", "Roaming", "Eth2Validators")
 		currentDataDir := flags.DefaultValidatorDir()
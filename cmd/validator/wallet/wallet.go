@@ -31,6 +31,7 @@ var Commands = &cli.Command{
 				flags.RemoteSignerKeyPathFlag,
 				flags.RemoteSignerCACertPathFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.Mnemonic25thWordFileFlag,
 				flags.SkipMnemonic25thWordCheckFlag,
 				features.Mainnet,
@@ -61,6 +62,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				flags.WalletDirFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.GrpcRemoteAddressFlag,
 				flags.DisableRemoteSignerTlsFlag,
 				flags.RemoteSignerCertPathFlag,
@@ -95,6 +97,7 @@ var Commands = &cli.Command{
 				flags.WalletDirFlag,
 				flags.MnemonicFileFlag,
 				flags.WalletPasswordFileFlag,
+				flags.WalletPasswordSecretFlag,
 				flags.NumAccountsFlag,
 				flags.Mnemonic25thWordFileFlag,
 				flags.SkipMnemonic25thWordCheckFlag,
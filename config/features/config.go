@@ -47,6 +47,7 @@ type Flags struct {
 	WriteWalletPasswordOnWebOnboarding  bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
 	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
+	EnableBeaconRESTApi                 bool // EnableBeaconRESTApi enables the validator client to use the standard beacon REST API instead of gRPC to query a beacon node.
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
 
@@ -66,6 +67,8 @@ type Flags struct {
 	DisableForkchoiceDoublyLinkedTree bool // DisableForkChoiceDoublyLinkedTree specifies whether fork choice store will use a doubly linked tree.
 	EnableBatchGossipAggregation      bool // EnableBatchGossipAggregation specifies whether to further aggregate our gossip batches before verifying them.
 	EnableOnlyBlindedBeaconBlocks     bool // EnableOnlyBlindedBeaconBlocks enables only storing blinded beacon blocks in the DB post-Bellatrix fork.
+	EnableProposerReorgs              bool // EnableProposerReorgs enables proposing on the parent of a late and weakly attested head block.
+	EnableZstdReqResp                 bool // EnableZstdReqResp enables negotiating the experimental zstd encoding for blocks by range requests.
 
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
@@ -241,6 +244,15 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(EnableOnlyBlindedBeaconBlocks)
 		cfg.EnableOnlyBlindedBeaconBlocks = true
 	}
+	if ctx.Bool(enableProposerReorgs.Name) {
+		logEnabled(enableProposerReorgs)
+		cfg.EnableProposerReorgs = true
+	}
+	if ctx.Bool(enableZstdReqResp.Name) {
+		logEnabled(enableZstdReqResp)
+		cfg.EnableZstdReqResp = true
+	}
+	recordFlagStatuses(ctx, BeaconChainFlags)
 	Init(cfg)
 	return nil
 }
@@ -275,7 +287,12 @@ func ConfigureValidator(ctx *cli.Context) error {
 		logEnabled(enableDoppelGangerProtection)
 		cfg.EnableDoppelGanger = true
 	}
+	if ctx.Bool(enableBeaconRESTApi.Name) {
+		logEnabled(enableBeaconRESTApi)
+		cfg.EnableBeaconRESTApi = true
+	}
 	cfg.KeystoreImportDebounceInterval = ctx.Duration(dynamicKeyReloadDebounceInterval.Name)
+	recordFlagStatuses(ctx, ValidatorFlags)
 	Init(cfg)
 	return nil
 }
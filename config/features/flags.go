@@ -83,6 +83,11 @@ var (
 			"a foolproof method to find duplicate instances in the network. Your validator will still be" +
 			" vulnerable if it is being run in unsafe configurations.",
 	}
+	enableBeaconRESTApi = &cli.BoolFlag{
+		Name: "enable-beacon-rest-api",
+		Usage: "Experimental enable of the standard beacon REST API when querying a beacon node. " +
+			"The validator client no longer requires the gRPC API of the beacon node when this flag is set",
+	}
 	enableHistoricalSpaceRepresentation = &cli.BoolFlag{
 		Name: "enable-historical-state-representation",
 		Usage: "Enables the beacon chain to save historical states in a space efficient manner." +
@@ -113,6 +118,15 @@ var (
 		Name:  "enable-only-blinded-beacon-blocks",
 		Usage: "Enables storing only blinded beacon blocks in the database without full execution layer transactions",
 	}
+	enableProposerReorgs = &cli.BoolFlag{
+		Name:  "enable-proposer-reorgs",
+		Usage: "Enables proposing on the parent of the head block when the head block arrived late and has little attestation weight, reorging it out",
+	}
+	enableZstdReqResp = &cli.BoolFlag{
+		Name: "enable-zstd-req-resp",
+		Usage: "(Experimental) Negotiates zstd instead of snappy compression for blocks by range requests with peers supporting it, " +
+			"falling back to snappy otherwise",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -130,6 +144,7 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	attestTimely,
 	enableSlashingProtectionPruning,
 	enableDoppelGangerProtection,
+	enableBeaconRESTApi,
 }...)
 
 // E2EValidatorFlags contains a list of the validator feature flags to be tested in E2E.
@@ -156,6 +171,8 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	disableForkChoiceDoublyLinkedTree,
 	disableGossipBatchAggregation,
 	EnableOnlyBlindedBeaconBlocks,
+	enableProposerReorgs,
+	enableZstdReqResp,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
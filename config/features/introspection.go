@@ -0,0 +1,84 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/urfave/cli/v2"
+)
+
+// FlagStatus describes a feature flag compiled in the client, as it was when the client was
+// configured.
+type FlagStatus struct {
+	Name       string `json:"name"`
+	Usage      string `json:"usage"`
+	Value      string `json:"value"`
+	Default    string `json:"default"`
+	Source     string `json:"source"`
+	Deprecated bool   `json:"deprecated"`
+}
+
+// flagStatusesResponse is the response of FlagStatusesHandler.
+type flagStatusesResponse struct {
+	Flags  []*FlagStatus `json:"flags"`
+	Config *Flags        `json:"config"`
+}
+
+var (
+	flagStatuses     []*FlagStatus
+	flagStatusesLock sync.RWMutex
+)
+
+// FlagStatuses returns the feature flags of the client with their values, recorded when the client
+// was configured.
+func FlagStatuses() []*FlagStatus {
+	flagStatusesLock.RLock()
+	defer flagStatusesLock.RUnlock()
+	return flagStatuses
+}
+
+// FlagStatusesHandler serves the feature flags of the client and the resulting feature
+// configuration as JSON, to audit the non default behavior of a running client.
+func FlagStatusesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&flagStatusesResponse{Flags: FlagStatuses(), Config: Get()}); err != nil {
+		log.WithError(err).Error("Failed to render feature flags")
+	}
+}
+
+// recordFlagStatuses records the values of the feature flags and where they come from.
+func recordFlagStatuses(ctx *cli.Context, flags []cli.Flag) {
+	deprecated := make(map[string]bool, len(deprecatedFlags))
+	for _, f := range deprecatedFlags {
+		deprecated[f.Names()[0]] = true
+	}
+	statuses := make([]*FlagStatus, 0, len(flags))
+	for _, f := range flags {
+		name := f.Names()[0]
+		status := &FlagStatus{
+			Name:       name,
+			Value:      fmt.Sprintf("%v", ctx.Value(name)),
+			Source:     cmd.FlagSource(ctx, name),
+			Deprecated: deprecated[name],
+		}
+		if df, ok := f.(cli.DocGenerationFlag); ok {
+			status.Usage = df.GetUsage()
+		}
+		if v := flagValue(f).FieldByName("Value"); v.IsValid() {
+			status.Default = fmt.Sprintf("%v", v.Interface())
+		}
+		statuses = append(statuses, status)
+	}
+
+	flagStatusesLock.Lock()
+	defer flagStatusesLock.Unlock()
+	flagStatuses = statuses
+}
@@ -0,0 +1,70 @@
+package features
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/cmd"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func validatorContext(t *testing.T, args ...string) *cli.Context {
+	app := &cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	for _, f := range ValidatorFlags {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(app, set, nil)
+}
+
+func TestFlagStatuses(t *testing.T) {
+	defer Init(&Flags{})
+	require.NoError(t, ConfigureValidator(validatorContext(t, "--"+enableDoppelGangerProtection.Name)))
+
+	statuses := make(map[string]*FlagStatus)
+	for _, s := range FlagStatuses() {
+		statuses[s.Name] = s
+	}
+	require.Equal(t, len(ValidatorFlags), len(statuses))
+
+	s := statuses[enableDoppelGangerProtection.Name]
+	require.NotNil(t, s)
+	assert.Equal(t, "true", s.Value)
+	assert.Equal(t, "false", s.Default)
+	assert.Equal(t, cmd.FlagSourceCommandLine, s.Source)
+	assert.Equal(t, false, s.Deprecated)
+	assert.Equal(t, enableDoppelGangerProtection.Usage, s.Usage)
+
+	s = statuses[dynamicKeyReloadDebounceInterval.Name]
+	require.NotNil(t, s)
+	assert.Equal(t, s.Default, s.Value)
+	assert.Equal(t, cmd.FlagSourceDefault, s.Source)
+
+	s = statuses[exampleDeprecatedFeatureFlag.Name]
+	require.NotNil(t, s)
+	assert.Equal(t, true, s.Deprecated)
+}
+
+func TestFlagStatusesHandler(t *testing.T) {
+	defer Init(&Flags{})
+	require.NoError(t, ConfigureValidator(validatorContext(t, "--"+enableDoppelGangerProtection.Name)))
+
+	rec := httptest.NewRecorder()
+	FlagStatusesHandler(rec, httptest.NewRequest(http.MethodGet, "/features", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	resp := &flagStatusesResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+	assert.Equal(t, len(ValidatorFlags), len(resp.Flags))
+	assert.Equal(t, true, resp.Config.EnableDoppelGanger)
+
+	rec = httptest.NewRecorder()
+	FlagStatusesHandler(rec, httptest.NewRequest(http.MethodPost, "/features", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
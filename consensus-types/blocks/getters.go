@@ -638,6 +638,17 @@ func (b *BeaconBlockBody) Execution() (interfaces.ExecutionData, error) {
 	}
 }
 
+// BLSToExecutionChanges returns the BLS to execution changes in the block. No block version before
+// Capella carries them.
+func (b *BeaconBlockBody) BLSToExecutionChanges() ([]*eth.SignedBLSToExecutionChange, error) {
+	switch b.version {
+	case version.Phase0, version.Altair, version.Bellatrix:
+		return nil, errNotSupported("BLSToExecutionChanges", b.version)
+	default:
+		return nil, errIncorrectBlockVersion
+	}
+}
+
 // HashTreeRoot returns the ssz root of the block body.
 func (b *BeaconBlockBody) HashTreeRoot() ([32]byte, error) {
 	pb, err := b.Proto()
@@ -1,6 +1,7 @@
 package blocks
 
 import (
+	"errors"
 	"testing"
 
 	ssz "github.com/prysmaticlabs/fastssz"
@@ -308,6 +309,12 @@ func Test_BeaconBlockBody_SyncAggregate(t *testing.T) {
 	assert.Equal(t, result, sa)
 }
 
+func Test_BeaconBlockBody_BLSToExecutionChanges(t *testing.T) {
+	bb := &BeaconBlockBody{version: version.Bellatrix}
+	_, err := bb.BLSToExecutionChanges()
+	assert.Equal(t, true, errors.Is(err, ErrUnsupportedGetter))
+}
+
 func Test_BeaconBlockBody_HashTreeRoot(t *testing.T) {
 	pb := hydrateBeaconBlockBody()
 	expectedHTR, err := pb.HashTreeRoot()
@@ -64,6 +64,7 @@ type BeaconBlockBody interface {
 	HashTreeRoot() ([32]byte, error)
 	Proto() (proto.Message, error)
 	Execution() (ExecutionData, error)
+	BLSToExecutionChanges() ([]*ethpb.SignedBLSToExecutionChange, error)
 }
 
 // ExecutionData represents execution layer information that is contained
@@ -0,0 +1,209 @@
+package trie
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+)
+
+// The fixed part of a serialized snapshot holds the offset of the finalized hashes (4 bytes), the
+// deposit root (32 bytes), the deposit count (8 bytes), the execution block hash (32 bytes) and the
+// execution block height (8 bytes).
+const depositSnapshotFixedLength = 4 + 32 + 8 + 32 + 8
+
+// DepositTreeSnapshot is the EIP-4881 snapshot of the deposit tree at a finalized execution block.
+// It only holds the roots of the largest full subtrees of the deposits, which is enough to keep
+// inserting deposits and computing proofs for them without the historical deposits.
+//
+// Spec definition:
+//
+//	class DepositTreeSnapshot:
+//	    finalized: List[Hash32, DEPOSIT_CONTRACT_DEPTH]
+//	    deposit_root: Hash32
+//	    deposit_count: uint64
+//	    execution_block_hash: Hash32
+//	    execution_block_height: uint64
+type DepositTreeSnapshot struct {
+	Finalized            [][32]byte
+	DepositRoot          [32]byte
+	DepositCount         uint64
+	ExecutionBlockHash   [32]byte
+	ExecutionBlockHeight uint64
+}
+
+// NewDepositTreeSnapshot returns the snapshot of the first depositCount deposits of the trie, which
+// were all included in the execution block with the given hash and height.
+func NewDepositTreeSnapshot(m *SparseMerkleTrie, depositCount uint64, executionHash [32]byte, executionHeight uint64) (*DepositTreeSnapshot, error) {
+	if depositCount > uint64(m.NumOfItems()) {
+		return nil, errors.Errorf("deposit count %d is greater than the %d items of the trie", depositCount, m.NumOfItems())
+	}
+	s := &DepositTreeSnapshot{
+		Finalized:            make([][32]byte, 0, bits.OnesCount64(depositCount)),
+		DepositCount:         depositCount,
+		ExecutionBlockHash:   executionHash,
+		ExecutionBlockHeight: executionHeight,
+	}
+	// The finalized subtrees are the left siblings of the path to the next deposit, largest first.
+	for i := int(m.depth) - 1; i >= 0; i-- {
+		if (depositCount>>uint(i))&1 == 1 {
+			s.Finalized = append(s.Finalized, bytesutil.ToBytes32(m.branches[i][(depositCount>>uint(i))-1]))
+		}
+	}
+	root, err := s.CalculateRoot()
+	if err != nil {
+		return nil, err
+	}
+	s.DepositRoot = root
+	return s, nil
+}
+
+// CalculateRoot computes the deposit root of the snapshot from its finalized hashes.
+//
+// Spec pseudocode definition:
+//
+//	def calculate_root(self) -> Hash32:
+//	    size = self.deposit_count
+//	    index = len(self.finalized)
+//	    root = zerohashes[0]
+//	    for level in range(0, DEPOSIT_CONTRACT_DEPTH):
+//	        if (size & 1) == 1:
+//	            index -= 1
+//	            root = sha256(self.finalized[index] + root)
+//	        else:
+//	            root = sha256(root + zerohashes[level])
+//	        size >>= 1
+//	    return sha256(root + to_le_bytes(self.deposit_count))
+func (s *DepositTreeSnapshot) CalculateRoot() ([32]byte, error) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	if bits.OnesCount64(s.DepositCount) != len(s.Finalized) {
+		return [32]byte{}, errors.Errorf("deposit count %d does not match %d finalized hashes", s.DepositCount, len(s.Finalized))
+	}
+	if depth < 64 && s.DepositCount >= 1<<depth {
+		return [32]byte{}, errors.Errorf("deposit count %d exceeds the capacity of the deposit tree", s.DepositCount)
+	}
+	size := s.DepositCount
+	index := len(s.Finalized)
+	root := ZeroHashes[0]
+	for level := uint64(0); level < depth; level++ {
+		if size&1 == 1 {
+			index--
+			root = hash.Hash(append(s.Finalized[index][:], root[:]...))
+		} else {
+			root = hash.Hash(append(root[:], ZeroHashes[level][:]...))
+		}
+		size >>= 1
+	}
+	enc := [32]byte{}
+	binary.LittleEndian.PutUint64(enc[:], s.DepositCount)
+	return hash.Hash(append(root[:], enc[:]...)), nil
+}
+
+// NewTrieFromSnapshot rebuilds a deposit trie from a snapshot. New deposits can be inserted in the
+// trie and proven, but the trie can't produce proofs for the deposits of the snapshot.
+func NewTrieFromSnapshot(s *DepositTreeSnapshot) (*SparseMerkleTrie, error) {
+	root, err := s.CalculateRoot()
+	if err != nil {
+		return nil, err
+	}
+	if root != s.DepositRoot {
+		return nil, errors.Errorf("snapshot deposit root %#x does not match its finalized hashes", s.DepositRoot)
+	}
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	if s.DepositCount == 0 {
+		return NewTrie(depth)
+	}
+	count := s.DepositCount
+	// Nodes below the finalized subtrees are unknown and left as zero hashes. They are never
+	// needed to insert or prove deposits which come after the snapshot.
+	layers := make([][][]byte, depth+1)
+	for i := uint64(0); i <= depth; i++ {
+		size := (count + (1 << i) - 1) >> i
+		layers[i] = make([][]byte, size)
+		for j := range layers[i] {
+			layers[i][j] = ZeroHashes[i][:]
+		}
+	}
+	index := 0
+	for i := int(depth) - 1; i >= 0; i-- {
+		if (count>>uint(i))&1 == 1 {
+			node := s.Finalized[index]
+			layers[i][(count>>uint(i))-1] = node[:]
+			index++
+		}
+	}
+	// Fill in the partially filled nodes on the path to the next deposit, up to the root.
+	node := ZeroHashes[0]
+	size := count
+	for i := uint64(0); i < depth; i++ {
+		if size&1 == 1 {
+			node = hash.Hash(append(bytesutil.SafeCopyBytes(layers[i][(count>>i)-1]), node[:]...))
+		} else {
+			node = hash.Hash(append(node[:], ZeroHashes[i][:]...))
+		}
+		size >>= 1
+		if parent := count >> (i + 1); parent < uint64(len(layers[i+1])) {
+			n := node
+			layers[i+1][parent] = n[:]
+		}
+	}
+	items := make([][]byte, count)
+	for i := range items {
+		items[i] = ZeroHashes[0][:]
+	}
+	// A trie with a single zero item is considered empty, so keep the actual leaf when it is known.
+	if count&1 == 1 {
+		items[count-1] = layers[0][count-1]
+	}
+	return &SparseMerkleTrie{
+		depth:         uint(depth),
+		branches:      layers,
+		originalItems: items,
+	}, nil
+}
+
+// MarshalSSZ returns the SSZ encoding of the snapshot.
+func (s *DepositTreeSnapshot) MarshalSSZ() ([]byte, error) {
+	if uint64(len(s.Finalized)) > params.BeaconConfig().DepositContractTreeDepth {
+		return nil, errors.Errorf("too many finalized hashes: %d", len(s.Finalized))
+	}
+	enc := make([]byte, depositSnapshotFixedLength, depositSnapshotFixedLength+32*len(s.Finalized))
+	binary.LittleEndian.PutUint32(enc[0:4], depositSnapshotFixedLength)
+	copy(enc[4:36], s.DepositRoot[:])
+	binary.LittleEndian.PutUint64(enc[36:44], s.DepositCount)
+	copy(enc[44:76], s.ExecutionBlockHash[:])
+	binary.LittleEndian.PutUint64(enc[76:84], s.ExecutionBlockHeight)
+	for _, f := range s.Finalized {
+		enc = append(enc, f[:]...)
+	}
+	return enc, nil
+}
+
+// UnmarshalSSZ decodes the SSZ encoding of a snapshot.
+func (s *DepositTreeSnapshot) UnmarshalSSZ(enc []byte) error {
+	if len(enc) < depositSnapshotFixedLength {
+		return errors.Errorf("snapshot encoding is too short: %d bytes", len(enc))
+	}
+	if offset := binary.LittleEndian.Uint32(enc[0:4]); offset != depositSnapshotFixedLength {
+		return errors.Errorf("invalid offset of finalized hashes: %d", offset)
+	}
+	rest := enc[depositSnapshotFixedLength:]
+	if len(rest)%32 != 0 {
+		return errors.Errorf("finalized hashes length %d is not a multiple of 32", len(rest))
+	}
+	if uint64(len(rest)/32) > params.BeaconConfig().DepositContractTreeDepth {
+		return errors.Errorf("too many finalized hashes: %d", len(rest)/32)
+	}
+	s.DepositRoot = bytesutil.ToBytes32(enc[4:36])
+	s.DepositCount = binary.LittleEndian.Uint64(enc[36:44])
+	s.ExecutionBlockHash = bytesutil.ToBytes32(enc[44:76])
+	s.ExecutionBlockHeight = binary.LittleEndian.Uint64(enc[76:84])
+	s.Finalized = make([][32]byte, len(rest)/32)
+	for i := range s.Finalized {
+		s.Finalized[i] = bytesutil.ToBytes32(rest[32*i : 32*(i+1)])
+	}
+	return nil
+}
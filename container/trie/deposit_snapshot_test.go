@@ -0,0 +1,94 @@
+package trie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/container/trie"
+	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestDepositTreeSnapshot(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := make([][]byte, 20)
+	for i := range items {
+		h := hash.Hash([]byte(fmt.Sprintf("deposit %d", i)))
+		items[i] = h[:]
+	}
+	full, err := trie.GenerateTrieFromItems(items, depth)
+	require.NoError(t, err)
+	fullRoot, err := full.HashTreeRoot()
+	require.NoError(t, err)
+
+	for _, count := range []int{0, 1, 2, 3, 5, 8, 13, 20} {
+		t.Run(fmt.Sprintf("%d deposits", count), func(t *testing.T) {
+			s, err := trie.NewDepositTreeSnapshot(full, uint64(count), [32]byte{'a'}, 100)
+			require.NoError(t, err)
+			var wantRoot [32]byte
+			if count == 0 {
+				empty, err := trie.NewTrie(depth)
+				require.NoError(t, err)
+				wantRoot, err = empty.HashTreeRoot()
+				require.NoError(t, err)
+			} else {
+				partial, err := trie.GenerateTrieFromItems(items[:count], depth)
+				require.NoError(t, err)
+				wantRoot, err = partial.HashTreeRoot()
+				require.NoError(t, err)
+			}
+			assert.Equal(t, wantRoot, s.DepositRoot)
+
+			enc, err := s.MarshalSSZ()
+			require.NoError(t, err)
+			decoded := &trie.DepositTreeSnapshot{}
+			require.NoError(t, decoded.UnmarshalSSZ(enc))
+			assert.DeepEqual(t, s, decoded)
+
+			restored, err := trie.NewTrieFromSnapshot(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, count, restored.NumOfItems())
+			root, err := restored.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, wantRoot, root)
+			for i := count; i < len(items); i++ {
+				require.NoError(t, restored.Insert(items[i], i))
+			}
+			root, err = restored.HashTreeRoot()
+			require.NoError(t, err)
+			assert.Equal(t, fullRoot, root)
+			for i := count; i < len(items); i++ {
+				proof, err := restored.MerkleProof(i)
+				require.NoError(t, err)
+				wantProof, err := full.MerkleProof(i)
+				require.NoError(t, err)
+				assert.DeepEqual(t, wantProof, proof)
+			}
+		})
+	}
+}
+
+func TestDepositTreeSnapshot_Invalid(t *testing.T) {
+	_, err := trie.NewDepositTreeSnapshot(emptyTrie(t), 1, [32]byte{}, 0)
+	require.ErrorContains(t, "deposit count 1 is greater than the 0 items of the trie", err)
+
+	s := &trie.DepositTreeSnapshot{DepositCount: 3, Finalized: [][32]byte{{'a'}}}
+	_, err = trie.NewTrieFromSnapshot(s)
+	require.ErrorContains(t, "deposit count 3 does not match 1 finalized hashes", err)
+	s.Finalized = append(s.Finalized, [32]byte{'b'})
+	_, err = trie.NewTrieFromSnapshot(s)
+	require.ErrorContains(t, "does not match its finalized hashes", err)
+
+	require.ErrorContains(t, "too short", (&trie.DepositTreeSnapshot{}).UnmarshalSSZ(make([]byte, 10)))
+	enc, err := s.MarshalSSZ()
+	require.NoError(t, err)
+	require.ErrorContains(t, "not a multiple of 32", (&trie.DepositTreeSnapshot{}).UnmarshalSSZ(enc[:len(enc)-1]))
+}
+
+func emptyTrie(t *testing.T) *trie.SparseMerkleTrie {
+	m, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	return m
+}
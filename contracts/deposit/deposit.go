@@ -3,6 +3,7 @@
 package deposit
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
@@ -26,15 +27,24 @@ import (
 //
 // See: https://github.com/ethereum/consensus-specs/blob/master/specs/validator/0_beacon-chain-validator.md#submit-deposit
 func DepositInput(depositKey, withdrawalKey bls.SecretKey, amountInGwei uint64) (*ethpb.Deposit_Data, [32]byte, error) {
+	di, _, dr, err := depositData(depositKey, WithdrawalCredentialsHash(withdrawalKey), amountInGwei)
+	return di, dr, err
+}
+
+// depositData signs the deposit message with the deposit key and returns the deposit data,
+// along with the roots of the deposit message and of the deposit data.
+func depositData(
+	depositKey bls.SecretKey, withdrawalCredentials []byte, amountInGwei uint64,
+) (*ethpb.Deposit_Data, [32]byte, [32]byte, error) {
 	depositMessage := &ethpb.DepositMessage{
 		PublicKey:             depositKey.PublicKey().Marshal(),
-		WithdrawalCredentials: WithdrawalCredentialsHash(withdrawalKey),
+		WithdrawalCredentials: withdrawalCredentials,
 		Amount:                amountInGwei,
 	}
 
 	sr, err := depositMessage.HashTreeRoot()
 	if err != nil {
-		return nil, [32]byte{}, err
+		return nil, [32]byte{}, [32]byte{}, err
 	}
 
 	domain, err := signing.ComputeDomain(
@@ -43,11 +53,11 @@ func DepositInput(depositKey, withdrawalKey bls.SecretKey, amountInGwei uint64)
 		nil, /*genesisValidatorsRoot*/
 	)
 	if err != nil {
-		return nil, [32]byte{}, err
+		return nil, [32]byte{}, [32]byte{}, err
 	}
 	root, err := (&ethpb.SigningData{ObjectRoot: sr[:], Domain: domain}).HashTreeRoot()
 	if err != nil {
-		return nil, [32]byte{}, err
+		return nil, [32]byte{}, [32]byte{}, err
 	}
 	di := &ethpb.Deposit_Data{
 		PublicKey:             depositMessage.PublicKey,
@@ -58,10 +68,10 @@ func DepositInput(depositKey, withdrawalKey bls.SecretKey, amountInGwei uint64)
 
 	dr, err := di.HashTreeRoot()
 	if err != nil {
-		return nil, [32]byte{}, err
+		return nil, [32]byte{}, [32]byte{}, err
 	}
 
-	return di, dr, nil
+	return di, sr, dr, nil
 }
 
 // WithdrawalCredentialsHash forms a 32 byte hash of the withdrawal public
@@ -76,6 +86,20 @@ func WithdrawalCredentialsHash(withdrawalKey bls.SecretKey) []byte {
 	return append([]byte{params.BeaconConfig().BLSWithdrawalPrefixByte}, h[1:]...)[:32]
 }
 
+// ExecutionAddressWithdrawalCredentials forms the 32 byte withdrawal credentials withdrawing to
+// an execution layer address.
+//
+// The specification is as follows:
+//   withdrawal_credentials[:1] == ETH1_ADDRESS_WITHDRAWAL_PREFIX
+//   withdrawal_credentials[1:12] == b'\x00' * 11
+//   withdrawal_credentials[12:] == eth1_withdrawal_address
+func ExecutionAddressWithdrawalCredentials(address common.Address) []byte {
+	credentials := make([]byte, 32)
+	credentials[0] = params.BeaconConfig().ETH1AddressWithdrawalPrefixByte
+	copy(credentials[12:], address.Bytes())
+	return credentials
+}
+
 // VerifyDepositSignature verifies the correctness of Eth1 deposit BLS signature
 func VerifyDepositSignature(dd *ethpb.Deposit_Data, domain []byte) error {
 	ddCopy := ethpb.CopyDepositData(dd)
@@ -1,8 +1,10 @@
 package deposit_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/contracts/deposit"
@@ -73,3 +75,49 @@ func TestVerifyDepositSignature_InvalidSig(t *testing.T) {
 		t.Fatal("Deposit Verification succeeds with a invalid signature")
 	}
 }
+
+func TestExecutionAddressWithdrawalCredentials(t *testing.T) {
+	address := common.HexToAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72")
+	credentials := deposit.ExecutionAddressWithdrawalCredentials(address)
+	require.Equal(t, 32, len(credentials))
+	assert.Equal(t, params.BeaconConfig().ETH1AddressWithdrawalPrefixByte, credentials[0])
+	assert.DeepEqual(t, make([]byte, 11), credentials[1:12])
+	assert.DeepEqual(t, address.Bytes(), credentials[12:])
+}
+
+func TestNewLaunchpadDepositData(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ConfigName = "test"
+	cfg.GenesisForkVersion = []byte{0x00, 0x00, 0x10, 0x20}
+	params.OverrideBeaconConfig(cfg)
+
+	depositKey, err := bls.RandKey()
+	require.NoError(t, err)
+	withdrawalKey, err := bls.RandKey()
+	require.NoError(t, err)
+	credentials := deposit.WithdrawalCredentialsHash(withdrawalKey)
+	dd, err := deposit.NewLaunchpadDepositData(depositKey, credentials, 32e9)
+	require.NoError(t, err)
+
+	di, dataRoot, err := deposit.DepositInput(depositKey, withdrawalKey, 32e9)
+	require.NoError(t, err)
+	messageRoot, err := (&ethpb.DepositMessage{
+		PublicKey:             di.PublicKey,
+		WithdrawalCredentials: di.WithdrawalCredentials,
+		Amount:                di.Amount,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%x", di.PublicKey), dd.PubKey)
+	assert.Equal(t, fmt.Sprintf("%x", credentials), dd.WithdrawalCredentials)
+	assert.Equal(t, uint64(32e9), dd.Amount)
+	assert.Equal(t, fmt.Sprintf("%x", di.Signature), dd.Signature)
+	assert.Equal(t, fmt.Sprintf("%x", messageRoot), dd.DepositMessageRoot)
+	assert.Equal(t, fmt.Sprintf("%x", dataRoot), dd.DepositDataRoot)
+	assert.Equal(t, "00001020", dd.ForkVersion)
+	assert.Equal(t, "test", dd.NetworkName)
+
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainDeposit, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, deposit.VerifyDepositSignature(di, domain))
+}
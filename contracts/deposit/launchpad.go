@@ -0,0 +1,49 @@
+package deposit
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+)
+
+// launchpadDepositCLIVersion is the version of the staking deposit CLI whose deposit data format
+// is produced, which the staking launchpad checks before accepting a file.
+const launchpadDepositCLIVersion = "2.3.0"
+
+// LaunchpadDepositData is a deposit in the JSON format of the deposit data files accepted by the
+// staking launchpad. Byte fields are hex encoded without prefix.
+type LaunchpadDepositData struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name"`
+	DepositCLIVersion     string `json:"deposit_cli_version"`
+}
+
+// NewLaunchpadDepositData signs a deposit of the amount with the deposit key for the network of
+// the active beacon chain config.
+func NewLaunchpadDepositData(
+	depositKey bls.SecretKey, withdrawalCredentials []byte, amountInGwei uint64,
+) (*LaunchpadDepositData, error) {
+	di, messageRoot, dataRoot, err := depositData(depositKey, withdrawalCredentials, amountInGwei)
+	if err != nil {
+		return nil, err
+	}
+	cfg := params.BeaconConfig()
+	return &LaunchpadDepositData{
+		PubKey:                fmt.Sprintf("%x", di.PublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%x", di.WithdrawalCredentials),
+		Amount:                di.Amount,
+		Signature:             fmt.Sprintf("%x", di.Signature),
+		DepositMessageRoot:    fmt.Sprintf("%x", messageRoot),
+		DepositDataRoot:       fmt.Sprintf("%x", dataRoot),
+		ForkVersion:           fmt.Sprintf("%x", cfg.GenesisForkVersion),
+		NetworkName:           cfg.ConfigName,
+		DepositCLIVersion:     launchpadDepositCLIVersion,
+	}, nil
+}
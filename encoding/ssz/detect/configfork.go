@@ -51,6 +51,30 @@ func FromState(marshaled []byte) (*VersionedUnmarshaler, error) {
 	return FromForkVersion(cv)
 }
 
+// FromStateWithConfig is like FromState, but resolves the version of the marshaled BeaconState with the given config
+// instead of the known configs. This is used for states of networks whose config is not loaded yet.
+func FromStateWithConfig(cfg *params.BeaconChainConfig, marshaled []byte) (*VersionedUnmarshaler, error) {
+	cv, err := beaconStateCurrentVersion.bytes4(marshaled)
+	if err != nil {
+		return nil, err
+	}
+	return fromConfigForkVersion(cfg, cv)
+}
+
+// FromBlock reads the slot of a marshaled SignedBeaconBlock and looks up the fork version scheduled for the epoch
+// of that slot in the current beacon config, so that the block can be unmarshaled with the returned VersionedUnmarshaler.
+func FromBlock(marshaled []byte) (*VersionedUnmarshaler, error) {
+	slot, err := slotFromBlock(marshaled)
+	if err != nil {
+		return nil, err
+	}
+	cv, err := forks.NewOrderedSchedule(params.BeaconConfig()).VersionForEpoch(slots.ToEpoch(slot))
+	if err != nil {
+		return nil, err
+	}
+	return FromForkVersion(cv)
+}
+
 var ErrForkNotFound = errors.New("version found in fork schedule but can't be matched to a named fork")
 
 // FromForkVersion uses a lookup table to resolve a Version (from a beacon node api for instance, or obtained by peeking at
@@ -60,6 +84,10 @@ func FromForkVersion(cv [fieldparams.VersionLength]byte) (*VersionedUnmarshaler,
 	if err != nil {
 		return nil, err
 	}
+	return fromConfigForkVersion(cfg, cv)
+}
+
+func fromConfigForkVersion(cfg *params.BeaconChainConfig, cv [fieldparams.VersionLength]byte) (*VersionedUnmarshaler, error) {
 	var fork int
 	switch cv {
 	case bytesutil.ToBytes4(cfg.GenesisForkVersion):
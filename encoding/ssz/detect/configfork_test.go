@@ -101,6 +101,46 @@ func TestByState(t *testing.T) {
 	}
 }
 
+func TestFromStateWithConfig(t *testing.T) {
+	cfg := params.MainnetConfig().Copy()
+	cfg.ConfigName = "customnet"
+	cfg.GenesisForkVersion = []byte{0x10, 0, 0, 0x01}
+	cfg.AltairForkVersion = []byte{0x10, 0, 0, 0x02}
+	cfg.BellatrixForkVersion = []byte{0x10, 0, 0, 0x03}
+	cases := []struct {
+		version     int
+		forkversion []byte
+	}{
+		{version: version.Phase0, forkversion: cfg.GenesisForkVersion},
+		{version: version.Altair, forkversion: cfg.AltairForkVersion},
+		{version: version.Bellatrix, forkversion: cfg.BellatrixForkVersion},
+	}
+	for _, c := range cases {
+		st, err := stateForVersion(c.version)
+		require.NoError(t, err)
+		require.NoError(t, st.SetFork(&ethpb.Fork{
+			PreviousVersion: make([]byte, 4),
+			CurrentVersion:  c.forkversion,
+		}))
+		m, err := st.MarshalSSZ()
+		require.NoError(t, err)
+		cf, err := FromStateWithConfig(cfg, m)
+		require.NoError(t, err)
+		require.Equal(t, c.version, cf.Fork)
+		require.Equal(t, bytesutil.ToBytes4(c.forkversion), cf.Version)
+		require.Equal(t, cfg, cf.Config)
+		_, err = cf.UnmarshalBeaconState(m)
+		require.NoError(t, err)
+	}
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	m, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	_, err = FromStateWithConfig(cfg, m)
+	require.ErrorIs(t, err, ErrForkNotFound)
+}
+
 func stateForVersion(v int) (state.BeaconState, error) {
 	switch v {
 	case version.Phase0:
@@ -276,6 +316,53 @@ func TestUnmarshalBlock(t *testing.T) {
 	}
 }
 
+func TestFromBlock(t *testing.T) {
+	undo, err := hackBellatrixMaxuint()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, undo())
+	}()
+	altairS, err := slots.EpochStart(params.BeaconConfig().AltairForkEpoch)
+	require.NoError(t, err)
+	cases := []struct {
+		b    func(*testing.T, types.Slot) interfaces.SignedBeaconBlock
+		name string
+		slot types.Slot
+		fork int
+	}{
+		{
+			name: "genesis - slot 0",
+			b:    signedTestBlockGenesis,
+			fork: version.Phase0,
+		},
+		{
+			name: "last slot of phase 0",
+			b:    signedTestBlockGenesis,
+			slot: altairS - 1,
+			fork: version.Phase0,
+		},
+		{
+			name: "first slot of altair",
+			b:    signedTestBlockAltair,
+			slot: altairS,
+			fork: version.Altair,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := c.b(t, c.slot)
+			marshaled, err := b.MarshalSSZ()
+			require.NoError(t, err)
+			cf, err := FromBlock(marshaled)
+			require.NoError(t, err)
+			require.Equal(t, c.fork, cf.Fork)
+			bcf, err := cf.UnmarshalBeaconBlock(marshaled)
+			require.NoError(t, err)
+			require.Equal(t, c.slot, bcf.Block().Slot())
+		})
+	}
+}
+
 func TestUnmarshalBlindedBlock(t *testing.T) {
 	undo, err := hackBellatrixMaxuint()
 	require.NoError(t, err)
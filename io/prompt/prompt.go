@@ -17,6 +17,31 @@ import (
 
 var au = aurora.NewAurora(true)
 
+// ErrNonInteractive is returned by prompts which need user input while prompts are disabled.
+var ErrNonInteractive = errors.New("input required in non-interactive mode")
+
+var nonInteractive bool
+
+// SetNonInteractive disables the prompts, so that anything waiting for user input fails right away
+// instead of hanging. Prompts with a default value return the default.
+func SetNonInteractive(v bool) {
+	nonInteractive = v
+}
+
+// IsNonInteractive returns true if prompts are disabled.
+func IsNonInteractive() bool {
+	return nonInteractive
+}
+
+// Wraps ErrNonInteractive with the first line of the prompt, which is usually the question itself.
+func nonInteractiveError(promptText string) error {
+	text := strings.TrimSpace(promptText)
+	if i := strings.Index(text, "\n"); i >= 0 {
+		text = text[:i]
+	}
+	return errors.Wrap(ErrNonInteractive, text)
+}
+
 // PasswordReaderFunc takes in a *file and returns a password using the terminal package
 func passwordReaderFunc(file *os.File) ([]byte, error) {
 	pass, err := terminal.ReadPassword(int(file.Fd()))
@@ -28,6 +53,9 @@ var PasswordReader = passwordReaderFunc
 
 // ValidatePrompt requests the user for text and expects the user to fulfill the provided validation function.
 func ValidatePrompt(r io.Reader, promptText string, validateFunc func(string) error) (string, error) {
+	if nonInteractive {
+		return "", nonInteractiveError(promptText)
+	}
 	var responseValid bool
 	var response string
 	for !responseValid {
@@ -50,6 +78,12 @@ func ValidatePrompt(r io.Reader, promptText string, validateFunc func(string) er
 
 // DefaultPrompt prompts the user for any text and performs no validation. If nothing is entered it returns the default.
 func DefaultPrompt(promptText, defaultValue string) (string, error) {
+	if nonInteractive {
+		if defaultValue != "" {
+			return defaultValue, nil
+		}
+		return "", nonInteractiveError(promptText)
+	}
 	var response string
 	if defaultValue != "" {
 		fmt.Printf("%s %s:\n", promptText, fmt.Sprintf("(%s: %s)", au.BrightGreen("default"), defaultValue))
@@ -71,6 +105,12 @@ func DefaultPrompt(promptText, defaultValue string) (string, error) {
 // DefaultAndValidatePrompt prompts the user for any text and expects it to fulfill a validation function. If nothing is entered
 // the default value is returned.
 func DefaultAndValidatePrompt(promptText, defaultValue string, validateFunc func(string) error) (string, error) {
+	if nonInteractive {
+		if defaultValue != "" {
+			return defaultValue, nil
+		}
+		return "", nonInteractiveError(promptText)
+	}
 	var responseValid bool
 	var response string
 	for !responseValid {
@@ -97,6 +137,9 @@ func DefaultAndValidatePrompt(promptText, defaultValue string, validateFunc func
 // PasswordPrompt prompts the user for a password, that repeatedly requests the password until it qualifies the
 // passed in validation function.
 func PasswordPrompt(promptText string, validateFunc func(string) error) (string, error) {
+	if nonInteractive {
+		return "", nonInteractiveError(promptText)
+	}
 	var responseValid bool
 	var response string
 	for !responseValid {
@@ -141,6 +184,9 @@ func InputPassword(
 		}
 		return enteredPassword, nil
 	}
+	if nonInteractive {
+		return "", errors.Wrapf(ErrNonInteractive, "use --%s to provide the password", passwordFileFlag.Name)
+	}
 	if strings.Contains(strings.ToLower(promptText), "new wallet") {
 		fmt.Println("Password requirements: at least 8 characters")
 	}
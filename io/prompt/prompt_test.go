@@ -0,0 +1,48 @@
+package prompt
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNonInteractive(t *testing.T) {
+	SetNonInteractive(true)
+	defer SetNonInteractive(false)
+	require.Equal(t, true, IsNonInteractive())
+
+	_, err := ValidatePrompt(strings.NewReader("y\n"), "Are you sure?\nThis can't be undone", ValidateYesOrNo)
+	assert.Equal(t, true, errors.Is(err, ErrNonInteractive))
+	assert.ErrorContains(t, "Are you sure?: input required in non-interactive mode", err)
+	_, err = PasswordPrompt("Enter a password", NotEmpty)
+	assert.Equal(t, true, errors.Is(err, ErrNonInteractive))
+
+	resp, err := DefaultPrompt("Enter a directory", "/tmp")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp", resp)
+	_, err = DefaultPrompt("Enter a directory", "")
+	assert.Equal(t, true, errors.Is(err, ErrNonInteractive))
+	resp, err = DefaultAndValidatePrompt("Enter a number", "3", ValidateNumber)
+	require.NoError(t, err)
+	assert.Equal(t, "3", resp)
+
+	passwordFileFlag := &cli.StringFlag{Name: "password-file"}
+	set := flag.NewFlagSet("test", 0)
+	set.String(passwordFileFlag.Name, "", "")
+	cliCtx := cli.NewContext(&cli.App{}, set, nil)
+	_, err = InputPassword(cliCtx, passwordFileFlag, "Enter a password", "", false, NotEmpty)
+	assert.ErrorContains(t, "use --password-file to provide the password", err)
+
+	passwordFile := t.TempDir() + "/password.txt"
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	require.NoError(t, set.Set(passwordFileFlag.Name, passwordFile))
+	password, err := InputPassword(cliCtx, passwordFileFlag, "Enter a password", "", false, NotEmpty)
+	require.NoError(t, err)
+	assert.Equal(t, "password", password)
+}
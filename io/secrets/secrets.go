@@ -0,0 +1,82 @@
+// Package secrets fetches secrets, such as wallet passwords, from external secret managers, so
+// that they do not have to be stored in plain text files on disk.
+//
+// A secret is referenced as scheme://target, where the scheme is one of:
+//
+//	vault://<path>[#<key>]        a HashiCorp Vault secret, read with VAULT_ADDR and VAULT_TOKEN.
+//	aws-sm://<secret id>[#<key>]  an AWS Secrets Manager secret, read with the AWS_* environment credentials.
+//	exec://<command> [args...]    the standard output of a command.
+//
+// For the Vault and AWS secrets, the key selects a field of a JSON secret. Vault secrets default to the
+// "password" field.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schemes of the secret references.
+const (
+	VaultScheme = "vault"
+	AWSScheme   = "aws-sm"
+	ExecScheme  = "exec"
+)
+
+const fetchTimeout = 30 * time.Second
+
+var httpClient = &http.Client{Timeout: fetchTimeout}
+
+// Fetch returns the secret referenced by ref, with trailing newlines removed.
+func Fetch(ctx context.Context, ref string) (string, error) {
+	scheme, target, ok := strings.Cut(ref, "://")
+	if !ok || target == "" {
+		return "", errors.Errorf("invalid secret reference %q, want scheme://target", ref)
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	var secret string
+	var err error
+	switch scheme {
+	case VaultScheme:
+		path, key, _ := strings.Cut(target, "#")
+		secret, err = fetchVault(ctx, path, key)
+	case AWSScheme:
+		id, key, _ := strings.Cut(target, "#")
+		secret, err = fetchAWS(ctx, id, key)
+	case ExecScheme:
+		secret, err = fetchExec(ctx, target)
+	default:
+		return "", errors.Errorf("unknown secret scheme %q, want %s, %s or %s", scheme, VaultScheme, AWSScheme, ExecScheme)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "could not fetch %s secret", scheme)
+	}
+	secret = strings.TrimRight(secret, "\r\n")
+	if secret == "" {
+		return "", errors.Errorf("%s secret is empty", scheme)
+	}
+	return secret, nil
+}
+
+// jsonField returns the string field of a JSON object.
+func jsonField(data []byte, key string) (string, error) {
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", errors.Wrap(err, "could not decode secret as a JSON object")
+	}
+	v, ok := fields[key]
+	if !ok {
+		return "", errors.Errorf("secret has no field %q", key)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", errors.Errorf("field %q of secret is not a string", key)
+	}
+	return s, nil
+}
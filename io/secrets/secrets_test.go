@@ -0,0 +1,124 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestFetch_InvalidReference(t *testing.T) {
+	_, err := Fetch(context.Background(), "/path/to/password.txt")
+	assert.ErrorContains(t, "want scheme://target", err)
+	_, err = Fetch(context.Background(), "gcp://project/secret")
+	assert.ErrorContains(t, "unknown secret scheme", err)
+}
+
+func TestFetch_Vault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/validator":
+			_, err := w.Write([]byte(`{"data":{"data":{"password":"kv2pass","other":"x"},"metadata":{"version":1}}}`))
+			require.NoError(t, err)
+		case "/v1/kv/validator":
+			_, err := w.Write([]byte(`{"data":{"wallet":"kv1pass\n"}}`))
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "token")
+	ctx := context.Background()
+
+	secret, err := Fetch(ctx, "vault://secret/data/validator")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2pass", secret)
+	secret, err = Fetch(ctx, "vault://secret/data/validator#other")
+	require.NoError(t, err)
+	assert.Equal(t, "x", secret)
+	secret, err = Fetch(ctx, "vault://kv/validator#wallet")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1pass", secret)
+
+	_, err = Fetch(ctx, "vault://secret/data/validator#missing")
+	assert.ErrorContains(t, "no field \"missing\"", err)
+	_, err = Fetch(ctx, "vault://secret/data/unknown")
+	assert.ErrorContains(t, "status 404", err)
+
+	t.Setenv("VAULT_TOKEN", "")
+	_, err = Fetch(ctx, "vault://secret/data/validator")
+	assert.ErrorContains(t, "VAULT_TOKEN is not set", err)
+}
+
+func TestFetch_AWS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, awsGetSecretCall, r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, true, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		req := make(map[string]string)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var resp interface{}
+		switch req["SecretId"] {
+		case "validator/wallet":
+			resp = map[string]string{"SecretString": "awspass"}
+		case "validator/json":
+			resp = map[string]string{"SecretString": `{"wallet":"jsonpass"}`}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			resp = map[string]string{"__type": "ResourceNotFoundException", "message": "not found"}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "session")
+	ctx := context.Background()
+
+	secret, err := Fetch(ctx, "aws-sm://validator/wallet")
+	require.NoError(t, err)
+	assert.Equal(t, "awspass", secret)
+	secret, err = Fetch(ctx, "aws-sm://validator/json#wallet")
+	require.NoError(t, err)
+	assert.Equal(t, "jsonpass", secret)
+	_, err = Fetch(ctx, "aws-sm://validator/unknown")
+	assert.ErrorContains(t, "ResourceNotFoundException", err)
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// Example of the AWS signature version 4 documentation.
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	creds := awsCredentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWSRequest(req, nil, creds, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", req.Header.Get("Authorization"))
+}
+
+func TestFetch_Exec(t *testing.T) {
+	ctx := context.Background()
+	secret, err := Fetch(ctx, "exec://echo execpass")
+	require.NoError(t, err)
+	assert.Equal(t, "execpass", secret)
+
+	_, err = Fetch(ctx, "exec://false")
+	assert.ErrorContains(t, "command false failed", err)
+	_, err = Fetch(ctx, "exec://true")
+	assert.ErrorContains(t, "secret is empty", err)
+}
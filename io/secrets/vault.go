@@ -0,0 +1,71 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const defaultVaultKey = "password"
+
+// fetchVault reads a field of a secret of a key/value secrets engine of Vault, version 1 or 2.
+func fetchVault(ctx context.Context, path, key string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", errors.New("VAULT_ADDR is not set")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", errors.New("VAULT_TOKEN is not set")
+	}
+	if key == "" {
+		key = defaultVaultKey
+	}
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimRight(addr, "/"), strings.TrimLeft(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("vault responded with status %d for %s", resp.StatusCode, path)
+	}
+	var secret struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", errors.Wrap(err, "could not decode vault response")
+	}
+	// Version 2 of the key/value engine nests the fields of the secret in data.data.
+	var v2 struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(secret.Data, &v2); err == nil && len(v2.Data) > 0 && v2.Data[0] == '{' {
+		if s, err := jsonField(v2.Data, key); err == nil {
+			return s, nil
+		}
+	}
+	return jsonField(secret.Data, key)
+}
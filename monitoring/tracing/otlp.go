@@ -0,0 +1,280 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+const (
+	// Maximum number of spans sent to the collector in a single request.
+	otlpBatchSize = 512
+	// Maximum number of spans waiting to be sent. Spans are dropped when the buffer is full.
+	otlpBufferSize = 10000
+	// Interval at which the buffered spans are sent, even if they don't fill a batch.
+	otlpFlushInterval = 5 * time.Second
+	// Timeout of a request to the collector.
+	otlpRequestTimeout = 10 * time.Second
+)
+
+// otlpExporter sends spans to an OpenTelemetry collector with the OTLP/HTTP protocol, using its
+// JSON encoding.
+type otlpExporter struct {
+	endpoint string
+	client   *http.Client
+	resource *otlpResource
+	spans    chan *trace.SpanData
+}
+
+func newOTLPExporter(endpoint string, resourceAttributes map[string]string) *otlpExporter {
+	resource := &otlpResource{Attributes: make([]*otlpKeyValue, 0, len(resourceAttributes))}
+	for k, v := range resourceAttributes {
+		resource.Attributes = append(resource.Attributes, otlpAttribute(k, v))
+	}
+	return &otlpExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: otlpRequestTimeout},
+		resource: resource,
+		spans:    make(chan *trace.SpanData, otlpBufferSize),
+	}
+}
+
+// ExportSpan buffers the span until the next batch of spans is sent.
+func (e *otlpExporter) ExportSpan(sd *trace.SpanData) {
+	select {
+	case e.spans <- sd:
+	default:
+		log.Debug("OTLP span buffer is full, dropping span")
+	}
+}
+
+// run sends the buffered spans to the collector in batches until the context is done.
+func (e *otlpExporter) run(ctx context.Context) {
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+	batch := make([]*trace.SpanData, 0, otlpBatchSize)
+	for {
+		select {
+		case sd := <-e.spans:
+			batch = append(batch, sd)
+			if len(batch) < otlpBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		case <-ctx.Done():
+			return
+		}
+		if err := e.send(ctx, batch); err != nil {
+			log.WithError(err).Error("Failed to export spans")
+		}
+		batch = batch[:0]
+	}
+}
+
+// send posts the spans to the collector.
+func (e *otlpExporter) send(ctx context.Context, spans []*trace.SpanData) error {
+	req := &otlpRequest{
+		ResourceSpans: []*otlpResourceSpans{{
+			Resource: e.resource,
+			ScopeSpans: []*otlpScopeSpans{{
+				Scope: &otlpScope{Name: "prysm"},
+				Spans: make([]*otlpSpan, len(spans)),
+			}},
+		}},
+	}
+	for i, sd := range spans {
+		req.ResourceSpans[0].ScopeSpans[0].Spans[i] = toOTLPSpan(sd)
+	}
+	enc, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(enc))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("collector responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func toOTLPSpan(sd *trace.SpanData) *otlpSpan {
+	s := &otlpSpan{
+		TraceID:           hex.EncodeToString(sd.TraceID[:]),
+		SpanID:            hex.EncodeToString(sd.SpanID[:]),
+		Name:              sd.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(sd.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(sd.EndTime.UnixNano(), 10),
+		Attributes:        otlpAttributes(sd.Attributes),
+		Status:            &otlpStatus{},
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentSpanID = hex.EncodeToString(sd.ParentSpanID[:])
+	}
+	switch sd.SpanKind {
+	case trace.SpanKindServer:
+		s.Kind = otlpSpanKindServer
+	case trace.SpanKindClient:
+		s.Kind = otlpSpanKindClient
+	}
+	if sd.Status.Code != trace.StatusCodeOK {
+		s.Status.Code = otlpStatusCodeError
+		s.Status.Message = sd.Status.Message
+	}
+	for _, a := range sd.Annotations {
+		s.Events = append(s.Events, &otlpEvent{
+			TimeUnixNano: strconv.FormatInt(a.Time.UnixNano(), 10),
+			Name:         a.Message,
+			Attributes:   otlpAttributes(a.Attributes),
+		})
+	}
+	for _, m := range sd.MessageEvents {
+		name := "message received"
+		if m.EventType == trace.MessageEventTypeSent {
+			name = "message sent"
+		}
+		s.Events = append(s.Events, &otlpEvent{
+			TimeUnixNano: strconv.FormatInt(m.Time.UnixNano(), 10),
+			Name:         name,
+			Attributes: []*otlpKeyValue{
+				otlpAttribute("message.id", m.MessageID),
+				otlpAttribute("message.uncompressed_size", m.UncompressedByteSize),
+			},
+		})
+	}
+	for _, l := range sd.Links {
+		s.Links = append(s.Links, &otlpLink{
+			TraceID:    hex.EncodeToString(l.TraceID[:]),
+			SpanID:     hex.EncodeToString(l.SpanID[:]),
+			Attributes: otlpAttributes(l.Attributes),
+		})
+	}
+	return s
+}
+
+func otlpAttributes(attributes map[string]interface{}) []*otlpKeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	kvs := make([]*otlpKeyValue, 0, len(attributes))
+	for k, v := range attributes {
+		kvs = append(kvs, otlpAttribute(k, v))
+	}
+	return kvs
+}
+
+func otlpAttribute(key string, value interface{}) *otlpKeyValue {
+	kv := &otlpKeyValue{Key: key}
+	switch v := value.(type) {
+	case bool:
+		kv.Value.BoolValue = &v
+	case int64:
+		i := strconv.FormatInt(v, 10)
+		kv.Value.IntValue = &i
+	case float64:
+		kv.Value.DoubleValue = &v
+	case string:
+		kv.Value.StringValue = &v
+	default:
+		str := fmt.Sprint(v)
+		kv.Value.StringValue = &str
+	}
+	return kv
+}
+
+// Span kinds and status codes of the OTLP protocol.
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+	otlpStatusCodeError  = 2
+)
+
+// The types below follow the JSON encoding of the OTLP ExportTraceServiceRequest message.
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   *otlpResource     `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope *otlpScope  `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []*otlpKeyValue `json:"attributes,omitempty"`
+	Events            []*otlpEvent    `json:"events,omitempty"`
+	Links             []*otlpLink     `json:"links,omitempty"`
+	Status            *otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Name         string          `json:"name"`
+	Attributes   []*otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID    string          `json:"traceId"`
+	SpanID     string          `json:"spanId"`
+	Attributes []*otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
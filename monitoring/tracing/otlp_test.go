@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"go.opencensus.io/trace"
+)
+
+func TestOTLPExporter_Send(t *testing.T) {
+	received := &otlpRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+	}))
+	defer srv.Close()
+
+	start := time.Unix(10, 5)
+	span := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		SpanKind:     trace.SpanKindClient,
+		Name:         "blockChain.ReceiveBlock",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"slot": int64(3)},
+		Annotations:  []trace.Annotation{{Time: start, Message: "received"}},
+		Status:       trace.Status{Code: trace.StatusCodeInternal, Message: "failed"},
+	}
+	e := newOTLPExporter(srv.URL, map[string]string{"service.name": "beacon-chain"})
+	require.NoError(t, e.send(context.Background(), []*trace.SpanData{span}))
+
+	require.Equal(t, 1, len(received.ResourceSpans))
+	rs := received.ResourceSpans[0]
+	require.Equal(t, 1, len(rs.Resource.Attributes))
+	assert.Equal(t, "service.name", rs.Resource.Attributes[0].Key)
+	assert.Equal(t, "beacon-chain", *rs.Resource.Attributes[0].Value.StringValue)
+	require.Equal(t, 1, len(rs.ScopeSpans))
+	require.Equal(t, 1, len(rs.ScopeSpans[0].Spans))
+	s := rs.ScopeSpans[0].Spans[0]
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", s.TraceID)
+	assert.Equal(t, "0102030405060708", s.SpanID)
+	assert.Equal(t, "0807060504030201", s.ParentSpanID)
+	assert.Equal(t, "blockChain.ReceiveBlock", s.Name)
+	assert.Equal(t, otlpSpanKindClient, s.Kind)
+	assert.Equal(t, "10000000005", s.StartTimeUnixNano)
+	assert.Equal(t, "11000000005", s.EndTimeUnixNano)
+	require.Equal(t, 1, len(s.Attributes))
+	assert.Equal(t, "slot", s.Attributes[0].Key)
+	assert.Equal(t, "3", *s.Attributes[0].Value.IntValue)
+	require.Equal(t, 1, len(s.Events))
+	assert.Equal(t, "received", s.Events[0].Name)
+	assert.Equal(t, otlpStatusCodeError, s.Status.Code)
+	assert.Equal(t, "failed", s.Status.Message)
+}
+
+func TestOTLPExporter_SendError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	e := newOTLPExporter(srv.URL, nil)
+	err := e.send(context.Background(), []*trace.SpanData{{Name: "span"}})
+	require.ErrorContains(t, "collector responded with status code 400", err)
+}
+
+func TestOTLPExporter_ExportSpanDropsWhenFull(t *testing.T) {
+	e := newOTLPExporter("http://127.0.0.1:4318/v1/traces", nil)
+	for i := 0; i < otlpBufferSize+1; i++ {
+		e.ExportSpan(&trace.SpanData{Name: "span"})
+	}
+	assert.Equal(t, otlpBufferSize, len(e.spans))
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	err := Setup("beacon-chain", "", "http://127.0.0.1:4318/v1/traces", "zipkin", 0.2, true)
+	require.ErrorContains(t, "unknown tracing exporter", err)
+}
@@ -3,7 +3,9 @@
 package tracing
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/prysmaticlabs/prysm/v3/runtime/version"
@@ -13,8 +15,16 @@ import (
 
 var log = logrus.WithField("prefix", "tracing")
 
+// Exporters which traces can be sent with.
+const (
+	// JaegerExporter sends traces to a Jaeger collector.
+	JaegerExporter = "jaeger"
+	// OTLPExporter sends traces to an OpenTelemetry collector with the OTLP/HTTP protocol.
+	OTLPExporter = "otlp"
+)
+
 // Setup creates and initializes a new tracing configuration..
-func Setup(serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
+func Setup(serviceName, processName, endpoint, exporter string, sampleFraction float64, enable bool) error {
 	if !enable {
 		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
 		return nil
@@ -29,8 +39,24 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 		MaxMessageEventsPerSpan: 500,
 	})
 
+	switch exporter {
+	case JaegerExporter, "":
+	case OTLPExporter:
+		log.Infof("Starting OTLP exporter endpoint at address = %s", endpoint)
+		e := newOTLPExporter(endpoint, map[string]string{
+			"service.name": serviceName,
+			"process_name": processName,
+			"version":      version.Version(),
+		})
+		go e.run(context.Background())
+		trace.RegisterExporter(e)
+		return nil
+	default:
+		return fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+
 	log.Infof("Starting Jaeger exporter endpoint at address = %s", endpoint)
-	exporter, err := jaeger.NewExporter(jaeger.Options{
+	jaegerExporter, err := jaeger.NewExporter(jaeger.Options{
 		CollectorEndpoint: endpoint,
 		Process: jaeger.Process{
 			ServiceName: serviceName,
@@ -47,7 +73,7 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 	if err != nil {
 		return err
 	}
-	trace.RegisterExporter(exporter)
+	trace.RegisterExporter(jaegerExporter)
 
 	return nil
 }
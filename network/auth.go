@@ -1,7 +1,10 @@
 package network
 
 import (
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -45,3 +48,73 @@ func (t *jwtTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+tokenString)
 	return t.underlyingTransport.RoundTrip(req)
 }
+
+// Maximum difference between the issued at claim of a JWT token and the current time for the
+// token to be accepted, as in the engine API authentication.
+const jwtIssuedAtDrift = 60 * time.Second
+
+// ErrUnauthorized is returned for a request which doesn't carry a valid JWT bearer token.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ParseJWTSecret decodes a hex encoded JWT secret of at least 32 bytes, as written in the secret
+// files shared with execution nodes.
+func ParseJWTSecret(enc []byte) ([]byte, error) {
+	strData := strings.TrimSpace(string(enc))
+	if len(strData) == 0 {
+		return nil, errors.New("JWT secret cannot be empty")
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strData, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < 32 {
+		return nil, errors.New("JWT secret should be a hex string of at least 32 bytes")
+	}
+	return secret, nil
+}
+
+// AuthorizeRequest checks that the request carries a bearer JWT token signed with the secret,
+// following the engine API authentication scheme: the token is HMAC signed and its issued at
+// claim is within a minute of the current time.
+func AuthorizeRequest(r *http.Request, secret []byte) error {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return errors.Wrap(ErrUnauthorized, "authorization header needs Bearer {token}")
+	}
+	token, err := jwt.Parse(strings.TrimPrefix(authHeader, "Bearer "), func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected JWT signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return errors.Wrapf(ErrUnauthorized, "could not parse JWT token: %v", err)
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return errors.Wrap(ErrUnauthorized, "invalid JWT claims")
+	}
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return errors.Wrap(ErrUnauthorized, "JWT token has no issued at claim")
+	}
+	if drift := time.Since(time.Unix(int64(iat), 0)); drift > jwtIssuedAtDrift || drift < -jwtIssuedAtDrift {
+		return errors.Wrap(ErrUnauthorized, "JWT token must be issued within a minute")
+	}
+	return nil
+}
+
+// WithAuthorization wraps the handler so that it only serves requests authorized with the secret,
+// see AuthorizeRequest.
+func WithAuthorization(secret []byte, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := AuthorizeRequest(r, secret); err != nil {
+			WriteError(w, &DefaultErrorJson{
+				Message: err.Error(),
+				Code:    http.StatusUnauthorized,
+			})
+			return
+		}
+		h(w, r)
+	}
+}
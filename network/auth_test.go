@@ -1,6 +1,7 @@
 package network
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -51,3 +52,47 @@ func TestJWTAuthTransport(t *testing.T) {
 	_, err := client.Get(srv.URL)
 	require.NoError(t, err)
 }
+
+func TestAuthorizeRequest(t *testing.T) {
+	secret := bytesutil.PadTo([]byte("foo"), 32)
+	newRequest := func(key []byte, method jwt.SigningMethod, iat time.Time) *http.Request {
+		token, err := jwt.NewWithClaims(method, jwt.MapClaims{"iat": iat.Unix()}).SignedString(key)
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/admin", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return r
+	}
+
+	require.NoError(t, AuthorizeRequest(newRequest(secret, jwt.SigningMethodHS256, time.Now()), secret))
+	require.ErrorIs(t, AuthorizeRequest(httptest.NewRequest(http.MethodPost, "/admin", nil), secret), ErrUnauthorized)
+	otherSecret := bytesutil.PadTo([]byte("bar"), 32)
+	require.ErrorIs(t, AuthorizeRequest(newRequest(otherSecret, jwt.SigningMethodHS256, time.Now()), secret), ErrUnauthorized)
+	err := AuthorizeRequest(newRequest(secret, jwt.SigningMethodHS256, time.Now().Add(-2*time.Minute)), secret)
+	require.ErrorContains(t, "issued within a minute", err)
+	r := newRequest(secret, jwt.SigningMethodHS256, time.Now())
+	r.Header.Set("Authorization", strings.Replace(r.Header.Get("Authorization"), "Bearer", "Basic", 1))
+	require.ErrorIs(t, AuthorizeRequest(r, secret), ErrUnauthorized)
+
+	called := false
+	h := WithAuthorization(secret, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	rr := httptest.NewRecorder()
+	h(rr, newRequest(otherSecret, jwt.SigningMethodHS256, time.Now()))
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+	require.Equal(t, false, called)
+	rr = httptest.NewRecorder()
+	h(rr, newRequest(secret, jwt.SigningMethodHS256, time.Now()))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, true, called)
+}
+
+func TestParseJWTSecret(t *testing.T) {
+	secret, err := ParseJWTSecret([]byte(" 0x" + strings.Repeat("ab", 32) + "\n"))
+	require.NoError(t, err)
+	require.DeepEqual(t, bytes.Repeat([]byte{0xab}, 32), secret)
+	_, err = ParseJWTSecret([]byte(""))
+	require.ErrorContains(t, "cannot be empty", err)
+	_, err = ParseJWTSecret([]byte("abab"))
+	require.ErrorContains(t, "at least 32 bytes", err)
+}
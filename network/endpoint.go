@@ -34,6 +34,15 @@ func (e Endpoint) HttpClient() *http.Client {
 	return NewHttpClientWithSecret(e.Auth.Value)
 }
 
+// HttpClientWithTransport creates a http client object dependant on the properties
+// of the network endpoint, which sends its requests through the given transport.
+func (e Endpoint) HttpClientWithTransport(transport http.RoundTripper) *http.Client {
+	if e.Auth.Method != authorization.Bearer {
+		return &http.Client{Transport: transport}
+	}
+	return newHttpClientWithSecret(e.Auth.Value, transport)
+}
+
 // Equals compares two authorization data objects for equality.
 func (d AuthorizationData) Equals(other AuthorizationData) bool {
 	return d.Method == other.Method && d.Value == other.Value
@@ -67,8 +76,12 @@ func Method(auth string) authorization.AuthorizationMethod {
 // NewHttpClientWithSecret returns a http client that utilizes
 // jwt authentication.
 func NewHttpClientWithSecret(secret string) *http.Client {
+	return newHttpClientWithSecret(secret, http.DefaultTransport)
+}
+
+func newHttpClientWithSecret(secret string, transport http.RoundTripper) *http.Client {
 	authTransport := &jwtTransport{
-		underlyingTransport: http.DefaultTransport,
+		underlyingTransport: transport,
 		jwtSecret:           []byte(secret),
 	}
 	return &http.Client{
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v3/network/authorization"
@@ -140,3 +141,28 @@ func TestAuthorizationDataEquals(t *testing.T) {
 		assert.Equal(t, false, d.Equals(other))
 	})
 }
+
+func TestEndpointHttpClientWithTransport(t *testing.T) {
+	transport := NewTransport(DefaultTransportConfig())
+
+	t.Run("no bearer", func(t *testing.T) {
+		e := Endpoint{Url: "Url"}
+		c := e.HttpClientWithTransport(transport)
+		assert.Equal(t, http.RoundTripper(transport), c.Transport)
+	})
+	t.Run("bearer", func(t *testing.T) {
+		e := Endpoint{
+			Url: "Url",
+			Auth: AuthorizationData{
+				Method: authorization.Bearer,
+				Value:  "secret",
+			},
+		}
+		c := e.HttpClientWithTransport(transport)
+		assert.Equal(t, DefaultRPCHTTPTimeout, c.Timeout)
+		jwt, ok := c.Transport.(*jwtTransport)
+		require.Equal(t, true, ok)
+		assert.Equal(t, http.RoundTripper(transport), jwt.underlyingTransport)
+		assert.DeepEqual(t, []byte("secret"), jwt.jwtSecret)
+	})
+}
@@ -0,0 +1,46 @@
+package network
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// TransportConfig tunes the connection pooling of the HTTP transport used to talk to an execution node.
+type TransportConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept open to the node.
+	MaxIdleConns int
+	// KeepAlive is the interval between TCP keep-alive probes of open connections.
+	KeepAlive time.Duration
+	// IdleConnTimeout is how long an idle connection is kept open before being closed.
+	IdleConnTimeout time.Duration
+}
+
+// DefaultTransportConfig returns the default connection pooling of execution node transports.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:    64,
+		KeepAlive:       30 * time.Second,
+		IdleConnTimeout: 90 * time.Second,
+	}
+}
+
+// NewTransport returns an HTTP/2 capable transport which keeps up to cfg.MaxIdleConns connections
+// open to the node it talks to. The default transport of the standard library only keeps 2 idle
+// connections per host, so connections are constantly opened and closed under high call rates.
+func NewTransport(cfg TransportConfig) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: cfg.KeepAlive,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConns,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
@@ -0,0 +1,20 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+)
+
+func TestNewTransport(t *testing.T) {
+	transport := NewTransport(TransportConfig{
+		MaxIdleConns:    10,
+		KeepAlive:       time.Minute,
+		IdleConnTimeout: 2 * time.Minute,
+	})
+	assert.Equal(t, true, transport.ForceAttemptHTTP2)
+	assert.Equal(t, 10, transport.MaxIdleConns)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 2*time.Minute, transport.IdleConnTimeout)
+}
@@ -0,0 +1,34 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestWriteJson(t *testing.T) {
+	type response struct {
+		Foo string `json:"foo"`
+	}
+	w := httptest.NewRecorder()
+	WriteJson(w, &response{Foo: "bar"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	resp := &response{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, "bar", resp.Foo)
+}
+
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, &DefaultErrorJson{Message: "not found", Code: http.StatusNotFound})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	e := &DefaultErrorJson{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), e))
+	assert.Equal(t, "not found", e.Message)
+	assert.Equal(t, http.StatusNotFound, e.Code)
+}
@@ -0,0 +1,192 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultProfileDuration is the duration of a profile when the request doesn't specify one.
+const defaultProfileDuration = 30 * time.Second
+
+// ProfileStatusResponse is the response of the endpoint starting a profile.
+type ProfileStatusResponse struct {
+	Data *ProfileStatus `json:"data"`
+}
+
+// ProfileStatus describes a profile being collected.
+type ProfileStatus struct {
+	Kind       string `json:"kind"`
+	InProgress bool   `json:"in_progress"`
+	Duration   string `json:"duration"`
+}
+
+// RuntimeSettingsResponse is the response of the endpoints reading and tuning the runtime settings.
+type RuntimeSettingsResponse struct {
+	Data *RuntimeSettings `json:"data"`
+}
+
+// RuntimeSettings are the runtime settings which can be tuned while the process runs. The memory
+// limit is empty when the runtime doesn't support it, and a limit of 0 removes it when tuning.
+type RuntimeSettings struct {
+	GCPercent   string `json:"gc_percent,omitempty"`
+	MemoryLimit string `json:"memory_limit,omitempty"`
+}
+
+// AdminHandlers serves the admin endpoints profiling the process and tuning its runtime. They
+// don't authenticate requests, which is left to the API registering them. The profile kind is
+// read from the kind path variable.
+type AdminHandlers struct {
+	ctx      context.Context
+	profiler *Profiler
+}
+
+// NewAdminHandlers creates the admin handlers collecting profiles with the profiler. Profiles in
+// progress are collected early when the context is done.
+func NewAdminHandlers(ctx context.Context, profiler *Profiler) *AdminHandlers {
+	return &AdminHandlers{
+		ctx:      ctx,
+		profiler: profiler,
+	}
+}
+
+// StartProfile starts collecting a CPU, heap or mutex profile for the duration given by the
+// duration query parameter, which is bounded by MaxProfileDuration and a whole number of seconds
+// for a mutex profile. The profile is fetched once collected.
+func (h *AdminHandlers) StartProfile(w http.ResponseWriter, r *http.Request) {
+	kind := mux.Vars(r)["kind"]
+	duration := defaultProfileDuration
+	if raw := r.URL.Query().Get("duration"); raw != "" {
+		var err error
+		duration, err = time.ParseDuration(raw)
+		if err != nil {
+			writeBadRequest(w, fmt.Errorf("invalid duration: %w", err))
+			return
+		}
+	}
+	if err := h.profiler.Start(h.ctx, kind, duration); err != nil {
+		code := http.StatusConflict
+		if errors.Is(err, ErrUnknownProfile) || errors.Is(err, ErrInvalidProfileDuration) {
+			code = http.StatusBadRequest
+		}
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("could not start profile: %v", err),
+			Code:    code,
+		})
+		return
+	}
+	network.WriteJson(w, &ProfileStatusResponse{Data: &ProfileStatus{
+		Kind:       kind,
+		InProgress: true,
+		Duration:   duration.String(),
+	}})
+}
+
+// GetProfile returns the latest profile of the kind which was collected, in the pprof format.
+func (h *AdminHandlers) GetProfile(w http.ResponseWriter, r *http.Request) {
+	kind := mux.Vars(r)["kind"]
+	profile, ok := h.profiler.Profile(kind)
+	if !ok {
+		message := fmt.Sprintf("no %s profile was collected", kind)
+		if h.profiler.InProgress(kind) {
+			message = fmt.Sprintf("%s profile is in progress", kind)
+		}
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: message,
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%d.pprof", kind, profile.Start.Unix())))
+	if _, err := w.Write(profile.Data); err != nil {
+		log.WithError(err).Error("Could not write profile")
+	}
+}
+
+// GetRuntimeSettings returns the runtime settings of the process.
+func (*AdminHandlers) GetRuntimeSettings(w http.ResponseWriter, _ *http.Request) {
+	network.WriteJson(w, &RuntimeSettingsResponse{Data: runtimeSettings()})
+}
+
+// TuneRuntimeSettings sets the garbage collection target percentage and the soft memory limit
+// provided in the request, within the bounds checked by CheckGCPercent and CheckMemoryLimit, and
+// returns the resulting runtime settings.
+func (*AdminHandlers) TuneRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	req := &RuntimeSettings{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeBadRequest(w, fmt.Errorf("could not decode request body: %w", err))
+		return
+	}
+	var gcPercent, memoryLimit int64
+	var err error
+	if req.GCPercent != "" {
+		if gcPercent, err = strconv.ParseInt(req.GCPercent, 10, 32); err != nil {
+			writeBadRequest(w, fmt.Errorf("invalid GC percent: %w", err))
+			return
+		}
+		if err := CheckGCPercent(int(gcPercent)); err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+	}
+	if req.MemoryLimit != "" {
+		if memoryLimit, err = strconv.ParseInt(req.MemoryLimit, 10, 64); err != nil {
+			writeBadRequest(w, fmt.Errorf("invalid memory limit: %w", err))
+			return
+		}
+		if err := CheckMemoryLimit(memoryLimit); err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+	}
+
+	// Both settings were checked before changing any, the memory limit only failing to change when
+	// the runtime doesn't support it.
+	if req.MemoryLimit != "" {
+		previous, err := TuneMemoryLimit(memoryLimit)
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		log.WithFields(log.Fields{
+			"previous": previous,
+			"limit":    memoryLimit,
+		}).Warn("Memory limit of the runtime changed")
+	}
+	if req.GCPercent != "" {
+		previous, err := TuneGCPercent(int(gcPercent))
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		log.WithFields(log.Fields{
+			"previous": previous,
+			"percent":  gcPercent,
+		}).Warn("GC percent of the runtime changed")
+	}
+	network.WriteJson(w, &RuntimeSettingsResponse{Data: runtimeSettings()})
+}
+
+func runtimeSettings() *RuntimeSettings {
+	settings := &RuntimeSettings{GCPercent: strconv.Itoa(GCPercent())}
+	if limit, err := MemoryLimit(); err == nil {
+		settings.MemoryLimit = strconv.FormatInt(limit, 10)
+	}
+	return settings
+}
+
+func writeBadRequest(w http.ResponseWriter, err error) {
+	network.WriteError(w, &network.DefaultErrorJson{
+		Message: err.Error(),
+		Code:    http.StatusBadRequest,
+	})
+}
@@ -0,0 +1,55 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestAdminHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := NewAdminHandlers(ctx, NewProfiler())
+	router := mux.NewRouter()
+	router.HandleFunc("/profiles/{kind}", h.StartProfile).Methods(http.MethodPost)
+	router.HandleFunc("/profiles/{kind}", h.GetProfile).Methods(http.MethodGet)
+	router.HandleFunc("/runtime", h.GetRuntimeSettings).Methods(http.MethodGet)
+	router.HandleFunc("/runtime", h.TuneRuntimeSettings).Methods(http.MethodPost)
+	serve := func(method, url, body string) *httptest.ResponseRecorder {
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequest(method, "http://example.com"+url, strings.NewReader(body)))
+		return writer
+	}
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/profiles/goroutine", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/profiles/heap", "").Code)
+	writer := serve(http.MethodPost, "/profiles/heap?duration=10ms", "")
+	require.Equal(t, http.StatusOK, writer.Code)
+	waitForProfile(t, h.profiler, ProfileHeap)
+	writer = serve(http.MethodGet, "/profiles/heap", "")
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.NotEqual(t, 0, writer.Body.Len())
+
+	previous := GCPercent()
+	defer func() {
+		_, err := TuneGCPercent(previous)
+		require.NoError(t, err)
+	}()
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/runtime", `{"gc_percent":"-1"}`).Code)
+	writer = serve(http.MethodPost, "/runtime", `{"gc_percent":"150"}`)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &RuntimeSettingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "150", resp.Data.GCPercent)
+	writer = serve(http.MethodGet, "/runtime", "")
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "150", resp.Data.GCPercent)
+}
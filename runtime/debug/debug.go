@@ -130,23 +130,31 @@ func (h *HandlerT) CPUProfile(file string, nsec uint) error {
 
 // StartCPUProfile turns on CPU profiling, writing to the given file.
 func (h *HandlerT) StartCPUProfile(file string) error {
+	return h.startCPUProfile(file, func() (io.WriteCloser, error) {
+		return os.Create(expandHome(file))
+	})
+}
+
+// startCPUProfile turns on CPU profiling, writing to the writer opened once no other CPU
+// profile is in progress. The dump names the destination of the profile in logs.
+func (h *HandlerT) startCPUProfile(dump string, open func() (io.WriteCloser, error)) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	if h.cpuW != nil {
 		return errors.New("CPU profiling already in progress")
 	}
-	f, err := os.Create(expandHome(file))
+	w, err := open()
 	if err != nil {
 		return err
 	}
-	if err := pprof.StartCPUProfile(f); err != nil {
-		if err := f.Close(); err != nil {
+	if err := pprof.StartCPUProfile(w); err != nil {
+		if err := w.Close(); err != nil {
 			log.WithError(err).Error("Failed to close file")
 		}
 		return err
 	}
-	h.cpuW = f
-	h.cpuFile = file
+	h.cpuW = w
+	h.cpuFile = dump
 	log.Info("CPU profiling started", " dump ", h.cpuFile)
 	return nil
 }
@@ -283,7 +291,7 @@ func (*HandlerT) FreeOSMemory() {
 // SetGCPercent sets the garbage collection target percentage. It returns the previous
 // setting. A negative value disables GC.
 func (*HandlerT) SetGCPercent(v int) int {
-	return debug.SetGCPercent(v)
+	return setGCPercent(v)
 }
 
 func writeProfile(name, file string) error {
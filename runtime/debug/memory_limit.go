@@ -0,0 +1,10 @@
+//go:build go1.19
+
+package debug
+
+import "runtime/debug"
+
+// setMemoryLimit sets the soft memory limit of the runtime, a negative limit only reading it.
+func setMemoryLimit(limit int64) (int64, error) {
+	return debug.SetMemoryLimit(limit), nil
+}
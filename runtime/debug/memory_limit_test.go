@@ -0,0 +1,33 @@
+//go:build go1.19
+
+package debug
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestTuneMemoryLimit(t *testing.T) {
+	initial, err := MemoryLimit()
+	require.NoError(t, err)
+	defer func() {
+		_, err := TuneMemoryLimit(initial)
+		require.NoError(t, err)
+	}()
+
+	previous, err := TuneMemoryLimit(2 * MinMemoryLimit)
+	require.NoError(t, err)
+	assert.Equal(t, initial, previous)
+	limit, err := MemoryLimit()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*MinMemoryLimit), limit)
+
+	_, err = TuneMemoryLimit(0)
+	require.NoError(t, err)
+	limit, err = MemoryLimit()
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), limit)
+}
@@ -0,0 +1,10 @@
+//go:build !go1.19
+
+package debug
+
+import "errors"
+
+// setMemoryLimit fails as the soft memory limit of the runtime is only available from Go 1.19.
+func setMemoryLimit(_ int64) (int64, error) {
+	return 0, errors.New("the memory limit requires Go 1.19 or later")
+}
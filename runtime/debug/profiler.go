@@ -0,0 +1,199 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	httppprof "net/http/pprof"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Kinds of profiles collected by the Profiler.
+const (
+	ProfileCPU   = "cpu"
+	ProfileHeap  = "heap"
+	ProfileMutex = "mutex"
+)
+
+// MaxProfileDuration bounds the duration of a profile, so that a profile which is never stopped
+// doesn't slow the process down indefinitely.
+const MaxProfileDuration = 5 * time.Minute
+
+var (
+	// ErrUnknownProfile is returned for a kind of profile which is not collected by the Profiler.
+	ErrUnknownProfile = errors.New("unknown profile")
+	// ErrProfileInProgress is returned when a profile of the same kind is already being collected.
+	ErrProfileInProgress = errors.New("profile already in progress")
+	// ErrInvalidProfileDuration is returned for a duration which is not positive or over MaxProfileDuration,
+	// or which is not a whole number of seconds for a mutex profile.
+	ErrInvalidProfileDuration = errors.New("invalid profile duration")
+)
+
+// Profile is a profile of the process in the pprof format.
+type Profile struct {
+	Kind     string
+	Start    time.Time
+	Duration time.Duration
+	Data     []byte
+}
+
+// Profiler collects profiles of the process on demand for a bounded duration, one of each kind at
+// a time, and keeps the latest profile of each kind until it is fetched or replaced. The CPU
+// profile samples the CPU usage during the duration through the debug handler, so that it can't
+// overlap with a CPU profile started by flag. The mutex profile is the contention recorded during
+// the duration, while mutex profiling is enabled, and the heap profile is the allocations sampled
+// up to the end of the duration.
+type Profiler struct {
+	handler  *HandlerT
+	lock     sync.Mutex
+	running  map[string]time.Time
+	profiles map[string]*Profile
+}
+
+// NewProfiler creates a profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{
+		handler:  Handler,
+		running:  make(map[string]time.Time),
+		profiles: make(map[string]*Profile),
+	}
+}
+
+// Start starts collecting a profile of the kind in the background. The profile is collected
+// until the duration elapses or the context is canceled, a canceled mutex profile being dropped.
+func (p *Profiler) Start(ctx context.Context, kind string, duration time.Duration) error {
+	if duration <= 0 || duration > MaxProfileDuration {
+		return fmt.Errorf("%w: %s is not within (0, %s]", ErrInvalidProfileDuration, duration, MaxProfileDuration)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.running[kind]; ok {
+		return ErrProfileInProgress
+	}
+
+	var collect func() ([]byte, error)
+	switch kind {
+	case ProfileCPU:
+		buf := new(bytes.Buffer)
+		// This fails when the CPU is already profiled, for instance with the cpuprofile flag.
+		if err := p.handler.startCPUProfile("profiler", func() (io.WriteCloser, error) {
+			return nopWriteCloser{buf}, nil
+		}); err != nil {
+			return err
+		}
+		collect = func() ([]byte, error) {
+			waitProfileDuration(ctx, duration)
+			if err := p.handler.StopCPUProfile(); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		}
+	case ProfileHeap:
+		collect = func() ([]byte, error) {
+			waitProfileDuration(ctx, duration)
+			return lookupProfile("heap")
+		}
+	case ProfileMutex:
+		if duration%time.Second != 0 {
+			return fmt.Errorf("%w: mutex profile duration %s is not a whole number of seconds", ErrInvalidProfileDuration, duration)
+		}
+		fraction := runtime.SetMutexProfileFraction(1)
+		collect = func() ([]byte, error) {
+			defer runtime.SetMutexProfileFraction(fraction)
+			return deltaProfile(ctx, "mutex", duration)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownProfile, kind)
+	}
+
+	start := time.Now()
+	p.running[kind] = start
+	log.WithField("profile", kind).WithField("duration", duration).Info("Profiling started")
+	go func() {
+		data, err := collect()
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		delete(p.running, kind)
+		if err != nil {
+			log.WithError(err).WithField("profile", kind).Error("Could not collect profile")
+			return
+		}
+		p.profiles[kind] = &Profile{
+			Kind:     kind,
+			Start:    start,
+			Duration: time.Since(start),
+			Data:     data,
+		}
+		log.WithField("profile", kind).Info("Profiling done")
+	}()
+	return nil
+}
+
+// InProgress tells whether a profile of the kind is being collected.
+func (p *Profiler) InProgress(kind string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, ok := p.running[kind]
+	return ok
+}
+
+// Profile returns the latest profile of the kind which was collected.
+func (p *Profiler) Profile(kind string) (*Profile, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	profile, ok := p.profiles[kind]
+	return profile, ok
+}
+
+// Waits until the duration elapses or the context is canceled.
+func waitProfileDuration(ctx context.Context, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+func lookupProfile(name string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := pprof.Lookup(name).WriteTo(buf, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// deltaProfile returns the difference of the cumulative profile with the given name over the
+// duration, in whole seconds, as served by the pprof handler.
+func deltaProfile(ctx context.Context, name string, duration time.Duration) ([]byte, error) {
+	seconds := strconv.FormatInt(int64(duration/time.Second), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/debug/pprof/"+name+"?seconds="+seconds, nil)
+	if err != nil {
+		return nil, err
+	}
+	rec := httptest.NewRecorder()
+	httppprof.Handler(name).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		return nil, fmt.Errorf("could not collect %s profile: %s", name, bytes.TrimSpace(rec.Body.Bytes()))
+	}
+	return rec.Body.Bytes(), nil
+}
+
+// nopWriteCloser is a writer with a no-op Close method.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+// waitForProfile waits for the profile of the kind to be collected.
+func waitForProfile(t *testing.T, p *Profiler, kind string) *Profile {
+	deadline := time.Now().Add(5 * time.Second)
+	for p.InProgress(kind) {
+		require.Equal(t, true, time.Now().Before(deadline), "profile was not collected in time")
+		time.Sleep(10 * time.Millisecond)
+	}
+	profile, ok := p.Profile(kind)
+	require.Equal(t, true, ok)
+	return profile
+}
+
+func TestProfiler_Start(t *testing.T) {
+	p := NewProfiler()
+	ctx := context.Background()
+	require.ErrorIs(t, p.Start(ctx, ProfileHeap, 0), ErrInvalidProfileDuration)
+	require.ErrorIs(t, p.Start(ctx, ProfileHeap, MaxProfileDuration+time.Second), ErrInvalidProfileDuration)
+	require.ErrorIs(t, p.Start(ctx, "goroutine", time.Second), ErrUnknownProfile)
+	_, ok := p.Profile(ProfileHeap)
+	assert.Equal(t, false, ok)
+
+	require.ErrorIs(t, p.Start(ctx, ProfileMutex, 1500*time.Millisecond), ErrInvalidProfileDuration)
+
+	for kind, duration := range map[string]time.Duration{ProfileCPU: 50 * time.Millisecond, ProfileMutex: time.Second} {
+		require.NoError(t, p.Start(ctx, kind, duration))
+		profile := waitForProfile(t, p, kind)
+		assert.Equal(t, kind, profile.Kind)
+		assert.NotEqual(t, 0, len(profile.Data))
+		assert.Equal(t, true, profile.Duration >= duration)
+	}
+
+	// The CPU can't be profiled while it is profiled through the debug handler.
+	require.NoError(t, Handler.StartCPUProfile(filepath.Join(t.TempDir(), "cpu.pprof")))
+	assert.ErrorContains(t, "CPU profiling already in progress", p.Start(ctx, ProfileCPU, time.Second))
+	require.NoError(t, Handler.StopCPUProfile())
+
+	// Canceling the context stops the profile before the end of its duration.
+	ctx, cancel := context.WithCancel(ctx)
+	require.NoError(t, p.Start(ctx, ProfileHeap, MaxProfileDuration))
+	assert.Equal(t, true, p.InProgress(ProfileHeap))
+	require.ErrorIs(t, p.Start(ctx, ProfileHeap, time.Second), ErrProfileInProgress)
+	cancel()
+	profile := waitForProfile(t, p, ProfileHeap)
+	assert.NotEqual(t, 0, len(profile.Data))
+	assert.Equal(t, true, profile.Duration < MaxProfileDuration)
+}
+
+func TestTuneGCPercent(t *testing.T) {
+	initial := GCPercent()
+	defer func() {
+		_, err := TuneGCPercent(initial)
+		require.NoError(t, err)
+	}()
+
+	_, err := TuneGCPercent(MinGCPercent - 1)
+	assert.ErrorContains(t, "is not within", err)
+	_, err = TuneGCPercent(-1)
+	assert.ErrorContains(t, "is not within", err)
+	_, err = TuneGCPercent(MaxGCPercent + 1)
+	assert.ErrorContains(t, "is not within", err)
+
+	previous, err := TuneGCPercent(150)
+	require.NoError(t, err)
+	assert.Equal(t, initial, previous)
+	assert.Equal(t, 150, GCPercent())
+	assert.Equal(t, 150, Handler.SetGCPercent(200))
+	assert.Equal(t, 200, GCPercent())
+
+	_, err = TuneMemoryLimit(MinMemoryLimit - 1)
+	assert.ErrorContains(t, "lower than the minimum", err)
+}
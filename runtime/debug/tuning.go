@@ -0,0 +1,99 @@
+package debug
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"sync"
+)
+
+// Bounds of the runtime settings which can be tuned while the process runs, so that garbage
+// collection can neither be disabled nor made to run continuously.
+const (
+	MinGCPercent = 10
+	MaxGCPercent = 1000
+	// MinMemoryLimit is the lowest soft memory limit, in bytes.
+	MinMemoryLimit = 1 << 30
+)
+
+// The garbage collection target percentage last set through this package, as the runtime can
+// only report it by setting it.
+var gcPercent = struct {
+	sync.Mutex
+	value int
+}{value: gcPercentFromEnv()}
+
+// gcPercentFromEnv returns the garbage collection target percentage the runtime starts with, as set
+// by GOGC, -1 meaning that garbage collection is disabled.
+func gcPercentFromEnv() int {
+	env := os.Getenv("GOGC")
+	if env == "off" {
+		return -1
+	}
+	if percent, err := strconv.Atoi(env); err == nil {
+		return percent
+	}
+	return 100
+}
+
+// setGCPercent sets the garbage collection target percentage and returns the previous setting.
+func setGCPercent(percent int) int {
+	gcPercent.Lock()
+	defer gcPercent.Unlock()
+	previous := debug.SetGCPercent(percent)
+	gcPercent.value = percent
+	return previous
+}
+
+// GCPercent returns the garbage collection target percentage, as set by GOGC or last set through
+// this package.
+func GCPercent() int {
+	gcPercent.Lock()
+	defer gcPercent.Unlock()
+	return gcPercent.value
+}
+
+// CheckGCPercent returns an error when the garbage collection target percentage is out of bounds.
+func CheckGCPercent(percent int) error {
+	if percent < MinGCPercent || percent > MaxGCPercent {
+		return fmt.Errorf("GC percent %d is not within [%d, %d]", percent, MinGCPercent, MaxGCPercent)
+	}
+	return nil
+}
+
+// TuneGCPercent sets the garbage collection target percentage within the bounds and returns the
+// previous setting.
+func TuneGCPercent(percent int) (int, error) {
+	if err := CheckGCPercent(percent); err != nil {
+		return 0, err
+	}
+	return setGCPercent(percent), nil
+}
+
+// CheckMemoryLimit returns an error when the soft memory limit is below the minimum, a limit of 0
+// standing for no limit.
+func CheckMemoryLimit(limit int64) error {
+	if limit != 0 && limit < MinMemoryLimit {
+		return fmt.Errorf("memory limit %d is lower than the minimum of %d bytes", limit, MinMemoryLimit)
+	}
+	return nil
+}
+
+// TuneMemoryLimit sets the soft memory limit of the runtime in bytes, as GOMEMLIMIT does, and
+// returns the previous setting. A limit of 0 removes the limit.
+func TuneMemoryLimit(limit int64) (int64, error) {
+	if err := CheckMemoryLimit(limit); err != nil {
+		return 0, err
+	}
+	if limit == 0 {
+		limit = math.MaxInt64
+	}
+	return setMemoryLimit(limit)
+}
+
+// MemoryLimit returns the soft memory limit of the runtime in bytes, math.MaxInt64 meaning no limit.
+func MemoryLimit() (int64, error) {
+	return setMemoryLimit(-1)
+}
@@ -0,0 +1,110 @@
+package interop
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/execution"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/v1"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/encoding/ssz"
+	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+)
+
+// GenerateGenesisStateForVersion deterministically generates a genesis state of the fork version v,
+// with numValidators validators of deterministic keys. The genesis phase0 state is upgraded to the
+// fork, and its fork is set as if the chain had started at it. The execution payload header, the
+// header of the genesis block of the execution chain, is required for Bellatrix genesis states.
+func GenerateGenesisStateForVersion(
+	ctx context.Context, genesisTime, numValidators uint64, v int, header *enginev1.ExecutionPayloadHeader,
+) (state.BeaconState, error) {
+	var forkVersion []byte
+	switch v {
+	case version.Phase0:
+		forkVersion = params.BeaconConfig().GenesisForkVersion
+	case version.Altair:
+		forkVersion = params.BeaconConfig().AltairForkVersion
+	case version.Bellatrix:
+		if header == nil {
+			return nil, errors.New("bellatrix genesis state needs an execution payload header")
+		}
+		forkVersion = params.BeaconConfig().BellatrixForkVersion
+	default:
+		return nil, errors.Errorf("unsupported genesis state version %s", version.String(v))
+	}
+
+	pbState, _, err := GenerateGenesisState(ctx, genesisTime, numValidators)
+	if err != nil {
+		return nil, err
+	}
+	st, err := v1.InitializeFromProto(pbState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize genesis state")
+	}
+	if v >= version.Altair {
+		st, err = altair.UpgradeToAltair(ctx, st)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not upgrade genesis state to altair")
+		}
+	}
+	if v >= version.Bellatrix {
+		st, err = execution.UpgradeToBellatrix(st)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not upgrade genesis state to bellatrix")
+		}
+		wrapped, err := blocks.WrappedExecutionPayloadHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if err := st.SetLatestExecutionPayloadHeader(wrapped); err != nil {
+			return nil, errors.Wrap(err, "could not set execution payload header")
+		}
+	}
+	if err := st.SetFork(&ethpb.Fork{
+		PreviousVersion: forkVersion,
+		CurrentVersion:  forkVersion,
+		Epoch:           0,
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not set fork of genesis state")
+	}
+	return st, nil
+}
+
+// ExecutionPayloadHeaderFromGenesis returns the execution payload header of the genesis block of
+// an execution chain, given its genesis.json specification.
+func ExecutionPayloadHeaderFromGenesis(g *core.Genesis) (*enginev1.ExecutionPayloadHeader, error) {
+	block := g.ToBlock(nil)
+	txRoot, err := ssz.TransactionsRoot([][]byte{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute transactions root")
+	}
+	baseFee := block.BaseFee()
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+	return &enginev1.ExecutionPayloadHeader{
+		ParentHash:       block.ParentHash().Bytes(),
+		FeeRecipient:     block.Coinbase().Bytes(),
+		StateRoot:        block.Root().Bytes(),
+		ReceiptsRoot:     block.ReceiptHash().Bytes(),
+		LogsBloom:        block.Bloom().Bytes(),
+		PrevRandao:       block.MixDigest().Bytes(),
+		BlockNumber:      block.NumberU64(),
+		GasLimit:         block.GasLimit(),
+		GasUsed:          block.GasUsed(),
+		Timestamp:        block.Time(),
+		ExtraData:        block.Extra(),
+		BaseFeePerGas:    bytesutil.PadTo(bytesutil.ReverseByteOrder(baseFee.Bytes()), fieldparams.RootLength),
+		BlockHash:        block.Hash().Bytes(),
+		TransactionsRoot: txRoot[:],
+	}, nil
+}
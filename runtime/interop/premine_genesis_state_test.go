@@ -0,0 +1,82 @@
+package interop_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	gethparams "github.com/ethereum/go-ethereum/params"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	enginev1 "github.com/prysmaticlabs/prysm/v3/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestGenerateGenesisStateForVersion(t *testing.T) {
+	ctx := context.Background()
+	header := &enginev1.ExecutionPayloadHeader{
+		ParentHash:       make([]byte, 32),
+		FeeRecipient:     make([]byte, 20),
+		StateRoot:        make([]byte, 32),
+		ReceiptsRoot:     make([]byte, 32),
+		LogsBloom:        make([]byte, 256),
+		PrevRandao:       make([]byte, 32),
+		BaseFeePerGas:    make([]byte, 32),
+		BlockHash:        common.HexToHash("0x01").Bytes(),
+		TransactionsRoot: make([]byte, 32),
+	}
+	cfg := params.BeaconConfig()
+	for v, forkVersion := range map[int][]byte{
+		version.Phase0:    cfg.GenesisForkVersion,
+		version.Altair:    cfg.AltairForkVersion,
+		version.Bellatrix: cfg.BellatrixForkVersion,
+	} {
+		t.Run(version.String(v), func(t *testing.T) {
+			st, err := interop.GenerateGenesisStateForVersion(ctx, 10, 16, v, header)
+			require.NoError(t, err)
+			assert.Equal(t, v, st.Version())
+			assert.Equal(t, 16, st.NumValidators())
+			assert.Equal(t, uint64(10), st.GenesisTime())
+			assert.DeepEqual(t, forkVersion, st.Fork().PreviousVersion)
+			assert.DeepEqual(t, forkVersion, st.Fork().CurrentVersion)
+			if v == version.Bellatrix {
+				got, err := st.LatestExecutionPayloadHeader()
+				require.NoError(t, err)
+				assert.DeepEqual(t, header.BlockHash, got.BlockHash)
+			}
+		})
+	}
+
+	_, err := interop.GenerateGenesisStateForVersion(ctx, 10, 16, version.Bellatrix, nil)
+	assert.ErrorContains(t, "needs an execution payload header", err)
+}
+
+func TestExecutionPayloadHeaderFromGenesis(t *testing.T) {
+	g := &core.Genesis{
+		Config:     gethparams.AllEthashProtocolChanges,
+		Timestamp:  1234,
+		ExtraData:  []byte("devnet"),
+		GasLimit:   30000000,
+		Difficulty: big.NewInt(1),
+		BaseFee:    big.NewInt(7),
+		Alloc: core.GenesisAlloc{
+			common.HexToAddress("0x0000000000000000000000000000000000000001"): {Balance: big.NewInt(1)},
+		},
+	}
+	header, err := interop.ExecutionPayloadHeaderFromGenesis(g)
+	require.NoError(t, err)
+	block := g.ToBlock(nil)
+	assert.DeepEqual(t, block.Hash().Bytes(), header.BlockHash)
+	assert.DeepEqual(t, block.Root().Bytes(), header.StateRoot)
+	assert.Equal(t, uint64(0), header.BlockNumber)
+	assert.Equal(t, uint64(1234), header.Timestamp)
+	assert.Equal(t, uint64(30000000), header.GasLimit)
+	assert.DeepEqual(t, []byte("devnet"), header.ExtraData)
+	assert.Equal(t, 32, len(header.BaseFeePerGas))
+	assert.Equal(t, byte(7), header.BaseFeePerGas[0])
+	assert.Equal(t, 32, len(header.TransactionsRoot))
+}
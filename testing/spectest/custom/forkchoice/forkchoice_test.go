@@ -0,0 +1,33 @@
+package forkchoice
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/testing/spectest/shared/common/forkchoice"
+)
+
+var (
+	vectorsDir = flag.String("vectors", "", "Absolute path to a fork choice test case, or to a directory of test cases")
+	config     = flag.String("config", "mainnet", "Spec config the test vectors were generated with (mainnet or minimal)")
+	fork       = flag.String("fork", "bellatrix", "Fork of the test vectors (phase0, altair or bellatrix)")
+)
+
+func TestCustom_Forkchoice(t *testing.T) {
+	if *vectorsDir == "" {
+		t.Skip("No test vectors provided, use -vectors to replay custom fork choice test vectors")
+	}
+	var v int
+	switch *fork {
+	case version.String(version.Phase0):
+		v = version.Phase0
+	case version.String(version.Altair):
+		v = version.Altair
+	case version.String(version.Bellatrix):
+		v = version.Bellatrix
+	default:
+		t.Fatalf("unknown fork: %s", *fork)
+	}
+	forkchoice.RunDir(t, *config, v, *vectorsDir)
+}
@@ -8,12 +8,15 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 )
 
@@ -24,10 +27,14 @@ type Builder struct {
 }
 
 func NewBuilder(t testing.TB, initialState state.BeaconState, initialBlock interfaces.SignedBeaconBlock) *Builder {
+	return newBuilder(t, initialState, initialBlock, protoarray.New())
+}
+
+func newBuilder(t testing.TB, initialState state.BeaconState, initialBlock interfaces.SignedBeaconBlock, fc forkchoice.ForkChoicer) *Builder {
 	execMock := &engineMock{
 		powBlocks: make(map[[32]byte]*ethpb.PowBlock),
 	}
-	service := startChainService(t, initialState, initialBlock, execMock)
+	service := startChainService(t, initialState, initialBlock, execMock, fc)
 	return &Builder{
 		service:  service,
 		execMock: execMock,
@@ -80,7 +87,8 @@ func (bb *Builder) AttesterSlashing(s *ethpb.AttesterSlashing) {
 	bb.service.InsertSlashingsToForkChoiceStore(context.TODO(), slashings)
 }
 
-// Check evaluates the fork choice results and compares them to the expected values.
+// Check evaluates the fork choice results and compares them to the expected values. Every
+// divergence from the expected values is reported before the test is stopped.
 func (bb *Builder) Check(t testing.TB, c *Check) {
 	if c == nil {
 		return
@@ -90,8 +98,8 @@ func (bb *Builder) Check(t testing.TB, c *Check) {
 	if c.Head != nil {
 		r, err := bb.service.HeadRoot(ctx)
 		require.NoError(t, err)
-		require.DeepEqual(t, common.FromHex(c.Head.Root), r)
-		require.Equal(t, types.Slot(c.Head.Slot), bb.service.HeadSlot())
+		assert.DeepEqual(t, common.FromHex(c.Head.Root), r, "Unexpected head root")
+		assert.Equal(t, types.Slot(c.Head.Slot), bb.service.HeadSlot(), "Unexpected head slot")
 	}
 	if c.JustifiedCheckPoint != nil {
 		cp := &ethpb.Checkpoint{
@@ -99,7 +107,7 @@ func (bb *Builder) Check(t testing.TB, c *Check) {
 			Root:  common.FromHex(c.JustifiedCheckPoint.Root),
 		}
 		got := bb.service.CurrentJustifiedCheckpt()
-		require.DeepEqual(t, cp, got)
+		assert.DeepEqual(t, cp, got, "Unexpected justified checkpoint")
 	}
 	if c.BestJustifiedCheckPoint != nil {
 		cp := &ethpb.Checkpoint{
@@ -107,7 +115,7 @@ func (bb *Builder) Check(t testing.TB, c *Check) {
 			Root:  common.FromHex(c.BestJustifiedCheckPoint.Root),
 		}
 		got := bb.service.BestJustifiedCheckpt()
-		require.DeepEqual(t, cp, got)
+		assert.DeepEqual(t, cp, got, "Unexpected best justified checkpoint")
 	}
 	if c.FinalizedCheckPoint != nil {
 		cp := &ethpb.Checkpoint{
@@ -115,12 +123,14 @@ func (bb *Builder) Check(t testing.TB, c *Check) {
 			Root:  common.FromHex(c.FinalizedCheckPoint.Root),
 		}
 		got := bb.service.FinalizedCheckpt()
-		require.DeepSSZEqual(t, cp, got)
+		assert.DeepSSZEqual(t, cp, got, "Unexpected finalized checkpoint")
 	}
 	if c.ProposerBoostRoot != nil {
 		want := fmt.Sprintf("%#x", common.FromHex(*c.ProposerBoostRoot))
 		got := fmt.Sprintf("%#x", bb.service.ForkChoiceStore().ProposerBoost())
-		require.DeepEqual(t, want, got)
+		assert.DeepEqual(t, want, got, "Unexpected proposer boost root")
+	}
+	if t.Failed() {
+		t.FailNow()
 	}
-
 }
@@ -0,0 +1,46 @@
+package forkchoice
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/spectest/utils"
+)
+
+// RunDir replays fork choice test vectors read from dir at runtime against the doubly linked tree
+// fork choice implementation. The vectors use the consensus spec test format: dir is either a single
+// test case, holding steps.yaml along with the anchor state, anchor block and the ssz_snappy files its
+// steps refer to, or a directory of such test cases. Every test case runs as its own subtest and any
+// divergence from the expected checks is reported as a failure of that subtest.
+func RunDir(t *testing.T, config string, fork int, dir string) {
+	require.NoError(t, utils.SetConfig(t, config))
+	if isTestCase(dir) {
+		runTest(t, fork, dir, doublylinkedtree.New())
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var found bool
+	for _, entry := range entries {
+		testPath := path.Join(dir, entry.Name())
+		if !entry.IsDir() || !isTestCase(testPath) {
+			continue
+		}
+		found = true
+		t.Run(entry.Name(), func(t *testing.T) {
+			runTest(t, fork, testPath, doublylinkedtree.New())
+		})
+	}
+	if !found {
+		t.Fatalf("No fork choice test cases found in %s", dir)
+	}
+}
+
+// isTestCase returns true if dir holds the steps of a fork choice test case.
+func isTestCase(dir string) bool {
+	info, err := os.Stat(path.Join(dir, "steps.yaml"))
+	return err == nil && !info.IsDir()
+}
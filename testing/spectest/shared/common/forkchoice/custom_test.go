@@ -0,0 +1,43 @@
+package forkchoice
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func writeTestCase(t *testing.T, dir string) {
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	stSSZ, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(dir, "anchor_state.ssz_snappy"), snappy.Encode(nil, stSSZ), os.ModePerm))
+	blkSSZ, err := util.NewBeaconBlock().Block.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(dir, "anchor_block.ssz_snappy"), snappy.Encode(nil, blkSSZ), os.ModePerm))
+	require.NoError(t, os.WriteFile(path.Join(dir, "steps.yaml"), []byte("- {tick: 12}\n- {tick: 24}\n"), os.ModePerm))
+}
+
+func TestRunDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCase(t, path.Join(dir, "case_1"))
+	writeTestCase(t, path.Join(dir, "case_2"))
+	require.NoError(t, os.MkdirAll(path.Join(dir, "not_a_case"), os.ModePerm))
+
+	RunDir(t, "mainnet", version.Phase0, dir)
+	RunDir(t, "mainnet", version.Phase0, path.Join(dir, "case_1"))
+}
+
+func TestIsTestCase(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, false, isTestCase(dir))
+	writeTestCase(t, dir)
+	assert.Equal(t, true, isTestCase(dir))
+}
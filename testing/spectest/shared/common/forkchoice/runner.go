@@ -7,6 +7,8 @@ import (
 
 	"github.com/golang/snappy"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/state"
 	v1 "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/v1"
 	v2 "github.com/prysmaticlabs/prysm/v3/beacon-chain/state/v2"
@@ -36,99 +38,104 @@ func Run(t *testing.T, config string, fork int) {
 
 		for _, folder := range testFolders {
 			t.Run(folder.Name(), func(t *testing.T) {
-				preStepsFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), "steps.yaml")
-				require.NoError(t, err)
-				var steps []Step
-				require.NoError(t, utils.UnmarshalYaml(preStepsFile, &steps))
-
-				preBeaconStateFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), "anchor_state.ssz_snappy")
-				require.NoError(t, err)
-				preBeaconStateSSZ, err := snappy.Decode(nil /* dst */, preBeaconStateFile)
-				require.NoError(t, err)
-
-				blockFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), "anchor_block.ssz_snappy")
-				require.NoError(t, err)
-				blockSSZ, err := snappy.Decode(nil /* dst */, blockFile)
-				require.NoError(t, err)
-
-				var beaconState state.BeaconState
-				var beaconBlock interfaces.SignedBeaconBlock
-				switch fork {
-				case version.Phase0:
-					beaconState = unmarshalPhase0State(t, preBeaconStateSSZ)
-					beaconBlock = unmarshalPhase0Block(t, blockSSZ)
-				case version.Altair:
-					beaconState = unmarshalAltairState(t, preBeaconStateSSZ)
-					beaconBlock = unmarshalAltairBlock(t, blockSSZ)
-				case version.Bellatrix:
-					beaconState = unmarshalBellatrixState(t, preBeaconStateSSZ)
-					beaconBlock = unmarshalBellatrixBlock(t, blockSSZ)
-				default:
-					t.Fatalf("unknown fork version: %v", fork)
-				}
-
-				builder := NewBuilder(t, beaconState, beaconBlock)
-
-				for _, step := range steps {
-					if step.Tick != nil {
-						builder.Tick(t, int64(*step.Tick))
-					}
-					if step.Block != nil {
-						blockFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), fmt.Sprint(*step.Block, ".ssz_snappy"))
-						require.NoError(t, err)
-						blockSSZ, err := snappy.Decode(nil /* dst */, blockFile)
-						require.NoError(t, err)
-						var beaconBlock interfaces.SignedBeaconBlock
-						switch fork {
-						case version.Phase0:
-							beaconBlock = unmarshalSignedPhase0Block(t, blockSSZ)
-						case version.Altair:
-							beaconBlock = unmarshalSignedAltairBlock(t, blockSSZ)
-						case version.Bellatrix:
-							beaconBlock = unmarshalSignedBellatrixBlock(t, blockSSZ)
-						default:
-							t.Fatalf("unknown fork version: %v", fork)
-						}
-						if step.Valid != nil && !*step.Valid {
-							builder.InvalidBlock(t, beaconBlock)
-						} else {
-							builder.ValidBlock(t, beaconBlock)
-						}
-					}
-					if step.AttesterSlashing != nil {
-						slashingFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), fmt.Sprint(*step.AttesterSlashing, ".ssz_snappy"))
-						require.NoError(t, err)
-						slashingSSZ, err := snappy.Decode(nil /* dst */, slashingFile)
-						require.NoError(t, err)
-						slashing := &ethpb.AttesterSlashing{}
-						require.NoError(t, slashing.UnmarshalSSZ(slashingSSZ), "Failed to unmarshal")
-						builder.AttesterSlashing(slashing)
-					}
-					if step.Attestation != nil {
-						attFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), fmt.Sprint(*step.Attestation, ".ssz_snappy"))
-						require.NoError(t, err)
-						attSSZ, err := snappy.Decode(nil /* dst */, attFile)
-						require.NoError(t, err)
-						att := &ethpb.Attestation{}
-						require.NoError(t, att.UnmarshalSSZ(attSSZ), "Failed to unmarshal")
-						builder.Attestation(t, att)
-					}
-					if step.PowBlock != nil {
-						powBlockFile, err := util.BazelFileBytes(testsFolderPath, folder.Name(), fmt.Sprint(*step.PowBlock, ".ssz_snappy"))
-						require.NoError(t, err)
-						p, err := snappy.Decode(nil /* dst */, powBlockFile)
-						require.NoError(t, err)
-						pb := &ethpb.PowBlock{}
-						require.NoError(t, pb.UnmarshalSSZ(p), "Failed to unmarshal")
-						builder.PoWBlock(pb)
-					}
-					builder.Check(t, step.Check)
-				}
+				runTest(t, fork, path.Join(testsFolderPath, folder.Name()), protoarray.New())
 			})
 		}
 	}
 }
 
+// runTest replays the steps of the test case stored in testPath against the given fork choice store.
+func runTest(t *testing.T, fork int, testPath string, fc forkchoice.ForkChoicer) {
+	preStepsFile, err := util.BazelFileBytes(testPath, "steps.yaml")
+	require.NoError(t, err)
+	var steps []Step
+	require.NoError(t, utils.UnmarshalYaml(preStepsFile, &steps))
+
+	preBeaconStateFile, err := util.BazelFileBytes(testPath, "anchor_state.ssz_snappy")
+	require.NoError(t, err)
+	preBeaconStateSSZ, err := snappy.Decode(nil /* dst */, preBeaconStateFile)
+	require.NoError(t, err)
+
+	blockFile, err := util.BazelFileBytes(testPath, "anchor_block.ssz_snappy")
+	require.NoError(t, err)
+	blockSSZ, err := snappy.Decode(nil /* dst */, blockFile)
+	require.NoError(t, err)
+
+	var beaconState state.BeaconState
+	var beaconBlock interfaces.SignedBeaconBlock
+	switch fork {
+	case version.Phase0:
+		beaconState = unmarshalPhase0State(t, preBeaconStateSSZ)
+		beaconBlock = unmarshalPhase0Block(t, blockSSZ)
+	case version.Altair:
+		beaconState = unmarshalAltairState(t, preBeaconStateSSZ)
+		beaconBlock = unmarshalAltairBlock(t, blockSSZ)
+	case version.Bellatrix:
+		beaconState = unmarshalBellatrixState(t, preBeaconStateSSZ)
+		beaconBlock = unmarshalBellatrixBlock(t, blockSSZ)
+	default:
+		t.Fatalf("unknown fork version: %v", fork)
+	}
+
+	builder := newBuilder(t, beaconState, beaconBlock, fc)
+
+	for _, step := range steps {
+		if step.Tick != nil {
+			builder.Tick(t, int64(*step.Tick))
+		}
+		if step.Block != nil {
+			blockFile, err := util.BazelFileBytes(testPath, fmt.Sprint(*step.Block, ".ssz_snappy"))
+			require.NoError(t, err)
+			blockSSZ, err := snappy.Decode(nil /* dst */, blockFile)
+			require.NoError(t, err)
+			var beaconBlock interfaces.SignedBeaconBlock
+			switch fork {
+			case version.Phase0:
+				beaconBlock = unmarshalSignedPhase0Block(t, blockSSZ)
+			case version.Altair:
+				beaconBlock = unmarshalSignedAltairBlock(t, blockSSZ)
+			case version.Bellatrix:
+				beaconBlock = unmarshalSignedBellatrixBlock(t, blockSSZ)
+			default:
+				t.Fatalf("unknown fork version: %v", fork)
+			}
+			if step.Valid != nil && !*step.Valid {
+				builder.InvalidBlock(t, beaconBlock)
+			} else {
+				builder.ValidBlock(t, beaconBlock)
+			}
+		}
+		if step.AttesterSlashing != nil {
+			slashingFile, err := util.BazelFileBytes(testPath, fmt.Sprint(*step.AttesterSlashing, ".ssz_snappy"))
+			require.NoError(t, err)
+			slashingSSZ, err := snappy.Decode(nil /* dst */, slashingFile)
+			require.NoError(t, err)
+			slashing := &ethpb.AttesterSlashing{}
+			require.NoError(t, slashing.UnmarshalSSZ(slashingSSZ), "Failed to unmarshal")
+			builder.AttesterSlashing(slashing)
+		}
+		if step.Attestation != nil {
+			attFile, err := util.BazelFileBytes(testPath, fmt.Sprint(*step.Attestation, ".ssz_snappy"))
+			require.NoError(t, err)
+			attSSZ, err := snappy.Decode(nil /* dst */, attFile)
+			require.NoError(t, err)
+			att := &ethpb.Attestation{}
+			require.NoError(t, att.UnmarshalSSZ(attSSZ), "Failed to unmarshal")
+			builder.Attestation(t, att)
+		}
+		if step.PowBlock != nil {
+			powBlockFile, err := util.BazelFileBytes(testPath, fmt.Sprint(*step.PowBlock, ".ssz_snappy"))
+			require.NoError(t, err)
+			p, err := snappy.Decode(nil /* dst */, powBlockFile)
+			require.NoError(t, err)
+			pb := &ethpb.PowBlock{}
+			require.NoError(t, pb.UnmarshalSSZ(p), "Failed to unmarshal")
+			builder.PoWBlock(pb)
+		}
+		builder.Check(t, step.Check)
+	}
+}
+
 func unmarshalPhase0State(t *testing.T, raw []byte) state.BeaconState {
 	base := &ethpb.BeaconState{}
 	require.NoError(t, base.UnmarshalSSZ(raw))
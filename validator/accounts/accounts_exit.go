@@ -3,7 +3,9 @@ package accounts
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -11,10 +13,13 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/blocks"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/validator/client"
 	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -63,6 +68,17 @@ func (acm *AccountsCLIManager) Exit(ctx context.Context) error {
 		acm.rawPubKeys,
 		acm.formattedPubKeys,
 	}
+	if acm.exitsFile != "" {
+		epoch := acm.exitEpoch
+		if epoch == nil {
+			currentEpoch, err := client.CurrentEpoch(ctx, *nodeClient)
+			if err != nil {
+				return err
+			}
+			epoch = &currentEpoch
+		}
+		return SavePresignedVoluntaryExits(ctx, cfg, *epoch, acm.exitsFile)
+	}
 	rawExitedKeys, trimmedExitedKeys, err := PerformVoluntaryExit(ctx, cfg)
 	if err != nil {
 		return err
@@ -110,6 +126,154 @@ func PerformVoluntaryExit(
 	return rawExitedKeys, formattedExitedKeys, nil
 }
 
+// SignVoluntaryExits signs voluntary exits of the accounts in cfg, taking effect at the given epoch,
+// without broadcasting them.
+func SignVoluntaryExits(ctx context.Context, cfg PerformExitCfg, epoch types.Epoch) ([]*ethpb.SignedVoluntaryExit, error) {
+	exits := make([]*ethpb.SignedVoluntaryExit, 0, len(cfg.RawPubKeys))
+	for i, key := range cfg.RawPubKeys {
+		signedExit, err := client.CreateSignedVoluntaryExit(ctx, cfg.ValidatorClient, cfg.Keymanager.Sign, key, epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not sign voluntary exit for account %s", cfg.FormattedPubKeys[i])
+		}
+		exits = append(exits, signedExit)
+	}
+	return exits, nil
+}
+
+// SavePresignedVoluntaryExits signs voluntary exits of the accounts in cfg, taking effect at the given
+// epoch, and saves them to path without broadcasting them. The exits are saved as a JSON list using the
+// format of the beacon node API, so they can be broadcast by BroadcastSignedVoluntaryExits or by
+// any beacon node.
+func SavePresignedVoluntaryExits(ctx context.Context, cfg PerformExitCfg, epoch types.Epoch, path string) error {
+	exits, err := SignVoluntaryExits(ctx, cfg, epoch)
+	if err != nil {
+		return err
+	}
+	enc, err := json.MarshalIndent(SignedVoluntaryExitsToJSON(exits), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal signed voluntary exits")
+	}
+	if err := file.WriteFile(path, enc); err != nil {
+		return errors.Wrapf(err, "could not write signed voluntary exits to %s", path)
+	}
+	log.WithFields(logrus.Fields{
+		"publicKeys": strings.Join(cfg.FormattedPubKeys, ", "),
+		"epoch":      epoch,
+		"path":       path,
+	}).Info("Saved pre-signed voluntary exits, they were not broadcast")
+	return nil
+}
+
+// BroadcastExits broadcasts the signed voluntary exits saved in the exits file of the manager.
+func (acm *AccountsCLIManager) BroadcastExits(ctx context.Context) error {
+	if acm.exitsFile == "" {
+		return errors.New("no signed voluntary exits file specified")
+	}
+	exits, err := ReadSignedVoluntaryExits(acm.exitsFile)
+	if err != nil {
+		return err
+	}
+	validatorClient, _, err := acm.prepareBeaconClients(ctx)
+	if err != nil {
+		return err
+	}
+	broadcast, err := BroadcastSignedVoluntaryExits(ctx, *validatorClient, exits)
+	if err != nil {
+		return err
+	}
+	log.Infof("Broadcast %d of %d signed voluntary exits", broadcast, len(exits))
+	return nil
+}
+
+// ReadSignedVoluntaryExits reads the signed voluntary exits saved by SavePresignedVoluntaryExits to path.
+func ReadSignedVoluntaryExits(path string) ([]*ethpb.SignedVoluntaryExit, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read signed voluntary exits from %s", path)
+	}
+	var exitsJSON []*SignedVoluntaryExitJSON
+	if err := json.Unmarshal(enc, &exitsJSON); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal signed voluntary exits")
+	}
+	return SignedVoluntaryExitsFromJSON(exitsJSON)
+}
+
+// SignedVoluntaryExitsToJSON converts signed voluntary exits to the format of the beacon node API.
+func SignedVoluntaryExitsToJSON(exits []*ethpb.SignedVoluntaryExit) []*SignedVoluntaryExitJSON {
+	exitsJSON := make([]*SignedVoluntaryExitJSON, len(exits))
+	for i, e := range exits {
+		exitsJSON[i] = &SignedVoluntaryExitJSON{
+			Message: &VoluntaryExitJSON{
+				Epoch:          strconv.FormatUint(uint64(e.Exit.Epoch), 10),
+				ValidatorIndex: strconv.FormatUint(uint64(e.Exit.ValidatorIndex), 10),
+			},
+			Signature: hexutil.Encode(e.Signature),
+		}
+	}
+	return exitsJSON
+}
+
+// SignedVoluntaryExitsFromJSON converts signed voluntary exits from the format of the beacon node API.
+func SignedVoluntaryExitsFromJSON(exitsJSON []*SignedVoluntaryExitJSON) ([]*ethpb.SignedVoluntaryExit, error) {
+	exits := make([]*ethpb.SignedVoluntaryExit, len(exitsJSON))
+	for i, e := range exitsJSON {
+		if e == nil || e.Message == nil {
+			return nil, fmt.Errorf("signed voluntary exit %d has no message", i)
+		}
+		epoch, err := strconv.ParseUint(e.Message.Epoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid epoch of signed voluntary exit %d", i)
+		}
+		index, err := strconv.ParseUint(e.Message.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index of signed voluntary exit %d", i)
+		}
+		sig, err := hexutil.Decode(e.Signature)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature of signed voluntary exit %d", i)
+		}
+		if len(sig) != fieldparams.BLSSignatureLength {
+			return nil, fmt.Errorf("invalid signature length of signed voluntary exit %d", i)
+		}
+		exits[i] = &ethpb.SignedVoluntaryExit{
+			Exit:      &ethpb.VoluntaryExit{Epoch: types.Epoch(epoch), ValidatorIndex: types.ValidatorIndex(index)},
+			Signature: sig,
+		}
+	}
+	return exits, nil
+}
+
+// BroadcastSignedVoluntaryExits submits signed voluntary exits to a beacon node for broadcasting. Exits
+// rejected by the beacon node are logged and skipped, the number of exits broadcast is returned.
+func BroadcastSignedVoluntaryExits(
+	ctx context.Context, validatorClient ethpb.BeaconNodeValidatorClient, exits []*ethpb.SignedVoluntaryExit,
+) (int, error) {
+	var broadcast int
+	for _, e := range exits {
+		if ctx.Err() != nil {
+			return broadcast, ctx.Err()
+		}
+		if _, err := validatorClient.ProposeExit(ctx, e); err != nil {
+			log.WithError(err).WithField("validatorIndex", e.Exit.ValidatorIndex).Error("Could not broadcast voluntary exit")
+			continue
+		}
+		broadcast++
+	}
+	return broadcast, nil
+}
+
+// SignedVoluntaryExitJSON is the format of the beacon node API for signed voluntary exits.
+type SignedVoluntaryExitJSON struct {
+	Message   *VoluntaryExitJSON `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+// VoluntaryExitJSON is the format of the beacon node API for voluntary exits.
+type VoluntaryExitJSON struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
 func prepareAllKeys(validatingKeys [][fieldparams.BLSPubkeyLength]byte) (raw [][]byte, formatted []string) {
 	raw = make([][]byte, len(validatingKeys))
 	formatted = make([]string, len(validatingKeys))
@@ -1,11 +1,21 @@
 package accounts
 
 import (
+	"context"
+	"encoding/json"
+	"path/filepath"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/mock"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/sirupsen/logrus/hooks/test"
 )
@@ -34,3 +44,81 @@ func TestPrepareAllKeys(t *testing.T) {
 	assert.Equal(t, "0x6b6579310000", formatted[0])
 	assert.Equal(t, "0x6b6579320000", formatted[1])
 }
+
+func TestParseValidatorIndices(t *testing.T) {
+	indices, err := ParseValidatorIndices("3, 10-12,11,0")
+	require.NoError(t, err)
+	assert.DeepEqual(t, []types.ValidatorIndex{3, 10, 11, 12, 0}, indices)
+
+	_, err = ParseValidatorIndices("12-10")
+	assert.ErrorContains(t, "end is lower than start", err)
+	_, err = ParseValidatorIndices("a-2")
+	assert.ErrorContains(t, "invalid validator index", err)
+	_, err = ParseValidatorIndices("0-18446744073709551615")
+	assert.ErrorContains(t, "is larger than", err)
+	_, err = ParseValidatorIndices(" , ")
+	assert.ErrorContains(t, "no validator indices specified", err)
+}
+
+func TestFilterKeysByValidatorIndices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	key1 := bytesutil.ToBytes48([]byte("key1"))
+	key2 := bytesutil.ToBytes48([]byte("key2"))
+	key3 := bytesutil.ToBytes48([]byte("key3"))
+	m.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(&ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{key1[:], key2[:], key3[:]},
+		Statuses: []*ethpb.ValidatorStatusResponse{
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+			{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS},
+		},
+		Indices: []types.ValidatorIndex{7, 5, 0},
+	}, nil).Times(2)
+
+	keys := [][fieldparams.BLSPubkeyLength]byte{key1, key2, key3}
+	filtered, err := FilterKeysByValidatorIndices(context.Background(), m, keys, []types.ValidatorIndex{5, 7})
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{key2, key1}, filtered)
+
+	// The validator of key3 has no index yet.
+	_, err = FilterKeysByValidatorIndices(context.Background(), m, keys, []types.ValidatorIndex{0})
+	assert.ErrorContains(t, "validator index 0 doesn't belong to any account", err)
+}
+
+func TestReadSignedVoluntaryExits_BroadcastSignedVoluntaryExits(t *testing.T) {
+	sig := make([]byte, fieldparams.BLSSignatureLength)
+	sig[0] = 1
+	exitsJSON := []*SignedVoluntaryExitJSON{
+		{Message: &VoluntaryExitJSON{Epoch: "100", ValidatorIndex: "5"}, Signature: hexutil.Encode(sig)},
+		{Message: &VoluntaryExitJSON{Epoch: "100", ValidatorIndex: "7"}, Signature: hexutil.Encode(sig)},
+	}
+	enc, err := json.Marshal(exitsJSON)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "exits.json")
+	require.NoError(t, file.WriteFile(path, enc))
+
+	exits, err := ReadSignedVoluntaryExits(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(exits))
+	assert.Equal(t, types.Epoch(100), exits[0].Exit.Epoch)
+	assert.Equal(t, types.ValidatorIndex(7), exits[1].Exit.ValidatorIndex)
+	assert.DeepEqual(t, sig, exits[1].Signature)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	m.EXPECT().ProposeExit(gomock.Any(), exits[0]).Return(nil, errors.New("already exited"))
+	m.EXPECT().ProposeExit(gomock.Any(), exits[1]).Return(&ethpb.ProposeExitResponse{}, nil)
+	broadcast, err := BroadcastSignedVoluntaryExits(context.Background(), m, exits)
+	require.NoError(t, err)
+	assert.Equal(t, 1, broadcast)
+
+	exitsJSON[1].Signature = "0x01"
+	enc, err = json.Marshal(exitsJSON)
+	require.NoError(t, err)
+	require.NoError(t, file.WriteFile(path, enc))
+	_, err = ReadSignedVoluntaryExits(path)
+	assert.ErrorContains(t, "invalid signature length of signed voluntary exit 1", err)
+}
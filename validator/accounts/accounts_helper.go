@@ -1,6 +1,7 @@
 package accounts
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -12,14 +13,19 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/io/prompt"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/petnames"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/userprompt"
 	"github.com/urfave/cli/v2"
 )
 
+// maxValidatorIndicesRange bounds the size of the ranges of validator indices parsed from user input.
+const maxValidatorIndicesRange = 1 << 20
+
 // selectAccounts Ask user to select accounts via an interactive user prompt.
 func selectAccounts(selectionPrompt string, pubKeys [][fieldparams.BLSPubkeyLength]byte) (filteredPubKeys []bls.PublicKey, err error) {
 	pubKeyStrings := make([]string, len(pubKeys))
@@ -113,6 +119,9 @@ func FilterPublicKeysFromUserInput(
 		}
 		return filterPublicKeys(pubKeyStrings)
 	}
+	if prompt.IsNonInteractive() {
+		return nil, errors.Wrapf(prompt.ErrNonInteractive, "use --%s to select the accounts", publicKeysFlag.Name)
+	}
 	return selectAccounts(selectionPrompt, validatingPublicKeys)
 }
 
@@ -142,7 +151,11 @@ func FilterExitAccountsFromUserInput(
 	r io.Reader,
 	validatingPublicKeys [][fieldparams.BLSPubkeyLength]byte,
 ) (rawPubKeys [][]byte, formattedPubKeys []string, err error) {
-	if !cliCtx.IsSet(flags.ExitAllFlag.Name) {
+	if cliCtx.IsSet(flags.VoluntaryExitIndicesFlag.Name) {
+		// The keys were already selected by validator index, see ValidatingKeysByIndices.
+		rawPubKeys, formattedPubKeys = prepareAllKeys(validatingPublicKeys)
+		fmt.Printf("About to perform a voluntary exit of %d accounts: %s\n", len(rawPubKeys), strings.Join(formattedPubKeys, ", "))
+	} else if !cliCtx.IsSet(flags.ExitAllFlag.Name) {
 		// Allow the user to interactively select the accounts to exit or optionally
 		// provide them via cli flags as a string of comma-separated, hex strings.
 		filteredPubKeys, err := FilterPublicKeysFromUserInput(
@@ -197,6 +210,9 @@ func FilterExitAccountsFromUserInput(
 		fmt.Printf("About to perform a voluntary exit of %d accounts\n", len(rawPubKeys))
 	}
 
+	if cliCtx.Bool(flags.ForceExitFlag.Name) {
+		return rawPubKeys, formattedPubKeys, nil
+	}
 	promptHeader := au.Red("===============IMPORTANT===============")
 	promptDescription := "Withdrawing funds is not possible in Phase 0 of the system. " +
 		"Please navigate to the following website and make sure you understand the current implications " +
@@ -217,3 +233,99 @@ func FilterExitAccountsFromUserInput(
 
 	return rawPubKeys, formattedPubKeys, nil
 }
+
+// ParseValidatorIndices parses a comma-separated list of validator indices, or of inclusive
+// ranges of validator indices, such as "10,12,20-30".
+func ParseValidatorIndices(s string) ([]types.ValidatorIndex, error) {
+	var indices []types.ValidatorIndex
+	seen := make(map[types.ValidatorIndex]bool)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		bounds := strings.SplitN(item, "-", 2)
+		start, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %q", item)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid validator index range %q", item)
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid validator index range %q: end is lower than start", item)
+			}
+			if end-start >= maxValidatorIndicesRange {
+				return nil, fmt.Errorf("validator index range %q is larger than %d", item, maxValidatorIndicesRange)
+			}
+		}
+		for i := start; i <= end; i++ {
+			idx := types.ValidatorIndex(i)
+			if !seen[idx] {
+				seen[idx] = true
+				indices = append(indices, idx)
+			}
+		}
+	}
+	if len(indices) == 0 {
+		return nil, errors.New("no validator indices specified")
+	}
+	return indices, nil
+}
+
+// ValidatingKeysByIndices returns the keys among validatingPublicKeys of the validators with the given
+// indices, as known by the beacon node. An error is returned if any of the indices doesn't belong
+// to one of the keys.
+func (acm *AccountsCLIManager) ValidatingKeysByIndices(
+	ctx context.Context,
+	validatingPublicKeys [][fieldparams.BLSPubkeyLength]byte,
+	indices []types.ValidatorIndex,
+) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	validatorClient, _, err := acm.prepareBeaconClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FilterKeysByValidatorIndices(ctx, *validatorClient, validatingPublicKeys, indices)
+}
+
+// FilterKeysByValidatorIndices returns the keys among validatingPublicKeys of the validators with the
+// given indices, in the order of the indices. The indices of the keys are looked up with the beacon node.
+func FilterKeysByValidatorIndices(
+	ctx context.Context,
+	validatorClient ethpb.BeaconNodeValidatorClient,
+	validatingPublicKeys [][fieldparams.BLSPubkeyLength]byte,
+	indices []types.ValidatorIndex,
+) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	req := &ethpb.MultipleValidatorStatusRequest{PublicKeys: make([][]byte, len(validatingPublicKeys))}
+	for i := range validatingPublicKeys {
+		req.PublicKeys[i] = validatingPublicKeys[i][:]
+	}
+	resp, err := validatorClient.MultipleValidatorStatus(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator statuses")
+	}
+	if len(resp.PublicKeys) != len(resp.Indices) || len(resp.PublicKeys) != len(resp.Statuses) {
+		return nil, errors.New("inconsistent validator statuses response")
+	}
+	keysByIndex := make(map[types.ValidatorIndex][fieldparams.BLSPubkeyLength]byte, len(resp.Indices))
+	for i, idx := range resp.Indices {
+		// Validators without a record in the beacon state don't have an index yet.
+		st := resp.Statuses[i].GetStatus()
+		if st == ethpb.ValidatorStatus_UNKNOWN_STATUS || st == ethpb.ValidatorStatus_DEPOSITED {
+			continue
+		}
+		keysByIndex[idx] = bytesutil.ToBytes48(resp.PublicKeys[i])
+	}
+	keys := make([][fieldparams.BLSPubkeyLength]byte, len(indices))
+	for i, idx := range indices {
+		key, ok := keysByIndex[idx]
+		if !ok {
+			return nil, fmt.Errorf("validator index %d doesn't belong to any account of the wallet", idx)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/io/file"
 	"github.com/prysmaticlabs/prysm/v3/io/prompt"
+	"github.com/prysmaticlabs/prysm/v3/io/secrets"
 	ethpbservice "github.com/prysmaticlabs/prysm/v3/proto/eth/service"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
@@ -72,6 +73,8 @@ type ImportAccountsConfig struct {
 	Keystores       []*keymanager.Keystore
 	Importer        keymanager.Importer
 	AccountPassword string
+	// DryRun only verifies the keystores, which requires the importer to be a keymanager.DryRunImporter.
+	DryRun bool
 }
 
 // Import can import external, EIP-2335 compliant keystore.json files as
@@ -131,7 +134,12 @@ func (acm *AccountsCLIManager) Import(ctx context.Context) error {
 	}
 
 	var accountsPassword string
-	if acm.readPasswordFile {
+	if acm.passwordSecret != "" {
+		accountsPassword, err = secrets.Fetch(ctx, acm.passwordSecret)
+		if err != nil {
+			return errors.Wrap(err, "could not fetch account password")
+		}
+	} else if acm.readPasswordFile {
 		data, err := os.ReadFile(acm.passwordFilePath) // #nosec G304
 		if err != nil {
 			return err
@@ -145,11 +153,16 @@ func (acm *AccountsCLIManager) Import(ctx context.Context) error {
 			return fmt.Errorf("could not read account password: %w", err)
 		}
 	}
-	fmt.Println("Importing accounts, this may take a while...")
+	if acm.dryRun {
+		fmt.Println("Verifying accounts, this may take a while...")
+	} else {
+		fmt.Println("Importing accounts, this may take a while...")
+	}
 	statuses, err := ImportAccounts(ctx, &ImportAccountsConfig{
 		Importer:        k,
 		Keystores:       keystoresImported,
 		AccountPassword: accountsPassword,
+		DryRun:          acm.dryRun,
 	})
 	if err != nil {
 		return err
@@ -162,6 +175,19 @@ func (acm *AccountsCLIManager) Import(ctx context.Context) error {
 			log.Warnf("Could not import keystore for %s: %s", keystoresImported[i].Pubkey, status.Message)
 		}
 	}
+	if acm.dryRun {
+		imported := 0
+		for _, status := range statuses {
+			if status.Status == ethpbservice.ImportedKeystoreStatus_IMPORTED {
+				imported++
+			}
+		}
+		fmt.Printf(
+			"Dry run complete, %s of %d accounts would be imported\n",
+			au.BrightMagenta(strconv.Itoa(imported)), len(keystoresImported),
+		)
+		return nil
+	}
 	fmt.Printf(
 		"Successfully imported %s accounts, view all of them by running `accounts list`\n",
 		au.BrightMagenta(strconv.Itoa(len(keystoresImported))),
@@ -189,6 +215,13 @@ func ImportAccounts(ctx context.Context, cfg *ImportAccountsConfig) ([]*ethpbser
 	for i := 0; i < len(cfg.Keystores); i++ {
 		passwords[i] = cfg.AccountPassword
 	}
+	if cfg.DryRun {
+		dryRunImporter, ok := cfg.Importer.(keymanager.DryRunImporter)
+		if !ok {
+			return nil, errors.New("keymanager cannot verify keystores without importing them")
+		}
+		return dryRunImporter.DryRunImportKeystores(ctx, cfg.Keystores, passwords)
+	}
 	return cfg.Importer.ImportKeystores(
 		ctx,
 		cfg.Keystores,
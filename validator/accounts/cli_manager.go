@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	grpcutil "github.com/prysmaticlabs/prysm/v3/api/grpc"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
@@ -35,6 +36,7 @@ type AccountsCLIManager struct {
 	listValidatorIndices bool
 	deletePublicKeys     bool
 	importPrivateKeys    bool
+	dryRun               bool
 	readPasswordFile     bool
 	dialOpts             []grpc.DialOption
 	grpcHeaders          []string
@@ -42,12 +44,15 @@ type AccountsCLIManager struct {
 	walletKeyCount       int
 	privateKeyFile       string
 	passwordFilePath     string
+	passwordSecret       string
 	keysDir              string
 	backupsDir           string
 	backupsPassword      string
 	filteredPubKeys      []bls.PublicKey
 	rawPubKeys           [][]byte
 	formattedPubKeys     []string
+	exitEpoch            *types.Epoch
+	exitsFile            string
 }
 
 func (acm *AccountsCLIManager) prepareBeaconClients(ctx context.Context) (*ethpb.BeaconNodeValidatorClient, *ethpb.NodeClient, error) {
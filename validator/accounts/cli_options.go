@@ -1,6 +1,7 @@
 package accounts
 
 import (
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
@@ -115,6 +116,14 @@ func WithImportPrivateKeys(importPrivateKeys bool) Option {
 	}
 }
 
+// WithDryRun indicates whether to only verify the keystores to import, without importing them.
+func WithDryRun(dryRun bool) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.dryRun = dryRun
+		return nil
+	}
+}
+
 // WithPrivateKeyFile specifies the private key path.
 func WithPrivateKeyFile(privateKeyFile string) Option {
 	return func(acc *AccountsCLIManager) error {
@@ -139,6 +148,15 @@ func WithPasswordFilePath(passwordFilePath string) Option {
 	}
 }
 
+// WithPasswordSecret specifies a reference to the password in an external secret manager, which
+// takes precedence over the password file.
+func WithPasswordSecret(passwordSecret string) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.passwordSecret = passwordSecret
+		return nil
+	}
+}
+
 // WithBackupsDir specifies the directory backups are written to.
 func WithBackupsDir(backupsDir string) Option {
 	return func(acc *AccountsCLIManager) error {
@@ -178,3 +196,20 @@ func WithFormattedPubKeys(formattedPubKeys []string) Option {
 		return nil
 	}
 }
+
+// WithExitEpoch specifies the epoch at which pre-signed voluntary exits take effect.
+func WithExitEpoch(epoch types.Epoch) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.exitEpoch = &epoch
+		return nil
+	}
+}
+
+// WithExitsFile specifies the file of signed voluntary exits. Voluntary exits are saved to it
+// instead of being broadcast, and broadcast from it by BroadcastExits.
+func WithExitsFile(exitsFile string) Option {
+	return func(acc *AccountsCLIManager) error {
+		acc.exitsFile = exitsFile
+		return nil
+	}
+}
@@ -154,6 +154,18 @@ func (_ MockValidator) UpdateDomainDataCaches(_ context.Context, _ types.Slot) {
 	panic("implement me")
 }
 
+func (_ MockValidator) PrepareSyncCommitteeSelections(_ context.Context, _ types.Slot) {
+	panic("implement me")
+}
+
+func (_ MockValidator) RebroadcastAttestations(_ context.Context, _ types.Slot) {
+	panic("implement me")
+}
+
+func (_ MockValidator) CheckFinality(_ context.Context, _ types.Slot) {
+	panic("implement me")
+}
+
 func (_ MockValidator) WaitForKeymanagerInitialization(_ context.Context) error {
 	panic("implement me")
 }
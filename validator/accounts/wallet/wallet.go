@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/io/file"
 	"github.com/prysmaticlabs/prysm/v3/io/prompt"
+	"github.com/prysmaticlabs/prysm/v3/io/secrets"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/iface"
 	accountsprompt "github.com/prysmaticlabs/prysm/v3/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
@@ -441,8 +442,16 @@ func readKeymanagerKindFromWalletPath(walletPath string) (keymanager.Kind, error
 	return 0, errors.New("no keymanager folder (imported, remote, derived) found in wallet path")
 }
 
+// passwordSecretFlags are the flags referencing a password in a secret manager, which take precedence
+// over the password file flags they are keyed by.
+var passwordSecretFlags = map[string]*cli.StringFlag{
+	flags.WalletPasswordFileFlag.Name:  flags.WalletPasswordSecretFlag,
+	flags.AccountPasswordFileFlag.Name: flags.AccountPasswordSecretFlag,
+}
+
 // InputPassword prompts for a password and optionally for password confirmation.
-// The password is validated according to custom rules.
+// The password is validated according to custom rules. The password is fetched from a
+// secret manager or read from a file instead if the corresponding flag is set.
 func InputPassword(
 	cliCtx *cli.Context,
 	passwordFileFlag *cli.StringFlag,
@@ -450,6 +459,16 @@ func InputPassword(
 	confirmPassword bool,
 	passwordValidator func(input string) error,
 ) (string, error) {
+	if secretFlag, ok := passwordSecretFlags[passwordFileFlag.Name]; ok && cliCtx.IsSet(secretFlag.Name) {
+		password, err := secrets.Fetch(cliCtx.Context, cliCtx.String(secretFlag.Name))
+		if err != nil {
+			return "", errors.Wrap(err, "could not fetch password")
+		}
+		if err := passwordValidator(password); err != nil {
+			return "", errors.Wrap(err, "password did not pass validation")
+		}
+		return password, nil
+	}
 	if cliCtx.IsSet(passwordFileFlag.Name) {
 		passwordFilePathInput := cliCtx.String(passwordFileFlag.Name)
 		data, err := file.ReadFileAsBytes(passwordFilePathInput)
@@ -462,6 +481,9 @@ func InputPassword(
 		}
 		return enteredPassword, nil
 	}
+	if prompt.IsNonInteractive() {
+		return "", errors.Wrapf(prompt.ErrNonInteractive, "use --%s to provide the password", passwordFileFlag.Name)
+	}
 	var hasValidPassword bool
 	var walletPassword string
 	var err error
@@ -2,12 +2,14 @@ package wallet_test
 
 import (
 	"context"
+	"flag"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
@@ -15,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v3/validator/keymanager/remote-web3signer"
 	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
 )
 
 func init() {
@@ -84,3 +87,23 @@ func TestWallet_InitializeKeymanager_web3Signer_nilConfig(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, nil, km)
 }
+
+func TestInputPassword_FromSecret(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("filepassword"), 0600))
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.WalletPasswordFileFlag.Name, passwordFile, "")
+	set.String(flags.WalletPasswordSecretFlag.Name, "exec://echo secretpassword", "")
+	require.NoError(t, set.Set(flags.WalletPasswordFileFlag.Name, passwordFile))
+	require.NoError(t, set.Set(flags.WalletPasswordSecretFlag.Name, "exec://echo secretpassword"))
+	cliCtx := cli.NewContext(&cli.App{}, set, nil)
+
+	// The secret takes precedence over the password file.
+	password, err := wallet.InputPassword(cliCtx, flags.WalletPasswordFileFlag, wallet.PasswordPromptText, false, wallet.ValidateExistingPass)
+	require.NoError(t, err)
+	assert.Equal(t, "secretpassword", password)
+
+	require.NoError(t, set.Set(flags.WalletPasswordSecretFlag.Name, "exec://false"))
+	_, err = wallet.InputPassword(cliCtx, flags.WalletPasswordFileFlag, wallet.PasswordPromptText, false, wallet.ValidateExistingPass)
+	assert.ErrorContains(t, "could not fetch password", err)
+}
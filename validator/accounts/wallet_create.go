@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v3/io/prompt"
+	"github.com/prysmaticlabs/prysm/v3/io/secrets"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
@@ -127,6 +128,29 @@ func CreateWalletWithKeymanager(ctx context.Context, cfg *CreateWalletConfig) (*
 	return w, nil
 }
 
+// inputNewWalletPassword fetches the password of a new wallet from a secret manager if
+// --wallet-password-secret is set, otherwise reads it from --wallet-password-file or prompts for it.
+func inputNewWalletPassword(cliCtx *cli.Context) (string, error) {
+	if cliCtx.IsSet(flags.WalletPasswordSecretFlag.Name) {
+		walletPassword, err := secrets.Fetch(cliCtx.Context, cliCtx.String(flags.WalletPasswordSecretFlag.Name))
+		if err != nil {
+			return "", errors.Wrap(err, "could not fetch wallet password")
+		}
+		if err := prompt.ValidatePasswordInput(walletPassword); err != nil {
+			return "", errors.Wrap(err, "password did not pass validation")
+		}
+		return walletPassword, nil
+	}
+	return prompt.InputPassword(
+		cliCtx,
+		flags.WalletPasswordFileFlag,
+		wallet.NewWalletPasswordPromptText,
+		wallet.ConfirmPasswordPromptText,
+		true, /* Should confirm password */
+		prompt.ValidatePasswordInput,
+	)
+}
+
 func extractKeymanagerKindFromCli(cliCtx *cli.Context) (keymanager.Kind, error) {
 	return inputKeymanagerKind(cliCtx)
 }
@@ -137,14 +161,7 @@ func ExtractWalletCreationConfigFromCli(cliCtx *cli.Context, keymanagerKind keym
 	if err != nil {
 		return nil, err
 	}
-	walletPassword, err := prompt.InputPassword(
-		cliCtx,
-		flags.WalletPasswordFileFlag,
-		wallet.NewWalletPasswordPromptText,
-		wallet.ConfirmPasswordPromptText,
-		true, /* Should confirm password */
-		prompt.ValidatePasswordInput,
-	)
+	walletPassword, err := inputNewWalletPassword(cliCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -263,6 +280,9 @@ func inputKeymanagerKind(cliCtx *cli.Context) (keymanager.Kind, error) {
 	if cliCtx.IsSet(flags.KeymanagerKindFlag.Name) {
 		return keymanager.ParseKind(cliCtx.String(flags.KeymanagerKindFlag.Name))
 	}
+	if prompt.IsNonInteractive() {
+		return keymanager.Local, errors.Wrapf(prompt.ErrNonInteractive, "use --%s to select the type of wallet", flags.KeymanagerKindFlag.Name)
+	}
 	promptSelect := promptui.Select{
 		Label: "Select a type of wallet",
 		Items: []string{
@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v3/validator/keymanager/local"
@@ -45,6 +46,7 @@ type testWalletConfig struct {
 	privateKeyFile          string
 	accountPasswordFile     string
 	walletPasswordFile      string
+	walletPasswordSecret    string
 	backupPasswordFile      string
 	backupPublicKeys        string
 	voluntaryExitPublicKeys string
@@ -81,6 +83,10 @@ func setupWalletCtx(
 		set.String(flags.ImportPrivateKeyFileFlag.Name, cfg.privateKeyFile, "")
 		assert.NoError(tb, set.Set(flags.ImportPrivateKeyFileFlag.Name, cfg.privateKeyFile))
 	}
+	if cfg.walletPasswordSecret != "" {
+		set.String(flags.WalletPasswordSecretFlag.Name, cfg.walletPasswordSecret, "")
+		assert.NoError(tb, set.Set(flags.WalletPasswordSecretFlag.Name, cfg.walletPasswordSecret))
+	}
 	assert.NoError(tb, set.Set(flags.WalletDirFlag.Name, cfg.walletDir))
 	assert.NoError(tb, set.Set(flags.SkipMnemonic25thWordCheckFlag.Name, "true"))
 	assert.NoError(tb, set.Set(flags.KeysDirFlag.Name, cfg.keysDir))
@@ -168,6 +174,46 @@ func TestCreateWallet_Local(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCreateWallet_PasswordSecret(t *testing.T) {
+	walletDir, passwordsDir, walletPasswordFile := setupWalletAndPasswordsDir(t)
+	secretPassword := "Th1sIsTheS3cretPassword!"
+	cliCtx := setupWalletCtx(t, &testWalletConfig{
+		walletDir:            walletDir,
+		passwordsDir:         passwordsDir,
+		keymanagerKind:       keymanager.Local,
+		walletPasswordFile:   walletPasswordFile,
+		walletPasswordSecret: "exec://echo " + secretPassword,
+	})
+
+	// The password from the secret manager takes precedence over the password file.
+	_, err := CreateAndSaveWalletCli(cliCtx)
+	require.NoError(t, err)
+	w, err := wallet.OpenWallet(cliCtx.Context, &wallet.Config{
+		WalletDir:      walletDir,
+		WalletPassword: secretPassword,
+	})
+	require.NoError(t, err)
+	_, err = w.InitializeKeymanager(cliCtx.Context, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	w, err = wallet.OpenWallet(cliCtx.Context, &wallet.Config{
+		WalletDir:      walletDir,
+		WalletPassword: password,
+	})
+	require.NoError(t, err)
+	_, err = w.InitializeKeymanager(cliCtx.Context, iface.InitKeymanagerConfig{ListenForChanges: false})
+	assert.NotNil(t, err)
+
+	cliCtx = setupWalletCtx(t, &testWalletConfig{
+		walletDir:            filepath.Join(t.TempDir(), "wallet"),
+		passwordsDir:         passwordsDir,
+		keymanagerKind:       keymanager.Local,
+		walletPasswordFile:   walletPasswordFile,
+		walletPasswordSecret: "exec://false",
+	})
+	_, err = CreateAndSaveWalletCli(cliCtx)
+	require.ErrorContains(t, "could not fetch wallet password", err)
+}
+
 func TestCreateWallet_Derived(t *testing.T) {
 	walletDir, passwordsDir, passwordFile := setupWalletAndPasswordsDir(t)
 	cliCtx := setupWalletCtx(t, &testWalletConfig{
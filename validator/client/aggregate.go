@@ -84,16 +84,26 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 		return
 	}
 
+	if err := v.finalityGuard.checkAttestation(ctx, res.AggregateAndProof.Aggregate.Data, v.blockHeader); err != nil {
+		log.WithError(err).Error("Refusing to sign aggregate conflicting with the pinned finalized checkpoint")
+		finalityGuardRefusalsCounterVec.WithLabelValues("aggregate").Inc()
+		if v.emitAccountMetrics {
+			ValidatorAggFailVec.WithLabelValues(fmtKey).Inc()
+		}
+		return
+	}
+
 	sig, err := v.aggregateAndProofSig(ctx, pubKey, res.AggregateAndProof, slot)
 	if err != nil {
 		log.WithError(err).Error("Could not sign aggregate and proof")
 		return
 	}
+	signedAgg := &ethpb.SignedAggregateAttestationAndProof{
+		Message:   res.AggregateAndProof,
+		Signature: sig,
+	}
 	_, err = v.validatorClient.SubmitSignedAggregateSelectionProof(ctx, &ethpb.SignedAggregateSubmitRequest{
-		SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProof{
-			Message:   res.AggregateAndProof,
-			Signature: sig,
-		},
+		SignedAggregateAndProof: signedAgg,
 	})
 	if err != nil {
 		log.WithError(err).Error("Could not submit signed aggregate and proof to beacon node")
@@ -102,6 +112,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 		}
 		return
 	}
+	v.attestationRebroadcaster.trackAggregate(signedAgg)
 
 	if err := v.addIndicesToLog(duty); err != nil {
 		log.WithError(err).Error("Could not add aggregator indices to logs")
@@ -85,6 +85,16 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 		return
 	}
 
+	if err := v.finalityGuard.checkAttestation(ctx, data, v.blockHeader); err != nil {
+		log.WithError(err).Error("Refusing to sign attestation conflicting with the pinned finalized checkpoint")
+		finalityGuardRefusalsCounterVec.WithLabelValues("attestation").Inc()
+		if v.emitAccountMetrics {
+			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
+		}
+		tracing.AnnotateError(span, err)
+		return
+	}
+
 	indexedAtt := &ethpb.IndexedAttestation{
 		AttestingIndices: []uint64{uint64(duty.ValidatorIndex)},
 		Data:             data,
@@ -155,6 +165,8 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 		return
 	}
 
+	v.attestationRebroadcaster.track(attestation)
+
 	if err := v.saveAttesterIndexToData(data, duty.ValidatorIndex); err != nil {
 		log.WithError(err).Error("Could not save validator index for logging")
 		if v.emitAccountMetrics {
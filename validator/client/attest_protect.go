@@ -10,6 +10,7 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1/slashings"
+	vdb "github.com/prysmaticlabs/prysm/v3/validator/db"
 	"github.com/prysmaticlabs/prysm/v3/validator/db/kv"
 	"go.opencensus.io/trace"
 )
@@ -17,32 +18,43 @@ import (
 var failedAttLocalProtectionErr = "attempted to make slashable attestation, rejected by local slashing protection"
 var failedPostAttSignExternalErr = "attempted to make slashable attestation, rejected by external slasher service"
 
-// Checks if an attestation is slashable by comparing it with the attesting
-// history for the given public key in our DB. If it is not, we then update the history
-// with new values and save it to the database.
-func (v *validator) slashableAttestationCheck(
+// SlashingProtectionError is returned when local slashing protection refuses to sign an object.
+type SlashingProtectionError struct {
+	// Kind is the kind of slashing signing would lead to. It is NotSlashable when signing is
+	// refused because the object is older than the lowest signed one in the database, as per EIP-3076.
+	Kind kv.SlashingKind
+	err  error
+}
+
+// Error returns the reason signing was refused.
+func (e *SlashingProtectionError) Error() string {
+	return e.err.Error()
+}
+
+// CheckSlashableAttestation checks an attestation against the attesting history of the given
+// public key in the database, applying the rules used before signing attestations. A
+// *SlashingProtectionError is returned when signing must be refused. Nothing is saved.
+func CheckSlashableAttestation(
 	ctx context.Context,
-	indexedAtt *ethpb.IndexedAttestation,
+	valDB vdb.Database,
 	pubKey [fieldparams.BLSPubkeyLength]byte,
 	signingRoot [32]byte,
+	indexedAtt *ethpb.IndexedAttestation,
 ) error {
-	ctx, span := trace.StartSpan(ctx, "validator.postAttSignUpdate")
-	defer span.End()
-
 	// Based on EIP3076, validator should refuse to sign any attestation with source epoch less
 	// than the minimum source epoch present in that signer’s attestations.
-	lowestSourceEpoch, exists, err := v.db.LowestSignedSourceEpoch(ctx, pubKey)
+	lowestSourceEpoch, exists, err := valDB.LowestSignedSourceEpoch(ctx, pubKey)
 	if err != nil {
 		return err
 	}
 	if exists && indexedAtt.Data.Source.Epoch < lowestSourceEpoch {
-		return fmt.Errorf(
+		return &SlashingProtectionError{Kind: kv.NotSlashable, err: fmt.Errorf(
 			"could not sign attestation lower than lowest source epoch in db, %d < %d",
 			indexedAtt.Data.Source.Epoch,
 			lowestSourceEpoch,
-		)
+		)}
 	}
-	existingSigningRoot, err := v.db.SigningRootAtTargetEpoch(ctx, pubKey, indexedAtt.Data.Target.Epoch)
+	existingSigningRoot, err := valDB.SigningRootAtTargetEpoch(ctx, pubKey, indexedAtt.Data.Target.Epoch)
 	if err != nil {
 		return err
 	}
@@ -50,24 +62,49 @@ func (v *validator) slashableAttestationCheck(
 
 	// Based on EIP3076, validator should refuse to sign any attestation with target epoch less
 	// than or equal to the minimum target epoch present in that signer’s attestations.
-	lowestTargetEpoch, exists, err := v.db.LowestSignedTargetEpoch(ctx, pubKey)
+	lowestTargetEpoch, exists, err := valDB.LowestSignedTargetEpoch(ctx, pubKey)
 	if err != nil {
 		return err
 	}
 	if signingRootsDiffer && exists && indexedAtt.Data.Target.Epoch <= lowestTargetEpoch {
-		return fmt.Errorf(
+		return &SlashingProtectionError{Kind: kv.NotSlashable, err: fmt.Errorf(
 			"could not sign attestation lower than or equal to lowest target epoch in db, %d <= %d",
 			indexedAtt.Data.Target.Epoch,
 			lowestTargetEpoch,
-		)
+		)}
 	}
-	fmtKey := "0x" + hex.EncodeToString(pubKey[:])
-	slashingKind, err := v.db.CheckSlashableAttestation(ctx, pubKey, signingRoot, indexedAtt)
+	slashingKind, err := valDB.CheckSlashableAttestation(ctx, pubKey, signingRoot, indexedAtt)
 	if err != nil {
+		if slashingKind == kv.NotSlashable {
+			return err
+		}
+		return &SlashingProtectionError{Kind: slashingKind, err: err}
+	}
+	return nil
+}
+
+// Checks if an attestation is slashable by comparing it with the attesting
+// history for the given public key in our DB. If it is not, we then update the history
+// with new values and save it to the database.
+func (v *validator) slashableAttestationCheck(
+	ctx context.Context,
+	indexedAtt *ethpb.IndexedAttestation,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signingRoot [32]byte,
+) error {
+	ctx, span := trace.StartSpan(ctx, "validator.postAttSignUpdate")
+	defer span.End()
+
+	fmtKey := "0x" + hex.EncodeToString(pubKey[:])
+	if err := CheckSlashableAttestation(ctx, v.db, pubKey, signingRoot, indexedAtt); err != nil {
+		var protectionErr *SlashingProtectionError
+		if !errors.As(err, &protectionErr) || protectionErr.Kind == kv.NotSlashable {
+			return err
+		}
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
-		switch slashingKind {
+		switch protectionErr.Kind {
 		case kv.DoubleVote:
 			log.Warn("Attestation is slashable as it is a double vote")
 		case kv.SurroundingVote:
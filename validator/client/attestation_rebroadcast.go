@@ -0,0 +1,290 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+const (
+	attestationInclusionPath    = "/prysm/v1/validators/attestation_inclusion"
+	attestationInclusionTimeout = 2 * time.Second
+	// maxInclusionQueries matches the maximum number of attestations the beacon node accepts per request.
+	maxInclusionQueries = 1024
+)
+
+// AttestationRebroadcastConfig defines when and through which beacon node attestations and aggregates
+// which were not included in a block are broadcast again.
+//
+// Beacon nodes drop the gossip messages they have seen in the last 550 heartbeats, about 385 seconds,
+// which is longer than the slots during which an attestation can be included. An identical message
+// submitted again to a beacon node it was already submitted to is dropped by that node, so is one
+// published to peers which already received it. Attestations are therefore rebroadcast through a
+// separate beacon node, which publishes to its own peers those which never propagated from the beacon
+// nodes they were first submitted to.
+type AttestationRebroadcastConfig struct {
+	// Slots after the attestation slot from which an attestation not included in a block is
+	// broadcast again, at every slot until it is included or can't be included anymore.
+	Slots types.Slot
+	// BeaconAPIEndpoint is the REST API of the beacon node, which reports whether attestations
+	// were included.
+	BeaconAPIEndpoint string
+	// Endpoint is the gRPC endpoint of the beacon node the attestations are broadcast again through.
+	// It must not be one of the beacon nodes the attestations are first submitted to.
+	Endpoint string
+}
+
+// trackedAttestation is an attestation or an aggregate submitted by the validator client, which
+// is not known to be included in a block yet.
+type trackedAttestation struct {
+	att       *ethpb.Attestation
+	aggregate *ethpb.SignedAggregateAttestationAndProof
+	dataRoot  [32]byte
+}
+
+// attestationRebroadcaster tracks the attestations and aggregates submitted by the validator
+// client, and broadcasts those which were not included after the configured number of slots again
+// through its own beacon node.
+type attestationRebroadcaster struct {
+	slots    types.Slot
+	url      string
+	endpoint string
+	client   *http.Client
+	// Client of the beacon node the attestations are broadcast again through, set once the
+	// validator service is connected to it.
+	validatorClient ethpb.BeaconNodeValidatorClient
+	lock            sync.Mutex
+	tracked         []*trackedAttestation
+}
+
+type attestationInclusionQuery struct {
+	Slot            string `json:"slot"`
+	CommitteeIndex  string `json:"committee_index"`
+	DataRoot        string `json:"data_root"`
+	AggregationBits string `json:"aggregation_bits"`
+}
+
+type attestationInclusionRequest struct {
+	Attestations []*attestationInclusionQuery `json:"attestations"`
+}
+
+type attestationInclusionStatus struct {
+	Included bool `json:"included"`
+	InPool   bool `json:"in_pool"`
+}
+
+type attestationInclusionResponse struct {
+	Data []*attestationInclusionStatus `json:"data"`
+}
+
+// newAttestationRebroadcaster returns nil when rebroadcasting is disabled.
+func newAttestationRebroadcaster(cfg *AttestationRebroadcastConfig) *attestationRebroadcaster {
+	if cfg == nil || cfg.Slots == 0 || cfg.BeaconAPIEndpoint == "" || cfg.Endpoint == "" {
+		return nil
+	}
+	return &attestationRebroadcaster{
+		slots:    cfg.Slots,
+		url:      strings.TrimSuffix(cfg.BeaconAPIEndpoint, "/"),
+		endpoint: cfg.Endpoint,
+		client:   &http.Client{Timeout: attestationInclusionTimeout},
+	}
+}
+
+// track records a submitted attestation.
+func (r *attestationRebroadcaster) track(att *ethpb.Attestation) {
+	if r == nil || att == nil || att.Data == nil {
+		return
+	}
+	r.add(&trackedAttestation{att: att})
+}
+
+// trackAggregate records a submitted aggregate.
+func (r *attestationRebroadcaster) trackAggregate(agg *ethpb.SignedAggregateAttestationAndProof) {
+	if r == nil || agg == nil || agg.Message == nil || agg.Message.Aggregate == nil || agg.Message.Aggregate.Data == nil {
+		return
+	}
+	r.add(&trackedAttestation{att: agg.Message.Aggregate, aggregate: agg})
+}
+
+func (r *attestationRebroadcaster) add(t *trackedAttestation) {
+	root, err := t.att.Data.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Debug("Could not hash attestation data, not tracking its inclusion")
+		return
+	}
+	t.dataRoot = root
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.tracked = append(r.tracked, t)
+}
+
+// due returns the tracked attestations to check at the slot, and forgets those which can't be
+// included in a block anymore.
+func (r *attestationRebroadcaster) due(slot types.Slot) []*trackedAttestation {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var due []*trackedAttestation
+	kept := r.tracked[:0]
+	for _, t := range r.tracked {
+		attSlot := t.att.Data.Slot
+		if slot > attSlot+params.BeaconConfig().SlotsPerEpoch {
+			continue
+		}
+		kept = append(kept, t)
+		if slot >= attSlot+r.slots {
+			due = append(due, t)
+		}
+	}
+	r.tracked = kept
+	return due
+}
+
+// untrack forgets the attestations, once they are included.
+func (r *attestationRebroadcaster) untrack(included map[*trackedAttestation]bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	kept := r.tracked[:0]
+	for _, t := range r.tracked {
+		if !included[t] {
+			kept = append(kept, t)
+		}
+	}
+	r.tracked = kept
+}
+
+// inclusionStatuses asks the beacon node whether the attestations were included in a block.
+func (r *attestationRebroadcaster) inclusionStatuses(ctx context.Context, tracked []*trackedAttestation) ([]*attestationInclusionStatus, error) {
+	statuses := make([]*attestationInclusionStatus, 0, len(tracked))
+	for start := 0; start < len(tracked); start += maxInclusionQueries {
+		end := start + maxInclusionQueries
+		if end > len(tracked) {
+			end = len(tracked)
+		}
+		req := &attestationInclusionRequest{Attestations: make([]*attestationInclusionQuery, 0, end-start)}
+		for _, t := range tracked[start:end] {
+			req.Attestations = append(req.Attestations, &attestationInclusionQuery{
+				Slot:            fmt.Sprintf("%d", t.att.Data.Slot),
+				CommitteeIndex:  fmt.Sprintf("%d", t.att.Data.CommitteeIndex),
+				DataRoot:        hexutil.Encode(t.dataRoot[:]),
+				AggregationBits: hexutil.Encode(t.att.AggregationBits),
+			})
+		}
+		resp, err := r.query(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Data) != end-start {
+			return nil, fmt.Errorf("got %d inclusion statuses for %d attestations", len(resp.Data), end-start)
+		}
+		statuses = append(statuses, resp.Data...)
+	}
+	return statuses, nil
+}
+
+func (r *attestationRebroadcaster) query(ctx context.Context, req *attestationInclusionRequest) (*attestationInclusionResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal attestation inclusion request")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+attestationInclusionPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := r.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if httpResp.StatusCode != http.StatusOK {
+		msg, err := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected status code %d", httpResp.StatusCode)
+		}
+		return nil, fmt.Errorf("unexpected status code %d: %s", httpResp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	resp := &attestationInclusionResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, errors.Wrap(err, "could not decode response")
+	}
+	return resp, nil
+}
+
+// RebroadcastAttestations broadcasts the attestations and aggregates submitted by the validator client
+// again through the beacon node of the rebroadcaster, when they were not included in a block within
+// the configured number of slots.
+func (v *validator) RebroadcastAttestations(ctx context.Context, slot types.Slot) {
+	r := v.attestationRebroadcaster
+	if r == nil || r.validatorClient == nil {
+		return
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.RebroadcastAttestations")
+	defer span.End()
+
+	due := r.due(slot)
+	if len(due) == 0 {
+		return
+	}
+	statuses, err := r.inclusionStatuses(ctx, due)
+	if err != nil {
+		log.WithError(err).Warn("Could not get the inclusion status of attestations")
+		return
+	}
+	included := make(map[*trackedAttestation]bool)
+	var rebroadcast, notInPool int
+	for i, t := range due {
+		if statuses[i].Included {
+			included[t] = true
+			continue
+		}
+		if !statuses[i].InPool {
+			notInPool++
+		}
+		if t.aggregate != nil {
+			_, err = r.validatorClient.SubmitSignedAggregateSelectionProof(ctx, &ethpb.SignedAggregateSubmitRequest{
+				SignedAggregateAndProof: t.aggregate,
+			})
+			if err == nil {
+				ValidatorRebroadcastCounterVec.WithLabelValues("aggregate").Inc()
+			}
+		} else {
+			_, err = r.validatorClient.ProposeAttestation(ctx, t.att)
+			if err == nil {
+				ValidatorRebroadcastCounterVec.WithLabelValues("attestation").Inc()
+			}
+		}
+		if err != nil {
+			log.WithError(err).WithField("attestationSlot", t.att.Data.Slot).Debug("Could not rebroadcast attestation")
+			continue
+		}
+		rebroadcast++
+	}
+	r.untrack(included)
+	if rebroadcast > 0 {
+		log.WithFields(logrus.Fields{
+			"slot":        slot,
+			"endpoint":    r.endpoint,
+			"rebroadcast": rebroadcast,
+			"notInPool":   notInPool,
+			"included":    len(included),
+		}).Info("Rebroadcast attestations not included in a block")
+	}
+}
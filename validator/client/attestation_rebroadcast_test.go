@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/go-bitfield"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+)
+
+func rebroadcastTestAttestation(slot uint64, bit uint64) *ethpb.Attestation {
+	bits := bitfield.NewBitlist(4)
+	bits.SetBitAt(bit, true)
+	return util.HydrateAttestation(&ethpb.Attestation{
+		Data:            util.HydrateAttestationData(&ethpb.AttestationData{Slot: types.Slot(slot)}),
+		AggregationBits: bits,
+	})
+}
+
+func TestValidator_RebroadcastAttestations(t *testing.T) {
+	// Attestations with the first bit set are included, the others are only in the pool.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, attestationInclusionPath, r.URL.Path)
+		req := &attestationInclusionRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		resp := &attestationInclusionResponse{}
+		for _, a := range req.Attestations {
+			resp.Data = append(resp.Data, &attestationInclusionStatus{Included: a.AggregationBits == "0x11", InPool: true})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	v, _, _, finish := setup(t)
+	defer finish()
+	assert.Equal(t, true, newAttestationRebroadcaster(&AttestationRebroadcastConfig{BeaconAPIEndpoint: srv.URL, Endpoint: "localhost:4001"}) == nil)
+	assert.Equal(t, true, newAttestationRebroadcaster(&AttestationRebroadcastConfig{Slots: 2, BeaconAPIEndpoint: srv.URL}) == nil)
+	r := newAttestationRebroadcaster(&AttestationRebroadcastConfig{Slots: 2, BeaconAPIEndpoint: srv.URL + "/", Endpoint: "localhost:4001"})
+	require.NotNil(t, r)
+	v.attestationRebroadcaster = r
+	// Attestations are not rebroadcast until the rebroadcaster is connected to its beacon node.
+	r.track(rebroadcastTestAttestation(1, 1))
+	v.RebroadcastAttestations(context.Background(), 3)
+	require.Equal(t, 1, len(r.tracked))
+	r.tracked = nil
+
+	// Attestations are rebroadcast through the beacon node of the rebroadcaster, not the one of the
+	// validator client which already broadcast them.
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rebroadcastClient := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	r.validatorClient = rebroadcastClient
+
+	included := rebroadcastTestAttestation(1, 0)
+	missed := rebroadcastTestAttestation(1, 1)
+	recent := rebroadcastTestAttestation(5, 1)
+	agg := &ethpb.SignedAggregateAttestationAndProof{
+		Message:   &ethpb.AggregateAttestationAndProof{Aggregate: rebroadcastTestAttestation(1, 2)},
+		Signature: make([]byte, 96),
+	}
+	r.track(included)
+	r.track(missed)
+	r.track(recent)
+	r.trackAggregate(agg)
+
+	rebroadcastClient.EXPECT().ProposeAttestation(gomock.Any(), missed).Return(&ethpb.AttestResponse{}, nil)
+	rebroadcastClient.EXPECT().SubmitSignedAggregateSelectionProof(
+		gomock.Any(),
+		&ethpb.SignedAggregateSubmitRequest{SignedAggregateAndProof: agg},
+	).Return(&ethpb.SignedAggregateSubmitResponse{}, nil)
+	v.RebroadcastAttestations(context.Background(), 3)
+	// The included attestation is not tracked anymore.
+	require.Equal(t, 3, len(r.tracked))
+
+	// Attestations which can't be included anymore are forgotten.
+	assert.Equal(t, 0, len(r.due(40)))
+	assert.Equal(t, 0, len(r.tracked))
+}
@@ -0,0 +1,44 @@
+package beacon_api
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// waitForActivationStream emulates the WaitForActivation stream by polling the status of the
+// validators once per slot.
+type waitForActivationStream struct {
+	restStream
+	client     *beaconApiValidatorClient
+	publicKeys [][]byte
+	sent       bool
+}
+
+// Recv returns the status of the validators, immediately on the first call and one slot after
+// the previous call afterwards.
+func (s *waitForActivationStream) Recv() (*ethpb.ValidatorActivationResponse, error) {
+	if s.sent {
+		select {
+		case <-time.After(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second):
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+	resp, err := s.client.getMultipleValidatorStatus(s.ctx, s.publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	s.sent = true
+
+	statuses := make([]*ethpb.ValidatorActivationResponse_Status, len(resp.PublicKeys))
+	for i, pk := range resp.PublicKeys {
+		statuses[i] = &ethpb.ValidatorActivationResponse_Status{
+			PublicKey: pk,
+			Status:    resp.Statuses[i],
+			Index:     resp.Indices[i],
+		}
+	}
+	return &ethpb.ValidatorActivationResponse{Statuses: statuses}, nil
+}
@@ -0,0 +1,93 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// getAttestationData returns the attestation data the given committee should attest to at the given slot.
+func (c *beaconApiValidatorClient) getAttestationData(ctx context.Context, slot types.Slot, committeeIndex types.CommitteeIndex) (*ethpb.AttestationData, error) {
+	resp := &consensusResponseJson{}
+	path := fmt.Sprintf("/eth/v1/validator/attestation_data?slot=%d&committee_index=%d", slot, committeeIndex)
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, path, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get attestation data")
+	}
+	data := &ethpb.AttestationData{}
+	if err := unmarshalConsensusJson(resp.Data, data); err != nil {
+		return nil, errors.Wrap(err, "could not decode attestation data")
+	}
+	return data, nil
+}
+
+// proposeAttestation publishes a signed attestation through the beacon node.
+func (c *beaconApiValidatorClient) proposeAttestation(ctx context.Context, att *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	if att == nil || att.Data == nil {
+		return nil, errors.New("attestation is nil")
+	}
+	root, err := att.Data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	body := []interface{}{marshalConsensusJson(att)}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/beacon/pool/attestations", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not publish attestation")
+	}
+	return &ethpb.AttestResponse{AttestationDataRoot: root[:]}, nil
+}
+
+// submitAggregateSelectionProof returns the aggregate of the attestations of the requested
+// committee, along with the selection proof of the aggregator. Unlike the gRPC API, the beacon
+// API does not check that the validator is an aggregator, which the validator client does anyway.
+func (c *beaconApiValidatorClient) submitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	indexResp, err := c.getValidatorIndex(ctx, in.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.getAttestationData(ctx, in.Slot, in.CommitteeIndex)
+	if err != nil {
+		return nil, err
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+
+	resp := &consensusResponseJson{}
+	path := fmt.Sprintf("/eth/v1/validator/aggregate_attestation?attestation_data_root=%s&slot=%d", hexutil.Encode(root[:]), in.Slot)
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, path, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get aggregate attestation")
+	}
+	aggregate := &ethpb.Attestation{}
+	if err := unmarshalConsensusJson(resp.Data, aggregate); err != nil {
+		return nil, errors.Wrap(err, "could not decode aggregate attestation")
+	}
+	return &ethpb.AggregateSelectionResponse{
+		AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: indexResp.Index,
+			Aggregate:       aggregate,
+			SelectionProof:  in.SlotSignature,
+		},
+	}, nil
+}
+
+// submitSignedAggregateSelectionProof publishes a signed aggregate through the beacon node.
+func (c *beaconApiValidatorClient) submitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	signed := in.SignedAggregateAndProof
+	if signed == nil || signed.Message == nil || signed.Message.Aggregate == nil || signed.Message.Aggregate.Data == nil {
+		return nil, errors.New("signed aggregate is nil")
+	}
+	root, err := signed.Message.Aggregate.Data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	body := []interface{}{marshalConsensusJson(signed)}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/validator/aggregate_and_proofs", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not publish aggregate")
+	}
+	return &ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: root[:]}, nil
+}
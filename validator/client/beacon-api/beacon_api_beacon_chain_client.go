@@ -0,0 +1,226 @@
+package beacon_api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Statuses of the validators counted as active by ListValidators.
+var activeValidatorStatuses = []string{"active_ongoing", "active_exiting", "active_slashed"}
+
+// beaconApiBeaconChainClient implements ethpb.BeaconChainClient on top of the standard beacon REST API.
+type beaconApiBeaconChainClient struct {
+	jsonRestHandler jsonRestHandler
+}
+
+// NewBeaconApiBeaconChainClient returns a beacon chain client sending its requests to the REST API
+// of the beacon node at host. Only the methods used by the validator client are supported.
+func NewBeaconApiBeaconChainClient(host string, timeout time.Duration) ethpb.BeaconChainClient {
+	return &beaconApiBeaconChainClient{
+		jsonRestHandler: jsonRestHandler{
+			httpClient: http.Client{Timeout: timeout},
+			host:       host,
+		},
+	}
+}
+
+// GetChainHead --
+func (c *beaconApiBeaconChainClient) GetChainHead(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	header := &blockHeaderResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, "/eth/v1/beacon/headers/head", header); err != nil {
+		return nil, errors.Wrap(err, "could not get head block header")
+	}
+	if header.Data == nil || header.Data.Header == nil || header.Data.Header.Message == nil {
+		return nil, errors.New("head block header is nil")
+	}
+	headSlot, err := strconv.ParseUint(header.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse head slot %s", header.Data.Header.Message.Slot)
+	}
+	headRoot, err := hexutil.Decode(header.Data.Root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode head block root %s", header.Data.Root)
+	}
+
+	checkpoints := &finalityCheckpointsResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, "/eth/v1/beacon/states/head/finality_checkpoints", checkpoints); err != nil {
+		return nil, errors.Wrap(err, "could not get finality checkpoints")
+	}
+	if checkpoints.Data == nil {
+		return nil, errors.New("finality checkpoints data is nil")
+	}
+	finalizedEpoch, finalizedSlot, finalizedRoot, err := parseCheckpoint(checkpoints.Data.Finalized)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse finalized checkpoint")
+	}
+	justifiedEpoch, justifiedSlot, justifiedRoot, err := parseCheckpoint(checkpoints.Data.CurrentJustified)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse current justified checkpoint")
+	}
+	previousJustifiedEpoch, previousJustifiedSlot, previousJustifiedRoot, err := parseCheckpoint(checkpoints.Data.PreviousJustified)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse previous justified checkpoint")
+	}
+
+	return &ethpb.ChainHead{
+		HeadSlot:                   types.Slot(headSlot),
+		HeadEpoch:                  slots.ToEpoch(types.Slot(headSlot)),
+		HeadBlockRoot:              headRoot,
+		FinalizedSlot:              finalizedSlot,
+		FinalizedEpoch:             finalizedEpoch,
+		FinalizedBlockRoot:         finalizedRoot,
+		JustifiedSlot:              justifiedSlot,
+		JustifiedEpoch:             justifiedEpoch,
+		JustifiedBlockRoot:         justifiedRoot,
+		PreviousJustifiedSlot:      previousJustifiedSlot,
+		PreviousJustifiedEpoch:     previousJustifiedEpoch,
+		PreviousJustifiedBlockRoot: previousJustifiedRoot,
+	}, nil
+}
+
+func parseCheckpoint(checkpoint *checkpointJson) (types.Epoch, types.Slot, []byte, error) {
+	if checkpoint == nil {
+		return 0, 0, nil, errors.New("checkpoint is nil")
+	}
+	epoch, err := strconv.ParseUint(checkpoint.Epoch, 10, 64)
+	if err != nil {
+		return 0, 0, nil, errors.Wrapf(err, "failed to parse epoch %s", checkpoint.Epoch)
+	}
+	root, err := hexutil.Decode(checkpoint.Root)
+	if err != nil {
+		return 0, 0, nil, errors.Wrapf(err, "failed to decode root %s", checkpoint.Root)
+	}
+	slot, err := slots.EpochStart(types.Epoch(epoch))
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return types.Epoch(epoch), slot, root, nil
+}
+
+// ListValidators only supports counting the active validators of the head state, which is what the
+// validator client uses it for. The returned validators are not populated.
+func (c *beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest, _ ...grpc.CallOption) (*ethpb.Validators, error) {
+	if !in.Active || in.PageSize != 0 || len(in.PublicKeys) > 0 || len(in.Indices) > 0 || in.QueryFilter != nil {
+		return nil, notSupported("ListValidators with filters")
+	}
+	validators, err := getStateValidators(ctx, c.jsonRestHandler, nil, activeValidatorStatuses)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get active validators")
+	}
+	return &ethpb.Validators{TotalSize: int32(len(validators))}, nil
+}
+
+// ListAttestations is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) ListAttestations(_ context.Context, _ *ethpb.ListAttestationsRequest, _ ...grpc.CallOption) (*ethpb.ListAttestationsResponse, error) {
+	return nil, notSupported("ListAttestations")
+}
+
+// ListIndexedAttestations is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) ListIndexedAttestations(_ context.Context, _ *ethpb.ListIndexedAttestationsRequest, _ ...grpc.CallOption) (*ethpb.ListIndexedAttestationsResponse, error) {
+	return nil, notSupported("ListIndexedAttestations")
+}
+
+// StreamAttestations is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) StreamAttestations(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamAttestationsClient, error) {
+	return nil, notSupported("StreamAttestations")
+}
+
+// StreamIndexedAttestations is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) StreamIndexedAttestations(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamIndexedAttestationsClient, error) {
+	return nil, notSupported("StreamIndexedAttestations")
+}
+
+// AttestationPool is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) AttestationPool(_ context.Context, _ *ethpb.AttestationPoolRequest, _ ...grpc.CallOption) (*ethpb.AttestationPoolResponse, error) {
+	return nil, notSupported("AttestationPool")
+}
+
+// ListBeaconBlocks is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) ListBeaconBlocks(_ context.Context, _ *ethpb.ListBlocksRequest, _ ...grpc.CallOption) (*ethpb.ListBeaconBlocksResponse, error) {
+	return nil, notSupported("ListBeaconBlocks")
+}
+
+// StreamBlocks is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) StreamBlocks(_ context.Context, _ *ethpb.StreamBlocksRequest, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamBlocksClient, error) {
+	return nil, notSupported("StreamBlocks")
+}
+
+// StreamChainHead is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) StreamChainHead(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamChainHeadClient, error) {
+	return nil, notSupported("StreamChainHead")
+}
+
+// ListBeaconCommittees is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) ListBeaconCommittees(_ context.Context, _ *ethpb.ListCommitteesRequest, _ ...grpc.CallOption) (*ethpb.BeaconCommittees, error) {
+	return nil, notSupported("ListBeaconCommittees")
+}
+
+// ListValidatorBalances is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) ListValidatorBalances(_ context.Context, _ *ethpb.ListValidatorBalancesRequest, _ ...grpc.CallOption) (*ethpb.ValidatorBalances, error) {
+	return nil, notSupported("ListValidatorBalances")
+}
+
+// GetValidator is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetValidator(_ context.Context, _ *ethpb.GetValidatorRequest, _ ...grpc.CallOption) (*ethpb.Validator, error) {
+	return nil, notSupported("GetValidator")
+}
+
+// GetValidatorActiveSetChanges is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetValidatorActiveSetChanges(_ context.Context, _ *ethpb.GetValidatorActiveSetChangesRequest, _ ...grpc.CallOption) (*ethpb.ActiveSetChanges, error) {
+	return nil, notSupported("GetValidatorActiveSetChanges")
+}
+
+// GetValidatorQueue is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetValidatorQueue(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.ValidatorQueue, error) {
+	return nil, notSupported("GetValidatorQueue")
+}
+
+// GetValidatorPerformance is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetValidatorPerformance(_ context.Context, _ *ethpb.ValidatorPerformanceRequest, _ ...grpc.CallOption) (*ethpb.ValidatorPerformanceResponse, error) {
+	return nil, notSupported("GetValidatorPerformance")
+}
+
+// ListValidatorAssignments is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) ListValidatorAssignments(_ context.Context, _ *ethpb.ListValidatorAssignmentsRequest, _ ...grpc.CallOption) (*ethpb.ValidatorAssignments, error) {
+	return nil, notSupported("ListValidatorAssignments")
+}
+
+// GetValidatorParticipation is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetValidatorParticipation(_ context.Context, _ *ethpb.GetValidatorParticipationRequest, _ ...grpc.CallOption) (*ethpb.ValidatorParticipationResponse, error) {
+	return nil, notSupported("GetValidatorParticipation")
+}
+
+// GetBeaconConfig is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetBeaconConfig(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.BeaconConfig, error) {
+	return nil, notSupported("GetBeaconConfig")
+}
+
+// StreamValidatorsInfo is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) StreamValidatorsInfo(_ context.Context, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamValidatorsInfoClient, error) {
+	return nil, notSupported("StreamValidatorsInfo")
+}
+
+// SubmitAttesterSlashing is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) SubmitAttesterSlashing(_ context.Context, _ *ethpb.AttesterSlashing, _ ...grpc.CallOption) (*ethpb.SubmitSlashingResponse, error) {
+	return nil, notSupported("SubmitAttesterSlashing")
+}
+
+// SubmitProposerSlashing is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) SubmitProposerSlashing(_ context.Context, _ *ethpb.ProposerSlashing, _ ...grpc.CallOption) (*ethpb.SubmitSlashingResponse, error) {
+	return nil, notSupported("SubmitProposerSlashing")
+}
+
+// GetIndividualVotes is not supported by the beacon REST API.
+func (*beaconApiBeaconChainClient) GetIndividualVotes(_ context.Context, _ *ethpb.IndividualVotesRequest, _ ...grpc.CallOption) (*ethpb.IndividualVotesRespond, error) {
+	return nil, notSupported("GetIndividualVotes")
+}
@@ -0,0 +1,100 @@
+package beacon_api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// beaconApiNodeClient implements ethpb.NodeClient on top of the standard beacon REST API.
+type beaconApiNodeClient struct {
+	jsonRestHandler jsonRestHandler
+}
+
+// NewBeaconApiNodeClient returns a node client sending its requests to the REST API of the beacon
+// node at host. Only the methods used by the validator client are supported.
+func NewBeaconApiNodeClient(host string, timeout time.Duration) ethpb.NodeClient {
+	return &beaconApiNodeClient{
+		jsonRestHandler: jsonRestHandler{
+			httpClient: http.Client{Timeout: timeout},
+			host:       host,
+		},
+	}
+}
+
+// GetSyncStatus --
+func (c *beaconApiNodeClient) GetSyncStatus(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+	resp := &syncingResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, "/eth/v1/node/syncing", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get sync status")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("sync status data is nil")
+	}
+	return &ethpb.SyncStatus{Syncing: resp.Data.IsSyncing}, nil
+}
+
+// GetGenesis --
+func (c *beaconApiNodeClient) GetGenesis(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.Genesis, error) {
+	genesis, err := getGenesis(ctx, c.jsonRestHandler)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis")
+	}
+	genesisTime, err := strconv.ParseInt(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse genesis time %s", genesis.GenesisTime)
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode genesis validators root %s", genesis.GenesisValidatorsRoot)
+	}
+	return &ethpb.Genesis{
+		GenesisTime:           timestamppb.New(time.Unix(genesisTime, 0)),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}, nil
+}
+
+// GetVersion --
+func (c *beaconApiNodeClient) GetVersion(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.Version, error) {
+	resp := &versionResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, "/eth/v1/node/version", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get version")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("version data is nil")
+	}
+	return &ethpb.Version{Version: resp.Data.Version}, nil
+}
+
+// ListImplementedServices is not supported by the beacon REST API.
+func (*beaconApiNodeClient) ListImplementedServices(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.ImplementedServices, error) {
+	return nil, notSupported("ListImplementedServices")
+}
+
+// GetHost is not supported by the beacon REST API.
+func (*beaconApiNodeClient) GetHost(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.HostData, error) {
+	return nil, notSupported("GetHost")
+}
+
+// GetPeer is not supported by the beacon REST API.
+func (*beaconApiNodeClient) GetPeer(_ context.Context, _ *ethpb.PeerRequest, _ ...grpc.CallOption) (*ethpb.Peer, error) {
+	return nil, notSupported("GetPeer")
+}
+
+// ListPeers is not supported by the beacon REST API.
+func (*beaconApiNodeClient) ListPeers(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.Peers, error) {
+	return nil, notSupported("ListPeers")
+}
+
+// GetETH1ConnectionStatus is not supported by the beacon REST API.
+func (*beaconApiNodeClient) GetETH1ConnectionStatus(_ context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.ETH1ConnectionStatus, error) {
+	return nil, notSupported("GetETH1ConnectionStatus")
+}
@@ -0,0 +1,77 @@
+package beacon_api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestNodeClient(t *testing.T) {
+	srv, _ := testServer(t, map[string]interface{}{
+		"/eth/v1/node/syncing": &syncingResponseJson{Data: &syncingJson{HeadSlot: "5", IsSyncing: true}},
+		"/eth/v1/node/version": &versionResponseJson{Data: &versionJson{Version: "Prysm/v3.1.2"}},
+		"/eth/v1/beacon/genesis": &genesisResponseJson{Data: &genesisJson{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode(bytesutil.PadTo([]byte{0x01}, 32)),
+		}},
+	})
+	client := NewBeaconApiNodeClient(srv.URL, time.Second)
+
+	syncStatus, err := client.GetSyncStatus(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, syncStatus.Syncing)
+
+	version, err := client.GetVersion(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "Prysm/v3.1.2", version.Version)
+
+	genesis, err := client.GetGenesis(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1606824023), genesis.GenesisTime.Seconds)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{0x01}, 32), genesis.GenesisValidatorsRoot)
+
+	_, err = client.ListPeers(context.Background(), &emptypb.Empty{})
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestBeaconChainClient(t *testing.T) {
+	root := hexutil.Encode(bytesutil.PadTo([]byte{0x02}, 32))
+	header := &blockHeaderResponseJson{Data: &blockHeaderContainerJson{
+		Root:   root,
+		Header: &signedBlockHeaderJson{Message: &blockHeaderJson{Slot: "65"}},
+	}}
+	srv, _ := testServer(t, map[string]interface{}{
+		"/eth/v1/beacon/headers/head": header,
+		"/eth/v1/beacon/states/head/finality_checkpoints": &finalityCheckpointsResponseJson{Data: &finalityCheckpointsJson{
+			PreviousJustified: &checkpointJson{Epoch: "0", Root: root},
+			CurrentJustified:  &checkpointJson{Epoch: "1", Root: root},
+			Finalized:         &checkpointJson{Epoch: "0", Root: root},
+		}},
+		"/eth/v1/beacon/states/head/validators?status=active_ongoing&status=active_exiting&status=active_slashed": &stateValidatorsResponseJson{
+			Data: []*validatorContainerJson{testValidator("0", testPubkey1, "active_ongoing"), testValidator("1", testPubkey2, "active_exiting")},
+		},
+	})
+	client := NewBeaconApiBeaconChainClient(srv.URL, time.Second)
+
+	head, err := client.GetChainHead(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(65), head.HeadSlot)
+	assert.Equal(t, types.Epoch(2), head.HeadEpoch)
+	assert.Equal(t, types.Epoch(1), head.JustifiedEpoch)
+	assert.Equal(t, types.Slot(32), head.JustifiedSlot)
+
+	validators, err := client.ListValidators(context.Background(), &ethpb.ListValidatorsRequest{Active: true})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), validators.TotalSize)
+
+	_, err = client.ListValidators(context.Background(), &ethpb.ListValidatorsRequest{PublicKeys: [][]byte{testPubkey1}})
+	require.ErrorIs(t, err, ErrNotSupported)
+}
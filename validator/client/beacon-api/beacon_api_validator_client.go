@@ -0,0 +1,190 @@
+package beacon_api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// beaconApiValidatorClient implements ethpb.BeaconNodeValidatorClient on top of the standard
+// beacon REST API, so that the validator client can be used with any beacon node implementing
+// the API instead of requiring Prysm's gRPC API.
+type beaconApiValidatorClient struct {
+	jsonRestHandler jsonRestHandler
+	// Beacon node events are streamed without the request timeout of the JSON handler.
+	eventsClient http.Client
+
+	genesisLock sync.Mutex
+	genesis     *genesisJson
+
+	subscriptionsLock sync.Mutex
+	subscriptions     map[subscriptionKey]*beaconCommitteeSubscribeJson
+}
+
+// NewBeaconApiValidatorClient returns a validator client sending its requests to the REST API of
+// the beacon node at host, for example http://127.0.0.1:3500.
+func NewBeaconApiValidatorClient(host string, timeout time.Duration) ethpb.BeaconNodeValidatorClient {
+	return &beaconApiValidatorClient{
+		jsonRestHandler: jsonRestHandler{
+			httpClient: http.Client{Timeout: timeout},
+			host:       host,
+		},
+		subscriptions: make(map[subscriptionKey]*beaconCommitteeSubscribeJson),
+	}
+}
+
+// GetDuties --
+func (c *beaconApiValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest, _ ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+	return c.getDuties(ctx, in)
+}
+
+// StreamDuties is not supported by the beacon REST API.
+func (*beaconApiValidatorClient) StreamDuties(_ context.Context, _ *ethpb.DutiesRequest, _ ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	return nil, notSupported("StreamDuties")
+}
+
+// DomainData --
+func (c *beaconApiValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest, _ ...grpc.CallOption) (*ethpb.DomainResponse, error) {
+	return c.getDomainData(ctx, in)
+}
+
+// WaitForChainStart --
+func (c *beaconApiValidatorClient) WaitForChainStart(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error) {
+	return &waitForChainStartStream{restStream: restStream{ctx: ctx}, client: c}, nil
+}
+
+// WaitForActivation --
+func (c *beaconApiValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest, _ ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return &waitForActivationStream{restStream: restStream{ctx: ctx}, client: c, publicKeys: in.PublicKeys}, nil
+}
+
+// ValidatorIndex --
+func (c *beaconApiValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest, _ ...grpc.CallOption) (*ethpb.ValidatorIndexResponse, error) {
+	return c.getValidatorIndex(ctx, in.PublicKey)
+}
+
+// ValidatorStatus --
+func (c *beaconApiValidatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest, _ ...grpc.CallOption) (*ethpb.ValidatorStatusResponse, error) {
+	resp, err := c.getMultipleValidatorStatus(ctx, [][]byte{in.PublicKey})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Statuses[0], nil
+}
+
+// MultipleValidatorStatus --
+func (c *beaconApiValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest, _ ...grpc.CallOption) (*ethpb.MultipleValidatorStatusResponse, error) {
+	if len(in.Indices) > 0 {
+		return nil, notSupported("MultipleValidatorStatus by index")
+	}
+	return c.getMultipleValidatorStatus(ctx, in.PublicKeys)
+}
+
+// GetBeaconBlock --
+func (c *beaconApiValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest, _ ...grpc.CallOption) (*ethpb.GenericBeaconBlock, error) {
+	return c.getBeaconBlock(ctx, in)
+}
+
+// ProposeBeaconBlock --
+func (c *beaconApiValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock, _ ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+	return c.proposeBeaconBlock(ctx, in)
+}
+
+// PrepareBeaconProposer --
+func (c *beaconApiValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if err := c.prepareBeaconProposer(ctx, in.Recipients); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+// GetAttestationData --
+func (c *beaconApiValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest, _ ...grpc.CallOption) (*ethpb.AttestationData, error) {
+	return c.getAttestationData(ctx, in.Slot, in.CommitteeIndex)
+}
+
+// ProposeAttestation --
+func (c *beaconApiValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation, _ ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+	return c.proposeAttestation(ctx, in)
+}
+
+// SubmitAggregateSelectionProof --
+func (c *beaconApiValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest, _ ...grpc.CallOption) (*ethpb.AggregateSelectionResponse, error) {
+	return c.submitAggregateSelectionProof(ctx, in)
+}
+
+// SubmitSignedAggregateSelectionProof --
+func (c *beaconApiValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest, _ ...grpc.CallOption) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return c.submitSignedAggregateSelectionProof(ctx, in)
+}
+
+// ProposeExit --
+func (c *beaconApiValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit, _ ...grpc.CallOption) (*ethpb.ProposeExitResponse, error) {
+	return c.proposeExit(ctx, in)
+}
+
+// SubscribeCommitteeSubnets --
+func (c *beaconApiValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if err := c.subscribeCommitteeSubnets(ctx, in); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+// CheckDoppelGanger is not supported by the beacon REST API.
+func (*beaconApiValidatorClient) CheckDoppelGanger(_ context.Context, _ *ethpb.DoppelGangerRequest, _ ...grpc.CallOption) (*ethpb.DoppelGangerResponse, error) {
+	return nil, notSupported("CheckDoppelGanger")
+}
+
+// GetSyncMessageBlockRoot --
+func (c *beaconApiValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.SyncMessageBlockRootResponse, error) {
+	root, err := c.getHeadBlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.SyncMessageBlockRootResponse{Root: root}, nil
+}
+
+// SubmitSyncMessage --
+func (c *beaconApiValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if err := c.submitSyncMessage(ctx, in); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+// GetSyncSubcommitteeIndex --
+func (c *beaconApiValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest, _ ...grpc.CallOption) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return c.getSyncSubcommitteeIndex(ctx, in)
+}
+
+// GetSyncCommitteeContribution --
+func (c *beaconApiValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest, _ ...grpc.CallOption) (*ethpb.SyncCommitteeContribution, error) {
+	return c.getSyncCommitteeContribution(ctx, in)
+}
+
+// SubmitSignedContributionAndProof --
+func (c *beaconApiValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if err := c.submitSignedContributionAndProof(ctx, in); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
+
+// StreamBlocksAltair --
+func (c *beaconApiValidatorClient) StreamBlocksAltair(ctx context.Context, _ *ethpb.StreamBlocksRequest, _ ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	return c.streamBlocks(ctx)
+}
+
+// SubmitValidatorRegistrations --
+func (c *beaconApiValidatorClient) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if err := c.submitValidatorRegistrations(ctx, in.Messages); err != nil {
+		return nil, err
+	}
+	return new(emptypb.Empty), nil
+}
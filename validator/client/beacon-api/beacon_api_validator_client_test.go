@@ -0,0 +1,329 @@
+package beacon_api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	testPubkey1 = bytesutil.PadTo([]byte{0x01}, 48)
+	testPubkey2 = bytesutil.PadTo([]byte{0x02}, 48)
+)
+
+// testServer returns a beacon API server answering the given paths, including their query, with
+// the JSON encoding of the associated responses. The bodies of the POST requests are recorded.
+func testServer(t *testing.T, responses map[string]interface{}) (*httptest.Server, map[string][]byte) {
+	posted := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			posted[r.URL.Path] = body
+		}
+		resp, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"code":404,"message":"not found"}`))
+			require.NoError(t, err)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, posted
+}
+
+func validatorsPath(ids ...string) string {
+	path := "/eth/v1/beacon/states/head/validators?"
+	for i, id := range ids {
+		if i > 0 {
+			path += "&"
+		}
+		path += "id=" + id
+	}
+	return path
+}
+
+func testValidator(index string, pubkey []byte, validatorStatus string) *validatorContainerJson {
+	return &validatorContainerJson{
+		Index:  index,
+		Status: validatorStatus,
+		Validator: &validatorJson{
+			PublicKey:       hexutil.Encode(pubkey),
+			ActivationEpoch: "0",
+		},
+	}
+}
+
+func TestGetDuties(t *testing.T) {
+	srv, posted := testServer(t, map[string]interface{}{
+		validatorsPath(hexutil.Encode(testPubkey1), hexutil.Encode(testPubkey2)): &stateValidatorsResponseJson{
+			Data: []*validatorContainerJson{testValidator("5", testPubkey1, "active_ongoing")},
+		},
+		"/eth/v1/validator/duties/attester/0": &attesterDutiesResponseJson{Data: []*attesterDutyJson{
+			{ValidatorIndex: "5", CommitteeIndex: "1", CommitteesAtSlot: "2", Slot: "3"},
+		}},
+		"/eth/v1/validator/duties/attester/1": &attesterDutiesResponseJson{Data: []*attesterDutyJson{
+			{ValidatorIndex: "5", CommitteeIndex: "0", CommitteesAtSlot: "2", Slot: "40"},
+		}},
+		"/eth/v1/beacon/states/head/committees?epoch=0": &stateCommitteesResponseJson{Data: []*committeeJson{
+			{Index: "0", Slot: "3", Validators: []string{"1", "2"}},
+			{Index: "1", Slot: "3", Validators: []string{"4", "5"}},
+		}},
+		"/eth/v1/beacon/states/head/committees?epoch=1": &stateCommitteesResponseJson{Data: []*committeeJson{
+			{Index: "0", Slot: "40", Validators: []string{"5", "6"}},
+		}},
+		"/eth/v1/validator/duties/proposer/0": &proposerDutiesResponseJson{Data: []*proposerDutyJson{
+			{ValidatorIndex: "5", Slot: "7"},
+			{ValidatorIndex: "6", Slot: "8"},
+		}},
+		"/eth/v1/validator/beacon_committee_subscriptions": struct{}{},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	resp, err := client.GetDuties(context.Background(), &ethpb.DutiesRequest{PublicKeys: [][]byte{testPubkey1, testPubkey2}})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.CurrentEpochDuties))
+	assert.DeepEqual(t, &ethpb.DutiesResponse_Duty{
+		PublicKey:      testPubkey1,
+		ValidatorIndex: 5,
+		Status:         ethpb.ValidatorStatus_ACTIVE,
+		AttesterSlot:   3,
+		CommitteeIndex: 1,
+		Committee:      []types.ValidatorIndex{4, 5},
+		ProposerSlots:  []types.Slot{7},
+	}, resp.CurrentEpochDuties[0])
+	assert.DeepEqual(t, &ethpb.DutiesResponse_Duty{
+		PublicKey: testPubkey2,
+		Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS,
+	}, resp.CurrentEpochDuties[1])
+	// The proposer duties of the next epoch are not known yet.
+	assert.Equal(t, types.Slot(40), resp.NextEpochDuties[0].AttesterSlot)
+	assert.Equal(t, 0, len(resp.NextEpochDuties[0].ProposerSlots))
+	assert.DeepEqual(t, []byte(`["5"]`), posted["/eth/v1/validator/duties/attester/0"])
+
+	_, err = client.SubscribeCommitteeSubnets(context.Background(), &ethpb.CommitteeSubnetsSubscribeRequest{
+		Slots:        []types.Slot{3, 40},
+		CommitteeIds: []types.CommitteeIndex{1, 0},
+		IsAggregator: []bool{true, false},
+	})
+	require.NoError(t, err)
+	var subscriptions []*beaconCommitteeSubscribeJson
+	require.NoError(t, json.Unmarshal(posted["/eth/v1/validator/beacon_committee_subscriptions"], &subscriptions))
+	assert.DeepEqual(t, []*beaconCommitteeSubscribeJson{
+		{ValidatorIndex: "5", CommitteeIndex: "1", CommitteesAtSlot: "2", Slot: "3", IsAggregator: true},
+		{ValidatorIndex: "5", CommitteeIndex: "0", CommitteesAtSlot: "2", Slot: "40", IsAggregator: false},
+	}, subscriptions)
+}
+
+func TestValidatorIndex_NotFound(t *testing.T) {
+	srv, _ := testServer(t, map[string]interface{}{
+		validatorsPath(hexutil.Encode(testPubkey1)): &stateValidatorsResponseJson{},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	_, err := client.ValidatorIndex(context.Background(), &ethpb.ValidatorIndexRequest{PublicKey: testPubkey1})
+	require.ErrorContains(t, "could not find validator index", err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMultipleValidatorStatus(t *testing.T) {
+	srv, _ := testServer(t, map[string]interface{}{
+		validatorsPath(hexutil.Encode(testPubkey1), hexutil.Encode(testPubkey2)): &stateValidatorsResponseJson{
+			Data: []*validatorContainerJson{testValidator("5", testPubkey2, "pending_queued")},
+		},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	resp, err := client.MultipleValidatorStatus(context.Background(), &ethpb.MultipleValidatorStatusRequest{PublicKeys: [][]byte{testPubkey1, testPubkey2}})
+	require.NoError(t, err)
+	assert.Equal(t, ethpb.ValidatorStatus_UNKNOWN_STATUS, resp.Statuses[0].Status)
+	assert.Equal(t, types.ValidatorIndex(^uint64(0)), resp.Indices[0])
+	assert.Equal(t, ethpb.ValidatorStatus_PENDING, resp.Statuses[1].Status)
+	assert.Equal(t, types.ValidatorIndex(5), resp.Indices[1])
+}
+
+func TestWaitForChainStart(t *testing.T) {
+	srv, _ := testServer(t, map[string]interface{}{
+		"/eth/v1/beacon/genesis": &genesisResponseJson{Data: &genesisJson{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode(bytesutil.PadTo([]byte{0x01}, 32)),
+		}},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	stream, err := client.WaitForChainStart(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Started)
+	assert.Equal(t, uint64(1606824023), resp.GenesisTime)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{0x01}, 32), resp.GenesisValidatorsRoot)
+}
+
+func TestWaitForChainStart_NotStarted(t *testing.T) {
+	srv, _ := testServer(t, map[string]interface{}{})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stream, err := client.WaitForChainStart(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.ErrorContains(t, context.DeadlineExceeded.Error(), err)
+}
+
+func TestGetBeaconBlock(t *testing.T) {
+	blk := util.NewBeaconBlockAltair().Block
+	blk.Slot = 10
+	data, err := json.Marshal(marshalConsensusJson(blk))
+	require.NoError(t, err)
+	randao := make([]byte, 96)
+	srv, _ := testServer(t, map[string]interface{}{
+		"/eth/v2/validator/blocks/10?randao_reveal=" + hexutil.Encode(randao): &consensusResponseJson{Version: "altair", Data: data},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	resp, err := client.GetBeaconBlock(context.Background(), &ethpb.BlockRequest{Slot: 10, RandaoReveal: randao})
+	require.NoError(t, err)
+	assert.DeepEqual(t, blk, resp.GetAltair())
+}
+
+func TestProposeBeaconBlock(t *testing.T) {
+	srv, posted := testServer(t, map[string]interface{}{
+		"/eth/v1/beacon/blocks": struct{}{},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	blk := util.NewBeaconBlockBellatrix()
+	resp, err := client.ProposeBeaconBlock(context.Background(), &ethpb.GenericSignedBeaconBlock{
+		Block: &ethpb.GenericSignedBeaconBlock_Bellatrix{Bellatrix: blk},
+	})
+	require.NoError(t, err)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, root[:], resp.BlockRoot)
+
+	decoded := &ethpb.SignedBeaconBlockBellatrix{}
+	require.NoError(t, unmarshalConsensusJson(posted["/eth/v1/beacon/blocks"], decoded))
+	assert.DeepEqual(t, blk, decoded)
+}
+
+func TestSubmitAggregateSelectionProof(t *testing.T) {
+	data := util.HydrateAttestation(&ethpb.Attestation{}).Data
+	data.Slot = 3
+	data.CommitteeIndex = 1
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	dataJson, err := json.Marshal(marshalConsensusJson(data))
+	require.NoError(t, err)
+	aggregate := &ethpb.Attestation{AggregationBits: []byte{0b111}, Data: data, Signature: make([]byte, 96)}
+	aggregateJson, err := json.Marshal(marshalConsensusJson(aggregate))
+	require.NoError(t, err)
+
+	srv, _ := testServer(t, map[string]interface{}{
+		validatorsPath(hexutil.Encode(testPubkey1)): &stateValidatorsResponseJson{
+			Data: []*validatorContainerJson{testValidator("5", testPubkey1, "active_ongoing")},
+		},
+		"/eth/v1/validator/attestation_data?slot=3&committee_index=1":                                          &consensusResponseJson{Data: dataJson},
+		"/eth/v1/validator/aggregate_attestation?attestation_data_root=" + hexutil.Encode(root[:]) + "&slot=3": &consensusResponseJson{Data: aggregateJson},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	selectionProof := bytesutil.PadTo([]byte{0x09}, 96)
+	resp, err := client.SubmitAggregateSelectionProof(context.Background(), &ethpb.AggregateSelectionRequest{
+		Slot:           3,
+		CommitteeIndex: 1,
+		PublicKey:      testPubkey1,
+		SlotSignature:  selectionProof,
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.AggregateAttestationAndProof{
+		AggregatorIndex: 5,
+		Aggregate:       aggregate,
+		SelectionProof:  selectionProof,
+	}, resp.AggregateAndProof)
+}
+
+func TestProposeAttestation(t *testing.T) {
+	srv, posted := testServer(t, map[string]interface{}{
+		"/eth/v1/beacon/pool/attestations": struct{}{},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: []byte{0b101}})
+	resp, err := client.ProposeAttestation(context.Background(), att)
+	require.NoError(t, err)
+	root, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, root[:], resp.AttestationDataRoot)
+
+	var body []json.RawMessage
+	require.NoError(t, json.Unmarshal(posted["/eth/v1/beacon/pool/attestations"], &body))
+	require.Equal(t, 1, len(body))
+	decoded := &ethpb.Attestation{}
+	require.NoError(t, unmarshalConsensusJson(body[0], decoded))
+	assert.DeepEqual(t, att, decoded)
+}
+
+func TestGetSyncSubcommitteeIndex(t *testing.T) {
+	srv, _ := testServer(t, map[string]interface{}{
+		validatorsPath(hexutil.Encode(testPubkey1)): &stateValidatorsResponseJson{
+			Data: []*validatorContainerJson{testValidator("5", testPubkey1, "active_ongoing")},
+		},
+		"/eth/v1/validator/duties/sync/0": &syncCommitteeDutiesResponseJson{Data: []*syncCommitteeDutyJson{
+			{ValidatorIndex: "5", ValidatorSyncCommitteeIndices: []string{"1", "130", "511"}},
+		}},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	resp, err := client.GetSyncSubcommitteeIndex(context.Background(), &ethpb.SyncSubcommitteeIndexRequest{PublicKey: testPubkey1, Slot: 1})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []types.CommitteeIndex{0, 1, 3}, resp.Indices)
+}
+
+func TestGetSyncCommitteeContribution(t *testing.T) {
+	headRoot := bytesutil.PadTo([]byte{0x03}, 32)
+	contribution := &ethpb.SyncCommitteeContribution{
+		Slot:              1,
+		BlockRoot:         headRoot,
+		SubcommitteeIndex: 2,
+		AggregationBits:   make([]byte, 16),
+		Signature:         make([]byte, 96),
+	}
+	contributionJson, err := json.Marshal(marshalConsensusJson(contribution))
+	require.NoError(t, err)
+	srv, _ := testServer(t, map[string]interface{}{
+		"/eth/v1/beacon/blocks/head/root": &blockRootResponseJson{Data: &blockRootJson{Root: hexutil.Encode(headRoot)}},
+		"/eth/v1/validator/sync_committee_contribution?slot=1&subcommittee_index=2&beacon_block_root=" + hexutil.Encode(headRoot): &consensusResponseJson{Data: contributionJson},
+	})
+	client := NewBeaconApiValidatorClient(srv.URL, time.Second)
+
+	resp, err := client.GetSyncCommitteeContribution(context.Background(), &ethpb.SyncCommitteeContributionRequest{Slot: 1, SubnetId: 2})
+	require.NoError(t, err)
+	assert.DeepEqual(t, contribution, resp)
+}
+
+func TestUnsupportedMethods(t *testing.T) {
+	client := NewBeaconApiValidatorClient("http://localhost:3500", time.Second)
+	_, err := client.StreamDuties(context.Background(), &ethpb.DutiesRequest{})
+	require.ErrorIs(t, err, ErrNotSupported)
+	_, err = client.CheckDoppelGanger(context.Background(), &ethpb.DoppelGangerRequest{})
+	require.ErrorIs(t, err, ErrNotSupported)
+}
@@ -0,0 +1,83 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"google.golang.org/protobuf/proto"
+)
+
+// getBeaconBlock asks the beacon node to produce an unsigned block for the requested slot.
+func (c *beaconApiValidatorClient) getBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	query := url.Values{}
+	query.Set("randao_reveal", hexutil.Encode(in.RandaoReveal))
+	if len(in.Graffiti) > 0 {
+		query.Set("graffiti", hexutil.Encode(in.Graffiti))
+	}
+	resp := &consensusResponseJson{}
+	path := fmt.Sprintf("/eth/v2/validator/blocks/%d?%s", in.Slot, query.Encode())
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, path, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get beacon block")
+	}
+
+	switch resp.Version {
+	case version.String(version.Phase0):
+		block := &ethpb.BeaconBlock{}
+		if err := unmarshalConsensusJson(resp.Data, block); err != nil {
+			return nil, errors.Wrap(err, "could not decode phase0 block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: block}}, nil
+	case version.String(version.Altair):
+		block := &ethpb.BeaconBlockAltair{}
+		if err := unmarshalConsensusJson(resp.Data, block); err != nil {
+			return nil, errors.Wrap(err, "could not decode altair block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Altair{Altair: block}}, nil
+	case version.String(version.Bellatrix):
+		block := &ethpb.BeaconBlockBellatrix{}
+		if err := unmarshalConsensusJson(resp.Data, block); err != nil {
+			return nil, errors.Wrap(err, "could not decode bellatrix block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Bellatrix{Bellatrix: block}}, nil
+	default:
+		return nil, errors.Errorf("unsupported block version %s", resp.Version)
+	}
+}
+
+// proposeBeaconBlock publishes a signed block through the beacon node.
+func (c *beaconApiValidatorClient) proposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	var signedBlock proto.Message
+	var blockRoot [32]byte
+	var err error
+	path := "/eth/v1/beacon/blocks"
+	switch b := in.Block.(type) {
+	case *ethpb.GenericSignedBeaconBlock_Phase0:
+		signedBlock = b.Phase0
+		blockRoot, err = b.Phase0.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_Altair:
+		signedBlock = b.Altair
+		blockRoot, err = b.Altair.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_Bellatrix:
+		signedBlock = b.Bellatrix
+		blockRoot, err = b.Bellatrix.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_BlindedBellatrix:
+		signedBlock = b.BlindedBellatrix
+		blockRoot, err = b.BlindedBellatrix.Block.HashTreeRoot()
+		path = "/eth/v1/beacon/blinded_blocks"
+	default:
+		return nil, errors.Errorf("unsupported block type %T", in.Block)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block root")
+	}
+
+	if err := c.jsonRestHandler.postRestJson(ctx, path, marshalConsensusJson(signedBlock), nil); err != nil {
+		return nil, errors.Wrap(err, "could not publish block")
+	}
+	return &ethpb.ProposeResponse{BlockRoot: blockRoot[:]}, nil
+}
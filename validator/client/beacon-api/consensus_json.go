@@ -0,0 +1,207 @@
+package beacon_api
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Consensus types are converted to and from the JSON representation used by the beacon API
+// by walking their protobuf descriptors: field names are the snake_case protobuf names,
+// integers are encoded as decimal strings and byte arrays as 0x-prefixed hex strings.
+
+// jsonFieldNames maps the consensus type fields whose beacon API name differs from their protobuf name.
+var jsonFieldNames = map[protoreflect.FullName]string{
+	"ethereum.eth.v1alpha1.AttestationData.committee_index":         "index",
+	"ethereum.eth.v1alpha1.Deposit.Data.public_key":                 "pubkey",
+	"ethereum.eth.v1alpha1.SignedVoluntaryExit.exit":                "message",
+	"ethereum.eth.v1alpha1.SignedBeaconBlockHeader.header":          "message",
+	"ethereum.eth.v1alpha1.SignedBeaconBlock.block":                 "message",
+	"ethereum.eth.v1alpha1.SignedBeaconBlockAltair.block":           "message",
+	"ethereum.eth.v1alpha1.SignedBeaconBlockBellatrix.block":        "message",
+	"ethereum.eth.v1alpha1.SignedBlindedBeaconBlockBellatrix.block": "message",
+	"ethereum.eth.v1alpha1.SyncCommitteeMessage.block_root":         "beacon_block_root",
+	"ethereum.eth.v1alpha1.SyncCommitteeContribution.block_root":    "beacon_block_root",
+}
+
+// Fields holding a little-endian uint256 in protobuf and a decimal string in the beacon API.
+var uint256Fields = map[protoreflect.Name]bool{
+	"base_fee_per_gas": true,
+}
+
+func jsonFieldName(fd protoreflect.FieldDescriptor) string {
+	if name, ok := jsonFieldNames[fd.FullName()]; ok {
+		return name
+	}
+	return string(fd.Name())
+}
+
+// marshalConsensusJson returns the beacon API JSON representation of a consensus type.
+func marshalConsensusJson(m proto.Message) map[string]interface{} {
+	return messageToJson(m.ProtoReflect())
+}
+
+// unmarshalConsensusJson decodes the beacon API JSON representation of a consensus type into m.
+func unmarshalConsensusJson(data json.RawMessage, m proto.Message) error {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return errors.Wrap(err, "failed to decode JSON object")
+	}
+	return jsonToMessage(obj, m.ProtoReflect())
+}
+
+func messageToJson(m protoreflect.Message) map[string]interface{} {
+	obj := make(map[string]interface{})
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		v := m.Get(fd)
+		if fd.IsList() {
+			list := v.List()
+			arr := make([]interface{}, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				arr[j] = valueToJson(fd, list.Get(j))
+			}
+			obj[jsonFieldName(fd)] = arr
+			continue
+		}
+		obj[jsonFieldName(fd)] = valueToJson(fd, v)
+	}
+	return obj
+}
+
+func valueToJson(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		return messageToJson(v.Message())
+	case protoreflect.BytesKind:
+		if uint256Fields[fd.Name()] {
+			return new(big.Int).SetBytes(bytesutil.ReverseByteOrder(v.Bytes())).String()
+		}
+		return hexutil.Encode(v.Bytes())
+	case protoreflect.Uint64Kind, protoreflect.Uint32Kind, protoreflect.Fixed64Kind, protoreflect.Fixed32Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.Int64Kind, protoreflect.Int32Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	default:
+		return v.Interface()
+	}
+}
+
+func jsonToMessage(obj map[string]interface{}, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := jsonFieldName(fd)
+		raw, ok := obj[name]
+		if !ok || raw == nil {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			arr, ok := raw.([]interface{})
+			if !ok {
+				return errors.Errorf("field %s is not an array", name)
+			}
+			list := m.Mutable(fd).List()
+			for _, item := range arr {
+				if fd.Kind() == protoreflect.MessageKind {
+					elem := list.NewElement()
+					if err := jsonToField(fd, item, elem.Message()); err != nil {
+						return err
+					}
+					list.Append(elem)
+					continue
+				}
+				v, err := jsonToValue(fd, item)
+				if err != nil {
+					return err
+				}
+				list.Append(v)
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			if err := jsonToField(fd, raw, m.Mutable(fd).Message()); err != nil {
+				return err
+			}
+		default:
+			v, err := jsonToValue(fd, raw)
+			if err != nil {
+				return err
+			}
+			m.Set(fd, v)
+		}
+	}
+	return nil
+}
+
+func jsonToField(fd protoreflect.FieldDescriptor, raw interface{}, m protoreflect.Message) error {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("field %s is not an object", jsonFieldName(fd))
+	}
+	return errors.Wrap(jsonToMessage(obj, m), jsonFieldName(fd))
+}
+
+func jsonToValue(fd protoreflect.FieldDescriptor, raw interface{}) (protoreflect.Value, error) {
+	name := jsonFieldName(fd)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, ok := raw.(bool)
+		if !ok {
+			return protoreflect.Value{}, errors.Errorf("field %s is not a boolean", name)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.StringKind:
+		s, ok := raw.(string)
+		if !ok {
+			return protoreflect.Value{}, errors.Errorf("field %s is not a string", name)
+		}
+		return protoreflect.ValueOfString(s), nil
+	}
+
+	s, ok := raw.(string)
+	if !ok {
+		return protoreflect.Value{}, errors.Errorf("field %s is not a string", name)
+	}
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		if uint256Fields[fd.Name()] {
+			n, ok := new(big.Int).SetString(s, 10)
+			if !ok {
+				return protoreflect.Value{}, errors.Errorf("field %s is not a decimal number", name)
+			}
+			return protoreflect.ValueOfBytes(bytesutil.PadTo(bytesutil.ReverseByteOrder(n.Bytes()), 32)), nil
+		}
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return protoreflect.Value{}, errors.Wrapf(err, "field %s is not a hex string", name)
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, errors.Wrapf(err, "field %s is not an unsigned integer", name)
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, errors.Wrapf(err, "field %s is not an unsigned integer", name)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Int64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, errors.Wrapf(err, "field %s is not an integer", name)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	default:
+		return protoreflect.Value{}, errors.Errorf("field %s has unsupported type %s", name, fd.Kind())
+	}
+}
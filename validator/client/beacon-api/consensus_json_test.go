@@ -0,0 +1,138 @@
+package beacon_api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v3/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v3/proto/migration"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// The protobuf types of Prysm's own beacon API (proto/eth/v1 and proto/eth/v2) use the field names of
+// the API specification, so the JSON produced for a v1alpha1 type must match the JSON produced for
+// its counterpart in these packages.
+func assertConformance(t *testing.T, alpha proto.Message, api proto.Message) {
+	assert.DeepEqual(t, marshalConsensusJson(api), marshalConsensusJson(alpha))
+
+	data, err := json.Marshal(marshalConsensusJson(alpha))
+	require.NoError(t, err)
+	decoded := alpha.ProtoReflect().New().Interface()
+	require.NoError(t, unmarshalConsensusJson(data, decoded))
+	assert.Equal(t, true, proto.Equal(alpha, decoded))
+}
+
+func TestConsensusJson_Phase0Block(t *testing.T) {
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 123
+	blk.Block.ProposerIndex = 456
+	blk.Block.Body.Graffiti = bytesutil.PadTo([]byte("graffiti"), 32)
+	blk.Block.Body.Attestations = []*ethpb.Attestation{util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: []byte{0b1101},
+		Data:            &ethpb.AttestationData{Slot: 122, CommitteeIndex: 3},
+	})}
+	v1Blk, err := migration.V1Alpha1ToV1SignedBlock(blk)
+	require.NoError(t, err)
+	assertConformance(t, blk.Block, v1Blk.Block)
+	assert.DeepEqual(t, marshalConsensusJson(blk.Block), marshalConsensusJson(blk)["message"])
+}
+
+func TestConsensusJson_AltairBlock(t *testing.T) {
+	blk := util.NewBeaconBlockAltair()
+	blk.Block.Slot = 123
+	blk.Block.Body.SyncAggregate.SyncCommitteeBits[0] = 0xff
+	v2Blk, err := migration.V1Alpha1BeaconBlockAltairToV2(blk.Block)
+	require.NoError(t, err)
+	assertConformance(t, blk.Block, v2Blk)
+	assertConformance(t, blk, &ethpbv2.SignedBeaconBlockAltair{Message: v2Blk, Signature: blk.Signature})
+}
+
+func TestConsensusJson_BellatrixBlock(t *testing.T) {
+	blk := util.NewBeaconBlockBellatrix()
+	blk.Block.Slot = 123
+	blk.Block.Body.ExecutionPayload.BlockNumber = 789
+	blk.Block.Body.ExecutionPayload.BaseFeePerGas = bytesutil.PadTo([]byte{0x01, 0x02}, 32)
+	blk.Block.Body.ExecutionPayload.Transactions = [][]byte{{0x01, 0x02, 0x03}}
+	v2Blk, err := migration.V1Alpha1BeaconBlockBellatrixToV2(blk.Block)
+	require.NoError(t, err)
+	assertConformance(t, blk.Block, v2Blk)
+
+	obj := marshalConsensusJson(blk.Block)
+	payload := obj["body"].(map[string]interface{})["execution_payload"].(map[string]interface{})
+	assert.Equal(t, "513", payload["base_fee_per_gas"])
+	assert.Equal(t, "789", payload["block_number"])
+}
+
+func TestConsensusJson_VoluntaryExit(t *testing.T) {
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 7},
+		Signature: make([]byte, 96),
+	}
+	assertConformance(t, exit, migration.V1Alpha1ExitToV1(exit))
+}
+
+func TestConsensusJson_SignedAggregateAttestationAndProof(t *testing.T) {
+	agg := &ethpb.SignedAggregateAttestationAndProof{
+		Message: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: 3,
+			Aggregate:       util.HydrateAttestation(&ethpb.Attestation{AggregationBits: []byte{0b11}}),
+			SelectionProof:  make([]byte, 96),
+		},
+		Signature: make([]byte, 96),
+	}
+	v1Agg := &ethpbv1.SignedAggregateAttestationAndProof{
+		Message:   migration.V1Alpha1AggregateAttAndProofToV1(agg.Message),
+		Signature: agg.Signature,
+	}
+	assertConformance(t, agg, v1Agg)
+}
+
+func TestConsensusJson_SignedContributionAndProof(t *testing.T) {
+	contribution := &ethpb.SignedContributionAndProof{
+		Message: &ethpb.ContributionAndProof{
+			AggregatorIndex: 1,
+			Contribution: &ethpb.SyncCommitteeContribution{
+				Slot:              2,
+				BlockRoot:         make([]byte, 32),
+				SubcommitteeIndex: 3,
+				AggregationBits:   make([]byte, 16),
+				Signature:         make([]byte, 96),
+			},
+			SelectionProof: make([]byte, 96),
+		},
+		Signature: make([]byte, 96),
+	}
+	assertConformance(t, contribution, migration.V1Alpha1SignedContributionAndProofToV2(contribution))
+}
+
+func TestConsensusJson_SyncCommitteeMessage(t *testing.T) {
+	msg := &ethpb.SyncCommitteeMessage{
+		Slot:           1,
+		BlockRoot:      make([]byte, 32),
+		ValidatorIndex: 2,
+		Signature:      make([]byte, 96),
+	}
+	v2Msg := &ethpbv2.SyncCommitteeMessage{
+		Slot:            msg.Slot,
+		BeaconBlockRoot: msg.BlockRoot,
+		ValidatorIndex:  msg.ValidatorIndex,
+		Signature:       msg.Signature,
+	}
+	assertConformance(t, msg, v2Msg)
+}
+
+func TestUnmarshalConsensusJson_InvalidField(t *testing.T) {
+	data := []byte(`{"slot":"abc","index":"1"}`)
+	err := unmarshalConsensusJson(data, &ethpb.AttestationData{})
+	require.ErrorContains(t, "field slot is not an unsigned integer", err)
+
+	data = []byte(`{"slot":1}`)
+	err = unmarshalConsensusJson(data, &ethpb.AttestationData{})
+	require.ErrorContains(t, "field slot is not a string", err)
+}
@@ -0,0 +1,34 @@
+package beacon_api
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// getDomainData computes the signature domain of an epoch from the fork schedule of the local
+// configuration and the genesis validators root of the beacon node.
+func (c *beaconApiValidatorClient) getDomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	fork, err := forks.Fork(in.Epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get fork of epoch %d", in.Epoch)
+	}
+	genesis, err := c.getGenesis(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis")
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode genesis validators root %s", genesis.GenesisValidatorsRoot)
+	}
+	domain, err := signing.Domain(fork, in.Epoch, bytesutil.ToBytes4(in.Domain), genesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signature domain")
+	}
+	return &ethpb.DomainResponse{SignatureDomain: domain}, nil
+}
@@ -0,0 +1,300 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// subscriptionKey identifies the committee of an attester duty.
+type subscriptionKey struct {
+	slot           types.Slot
+	committeeIndex types.CommitteeIndex
+}
+
+type syncCommitteeSubscriptionJson struct {
+	ValidatorIndex       string   `json:"validator_index"`
+	SyncCommitteeIndices []string `json:"sync_committee_indices"`
+	UntilEpoch           string   `json:"until_epoch"`
+}
+
+// getDuties returns the duties of the given validators for the requested epoch and the next one.
+// Like the gRPC API, it also subscribes the beacon node to the sync committee subnets of the
+// validators that are part of a sync committee.
+func (c *beaconApiValidatorClient) getDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	validators, err := c.getValidatorsByPublicKey(ctx, in.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	currentEpochDuties, err := c.getDutiesForEpoch(ctx, in.Epoch, in.PublicKeys, validators, false /* nextEpoch */)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get duties for epoch %d", in.Epoch)
+	}
+	nextEpochDuties, err := c.getDutiesForEpoch(ctx, in.Epoch+1, in.PublicKeys, validators, true /* nextEpoch */)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get duties for epoch %d", in.Epoch+1)
+	}
+	c.pruneSubscriptions(in.Epoch)
+	return &ethpb.DutiesResponse{
+		CurrentEpochDuties: currentEpochDuties,
+		NextEpochDuties:    nextEpochDuties,
+	}, nil
+}
+
+func (c *beaconApiValidatorClient) getDutiesForEpoch(
+	ctx context.Context,
+	epoch types.Epoch,
+	publicKeys [][]byte,
+	validators map[string]*validatorContainerJson,
+	nextEpoch bool,
+) ([]*ethpb.DutiesResponse_Duty, error) {
+	duties := make([]*ethpb.DutiesResponse_Duty, len(publicKeys))
+	dutiesByIndex := make(map[types.ValidatorIndex]*ethpb.DutiesResponse_Duty, len(publicKeys))
+	indices := make([]string, 0, len(publicKeys))
+	for i, pk := range publicKeys {
+		duties[i] = &ethpb.DutiesResponse_Duty{
+			PublicKey: pk,
+			Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS,
+		}
+		v, ok := validators[hexutil.Encode(pk)]
+		if !ok {
+			continue
+		}
+		index, validatorStatus, err := parseValidator(v)
+		if err != nil {
+			return nil, err
+		}
+		duties[i].ValidatorIndex = index
+		duties[i].Status = validatorStatus
+		dutiesByIndex[index] = duties[i]
+		indices = append(indices, v.Index)
+	}
+	if len(indices) == 0 {
+		return duties, nil
+	}
+
+	if err := c.addAttesterDuties(ctx, epoch, indices, dutiesByIndex); err != nil {
+		return nil, err
+	}
+	if err := c.addProposerDuties(ctx, epoch, dutiesByIndex, nextEpoch); err != nil {
+		return nil, err
+	}
+	if epoch >= params.BeaconConfig().AltairForkEpoch {
+		if err := c.addSyncCommitteeDuties(ctx, epoch, indices, dutiesByIndex); err != nil {
+			return nil, err
+		}
+	}
+	return duties, nil
+}
+
+// addAttesterDuties sets the attester slot and committee of the given validators.
+func (c *beaconApiValidatorClient) addAttesterDuties(
+	ctx context.Context,
+	epoch types.Epoch,
+	indices []string,
+	dutiesByIndex map[types.ValidatorIndex]*ethpb.DutiesResponse_Duty,
+) error {
+	attesterDuties := &attesterDutiesResponseJson{}
+	if err := c.jsonRestHandler.postRestJson(ctx, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), indices, attesterDuties); err != nil {
+		return errors.Wrap(err, "could not get attester duties")
+	}
+	if len(attesterDuties.Data) == 0 {
+		return nil
+	}
+	committees, err := c.getCommittees(ctx, epoch)
+	if err != nil {
+		return err
+	}
+
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+	for _, d := range attesterDuties.Data {
+		index, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse validator index %s", d.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(d.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse attester slot %s", d.Slot)
+		}
+		committeeIndex, err := strconv.ParseUint(d.CommitteeIndex, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse committee index %s", d.CommitteeIndex)
+		}
+		duty, ok := dutiesByIndex[types.ValidatorIndex(index)]
+		if !ok {
+			continue
+		}
+		key := subscriptionKey{slot: types.Slot(slot), committeeIndex: types.CommitteeIndex(committeeIndex)}
+		committee, ok := committees[key]
+		if !ok {
+			return errors.Errorf("could not find committee %d of slot %d", committeeIndex, slot)
+		}
+		duty.AttesterSlot = key.slot
+		duty.CommitteeIndex = key.committeeIndex
+		duty.Committee = committee
+		c.subscriptions[key] = &beaconCommitteeSubscribeJson{
+			ValidatorIndex:   d.ValidatorIndex,
+			CommitteeIndex:   d.CommitteeIndex,
+			CommitteesAtSlot: d.CommitteesAtSlot,
+			Slot:             d.Slot,
+		}
+	}
+	return nil
+}
+
+// getCommittees returns the members of all the beacon committees of an epoch.
+func (c *beaconApiValidatorClient) getCommittees(ctx context.Context, epoch types.Epoch) (map[subscriptionKey][]types.ValidatorIndex, error) {
+	resp := &stateCommitteesResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, fmt.Sprintf("/eth/v1/beacon/states/head/committees?epoch=%d", epoch), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get committees")
+	}
+	committees := make(map[subscriptionKey][]types.ValidatorIndex, len(resp.Data))
+	for _, committee := range resp.Data {
+		if committee == nil {
+			return nil, errors.New("committee is nil")
+		}
+		slot, err := strconv.ParseUint(committee.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse committee slot %s", committee.Slot)
+		}
+		committeeIndex, err := strconv.ParseUint(committee.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse committee index %s", committee.Index)
+		}
+		members := make([]types.ValidatorIndex, len(committee.Validators))
+		for i, v := range committee.Validators {
+			index, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse committee member %s", v)
+			}
+			members[i] = types.ValidatorIndex(index)
+		}
+		committees[subscriptionKey{slot: types.Slot(slot), committeeIndex: types.CommitteeIndex(committeeIndex)}] = members
+	}
+	return committees, nil
+}
+
+// addProposerDuties sets the proposer slots of the given validators. Beacon nodes are not required
+// to serve the proposer duties of the next epoch, in which case these are left empty.
+func (c *beaconApiValidatorClient) addProposerDuties(
+	ctx context.Context,
+	epoch types.Epoch,
+	dutiesByIndex map[types.ValidatorIndex]*ethpb.DutiesResponse_Duty,
+	nextEpoch bool,
+) error {
+	proposerDuties := &proposerDutiesResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), proposerDuties); err != nil {
+		if nextEpoch {
+			log.WithError(err).WithField("epoch", epoch).Debug("Could not get proposer duties of next epoch")
+			return nil
+		}
+		return errors.Wrap(err, "could not get proposer duties")
+	}
+	for _, d := range proposerDuties.Data {
+		index, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse validator index %s", d.ValidatorIndex)
+		}
+		duty, ok := dutiesByIndex[types.ValidatorIndex(index)]
+		if !ok {
+			continue
+		}
+		slot, err := strconv.ParseUint(d.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse proposer slot %s", d.Slot)
+		}
+		duty.ProposerSlots = append(duty.ProposerSlots, types.Slot(slot))
+	}
+	return nil
+}
+
+// addSyncCommitteeDuties flags the given validators that are part of the sync committee of an epoch
+// and subscribes the beacon node to their sync committee subnets until the end of the period.
+func (c *beaconApiValidatorClient) addSyncCommitteeDuties(
+	ctx context.Context,
+	epoch types.Epoch,
+	indices []string,
+	dutiesByIndex map[types.ValidatorIndex]*ethpb.DutiesResponse_Duty,
+) error {
+	syncDuties := &syncCommitteeDutiesResponseJson{}
+	if err := c.jsonRestHandler.postRestJson(ctx, fmt.Sprintf("/eth/v1/validator/duties/sync/%d", epoch), indices, syncDuties); err != nil {
+		return errors.Wrap(err, "could not get sync committee duties")
+	}
+	if len(syncDuties.Data) == 0 {
+		return nil
+	}
+	epochsPerPeriod := params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	untilEpoch := strconv.FormatUint(uint64((epoch/epochsPerPeriod+1)*epochsPerPeriod), 10)
+	subscriptions := make([]*syncCommitteeSubscriptionJson, 0, len(syncDuties.Data))
+	for _, d := range syncDuties.Data {
+		index, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse validator index %s", d.ValidatorIndex)
+		}
+		if duty, ok := dutiesByIndex[types.ValidatorIndex(index)]; ok {
+			duty.IsSyncCommittee = true
+		}
+		subscriptions = append(subscriptions, &syncCommitteeSubscriptionJson{
+			ValidatorIndex:       d.ValidatorIndex,
+			SyncCommitteeIndices: d.ValidatorSyncCommitteeIndices,
+			UntilEpoch:           untilEpoch,
+		})
+	}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/validator/sync_committee_subscriptions", subscriptions, nil); err != nil {
+		log.WithError(err).Error("Could not subscribe to sync committee subnets")
+	}
+	return nil
+}
+
+// subscribeCommitteeSubnets subscribes the beacon node to the attestation subnets of the given
+// committees. The beacon API identifies a subscription by the index of one of the committee
+// members, which is known from the attester duties fetched beforehand.
+func (c *beaconApiValidatorClient) subscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest) error {
+	if len(in.Slots) != len(in.CommitteeIds) || len(in.Slots) != len(in.IsAggregator) {
+		return errors.New("slots, committee ids and aggregator flags must have the same length")
+	}
+	c.subscriptionsLock.Lock()
+	subscriptions := make([]*beaconCommitteeSubscribeJson, 0, len(in.Slots))
+	for i, slot := range in.Slots {
+		sub, ok := c.subscriptions[subscriptionKey{slot: slot, committeeIndex: in.CommitteeIds[i]}]
+		if !ok {
+			log.WithFields(map[string]interface{}{
+				"slot":           slot,
+				"committeeIndex": in.CommitteeIds[i],
+			}).Warn("Could not find attester duty of committee, not subscribing to its subnet")
+			continue
+		}
+		subscription := *sub
+		subscription.IsAggregator = in.IsAggregator[i]
+		subscriptions = append(subscriptions, &subscription)
+	}
+	c.subscriptionsLock.Unlock()
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	return c.jsonRestHandler.postRestJson(ctx, "/eth/v1/validator/beacon_committee_subscriptions", subscriptions, nil)
+}
+
+// pruneSubscriptions forgets the committees of the attester duties prior to the given epoch.
+func (c *beaconApiValidatorClient) pruneSubscriptions(epoch types.Epoch) {
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return
+	}
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+	for key := range c.subscriptions {
+		if key.slot < startSlot {
+			delete(c.subscriptions, key)
+		}
+	}
+}
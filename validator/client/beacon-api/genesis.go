@@ -0,0 +1,79 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// Interval at which the genesis endpoint is polled while waiting for the beacon chain to start.
+var chainStartPollInterval = 10 * time.Second
+
+// getGenesis queries the genesis of the beacon chain. The beacon node answers with a 404 status
+// code until the chain has started.
+func getGenesis(ctx context.Context, handler jsonRestHandler) (*genesisJson, error) {
+	resp := &genesisResponseJson{}
+	if err := handler.getRestJsonResponse(ctx, "/eth/v1/beacon/genesis", resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("genesis data is nil")
+	}
+	return resp.Data, nil
+}
+
+// getGenesis returns the genesis of the beacon chain, which is cached once known.
+func (c *beaconApiValidatorClient) getGenesis(ctx context.Context) (*genesisJson, error) {
+	c.genesisLock.Lock()
+	defer c.genesisLock.Unlock()
+	if c.genesis != nil {
+		return c.genesis, nil
+	}
+	genesis, err := getGenesis(ctx, c.jsonRestHandler)
+	if err != nil {
+		return nil, err
+	}
+	c.genesis = genesis
+	return genesis, nil
+}
+
+// waitForChainStartStream emulates the WaitForChainStart stream by polling the genesis
+// endpoint until the beacon chain has started.
+type waitForChainStartStream struct {
+	restStream
+	client *beaconApiValidatorClient
+}
+
+// Recv blocks until the beacon chain has started.
+func (s *waitForChainStartStream) Recv() (*ethpb.ChainStartResponse, error) {
+	for {
+		genesis, err := s.client.getGenesis(s.ctx)
+		if err == nil {
+			genesisTime, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse genesis time %s", genesis.GenesisTime)
+			}
+			genesisValidatorsRoot, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode genesis validators root %s", genesis.GenesisValidatorsRoot)
+			}
+			return &ethpb.ChainStartResponse{
+				Started:               true,
+				GenesisTime:           genesisTime,
+				GenesisValidatorsRoot: genesisValidatorsRoot,
+			}, nil
+		}
+		if !isNotFound(err) {
+			return nil, err
+		}
+		select {
+		case <-time.After(chainStartPollInterval):
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package beacon_api
+
+import "encoding/json"
+
+// The types below mirror the JSON objects of the standard beacon REST API that are not consensus
+// types. Consensus types are decoded with unmarshalConsensusJson.
+
+type genesisResponseJson struct {
+	Data *genesisJson `json:"data"`
+}
+
+type genesisJson struct {
+	GenesisTime           string `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+
+type syncingResponseJson struct {
+	Data *syncingJson `json:"data"`
+}
+
+type syncingJson struct {
+	HeadSlot     string `json:"head_slot"`
+	SyncDistance string `json:"sync_distance"`
+	IsSyncing    bool   `json:"is_syncing"`
+	IsOptimistic bool   `json:"is_optimistic"`
+}
+
+type versionResponseJson struct {
+	Data *versionJson `json:"data"`
+}
+
+type versionJson struct {
+	Version string `json:"version"`
+}
+
+type stateValidatorsResponseJson struct {
+	Data []*validatorContainerJson `json:"data"`
+}
+
+type validatorContainerJson struct {
+	Index     string         `json:"index"`
+	Balance   string         `json:"balance"`
+	Status    string         `json:"status"`
+	Validator *validatorJson `json:"validator"`
+}
+
+type validatorJson struct {
+	PublicKey                  string `json:"pubkey"`
+	WithdrawalCredentials      string `json:"withdrawal_credentials"`
+	EffectiveBalance           string `json:"effective_balance"`
+	Slashed                    bool   `json:"slashed"`
+	ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+	ActivationEpoch            string `json:"activation_epoch"`
+	ExitEpoch                  string `json:"exit_epoch"`
+	WithdrawableEpoch          string `json:"withdrawable_epoch"`
+}
+
+type stateCommitteesResponseJson struct {
+	Data []*committeeJson `json:"data"`
+}
+
+type committeeJson struct {
+	Index      string   `json:"index"`
+	Slot       string   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+
+type attesterDutiesResponseJson struct {
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*attesterDutyJson `json:"data"`
+}
+
+type attesterDutyJson struct {
+	Pubkey                  string `json:"pubkey"`
+	ValidatorIndex          string `json:"validator_index"`
+	CommitteeIndex          string `json:"committee_index"`
+	CommitteeLength         string `json:"committee_length"`
+	CommitteesAtSlot        string `json:"committees_at_slot"`
+	ValidatorCommitteeIndex string `json:"validator_committee_index"`
+	Slot                    string `json:"slot"`
+}
+
+type proposerDutiesResponseJson struct {
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*proposerDutyJson `json:"data"`
+}
+
+type proposerDutyJson struct {
+	Pubkey         string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+}
+
+type syncCommitteeDutiesResponseJson struct {
+	Data []*syncCommitteeDutyJson `json:"data"`
+}
+
+type syncCommitteeDutyJson struct {
+	Pubkey                        string   `json:"pubkey"`
+	ValidatorIndex                string   `json:"validator_index"`
+	ValidatorSyncCommitteeIndices []string `json:"validator_sync_committee_indices"`
+}
+
+type beaconCommitteeSubscribeJson struct {
+	ValidatorIndex   string `json:"validator_index"`
+	CommitteeIndex   string `json:"committee_index"`
+	CommitteesAtSlot string `json:"committees_at_slot"`
+	Slot             string `json:"slot"`
+	IsAggregator     bool   `json:"is_aggregator"`
+}
+
+type feeRecipientJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	FeeRecipient   string `json:"fee_recipient"`
+}
+
+type blockRootResponseJson struct {
+	Data *blockRootJson `json:"data"`
+}
+
+type blockRootJson struct {
+	Root string `json:"root"`
+}
+
+type blockHeaderResponseJson struct {
+	Data *blockHeaderContainerJson `json:"data"`
+}
+
+type blockHeaderContainerJson struct {
+	Root      string                 `json:"root"`
+	Canonical bool                   `json:"canonical"`
+	Header    *signedBlockHeaderJson `json:"header"`
+}
+
+type signedBlockHeaderJson struct {
+	Message   *blockHeaderJson `json:"message"`
+	Signature string           `json:"signature"`
+}
+
+type blockHeaderJson struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type finalityCheckpointsResponseJson struct {
+	Data *finalityCheckpointsJson `json:"data"`
+}
+
+type finalityCheckpointsJson struct {
+	PreviousJustified *checkpointJson `json:"previous_justified"`
+	CurrentJustified  *checkpointJson `json:"current_justified"`
+	Finalized         *checkpointJson `json:"finalized"`
+}
+
+type checkpointJson struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// consensusResponseJson is a response holding a consensus type, along with the name of the fork it
+// belongs to for the versioned endpoints.
+type consensusResponseJson struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type blockEventJson struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block"`
+}
@@ -0,0 +1,99 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/api/gateway/apimiddleware"
+)
+
+// ErrNotSupported is returned for the requests that cannot be served by the standard beacon REST API.
+var ErrNotSupported = errors.New("not supported by the beacon REST API")
+
+func notSupported(method string) error {
+	return errors.Wrap(ErrNotSupported, method)
+}
+
+// restApiError is returned when the beacon node answers a request with an error status code.
+type restApiError struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *restApiError) Error() string {
+	return fmt.Sprintf("beacon node returned status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+// isNotFound returns true if the beacon node answered a request with a 404 status code.
+func isNotFound(err error) bool {
+	var apiErr *restApiError
+	return errors.As(err, &apiErr) && apiErr.code == http.StatusNotFound
+}
+
+// jsonRestHandler sends JSON requests to the REST API of a beacon node.
+type jsonRestHandler struct {
+	httpClient http.Client
+	host       string
+}
+
+// getRestJsonResponse sends a GET request to the given path of the beacon node and decodes
+// the JSON response into resp.
+func (c jsonRestHandler) getRestJsonResponse(ctx context.Context, path string, resp interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+path, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request for %s", path)
+	}
+	return c.do(req, path, resp)
+}
+
+// postRestJson sends a POST request with the JSON encoding of body to the given path of the
+// beacon node and decodes the JSON response into resp, unless resp is nil.
+func (c jsonRestHandler) postRestJson(ctx context.Context, path string, body interface{}, resp interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal request body for %s", path)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.host+path, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request for %s", path)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, path, resp)
+}
+
+func (c jsonRestHandler) do(req *http.Request, path string, resp interface{}) error {
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to query REST API %s", path)
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		errorJson := &apimiddleware.DefaultErrorJson{}
+		body, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read error response for %s", path)
+		}
+		if err := json.Unmarshal(body, errorJson); err != nil || errorJson.Message == "" {
+			errorJson.Message = string(body)
+		}
+		return &restApiError{path: path, code: httpResp.StatusCode, message: errorJson.Message}
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return errors.Wrapf(err, "failed to decode response for %s", path)
+	}
+	return nil
+}
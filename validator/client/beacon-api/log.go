@@ -0,0 +1,5 @@
+package beacon_api
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "beacon-api")
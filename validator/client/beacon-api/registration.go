@@ -0,0 +1,52 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+// prepareBeaconProposer sends the fee recipients of the validators to the beacon node.
+func (c *beaconApiValidatorClient) prepareBeaconProposer(ctx context.Context, recipients []*ethpb.PrepareBeaconProposerRequest_FeeRecipientContainer) error {
+	body := make([]*feeRecipientJson, len(recipients))
+	for i, r := range recipients {
+		body[i] = &feeRecipientJson{
+			ValidatorIndex: strconv.FormatUint(uint64(r.ValidatorIndex), 10),
+			FeeRecipient:   hexutil.Encode(r.FeeRecipient),
+		}
+	}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/validator/prepare_beacon_proposer", body, nil); err != nil {
+		return errors.Wrap(err, "could not prepare beacon proposer")
+	}
+	return nil
+}
+
+// submitValidatorRegistrations sends the signed builder registrations of the validators to the beacon node.
+func (c *beaconApiValidatorClient) submitValidatorRegistrations(ctx context.Context, registrations []*ethpb.SignedValidatorRegistrationV1) error {
+	body := make([]interface{}, len(registrations))
+	for i, r := range registrations {
+		body[i] = marshalConsensusJson(r)
+	}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/validator/register_validator", body, nil); err != nil {
+		return errors.Wrap(err, "could not submit validator registrations")
+	}
+	return nil
+}
+
+// proposeExit publishes a signed voluntary exit through the beacon node.
+func (c *beaconApiValidatorClient) proposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	if in == nil || in.Exit == nil {
+		return nil, errors.New("signed voluntary exit is nil")
+	}
+	root, err := in.Exit.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute exit root")
+	}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/beacon/pool/voluntary_exits", marshalConsensusJson(in), nil); err != nil {
+		return nil, errors.Wrap(err, "could not publish voluntary exit")
+	}
+	return &ethpb.ProposeExitResponse{ExitRoot: root[:]}, nil
+}
@@ -0,0 +1,156 @@
+package beacon_api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Maximum number of validator ids sent in a single request to the validators endpoint, which
+// keeps the request URL reasonably short.
+const maxValidatorIdsPerRequest = 64
+
+// validatorStatuses maps the validator statuses of the beacon API to Prysm's statuses.
+var validatorStatuses = map[string]ethpb.ValidatorStatus{
+	"pending_initialized": ethpb.ValidatorStatus_DEPOSITED,
+	"pending_queued":      ethpb.ValidatorStatus_PENDING,
+	"active_ongoing":      ethpb.ValidatorStatus_ACTIVE,
+	"active_exiting":      ethpb.ValidatorStatus_EXITING,
+	"active_slashed":      ethpb.ValidatorStatus_SLASHING,
+	"exited_unslashed":    ethpb.ValidatorStatus_EXITED,
+	"exited_slashed":      ethpb.ValidatorStatus_EXITED,
+	"withdrawal_possible": ethpb.ValidatorStatus_EXITED,
+	"withdrawal_done":     ethpb.ValidatorStatus_EXITED,
+}
+
+// getStateValidators returns the validators of the head state matching the given ids, which are
+// either hex encoded public keys or validator indices, and statuses. All validators matching the
+// statuses are returned if no id is given.
+func getStateValidators(ctx context.Context, handler jsonRestHandler, ids []string, statuses []string) ([]*validatorContainerJson, error) {
+	query := url.Values{}
+	for _, s := range statuses {
+		query.Add("status", s)
+	}
+	if len(ids) == 0 {
+		resp := &stateValidatorsResponseJson{}
+		if err := handler.getRestJsonResponse(ctx, "/eth/v1/beacon/states/head/validators?"+query.Encode(), resp); err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	}
+
+	var validators []*validatorContainerJson
+	for start := 0; start < len(ids); start += maxValidatorIdsPerRequest {
+		end := start + maxValidatorIdsPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunkQuery := url.Values{}
+		for k, v := range query {
+			chunkQuery[k] = v
+		}
+		chunkQuery["id"] = ids[start:end]
+		resp := &stateValidatorsResponseJson{}
+		if err := handler.getRestJsonResponse(ctx, "/eth/v1/beacon/states/head/validators?"+chunkQuery.Encode(), resp); err != nil {
+			return nil, err
+		}
+		validators = append(validators, resp.Data...)
+	}
+	return validators, nil
+}
+
+// getValidatorsByPublicKey returns the validators of the head state with the given public keys,
+// indexed by their lower case hex encoded public key. Unknown public keys are absent from the result.
+func (c *beaconApiValidatorClient) getValidatorsByPublicKey(ctx context.Context, publicKeys [][]byte) (map[string]*validatorContainerJson, error) {
+	ids := make([]string, len(publicKeys))
+	for i, pk := range publicKeys {
+		ids[i] = hexutil.Encode(pk)
+	}
+	validators, err := getStateValidators(ctx, c.jsonRestHandler, ids, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validators")
+	}
+	byPublicKey := make(map[string]*validatorContainerJson, len(validators))
+	for _, v := range validators {
+		if v == nil || v.Validator == nil {
+			return nil, errors.New("validator is nil")
+		}
+		byPublicKey[strings.ToLower(v.Validator.PublicKey)] = v
+	}
+	return byPublicKey, nil
+}
+
+// getValidatorIndex returns the index of the validator with the given public key, or a NotFound
+// error if the beacon node does not know it, like the gRPC API does.
+func (c *beaconApiValidatorClient) getValidatorIndex(ctx context.Context, publicKey []byte) (*ethpb.ValidatorIndexResponse, error) {
+	validators, err := c.getValidatorsByPublicKey(ctx, [][]byte{publicKey})
+	if err != nil {
+		return nil, err
+	}
+	v, ok := validators[hexutil.Encode(publicKey)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "could not find validator index for public key %#x", publicKey)
+	}
+	index, err := strconv.ParseUint(v.Index, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse validator index %s", v.Index)
+	}
+	return &ethpb.ValidatorIndexResponse{Index: types.ValidatorIndex(index)}, nil
+}
+
+// getMultipleValidatorStatus returns the status of the validators with the given public keys.
+// Unknown validators have the UNKNOWN_STATUS status and the maximum uint64 as index.
+func (c *beaconApiValidatorClient) getMultipleValidatorStatus(ctx context.Context, publicKeys [][]byte) (*ethpb.MultipleValidatorStatusResponse, error) {
+	validators, err := c.getValidatorsByPublicKey(ctx, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: publicKeys,
+		Statuses:   make([]*ethpb.ValidatorStatusResponse, len(publicKeys)),
+		Indices:    make([]types.ValidatorIndex, len(publicKeys)),
+	}
+	for i, pk := range publicKeys {
+		v, ok := validators[hexutil.Encode(pk)]
+		if !ok {
+			resp.Statuses[i] = &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS}
+			resp.Indices[i] = types.ValidatorIndex(^uint64(0))
+			continue
+		}
+		index, validatorStatus, err := parseValidator(v)
+		if err != nil {
+			return nil, err
+		}
+		activationEpoch, err := strconv.ParseUint(v.Validator.ActivationEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse activation epoch %s", v.Validator.ActivationEpoch)
+		}
+		resp.Statuses[i] = &ethpb.ValidatorStatusResponse{
+			Status:          validatorStatus,
+			ActivationEpoch: types.Epoch(activationEpoch),
+		}
+		resp.Indices[i] = index
+	}
+	return resp, nil
+}
+
+// parseValidator returns the index and status of a validator returned by the validators endpoint.
+func parseValidator(v *validatorContainerJson) (types.ValidatorIndex, ethpb.ValidatorStatus, error) {
+	index, err := strconv.ParseUint(v.Index, 10, 64)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to parse validator index %s", v.Index)
+	}
+	validatorStatus, ok := validatorStatuses[v.Status]
+	if !ok {
+		return 0, 0, errors.Errorf("unknown validator status %s", v.Status)
+	}
+	return types.ValidatorIndex(index), validatorStatus, nil
+}
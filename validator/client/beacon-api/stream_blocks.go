@@ -0,0 +1,111 @@
+package beacon_api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+)
+
+// streamBlocks subscribes to the block events of the beacon node.
+func (c *beaconApiValidatorClient) streamBlocks(ctx context.Context) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	const path = "/eth/v1/events?topics=block"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.jsonRestHandler.host+path, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request for %s", path)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.eventsClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query REST API %s", path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+		return nil, &restApiError{path: path, code: resp.StatusCode, message: resp.Status}
+	}
+	go func() {
+		<-ctx.Done()
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close event stream")
+		}
+	}()
+	return &blocksStream{
+		restStream: restStream{ctx: ctx},
+		client:     c,
+		scanner:    bufio.NewScanner(resp.Body),
+	}, nil
+}
+
+// blocksStream emulates the StreamBlocksAltair stream by fetching the blocks announced by the
+// server-sent block events of the beacon node.
+type blocksStream struct {
+	restStream
+	client  *beaconApiValidatorClient
+	scanner *bufio.Scanner
+}
+
+// Recv blocks until the beacon node announces a new block and returns it.
+func (s *blocksStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	event := ""
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:") && event == "block":
+			blockEvent := &blockEventJson{}
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), blockEvent); err != nil {
+				return nil, errors.Wrap(err, "failed to decode block event")
+			}
+			return s.client.getSignedBlock(s.ctx, blockEvent.Block)
+		case line == "":
+			event = ""
+		}
+	}
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read event stream")
+	}
+	return nil, errors.New("event stream closed by the beacon node")
+}
+
+// getSignedBlock returns the block with the given root.
+func (c *beaconApiValidatorClient) getSignedBlock(ctx context.Context, root string) (*ethpb.StreamBlocksResponse, error) {
+	resp := &consensusResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", root), resp); err != nil {
+		return nil, errors.Wrapf(err, "could not get block %s", root)
+	}
+
+	switch resp.Version {
+	case version.String(version.Phase0):
+		block := &ethpb.SignedBeaconBlock{}
+		if err := unmarshalConsensusJson(resp.Data, block); err != nil {
+			return nil, errors.Wrap(err, "could not decode phase0 block")
+		}
+		return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_Phase0Block{Phase0Block: block}}, nil
+	case version.String(version.Altair):
+		block := &ethpb.SignedBeaconBlockAltair{}
+		if err := unmarshalConsensusJson(resp.Data, block); err != nil {
+			return nil, errors.Wrap(err, "could not decode altair block")
+		}
+		return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_AltairBlock{AltairBlock: block}}, nil
+	case version.String(version.Bellatrix):
+		block := &ethpb.SignedBeaconBlockBellatrix{}
+		if err := unmarshalConsensusJson(resp.Data, block); err != nil {
+			return nil, errors.Wrap(err, "could not decode bellatrix block")
+		}
+		return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_BellatrixBlock{BellatrixBlock: block}}, nil
+	default:
+		return nil, errors.Errorf("unsupported block version %s", resp.Version)
+	}
+}
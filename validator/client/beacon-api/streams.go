@@ -0,0 +1,43 @@
+package beacon_api
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// restStream implements grpc.ClientStream for the server streams that are emulated on top of the
+// beacon REST API. Only the Recv methods of the embedding streams are meaningful.
+type restStream struct {
+	ctx context.Context
+}
+
+// Header --
+func (*restStream) Header() (metadata.MD, error) {
+	return nil, nil
+}
+
+// Trailer --
+func (*restStream) Trailer() metadata.MD {
+	return nil
+}
+
+// CloseSend --
+func (*restStream) CloseSend() error {
+	return nil
+}
+
+// Context --
+func (s *restStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg --
+func (*restStream) SendMsg(_ interface{}) error {
+	return notSupported("SendMsg")
+}
+
+// RecvMsg --
+func (*restStream) RecvMsg(_ interface{}) error {
+	return notSupported("RecvMsg")
+}
@@ -0,0 +1,97 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+)
+
+// getHeadBlockRoot returns the root of the head block of the beacon node.
+func (c *beaconApiValidatorClient) getHeadBlockRoot(ctx context.Context) ([]byte, error) {
+	resp := &blockRootResponseJson{}
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, "/eth/v1/beacon/blocks/head/root", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get head block root")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("block root data is nil")
+	}
+	root, err := hexutil.Decode(resp.Data.Root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode head block root %s", resp.Data.Root)
+	}
+	return root, nil
+}
+
+// submitSyncMessage publishes a sync committee message through the beacon node.
+func (c *beaconApiValidatorClient) submitSyncMessage(ctx context.Context, msg *ethpb.SyncCommitteeMessage) error {
+	body := []interface{}{marshalConsensusJson(msg)}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/beacon/pool/sync_committees", body, nil); err != nil {
+		return errors.Wrap(err, "could not publish sync committee message")
+	}
+	return nil
+}
+
+// getSyncSubcommitteeIndex returns the subcommittees of the sync committee the validator is part of.
+func (c *beaconApiValidatorClient) getSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	indexResp, err := c.getValidatorIndex(ctx, in.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	index := strconv.FormatUint(uint64(indexResp.Index), 10)
+	resp := &syncCommitteeDutiesResponseJson{}
+	path := fmt.Sprintf("/eth/v1/validator/duties/sync/%d", slots.ToEpoch(in.Slot))
+	if err := c.jsonRestHandler.postRestJson(ctx, path, []string{index}, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get sync committee duties")
+	}
+
+	subcommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
+	var indices []types.CommitteeIndex
+	for _, d := range resp.Data {
+		if d.ValidatorIndex != index {
+			continue
+		}
+		for _, s := range d.ValidatorSyncCommitteeIndices {
+			i, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse sync committee index %s", s)
+			}
+			indices = append(indices, types.CommitteeIndex(i/subcommitteeSize))
+		}
+	}
+	return &ethpb.SyncSubcommitteeIndexResponse{Indices: indices}, nil
+}
+
+// getSyncCommitteeContribution returns the aggregate of the sync committee messages of a subcommittee
+// for the head block.
+func (c *beaconApiValidatorClient) getSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	root, err := c.getHeadBlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &consensusResponseJson{}
+	path := fmt.Sprintf("/eth/v1/validator/sync_committee_contribution?slot=%d&subcommittee_index=%d&beacon_block_root=%s", in.Slot, in.SubnetId, hexutil.Encode(root))
+	if err := c.jsonRestHandler.getRestJsonResponse(ctx, path, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get sync committee contribution")
+	}
+	contribution := &ethpb.SyncCommitteeContribution{}
+	if err := unmarshalConsensusJson(resp.Data, contribution); err != nil {
+		return nil, errors.Wrap(err, "could not decode sync committee contribution")
+	}
+	return contribution, nil
+}
+
+// submitSignedContributionAndProof publishes a signed sync committee contribution through the beacon node.
+func (c *beaconApiValidatorClient) submitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) error {
+	body := []interface{}{marshalConsensusJson(in)}
+	if err := c.jsonRestHandler.postRestJson(ctx, "/eth/v1/validator/contribution_and_proofs", body, nil); err != nil {
+		return errors.Wrap(err, "could not publish sync committee contribution")
+	}
+	return nil
+}
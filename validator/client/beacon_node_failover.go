@@ -0,0 +1,552 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Maximum amount of time a beacon node has to answer a health check.
+const beaconNodeHealthCheckTimeout = 2 * time.Second
+
+var errNoBeaconNodes = errors.New("no beacon nodes configured")
+
+// beaconNode is a single beacon node endpoint the validator client is connected to.
+type beaconNode struct {
+	endpoint        string
+	conn            *grpc.ClientConn
+	validatorClient ethpb.BeaconNodeValidatorClient
+	nodeClient      ethpb.NodeClient
+	healthy         bool
+	synced          bool
+	latency         time.Duration
+}
+
+func newBeaconNode(endpoint string, conn *grpc.ClientConn) *beaconNode {
+	return &beaconNode{
+		endpoint:        endpoint,
+		conn:            conn,
+		validatorClient: ethpb.NewBeaconNodeValidatorClient(conn),
+		nodeClient:      ethpb.NewNodeClient(conn),
+		healthy:         true,
+		synced:          true,
+	}
+}
+
+// failoverValidatorClient implements ethpb.BeaconNodeValidatorClient on top of several beacon nodes.
+// Requests for duties and data are routed to the healthiest node, which is a reachable and synced
+// node with the lowest latency, and are retried against the next best node on failure. Signed
+// objects are broadcast to every healthy node so that they are published even if one node is
+// down or lagging behind.
+type failoverValidatorClient struct {
+	lock    sync.RWMutex
+	nodes   []*beaconNode
+	primary string
+}
+
+func newFailoverValidatorClient(nodes []*beaconNode) *failoverValidatorClient {
+	return &failoverValidatorClient{nodes: nodes}
+}
+
+// run checks the health of every beacon node once per slot until the context is canceled.
+func (c *failoverValidatorClient) run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	c.checkHealth(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			c.checkHealth(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth queries the sync status of every beacon node and records whether it is reachable,
+// whether it is synced and how long it took to answer.
+func (c *failoverValidatorClient) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range c.nodes {
+		wg.Add(1)
+		go func(n *beaconNode) {
+			defer wg.Done()
+			hctx, cancel := context.WithTimeout(ctx, beaconNodeHealthCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			resp, err := n.nodeClient.GetSyncStatus(hctx, &emptypb.Empty{})
+			latency := time.Since(start)
+
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			n.healthy = err == nil
+			n.synced = err == nil && !resp.Syncing
+			n.latency = latency
+			if err != nil {
+				log.WithError(err).WithField("endpoint", n.endpoint).Debug("Beacon node health check failed")
+			}
+			beaconNodeHealthyGaugeVec.WithLabelValues(n.endpoint).Set(boolToFloat(n.healthy))
+			beaconNodeSyncedGaugeVec.WithLabelValues(n.endpoint).Set(boolToFloat(n.synced))
+			beaconNodeLatencyGaugeVec.WithLabelValues(n.endpoint).Set(latency.Seconds())
+		}(n)
+	}
+	wg.Wait()
+
+	ranked := c.rankedNodes()
+	if len(ranked) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	best := ranked[0]
+	if best.endpoint == c.primary {
+		return
+	}
+	if c.primary != "" {
+		beaconNodePrimaryGaugeVec.WithLabelValues(c.primary).Set(0)
+	}
+	beaconNodePrimaryGaugeVec.WithLabelValues(best.endpoint).Set(1)
+	log.WithFields(logrus.Fields{
+		"endpoint": best.endpoint,
+		"healthy":  best.healthy,
+		"synced":   best.synced,
+		"latency":  best.latency,
+	}).Info("Routing validator duties to beacon node")
+	c.primary = best.endpoint
+}
+
+// rankedNodes returns the beacon nodes from the healthiest to the least healthy one.
+// Healthy nodes come before unreachable ones, synced nodes before syncing ones,
+// and nodes are then ordered by latency.
+func (c *failoverValidatorClient) rankedNodes() []*beaconNode {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	ranked := make([]*beaconNode, len(c.nodes))
+	copy(ranked, c.nodes)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].healthy != ranked[j].healthy {
+			return ranked[i].healthy
+		}
+		if ranked[i].synced != ranked[j].synced {
+			return ranked[i].synced
+		}
+		return ranked[i].latency < ranked[j].latency
+	})
+	return ranked
+}
+
+// healthyNodes returns the ranked reachable beacon nodes. If no node passed its last
+// health check, all nodes are returned so that requests are still attempted.
+func (c *failoverValidatorClient) healthyNodes() []*beaconNode {
+	ranked := c.rankedNodes()
+	healthy := make([]*beaconNode, 0, len(ranked))
+	for _, n := range ranked {
+		c.lock.RLock()
+		ok := n.healthy
+		c.lock.RUnlock()
+		if ok {
+			healthy = append(healthy, n)
+		}
+	}
+	if len(healthy) == 0 {
+		return ranked
+	}
+	return healthy
+}
+
+// query sends a request to the healthiest beacon node, falling back to the next best
+// node if it fails. The error of the last attempted node is returned if all of them fail.
+func (c *failoverValidatorClient) query(
+	ctx context.Context,
+	method string,
+	f func(client ethpb.BeaconNodeValidatorClient) (interface{}, error),
+) (interface{}, error) {
+	return c.queryNodes(ctx, method, func(n *beaconNode) (interface{}, error) {
+		return f(n.validatorClient)
+	})
+}
+
+// queryNodes is query for requests made on the beacon node itself rather than its validator client.
+func (c *failoverValidatorClient) queryNodes(
+	ctx context.Context,
+	method string,
+	f func(n *beaconNode) (interface{}, error),
+) (interface{}, error) {
+	err := errNoBeaconNodes
+	for _, n := range c.rankedNodes() {
+		var resp interface{}
+		resp, err = f(n)
+		if err == nil {
+			beaconNodeRequestsCounterVec.WithLabelValues(n.endpoint, "success").Inc()
+			return resp, nil
+		}
+		beaconNodeRequestsCounterVec.WithLabelValues(n.endpoint, "failure").Inc()
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		log.WithError(err).WithFields(logrus.Fields{
+			"endpoint": n.endpoint,
+			"method":   method,
+		}).Debug("Beacon node request failed, trying next beacon node")
+	}
+	return nil, err
+}
+
+// broadcast sends a request to every healthy beacon node concurrently. It succeeds if at least
+// one node accepted the request, in which case the response of the healthiest such node is
+// returned. Otherwise the error of the healthiest node is returned.
+func (c *failoverValidatorClient) broadcast(
+	ctx context.Context,
+	method string,
+	f func(client ethpb.BeaconNodeValidatorClient) (interface{}, error),
+) (interface{}, error) {
+	nodes := c.healthyNodes()
+	if len(nodes) == 0 {
+		return nil, errNoBeaconNodes
+	}
+	resps := make([]interface{}, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *beaconNode) {
+			defer wg.Done()
+			resps[i], errs[i] = f(n.validatorClient)
+			if errs[i] != nil {
+				beaconNodeRequestsCounterVec.WithLabelValues(n.endpoint, "failure").Inc()
+				log.WithError(errs[i]).WithFields(logrus.Fields{
+					"endpoint": n.endpoint,
+					"method":   method,
+				}).Debug("Could not broadcast to beacon node")
+				return
+			}
+			beaconNodeRequestsCounterVec.WithLabelValues(n.endpoint, "success").Inc()
+		}(i, n)
+	}
+	wg.Wait()
+	for i := range nodes {
+		if errs[i] == nil {
+			return resps[i], nil
+		}
+	}
+	return nil, errs[0]
+}
+
+// failoverConn implements grpc.ClientConnInterface on top of the beacon nodes of a failover
+// client, so that the beacon chain, node and slasher clients of the validator client follow the
+// same routing as its validator client. Requests are sent to the healthiest beacon node and
+// unary requests are retried against the next best node on failure.
+type failoverConn struct {
+	client *failoverValidatorClient
+}
+
+// Invoke --
+func (c *failoverConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	_, err := c.client.queryNodes(ctx, method, func(n *beaconNode) (interface{}, error) {
+		return nil, n.conn.Invoke(ctx, method, args, reply, opts...)
+	})
+	return err
+}
+
+// NewStream opens the stream on the healthiest beacon node which accepts it. A stream stays on
+// the node it was opened on, callers open a new stream when it fails.
+func (c *failoverConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	resp, err := c.client.queryNodes(ctx, method, func(n *beaconNode) (interface{}, error) {
+		return n.conn.NewStream(ctx, desc, method, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(grpc.ClientStream), nil
+}
+
+// GetDuties --
+func (c *failoverValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+	resp, err := c.query(ctx, "GetDuties", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.GetDuties(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.DutiesResponse), nil
+}
+
+// StreamDuties --
+func (c *failoverValidatorClient) StreamDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamDutiesClient, error) {
+	resp, err := c.query(ctx, "StreamDuties", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.StreamDuties(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(ethpb.BeaconNodeValidator_StreamDutiesClient), nil
+}
+
+// DomainData --
+func (c *failoverValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest, opts ...grpc.CallOption) (*ethpb.DomainResponse, error) {
+	resp, err := c.query(ctx, "DomainData", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.DomainData(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.DomainResponse), nil
+}
+
+// WaitForChainStart --
+// Deprecated: Do not use.
+func (c *failoverValidatorClient) WaitForChainStart(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error) {
+	resp, err := c.query(ctx, "WaitForChainStart", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.WaitForChainStart(ctx, in, opts...) // nolint:staticcheck
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(ethpb.BeaconNodeValidator_WaitForChainStartClient), nil
+}
+
+// WaitForActivation --
+func (c *failoverValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	resp, err := c.query(ctx, "WaitForActivation", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.WaitForActivation(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(ethpb.BeaconNodeValidator_WaitForActivationClient), nil
+}
+
+// ValidatorIndex --
+func (c *failoverValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest, opts ...grpc.CallOption) (*ethpb.ValidatorIndexResponse, error) {
+	resp, err := c.query(ctx, "ValidatorIndex", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.ValidatorIndex(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ValidatorIndexResponse), nil
+}
+
+// ValidatorStatus --
+func (c *failoverValidatorClient) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.ValidatorStatusResponse, error) {
+	resp, err := c.query(ctx, "ValidatorStatus", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.ValidatorStatus(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ValidatorStatusResponse), nil
+}
+
+// MultipleValidatorStatus --
+func (c *failoverValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.MultipleValidatorStatusResponse, error) {
+	resp, err := c.query(ctx, "MultipleValidatorStatus", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.MultipleValidatorStatus(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.MultipleValidatorStatusResponse), nil
+}
+
+// GetBeaconBlock --
+func (c *failoverValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest, opts ...grpc.CallOption) (*ethpb.GenericBeaconBlock, error) {
+	resp, err := c.query(ctx, "GetBeaconBlock", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.GetBeaconBlock(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.GenericBeaconBlock), nil
+}
+
+// ProposeBeaconBlock --
+func (c *failoverValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock, opts ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+	resp, err := c.broadcast(ctx, "ProposeBeaconBlock", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.ProposeBeaconBlock(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ProposeResponse), nil
+}
+
+// PrepareBeaconProposer --
+func (c *failoverValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, "PrepareBeaconProposer", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.PrepareBeaconProposer(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+// GetAttestationData --
+func (c *failoverValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest, opts ...grpc.CallOption) (*ethpb.AttestationData, error) {
+	resp, err := c.query(ctx, "GetAttestationData", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.GetAttestationData(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AttestationData), nil
+}
+
+// ProposeAttestation --
+func (c *failoverValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation, opts ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+	resp, err := c.broadcast(ctx, "ProposeAttestation", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.ProposeAttestation(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AttestResponse), nil
+}
+
+// SubmitAggregateSelectionProof --
+func (c *failoverValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest, opts ...grpc.CallOption) (*ethpb.AggregateSelectionResponse, error) {
+	resp, err := c.query(ctx, "SubmitAggregateSelectionProof", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.SubmitAggregateSelectionProof(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AggregateSelectionResponse), nil
+}
+
+// SubmitSignedAggregateSelectionProof --
+func (c *failoverValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest, opts ...grpc.CallOption) (*ethpb.SignedAggregateSubmitResponse, error) {
+	resp, err := c.broadcast(ctx, "SubmitSignedAggregateSelectionProof", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.SubmitSignedAggregateSelectionProof(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SignedAggregateSubmitResponse), nil
+}
+
+// ProposeExit --
+func (c *failoverValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit, opts ...grpc.CallOption) (*ethpb.ProposeExitResponse, error) {
+	resp, err := c.broadcast(ctx, "ProposeExit", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.ProposeExit(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ProposeExitResponse), nil
+}
+
+// SubscribeCommitteeSubnets --
+func (c *failoverValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, "SubscribeCommitteeSubnets", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.SubscribeCommitteeSubnets(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+// CheckDoppelGanger --
+func (c *failoverValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest, opts ...grpc.CallOption) (*ethpb.DoppelGangerResponse, error) {
+	resp, err := c.query(ctx, "CheckDoppelGanger", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.CheckDoppelGanger(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.DoppelGangerResponse), nil
+}
+
+// GetSyncMessageBlockRoot --
+func (c *failoverValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.SyncMessageBlockRootResponse, error) {
+	resp, err := c.query(ctx, "GetSyncMessageBlockRoot", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.GetSyncMessageBlockRoot(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncMessageBlockRootResponse), nil
+}
+
+// SubmitSyncMessage --
+func (c *failoverValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, "SubmitSyncMessage", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.SubmitSyncMessage(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+// GetSyncSubcommitteeIndex --
+func (c *failoverValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest, opts ...grpc.CallOption) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	resp, err := c.query(ctx, "GetSyncSubcommitteeIndex", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.GetSyncSubcommitteeIndex(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncSubcommitteeIndexResponse), nil
+}
+
+// GetSyncCommitteeContribution --
+func (c *failoverValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest, opts ...grpc.CallOption) (*ethpb.SyncCommitteeContribution, error) {
+	resp, err := c.query(ctx, "GetSyncCommitteeContribution", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.GetSyncCommitteeContribution(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncCommitteeContribution), nil
+}
+
+// SubmitSignedContributionAndProof --
+func (c *failoverValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, "SubmitSignedContributionAndProof", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.SubmitSignedContributionAndProof(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+// StreamBlocksAltair --
+func (c *failoverValidatorClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	resp, err := c.query(ctx, "StreamBlocksAltair", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.StreamBlocksAltair(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(ethpb.BeaconNodeValidator_StreamBlocksAltairClient), nil
+}
+
+// SubmitValidatorRegistrations --
+func (c *failoverValidatorClient) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, "SubmitValidatorRegistrations", func(vc ethpb.BeaconNodeValidatorClient) (interface{}, error) {
+		return vc.SubmitValidatorRegistrations(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
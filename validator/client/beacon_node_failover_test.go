@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var _ ethpb.BeaconNodeValidatorClient = (*failoverValidatorClient)(nil)
+
+type mockBeaconNode struct {
+	*beaconNode
+	validatorClient *mock2.MockBeaconNodeValidatorClient
+	nodeClient      *mock2.MockNodeClient
+}
+
+func newMockBeaconNode(ctrl *gomock.Controller, endpoint string) *mockBeaconNode {
+	validatorClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	nodeClient := mock2.NewMockNodeClient(ctrl)
+	return &mockBeaconNode{
+		beaconNode: &beaconNode{
+			endpoint:        endpoint,
+			validatorClient: validatorClient,
+			nodeClient:      nodeClient,
+			healthy:         true,
+			synced:          true,
+		},
+		validatorClient: validatorClient,
+		nodeClient:      nodeClient,
+	}
+}
+
+func TestFailoverValidatorClient_CheckHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	down := newMockBeaconNode(ctrl, "down:4000")
+	syncing := newMockBeaconNode(ctrl, "syncing:4000")
+	synced := newMockBeaconNode(ctrl, "synced:4000")
+	down.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	syncing.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: true}, nil)
+	synced.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: false}, nil)
+
+	c := newFailoverValidatorClient([]*beaconNode{down.beaconNode, syncing.beaconNode, synced.beaconNode})
+	c.checkHealth(context.Background())
+
+	ranked := c.rankedNodes()
+	require.Equal(t, 3, len(ranked))
+	assert.Equal(t, "synced:4000", ranked[0].endpoint)
+	assert.Equal(t, "syncing:4000", ranked[1].endpoint)
+	assert.Equal(t, "down:4000", ranked[2].endpoint)
+	assert.Equal(t, "synced:4000", c.primary)
+	assert.Equal(t, 2, len(c.healthyNodes()))
+}
+
+func TestFailoverValidatorClient_RankedNodes_Latency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	slow := newMockBeaconNode(ctrl, "slow:4000")
+	slow.latency = 100
+	fast := newMockBeaconNode(ctrl, "fast:4000")
+	fast.latency = 10
+
+	c := newFailoverValidatorClient([]*beaconNode{slow.beaconNode, fast.beaconNode})
+	ranked := c.rankedNodes()
+	assert.Equal(t, "fast:4000", ranked[0].endpoint)
+	assert.Equal(t, "slow:4000", ranked[1].endpoint)
+}
+
+func TestFailoverValidatorClient_Query_FallsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first:4000")
+	second := newMockBeaconNode(ctrl, "second:4000")
+	want := &ethpb.DutiesResponse{}
+	first.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	second.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(want, nil)
+
+	c := newFailoverValidatorClient([]*beaconNode{first.beaconNode, second.beaconNode})
+	resp, err := c.GetDuties(context.Background(), &ethpb.DutiesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, want, resp)
+}
+
+func TestFailoverValidatorClient_Query_AllFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first:4000")
+	second := newMockBeaconNode(ctrl, "second:4000")
+	first.validatorClient.EXPECT().GetAttestationData(gomock.Any(), gomock.Any()).Return(nil, errors.New("first failed"))
+	second.validatorClient.EXPECT().GetAttestationData(gomock.Any(), gomock.Any()).Return(nil, errors.New("second failed"))
+
+	c := newFailoverValidatorClient([]*beaconNode{first.beaconNode, second.beaconNode})
+	_, err := c.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{})
+	assert.ErrorContains(t, "second failed", err)
+}
+
+func TestFailoverValidatorClient_Broadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first:4000")
+	second := newMockBeaconNode(ctrl, "second:4000")
+	down := newMockBeaconNode(ctrl, "down:4000")
+	down.healthy = false
+	want := &ethpb.AttestResponse{AttestationDataRoot: []byte{'a'}}
+	first.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	second.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), gomock.Any()).Return(want, nil)
+
+	c := newFailoverValidatorClient([]*beaconNode{first.beaconNode, second.beaconNode, down.beaconNode})
+	resp, err := c.ProposeAttestation(context.Background(), &ethpb.Attestation{})
+	require.NoError(t, err)
+	assert.Equal(t, want, resp)
+}
+
+func TestFailoverValidatorClient_Broadcast_AllFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first:4000")
+	second := newMockBeaconNode(ctrl, "second:4000")
+	first.validatorClient.EXPECT().SubmitSyncMessage(gomock.Any(), gomock.Any()).Return(nil, errors.New("first failed"))
+	second.validatorClient.EXPECT().SubmitSyncMessage(gomock.Any(), gomock.Any()).Return(nil, errors.New("second failed"))
+
+	c := newFailoverValidatorClient([]*beaconNode{first.beaconNode, second.beaconNode})
+	_, err := c.SubmitSyncMessage(context.Background(), &ethpb.SyncCommitteeMessage{})
+	assert.ErrorContains(t, "first failed", err)
+}
+
+func TestFailoverValidatorClient_NoHealthyNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first:4000")
+	first.healthy = false
+	first.validatorClient.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any()).Return(&emptypb.Empty{}, nil)
+
+	c := newFailoverValidatorClient([]*beaconNode{first.beaconNode})
+	_, err := c.SubscribeCommitteeSubnets(context.Background(), &ethpb.CommitteeSubnetsSubscribeRequest{})
+	require.NoError(t, err)
+}
+
+type syncStatusServer struct {
+	ethpb.UnimplementedNodeServer
+}
+
+func (*syncStatusServer) GetSyncStatus(context.Context, *emptypb.Empty) (*ethpb.SyncStatus, error) {
+	return &ethpb.SyncStatus{Syncing: true}, nil
+}
+
+func TestFailoverConn(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	ethpb.RegisterNodeServer(srv, &syncStatusServer{})
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	defer srv.Stop()
+
+	// The first node doesn't listen anymore, requests fall back to the second one.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+	downConn, err := grpc.Dial(closed.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, downConn.Close())
+	}()
+	upConn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, upConn.Close())
+	}()
+
+	c := newFailoverValidatorClient([]*beaconNode{
+		newBeaconNode(closed.Addr().String(), downConn),
+		newBeaconNode(lis.Addr().String(), upConn),
+	})
+	resp, err := ethpb.NewNodeClient(&failoverConn{client: c}).GetSyncStatus(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Syncing)
+}
+
+func TestParseBeaconNodeEndpoints(t *testing.T) {
+	assert.DeepEqual(t, []string{"127.0.0.1:4000"}, ParseBeaconNodeEndpoints("127.0.0.1:4000"))
+	assert.DeepEqual(t, []string{"127.0.0.1:4000", "127.0.0.1:4001"}, ParseBeaconNodeEndpoints("127.0.0.1:4000, 127.0.0.1:4001,"))
+	assert.Equal(t, 0, len(ParseBeaconNodeEndpoints("")))
+}
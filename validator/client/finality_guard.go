@@ -0,0 +1,328 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errFinalityNotChecked = errors.New("checkpoints of the beacon node were not checked yet")
+
+// FinalityGuardConfig defines the finalized checkpoint which the checkpoints reported by the
+// beacon node must be consistent with before blocks and attestations are signed.
+type FinalityGuardConfig struct {
+	// Checkpoint is the finalized checkpoint pinned at startup. When nil, the first finalized
+	// checkpoint reported by the beacon node is pinned.
+	Checkpoint *ethpb.Checkpoint
+}
+
+// blockHeaderFetcher returns the header of the block with the root.
+type blockHeaderFetcher func(ctx context.Context, root []byte) (*ethpb.BeaconBlockHeader, error)
+
+// finalityGuard pins a finalized checkpoint and refuses signing blocks and attestations which don't
+// descend from it, protecting against a compromised beacon node or one following a fork. The signed
+// data is checked against the pinned checkpoint by walking the block headers from the parent of a
+// block, or the source and target of an attestation, back to the pinned block. The headers are
+// fetched from the beacon node but their roots are computed locally, so that the beacon node can't
+// make a block look like a descendant of the pinned one. The pinned checkpoint follows the finality
+// of the beacon node once the new finalized block is checked to descend from it.
+type finalityGuard struct {
+	lock        sync.RWMutex
+	pinned      *ethpb.Checkpoint
+	err         error
+	lastChecked types.Slot
+	// Roots of the blocks known to descend from the pinned block.
+	descendants map[[32]byte]bool
+	// Concurrent walks from the same block, such as those of the attestations of every validator to
+	// the same target, share a single walk.
+	walks singleflight.Group
+}
+
+// newFinalityGuard returns nil when no guard is configured, which disables the checks.
+func newFinalityGuard(cfg *FinalityGuardConfig) *finalityGuard {
+	if cfg == nil {
+		return nil
+	}
+	return &finalityGuard{
+		pinned:      cfg.Checkpoint,
+		err:         errFinalityNotChecked,
+		descendants: make(map[[32]byte]bool),
+	}
+}
+
+// due tells whether the checkpoints of the beacon node should be checked at the slot, which is
+// once per epoch while they are consistent with the pinned checkpoint and at every slot otherwise.
+func (g *finalityGuard) due(slot types.Slot) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.err != nil || slots.ToEpoch(slot) > slots.ToEpoch(g.lastChecked)
+}
+
+// update checks the chain head reported by the beacon node against the pinned checkpoint. When the
+// beacon node finalized a later checkpoint, it is pinned if its block descends from the pinned one.
+func (g *finalityGuard) update(ctx context.Context, slot types.Slot, head *ethpb.ChainHead, header blockHeaderFetcher) error {
+	finalized := &ethpb.Checkpoint{Epoch: head.FinalizedEpoch, Root: head.FinalizedBlockRoot}
+	g.lock.RLock()
+	pinned := g.pinned
+	g.lock.RUnlock()
+
+	var conflict error
+	switch {
+	case pinned == nil:
+		log.WithFields(logrus.Fields{
+			"epoch": finalized.Epoch,
+			"root":  fmt.Sprintf("%#x", finalized.Root),
+		}).Info("Pinned finalized checkpoint of the beacon node")
+	case finalized.Epoch < pinned.Epoch:
+		conflict = errors.Errorf(
+			"beacon node finalized epoch %d is before the pinned finalized epoch %d",
+			finalized.Epoch,
+			pinned.Epoch,
+		)
+	case finalized.Epoch == pinned.Epoch:
+		if !bytes.Equal(finalized.Root, pinned.Root) {
+			conflict = errors.Errorf(
+				"beacon node finalized block %#x at epoch %d instead of the pinned block %#x",
+				finalized.Root,
+				finalized.Epoch,
+				pinned.Root,
+			)
+		}
+	default:
+		descends, err := g.descendsFromPinned(ctx, pinned, finalized.Root, header)
+		if err != nil {
+			return errors.Wrap(err, "could not check whether the finalized block descends from the pinned block")
+		}
+		if !descends {
+			conflict = errors.Errorf(
+				"beacon node finalized block %#x of epoch %d does not descend from the pinned finalized block %#x of epoch %d",
+				finalized.Root,
+				finalized.Epoch,
+				pinned.Root,
+				pinned.Epoch,
+			)
+		}
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.lastChecked = slot
+	g.err = conflict
+	if conflict != nil {
+		finalityGuardConflictGauge.Set(1)
+		return nil
+	}
+	finalityGuardConflictGauge.Set(0)
+	if pinned == nil || finalized.Epoch > pinned.Epoch {
+		g.pinned = finalized
+		g.descendants = make(map[[32]byte]bool)
+	}
+	return nil
+}
+
+// descendsFromPinned tells whether the block with the root is the pinned block or descends from it.
+// The headers of the block and its ancestors are fetched until the pinned block, a block known to
+// descend from it, or a block at or before the slot of the pinned checkpoint is reached.
+func (g *finalityGuard) descendsFromPinned(ctx context.Context, pinned *ethpb.Checkpoint, root []byte, header blockHeaderFetcher) (bool, error) {
+	// Every block descends from the genesis block.
+	if pinned.Epoch == 0 {
+		return true, nil
+	}
+	key := fmt.Sprintf("%#x/%#x", pinned.Root, root)
+	descends, err, _ := g.walks.Do(key, func() (interface{}, error) {
+		return g.walkToPinned(ctx, pinned, root, header)
+	})
+	if err != nil {
+		return false, err
+	}
+	return descends.(bool), nil
+}
+
+// walkToPinned walks the ancestry of the block with the root back to the pinned block, see
+// descendsFromPinned.
+func (g *finalityGuard) walkToPinned(ctx context.Context, pinned *ethpb.Checkpoint, root []byte, header blockHeaderFetcher) (bool, error) {
+	pinnedSlot, err := slots.EpochStart(pinned.Epoch)
+	if err != nil {
+		return false, err
+	}
+	pinnedRoot := bytesutil.ToBytes32(pinned.Root)
+	var walked [][32]byte
+	r := bytesutil.ToBytes32(root)
+	for {
+		if r == pinnedRoot || g.isDescendant(pinned, r) {
+			g.addDescendants(pinned, walked)
+			return true, nil
+		}
+		h, err := header(ctx, r[:])
+		if err != nil {
+			return false, errors.Wrapf(err, "could not get block %#x", r)
+		}
+		hr, err := h.HashTreeRoot()
+		if err != nil {
+			return false, err
+		}
+		if hr != r {
+			return false, errors.Errorf("beacon node returned block %#x instead of block %#x", hr, r)
+		}
+		if h.Slot <= pinnedSlot {
+			return false, nil
+		}
+		walked = append(walked, r)
+		r = bytesutil.ToBytes32(h.ParentRoot)
+	}
+}
+
+// isDescendant tells whether the block with the root is known to descend from the pinned block.
+func (g *finalityGuard) isDescendant(pinned *ethpb.Checkpoint, root [32]byte) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.pinned == pinned && g.descendants[root]
+}
+
+// addDescendants records blocks which descend from the pinned block, unless another block was pinned
+// meanwhile.
+func (g *finalityGuard) addDescendants(pinned *ethpb.Checkpoint, roots [][32]byte) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.pinned != pinned {
+		return
+	}
+	for _, r := range roots {
+		g.descendants[r] = true
+	}
+}
+
+// checkBlock returns an error when blocks must not be signed, because the beacon node is not
+// consistent with the pinned checkpoint.
+func (g *finalityGuard) checkBlock() error {
+	if g == nil {
+		return nil
+	}
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.err
+}
+
+// checkProposal returns an error when the block with the parent root must not be signed, because
+// the beacon node is not consistent with the pinned checkpoint or the parent does not descend from
+// the pinned block.
+func (g *finalityGuard) checkProposal(ctx context.Context, parentRoot []byte, header blockHeaderFetcher) error {
+	if g == nil {
+		return nil
+	}
+	g.lock.RLock()
+	pinned, err := g.pinned, g.err
+	g.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+	descends, err := g.descendsFromPinned(ctx, pinned, parentRoot, header)
+	if err != nil {
+		return errors.Wrap(err, "could not check whether the parent block descends from the pinned block")
+	}
+	if !descends {
+		return errors.Errorf("parent block %#x does not descend from the pinned finalized block %#x", parentRoot, pinned.Root)
+	}
+	return nil
+}
+
+// checkAttestation returns an error when the attestation data must not be signed, because the
+// beacon node is not consistent with the pinned checkpoint or the source or target of the data
+// conflict with it.
+func (g *finalityGuard) checkAttestation(ctx context.Context, data *ethpb.AttestationData, header blockHeaderFetcher) error {
+	if g == nil {
+		return nil
+	}
+	g.lock.RLock()
+	pinned, err := g.pinned, g.err
+	g.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+	if data.Source.Epoch < pinned.Epoch {
+		return errors.Errorf("source epoch %d is before the pinned finalized epoch %d", data.Source.Epoch, pinned.Epoch)
+	}
+	if data.Source.Epoch == pinned.Epoch && !bytes.Equal(data.Source.Root, pinned.Root) {
+		return errors.Errorf("source root %#x conflicts with the pinned finalized root %#x", data.Source.Root, pinned.Root)
+	}
+	if data.Target.Epoch == pinned.Epoch && !bytes.Equal(data.Target.Root, pinned.Root) {
+		return errors.Errorf("target root %#x conflicts with the pinned finalized root %#x", data.Target.Root, pinned.Root)
+	}
+	for _, c := range []struct {
+		name       string
+		checkpoint *ethpb.Checkpoint
+	}{
+		{name: "source", checkpoint: data.Source},
+		{name: "target", checkpoint: data.Target},
+	} {
+		if c.checkpoint.Epoch == pinned.Epoch {
+			continue
+		}
+		descends, err := g.descendsFromPinned(ctx, pinned, c.checkpoint.Root, header)
+		if err != nil {
+			return errors.Wrapf(err, "could not check whether the %s block descends from the pinned block", c.name)
+		}
+		if !descends {
+			return errors.Errorf("%s root %#x does not descend from the pinned finalized root %#x", c.name, c.checkpoint.Root, pinned.Root)
+		}
+	}
+	return nil
+}
+
+// CheckFinality compares the checkpoints reported by the beacon node with the pinned finalized
+// checkpoint, so that blocks and attestations are not signed while they conflict.
+func (v *validator) CheckFinality(ctx context.Context, slot types.Slot) {
+	g := v.finalityGuard
+	if g == nil || !g.due(slot) {
+		return
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckFinality")
+	defer span.End()
+
+	head, err := v.beaconClient.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).Warn("Could not get the chain head to check finality")
+		return
+	}
+	if err := g.update(ctx, slot, head, v.blockHeader); err != nil {
+		log.WithError(err).Warn("Could not check the finality of the beacon node")
+		return
+	}
+	if err := g.checkBlock(); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"justifiedEpoch": head.JustifiedEpoch,
+			"finalizedEpoch": head.FinalizedEpoch,
+		}).Error("Beacon node is not consistent with the pinned finalized checkpoint, not signing blocks and attestations")
+	}
+}
+
+// blockHeader returns the header of the block with the root, as reported by the beacon node.
+func (v *validator) blockHeader(ctx context.Context, root []byte) (*ethpb.BeaconBlockHeader, error) {
+	resp, err := v.beaconClientV1.GetBlockHeader(ctx, &ethpbv1.BlockRequest{BlockId: root})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil || resp.Data.Header == nil || resp.Data.Header.Message == nil {
+		return nil, errors.Errorf("beacon node returned no header for block %#x", root)
+	}
+	h := resp.Data.Header.Message
+	return &ethpb.BeaconBlockHeader{
+		Slot:          h.Slot,
+		ProposerIndex: h.ProposerIndex,
+		ParentRoot:    h.ParentRoot,
+		StateRoot:     h.StateRoot,
+		BodyRoot:      h.BodyRoot,
+	}, nil
+}
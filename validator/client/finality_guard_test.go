@@ -0,0 +1,265 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpbservice "github.com/prysmaticlabs/prysm/v3/proto/eth/service"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v3/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/testing/util"
+	"google.golang.org/grpc"
+)
+
+// headerChain serves the headers of test blocks by root.
+type headerChain map[[32]byte]*ethpb.BeaconBlockHeader
+
+func (c headerChain) add(t *testing.T, slot types.Slot, parent []byte) []byte {
+	h := &ethpb.BeaconBlockHeader{Slot: slot, ParentRoot: parent, StateRoot: make([]byte, 32), BodyRoot: make([]byte, 32)}
+	root, err := h.HashTreeRoot()
+	require.NoError(t, err)
+	c[root] = h
+	return root[:]
+}
+
+func (c headerChain) header(_ context.Context, root []byte) (*ethpb.BeaconBlockHeader, error) {
+	h, ok := c[bytesutil.ToBytes32(root)]
+	if !ok {
+		return nil, errors.New("unavailable")
+	}
+	return h, nil
+}
+
+// headerClient serves the headers of test blocks through the v1 beacon chain API.
+type headerClient struct {
+	ethpbservice.BeaconChainClient
+	headers map[[32]byte]*ethpbv1.BeaconBlockHeader
+}
+
+func (c *headerClient) GetBlockHeader(_ context.Context, req *ethpbv1.BlockRequest, _ ...grpc.CallOption) (*ethpbv1.BlockHeaderResponse, error) {
+	h, ok := c.headers[bytesutil.ToBytes32(req.BlockId)]
+	if !ok {
+		return nil, errors.New("unavailable")
+	}
+	return &ethpbv1.BlockHeaderResponse{Data: &ethpbv1.BlockHeaderContainer{
+		Root:   req.BlockId,
+		Header: &ethpbv1.BeaconBlockHeaderContainer{Message: h},
+	}}, nil
+}
+
+func TestFinalityGuard_Update(t *testing.T) {
+	ctx := context.Background()
+	chain := headerChain{}
+	root1 := chain.add(t, 32, make([]byte, 32))
+	root2 := chain.add(t, 64, chain.add(t, 40, root1))
+	other := chain.add(t, 96, chain.add(t, 70, chain.add(t, 33, make([]byte, 32))))
+	assert.Equal(t, true, newFinalityGuard(nil) == nil)
+
+	g := newFinalityGuard(&FinalityGuardConfig{})
+	assert.ErrorContains(t, "not checked yet", g.checkBlock())
+	// The first finalized checkpoint of the beacon node is pinned.
+	require.NoError(t, g.update(ctx, 32, &ethpb.ChainHead{FinalizedEpoch: 1, FinalizedBlockRoot: root1}, chain.header))
+	require.NoError(t, g.checkBlock())
+	assert.DeepEqual(t, &ethpb.Checkpoint{Epoch: 1, Root: root1}, g.pinned)
+
+	// The pinned checkpoint follows the finality of the beacon node.
+	require.NoError(t, g.update(ctx, 64, &ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: root2}, chain.header))
+	require.NoError(t, g.checkBlock())
+	assert.DeepEqual(t, &ethpb.Checkpoint{Epoch: 2, Root: root2}, g.pinned)
+
+	require.NoError(t, g.update(ctx, 96, &ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: other}, chain.header))
+	assert.ErrorContains(t, "instead of the pinned block", g.checkBlock())
+	require.NoError(t, g.update(ctx, 97, &ethpb.ChainHead{FinalizedEpoch: 1, FinalizedBlockRoot: root1}, chain.header))
+	assert.ErrorContains(t, "is before the pinned finalized epoch 2", g.checkBlock())
+	require.NoError(t, g.update(ctx, 98, &ethpb.ChainHead{FinalizedEpoch: 3, FinalizedBlockRoot: other}, chain.header))
+	assert.ErrorContains(t, "does not descend from the pinned finalized block", g.checkBlock())
+	// A conflicting beacon node doesn't move the pinned checkpoint.
+	assert.DeepEqual(t, &ethpb.Checkpoint{Epoch: 2, Root: root2}, g.pinned)
+
+	unknown := make([]byte, 32)
+	unknown[0] = 1
+	assert.ErrorContains(t, "unavailable", g.update(ctx, 99, &ethpb.ChainHead{FinalizedEpoch: 3, FinalizedBlockRoot: unknown}, chain.header))
+	assert.Equal(t, true, g.checkBlock() != nil)
+
+	require.NoError(t, g.update(ctx, 100, &ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: root2}, chain.header))
+	require.NoError(t, g.checkBlock())
+}
+
+func TestFinalityGuard_DescendsFromPinned(t *testing.T) {
+	ctx := context.Background()
+	chain := headerChain{}
+	// The pinned block is the last block before the start of epoch 2.
+	pinned := chain.add(t, 60, make([]byte, 32))
+	child := chain.add(t, 65, pinned)
+	grandchild := chain.add(t, 70, child)
+	fork := chain.add(t, 75, chain.add(t, 64, chain.add(t, 50, make([]byte, 32))))
+	g := newFinalityGuard(&FinalityGuardConfig{Checkpoint: &ethpb.Checkpoint{Epoch: 2, Root: pinned}})
+
+	for _, root := range [][]byte{pinned, child, grandchild} {
+		descends, err := g.descendsFromPinned(ctx, g.pinned, root, chain.header)
+		require.NoError(t, err)
+		assert.Equal(t, true, descends)
+	}
+	// Descendants are remembered until another block is pinned.
+	assert.Equal(t, true, g.isDescendant(g.pinned, bytesutil.ToBytes32(grandchild)))
+	descends, err := g.descendsFromPinned(ctx, g.pinned, fork, chain.header)
+	require.NoError(t, err)
+	assert.Equal(t, false, descends)
+	assert.Equal(t, false, g.isDescendant(g.pinned, bytesutil.ToBytes32(fork)))
+
+	// The beacon node can't substitute the header of another block.
+	forged := headerChain{bytesutil.ToBytes32(fork): chain[bytesutil.ToBytes32(child)]}
+	_, err = g.descendsFromPinned(ctx, g.pinned, fork, forged.header)
+	assert.ErrorContains(t, "instead of block", err)
+
+	// Every block descends from the genesis block.
+	descends, err = g.descendsFromPinned(ctx, &ethpb.Checkpoint{Root: make([]byte, 32)}, fork, chain.header)
+	require.NoError(t, err)
+	assert.Equal(t, true, descends)
+}
+
+func TestFinalityGuard_DescendsFromPinned_SharedWalk(t *testing.T) {
+	ctx := context.Background()
+	chain := headerChain{}
+	pinned := chain.add(t, 60, make([]byte, 32))
+	target := chain.add(t, 96, chain.add(t, 65, pinned))
+	g := newFinalityGuard(&FinalityGuardConfig{Checkpoint: &ethpb.Checkpoint{Epoch: 2, Root: pinned}})
+
+	// The first header fetch is held until every walk started, so that they run concurrently.
+	const walks = 16
+	var fetches int32
+	release := make(chan struct{})
+	header := func(ctx context.Context, root []byte) (*ethpb.BeaconBlockHeader, error) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			<-release
+		}
+		return chain.header(ctx, root)
+	}
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	started.Add(walks)
+	for i := 0; i < walks; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			descends, err := g.descendsFromPinned(ctx, g.pinned, target, header)
+			assert.NoError(t, err)
+			assert.Equal(t, true, descends)
+		}()
+	}
+	started.Wait()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches), "The ancestry was walked more than once")
+}
+
+func TestFinalityGuard_CheckProposal(t *testing.T) {
+	ctx := context.Background()
+	chain := headerChain{}
+	root := chain.add(t, 64, make([]byte, 32))
+	parent := chain.add(t, 80, root)
+	fork := chain.add(t, 80, chain.add(t, 60, make([]byte, 32)))
+	var nilGuard *finalityGuard
+	require.NoError(t, nilGuard.checkProposal(ctx, fork, chain.header))
+
+	g := newFinalityGuard(&FinalityGuardConfig{Checkpoint: &ethpb.Checkpoint{Epoch: 2, Root: root}})
+	assert.ErrorContains(t, "not checked yet", g.checkProposal(ctx, parent, chain.header))
+	require.NoError(t, g.update(ctx, 81, &ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: root}, chain.header))
+	require.NoError(t, g.checkProposal(ctx, parent, chain.header))
+	require.NoError(t, g.checkProposal(ctx, root, chain.header))
+	assert.ErrorContains(t, "does not descend from the pinned finalized block", g.checkProposal(ctx, fork, chain.header))
+}
+
+func TestFinalityGuard_CheckAttestation(t *testing.T) {
+	ctx := context.Background()
+	chain := headerChain{}
+	root := chain.add(t, 64, make([]byte, 32))
+	other := chain.add(t, 96, root)
+	fork := chain.add(t, 96, chain.add(t, 60, make([]byte, 32)))
+	var nilGuard *finalityGuard
+	require.NoError(t, nilGuard.checkAttestation(ctx, util.HydrateAttestationData(&ethpb.AttestationData{}), chain.header))
+	require.NoError(t, nilGuard.checkBlock())
+
+	g := newFinalityGuard(&FinalityGuardConfig{Checkpoint: &ethpb.Checkpoint{Epoch: 2, Root: root}})
+	checkpoint := func(epoch types.Epoch, root []byte) *ethpb.Checkpoint {
+		return &ethpb.Checkpoint{Epoch: epoch, Root: root}
+	}
+	data := util.HydrateAttestationData(&ethpb.AttestationData{Source: checkpoint(2, root), Target: checkpoint(3, other)})
+	assert.ErrorContains(t, "not checked yet", g.checkAttestation(ctx, data, chain.header))
+	require.NoError(t, g.update(ctx, 64, &ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: root}, chain.header))
+	require.NoError(t, g.checkAttestation(ctx, data, chain.header))
+
+	data.Source = checkpoint(1, other)
+	assert.ErrorContains(t, "source epoch 1 is before the pinned finalized epoch 2", g.checkAttestation(ctx, data, chain.header))
+	data.Source = checkpoint(2, other)
+	assert.ErrorContains(t, "source root", g.checkAttestation(ctx, data, chain.header))
+	data.Source = checkpoint(1, other)
+	data.Target = checkpoint(2, other)
+	assert.ErrorContains(t, "source epoch", g.checkAttestation(ctx, data, chain.header))
+	data.Source = checkpoint(2, root)
+	assert.ErrorContains(t, "target root", g.checkAttestation(ctx, data, chain.header))
+
+	// The source and target must descend from the pinned block.
+	data.Source = checkpoint(3, other)
+	data.Target = checkpoint(3, fork)
+	assert.ErrorContains(t, "target root", g.checkAttestation(ctx, data, chain.header))
+	data.Source = checkpoint(3, fork)
+	data.Target = checkpoint(3, other)
+	assert.ErrorContains(t, "source root", g.checkAttestation(ctx, data, chain.header))
+}
+
+func TestValidator_CheckFinality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	beaconClient := mock.NewMockBeaconChainClient(ctrl)
+	root := make([]byte, 32)
+	root[0] = 1
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 64
+	blk.Block.ParentRoot = root
+	r, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	later := r[:]
+	v := &validator{
+		beaconClient: beaconClient,
+		beaconClientV1: &headerClient{headers: map[[32]byte]*ethpbv1.BeaconBlockHeader{
+			r: {
+				Slot:       blk.Block.Slot,
+				ParentRoot: blk.Block.ParentRoot,
+				StateRoot:  blk.Block.StateRoot,
+				BodyRoot:   bodyRoot[:],
+			},
+		}},
+		finalityGuard: newFinalityGuard(&FinalityGuardConfig{Checkpoint: &ethpb.Checkpoint{Epoch: 1, Root: root}}),
+	}
+
+	beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: later}, nil)
+	v.CheckFinality(context.Background(), 64)
+	require.NoError(t, v.finalityGuard.checkBlock())
+	assert.DeepEqual(t, later, v.finalityGuard.pinned.Root)
+
+	// The beacon node is checked once per epoch while it is consistent with the pinned checkpoint.
+	v.CheckFinality(context.Background(), 65)
+
+	beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{FinalizedEpoch: 1, FinalizedBlockRoot: root}, nil)
+	v.CheckFinality(context.Background(), 96)
+	assert.ErrorContains(t, "is before the pinned finalized epoch", v.finalityGuard.checkBlock())
+	// It is checked at every slot while it is not.
+	beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{FinalizedEpoch: 2, FinalizedBlockRoot: later}, nil)
+	v.CheckFinality(context.Background(), 97)
+	require.NoError(t, v.finalityGuard.checkBlock())
+}
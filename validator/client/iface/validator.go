@@ -54,6 +54,9 @@ type Validator interface {
 	LogAttestationsSubmitted()
 	LogSyncCommitteeMessagesSubmitted()
 	UpdateDomainDataCaches(ctx context.Context, slot types.Slot)
+	PrepareSyncCommitteeSelections(ctx context.Context, slot types.Slot)
+	RebroadcastAttestations(ctx context.Context, slot types.Slot)
+	CheckFinality(ctx context.Context, slot types.Slot)
 	WaitForKeymanagerInitialization(ctx context.Context) error
 	AllValidatorsAreExited(ctx context.Context) (bool, error)
 	Keymanager() (keymanager.IKeymanager, error)
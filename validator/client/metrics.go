@@ -37,6 +37,18 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorRebroadcastCounterVec used to count attestations and aggregates broadcast again because they
+	// were not included in a block.
+	ValidatorRebroadcastCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "attestations_rebroadcast_total",
+			Help:      "Count of attestations and aggregates broadcast again because they were not included in a block",
+		},
+		[]string{
+			"kind",
+		},
+	)
 	// ValidatorAggFailVec used to count failed aggregations.
 	ValidatorAggFailVec = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -212,6 +224,92 @@ var (
 			"pubkey",
 		},
 	)
+	// beaconNodeHealthyGaugeVec used to track whether each beacon node answered its last health check.
+	beaconNodeHealthyGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_healthy",
+			Help:      "1 if the beacon node answered its last health check, 0 otherwise",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeSyncedGaugeVec used to track whether each beacon node is synced.
+	beaconNodeSyncedGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_synced",
+			Help:      "1 if the beacon node is synced, 0 otherwise",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeLatencyGaugeVec used to track the health check latency of each beacon node.
+	beaconNodeLatencyGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_latency_seconds",
+			Help:      "Time it took the beacon node to answer its last health check",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodePrimaryGaugeVec used to track which beacon node validator duties are routed to.
+	beaconNodePrimaryGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_primary",
+			Help:      "1 if validator duties are currently routed to the beacon node, 0 otherwise",
+		},
+		[]string{
+			"endpoint",
+		},
+	)
+	// beaconNodeRequestsCounterVec used to count requests sent to each beacon node by result.
+	beaconNodeRequestsCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_requests_total",
+			Help:      "Count the requests sent to each beacon node, by result",
+		},
+		[]string{
+			"endpoint",
+			"result",
+		},
+	)
+	// syncCommitteeSubmissionDelayHistogramVec used to track when sync committee messages and
+	// contributions are submitted, relative to the start of their slot.
+	syncCommitteeSubmissionDelayHistogramVec = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "validator",
+			Name:      "sync_committee_submission_delay_seconds",
+			Help:      "Time between the start of the slot and the submission of a sync committee message or contribution",
+			Buckets:   []float64{0.5, 1, 2, 3, 4, 4.5, 5, 6, 8, 8.5, 9, 10, 12},
+		},
+		[]string{
+			"kind",
+		},
+	)
+	finalityGuardConflictGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "finality_guard_conflict",
+			Help:      "1 when the beacon node reports checkpoints conflicting with the pinned finalized checkpoint and signing is refused",
+		},
+	)
+	finalityGuardRefusalsCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "finality_guard_refusals_total",
+			Help:      "Count the blocks and attestations not signed because of the pinned finalized checkpoint, by kind",
+		},
+		[]string{
+			"kind",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+)
+
+const (
+	proposalCheckPath    = "/v2/validator/slashing-protection/check/block"
+	proposalCheckTimeout = 2 * time.Second
+)
+
+// ProposalCoordinationConfig defines the peer validator clients queried before a block is
+// proposed, so that hot-standby instances running the same keys never sign two different blocks
+// for the same slot.
+type ProposalCoordinationConfig struct {
+	// URLs of the peer validator client web APIs.
+	URLs []string
+	// AuthToken is sent as a bearer token to the peers.
+	AuthToken string
+	// Strict aborts the proposal when a peer can't be reached, instead of only warning.
+	Strict bool
+}
+
+// proposalCoordinator asks every configured peer validator client whether its own slashing
+// protection history allows the proposal, using the block check endpoint of its web API.
+type proposalCoordinator struct {
+	urls   []string
+	token  string
+	strict bool
+	client *http.Client
+}
+
+type proposalCheckRequest struct {
+	PublicKey   string               `json:"public_key"`
+	SigningRoot string               `json:"signing_root"`
+	Header      *proposalCheckHeader `json:"header"`
+}
+
+type proposalCheckHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type proposalCheckResponse struct {
+	Data *struct {
+		Allowed bool   `json:"allowed"`
+		Reason  string `json:"reason"`
+	} `json:"data"`
+}
+
+// newProposalCoordinator returns nil when no peers are configured, which disables coordination.
+func newProposalCoordinator(cfg *ProposalCoordinationConfig) *proposalCoordinator {
+	if cfg == nil || len(cfg.URLs) == 0 {
+		return nil
+	}
+	urls := make([]string, 0, len(cfg.URLs))
+	for _, u := range cfg.URLs {
+		if u = strings.TrimSuffix(strings.TrimSpace(u), "/"); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	return &proposalCoordinator{
+		urls:   urls,
+		token:  cfg.AuthToken,
+		strict: cfg.Strict,
+		client: &http.Client{Timeout: proposalCheckTimeout},
+	}
+}
+
+// checkProposal returns an error if any peer reports that signing the block would be slashable
+// given its history, which means another instance already proposed a different block for the slot.
+// Unreachable peers only produce a warning unless the coordinator is strict.
+func (c *proposalCoordinator) checkProposal(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	header *ethpb.BeaconBlockHeader,
+	signingRoot [32]byte,
+) error {
+	body, err := json.Marshal(&proposalCheckRequest{
+		PublicKey:   hexutil.Encode(pubKey[:]),
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+		Header: &proposalCheckHeader{
+			Slot:          fmt.Sprintf("%d", header.Slot),
+			ProposerIndex: fmt.Sprintf("%d", header.ProposerIndex),
+			ParentRoot:    hexutil.Encode(header.ParentRoot),
+			StateRoot:     hexutil.Encode(header.StateRoot),
+			BodyRoot:      hexutil.Encode(header.BodyRoot),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not marshal proposal check request")
+	}
+	for _, u := range c.urls {
+		resp, err := c.query(ctx, u, body)
+		if err != nil {
+			if c.strict {
+				return errors.Wrapf(err, "could not check proposal with peer %s", u)
+			}
+			log.WithError(err).WithField("peer", u).Warn("Could not check proposal with peer validator client")
+			continue
+		}
+		if !resp.Data.Allowed {
+			return fmt.Errorf("peer %s rejected proposal for slot %d: %s", u, header.Slot, resp.Data.Reason)
+		}
+	}
+	return nil
+}
+
+func (c *proposalCoordinator) query(ctx context.Context, url string, body []byte) (*proposalCheckResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+proposalCheckPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if httpResp.StatusCode != http.StatusOK {
+		msg, err := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unexpected status code %d", httpResp.StatusCode)
+		}
+		return nil, fmt.Errorf("unexpected status code %d: %s", httpResp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	resp := &proposalCheckResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, errors.Wrap(err, "could not decode response")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("response has no data")
+	}
+	return resp, nil
+}
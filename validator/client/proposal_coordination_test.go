@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func proposalCheckServer(t *testing.T, allowed bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, proposalCheckPath, r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req := &proposalCheckRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Equal(t, "3", req.Header.Slot)
+		resp := &proposalCheckResponse{}
+		resp.Data = &struct {
+			Allowed bool   `json:"allowed"`
+			Reason  string `json:"reason"`
+		}{Allowed: allowed}
+		if !allowed {
+			resp.Data.Reason = "a different block was already signed at the slot"
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func TestProposalCoordinator_CheckProposal(t *testing.T) {
+	ctx := context.Background()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	header := &ethpb.BeaconBlockHeader{
+		Slot:       3,
+		ParentRoot: bytesutil.PadTo([]byte("parent"), 32),
+		StateRoot:  bytesutil.PadTo([]byte("state"), 32),
+		BodyRoot:   bytesutil.PadTo([]byte("body"), 32),
+	}
+	signingRoot := [32]byte{'a'}
+
+	allowing := proposalCheckServer(t, true)
+	defer allowing.Close()
+	rejecting := proposalCheckServer(t, false)
+	defer rejecting.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	assert.Equal(t, true, newProposalCoordinator(nil) == nil)
+	assert.Equal(t, true, newProposalCoordinator(&ProposalCoordinationConfig{URLs: []string{" "}}) == nil)
+
+	c := newProposalCoordinator(&ProposalCoordinationConfig{URLs: []string{allowing.URL + "/"}, AuthToken: "token"})
+	require.NoError(t, c.checkProposal(ctx, pubKey, header, signingRoot))
+
+	c = newProposalCoordinator(&ProposalCoordinationConfig{URLs: []string{allowing.URL, rejecting.URL}, AuthToken: "token"})
+	err := c.checkProposal(ctx, pubKey, header, signingRoot)
+	require.ErrorContains(t, "a different block was already signed at the slot", err)
+
+	c = newProposalCoordinator(&ProposalCoordinationConfig{URLs: []string{allowing.URL}, AuthToken: "wrong"})
+	require.NoError(t, c.checkProposal(ctx, pubKey, header, signingRoot))
+	c.strict = true
+	require.ErrorContains(t, "unexpected status code 401", c.checkProposal(ctx, pubKey, header, signingRoot))
+
+	c = newProposalCoordinator(&ProposalCoordinationConfig{URLs: []string{unreachable.URL}, AuthToken: "token"})
+	require.NoError(t, c.checkProposal(ctx, pubKey, header, signingRoot))
+	c.strict = true
+	require.ErrorContains(t, "could not check proposal with peer", c.checkProposal(ctx, pubKey, header, signingRoot))
+}
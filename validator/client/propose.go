@@ -22,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v3/runtime/version"
 	prysmTime "github.com/prysmaticlabs/prysm/v3/time"
 	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v3/validator/graffiti"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -70,6 +71,11 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 		// to produce the block.
 		log.WithError(err).Warn("Could not get graffiti")
 	}
+	if rendered, err := v.renderGraffiti(ctx, g, pubKey, slot); err != nil {
+		log.WithError(err).Warn("Could not render graffiti, using it as is")
+	} else {
+		g = rendered
+	}
 
 	// Request block from beacon node
 	b, err := v.validatorClient.GetBeaconBlock(ctx, &ethpb.BlockRequest{
@@ -95,6 +101,15 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 		return
 	}
 
+	if err := v.finalityGuard.checkProposal(ctx, wb.ParentRoot(), v.blockHeader); err != nil {
+		log.WithError(err).Error("Refusing to sign block from a beacon node conflicting with the pinned finalized checkpoint")
+		finalityGuardRefusalsCounterVec.WithLabelValues("block").Inc()
+		if v.emitAccountMetrics {
+			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+		}
+		return
+	}
+
 	sig, signingRoot, err := v.signBlock(ctx, pubKey, epoch, slot, wb)
 	if err != nil {
 		log.WithError(err).Error("Failed to sign block")
@@ -120,6 +135,26 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 		return
 	}
 
+	if v.proposalCoordinator != nil {
+		header, err := blk.Header()
+		if err != nil {
+			log.WithError(err).Error("Failed to get block header")
+			if v.emitAccountMetrics {
+				ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
+		}
+		if err := v.proposalCoordinator.checkProposal(ctx, pubKey, header.Header, signingRoot); err != nil {
+			log.WithFields(
+				blockLogFields(pubKey, wb, nil),
+			).WithError(err).Error("Failed proposal coordination check with peer validator clients")
+			if v.emitAccountMetrics {
+				ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
+		}
+	}
+
 	// Propose and broadcast block via beacon node
 	proposal, err := blk.PbGenericBlock()
 	if err != nil {
@@ -197,20 +232,14 @@ func ProposeExit(
 	if err != nil {
 		return errors.Wrap(err, "gRPC call to get validator index failed")
 	}
-	genesisResponse, err := nodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	currentEpoch, err := CurrentEpoch(ctx, nodeClient)
 	if err != nil {
-		return errors.Wrap(err, "gRPC call to get genesis time failed")
+		return err
 	}
-	totalSecondsPassed := prysmTime.Now().Unix() - genesisResponse.GenesisTime.Seconds
-	currentEpoch := types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)))
-
-	exit := &ethpb.VoluntaryExit{Epoch: currentEpoch, ValidatorIndex: indexResponse.Index}
-	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
+	signedExit, err := signedVoluntaryExit(ctx, validatorClient, signer, pubKey, indexResponse.Index, currentEpoch)
 	if err != nil {
-		return errors.Wrap(err, "failed to sign voluntary exit")
+		return err
 	}
-
-	signedExit := &ethpb.SignedVoluntaryExit{Exit: exit, Signature: sig}
 	exitResp, err := validatorClient.ProposeExit(ctx, signedExit)
 	if err != nil {
 		return errors.Wrap(err, "failed to propose voluntary exit")
@@ -223,6 +252,51 @@ func ProposeExit(
 	return nil
 }
 
+// CreateSignedVoluntaryExit signs a voluntary exit of the validator with the given public key, taking
+// effect at the given epoch. The exit isn't broadcast, which allows to pre-sign exits for a future epoch.
+func CreateSignedVoluntaryExit(
+	ctx context.Context,
+	validatorClient ethpb.BeaconNodeValidatorClient,
+	signer iface.SigningFunc,
+	pubKey []byte,
+	epoch types.Epoch,
+) (*ethpb.SignedVoluntaryExit, error) {
+	ctx, span := trace.StartSpan(ctx, "validator.CreateSignedVoluntaryExit")
+	defer span.End()
+
+	indexResponse, err := validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey})
+	if err != nil {
+		return nil, errors.Wrap(err, "gRPC call to get validator index failed")
+	}
+	return signedVoluntaryExit(ctx, validatorClient, signer, pubKey, indexResponse.Index, epoch)
+}
+
+func signedVoluntaryExit(
+	ctx context.Context,
+	validatorClient ethpb.BeaconNodeValidatorClient,
+	signer iface.SigningFunc,
+	pubKey []byte,
+	index types.ValidatorIndex,
+	epoch types.Epoch,
+) (*ethpb.SignedVoluntaryExit, error) {
+	exit := &ethpb.VoluntaryExit{Epoch: epoch, ValidatorIndex: index}
+	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+	return &ethpb.SignedVoluntaryExit{Exit: exit, Signature: sig}, nil
+}
+
+// CurrentEpoch returns the current epoch, computed from the genesis time of the beacon node.
+func CurrentEpoch(ctx context.Context, nodeClient ethpb.NodeClient) (types.Epoch, error) {
+	genesisResponse, err := nodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, errors.Wrap(err, "gRPC call to get genesis time failed")
+	}
+	totalSecondsPassed := prysmTime.Now().Unix() - genesisResponse.GenesisTime.Seconds
+	return types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))), nil
+}
+
 // Sign randao reveal with randao domain and private key.
 func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, epoch types.Epoch, slot types.Slot) ([]byte, error) {
 	domain, err := v.domainData(ctx, epoch, params.BeaconConfig().DomainRandao[:])
@@ -322,6 +396,27 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
+// Fills the variables of the graffiti template for the proposal of the validator at the slot.
+// Static graffiti are returned as is.
+func (v *validator) renderGraffiti(ctx context.Context, tmpl []byte, pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot) ([]byte, error) {
+	if !graffiti.IsTemplate(string(tmpl)) {
+		return tmpl, nil
+	}
+	data := &graffiti.TemplateData{Slot: slot}
+	if v.graffitiStruct != nil {
+		data.Tags = v.graffitiStruct.Tags
+	}
+	// The validator index is only requested when used, as the graffiti file may have requested it already.
+	if graffiti.UsesVariable(string(tmpl), graffiti.IndexVariable) {
+		idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
+		if err != nil {
+			return nil, err
+		}
+		data.Index = idx.Index
+	}
+	return graffiti.Render(string(tmpl), data)
+}
+
 // Gets the graffiti from cli or file for the validator public key.
 func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
 	// When specified, default graffiti from the command line takes the first priority.
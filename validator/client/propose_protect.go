@@ -9,27 +9,27 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	vdb "github.com/prysmaticlabs/prysm/v3/validator/db"
+	"github.com/prysmaticlabs/prysm/v3/validator/db/kv"
 	"github.com/sirupsen/logrus"
 )
 
 var failedBlockSignLocalErr = "attempted to sign a double proposal, block rejected by local protection"
 var failedBlockSignExternalErr = "attempted a double proposal, block rejected by remote slashing protection"
 
-func (v *validator) slashableProposalCheck(
-	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signedBlock interfaces.SignedBeaconBlock, signingRoot [32]byte,
+// CheckSlashableProposal checks a block proposal at the given slot against the proposal history
+// of the given public key in the database, applying the rules used before signing blocks. A
+// *SlashingProtectionError is returned when signing must be refused. Nothing is saved.
+func CheckSlashableProposal(
+	ctx context.Context, valDB vdb.Database, pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot, signingRoot [32]byte,
 ) error {
-	fmtKey := fmt.Sprintf("%#x", pubKey[:])
-
-	blk := signedBlock.Block()
-	prevSigningRoot, proposalAtSlotExists, err := v.db.ProposalHistoryForSlot(ctx, pubKey, blk.Slot())
+	prevSigningRoot, proposalAtSlotExists, err := valDB.ProposalHistoryForSlot(ctx, pubKey, slot)
 	if err != nil {
-		if v.emitAccountMetrics {
-			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
-		}
 		return errors.Wrap(err, "failed to get proposal history")
 	}
 
-	lowestSignedProposalSlot, lowestProposalExists, err := v.db.LowestSignedProposal(ctx, pubKey)
+	lowestSignedProposalSlot, lowestProposalExists, err := valDB.LowestSignedProposal(ctx, pubKey)
 	if err != nil {
 		return err
 	}
@@ -40,22 +40,35 @@ func (v *validator) slashableProposalCheck(
 	// we consider that proposal slashable.
 	signingRootIsDifferent := prevSigningRoot == params.BeaconConfig().ZeroHash || prevSigningRoot != signingRoot
 	if proposalAtSlotExists && signingRootIsDifferent {
-		if v.emitAccountMetrics {
-			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
-		}
-		return errors.New(failedBlockSignLocalErr)
+		return &SlashingProtectionError{Kind: kv.DoubleVote, err: errors.New(failedBlockSignLocalErr)}
 	}
 
 	// Based on EIP3076, validator should refuse to sign any proposal with slot less
 	// than or equal to the minimum signed proposal present in the DB for that public key.
 	// In the case the slot of the incoming block is equal to the minimum signed proposal, we
 	// then also check the signing root is different.
-	if lowestProposalExists && signingRootIsDifferent && lowestSignedProposalSlot >= blk.Slot() {
-		return fmt.Errorf(
+	if lowestProposalExists && signingRootIsDifferent && lowestSignedProposalSlot >= slot {
+		return &SlashingProtectionError{Kind: kv.NotSlashable, err: fmt.Errorf(
 			"could not sign block with slot <= lowest signed slot in db, lowest signed slot: %d >= block slot: %d",
 			lowestSignedProposalSlot,
-			blk.Slot(),
-		)
+			slot,
+		)}
+	}
+	return nil
+}
+
+func (v *validator) slashableProposalCheck(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, signedBlock interfaces.SignedBeaconBlock, signingRoot [32]byte,
+) error {
+	fmtKey := fmt.Sprintf("%#x", pubKey[:])
+
+	blk := signedBlock.Block()
+	if err := CheckSlashableProposal(ctx, v.db, pubKey, blk.Slot(), signingRoot); err != nil {
+		var protectionErr *SlashingProtectionError
+		if v.emitAccountMetrics && (!errors.As(err, &protectionErr) || protectionErr.Kind != kv.NotSlashable) {
+			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
+		}
+		return err
 	}
 
 	if features.Get().RemoteSlasherProtection {
@@ -509,6 +509,11 @@ func TestProposeBlock_BroadcastsBlock_WithGraffiti(t *testing.T) {
 	testProposeBlock(t, blockGraffiti)
 }
 
+func TestProposeBlock_BroadcastsBlock_WithUnrenderableGraffiti(t *testing.T) {
+	// The tag is not defined, so the graffiti is used as is.
+	testProposeBlock(t, []byte("{tag:team} is not a defined tag!"))
+}
+
 func testProposeBlock(t *testing.T, graffiti []byte) {
 	tests := []struct {
 		name  string
@@ -1001,3 +1006,37 @@ func TestGetGraffitiOrdered_Ok(t *testing.T) {
 		require.DeepEqual(t, want, got)
 	}
 }
+
+func TestRenderGraffiti(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	ctrl := gomock.NewController(t)
+	m := &mocks{
+		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+	}
+	v := &validator{
+		validatorClient: m.validatorClient,
+		graffitiStruct: &graffiti.Graffiti{
+			Tags: map[string]string{"operator": "Mr T"},
+		},
+	}
+
+	// The validator index is not requested when the template does not use it.
+	got, err := v.renderGraffiti(context.Background(), []byte("{tag:operator} {slot}"), pubKey, 100)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("Mr T 100"), got)
+
+	m.validatorClient.EXPECT().
+		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
+		Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+	got, err = v.renderGraffiti(context.Background(), []byte("{tag:operator} #{index}"), pubKey, 100)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("Mr T #2"), got)
+
+	_, err = v.renderGraffiti(context.Background(), []byte("{tag:team}"), pubKey, 100)
+	require.ErrorContains(t, "undefined tag", err)
+
+	// Static graffiti containing braces are not templates.
+	got, err = v.renderGraffiti(context.Background(), []byte("{{json} {"), pubKey, 100)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("{{json} {"), got)
+}
@@ -118,6 +118,9 @@ func run(ctx context.Context, v iface.Validator) {
 				continue
 			}
 
+			// Check the checkpoints of the beacon node before signing anything in the slot.
+			go v.CheckFinality(slotCtx, slot)
+
 			if slots.IsEpochStart(slot) {
 				go func() {
 					//deadline set for next epoch rounded up
@@ -141,6 +144,12 @@ func run(ctx context.Context, v iface.Validator) {
 				continue
 			}
 			performRoles(slotCtx, allRoles, v, slot, &wg, span)
+
+			// Start computing the sync committee selections of the next slot.
+			go v.PrepareSyncCommitteeSelections(slotCtx, slot+1)
+
+			// Broadcast attestations which were not included in time again.
+			go v.RebroadcastAttestations(slotCtx, slot)
 		}
 	}
 }
@@ -14,13 +14,16 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v3/api/grpc"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v3/cache/lru"
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
 	"github.com/prysmaticlabs/prysm/v3/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpbservice "github.com/prysmaticlabs/prysm/v3/proto/eth/service"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
+	beaconApi "github.com/prysmaticlabs/prysm/v3/validator/client/beacon-api"
 	"github.com/prysmaticlabs/prysm/v3/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/v3/validator/db"
 	"github.com/prysmaticlabs/prysm/v3/validator/graffiti"
@@ -53,6 +56,8 @@ type ValidatorService struct {
 	logValidatorBalances  bool
 	interopKeysConfig     *local.InteropKeymanagerConfig
 	conn                  *grpc.ClientConn
+	beaconNodes           []*beaconNode
+	failover              *failoverValidatorClient
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -63,6 +68,8 @@ type ValidatorService struct {
 	dataDir               string
 	withCert              string
 	endpoint              string
+	beaconApiEndpoint     string
+	beaconApiTimeout      time.Duration
 	ctx                   context.Context
 	validator             iface.Validator
 	db                    db.Database
@@ -70,6 +77,10 @@ type ValidatorService struct {
 	graffiti              []byte
 	Web3SignerConfig      *remoteweb3signer.SetupConfig
 	ProposerSettings      *validatorserviceconfig.ProposerSettings
+	proposalCoordinator   *proposalCoordinator
+	rebroadcaster         *attestationRebroadcaster
+	rebroadcastConn       *grpc.ClientConn
+	finalityGuard         *finalityGuard
 }
 
 // Config for the validator service.
@@ -91,8 +102,13 @@ type Config struct {
 	GrpcHeadersFlag            string
 	GraffitiFlag               string
 	Endpoint                   string
+	BeaconApiEndpoint          string
+	BeaconApiTimeout           time.Duration
 	Web3SignerConfig           *remoteweb3signer.SetupConfig
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
+	ProposalCoordination       *ProposalCoordinationConfig
+	AttestationRebroadcast     *AttestationRebroadcastConfig
+	FinalityGuard              *FinalityGuardConfig
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -103,6 +119,8 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		ctx:                   ctx,
 		cancel:                cancel,
 		endpoint:              cfg.Endpoint,
+		beaconApiEndpoint:     cfg.BeaconApiEndpoint,
+		beaconApiTimeout:      cfg.BeaconApiTimeout,
 		withCert:              cfg.CertFlag,
 		dataDir:               cfg.DataDir,
 		graffiti:              []byte(cfg.GraffitiFlag),
@@ -121,6 +139,21 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		graffitiStruct:        cfg.GraffitiStruct,
 		Web3SignerConfig:      cfg.Web3SignerConfig,
 		ProposerSettings:      cfg.ProposerSettings,
+		proposalCoordinator:   newProposalCoordinator(cfg.ProposalCoordination),
+		rebroadcaster:         newAttestationRebroadcaster(cfg.AttestationRebroadcast),
+		finalityGuard:         newFinalityGuard(cfg.FinalityGuard),
+	}
+
+	// The beacon REST API replaces the gRPC connection altogether.
+	if features.Get().EnableBeaconRESTApi {
+		if features.Get().RemoteSlasherProtection {
+			return s, errors.New("remote slashing protection is not supported with the beacon REST API")
+		}
+		if s.rebroadcaster != nil {
+			return s, errors.New("attestation rebroadcasting is not supported with the beacon REST API")
+		}
+		log.WithField("endpoint", s.beaconApiEndpoint).Info("Using the beacon REST API")
+		return s, nil
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -135,14 +168,37 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 
 	s.ctx = grpcutil.AppendHeaders(ctx, s.grpcHeaders)
 
-	conn, err := grpc.DialContext(ctx, s.endpoint, dialOpts...)
-	if err != nil {
-		return s, err
+	// When several beacon nodes are provided, connect to each of them separately so that
+	// requests can be routed to the healthiest node and signed objects broadcast to all of them.
+	endpoints := ParseBeaconNodeEndpoints(s.endpoint)
+	if len(endpoints) > 1 {
+		for _, endpoint := range endpoints {
+			nodeConn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+			if err != nil {
+				return s, errors.Wrapf(err, "could not dial beacon node %s", endpoint)
+			}
+			s.beaconNodes = append(s.beaconNodes, newBeaconNode(endpoint, nodeConn))
+		}
+		s.failover = newFailoverValidatorClient(s.beaconNodes)
+		log.WithField("endpoints", endpoints).Info("Using multiple beacon nodes with automatic failover")
+	} else {
+		conn, err := grpc.DialContext(ctx, s.endpoint, dialOpts...)
+		if err != nil {
+			return s, err
+		}
+		s.conn = conn
+	}
+	if s.rebroadcaster != nil {
+		conn, err := grpc.DialContext(ctx, s.rebroadcaster.endpoint, dialOpts...)
+		if err != nil {
+			return s, errors.Wrapf(err, "could not dial beacon node %s to rebroadcast attestations", s.rebroadcaster.endpoint)
+		}
+		s.rebroadcastConn = conn
+		s.rebroadcaster.validatorClient = ethpb.NewBeaconNodeValidatorClient(conn)
 	}
 	if s.withCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
-	s.conn = conn
 
 	return s, nil
 }
@@ -177,14 +233,33 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
+	var validatorClient ethpb.BeaconNodeValidatorClient = ethpb.NewBeaconNodeValidatorClient(v.clientConn())
+	var beaconClient ethpb.BeaconChainClient = ethpb.NewBeaconChainClient(v.clientConn())
+	logValidatorBalances := v.logValidatorBalances
+	if features.Get().EnableBeaconRESTApi {
+		validatorClient = beaconApi.NewBeaconApiValidatorClient(v.beaconApiEndpoint, v.beaconApiTimeout)
+		beaconClient = beaconApi.NewBeaconApiBeaconChainClient(v.beaconApiEndpoint, v.beaconApiTimeout)
+		if logValidatorBalances {
+			log.Warn("Validator performance is not available through the beacon REST API, disabling balance logging")
+			logValidatorBalances = false
+		}
+	} else if v.failover != nil {
+		go v.failover.run(v.ctx)
+		validatorClient = v.failover
+	}
+
 	valStruct := &validator{
 		db:                             v.db,
-		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
-		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
-		slashingProtectionClient:       ethpb.NewSlasherClient(v.conn),
-		node:                           ethpb.NewNodeClient(v.conn),
+		validatorClient:                validatorClient,
+		beaconClient:                   beaconClient,
+		beaconClientV1:                 ethpbservice.NewBeaconChainClient(v.clientConn()),
+		slashingProtectionClient:       ethpb.NewSlasherClient(v.clientConn()),
+		proposalCoordinator:            v.proposalCoordinator,
+		attestationRebroadcaster:       v.rebroadcaster,
+		finalityGuard:                  v.finalityGuard,
+		node:                           v.nodeClient(),
 		graffiti:                       v.graffiti,
-		logValidatorBalances:           v.logValidatorBalances,
+		logValidatorBalances:           logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
 		startBalances:                  make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
 		prevBalance:                    make(map[[fieldparams.BLSPubkeyLength]byte]uint64),
@@ -225,6 +300,16 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	for _, n := range v.beaconNodes {
+		if err := n.conn.Close(); err != nil {
+			log.WithError(err).WithField("endpoint", n.endpoint).Error("Could not close beacon node connection")
+		}
+	}
+	if v.rebroadcastConn != nil {
+		if err := v.rebroadcastConn.Close(); err != nil {
+			log.WithError(err).Error("Could not close attestation rebroadcast connection")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.Close()
 	}
@@ -233,7 +318,7 @@ func (v *ValidatorService) Stop() error {
 
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
-	if v.conn == nil {
+	if v.conn == nil && v.failover == nil && !features.Get().EnableBeaconRESTApi {
 		return errors.New("no connection to beacon RPC")
 	}
 	return nil
@@ -302,10 +387,22 @@ func ConstructDialOptions(
 	return dialOpts
 }
 
+// ParseBeaconNodeEndpoints splits a comma-separated list of beacon node endpoints,
+// ignoring surrounding whitespace and empty entries.
+func ParseBeaconNodeEndpoints(endpoint string) []string {
+	var endpoints []string
+	for _, e := range strings.Split(endpoint, ",") {
+		e = strings.TrimSpace(e)
+		if e != "" {
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints
+}
+
 // Syncing returns whether or not the beacon node is currently synchronizing the chain.
 func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
-	nc := ethpb.NewNodeClient(v.conn)
-	resp, err := nc.GetSyncStatus(ctx, &emptypb.Empty{})
+	resp, err := v.nodeClient().GetSyncStatus(ctx, &emptypb.Empty{})
 	if err != nil {
 		return false, err
 	}
@@ -315,6 +412,23 @@ func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
 // GenesisInfo queries the beacon node for the chain genesis info containing
 // the genesis time along with the validator deposit contract address.
 func (v *ValidatorService) GenesisInfo(ctx context.Context) (*ethpb.Genesis, error) {
-	nc := ethpb.NewNodeClient(v.conn)
-	return nc.GetGenesis(ctx, &emptypb.Empty{})
+	return v.nodeClient().GetGenesis(ctx, &emptypb.Empty{})
+}
+
+// nodeClient returns a client for the node API of the beacon node, either over gRPC or
+// over the beacon REST API.
+func (v *ValidatorService) nodeClient() ethpb.NodeClient {
+	if features.Get().EnableBeaconRESTApi {
+		return beaconApi.NewBeaconApiNodeClient(v.beaconApiEndpoint, v.beaconApiTimeout)
+	}
+	return ethpb.NewNodeClient(v.clientConn())
+}
+
+// clientConn returns the gRPC connection to the beacon node. With several beacon nodes, requests
+// made on the connection are routed to the healthiest node, like the requests of the validator client.
+func (v *ValidatorService) clientConn() grpc.ClientConnInterface {
+	if v.failover != nil {
+		return &failoverConn{client: v.failover}
+	}
+	return v.conn
 }
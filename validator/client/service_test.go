@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prysmaticlabs/prysm/v3/config/features"
 	"github.com/prysmaticlabs/prysm/v3/runtime"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
@@ -72,3 +73,35 @@ func TestStart_GrpcHeaders(t *testing.T) {
 		}
 	}
 }
+
+func TestNew_MultipleEndpoints(t *testing.T) {
+	validatorService, err := NewValidatorService(context.Background(), &Config{Endpoint: "127.0.0.1:4000,127.0.0.1:4001"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(validatorService.beaconNodes))
+	assert.Equal(t, "127.0.0.1:4000", validatorService.beaconNodes[0].endpoint)
+	assert.Equal(t, "127.0.0.1:4001", validatorService.beaconNodes[1].endpoint)
+	// Every client goes through the failover connection rather than a connection to the raw list.
+	assert.Equal(t, true, validatorService.conn == nil)
+	_, ok := validatorService.clientConn().(*failoverConn)
+	assert.Equal(t, true, ok)
+	assert.NoError(t, validatorService.Status())
+	assert.NoError(t, validatorService.Stop())
+}
+
+func TestNew_BeaconRESTApi(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableBeaconRESTApi: true})
+	defer resetCfg()
+	validatorService, err := NewValidatorService(context.Background(), &Config{
+		Endpoint:          "127.0.0.1:4000",
+		BeaconApiEndpoint: "http://127.0.0.1:3500",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, validatorService.conn == nil)
+	assert.NoError(t, validatorService.Status())
+	assert.NoError(t, validatorService.Stop())
+
+	resetCfg = features.InitWithReset(&features.Flags{EnableBeaconRESTApi: true, RemoteSlasherProtection: true})
+	defer resetCfg()
+	_, err = NewValidatorService(context.Background(), &Config{BeaconApiEndpoint: "http://127.0.0.1:3500"})
+	assert.ErrorContains(t, "remote slashing protection is not supported", err)
+}
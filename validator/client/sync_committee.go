@@ -3,10 +3,12 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	emptypb "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
@@ -21,6 +23,27 @@ import (
 	"go.opencensus.io/trace"
 )
 
+// syncCommitteeSelectionWorkers is the number of keys for which sync committee selections are
+// computed concurrently. Remote signers are mostly bound by latency, so this exceeds the CPU count
+// of most hosts.
+const syncCommitteeSelectionWorkers = 32
+
+// syncCommitteeSelection holds the subcommittee indices of a validator in the sync committee at a
+// slot, along with its selection proof for the subnet of each index.
+type syncCommitteeSelection struct {
+	indices         []types.CommitteeIndex
+	selectionProofs [][]byte
+}
+
+// syncMessageBlockRootRequest is the request of the block root signed by sync committee messages at
+// a slot, shared by all the keys of the validator.
+type syncMessageBlockRootRequest struct {
+	slot types.Slot
+	done chan struct{}
+	root []byte
+	err  error
+}
+
 // SubmitSyncCommitteeMessage submits the sync committee message to the beacon chain.
 func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) {
 	ctx, span := trace.StartSpan(ctx, "validator.SubmitSyncCommitteeMessage")
@@ -29,7 +52,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 
 	v.waitOneThirdOrValidBlock(ctx, slot)
 
-	res, err := v.validatorClient.GetSyncMessageBlockRoot(ctx, &emptypb.Empty{})
+	blockRoot, err := v.syncMessageBlockRoot(ctx, slot)
 	if err != nil {
 		log.WithError(err).Error("Could not request sync message block root to sign")
 		tracing.AnnotateError(span, err)
@@ -47,7 +70,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 		log.WithError(err).Error("Could not get sync committee domain data")
 		return
 	}
-	sszRoot := types.SSZBytes(res.Root)
+	sszRoot := types.SSZBytes(blockRoot)
 	r, err := signing.ComputeSigningRoot(&sszRoot, d.SignatureDomain)
 	if err != nil {
 		log.WithError(err).Error("Could not get sync committee message signing root")
@@ -59,7 +82,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 		SigningRoot:     r[:],
 		SignatureDomain: d.SignatureDomain,
 		Object: &validatorpb.SignRequest_SyncMessageBlockRoot{
-			SyncMessageBlockRoot: res.Root,
+			SyncMessageBlockRoot: blockRoot,
 		},
 		SigningSlot: slot,
 	})
@@ -70,7 +93,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 
 	msg := &ethpb.SyncCommitteeMessage{
 		Slot:           slot,
-		BlockRoot:      res.Root,
+		BlockRoot:      blockRoot,
 		ValidatorIndex: duty.ValidatorIndex,
 		Signature:      sig.Marshal(),
 	}
@@ -81,6 +104,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 
 	msgSlot := msg.Slot
 	slotTime := time.Unix(int64(v.genesisTime+uint64(msgSlot)*params.BeaconConfig().SecondsPerSlot), 0)
+	syncCommitteeSubmissionDelayHistogramVec.WithLabelValues("message").Observe(time.Since(slotTime).Seconds())
 	log.WithFields(logrus.Fields{
 		"slot":               msg.Slot,
 		"slotStartTime":      slotTime,
@@ -103,29 +127,32 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		return
 	}
 
-	indexRes, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
-		PublicKey: pubKey[:],
-		Slot:      slot,
-	})
-	if err != nil {
-		log.WithError(err).Error("Could not get sync subcommittee index")
-		return
+	selection, ok := v.cachedSyncCommitteeSelection(slot, pubKey)
+	if !ok {
+		indexRes, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
+			PublicKey: pubKey[:],
+			Slot:      slot,
+		})
+		if err != nil {
+			log.WithError(err).Error("Could not get sync subcommittee index")
+			return
+		}
+		selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes)
+		if err != nil {
+			log.WithError(err).Error("Could not get selection proofs")
+			return
+		}
+		selection = v.cacheSyncCommitteeSelection(slot, pubKey, indexRes.Indices, selectionProofs)
 	}
-	if len(indexRes.Indices) == 0 {
+	if len(selection.indices) == 0 {
 		log.Debug("Empty subcommittee index list, do nothing")
 		return
 	}
 
-	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes)
-	if err != nil {
-		log.WithError(err).Error("Could not get selection proofs")
-		return
-	}
-
 	v.waitToSlotTwoThirds(ctx, slot)
 
-	for i, comIdx := range indexRes.Indices {
-		isAggregator, err := altair.IsSyncCommitteeAggregator(selectionProofs[i])
+	for i, comIdx := range selection.indices {
+		isAggregator, err := altair.IsSyncCommitteeAggregator(selection.selectionProofs[i])
 		if err != nil {
 			log.WithError(err).Error("Could check in aggregator")
 			return
@@ -156,7 +183,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		contributionAndProof := &ethpb.ContributionAndProof{
 			AggregatorIndex: duty.ValidatorIndex,
 			Contribution:    contribution,
-			SelectionProof:  selectionProofs[i],
+			SelectionProof:  selection.selectionProofs[i],
 		}
 		sig, err := v.signContributionAndProof(ctx, pubKey, contributionAndProof, slot)
 		if err != nil {
@@ -174,6 +201,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 
 		contributionSlot := contributionAndProof.Contribution.Slot
 		slotTime := time.Unix(int64(v.genesisTime+uint64(contributionSlot)*params.BeaconConfig().SecondsPerSlot), 0)
+		syncCommitteeSubmissionDelayHistogramVec.WithLabelValues("contribution").Observe(time.Since(slotTime).Seconds())
 		log.WithFields(logrus.Fields{
 			"slot":               contributionAndProof.Contribution.Slot,
 			"slotStartTime":      slotTime,
@@ -186,6 +214,176 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 	}
 }
 
+// syncMessageBlockRoot returns the block root to sign in the sync committee messages of the slot. The
+// beacon node is requested once per slot, the keys signing at the same time share the response.
+func (v *validator) syncMessageBlockRoot(ctx context.Context, slot types.Slot) ([]byte, error) {
+	v.syncMessageBlockRootLock.Lock()
+	req := v.syncMessageBlockRootReq
+	if req != nil && req.slot == slot {
+		v.syncMessageBlockRootLock.Unlock()
+		select {
+		case <-req.done:
+			return req.root, req.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	req = &syncMessageBlockRootRequest{slot: slot, done: make(chan struct{})}
+	v.syncMessageBlockRootReq = req
+	v.syncMessageBlockRootLock.Unlock()
+
+	res, err := v.validatorClient.GetSyncMessageBlockRoot(ctx, &emptypb.Empty{})
+	if err != nil {
+		req.err = err
+		// Let the next key retry the request.
+		v.syncMessageBlockRootLock.Lock()
+		if v.syncMessageBlockRootReq == req {
+			v.syncMessageBlockRootReq = nil
+		}
+		v.syncMessageBlockRootLock.Unlock()
+	} else {
+		req.root = res.Root
+	}
+	close(req.done)
+	return req.root, req.err
+}
+
+// PrepareSyncCommitteeSelections computes ahead of the slot the subcommittee indices and selection
+// proofs of the keys in the sync committee at the slot, so that neither the aggregator checks at the
+// start of the slot nor the contributions have to wait for them.
+func (v *validator) PrepareSyncCommitteeSelections(ctx context.Context, slot types.Slot) {
+	ctx, span := trace.StartSpan(ctx, "validator.PrepareSyncCommitteeSelections")
+	defer span.End()
+
+	pubKeys := v.syncCommitteeKeysAt(slot)
+	if len(pubKeys) == 0 {
+		return
+	}
+	start := time.Now()
+	keysCh := make(chan [fieldparams.BLSPubkeyLength]byte, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		keysCh <- pubKey
+	}
+	close(keysCh)
+
+	workers := syncCommitteeSelectionWorkers
+	if len(pubKeys) < workers {
+		workers = len(pubKeys)
+	}
+	var failed uint64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for pubKey := range keysCh {
+				if _, err := v.syncCommitteeSelection(ctx, slot, pubKey); err != nil {
+					atomic.AddUint64(&failed, 1)
+					log.WithError(err).WithFields(logrus.Fields{
+						"slot":   slot,
+						"pubKey": fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+					}).Debug("Could not prepare sync committee selection")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	log.WithFields(logrus.Fields{
+		"slot":    slot,
+		"keys":    len(pubKeys),
+		"failed":  failed,
+		"elapsed": time.Since(start),
+	}).Debug("Prepared sync committee selections")
+}
+
+// syncCommitteeKeysAt returns the keys in the sync committee at the slot, as far as the current
+// duties tell. It runs alongside the duties updates, which replace the duties under the lock.
+func (v *validator) syncCommitteeKeysAt(slot types.Slot) [][fieldparams.BLSPubkeyLength]byte {
+	v.dutiesLock.RLock()
+	duties, dutiesEpoch := v.duties, v.dutiesEpoch
+	v.dutiesLock.RUnlock()
+	if duties == nil {
+		return nil
+	}
+	// At the last slot of an epoch, the sync committee of the following epoch signs.
+	epoch := slots.ToEpoch(slot)
+	if slots.IsEpochEnd(slot) {
+		epoch++
+	}
+	var epochDuties []*ethpb.DutiesResponse_Duty
+	switch epoch {
+	case dutiesEpoch:
+		epochDuties = duties.Duties
+	case dutiesEpoch + 1:
+		epochDuties = duties.NextEpochDuties
+	default:
+		return nil
+	}
+	var pubKeys [][fieldparams.BLSPubkeyLength]byte
+	for _, duty := range epochDuties {
+		if duty != nil && duty.IsSyncCommittee {
+			pubKeys = append(pubKeys, bytesutil.ToBytes48(duty.PublicKey))
+		}
+	}
+	return pubKeys
+}
+
+// syncCommitteeSelection returns the sync committee selection of the key at the slot, from the cache
+// if it was prepared.
+func (v *validator) syncCommitteeSelection(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (*syncCommitteeSelection, error) {
+	if selection, ok := v.cachedSyncCommitteeSelection(slot, pubKey); ok {
+		return selection, nil
+	}
+
+	res, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
+		PublicKey: pubKey[:],
+		Slot:      slot,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync subcommittee index")
+	}
+	proofs, err := v.selectionProofs(ctx, slot, pubKey, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get selection proofs")
+	}
+	return v.cacheSyncCommitteeSelection(slot, pubKey, res.Indices, proofs), nil
+}
+
+// cachedSyncCommitteeSelection returns the sync committee selection of the key at the slot if it was
+// prepared.
+func (v *validator) cachedSyncCommitteeSelection(slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (*syncCommitteeSelection, bool) {
+	v.syncCommitteeSelectionsLock.RLock()
+	defer v.syncCommitteeSelectionsLock.RUnlock()
+	selection, ok := v.syncCommitteeSelections[slot][pubKey]
+	return selection, ok
+}
+
+// cacheSyncCommitteeSelection caches the sync committee selection of the key at the slot, and returns it.
+func (v *validator) cacheSyncCommitteeSelection(
+	slot types.Slot,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	indices []types.CommitteeIndex,
+	selectionProofs [][]byte,
+) *syncCommitteeSelection {
+	selection := &syncCommitteeSelection{indices: indices, selectionProofs: selectionProofs}
+	v.syncCommitteeSelectionsLock.Lock()
+	defer v.syncCommitteeSelectionsLock.Unlock()
+	if v.syncCommitteeSelections == nil {
+		v.syncCommitteeSelections = make(map[types.Slot]map[[fieldparams.BLSPubkeyLength]byte]*syncCommitteeSelection)
+	}
+	if v.syncCommitteeSelections[slot] == nil {
+		v.syncCommitteeSelections[slot] = make(map[[fieldparams.BLSPubkeyLength]byte]*syncCommitteeSelection)
+	}
+	v.syncCommitteeSelections[slot][pubKey] = selection
+	// Contributions of the previous slot are still being produced, older selections are unused.
+	for s := range v.syncCommitteeSelections {
+		if s+1 < slot {
+			delete(v.syncCommitteeSelections, s)
+		}
+	}
+	return selection
+}
+
 // Signs and returns selection proofs per validator for slot and pub key.
 func (v *validator) selectionProofs(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse) ([][]byte, error) {
 	selectionProofs := make([][]byte, len(indexRes.Indices))
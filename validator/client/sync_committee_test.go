@@ -3,12 +3,14 @@ package client
 import (
 	"context"
 	"encoding/hex"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
@@ -468,3 +470,146 @@ func TestSubmitSignedContributionAndProof_Ok(t *testing.T) {
 
 	validator.SubmitSignedContributionAndProof(context.Background(), 1, pubKey)
 }
+
+func TestSyncMessageBlockRoot_SharedAcrossKeys(t *testing.T) {
+	validator, m, _, finish := setup(t)
+	defer finish()
+
+	root := bytesutil.PadTo([]byte{'a'}, 32)
+	m.validatorClient.EXPECT().GetSyncMessageBlockRoot(
+		gomock.Any(), // ctx
+		&emptypb.Empty{},
+	).Return(&ethpb.SyncMessageBlockRootResponse{Root: root}, nil).Times(1)
+
+	var wg sync.WaitGroup
+	roots := make([][]byte, 8)
+	for i := range roots {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := validator.syncMessageBlockRoot(context.Background(), 1)
+			require.NoError(t, err)
+			roots[i] = r
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range roots {
+		assert.DeepEqual(t, root, r)
+	}
+
+	// The next slot requests a new root.
+	m.validatorClient.EXPECT().GetSyncMessageBlockRoot(
+		gomock.Any(), // ctx
+		&emptypb.Empty{},
+	).Return(&ethpb.SyncMessageBlockRootResponse{Root: make([]byte, 32)}, nil).Times(1)
+	r, err := validator.syncMessageBlockRoot(context.Background(), 2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]byte, 32), r)
+}
+
+func TestSyncMessageBlockRoot_RetriesAfterFailure(t *testing.T) {
+	validator, m, _, finish := setup(t)
+	defer finish()
+
+	root := bytesutil.PadTo([]byte{'a'}, 32)
+	gomock.InOrder(
+		m.validatorClient.EXPECT().GetSyncMessageBlockRoot(
+			gomock.Any(), // ctx
+			&emptypb.Empty{},
+		).Return(nil, errors.New("something bad happened")),
+		m.validatorClient.EXPECT().GetSyncMessageBlockRoot(
+			gomock.Any(), // ctx
+			&emptypb.Empty{},
+		).Return(&ethpb.SyncMessageBlockRootResponse{Root: root}, nil),
+	)
+
+	_, err := validator.syncMessageBlockRoot(context.Background(), 1)
+	require.ErrorContains(t, "something bad happened", err)
+	r, err := validator.syncMessageBlockRoot(context.Background(), 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, root, r)
+}
+
+func TestSyncCommitteeKeysAt(t *testing.T) {
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+	validator.dutiesEpoch = 1
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: pubKey[:], IsSyncCommittee: true},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: pubKey[:], IsSyncCommittee: false},
+		},
+	}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, validator.syncCommitteeKeysAt(slotsPerEpoch))
+	// The last slot of the epoch is signed by the sync committee of the next epoch.
+	assert.Equal(t, 0, len(validator.syncCommitteeKeysAt(2*slotsPerEpoch-1)))
+	assert.Equal(t, 0, len(validator.syncCommitteeKeysAt(2*slotsPerEpoch)))
+	// Unknown duties.
+	assert.Equal(t, 0, len(validator.syncCommitteeKeysAt(slotsPerEpoch-2)))
+	assert.Equal(t, 0, len(validator.syncCommitteeKeysAt(3*slotsPerEpoch)))
+}
+
+func TestPrepareSyncCommitteeSelections_CachesSelections(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+	validator.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: pubKey[:], ValidatorIndex: 7, IsSyncCommittee: true},
+		},
+	}
+
+	m.validatorClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		&ethpb.SyncSubcommitteeIndexRequest{
+			Slot:      1,
+			PublicKey: pubKey[:],
+		},
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{1}}, nil).Times(1)
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), // ctx
+			gomock.Any()). // epoch
+		Return(&ethpb.DomainResponse{
+			SignatureDomain: make([]byte, 32),
+		}, nil)
+
+	validator.PrepareSyncCommitteeSelections(context.Background(), 1)
+	require.Equal(t, 1, len(validator.syncCommitteeSelections[1]))
+
+	// The aggregator check and the contribution use the prepared selection.
+	_, err := validator.isSyncCommitteeAggregator(context.Background(), 1, pubKey)
+	require.NoError(t, err)
+	selection, err := validator.syncCommitteeSelection(context.Background(), 1, pubKey)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []types.CommitteeIndex{1}, selection.indices)
+	assert.Equal(t, 1, len(selection.selectionProofs))
+}
+
+func TestSyncCommitteeSelection_PrunesOldSlots(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t)
+	defer finish()
+
+	pubKey := bytesutil.ToBytes48(validatorKey.PublicKey().Marshal())
+	m.validatorClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{}, nil).Times(3)
+
+	for _, slot := range []types.Slot{1, 2, 3} {
+		_, err := validator.syncCommitteeSelection(context.Background(), slot, pubKey)
+		require.NoError(t, err)
+	}
+	_, ok := validator.syncCommitteeSelections[1]
+	assert.Equal(t, false, ok)
+	_, ok = validator.syncCommitteeSelections[2]
+	assert.Equal(t, true, ok)
+	_, ok = validator.syncCommitteeSelections[3]
+	assert.Equal(t, true, ok)
+}
@@ -186,6 +186,15 @@ func (_ *FakeValidator) LogAttestationsSubmitted() {}
 // UpdateDomainDataCaches for mocking.
 func (_ *FakeValidator) UpdateDomainDataCaches(context.Context, types.Slot) {}
 
+// PrepareSyncCommitteeSelections for mocking.
+func (_ *FakeValidator) PrepareSyncCommitteeSelections(context.Context, types.Slot) {}
+
+// RebroadcastAttestations for mocking.
+func (_ *FakeValidator) RebroadcastAttestations(context.Context, types.Slot) {}
+
+// CheckFinality for mocking.
+func (_ *FakeValidator) CheckFinality(context.Context, types.Slot) {}
+
 // BalancesByPubkeys for mocking.
 func (fv *FakeValidator) BalancesByPubkeys(_ context.Context) map[[fieldparams.BLSPubkeyLength]byte]uint64 {
 	return fv.Balances
@@ -31,6 +31,7 @@ import (
 	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	ethpbservice "github.com/prysmaticlabs/prysm/v3/proto/eth/service"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v3/time/slots"
 	accountsiface "github.com/prysmaticlabs/prysm/v3/validator/accounts/iface"
@@ -44,7 +45,9 @@ import (
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v3/validator/keymanager/remote-web3signer"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -70,13 +73,18 @@ type validator struct {
 	attLogsLock                        sync.Mutex
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
 	highestValidSlotLock               sync.Mutex
+	dutiesLock                         sync.RWMutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
+	syncCommitteeSelectionsLock        sync.RWMutex
+	syncMessageBlockRootLock           sync.Mutex
 	eipImportBlacklistedPublicKeys     map[[fieldparams.BLSPubkeyLength]byte]bool
 	walletInitializedFeed              *event.Feed
 	attLogs                            map[[32]byte]*attSubmitted
 	startBalances                      map[[fieldparams.BLSPubkeyLength]byte]uint64
 	duties                             *ethpb.DutiesResponse
+	dutiesEpoch                        types.Epoch
+	dutiesCompressionUnsupported       bool
 	prevBalance                        map[[fieldparams.BLSPubkeyLength]byte]uint64
 	pubkeyToValidatorIndex             map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex
 	signedValidatorRegistrations       map[[fieldparams.BLSPubkeyLength]byte]*ethpb.SignedValidatorRegistrationV1
@@ -91,14 +99,20 @@ type validator struct {
 	graffitiStruct                     *graffiti.Graffiti
 	node                               ethpb.NodeClient
 	slashingProtectionClient           ethpb.SlasherClient
+	proposalCoordinator                *proposalCoordinator
+	attestationRebroadcaster           *attestationRebroadcaster
+	finalityGuard                      *finalityGuard
 	db                                 vdb.Database
 	beaconClient                       ethpb.BeaconChainClient
+	beaconClientV1                     ethpbservice.BeaconChainClient
 	keyManager                         keymanager.IKeymanager
 	ticker                             slots.Ticker
 	validatorClient                    ethpb.BeaconNodeValidatorClient
 	graffiti                           []byte
 	voteStats                          voteStats
 	syncCommitteeStats                 syncCommitteeStats
+	syncCommitteeSelections            map[types.Slot]map[[fieldparams.BLSPubkeyLength]byte]*syncCommitteeSelection
+	syncMessageBlockRootReq            *syncMessageBlockRootRequest
 	Web3SignerConfig                   *remoteweb3signer.SetupConfig
 	ProposerSettings                   *validatorserviceconfig.ProposerSettings
 	walletInitializedChannel           chan *wallet.Wallet
@@ -599,16 +613,27 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 		Epoch:      types.Epoch(slot / params.BeaconConfig().SlotsPerEpoch),
 		PublicKeys: bytesutil.FromBytes48Array(filteredKeys),
 	}
+	req.DependentRoot = v.cachedDependentRoot(req.Epoch, filteredKeys)
 
 	// If duties is nil it means we have had no prior duties and just started up.
-	resp, err := v.validatorClient.GetDuties(ctx, req)
+	resp, err := v.getDuties(ctx, req)
 	if err != nil {
+		v.dutiesLock.Lock()
 		v.duties = nil // Clear assignments so we know to retry the request.
+		v.dutiesLock.Unlock()
 		log.Error(err)
 		return err
 	}
+	if len(req.DependentRoot) != 0 && bytes.Equal(resp.CurrentEpochDependentRoot, req.DependentRoot) {
+		// The beacon node left out the committee assignments we already know of.
+		fillCommitteeAssignments(resp.CurrentEpochDuties, v.duties.NextEpochDuties)
+		fillCommitteeAssignments(resp.Duties, v.duties.NextEpochDuties)
+	}
 
+	v.dutiesLock.Lock()
 	v.duties = resp
+	v.dutiesEpoch = req.Epoch
+	v.dutiesLock.Unlock()
 	v.logDuties(slot, v.duties.CurrentEpochDuties)
 
 	// Non-blocking call for beacon node to start subscriptions for aggregators.
@@ -621,6 +646,58 @@ func (v *validator) UpdateDuties(ctx context.Context, slot types.Slot) error {
 	return nil
 }
 
+// getDuties requests the duties compressed, falling back to uncompressed requests for beacon nodes
+// which do not support compression.
+func (v *validator) getDuties(ctx context.Context, req *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	if v.dutiesCompressionUnsupported {
+		return v.validatorClient.GetDuties(ctx, req)
+	}
+	resp, err := v.validatorClient.GetDuties(ctx, req, grpc.UseCompressor(gzip.Name))
+	if status.Code(err) == codes.Unimplemented {
+		log.WithError(err).Debug("Beacon node does not support compressed duties, requesting them uncompressed")
+		v.dutiesCompressionUnsupported = true
+		return v.validatorClient.GetDuties(ctx, req)
+	}
+	return resp, err
+}
+
+// cachedDependentRoot returns the dependent root of the cached next epoch duties if they hold the
+// duties of every key for the epoch, so that the beacon node may leave their committee assignments
+// out of the response.
+func (v *validator) cachedDependentRoot(epoch types.Epoch, pubKeys [][fieldparams.BLSPubkeyLength]byte) []byte {
+	if v.duties == nil || v.dutiesEpoch+1 != epoch || len(v.duties.NextEpochDependentRoot) == 0 {
+		return nil
+	}
+	cached := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(v.duties.NextEpochDuties))
+	for _, duty := range v.duties.NextEpochDuties {
+		cached[bytesutil.ToBytes48(duty.PublicKey)] = true
+	}
+	for _, pubKey := range pubKeys {
+		if !cached[pubKey] {
+			return nil
+		}
+	}
+	return v.duties.NextEpochDependentRoot
+}
+
+// fillCommitteeAssignments copies the committee assignments of the known duties into the duties
+// of the same public keys.
+func fillCommitteeAssignments(duties, known []*ethpb.DutiesResponse_Duty) {
+	byKey := make(map[[fieldparams.BLSPubkeyLength]byte]*ethpb.DutiesResponse_Duty, len(known))
+	for _, duty := range known {
+		byKey[bytesutil.ToBytes48(duty.PublicKey)] = duty
+	}
+	for _, duty := range duties {
+		k, ok := byKey[bytesutil.ToBytes48(duty.PublicKey)]
+		if !ok {
+			continue
+		}
+		duty.Committee = k.Committee
+		duty.AttesterSlot = k.AttesterSlot
+		duty.CommitteeIndex = k.CommitteeIndex
+	}
+}
+
 // subscribeToSubnets iterates through each validator duty, signs each slot, and asks beacon node
 // to eagerly subscribe to subnets so that the aggregator has attestations to aggregate.
 func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesResponse) error {
@@ -691,6 +768,10 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 // validator is known to not have a roles at the slot. Returns UNKNOWN if the
 // validator assignments are unknown. Otherwise returns a valid ValidatorRole map.
 func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole, error) {
+	// Sync committee selections are usually prepared during the previous slot, this only computes
+	// them, in parallel, after a restart or a failure.
+	v.PrepareSyncCommitteeSelections(ctx, slot)
+
 	rolesAt := make(map[[fieldparams.BLSPubkeyLength]byte][]iface.ValidatorRole)
 	for validator, duty := range v.duties.Duties {
 		var roles []iface.ValidatorRole
@@ -789,22 +870,13 @@ func (v *validator) isAggregator(ctx context.Context, committee []types.Validato
 //    modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
 //    return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
 func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
-	res, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
-		PublicKey: pubKey[:],
-		Slot:      slot,
-	})
+	selection, err := v.syncCommitteeSelection(ctx, slot, pubKey)
 	if err != nil {
 		return false, err
 	}
 
-	for _, index := range res.Indices {
-		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
-		subnet := uint64(index) / subCommitteeSize
-		sig, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
-		if err != nil {
-			return false, err
-		}
-		isAggregator, err := altair.IsSyncCommitteeAggregator(sig)
+	for _, proof := range selection.selectionProofs {
+		isAggregator, err := altair.IsSyncCommitteeAggregator(proof)
 		if err != nil {
 			return false, err
 		}
@@ -827,6 +899,9 @@ func (v *validator) UpdateDomainDataCaches(ctx context.Context, slot types.Slot)
 		params.BeaconConfig().DomainBeaconProposer[:],
 		params.BeaconConfig().DomainSelectionProof[:],
 		params.BeaconConfig().DomainAggregateAndProof[:],
+		params.BeaconConfig().DomainSyncCommittee[:],
+		params.BeaconConfig().DomainSyncCommitteeSelectionProof[:],
+		params.BeaconConfig().DomainContributionAndProof[:],
 	} {
 		_, err := v.domainData(ctx, slots.ToEpoch(slot), d)
 		if err != nil {
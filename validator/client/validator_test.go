@@ -39,6 +39,8 @@ import (
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -546,6 +548,7 @@ func TestUpdateDuties_ReturnsError(t *testing.T) {
 	client.EXPECT().GetDuties(
 		gomock.Any(),
 		gomock.Any(),
+		gomock.Any(),
 	).Return(nil, expected)
 
 	assert.ErrorContains(t, expected.Error(), v.UpdateDuties(context.Background(), params.BeaconConfig().SlotsPerEpoch))
@@ -586,6 +589,7 @@ func TestUpdateDuties_OK(t *testing.T) {
 	client.EXPECT().GetDuties(
 		gomock.Any(),
 		gomock.Any(),
+		gomock.Any(),
 	).Return(resp, nil)
 
 	var wg sync.WaitGroup
@@ -609,6 +613,85 @@ func TestUpdateDuties_OK(t *testing.T) {
 	assert.Equal(t, resp.Duties[0].ValidatorIndex, v.duties.Duties[0].ValidatorIndex, "Unexpected validator assignments")
 }
 
+func TestUpdateDuties_FillsOmittedCommitteeAssignments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+
+	slot := params.BeaconConfig().SlotsPerEpoch
+	privKey, err := bls.RandKey()
+	require.NoError(t, err)
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], privKey.PublicKey().Marshal())
+	km := &mockKeymanager{
+		keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{
+			pubKey: privKey,
+		},
+	}
+	dependentRoot := bytesutil.PadTo([]byte("root"), 32)
+	v := validator{
+		keyManager:      km,
+		validatorClient: client,
+		duties: &ethpb.DutiesResponse{
+			NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+				{
+					AttesterSlot:   slot + 3,
+					CommitteeIndex: 5,
+					Committee:      []types.ValidatorIndex{7, 8, 9},
+					PublicKey:      pubKey[:],
+				},
+			},
+			NextEpochDependentRoot: dependentRoot,
+		},
+	}
+	resp := &ethpb.DutiesResponse{
+		Duties:                    []*ethpb.DutiesResponse_Duty{{PublicKey: pubKey[:], ValidatorIndex: 8}},
+		CurrentEpochDuties:        []*ethpb.DutiesResponse_Duty{{PublicKey: pubKey[:], ValidatorIndex: 8}},
+		CurrentEpochDependentRoot: dependentRoot,
+	}
+	client.EXPECT().GetDuties(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, req *ethpb.DutiesRequest, _ ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+		assert.DeepEqual(t, dependentRoot, req.DependentRoot)
+		return resp, nil
+	})
+	client.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any()).AnyTimes()
+
+	require.NoError(t, v.UpdateDuties(context.Background(), slot))
+	for _, duty := range []*ethpb.DutiesResponse_Duty{v.duties.Duties[0], v.duties.CurrentEpochDuties[0]} {
+		assert.Equal(t, slot+3, duty.AttesterSlot)
+		assert.Equal(t, types.CommitteeIndex(5), duty.CommitteeIndex)
+		assert.DeepEqual(t, []types.ValidatorIndex{7, 8, 9}, duty.Committee)
+	}
+	assert.Equal(t, types.Epoch(1), v.dutiesEpoch)
+}
+
+func TestUpdateDuties_RetriesUncompressed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+
+	km := &mockKeymanager{keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{}}
+	v := validator{
+		keyManager:      km,
+		validatorClient: client,
+	}
+	resp := &ethpb.DutiesResponse{}
+	gomock.InOrder(
+		client.EXPECT().GetDuties(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			nil, status.Error(codes.Unimplemented, "grpc: Decompressor is not installed for grpc-encoding \"gzip\""),
+		),
+		client.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2),
+	)
+	client.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), gomock.Any()).AnyTimes()
+
+	require.NoError(t, v.UpdateDuties(context.Background(), 0))
+	assert.Equal(t, true, v.dutiesCompressionUnsupported)
+	require.NoError(t, v.UpdateDuties(context.Background(), params.BeaconConfig().SlotsPerEpoch))
+}
+
 func TestUpdateDuties_OK_FilterBlacklistedPublicKeys(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctrl := gomock.NewController(t)
@@ -642,6 +725,7 @@ func TestUpdateDuties_OK_FilterBlacklistedPublicKeys(t *testing.T) {
 	client.EXPECT().GetDuties(
 		gomock.Any(),
 		gomock.Any(),
+		gomock.Any(),
 	).Return(resp, nil)
 
 	var wg sync.WaitGroup
@@ -1343,6 +1427,8 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 	c := params.BeaconConfig().Copy()
 	c.TargetAggregatorsPerSyncSubcommittee = math.MaxUint64
 	params.OverrideBeaconConfig(c)
+	// Drop the selection cached by the previous check.
+	v.syncCommitteeSelections = nil
 
 	m.validatorClient.EXPECT().DomainData(
 		gomock.Any(), // ctx
@@ -56,6 +56,9 @@ var blockedBuckets = [][]byte{
 // Config represents store's config object.
 type Config struct {
 	PubKeys [][fieldparams.BLSPubkeyLength]byte
+	// Registerer is the registry the metrics of the database are registered with. The global
+	// prometheus registry is used when it is nil.
+	Registerer prometheus.Registerer
 }
 
 // Store defines an implementation of the Prysm Database interface
@@ -67,11 +70,12 @@ type Store struct {
 	batchedAttestationsChan            chan *AttestationRecord
 	batchAttestationsFlushedFeed       *event.Feed
 	batchedAttestationsFlushInProgress abool.AtomicBool
+	registerer                         prometheus.Registerer
 }
 
 // Close closes the underlying boltdb database.
 func (s *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(s.db))
+	s.registerer.Unregister(createBoltCollector(s.db))
 	return s.db.Close()
 }
 
@@ -87,7 +91,7 @@ func (s *Store) ClearDB() error {
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(s.db))
+	s.registerer.Unregister(createBoltCollector(s.db))
 	return os.Remove(filepath.Join(s.databasePath, ProtectionDbFileName))
 }
 
@@ -136,6 +140,10 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		batchedAttestations:          NewQueuedAttestationRecords(),
 		batchedAttestationsChan:      make(chan *AttestationRecord, attestationBatchCapacity),
 		batchAttestationsFlushedFeed: new(event.Feed),
+		registerer:                   prometheus.DefaultRegisterer,
+	}
+	if config != nil && config.Registerer != nil {
+		kv.registerer = config.Registerer
 	}
 
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
@@ -175,7 +183,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	// intervals to our database.
 	go kv.batchAttestationWrites(ctx)
 
-	return kv, prometheus.Register(createBoltCollector(kv.db))
+	return kv, kv.registerer.Register(createBoltCollector(kv.db))
 }
 
 // UpdatePublicKeysBuckets for a specified list of keys.
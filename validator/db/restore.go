@@ -21,7 +21,7 @@ func Restore(cliCtx *cli.Context) error {
 	sourceFile := cliCtx.String(cmd.RestoreSourceFileFlag.Name)
 	targetDir := cliCtx.String(cmd.RestoreTargetDirFlag.Name)
 
-	if file.FileExists(path.Join(targetDir, kv.ProtectionDbFileName)) {
+	if file.FileExists(path.Join(targetDir, kv.ProtectionDbFileName)) && !cliCtx.Bool(cmd.RestoreOverwriteFlag.Name) {
 		resp, err := prompt.ValidatePrompt(
 			os.Stdin, dbExistsYesNoPrompt, prompt.ValidateYesOrNo,
 		)
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"os"
 	"path"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
+	"github.com/prysmaticlabs/prysm/v3/io/prompt"
 	"github.com/prysmaticlabs/prysm/v3/testing/assert"
 	"github.com/prysmaticlabs/prysm/v3/testing/require"
 	"github.com/prysmaticlabs/prysm/v3/validator/db/kv"
@@ -60,3 +62,34 @@ func TestRestore(t *testing.T) {
 	require.DeepEqual(t, root[:], genesisRoot, "Restored database has incorrect data")
 	assert.LogsContain(t, logHook, "Restore completed successfully")
 }
+
+func TestRestore_ExistingDatabase(t *testing.T) {
+	prompt.SetNonInteractive(true)
+	defer prompt.SetNonInteractive(false)
+
+	sourceFile := path.Join(t.TempDir(), "backup.db")
+	require.NoError(t, os.WriteFile(sourceFile, []byte("backup"), params.BeaconIoConfig().ReadWritePermissions))
+	restoreDir := t.TempDir()
+	require.NoError(t, os.Chmod(restoreDir, params.BeaconIoConfig().ReadWriteExecutePermissions))
+	targetFile := path.Join(restoreDir, kv.ProtectionDbFileName)
+	require.NoError(t, os.WriteFile(targetFile, []byte("existing"), params.BeaconIoConfig().ReadWritePermissions))
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.RestoreSourceFileFlag.Name, sourceFile, "")
+	set.String(cmd.RestoreTargetDirFlag.Name, restoreDir, "")
+	overwrite := set.Bool(cmd.RestoreOverwriteFlag.Name, false, "")
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	err := Restore(cliCtx)
+	assert.Equal(t, true, errors.Is(err, prompt.ErrNonInteractive))
+	data, err := os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, "existing", string(data))
+
+	*overwrite = true
+	require.NoError(t, Restore(cliCtx))
+	data, err = os.ReadFile(targetFile)
+	require.NoError(t, err)
+	assert.Equal(t, "backup", string(data))
+}
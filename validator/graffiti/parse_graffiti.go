@@ -22,9 +22,11 @@ type Graffiti struct {
 	Ordered  []string                        `yaml:"ordered,omitempty"`
 	Random   []string                        `yaml:"random,omitempty"`
 	Specific map[types.ValidatorIndex]string `yaml:"specific,omitempty"`
+	Tags     map[string]string               `yaml:"tags,omitempty"`
 }
 
-// ParseGraffitiFile parses the graffiti file and returns the graffiti struct.
+// ParseGraffitiFile parses the graffiti file and returns the graffiti struct. Every graffiti is a
+// template which may reference the tags defined in the file.
 func ParseGraffitiFile(f string) (*Graffiti, error) {
 	yamlFile, err := os.ReadFile(f) // #nosec G304
 	if err != nil {
@@ -54,10 +56,28 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 	g.Default = ParseHexGraffiti(g.Default)
 	g.Hash = hash.Hash(yamlFile)
 
+	if err := g.validateTemplates(); err != nil {
+		return nil, err
+	}
 	return g, nil
 }
 
-// ParseHexGraffiti checks if a graffiti input is being represented in hex and converts it to ASCII if so
+func (g *Graffiti) validateTemplates() error {
+	templates := append([]string{g.Default}, g.Ordered...)
+	templates = append(templates, g.Random...)
+	for _, t := range g.Specific {
+		templates = append(templates, t)
+	}
+	for _, t := range templates {
+		if err := ValidateTemplate(t, g.Tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseHexGraffiti checks if a graffiti input is being represented in hex and converts it to ASCII if so.
+// The braces of decoded graffiti are escaped, so that it is not rendered as a template.
 func ParseHexGraffiti(rawGraffiti string) string {
 	splitGraffiti := strings.SplitN(rawGraffiti, ":", 2)
 	if strings.ToLower(splitGraffiti[0]) == hexGraffitiPrefix {
@@ -78,7 +98,7 @@ func ParseHexGraffiti(rawGraffiti string) string {
 			log.WithError(err).Debug("Error while decoding hex string")
 			return rawGraffiti
 		}
-		return string(graffiti)
+		return strings.ReplaceAll(string(graffiti), "{", "{{")
 	}
 	return rawGraffiti
 }
@@ -158,6 +158,46 @@ specific:
 	require.DeepEqual(t, wanted, got)
 }
 
+func TestParseGraffitiFile_Tags(t *testing.T) {
+	input := []byte(`default: "{tag:operator} {version}"
+specific:
+  1234: "{tag:operator} #{index}"
+tags:
+  operator: "Mr T"`)
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	got, err := ParseGraffitiFile(someFileName)
+	require.NoError(t, err)
+
+	wanted := &Graffiti{
+		Hash:    hash.Hash(input),
+		Default: "{tag:operator} {version}",
+		Specific: map[types.ValidatorIndex]string{
+			1234: "{tag:operator} #{index}",
+		},
+		Tags: map[string]string{
+			"operator": "Mr T",
+		},
+	}
+	require.DeepEqual(t, wanted, got)
+}
+
+func TestParseGraffitiFile_UndefinedTag(t *testing.T) {
+	input := []byte(`ordered:
+  - "{tag:operator}"`)
+
+	someFileName := filepath.Join(t.TempDir(), "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	_, err := ParseGraffitiFile(someFileName)
+	require.ErrorContains(t, `undefined tag "operator"`, err)
+}
+
 func TestParseHexGraffiti(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -209,6 +249,11 @@ func TestParseHexGraffiti(t *testing.T) {
 			want:  "hex:0xhola mundo",
 			input: "hex:0xhola mundo",
 		},
+		{
+			name:  "hex data with braces",
+			want:  "{{slot}",
+			input: "hex:7b736c6f747d",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,190 @@
+package graffiti
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+)
+
+// MaxLength is the number of bytes of graffiti included in a block.
+const MaxLength = 32
+
+// Variables available in graffiti templates, written between braces, as in "{slot}". A custom tag
+// defined in the graffiti file is referenced with the tag prefix, as in "{tag:operator}". A literal
+// brace is written "{{".
+const (
+	VersionVariable = "version"
+	SlotVariable    = "slot"
+	EpochVariable   = "epoch"
+	IndexVariable   = "index"
+	tagPrefix       = "tag:"
+)
+
+var errUnclosedVariable = errors.New("unclosed variable")
+
+// TemplateData holds the values of the template variables for a block proposal.
+type TemplateData struct {
+	Slot  types.Slot
+	Index types.ValidatorIndex
+	Tags  map[string]string
+}
+
+// segment is either a literal text or a variable of a template.
+type segment struct {
+	text     string
+	variable string
+}
+
+func parseTemplate(tmpl string) ([]segment, error) {
+	var segments []segment
+	var text strings.Builder
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != '{' {
+			text.WriteByte(tmpl[i])
+			continue
+		}
+		if i+1 < len(tmpl) && tmpl[i+1] == '{' {
+			text.WriteByte('{')
+			i++
+			continue
+		}
+		end := strings.IndexByte(tmpl[i:], '}')
+		if end < 0 {
+			return nil, errors.Wrapf(errUnclosedVariable, "in graffiti %q", tmpl)
+		}
+		if text.Len() > 0 {
+			segments = append(segments, segment{text: text.String()})
+			text.Reset()
+		}
+		segments = append(segments, segment{variable: tmpl[i+1 : i+end]})
+		i += end
+	}
+	if text.Len() > 0 {
+		segments = append(segments, segment{text: text.String()})
+	}
+	return segments, nil
+}
+
+// ValidateTemplate checks that the graffiti template is well formed and only references known
+// variables and the given tags.
+func ValidateTemplate(tmpl string, tags map[string]string) error {
+	segments, err := parseTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	for _, s := range segments {
+		if s.text != "" {
+			continue
+		}
+		if _, err := variableValue(s.variable, &TemplateData{Tags: tags}); err != nil {
+			return errors.Wrapf(err, "in graffiti %q", tmpl)
+		}
+	}
+	return nil
+}
+
+// IsTemplate returns true if the graffiti references one of the variables or a tag. Other
+// graffiti, even containing braces, are static and used as is.
+func IsTemplate(tmpl string) bool {
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != '{' {
+			continue
+		}
+		if i+1 < len(tmpl) && tmpl[i+1] == '{' {
+			i++
+			continue
+		}
+		end := strings.IndexByte(tmpl[i:], '}')
+		if end < 0 {
+			return false
+		}
+		switch variable := tmpl[i+1 : i+end]; variable {
+		case VersionVariable, SlotVariable, EpochVariable, IndexVariable:
+			return true
+		default:
+			if strings.HasPrefix(variable, tagPrefix) {
+				return true
+			}
+		}
+		i += end
+	}
+	return false
+}
+
+// UsesVariable returns true if the graffiti template references the variable.
+func UsesVariable(tmpl, variable string) bool {
+	segments, err := parseTemplate(tmpl)
+	if err != nil {
+		return false
+	}
+	for _, s := range segments {
+		if s.text == "" && s.variable == variable {
+			return true
+		}
+	}
+	return false
+}
+
+// Render fills the variables of the graffiti template. The result is truncated to MaxLength bytes,
+// without splitting a UTF-8 character.
+func Render(tmpl string, data *TemplateData) ([]byte, error) {
+	segments, err := parseTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	for _, s := range segments {
+		if s.text != "" {
+			b.WriteString(s.text)
+			continue
+		}
+		v, err := variableValue(s.variable, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "in graffiti %q", tmpl)
+		}
+		b.WriteString(v)
+	}
+	rendered := b.String()
+	if len(rendered) > MaxLength {
+		log.WithField("graffiti", rendered).Debugf("Truncating graffiti to %d bytes", MaxLength)
+		rendered = truncate(rendered, MaxLength)
+	}
+	return []byte(rendered), nil
+}
+
+func variableValue(variable string, data *TemplateData) (string, error) {
+	switch variable {
+	case VersionVariable:
+		return version.SemanticVersion(), nil
+	case SlotVariable:
+		return strconv.FormatUint(uint64(data.Slot), 10), nil
+	case EpochVariable:
+		return strconv.FormatUint(uint64(data.Slot/params.BeaconConfig().SlotsPerEpoch), 10), nil
+	case IndexVariable:
+		return strconv.FormatUint(uint64(data.Index), 10), nil
+	}
+	if strings.HasPrefix(variable, tagPrefix) {
+		tag, ok := data.Tags[strings.TrimPrefix(variable, tagPrefix)]
+		if !ok {
+			return "", errors.Errorf("undefined tag %q", strings.TrimPrefix(variable, tagPrefix))
+		}
+		return tag, nil
+	}
+	return "", errors.Errorf("unknown variable %q", variable)
+}
+
+// truncate cuts s to at most n bytes, moving the cut back to the start of a UTF-8 character
+// split by it.
+func truncate(s string, n int) string {
+	for i := n; i > 0 && n-i < utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			return s[:i]
+		}
+	}
+	return s[:n]
+}
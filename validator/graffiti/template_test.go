@@ -0,0 +1,67 @@
+package graffiti
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/runtime/version"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func TestRender(t *testing.T) {
+	data := &TemplateData{
+		Slot:  65,
+		Index: 1234,
+		Tags:  map[string]string{"operator": "Mr T"},
+	}
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{name: "static", tmpl: "Mr T was here", want: "Mr T was here"},
+		{name: "empty", tmpl: "", want: ""},
+		{name: "slot and epoch", tmpl: "slot {slot} epoch {epoch}", want: "slot 65 epoch 2"},
+		{name: "index", tmpl: "#{index}", want: "#1234"},
+		{name: "tag", tmpl: "{tag:operator}/{index}", want: "Mr T/1234"},
+		{name: "version", tmpl: "prysm {version}", want: "prysm " + version.SemanticVersion()},
+		{name: "escaped brace", tmpl: "{{slot} }", want: "{slot} }"},
+		{name: "truncated", tmpl: strings.Repeat("a", 30) + "{slot}", want: strings.Repeat("a", 30) + "65"},
+		{name: "truncated at character boundary", tmpl: strings.Repeat("a", 31) + "é", want: strings.Repeat("a", 31)},
+		{name: "truncated long", tmpl: strings.Repeat("ab", 20), want: strings.Repeat("ab", 16)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.tmpl, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, true, len(got) <= MaxLength)
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tags := map[string]string{"operator": "Mr T"}
+	require.NoError(t, ValidateTemplate("{tag:operator} {slot} {epoch} {index} {version} {{", tags))
+	require.ErrorContains(t, "unclosed variable", ValidateTemplate("slot {slot", tags))
+	require.ErrorContains(t, `unknown variable "validator"`, ValidateTemplate("{validator}", tags))
+	require.ErrorContains(t, `undefined tag "team"`, ValidateTemplate("{tag:team}", tags))
+	require.ErrorContains(t, `undefined tag "operator"`, ValidateTemplate("{tag:operator}", nil))
+}
+
+func TestIsTemplate(t *testing.T) {
+	assert.Equal(t, true, IsTemplate("slot {slot}"))
+	assert.Equal(t, true, IsTemplate("{tag:team}"))
+	assert.Equal(t, true, IsTemplate("{json} {epoch} {"))
+	assert.Equal(t, false, IsTemplate("Mr T was here"))
+	assert.Equal(t, false, IsTemplate("{json}"))
+	assert.Equal(t, false, IsTemplate("{{slot} {"))
+}
+
+func TestUsesVariable(t *testing.T) {
+	assert.Equal(t, true, UsesVariable("#{index}", IndexVariable))
+	assert.Equal(t, false, UsesVariable("#{{index}", IndexVariable))
+	assert.Equal(t, false, UsesVariable("{slot}", IndexVariable))
+	assert.Equal(t, false, UsesVariable("{index", IndexVariable))
+}
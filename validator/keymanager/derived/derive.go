@@ -0,0 +1,79 @@
+package derived
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v3/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
+	util "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// AccountKeys holds the keys of an account index derived from a mnemonic.
+type AccountKeys struct {
+	Index         uint64
+	ValidatingKey bls.SecretKey
+	WithdrawalKey bls.SecretKey
+}
+
+// DeriveAccountKeys derives the validating and withdrawal keys of the given account indices
+// from a mnemonic, according to EIP-2333 and EIP-2334.
+func DeriveAccountKeys(mnemonic, mnemonicPassphrase string, indices []uint64) ([]*AccountKeys, error) {
+	seed, err := seedFromMnemonic(mnemonic, mnemonicPassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not derive seed from mnemonic")
+	}
+	accounts := make([]*AccountKeys, len(indices))
+	for i, index := range indices {
+		validatingKey, err := secretKeyFromSeedAndPath(seed, fmt.Sprintf(ValidatingKeyDerivationPathTemplate, index))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not derive validating key of account %d", index)
+		}
+		withdrawalKey, err := secretKeyFromSeedAndPath(seed, fmt.Sprintf(WithdrawalKeyDerivationPathTemplate, index))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not derive withdrawal key of account %d", index)
+		}
+		accounts[i] = &AccountKeys{
+			Index:         index,
+			ValidatingKey: validatingKey,
+			WithdrawalKey: withdrawalKey,
+		}
+	}
+	return accounts, nil
+}
+
+// ValidatingKeyPath returns the EIP-2334 derivation path of the validating key.
+func (a *AccountKeys) ValidatingKeyPath() string {
+	return fmt.Sprintf(ValidatingKeyDerivationPathTemplate, a.Index)
+}
+
+// ValidatingKeystore encrypts the validating key with the password into an EIP-2335 keystore.
+func (a *AccountKeys) ValidatingKeystore(password string) (*keymanager.Keystore, error) {
+	encryptor := keystorev4.New()
+	cryptoFields, err := encryptor.Encrypt(a.ValidatingKey.Marshal(), password)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not encrypt validating key of account %d", a.Index)
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	return &keymanager.Keystore{
+		Crypto:  cryptoFields,
+		ID:      id.String(),
+		Pubkey:  fmt.Sprintf("%x", a.ValidatingKey.PublicKey().Marshal()),
+		Version: encryptor.Version(),
+		Name:    encryptor.Name(),
+		Path:    a.ValidatingKeyPath(),
+	}, nil
+}
+
+func secretKeyFromSeedAndPath(seed []byte, path string) (bls.SecretKey, error) {
+	privKey, err := util.PrivateKeyFromSeedAndPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return bls.SecretKeyFromBytes(privKey.Marshal())
+}
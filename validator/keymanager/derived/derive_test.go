@@ -0,0 +1,53 @@
+package derived
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	util "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func TestDeriveAccountKeys(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed, err := seedFromMnemonic(mnemonic, "TREZOR")
+	require.NoError(t, err)
+
+	accounts, err := DeriveAccountKeys(mnemonic, "TREZOR", []uint64{3, 0})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(accounts))
+	for i, index := range []uint64{3, 0} {
+		assert.Equal(t, index, accounts[i].Index)
+		validatingKey, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0/0", index))
+		require.NoError(t, err)
+		withdrawalKey, err := util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0", index))
+		require.NoError(t, err)
+		assert.DeepEqual(t, validatingKey.Marshal(), accounts[i].ValidatingKey.Marshal())
+		assert.DeepEqual(t, withdrawalKey.Marshal(), accounts[i].WithdrawalKey.Marshal())
+	}
+	assert.Equal(t, "m/12381/3600/3/0/0", accounts[0].ValidatingKeyPath())
+
+	// The passphrase is part of the seed.
+	other, err := DeriveAccountKeys(mnemonic, "", []uint64{3})
+	require.NoError(t, err)
+	assert.NotEqual(t, fmt.Sprintf("%x", accounts[0].ValidatingKey.Marshal()), fmt.Sprintf("%x", other[0].ValidatingKey.Marshal()))
+
+	_, err = DeriveAccountKeys("not a mnemonic", "", []uint64{0})
+	assert.ErrorContains(t, "could not derive seed from mnemonic", err)
+}
+
+func TestAccountKeys_ValidatingKeystore(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	accounts, err := DeriveAccountKeys(mnemonic, "", []uint64{1})
+	require.NoError(t, err)
+
+	keystore, err := accounts[0].ValidatingKeystore("password")
+	require.NoError(t, err)
+	assert.Equal(t, "m/12381/3600/1/0/0", keystore.Path)
+	assert.Equal(t, fmt.Sprintf("%x", accounts[0].ValidatingKey.PublicKey().Marshal()), keystore.Pubkey)
+	decrypted, err := keystorev4.New().Decrypt(keystore.Crypto, "password")
+	require.NoError(t, err)
+	assert.DeepEqual(t, accounts[0].ValidatingKey.Marshal(), decrypted)
+}
@@ -24,6 +24,9 @@ const (
 	// keys for Prysm Ethereum validators. According to EIP-2334, the format is as follows:
 	// m / purpose / coin_type / account_index / withdrawal_key / validating_key
 	ValidatingKeyDerivationPathTemplate = "m/12381/3600/%d/0/0"
+	// WithdrawalKeyDerivationPathTemplate defining the hierarchical path for withdrawal
+	// keys, the parent of the validating key of the same account index according to EIP-2334.
+	WithdrawalKeyDerivationPathTemplate = "m/12381/3600/%d/0"
 )
 
 // SetupConfig includes configuration values for initializing
@@ -116,6 +119,13 @@ func (km *Keymanager) ImportKeystores(
 	return km.localKM.ImportKeystores(ctx, keystores, passwords)
 }
 
+// DryRunImportKeystores for a derived keymanager.
+func (km *Keymanager) DryRunImportKeystores(
+	ctx context.Context, keystores []*keymanager.Keystore, passwords []string,
+) ([]*ethpbservice.ImportedKeystoreStatus, error) {
+	return km.localKM.DryRunImportKeystores(ctx, keystores, passwords)
+}
+
 // DeleteKeystores for a derived keymanager.
 func (km *Keymanager) DeleteKeystores(
 	ctx context.Context, publicKeys [][]byte,
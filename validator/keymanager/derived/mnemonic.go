@@ -66,6 +66,9 @@ func (m *EnglishMnemonicGenerator) ConfirmAcknowledgement(phrase string) error {
 	}
 	// Confirm the user has written down the mnemonic phrase offline.
 	_, err := prompt.ValidatePrompt(os.Stdin, confirmationText, prompt.ValidateConfirmation)
+	if errors.Is(err, prompt.ErrNonInteractive) {
+		return err
+	}
 	if err != nil {
 		log.Errorf("Could not confirm acknowledgement of userprompt, please enter y")
 	}
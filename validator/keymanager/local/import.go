@@ -5,7 +5,9 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/k0kubun/go-ansi"
 	"github.com/pkg/errors"
@@ -16,11 +18,47 @@ import (
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 )
 
-// ImportKeystores into the local keymanager from an external source.
+const (
+	// An EIP-2335 keystore encrypted with the default scrypt parameters takes 256 MiB of memory to
+	// decrypt, which bounds the number of keystores decrypted in parallel.
+	maxDecryptionWorkers = 8
+	// The accounts keystore is written to disk after every batch of imported keys, so that an
+	// interrupted import keeps the keys imported so far and can be resumed.
+	importBatchSize = 100
+)
+
+type decryptedKeystore struct {
+	index   int
+	privKey []byte
+	pubKey  []byte
+	err     error
+}
+
+// ImportKeystores into the local keymanager from an external source. Keystores are decrypted in
+// parallel, and the keystores of keys already in the wallet are skipped as duplicates.
 func (km *Keymanager) ImportKeystores(
 	ctx context.Context,
 	keystores []*keymanager.Keystore,
 	passwords []string,
+) ([]*ethpbservice.ImportedKeystoreStatus, error) {
+	return km.importKeystores(ctx, keystores, passwords, false /* dry run */)
+}
+
+// DryRunImportKeystores decrypts the keystores and returns the status each of them would have once
+// imported, without modifying the wallet.
+func (km *Keymanager) DryRunImportKeystores(
+	ctx context.Context,
+	keystores []*keymanager.Keystore,
+	passwords []string,
+) ([]*ethpbservice.ImportedKeystoreStatus, error) {
+	return km.importKeystores(ctx, keystores, passwords, true /* dry run */)
+}
+
+func (km *Keymanager) importKeystores(
+	ctx context.Context,
+	keystores []*keymanager.Keystore,
+	passwords []string,
+	dryRun bool,
 ) ([]*ethpbservice.ImportedKeystoreStatus, error) {
 	if len(passwords) == 0 {
 		return nil, ErrNoPasswords
@@ -28,61 +66,146 @@ func (km *Keymanager) ImportKeystores(
 	if len(passwords) != len(keystores) {
 		return nil, ErrMismatchedNumPasswords
 	}
-	decryptor := keystorev4.New()
-	bar := initializeProgressBar(len(keystores), "Importing accounts...")
-	keys := map[string]string{}
 	statuses := make([]*ethpbservice.ImportedKeystoreStatus, len(keystores))
-	var err error
-
-	for i := 0; i < len(keystores); i++ {
-		var privKeyBytes []byte
-		var pubKeyBytes []byte
-		privKeyBytes, pubKeyBytes, _, err = km.attemptDecryptKeystore(decryptor, keystores[i], passwords[i])
-		if err != nil {
-			statuses[i] = &ethpbservice.ImportedKeystoreStatus{
-				Status:  ethpbservice.ImportedKeystoreStatus_ERROR,
-				Message: err.Error(),
-			}
-			continue
-		}
-		if err := bar.Add(1); err != nil {
-			log.Error(err)
-		}
-		// if key exists prior to being added then output log that duplicate key was found
-		if _, ok := keys[string(pubKeyBytes)]; ok {
-			log.Warnf("Duplicate key in import will be ignored: %#x", pubKeyBytes)
+	existing := km.existingPublicKeys()
+	toDecrypt := make([]int, 0, len(keystores))
+	for i, keystore := range keystores {
+		pubKey, err := hex.DecodeString(keystore.Pubkey)
+		if err == nil && existing[string(pubKey)] {
 			statuses[i] = &ethpbservice.ImportedKeystoreStatus{
 				Status: ethpbservice.ImportedKeystoreStatus_DUPLICATE,
 			}
 			continue
 		}
-		keys[string(pubKeyBytes)] = string(privKeyBytes)
-		statuses[i] = &ethpbservice.ImportedKeystoreStatus{
-			Status: ethpbservice.ImportedKeystoreStatus_IMPORTED,
-		}
+		toDecrypt = append(toDecrypt, i)
+	}
+	if skipped := len(keystores) - len(toDecrypt); skipped > 0 {
+		log.Infof("Skipping %d keystores already imported in the wallet", skipped)
 	}
+
+	bar := initializeProgressBar(len(toDecrypt), "Importing accounts...")
+	keys := map[string]bool{}
 	privKeys := make([][]byte, 0)
 	pubKeys := make([][]byte, 0)
-	for pubKey, privKey := range keys {
-		pubKeys = append(pubKeys, []byte(pubKey))
-		privKeys = append(privKeys, []byte(privKey))
+	// Keystores are decrypted out of order, but processed in order so that the first of duplicated
+	// keystores is the one imported.
+	decrypted := make(map[int]*decryptedKeystore)
+	next := 0
+	for d := range decryptKeystores(ctx, km, keystores, passwords, toDecrypt) {
+		decrypted[d.index] = d
+		for ; next < len(toDecrypt) && decrypted[toDecrypt[next]] != nil; next++ {
+			d := decrypted[toDecrypt[next]]
+			delete(decrypted, d.index)
+			if err := bar.Add(1); err != nil {
+				log.Error(err)
+			}
+			if d.err != nil {
+				statuses[d.index] = &ethpbservice.ImportedKeystoreStatus{
+					Status:  ethpbservice.ImportedKeystoreStatus_ERROR,
+					Message: d.err.Error(),
+				}
+				continue
+			}
+			// if key exists prior to being added then output log that duplicate key was found
+			if keys[string(d.pubKey)] {
+				log.Warnf("Duplicate key in import will be ignored: %#x", d.pubKey)
+				statuses[d.index] = &ethpbservice.ImportedKeystoreStatus{
+					Status: ethpbservice.ImportedKeystoreStatus_DUPLICATE,
+				}
+				continue
+			}
+			keys[string(d.pubKey)] = true
+			statuses[d.index] = &ethpbservice.ImportedKeystoreStatus{
+				Status: ethpbservice.ImportedKeystoreStatus_IMPORTED,
+			}
+			privKeys = append(privKeys, d.privKey)
+			pubKeys = append(pubKeys, d.pubKey)
+			if !dryRun && len(pubKeys) == importBatchSize {
+				if err := km.ImportKeypairs(ctx, privKeys, pubKeys); err != nil {
+					return nil, err
+				}
+				// The keymanager may keep the written slices as its accounts store.
+				privKeys = make([][]byte, 0)
+				pubKeys = make([][]byte, 0)
+			}
+		}
 	}
-
-	// Write the accounts to disk into a single keystore.
-	accountsKeystore, err := km.CreateAccountsKeystore(ctx, privKeys, pubKeys)
-	if err != nil {
-		return nil, err
+	if dryRun {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return statuses, nil
 	}
-	encodedAccounts, err := json.MarshalIndent(accountsKeystore, "", "\t")
-	if err != nil {
+	// Write the remaining accounts to disk, including when the import was interrupted.
+	if err := km.ImportKeypairs(ctx, privKeys, pubKeys); err != nil {
 		return nil, err
 	}
-	if err := km.wallet.WriteFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName, encodedAccounts); err != nil {
-		return nil, err
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 	return statuses, nil
 }
 
+// decryptKeystores decrypts the keystores at the given indices with a pool of workers, and sends
+// the results on the returned channel, which is closed once all are decrypted or the context is
+// canceled.
+func decryptKeystores(
+	ctx context.Context,
+	km *Keymanager,
+	keystores []*keymanager.Keystore,
+	passwords []string,
+	indices []int,
+) <-chan *decryptedKeystore {
+	workers := runtime.NumCPU()
+	if workers > maxDecryptionWorkers {
+		workers = maxDecryptionWorkers
+	}
+	if workers > len(indices) {
+		workers = len(indices)
+	}
+	jobs := make(chan int)
+	results := make(chan *decryptedKeystore, workers)
+	go func() {
+		defer close(jobs)
+		for _, i := range indices {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			decryptor := keystorev4.New()
+			for i := range jobs {
+				privKey, pubKey, _, err := km.attemptDecryptKeystore(decryptor, keystores[i], passwords[i])
+				results <- &decryptedKeystore{index: i, privKey: privKey, pubKey: pubKey, err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// existingPublicKeys returns the set of public keys in the wallet.
+func (km *Keymanager) existingPublicKeys() map[string]bool {
+	existing := make(map[string]bool)
+	if km.accountsStore == nil {
+		return existing
+	}
+	for _, pubKey := range km.accountsStore.PublicKeys {
+		existing[string(pubKey)] = true
+	}
+	return existing
+}
+
 // ImportKeypairs directly into the keymanager.
 func (km *Keymanager) ImportKeypairs(ctx context.Context, privKeys, pubKeys [][]byte) error {
 	// Write the accounts to disk into a single keystore.
@@ -191,3 +191,67 @@ func TestLocalKeymanager_ImportKeystores(t *testing.T) {
 		)
 	})
 }
+
+func TestLocalKeymanager_ImportKeystores_SkipsExistingKeys(t *testing.T) {
+	ctx := context.Background()
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:        wallet,
+		accountsStore: &accountStore{},
+	}
+	keystores := []*keymanager.Keystore{
+		createRandomKeystore(t, password),
+		createRandomKeystore(t, password),
+	}
+	statuses, err := dr.ImportKeystores(ctx, keystores[:1], []string{password})
+	require.NoError(t, err)
+	require.Equal(t, ethpbservice.ImportedKeystoreStatus_IMPORTED, statuses[0].Status)
+
+	// Resuming the import does not decrypt the keystore already imported, even with a wrong password.
+	statuses, err = dr.ImportKeystores(ctx, keystores, []string{"foobar", password})
+	require.NoError(t, err)
+	require.Equal(t, ethpbservice.ImportedKeystoreStatus_DUPLICATE, statuses[0].Status)
+	require.Equal(t, ethpbservice.ImportedKeystoreStatus_IMPORTED, statuses[1].Status)
+	require.Equal(t, 2, len(dr.accountsStore.PublicKeys))
+}
+
+func TestLocalKeymanager_DryRunImportKeystores(t *testing.T) {
+	ctx := context.Background()
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:        wallet,
+		accountsStore: &accountStore{},
+	}
+	keystore := createRandomKeystore(t, password)
+	keystores := []*keymanager.Keystore{keystore, keystore, createRandomKeystore(t, password)}
+	statuses, err := dr.DryRunImportKeystores(ctx, keystores, []string{password, password, "foobar"})
+	require.NoError(t, err)
+	require.Equal(t, len(keystores), len(statuses))
+	assert.Equal(t, ethpbservice.ImportedKeystoreStatus_IMPORTED, statuses[0].Status)
+	assert.Equal(t, ethpbservice.ImportedKeystoreStatus_DUPLICATE, statuses[1].Status)
+	assert.Equal(t, ethpbservice.ImportedKeystoreStatus_ERROR, statuses[2].Status)
+
+	// Nothing is written to the wallet.
+	assert.Equal(t, 0, len(dr.accountsStore.PublicKeys))
+	assert.Equal(t, 0, len(wallet.Files))
+}
+
+func TestLocalKeymanager_ImportKeystores_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dr := &Keymanager{
+		wallet: &mock.Wallet{
+			Files:          make(map[string]map[string][]byte),
+			WalletPassword: password,
+		},
+		accountsStore: &accountStore{},
+	}
+	_, err := dr.ImportKeystores(ctx, []*keymanager.Keystore{createRandomKeystore(t, password)}, []string{password})
+	require.ErrorIs(t, err, context.Canceled)
+}
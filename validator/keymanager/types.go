@@ -45,6 +45,14 @@ type Importer interface {
 	) ([]*ethpbservice.ImportedKeystoreStatus, error)
 }
 
+// DryRunImporter can verify keystores as they would be imported into the keymanager, without
+// importing them.
+type DryRunImporter interface {
+	DryRunImportKeystores(
+		ctx context.Context, keystores []*Keystore, passwords []string,
+	) ([]*ethpbservice.ImportedKeystoreStatus, error)
+}
+
 // Deleter can delete keystores from the keymanager.
 type Deleter interface {
 	DeleteKeystores(ctx context.Context, publicKeys [][]byte) ([]*ethpbservice.DeletedKeystoreStatus, error)
@@ -24,6 +24,8 @@ var (
 	_ = keymanager.KeysFetcher(&derived.Keymanager{})
 	_ = keymanager.Importer(&local.Keymanager{})
 	_ = keymanager.Importer(&derived.Keymanager{})
+	_ = keymanager.DryRunImporter(&local.Keymanager{})
+	_ = keymanager.DryRunImporter(&derived.Keymanager{})
 	_ = keymanager.Deleter(&local.Keymanager{})
 	_ = keymanager.Deleter(&derived.Keymanager{})
 
@@ -16,21 +16,26 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
+	prom "github.com/prometheus/client_golang/prometheus"
 	fastssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v3/api/gateway"
 	"github.com/prysmaticlabs/prysm/v3/api/gateway/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v3/async/event"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v3/cmd"
 	"github.com/prysmaticlabs/prysm/v3/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v3/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v3/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/v3/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v3/container/slice"
 	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v3/io/file"
@@ -70,16 +75,19 @@ type ValidatorClient struct {
 	lock              sync.RWMutex
 	wallet            *wallet.Wallet
 	walletInitialized *event.Feed
+	router            *mux.Router
 	stop              chan struct{} // Channel to wait for termination notifications.
+	registerer        prom.Registerer
 }
 
 // NewValidatorClient creates a new instance of the Prysm validator client.
-func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
+func NewValidatorClient(cliCtx *cli.Context, opts ...Option) (*ValidatorClient, error) {
 	// TODO(#9883) - Maybe we can pass in a new validator client config instead of the cliCTX to abstract away the use of flags here .
 	if err := tracing2.Setup(
 		"validator", // service name
 		cliCtx.String(cmd.TracingProcessNameFlag.Name),
 		cliCtx.String(cmd.TracingEndpointFlag.Name),
+		cliCtx.String(cmd.TracingExporterFlag.Name),
 		cliCtx.Float64(cmd.TraceSampleFractionFlag.Name),
 		cliCtx.Bool(cmd.EnableTracingFlag.Name),
 	); err != nil {
@@ -104,7 +112,14 @@ func NewValidatorClient(cliCtx *cli.Context) (*ValidatorClient, error) {
 		cancel:            cancel,
 		services:          registry,
 		walletInitialized: new(event.Feed),
+		router:            mux.NewRouter(),
 		stop:              make(chan struct{}),
+		registerer:        prom.DefaultRegisterer,
+	}
+	for _, opt := range opts {
+		if err := opt(validatorClient); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := features.ConfigureValidator(cliCtx); err != nil {
@@ -241,7 +256,8 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	log.WithField("databasePath", dataDir).Info("Checking DB")
 
 	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys: nil,
+		PubKeys:    nil,
+		Registerer: c.registerer,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
@@ -317,7 +333,8 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 	}
 	log.WithField("databasePath", dataDir).Info("Checking DB")
 	valDB, err := kv.NewKVStore(cliCtx.Context, dataDir, &kv.Config{
-		PubKeys: nil,
+		PubKeys:    nil,
+		Registerer: c.registerer,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize db")
@@ -351,7 +368,7 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 }
 
 func (c *ValidatorClient) registerPrometheusService(cliCtx *cli.Context) error {
-	var additionalHandlers []prometheus.Handler
+	additionalHandlers := []prometheus.Handler{{Path: "/features", Handler: features.FlagStatusesHandler}}
 	if cliCtx.IsSet(cmd.EnableBackupWebhookFlag.Name) {
 		additionalHandlers = append(
 			additionalHandlers,
@@ -398,6 +415,16 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 			log.WithError(err).Warn("Could not parse graffiti file")
 		}
 	}
+	graffiti = g.ParseHexGraffiti(graffiti)
+	var graffitiTags map[string]string
+	if gStruct != nil {
+		graffitiTags = gStruct.Tags
+	}
+	if g.IsTemplate(graffiti) {
+		if err := g.ValidateTemplate(graffiti, graffitiTags); err != nil {
+			return errors.Wrap(err, "invalid graffiti flag")
+		}
+	}
 
 	wsc, err := web3SignerConfig(c.cliCtx)
 	if err != nil {
@@ -409,13 +436,28 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	pcc, err := proposalCoordinationConfig(c.cliCtx)
+	if err != nil {
+		return err
+	}
+	arc, err := attestationRebroadcastConfig(c.cliCtx)
+	if err != nil {
+		return err
+	}
+	fgc, err := finalityGuardConfig(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
 	v, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
+		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		BeaconApiTimeout:           time.Second * time.Duration(params.BeaconConfig().SecondsPerSlot),
 		DataDir:                    dataDir,
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
 		CertFlag:                   cert,
-		GraffitiFlag:               g.ParseHexGraffiti(graffiti),
+		GraffitiFlag:               graffiti,
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcRetryDelay:             grpcRetryDelay,
@@ -428,6 +470,9 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		GraffitiStruct:             gStruct,
 		Web3SignerConfig:           wsc,
 		ProposerSettings:           bpc,
+		ProposalCoordination:       pcc,
+		AttestationRebroadcast:     arc,
+		FinalityGuard:              fgc,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -436,6 +481,76 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	return c.services.RegisterService(v)
 }
 
+func proposalCoordinationConfig(cliCtx *cli.Context) (*client.ProposalCoordinationConfig, error) {
+	urls := cliCtx.StringSlice(flags.ProposerCoordinationURLsFlag.Name)
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	cfg := &client.ProposalCoordinationConfig{
+		URLs:   urls,
+		Strict: cliCtx.Bool(flags.ProposerCoordinationStrictFlag.Name),
+	}
+	if cliCtx.IsSet(flags.ProposerCoordinationTokenFileFlag.Name) {
+		b, err := os.ReadFile(filepath.Clean(cliCtx.String(flags.ProposerCoordinationTokenFileFlag.Name)))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read proposer coordination token file")
+		}
+		cfg.AuthToken = strings.TrimSpace(string(b))
+	}
+	log.WithField("peers", urls).Info("Checking proposals with redundant validator clients")
+	return cfg, nil
+}
+
+func attestationRebroadcastConfig(cliCtx *cli.Context) (*client.AttestationRebroadcastConfig, error) {
+	slots := cliCtx.Uint64(flags.AttestationRebroadcastSlotsFlag.Name)
+	if slots == 0 {
+		return nil, nil
+	}
+	provider := cliCtx.String(flags.AttestationRebroadcastProviderFlag.Name)
+	if provider == "" {
+		return nil, fmt.Errorf("--%s requires --%s", flags.AttestationRebroadcastSlotsFlag.Name, flags.AttestationRebroadcastProviderFlag.Name)
+	}
+	for _, endpoint := range client.ParseBeaconNodeEndpoints(cliCtx.String(flags.BeaconRPCProviderFlag.Name)) {
+		if endpoint == provider {
+			return nil, fmt.Errorf(
+				"--%s must not be one of --%s, the beacon node drops the attestations it already broadcast",
+				flags.AttestationRebroadcastProviderFlag.Name,
+				flags.BeaconRPCProviderFlag.Name,
+			)
+		}
+	}
+	endpoint := cliCtx.String(flags.BeaconRESTApiProviderFlag.Name)
+	log.WithFields(logrus.Fields{
+		"slots":    slots,
+		"endpoint": endpoint,
+		"provider": provider,
+	}).Info("Rebroadcasting attestations not included in a block")
+	return &client.AttestationRebroadcastConfig{
+		Slots:             types.Slot(slots),
+		BeaconAPIEndpoint: endpoint,
+		Endpoint:          provider,
+	}, nil
+}
+
+func finalityGuardConfig(cliCtx *cli.Context) (*client.FinalityGuardConfig, error) {
+	if !cliCtx.Bool(flags.FinalityGuardFlag.Name) && !cliCtx.IsSet(flags.FinalityGuardCheckpointFlag.Name) {
+		return nil, nil
+	}
+	checkpoint, err := helpers.ParseWeakSubjectivityInputString(cliCtx.String(flags.FinalityGuardCheckpointFlag.Name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse --%s", flags.FinalityGuardCheckpointFlag.Name)
+	}
+	if checkpoint != nil {
+		log.WithFields(logrus.Fields{
+			"epoch": checkpoint.Epoch,
+			"root":  fmt.Sprintf("%#x", checkpoint.Root),
+		}).Info("Refusing to sign blocks and attestations conflicting with the pinned finalized checkpoint")
+	} else {
+		log.Info("Refusing to sign blocks and attestations conflicting with the finalized checkpoint of the beacon node")
+	}
+	return &client.FinalityGuardConfig{Checkpoint: checkpoint}, nil
+}
+
 func web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error) {
 	var web3signerConfig *remoteweb3signer.SetupConfig
 	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
@@ -617,6 +732,10 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 	rpcPort := cliCtx.Int(flags.RPCPort.Name)
 	nodeGatewayEndpoint := cliCtx.String(flags.BeaconRPCGatewayProviderFlag.Name)
 	beaconClientEndpoint := cliCtx.String(flags.BeaconRPCProviderFlag.Name)
+	// The web API talks to a single beacon node, the first one when several are provided.
+	if endpoints := client.ParseBeaconNodeEndpoints(beaconClientEndpoint); len(endpoints) > 0 {
+		beaconClientEndpoint = endpoints[0]
+	}
 	maxCallRecvMsgSize := c.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	grpcRetries := c.cliCtx.Uint(flags.GrpcRetriesFlag.Name)
 	grpcRetryDelay := c.cliCtx.Duration(flags.GrpcRetryDelayFlag.Name)
@@ -645,6 +764,9 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 		ClientGrpcHeaders:        strings.Split(grpcHeaders, ","),
 		ClientWithCert:           clientCert,
 	})
+	server.RegisterSlashingProtectionCheckHandlers(c.router)
+	server.RegisterAdminHandlers(c.router)
+	server.RegisterExitHandlers(c.router)
 	return c.services.RegisterService(server)
 }
 
@@ -729,6 +851,7 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		gateway.WithApiMiddleware(&validatormiddleware.ValidatorEndpointFactory{}),
 		gateway.WithMuxHandler(muxHandler),
 		gateway.WithTimeout(uint64(timeout)),
+		gateway.WithRouter(c.router),
 	}
 	gw, err := gateway.New(cliCtx.Context, opts...)
 	if err != nil {
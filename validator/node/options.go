@@ -0,0 +1,15 @@
+package node
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Option for validator client configuration.
+type Option func(c *ValidatorClient) error
+
+// WithRegisterer sets the registry the metrics collectors of the validator client are registered with,
+// instead of the global prometheus registry. It allows running several validator clients in the same process.
+func WithRegisterer(r prometheus.Registerer) Option {
+	return func(c *ValidatorClient) error {
+		c.registerer = r
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
+)
+
+// RegisterAdminHandlers registers the endpoints profiling the process and tuning its runtime.
+// They require the authentication of the web API, so that production validator clients can be
+// profiled without restarting them nor exposing the pprof server.
+func (s *Server) RegisterAdminHandlers(router *mux.Router) {
+	router.HandleFunc("/v2/validator/admin/profiles/{kind}", s.StartProfile).Methods(http.MethodPost)
+	router.HandleFunc("/v2/validator/admin/profiles/{kind}", s.GetProfile).Methods(http.MethodGet)
+	router.HandleFunc("/v2/validator/admin/runtime", s.GetRuntimeSettings).Methods(http.MethodGet)
+	router.HandleFunc("/v2/validator/admin/runtime", s.TuneRuntimeSettings).Methods(http.MethodPost)
+}
+
+// StartProfile starts collecting a profile, see debug.AdminHandlers.
+func (s *Server) StartProfile(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAdminPreconditions(w, r) {
+		return
+	}
+	s.adminHandlers().StartProfile(w, r)
+}
+
+// GetProfile returns the latest collected profile of a kind, see debug.AdminHandlers.
+func (s *Server) GetProfile(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAdminPreconditions(w, r) {
+		return
+	}
+	s.adminHandlers().GetProfile(w, r)
+}
+
+// GetRuntimeSettings returns the runtime settings of the process, see debug.AdminHandlers.
+func (s *Server) GetRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAdminPreconditions(w, r) {
+		return
+	}
+	s.adminHandlers().GetRuntimeSettings(w, r)
+}
+
+// TuneRuntimeSettings tunes the runtime settings of the process, see debug.AdminHandlers.
+func (s *Server) TuneRuntimeSettings(w http.ResponseWriter, r *http.Request) {
+	if !s.checkAdminPreconditions(w, r) {
+		return
+	}
+	s.adminHandlers().TuneRuntimeSettings(w, r)
+}
+
+func (s *Server) adminHandlers() *debug.AdminHandlers {
+	return debug.NewAdminHandlers(s.ctx, s.profiler)
+}
+
+func (s *Server) checkAdminPreconditions(w http.ResponseWriter, r *http.Request) bool {
+	if err := s.authorizeRequest(r); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: err.Error(),
+			Code:    http.StatusUnauthorized,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prysmaticlabs/prysm/v3/runtime/debug"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+)
+
+func adminRequest(t *testing.T, s *Server, method, url string, body interface{}) *httptest.ResponseRecorder {
+	var enc []byte
+	if body != nil {
+		var err error
+		enc, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	request := httptest.NewRequest(method, "http://example.com"+url, bytes.NewReader(enc))
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+token)
+	writer := httptest.NewRecorder()
+	router := mux.NewRouter()
+	s.RegisterAdminHandlers(router)
+	router.ServeHTTP(writer, request)
+	return writer
+}
+
+func TestServer_Profile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Server{ctx: ctx, jwtSecret: []byte("testKey"), profiler: debug.NewProfiler()}
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/v2/validator/admin/profiles/heap", nil)
+	writer := httptest.NewRecorder()
+	s.StartProfile(writer, request)
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/profiles/heap?duration=forever", nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/profiles/heap?duration=1h", nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/profiles/goroutine", nil)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = adminRequest(t, s, http.MethodGet, "/v2/validator/admin/profiles/heap", nil)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/profiles/heap?duration=1m", nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &debug.ProfileStatusResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, &debug.ProfileStatus{Kind: "heap", InProgress: true, Duration: "1m0s"}, resp.Data)
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/profiles/heap", nil)
+	assert.Equal(t, http.StatusConflict, writer.Code)
+	writer = adminRequest(t, s, http.MethodGet, "/v2/validator/admin/profiles/heap", nil)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+	assert.Equal(t, true, strings.Contains(writer.Body.String(), "in progress"))
+
+	// The profile is collected when the server stops.
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for s.profiler.InProgress(debug.ProfileHeap) {
+		require.Equal(t, true, time.Now().Before(deadline), "profile was not collected in time")
+		time.Sleep(10 * time.Millisecond)
+	}
+	writer = adminRequest(t, s, http.MethodGet, "/v2/validator/admin/profiles/heap", nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "application/octet-stream", writer.Header().Get("Content-Type"))
+	assert.NotEqual(t, 0, writer.Body.Len())
+}
+
+func TestServer_RuntimeSettings(t *testing.T) {
+	s := &Server{jwtSecret: []byte("testKey")}
+	initial := debug.GCPercent()
+	defer func() {
+		_, err := debug.TuneGCPercent(initial)
+		require.NoError(t, err)
+	}()
+
+	writer := adminRequest(t, s, http.MethodGet, "/v2/validator/admin/runtime", nil)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &debug.RuntimeSettingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, strconv.Itoa(initial), resp.Data.GCPercent)
+
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/runtime", &debug.RuntimeSettings{GCPercent: "150"})
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "150", resp.Data.GCPercent)
+	assert.Equal(t, 150, debug.GCPercent())
+
+	// Nothing changes when one of the settings is invalid.
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/runtime", &debug.RuntimeSettings{GCPercent: "200", MemoryLimit: "1024"})
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, true, strings.Contains(writer.Body.String(), "lower than the minimum"))
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/runtime", &debug.RuntimeSettings{GCPercent: "-1"})
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = adminRequest(t, s, http.MethodPost, "/v2/validator/admin/runtime", &debug.RuntimeSettings{GCPercent: "a lot"})
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, 150, debug.GCPercent())
+}
@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v3/validator/client"
+)
+
+// SignExitsRequest selects the accounts whose voluntary exits are signed, by public key and by
+// validator index. The validator indices are a comma-separated list of indices or inclusive
+// ranges of indices, such as "10,12,20-30". The exits take effect at the epoch of the request,
+// which defaults to the current epoch.
+type SignExitsRequest struct {
+	PublicKeys       []string `json:"public_keys"`
+	ValidatorIndices string   `json:"validator_indices"`
+	Epoch            string   `json:"epoch"`
+}
+
+// SignedExitsResponse is the response of the endpoint signing voluntary exits.
+type SignedExitsResponse struct {
+	Data []*accounts.SignedVoluntaryExitJSON `json:"data"`
+}
+
+// BroadcastExitsRequest contains signed voluntary exits to broadcast, as returned by the endpoint
+// signing them or saved by the voluntary-exit command.
+type BroadcastExitsRequest struct {
+	Data []*accounts.SignedVoluntaryExitJSON `json:"data"`
+}
+
+// BroadcastExitsResponse is the response of the endpoint broadcasting signed voluntary exits.
+type BroadcastExitsResponse struct {
+	Data *BroadcastExits `json:"data"`
+}
+
+// BroadcastExits counts the signed voluntary exits which were broadcast, the others having been
+// rejected by the beacon node.
+type BroadcastExits struct {
+	Broadcast string `json:"broadcast"`
+	Total     string `json:"total"`
+}
+
+// RegisterExitHandlers registers the HTTP endpoints signing voluntary exits of several accounts at
+// once and broadcasting signed voluntary exits with the given router.
+func (s *Server) RegisterExitHandlers(router *mux.Router) {
+	router.HandleFunc("/v2/validator/accounts/exits/sign", s.SignExits).Methods(http.MethodPost)
+	router.HandleFunc("/v2/validator/accounts/exits/broadcast", s.BroadcastExits).Methods(http.MethodPost)
+}
+
+// SignExits signs voluntary exits of the selected accounts without broadcasting them, so that
+// exits can be pre-signed for a future epoch and broadcast later, by BroadcastExits or by any
+// beacon node. Anyone holding the signed exits can exit the validators.
+func (s *Server) SignExits(w http.ResponseWriter, r *http.Request) {
+	if !s.checkExitPreconditions(w, r) {
+		return
+	}
+	ctx := r.Context()
+	req := &SignExitsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeBadRequest(w, errors.Wrap(err, "could not decode request body"))
+		return
+	}
+	if len(req.PublicKeys) == 0 && req.ValidatorIndices == "" {
+		writeBadRequest(w, errors.New("no public keys nor validator indices specified"))
+		return
+	}
+	km, err := s.validatorService.Keymanager()
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get keymanager").Error(),
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+	validatingKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get validating public keys").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	known := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(validatingKeys))
+	for _, key := range validatingKeys {
+		known[key] = true
+	}
+
+	var selected [][fieldparams.BLSPubkeyLength]byte
+	for _, raw := range req.PublicKeys {
+		key, err := decodePublicKey(raw)
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		if !known[key] {
+			writeBadRequest(w, fmt.Errorf("public key %s doesn't belong to any account of the wallet", raw))
+			return
+		}
+		selected = append(selected, key)
+	}
+	if req.ValidatorIndices != "" {
+		indices, err := accounts.ParseValidatorIndices(req.ValidatorIndices)
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		keys, err := accounts.FilterKeysByValidatorIndices(ctx, s.beaconNodeValidatorClient, validatingKeys, indices)
+		if err != nil {
+			writeBadRequest(w, errors.Wrap(err, "could not select accounts by validator index"))
+			return
+		}
+		selected = append(selected, keys...)
+	}
+
+	var epoch types.Epoch
+	if req.Epoch != "" {
+		e, err := strconv.ParseUint(req.Epoch, 10, 64)
+		if err != nil {
+			writeBadRequest(w, errors.Wrapf(err, "invalid epoch %s", req.Epoch))
+			return
+		}
+		epoch = types.Epoch(e)
+	} else {
+		epoch, err = client.CurrentEpoch(ctx, s.beaconNodeClient)
+		if err != nil {
+			network.WriteError(w, &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "could not get current epoch").Error(),
+				Code:    http.StatusServiceUnavailable,
+			})
+			return
+		}
+	}
+
+	cfg := accounts.PerformExitCfg{
+		ValidatorClient: s.beaconNodeValidatorClient,
+		NodeClient:      s.beaconNodeClient,
+		Keymanager:      km,
+	}
+	seen := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(selected))
+	for _, key := range selected {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		k := key
+		cfg.RawPubKeys = append(cfg.RawPubKeys, k[:])
+		cfg.FormattedPubKeys = append(cfg.FormattedPubKeys, fmt.Sprintf("%#x", key))
+	}
+	exits, err := accounts.SignVoluntaryExits(ctx, cfg, epoch)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not sign voluntary exits").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	network.WriteJson(w, &SignedExitsResponse{Data: accounts.SignedVoluntaryExitsToJSON(exits)})
+}
+
+// BroadcastExits submits signed voluntary exits to the beacon node for broadcasting. Exits rejected
+// by the beacon node are skipped.
+func (s *Server) BroadcastExits(w http.ResponseWriter, r *http.Request) {
+	if !s.checkExitPreconditions(w, r) {
+		return
+	}
+	req := &BroadcastExitsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeBadRequest(w, errors.Wrap(err, "could not decode request body"))
+		return
+	}
+	if len(req.Data) == 0 {
+		writeBadRequest(w, errors.New("no signed voluntary exits specified"))
+		return
+	}
+	exits, err := accounts.SignedVoluntaryExitsFromJSON(req.Data)
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	broadcast, err := accounts.BroadcastSignedVoluntaryExits(r.Context(), s.beaconNodeValidatorClient, exits)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not broadcast voluntary exits").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	network.WriteJson(w, &BroadcastExitsResponse{Data: &BroadcastExits{
+		Broadcast: strconv.Itoa(broadcast),
+		Total:     strconv.Itoa(len(exits)),
+	}})
+}
+
+func (s *Server) checkExitPreconditions(w http.ResponseWriter, r *http.Request) bool {
+	if err := s.authorizeRequest(r); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: err.Error(),
+			Code:    http.StatusUnauthorized,
+		})
+		return false
+	}
+	if s.validatorService == nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "validator service not yet initialized",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/v3/testing/mock"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts/iface"
+	mock "github.com/prysmaticlabs/prysm/v3/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v3/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v3/validator/client"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v3/validator/keymanager/derived"
+	constant "github.com/prysmaticlabs/prysm/v3/validator/testing"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func exitRequest(t *testing.T, s *Server, url string, body interface{}) *httptest.ResponseRecorder {
+	enc, err := json.Marshal(body)
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com"+url, bytes.NewReader(enc))
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+token)
+	writer := httptest.NewRecorder()
+	router := mux.NewRouter()
+	s.RegisterExitHandlers(router)
+	router.ServeHTTP(writer, request)
+	return writer
+}
+
+func TestServer_SignExits_BroadcastExits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockValidatorClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	mockNodeClient := mock2.NewMockNodeClient(ctrl)
+
+	localWalletDir := setupWalletDir(t)
+	defaultWalletPath = localWalletDir
+	w, err := accounts.CreateWalletWithKeymanager(ctx, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      defaultWalletPath,
+			KeymanagerKind: keymanager.Derived,
+			WalletPassword: strongPass,
+		},
+		SkipMnemonicConfirm: true,
+	})
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	dr, ok := km.(*derived.Keymanager)
+	require.Equal(t, true, ok)
+	require.NoError(t, dr.RecoverAccountsFromMnemonic(ctx, constant.TestMnemonic, "", 2))
+	pubKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Wallet:    w,
+		Validator: &mock.MockValidator{Km: km},
+	})
+	require.NoError(t, err)
+	s := &Server{
+		jwtSecret:                 []byte("testKey"),
+		wallet:                    w,
+		beaconNodeClient:          mockNodeClient,
+		beaconNodeValidatorClient: mockValidatorClient,
+		validatorService:          vs,
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/v2/validator/accounts/exits/sign", nil)
+	writer := httptest.NewRecorder()
+	s.SignExits(writer, request)
+	assert.Equal(t, http.StatusUnauthorized, writer.Code)
+
+	writer = exitRequest(t, s, "/v2/validator/accounts/exits/sign", &SignExitsRequest{})
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	writer = exitRequest(t, s, "/v2/validator/accounts/exits/sign", &SignExitsRequest{
+		PublicKeys: []string{hexutil.Encode(make([]byte, fieldparams.BLSPubkeyLength))},
+	})
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+
+	// Exits are pre-signed for a future epoch by public key.
+	mockValidatorClient.EXPECT().ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKeys[0][:]}).
+		Return(&ethpb.ValidatorIndexResponse{Index: 5}, nil)
+	mockValidatorClient.EXPECT().DomainData(gomock.Any(), gomock.Any()).Times(2).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+	writer = exitRequest(t, s, "/v2/validator/accounts/exits/sign", &SignExitsRequest{
+		PublicKeys: []string{hexutil.Encode(pubKeys[0][:])},
+		Epoch:      "100",
+	})
+	require.Equal(t, http.StatusOK, writer.Code)
+	signed := &SignedExitsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), signed))
+	require.Equal(t, 1, len(signed.Data))
+	assert.DeepEqual(t, &accounts.VoluntaryExitJSON{Epoch: "100", ValidatorIndex: "5"}, signed.Data[0].Message)
+
+	// Exits are signed for the current epoch by validator index.
+	mockValidatorClient.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).
+		Return(&ethpb.MultipleValidatorStatusResponse{
+			PublicKeys: [][]byte{pubKeys[0][:], pubKeys[1][:]},
+			Indices:    []types.ValidatorIndex{5, 7},
+			Statuses: []*ethpb.ValidatorStatusResponse{
+				{Status: ethpb.ValidatorStatus_ACTIVE},
+				{Status: ethpb.ValidatorStatus_ACTIVE},
+			},
+		}, nil)
+	mockValidatorClient.EXPECT().ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKeys[1][:]}).
+		Return(&ethpb.ValidatorIndexResponse{Index: 7}, nil)
+	mockNodeClient.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).
+		Return(&ethpb.Genesis{GenesisTime: timestamppb.New(time.Now())}, nil)
+	writer = exitRequest(t, s, "/v2/validator/accounts/exits/sign", &SignExitsRequest{ValidatorIndices: "7"})
+	require.Equal(t, http.StatusOK, writer.Code)
+	current := &SignedExitsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), current))
+	require.Equal(t, 1, len(current.Data))
+	assert.DeepEqual(t, &accounts.VoluntaryExitJSON{Epoch: "0", ValidatorIndex: "7"}, current.Data[0].Message)
+
+	// The pre-signed exits are broadcast later.
+	mockValidatorClient.EXPECT().ProposeExit(gomock.Any(), gomock.AssignableToTypeOf(&ethpb.SignedVoluntaryExit{})).
+		Return(&ethpb.ProposeExitResponse{}, nil)
+	writer = exitRequest(t, s, "/v2/validator/accounts/exits/broadcast", &BroadcastExitsRequest{Data: signed.Data})
+	require.Equal(t, http.StatusOK, writer.Code)
+	broadcast := &BroadcastExitsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), broadcast))
+	assert.DeepEqual(t, &BroadcastExits{Broadcast: "1", Total: "1"}, broadcast.Data)
+
+	signed.Data[0].Signature = "0x01"
+	writer = exitRequest(t, s, "/v2/validator/accounts/exits/broadcast", &BroadcastExitsRequest{Data: signed.Data})
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
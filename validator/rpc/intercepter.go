@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -44,10 +45,26 @@ func (s *Server) authorize(ctx context.Context) error {
 	if !ok {
 		return status.Errorf(codes.Unauthenticated, "Authorization token could not be found")
 	}
-	if len(authHeader) < 1 || !strings.Contains(authHeader[0], "Bearer ") {
+	if len(authHeader) < 1 {
 		return status.Error(codes.Unauthenticated, "Invalid auth header, needs Bearer {token}")
 	}
-	token := strings.Split(authHeader[0], "Bearer ")[1]
+	return s.authorizeHeader(authHeader[0])
+}
+
+// Authorize the HTTP request carries a valid token in its authorization header.
+func (s *Server) authorizeRequest(r *http.Request) error {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return status.Errorf(codes.Unauthenticated, "Authorization token could not be found")
+	}
+	return s.authorizeHeader(authHeader)
+}
+
+func (s *Server) authorizeHeader(authHeader string) error {
+	if !strings.Contains(authHeader, "Bearer ") {
+		return status.Error(codes.Unauthenticated, "Invalid auth header, needs Bearer {token}")
+	}
+	token := strings.Split(authHeader, "Bearer ")[1]
 	_, err := jwt.Parse(token, s.validateJWT)
 	if err != nil {
 		return status.Errorf(codes.Unauthenticated, "Could not parse JWT token: %v", err)
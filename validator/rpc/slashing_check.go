@@ -0,0 +1,346 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	fssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v3/config/params"
+	types "github.com/prysmaticlabs/prysm/v3/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v3/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v3/network"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/time/slots"
+	"github.com/prysmaticlabs/prysm/v3/validator/client"
+)
+
+var errInvalidSigningRoot = errors.New("signing root is not a valid root")
+
+// CheckAttestationRequest asks whether signing an attestation would be allowed by slashing protection.
+// The signing root is optional, it is computed with the domain provided by the beacon node when omitted.
+type CheckAttestationRequest struct {
+	PublicKey   string               `json:"public_key"`
+	SigningRoot string               `json:"signing_root"`
+	Data        *AttestationDataJson `json:"data"`
+}
+
+// AttestationDataJson is the JSON representation of attestation data.
+type AttestationDataJson struct {
+	Slot            string          `json:"slot"`
+	CommitteeIndex  string          `json:"index"`
+	BeaconBlockRoot string          `json:"beacon_block_root"`
+	Source          *CheckpointJson `json:"source"`
+	Target          *CheckpointJson `json:"target"`
+}
+
+// CheckpointJson is the JSON representation of a checkpoint.
+type CheckpointJson struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// CheckBlockRequest asks whether signing a block would be allowed by slashing protection.
+// The signing root is optional, it is computed with the domain provided by the beacon node when omitted.
+type CheckBlockRequest struct {
+	PublicKey   string                 `json:"public_key"`
+	SigningRoot string                 `json:"signing_root"`
+	Header      *BeaconBlockHeaderJson `json:"header"`
+}
+
+// BeaconBlockHeaderJson is the JSON representation of a beacon block header.
+type BeaconBlockHeaderJson struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+// SlashingProtectionCheckResponse is the response of the slashing protection check endpoints.
+type SlashingProtectionCheckResponse struct {
+	Data *SlashingProtectionCheck `json:"data"`
+}
+
+// SlashingProtectionCheck tells whether signing an object would be allowed by the local
+// slashing protection database and, when it would not, why.
+type SlashingProtectionCheck struct {
+	Allowed     bool   `json:"allowed"`
+	Reason      string `json:"reason,omitempty"`
+	SigningRoot string `json:"signing_root"`
+}
+
+// RegisterSlashingProtectionCheckHandlers registers the HTTP endpoints checking objects against
+// slashing protection with the given router.
+func (s *Server) RegisterSlashingProtectionCheckHandlers(router *mux.Router) {
+	router.HandleFunc("/v2/validator/slashing-protection/check/attestation", s.CheckAttestation).Methods(http.MethodPost)
+	router.HandleFunc("/v2/validator/slashing-protection/check/block", s.CheckBlock).Methods(http.MethodPost)
+}
+
+// CheckAttestation answers whether signing the attestation data of the request with the given key
+// would be allowed by the local slashing protection database. Nothing is signed nor saved, which
+// makes it suitable for pre-flight checks.
+func (s *Server) CheckAttestation(w http.ResponseWriter, r *http.Request) {
+	if !s.checkPreconditions(w, r) {
+		return
+	}
+	req := &CheckAttestationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeBadRequest(w, errors.Wrap(err, "could not decode request body"))
+		return
+	}
+	pubKey, err := decodePublicKey(req.PublicKey)
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	data, err := attestationDataFromJson(req.Data)
+	if err != nil {
+		writeBadRequest(w, errors.Wrap(err, "invalid attestation data"))
+		return
+	}
+	signingRoot, err := s.signingRoot(r.Context(), req.SigningRoot, data, data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester)
+	if err != nil {
+		writeSigningRootError(w, err)
+		return
+	}
+	reason, err := slashingProtectionReason(
+		client.CheckSlashableAttestation(r.Context(), s.valDB, pubKey, signingRoot, &ethpb.IndexedAttestation{Data: data}),
+	)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not check attestation").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	network.WriteJson(w, &SlashingProtectionCheckResponse{Data: &SlashingProtectionCheck{
+		Allowed:     reason == "",
+		Reason:      reason,
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+	}})
+}
+
+// CheckBlock answers whether signing a block with the header of the request with the given key
+// would be allowed by the local slashing protection database. Nothing is signed nor saved, which
+// makes it suitable for pre-flight checks.
+func (s *Server) CheckBlock(w http.ResponseWriter, r *http.Request) {
+	if !s.checkPreconditions(w, r) {
+		return
+	}
+	req := &CheckBlockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeBadRequest(w, errors.Wrap(err, "could not decode request body"))
+		return
+	}
+	pubKey, err := decodePublicKey(req.PublicKey)
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	header, err := blockHeaderFromJson(req.Header)
+	if err != nil {
+		writeBadRequest(w, errors.Wrap(err, "invalid block header"))
+		return
+	}
+	signingRoot, err := s.signingRoot(r.Context(), req.SigningRoot, header, slots.ToEpoch(header.Slot), params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		writeSigningRootError(w, err)
+		return
+	}
+	reason, err := slashingProtectionReason(
+		client.CheckSlashableProposal(r.Context(), s.valDB, pubKey, header.Slot, signingRoot),
+	)
+	if err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not check block").Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	network.WriteJson(w, &SlashingProtectionCheckResponse{Data: &SlashingProtectionCheck{
+		Allowed:     reason == "",
+		Reason:      reason,
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+	}})
+}
+
+// Returns the reason why local slashing protection would refuse to sign, or an empty reason when
+// signing is allowed, from the result of the validator client's slashing protection check.
+func slashingProtectionReason(err error) (string, error) {
+	if err == nil {
+		return "", nil
+	}
+	var protectionErr *client.SlashingProtectionError
+	if errors.As(err, &protectionErr) {
+		return protectionErr.Error(), nil
+	}
+	return "", err
+}
+
+// Returns the signing root given in the request or, if none was given, computes it from the object
+// and the domain of the given epoch provided by the beacon node.
+func (s *Server) signingRoot(
+	ctx context.Context, rawRoot string, obj fssz.HashRoot, epoch types.Epoch, domainType [4]byte,
+) ([32]byte, error) {
+	if rawRoot != "" {
+		root, err := hexutil.Decode(rawRoot)
+		if err != nil || len(root) != fieldparams.RootLength {
+			return [32]byte{}, errInvalidSigningRoot
+		}
+		return bytesutil.ToBytes32(root), nil
+	}
+	if s.beaconNodeValidatorClient == nil {
+		return [32]byte{}, errors.New("no beacon node connection to get the signing domain from")
+	}
+	domain, err := s.beaconNodeValidatorClient.DomainData(ctx, &ethpb.DomainRequest{Epoch: epoch, Domain: domainType[:]})
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not get signing domain")
+	}
+	return signing.ComputeSigningRoot(obj, domain.SignatureDomain)
+}
+
+func (s *Server) checkPreconditions(w http.ResponseWriter, r *http.Request) bool {
+	if err := s.authorizeRequest(r); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: err.Error(),
+			Code:    http.StatusUnauthorized,
+		})
+		return false
+	}
+	if s.valDB == nil {
+		network.WriteError(w, &network.DefaultErrorJson{
+			Message: "err finding validator database at path",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return false
+	}
+	return true
+}
+
+func writeBadRequest(w http.ResponseWriter, err error) {
+	network.WriteError(w, &network.DefaultErrorJson{
+		Message: err.Error(),
+		Code:    http.StatusBadRequest,
+	})
+}
+
+func writeSigningRootError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errInvalidSigningRoot) {
+		writeBadRequest(w, err)
+		return
+	}
+	network.WriteError(w, &network.DefaultErrorJson{
+		Message: errors.Wrap(err, "could not compute signing root").Error(),
+		Code:    http.StatusServiceUnavailable,
+	})
+}
+
+func decodePublicKey(raw string) ([fieldparams.BLSPubkeyLength]byte, error) {
+	pubKey, err := hexutil.Decode(raw)
+	if err != nil || len(pubKey) != fieldparams.BLSPubkeyLength {
+		return [fieldparams.BLSPubkeyLength]byte{}, fmt.Errorf("public key %s is not a valid BLS public key", raw)
+	}
+	return bytesutil.ToBytes48(pubKey), nil
+}
+
+func attestationDataFromJson(data *AttestationDataJson) (*ethpb.AttestationData, error) {
+	if data == nil || data.Source == nil || data.Target == nil {
+		return nil, errors.New("missing attestation data")
+	}
+	slot, err := parseUint("slot", data.Slot)
+	if err != nil {
+		return nil, err
+	}
+	committeeIndex, err := parseUint("index", data.CommitteeIndex)
+	if err != nil {
+		return nil, err
+	}
+	blockRoot, err := parseRoot("beacon_block_root", data.BeaconBlockRoot)
+	if err != nil {
+		return nil, err
+	}
+	source, err := checkpointFromJson("source", data.Source)
+	if err != nil {
+		return nil, err
+	}
+	target, err := checkpointFromJson("target", data.Target)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.AttestationData{
+		Slot:            types.Slot(slot),
+		CommitteeIndex:  types.CommitteeIndex(committeeIndex),
+		BeaconBlockRoot: blockRoot,
+		Source:          source,
+		Target:          target,
+	}, nil
+}
+
+func checkpointFromJson(name string, cp *CheckpointJson) (*ethpb.Checkpoint, error) {
+	epoch, err := parseUint(name+".epoch", cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseRoot(name+".root", cp.Root)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.Checkpoint{Epoch: types.Epoch(epoch), Root: root}, nil
+}
+
+func blockHeaderFromJson(header *BeaconBlockHeaderJson) (*ethpb.BeaconBlockHeader, error) {
+	if header == nil {
+		return nil, errors.New("missing block header")
+	}
+	slot, err := parseUint("slot", header.Slot)
+	if err != nil {
+		return nil, err
+	}
+	proposerIndex, err := parseUint("proposer_index", header.ProposerIndex)
+	if err != nil {
+		return nil, err
+	}
+	parentRoot, err := parseRoot("parent_root", header.ParentRoot)
+	if err != nil {
+		return nil, err
+	}
+	stateRoot, err := parseRoot("state_root", header.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+	bodyRoot, err := parseRoot("body_root", header.BodyRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.BeaconBlockHeader{
+		Slot:          types.Slot(slot),
+		ProposerIndex: types.ValidatorIndex(proposerIndex),
+		ParentRoot:    parentRoot,
+		StateRoot:     stateRoot,
+		BodyRoot:      bodyRoot,
+	}, nil
+}
+
+func parseUint(name, raw string) (uint64, error) {
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse %s", name)
+	}
+	return v, nil
+}
+
+func parseRoot(name, raw string) ([]byte, error) {
+	root, err := hexutil.Decode(raw)
+	if err != nil || len(root) != fieldparams.RootLength {
+		return nil, fmt.Errorf("%s %s is not a valid root", name, raw)
+	}
+	return root, nil
+}
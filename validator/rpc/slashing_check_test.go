@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v3/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v3/testing/assert"
+	"github.com/prysmaticlabs/prysm/v3/testing/require"
+	dbtest "github.com/prysmaticlabs/prysm/v3/validator/db/testing"
+)
+
+func slashingCheckRequest(t *testing.T, s *Server, handler http.HandlerFunc, body interface{}) (*httptest.ResponseRecorder, *SlashingProtectionCheck) {
+	enc, err := json.Marshal(body)
+	require.NoError(t, err)
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/v2/validator/slashing-protection/check", bytes.NewReader(enc))
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer "+token)
+	writer := httptest.NewRecorder()
+	handler(writer, request)
+	if writer.Code != http.StatusOK {
+		return writer, nil
+	}
+	resp := &SlashingProtectionCheckResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	return writer, resp.Data
+}
+
+func attestationCheckRequest(pubKey [fieldparams.BLSPubkeyLength]byte, signingRoot [32]byte, source, target string) *CheckAttestationRequest {
+	root := hexutil.Encode(make([]byte, 32))
+	return &CheckAttestationRequest{
+		PublicKey:   hexutil.Encode(pubKey[:]),
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+		Data: &AttestationDataJson{
+			Slot:            "64",
+			CommitteeIndex:  "0",
+			BeaconBlockRoot: root,
+			Source:          &CheckpointJson{Epoch: source, Root: root},
+			Target:          &CheckpointJson{Epoch: target, Root: root},
+		},
+	}
+}
+
+func TestServer_CheckAttestation(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	s := &Server{jwtSecret: []byte("testKey"), valDB: validatorDB}
+
+	signingRoot := [32]byte{'a'}
+	require.NoError(t, validatorDB.SaveAttestationForPubKey(ctx, pubKey, signingRoot, &ethpb.IndexedAttestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 1},
+			Target: &ethpb.Checkpoint{Epoch: 2},
+		},
+	}))
+
+	t.Run("allowed", func(t *testing.T) {
+		newSigningRoot := [32]byte{'b'}
+		writer, check := slashingCheckRequest(t, s, s.CheckAttestation, attestationCheckRequest(pubKey, newSigningRoot, "2", "3"))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, true, check.Allowed)
+		assert.Equal(t, hexutil.Encode(newSigningRoot[:]), check.SigningRoot)
+	})
+	t.Run("same attestation", func(t *testing.T) {
+		writer, check := slashingCheckRequest(t, s, s.CheckAttestation, attestationCheckRequest(pubKey, signingRoot, "1", "2"))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, true, check.Allowed)
+	})
+	t.Run("double vote", func(t *testing.T) {
+		writer, check := slashingCheckRequest(t, s, s.CheckAttestation, attestationCheckRequest(pubKey, [32]byte{'b'}, "1", "2"))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, false, check.Allowed)
+		assert.NotEqual(t, "", check.Reason)
+	})
+	t.Run("source lower than lowest signed source", func(t *testing.T) {
+		writer, check := slashingCheckRequest(t, s, s.CheckAttestation, attestationCheckRequest(pubKey, [32]byte{'b'}, "0", "3"))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, false, check.Allowed)
+	})
+	t.Run("invalid public key", func(t *testing.T) {
+		req := attestationCheckRequest(pubKey, signingRoot, "1", "2")
+		req.PublicKey = "0x01"
+		writer, _ := slashingCheckRequest(t, s, s.CheckAttestation, req)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/v2/validator/slashing-protection/check/attestation", nil)
+		writer := httptest.NewRecorder()
+		s.CheckAttestation(writer, request)
+		require.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+}
+
+func TestServer_CheckBlock(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	s := &Server{jwtSecret: []byte("testKey"), valDB: validatorDB}
+
+	signingRoot := [32]byte{'a'}
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, pubKey, 10, signingRoot[:]))
+
+	root := hexutil.Encode(make([]byte, 32))
+	blockRequest := func(slot string, signingRoot [32]byte) *CheckBlockRequest {
+		return &CheckBlockRequest{
+			PublicKey:   hexutil.Encode(pubKey[:]),
+			SigningRoot: hexutil.Encode(signingRoot[:]),
+			Header: &BeaconBlockHeaderJson{
+				Slot:          slot,
+				ProposerIndex: "1",
+				ParentRoot:    root,
+				StateRoot:     root,
+				BodyRoot:      root,
+			},
+		}
+	}
+
+	writer, check := slashingCheckRequest(t, s, s.CheckBlock, blockRequest("11", [32]byte{'b'}))
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, check.Allowed)
+
+	writer, check = slashingCheckRequest(t, s, s.CheckBlock, blockRequest("10", signingRoot))
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, check.Allowed)
+
+	writer, check = slashingCheckRequest(t, s, s.CheckBlock, blockRequest("10", [32]byte{'b'}))
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, false, check.Allowed)
+	assert.Equal(t, "attempted to sign a double proposal, block rejected by local protection", check.Reason)
+
+	writer, check = slashingCheckRequest(t, s, s.CheckBlock, blockRequest("9", [32]byte{'b'}))
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, false, check.Allowed)
+
+	// Nothing was saved by the checks.
+	_, exists, err := validatorDB.ProposalHistoryForSlot(ctx, pubKey, 11)
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+
+	writer, _ = slashingCheckRequest(t, s, s.CheckBlock, blockRequest("abc", signingRoot))
+	require.Equal(t, http.StatusBadRequest, writer.Code)
+}